@@ -0,0 +1,266 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+// Package ci connects a plonk repository to GitHub Actions: it writes a
+// workflow that checks the repository on every push, and turns the JSON
+// results of plonk commands into GitHub problem annotations.
+package ci
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/richhaase/plonk/internal/output"
+)
+
+// WorkflowPath is where the workflow goes in the plonk directory
+const WorkflowPath = ".github/workflows/plonk.yml"
+
+// DefaultRunners are the runners the workflow checks on, one per platform
+// plonk supports
+var DefaultRunners = []string{"ubuntu-latest", "macos-latest"}
+
+// Workflow returns a GitHub Actions workflow that installs plonk on each
+// runner and checks the repository with plonk simulate and plonk apply
+// --check, annotating the problems they find. apply --check exits 2 on a
+// fresh runner, where everything is still to install, so only errors fail
+// the job.
+func Workflow(runners []string) string {
+	var b strings.Builder
+	b.WriteString(`# Checks this plonk repository on every push and pull request.
+# Written by 'plonk ci setup'.
+name: plonk
+
+on:
+  push:
+  pull_request:
+
+jobs:
+  check:
+    strategy:
+      fail-fast: false
+      matrix:
+        os: [`)
+	b.WriteString(strings.Join(runners, ", "))
+	b.WriteString(`]
+    runs-on: ${{ matrix.os }}
+    env:
+      PLONK_DIR: ${{ github.workspace }}
+    steps:
+      - uses: actions/checkout@v4
+      - uses: actions/setup-go@v5
+        with:
+          go-version: stable
+      - name: Install plonk
+        run: go install github.com/richhaase/plonk/cmd/plonk@latest
+      - name: Simulate a fresh machine
+        run: |
+          status=0
+          plonk simulate . -o json > "$RUNNER_TEMP/simulate.json" || status=$?
+          plonk ci annotate "$RUNNER_TEMP/simulate.json"
+          exit $status
+      - name: Check apply
+        run: |
+          status=0
+          plonk apply --check -o json > "$RUNNER_TEMP/apply.json" || status=$?
+          plonk ci annotate "$RUNNER_TEMP/apply.json"
+          # 2 means there are changes to make, as on any fresh runner
+          if [ "$status" -eq 2 ]; then status=0; fi
+          exit $status
+`)
+	return b.String()
+}
+
+// Annotation is one GitHub problem annotation
+type Annotation struct {
+	Level   string // "error", "warning" or "notice"
+	File    string // relative to the repository root; "" for none
+	Line    int    // 0 for none
+	Title   string
+	Message string
+}
+
+// String formats the annotation as a GitHub workflow command
+func (a Annotation) String() string {
+	var props []string
+	if a.File != "" {
+		props = append(props, "file="+escapeProperty(a.File))
+		if a.Line > 0 {
+			props = append(props, fmt.Sprintf("line=%d", a.Line))
+		}
+	}
+	if a.Title != "" {
+		props = append(props, "title="+escapeProperty(a.Title))
+	}
+	command := "::" + a.Level
+	if len(props) > 0 {
+		command += " " + strings.Join(props, ",")
+	}
+	return command + "::" + escapeData(a.Message)
+}
+
+// escapeData escapes a workflow command's message
+func escapeData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// escapeProperty escapes a workflow command's property value
+func escapeProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
+
+// Annotate returns annotations for the JSON output of plonk apply or plonk
+// simulate. plonkDir is the repository root, and lockData the plonk.lock
+// in it, used to point package problems at their line.
+func Annotate(data []byte, plonkDir string, lockData []byte) ([]Annotation, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("not JSON output from plonk: %w", err)
+	}
+	_, hasScope := fields["scope"]
+	_, hasDotfiles := fields["dotfiles"]
+	_, hasSource := fields["source"]
+	switch {
+	case hasScope:
+		var result output.ApplyResult
+		if err := json.Unmarshal(data, &result); err != nil {
+			return nil, err
+		}
+		return annotateApply(result, plonkDir, lockData), nil
+	case hasSource && hasDotfiles:
+		var result output.SimulateOutput
+		if err := json.Unmarshal(data, &result); err != nil {
+			return nil, err
+		}
+		return annotateSimulate(result, lockData), nil
+	}
+	return nil, fmt.Errorf("expected the JSON output of plonk apply or plonk simulate")
+}
+
+// annotateApply reports failed packages, dotfiles and resources
+func annotateApply(result output.ApplyResult, plonkDir string, lockData []byte) []Annotation {
+	var annotations []Annotation
+	if result.Packages != nil {
+		for _, m := range result.Packages.Managers {
+			for _, pkg := range m.Packages {
+				if pkg.Status != "failed" {
+					continue
+				}
+				manager := pkg.Manager
+				if manager == "" {
+					manager = m.Name
+				}
+				annotations = append(annotations, Annotation{
+					Level:   "error",
+					File:    "plonk.lock",
+					Line:    lockLine(lockData, manager, pkg.Name),
+					Title:   manager + ":" + pkg.Name,
+					Message: pkg.Error,
+				})
+			}
+		}
+	}
+	if result.Dotfiles != nil {
+		for _, d := range result.Dotfiles.Actions {
+			if d.Status != "failed" {
+				continue
+			}
+			annotations = append(annotations, Annotation{
+				Level:   "error",
+				File:    repoPath(d.Source, plonkDir),
+				Title:   "dotfile " + d.Destination,
+				Message: d.Error,
+			})
+		}
+	}
+	if result.Resources != nil {
+		for _, r := range result.Resources.Resources {
+			if r.Error != "" {
+				annotations = append(annotations, Annotation{Level: "error", Title: "resource " + r.Name, Message: r.Error})
+			}
+			for _, item := range r.Items {
+				if item.Status == "failed" {
+					annotations = append(annotations, Annotation{Level: "error", Title: r.Name + ": " + item.Name, Message: item.Error})
+				}
+			}
+		}
+	}
+	if len(annotations) == 0 && result.Error != "" {
+		annotations = append(annotations, Annotation{Level: "error", Title: "plonk apply", Message: result.Error})
+	}
+	return annotations
+}
+
+// annotateSimulate reports templates that don't render, a lock signature
+// that doesn't verify and managers that need more than plonk
+func annotateSimulate(result output.SimulateOutput, lockData []byte) []Annotation {
+	var annotations []Annotation
+	if result.Signature != "" && result.Signature != "verified" {
+		annotations = append(annotations, Annotation{
+			Level:   "error",
+			File:    "plonk.lock",
+			Title:   "lock signature",
+			Message: result.Signature,
+		})
+	}
+	for _, d := range result.Dotfiles {
+		if d.Error != "" {
+			annotations = append(annotations, Annotation{
+				Level:   "error",
+				File:    filepath.ToSlash(d.Source),
+				Title:   "dotfile ~/" + d.Target,
+				Message: d.Error,
+			})
+		}
+	}
+	for _, m := range result.Managers {
+		if m.Note == "" || m.Skipped || len(m.Packages) == 0 {
+			continue
+		}
+		annotations = append(annotations, Annotation{
+			Level:   "notice",
+			File:    "plonk.lock",
+			Line:    lockLine(lockData, m.Manager, m.Packages[0]),
+			Title:   m.Manager,
+			Message: fmt.Sprintf("%s packages: %s", m.Manager, m.Note),
+		})
+	}
+	return annotations
+}
+
+// repoPath returns path relative to the repository root, or "" when it
+// lies outside it
+func repoPath(path, plonkDir string) string {
+	if !filepath.IsAbs(path) {
+		return filepath.ToSlash(path)
+	}
+	rel, err := filepath.Rel(plonkDir, path)
+	if err != nil || !filepath.IsLocal(rel) {
+		return ""
+	}
+	return filepath.ToSlash(rel)
+}
+
+// lockLine returns the line of plonk.lock that lists manager's package
+// name, or 0
+func lockLine(lockData []byte, manager, name string) int {
+	scanner := bufio.NewScanner(bytes.NewReader(lockData))
+	current := ""
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		trimmed := strings.TrimSpace(text)
+		if key, ok := strings.CutSuffix(trimmed, ":"); ok && strings.HasPrefix(text, "  ") && !strings.HasPrefix(text, "   ") {
+			current = key
+			continue
+		}
+		item, ok := strings.CutPrefix(trimmed, "- ")
+		if ok && current == manager && strings.Trim(item, `"'`) == name {
+			return line
+		}
+	}
+	return 0
+}
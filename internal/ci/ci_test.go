@@ -0,0 +1,92 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package ci
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+const testLock = `version: 3
+packages:
+  brew:
+    - ripgrep
+  cargo:
+    - bat
+    - ripgrep
+`
+
+func TestAnnotate_Apply(t *testing.T) {
+	data := []byte(`{
+  "dry_run": true, "success": false, "changed": true, "scope": "all",
+  "packages": {"managers": [{"name": "cargo", "packages": [
+    {"name": "bat", "status": "would-install"},
+    {"name": "ripgrep", "manager": "cargo", "status": "failed", "error": "cargo: not found"}
+  ]}]},
+  "dotfiles": {"actions": [
+    {"source": "/work/zshrc", "destination": "/home/runner/.zshrc", "action": "failed", "status": "failed", "error": "template: missing EMAIL"},
+    {"source": "/work/vimrc", "destination": "/home/runner/.vimrc", "action": "added", "status": "success"}
+  ]}
+}`)
+	annotations, err := Annotate(data, "/work", []byte(testLock))
+	require.NoError(t, err)
+	assert.Equal(t, []Annotation{
+		{Level: "error", File: "plonk.lock", Line: 7, Title: "cargo:ripgrep", Message: "cargo: not found"},
+		{Level: "error", File: "zshrc", Title: "dotfile /home/runner/.zshrc", Message: "template: missing EMAIL"},
+	}, annotations)
+}
+
+func TestAnnotate_Simulate(t *testing.T) {
+	data := []byte(`{
+  "source": ".",
+  "signature": "no signature for plonk.lock",
+  "managers": [
+    {"manager": "cargo", "packages": ["bat"], "note": "after brew install rust"},
+    {"manager": "brew", "packages": ["ripgrep"]}
+  ],
+  "dotfiles": [{"source": "config/git/config.tmpl", "target": ".config/git/config", "error": "undefined variable EMAIL"}]
+}`)
+	annotations, err := Annotate(data, "/work", []byte(testLock))
+	require.NoError(t, err)
+	assert.Equal(t, []Annotation{
+		{Level: "error", File: "plonk.lock", Title: "lock signature", Message: "no signature for plonk.lock"},
+		{Level: "error", File: "config/git/config.tmpl", Title: "dotfile ~/.config/git/config", Message: "undefined variable EMAIL"},
+		{Level: "notice", File: "plonk.lock", Line: 6, Title: "cargo", Message: "cargo packages: after brew install rust"},
+	}, annotations)
+}
+
+func TestAnnotate_RejectsOtherOutput(t *testing.T) {
+	_, err := Annotate([]byte(`{"checks": []}`), "/work", nil)
+	assert.Error(t, err)
+	_, err = Annotate([]byte("Plonk Apply"), "/work", nil)
+	assert.Error(t, err)
+}
+
+func TestAnnotationString(t *testing.T) {
+	a := Annotation{Level: "error", File: "plonk.lock", Line: 3, Title: "brew:a,b", Message: "100% failed\nretry"}
+	assert.Equal(t, "::error file=plonk.lock,line=3,title=brew%3Aa%2Cb::100%25 failed%0Aretry", a.String())
+	assert.Equal(t, "::notice::hi", Annotation{Level: "notice", Message: "hi"}.String())
+}
+
+func TestWorkflow(t *testing.T) {
+	workflow := Workflow([]string{"ubuntu-24.04", "macos-15"})
+	var parsed struct {
+		Jobs map[string]struct {
+			Strategy struct {
+				Matrix struct {
+					OS []string `yaml:"os"`
+				} `yaml:"matrix"`
+			} `yaml:"strategy"`
+			Steps []map[string]any `yaml:"steps"`
+		} `yaml:"jobs"`
+	}
+	require.NoError(t, yaml.Unmarshal([]byte(workflow), &parsed))
+	job := parsed.Jobs["check"]
+	assert.Equal(t, []string{"ubuntu-24.04", "macos-15"}, job.Strategy.Matrix.OS)
+	assert.Contains(t, workflow, "plonk apply --check -o json")
+	assert.Contains(t, workflow, "plonk ci annotate")
+}
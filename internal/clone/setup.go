@@ -12,6 +12,7 @@ import (
 	"sort"
 
 	"github.com/richhaase/plonk/internal/config"
+	"github.com/richhaase/plonk/internal/dotfiles"
 	"github.com/richhaase/plonk/internal/lock"
 	"github.com/richhaase/plonk/internal/orchestrator"
 	"github.com/richhaase/plonk/internal/output"
@@ -21,6 +22,26 @@ import (
 // Config represents setup configuration options
 type Config struct {
 	DryRun bool // Whether to show what would happen without making changes
+
+	// Confirm asks the user a yes/no question during the post-clone
+	// checklist. Nil means non-interactive: every question takes its default.
+	Confirm func(question string, def bool) bool
+
+	// Ask asks a template prompt before dotfiles are deployed and returns
+	// the answer. Nil leaves prompts unanswered until 'plonk apply'.
+	Ask func(question, def string) string
+
+	// Resolve chooses what to do about a dotfile that already exists in
+	// the home directory with other content. Nil takes the repository's.
+	Resolve func(c dotfiles.Conflict) dotfiles.Resolution
+}
+
+// confirm asks question through cfg.Confirm, or returns def when non-interactive
+func (c Config) confirm(question string, def bool) bool {
+	if c.Confirm == nil {
+		return def
+	}
+	return c.Confirm(question, def)
 }
 
 // CloneAndSetup clones a repository and sets up plonk intelligently
@@ -48,7 +69,9 @@ func CloneAndSetup(ctx context.Context, gitRepo string, cfg Config) error {
 
 		output.Printf("Dry run: would create default plonk.yaml configuration\n")
 		output.Printf("Dry run: would detect required package managers from lock file\n")
-		output.Printf("Dry run: would run 'plonk apply' after setup\n")
+		output.Printf("Dry run: would offer to install missing managers with Homebrew (default: no)\n")
+		output.Printf("Dry run: would offer to install packages and deploy dotfiles (default: yes)\n")
+		output.Printf("Dry run: would verify packages, commands on PATH, dotfiles and doctor checks\n")
 		output.Printf("Dry run: no changes made\n")
 		return nil
 	}
@@ -84,15 +107,29 @@ func CloneAndSetup(ctx context.Context, gitRepo string, cfg Config) error {
 		output.Printf("Created default plonk.yaml configuration\n")
 	}
 
-	if err := SetupFromClonedRepo(ctx, plonkDir, hasConfig); err != nil {
+	if err := SetupFromClonedRepo(ctx, plonkDir, hasConfig, cfg); err != nil {
 		return err
 	}
-	output.Printf("Setup complete! Your dotfiles are now managed by plonk.\n")
+
+	output.StageUpdate("Verifying setup...")
+	homeDir, err := config.GetHomeDir()
+	if err != nil {
+		return fmt.Errorf("cannot determine home directory: %w", err)
+	}
+	report := Verify(ctx, plonkDir, homeDir, config.LoadWithDefaults(plonkDir))
+	output.RenderOutput(report)
+	if report.Ready {
+		output.Printf("Setup complete! Your dotfiles are now managed by plonk.\n")
+	} else {
+		output.Printf("Setup finished, but this machine is not ready yet.\n")
+	}
 	return nil
 }
 
-// SetupFromClonedRepo performs post-clone setup: detect managers, install, and apply
-func SetupFromClonedRepo(ctx context.Context, plonkDir string, hasConfig bool) error {
+// SetupFromClonedRepo performs post-clone setup as a checklist: detect
+// managers, offer to bootstrap missing ones, then offer to install packages
+// and deploy dotfiles. setupCfg.Confirm answers each step.
+func SetupFromClonedRepo(ctx context.Context, plonkDir string, hasConfig bool, setupCfg Config) error {
 	repoCfg := config.LoadWithDefaults(plonkDir)
 
 	// Detect required managers from lock file
@@ -118,6 +155,7 @@ func SetupFromClonedRepo(ctx context.Context, plonkDir string, hasConfig bool) e
 		if installErr != nil {
 			return fmt.Errorf("failed to evaluate required tools: %w", installErr)
 		}
+		missingManagers = bootstrapManagers(ctx, missingManagers, setupCfg)
 		if len(missingManagers) > 0 {
 			output.Printf("\nThe package managers listed above are missing. Install them manually and run 'plonk doctor' when ready.\n")
 		}
@@ -125,9 +163,15 @@ func SetupFromClonedRepo(ctx context.Context, plonkDir string, hasConfig bool) e
 		output.Printf("No package managers detected from lock file.\n")
 	}
 
+	applyPackages := hasConfig && setupCfg.confirm("Install tracked packages now?", true)
+	applyDotfiles := hasConfig && setupCfg.confirm("Deploy dotfiles into your home directory now?", true)
+	if hasConfig && !applyPackages && !applyDotfiles {
+		output.Printf("Skipping apply. Run 'plonk apply' when you are ready.\n")
+	}
+
 	// Run apply if config exists
-	if hasConfig {
-		if len(missingManagers) > 0 {
+	if applyPackages || applyDotfiles {
+		if applyPackages && len(missingManagers) > 0 {
 			output.Printf("Some package managers are missing; continuing with 'plonk apply' for everything else.\n")
 			output.Printf("After installing the missing managers, re-run 'plonk doctor' and 'plonk apply' to reconcile remaining packages.\n")
 		}
@@ -141,14 +185,39 @@ func SetupFromClonedRepo(ctx context.Context, plonkDir string, hasConfig bool) e
 		if cfg == nil {
 			cfg = config.LoadWithDefaults(plonkDir)
 		}
+		if applyDotfiles && setupCfg.Ask != nil {
+			err := dotfiles.AnswerPrompts(plonkDir, homeDir, func(p dotfiles.TemplatePrompt) (string, error) {
+				return setupCfg.Ask(p.Question, p.Default), nil
+			})
+			if err != nil {
+				output.Printf("Warning: could not save template answers: %v\n", err)
+			}
+		}
+		var leave map[string]bool
+		if applyDotfiles && setupCfg.Resolve != nil {
+			leave, err = dotfiles.ResolveConflicts(plonkDir, homeDir, cfg.IgnorePatterns, setupCfg.Resolve)
+			if err != nil {
+				return fmt.Errorf("failed to resolve dotfile conflicts: %w", err)
+			}
+		}
 		orch := orchestrator.New(
 			orchestrator.WithConfig(cfg),
 			orchestrator.WithConfigDir(plonkDir),
 			orchestrator.WithHomeDir(homeDir),
 			orchestrator.WithDryRun(false),
+			orchestrator.WithPackagesOnly(!applyDotfiles),
+			orchestrator.WithDotfilesOnly(!applyPackages),
+			orchestrator.WithDotfileExclusions(leave),
 		)
 		result, err := orch.Apply(ctx)
-		result.Scope = "all"
+		switch {
+		case !applyDotfiles:
+			result.Scope = "packages"
+		case !applyPackages:
+			result.Scope = "dotfiles"
+		default:
+			result.Scope = "all"
+		}
 		output.RenderOutput(result)
 		if err != nil {
 			hasDotfileErrors := len(result.DotfileErrors) > 0
@@ -227,8 +296,11 @@ func getManagerDescription(_ *config.Config, manager string) string {
 }
 
 // getManualInstallInstructions returns manual installation instructions
-func getManualInstallInstructions(_ *config.Config, _ string) string {
-	return "See official documentation for installation instructions"
+func getManualInstallInstructions(_ *config.Config, manager string) string {
+	if !packages.IsSupportedManager(manager) {
+		return packages.UnsupportedManagerError(manager).Error()
+	}
+	return packages.ManagerInstallHint(manager)
 }
 
 // DetectRequiredManagers reads a lock file and returns unique package managers
@@ -279,7 +351,7 @@ func installDetectedManagers(ctx context.Context, cfgData *config.Config, manage
 
 		binary := managerBinaries[mgr]
 		if binary == "" {
-			binary = mgr
+			binary = packages.PluginPrefix + mgr
 		}
 
 		_, err := exec.LookPath(binary)
@@ -302,6 +374,35 @@ func installDetectedManagers(ctx context.Context, cfgData *config.Config, manage
 	return missingManagers, nil
 }
 
+// bootstrapManagers offers to install missing managers with Homebrew and
+// returns the managers that are still missing
+func bootstrapManagers(ctx context.Context, missing []string, cfg Config) []string {
+	if len(missing) == 0 {
+		return nil
+	}
+	if _, err := exec.LookPath("brew"); err != nil {
+		return missing
+	}
+
+	var still []string
+	for _, mgr := range missing {
+		formula := packages.ManagerBrewFormula(mgr)
+		if formula == "" || !cfg.confirm(fmt.Sprintf("Install %s with 'brew install %s'?", mgr, formula), false) {
+			still = append(still, mgr)
+			continue
+		}
+		output.StageUpdate(fmt.Sprintf("Installing %s...", formula))
+		cmd := exec.CommandContext(ctx, "brew", "install", formula)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			output.Printf("Failed to install %s: %v\n%s\n", formula, err, out)
+			still = append(still, mgr)
+			continue
+		}
+		output.Printf("Installed %s\n", mgr)
+	}
+	return still
+}
+
 // missingManagersNow returns managers that are currently unavailable on PATH.
 func missingManagersNow(managers []string) []string {
 	managerBinaries := map[string]string{
@@ -320,7 +421,7 @@ func missingManagersNow(managers []string) []string {
 		}
 		binary := managerBinaries[mgr]
 		if binary == "" {
-			binary = mgr
+			binary = packages.PluginPrefix + mgr
 		}
 		if _, err := exec.LookPath(binary); err != nil {
 			missing = append(missing, mgr)
@@ -0,0 +1,48 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package clone
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigConfirm(t *testing.T) {
+	assert.True(t, Config{}.confirm("apply?", true))
+	assert.False(t, Config{}.confirm("bootstrap?", false))
+
+	var asked []string
+	cfg := Config{Confirm: func(q string, def bool) bool {
+		asked = append(asked, q)
+		return !def
+	}}
+	assert.False(t, cfg.confirm("apply?", true))
+	assert.Equal(t, []string{"apply?"}, asked)
+}
+
+func TestBootstrapManagers_WithoutBrew(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+	asked := false
+	cfg := Config{Confirm: func(string, bool) bool { asked = true; return true }}
+
+	still := bootstrapManagers(context.Background(), []string{"cargo", "uv"}, cfg)
+	assert.Equal(t, []string{"cargo", "uv"}, still)
+	assert.False(t, asked, "no questions when Homebrew is unavailable")
+}
+
+func TestSetupFromClonedRepo_SkipApply(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+	plonkDir := t.TempDir()
+
+	var asked []string
+	cfg := Config{Confirm: func(q string, def bool) bool {
+		asked = append(asked, q)
+		return false
+	}}
+	require.NoError(t, SetupFromClonedRepo(context.Background(), plonkDir, true, cfg))
+	assert.Equal(t, []string{"Install tracked packages now?", "Deploy dotfiles into your home directory now?"}, asked)
+}
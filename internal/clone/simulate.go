@@ -0,0 +1,123 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package clone
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/richhaase/plonk/internal/config"
+	"github.com/richhaase/plonk/internal/dotfiles"
+	"github.com/richhaase/plonk/internal/lock"
+	"github.com/richhaase/plonk/internal/output"
+	"github.com/richhaase/plonk/internal/packages"
+	"github.com/richhaase/plonk/internal/resources"
+	"github.com/richhaase/plonk/internal/signing"
+)
+
+// simulatedHome stands in for the home directory of the simulated machine;
+// targets are only named, never touched
+const simulatedHome = "~"
+
+// Simulate plans clone and apply of source for a machine with nothing
+// installed and an empty home directory, without installing, deploying or
+// running anything. source is a local checkout, such as a pull request
+// branch, or a repository that is cloned to a temporary directory.
+func Simulate(ctx context.Context, source string) (output.SimulateOutput, error) {
+	if info, err := os.Stat(source); err == nil && info.IsDir() {
+		return SimulateDir(ctx, source)
+	}
+
+	gitURL, err := parseGitURL(source)
+	if err != nil {
+		return output.SimulateOutput{}, fmt.Errorf("invalid git repository: %w", err)
+	}
+	tmp, err := os.MkdirTemp("", "plonk-simulate-*")
+	if err != nil {
+		return output.SimulateOutput{}, err
+	}
+	defer os.RemoveAll(tmp)
+
+	dir := filepath.Join(tmp, "repo")
+	if err := cloneRepository(gitURL, dir); err != nil {
+		return output.SimulateOutput{}, err
+	}
+	result, err := SimulateDir(ctx, dir)
+	result.Source = gitURL
+	return result, err
+}
+
+// SimulateDir plans clone and apply of the plonk directory dir for a fresh
+// machine. Every locked package would be installed; managers other than
+// brew would first be installed with brew, as clone does, and disabled
+// managers are skipped. Dotfiles are listed with their targets, and
+// templates are rendered so missing variables show up as problems.
+func SimulateDir(ctx context.Context, dir string) (output.SimulateOutput, error) {
+	result := output.SimulateOutput{Source: dir}
+
+	cfg, err := config.Load(dir)
+	if err != nil {
+		return result, fmt.Errorf("plonk.yaml: %w", err)
+	}
+	lockFile, err := lock.NewLockV3Service(dir).Read()
+	if err != nil {
+		return result, fmt.Errorf("plonk.lock: %w", err)
+	}
+
+	if signing.TrustFile() != "" {
+		result.Signature = "verified"
+		if err := signing.VerifyLock(ctx, dir); err != nil {
+			result.Signature = err.Error()
+		}
+	}
+
+	for _, manager := range sortedManagers(lockFile.Packages) {
+		m := output.SimulatedManager{Manager: manager, Packages: lockFile.Packages[manager]}
+		formula := packages.ManagerBrewFormula(manager)
+		switch {
+		case slices.Contains(cfg.ActiveDisabledManagers(), manager):
+			m.Skipped, m.Note = true, "disabled in plonk.yaml"
+		case !slices.Contains(packages.SupportedManagers, manager):
+			m.Note = fmt.Sprintf("needs the plonk-manager-%s plugin", manager)
+		case formula != "":
+			m.Note = fmt.Sprintf("after brew install %s", formula)
+		}
+		result.Managers = append(result.Managers, m)
+	}
+
+	dm := dotfiles.NewDotfileManager(dir, simulatedHome, cfg.IgnorePatterns)
+	files, err := dm.List()
+	if err != nil {
+		return result, fmt.Errorf("dotfiles: %w", err)
+	}
+	for _, d := range files {
+		sim := output.SimulatedDotfile{Source: d.Name, Target: d.Target}
+		if _, err := dm.RenderSource(d.Name); err != nil {
+			sim.Error = err.Error()
+		}
+		result.Dotfiles = append(result.Dotfiles, sim)
+	}
+
+	for _, name := range cfg.ActiveResources() {
+		if resources.IsBuiltin(name) {
+			result.Resources = append(result.Resources, name)
+			continue
+		}
+		result.Resources = append(result.Resources, resources.PluginPrefix+name)
+	}
+	return result, nil
+}
+
+// sortedManagers returns the managers of a lock file in name order
+func sortedManagers(pkgs map[string][]string) []string {
+	managers := make([]string, 0, len(pkgs))
+	for manager := range pkgs {
+		managers = append(managers, manager)
+	}
+	slices.Sort(managers)
+	return managers
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package clone
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/richhaase/plonk/internal/lock"
+	"github.com/richhaase/plonk/internal/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulateDir(t *testing.T) {
+	t.Setenv("PLONK_CONTAINER", "false")
+	t.Setenv("PLONK_ALLOWED_SIGNERS", "")
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	t.Setenv("SIMULATE_TEST_UNSET", "")
+	os.Unsetenv("SIMULATE_TEST_UNSET")
+
+	dir := t.TempDir()
+	lockFile := lock.NewLockV3()
+	lockFile.AddPackage("brew", "ripgrep")
+	lockFile.AddPackage("cargo", "bat")
+	lockFile.AddPackage("go", "golang.org/x/tools/gopls")
+	lockFile.AddPackage("mas", "497799835")
+	require.NoError(t, lock.NewLockV3Service(dir).Write(lockFile))
+
+	files := map[string]string{
+		"plonk.yaml":           "disabled_managers: [go]\nresources: [vscode]\n",
+		"zshrc":                "export EDITOR=nvim\n",
+		"gitconfig.tmpl":       "[user]\n  email = {{SIMULATE_TEST_UNSET}}\n",
+		"config/nvim/init.lua": "vim.o.number = true\n",
+		".plonk.lock.sig":      "not deployed\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+
+	result, err := SimulateDir(context.Background(), dir)
+	require.NoError(t, err)
+
+	assert.Empty(t, result.Signature, "no trust file means no verification")
+	assert.Equal(t, []output.SimulatedManager{
+		{Manager: "brew", Packages: []string{"ripgrep"}},
+		{Manager: "cargo", Packages: []string{"bat"}, Note: "after brew install rust"},
+		{Manager: "go", Packages: []string{"golang.org/x/tools/gopls"}, Skipped: true, Note: "disabled in plonk.yaml"},
+		{Manager: "mas", Packages: []string{"497799835"}, Note: "needs the plonk-manager-mas plugin"},
+	}, result.Managers)
+
+	targets := make(map[string]string)
+	for _, d := range result.Dotfiles {
+		targets[d.Target] = d.Error
+	}
+	assert.Len(t, targets, 3)
+	assert.Contains(t, targets, filepath.Join("~", ".zshrc"))
+	assert.Contains(t, targets, filepath.Join("~", ".config", "nvim", "init.lua"))
+	assert.Contains(t, targets[filepath.Join("~", ".gitconfig")], "SIMULATE_TEST_UNSET")
+
+	assert.Equal(t, []string{"plonk-resource-vscode"}, result.Resources)
+	assert.True(t, result.Failed())
+}
+
+func TestSimulate_InvalidSource(t *testing.T) {
+	_, err := Simulate(context.Background(), "not a repository")
+	assert.Error(t, err)
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package clone
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/richhaase/plonk/internal/config"
+	"github.com/richhaase/plonk/internal/diagnostics"
+	"github.com/richhaase/plonk/internal/dotfiles"
+	"github.com/richhaase/plonk/internal/output"
+	"github.com/richhaase/plonk/internal/packages"
+)
+
+// Verify checks that the machine plonkDir was just set up on is ready:
+// every tracked package installed with its manager's command directory on
+// PATH, every dotfile deployed with the repository's content, and doctor
+// finding nothing critical
+func Verify(ctx context.Context, plonkDir, homeDir string, cfg *config.Config) output.VerifyOutput {
+	packages.Configure(cfg)
+	checks := []output.VerifyCheck{
+		verifyPackages(ctx, plonkDir),
+		verifyCommandPath(plonkDir, cfg, os.Getenv("PATH")),
+		verifyDotfiles(plonkDir, homeDir, cfg),
+		verifyDoctor(ctx),
+	}
+
+	result := output.VerifyOutput{Ready: true, Checks: checks}
+	for _, c := range checks {
+		result.Ready = result.Ready && c.Passed
+	}
+	return result
+}
+
+// verifyPackages checks that every tracked package is installed
+func verifyPackages(ctx context.Context, plonkDir string) output.VerifyCheck {
+	check := output.VerifyCheck{Name: "Packages"}
+	statuses, err := packages.Reconcile(ctx, plonkDir)
+	if err != nil {
+		check.Message = err.Error()
+		return check
+	}
+	for _, s := range statuses {
+		switch s.State {
+		case packages.StatusMissing:
+			check.Issues = append(check.Issues, fmt.Sprintf("%s:%s is not installed", s.Manager, s.Name))
+		case packages.StatusError:
+			check.Issues = append(check.Issues, fmt.Sprintf("%s:%s could not be checked: %v", s.Manager, s.Name, s.Err))
+		}
+	}
+	check.Passed = len(check.Issues) == 0
+	check.Message = fmt.Sprintf("%d of %d installed", len(statuses)-len(check.Issues), len(statuses))
+	return check
+}
+
+// verifyCommandPath checks that the commands of tracked packages can be
+// run: each manager's command directory, or plonk's shim directory with
+// shims on, must be on pathEnv
+func verifyCommandPath(plonkDir string, cfg *config.Config, pathEnv string) output.VerifyCheck {
+	check := output.VerifyCheck{Name: "Commands on PATH"}
+	managers, _ := DetectRequiredManagers(filepath.Join(plonkDir, "plonk.lock"))
+
+	var onPath []string
+	for _, dir := range filepath.SplitList(pathEnv) {
+		if dir != "" {
+			onPath = append(onPath, filepath.Clean(dir))
+		}
+	}
+	shimmed := cfg != nil && cfg.Shims && slices.Contains(onPath, filepath.Clean(config.GetShimDirectory()))
+
+	checked := 0
+	for _, manager := range managers {
+		dir := packages.CommandDir(manager)
+		if dir == "" || packages.IsManagerDisabled(manager) {
+			continue
+		}
+		checked++
+		if !shimmed && !slices.Contains(onPath, filepath.Clean(dir)) {
+			check.Issues = append(check.Issues, fmt.Sprintf("%s installs commands in %s, which is not on PATH", manager, dir))
+		}
+	}
+	check.Passed = len(check.Issues) == 0
+	check.Message = fmt.Sprintf("%d of %d managers' command directories on PATH", checked-len(check.Issues), checked)
+	if shimmed {
+		check.Message = "plonk's shim directory is on PATH"
+	}
+	return check
+}
+
+// verifyDotfiles checks that every dotfile is deployed with the
+// repository's content, or deliberately kept local
+func verifyDotfiles(plonkDir, homeDir string, cfg *config.Config) output.VerifyCheck {
+	check := output.VerifyCheck{Name: "Dotfiles"}
+	statuses, err := dotfiles.NewDotfileManager(plonkDir, homeDir, cfg.IgnorePatterns).Reconcile()
+	if err != nil {
+		check.Message = err.Error()
+		return check
+	}
+	kept := 0
+	for _, s := range statuses {
+		switch {
+		case s.KeptLocal:
+			kept++
+		case s.State == dotfiles.SyncStateMissing:
+			check.Issues = append(check.Issues, s.Target+" is not deployed")
+		case s.State == dotfiles.SyncStateDrifted && s.Problem != "":
+			check.Issues = append(check.Issues, fmt.Sprintf("%s: %s", s.Target, s.Problem))
+		case s.State == dotfiles.SyncStateDrifted:
+			check.Issues = append(check.Issues, s.Target+" differs from the repository")
+		case s.State == dotfiles.SyncStateError:
+			check.Issues = append(check.Issues, fmt.Sprintf("%s could not be checked: %v", s.Target, s.Error))
+		}
+	}
+	check.Passed = len(check.Issues) == 0
+	check.Message = fmt.Sprintf("%d of %d match the repository", len(statuses)-len(check.Issues)-kept, len(statuses))
+	if kept > 0 {
+		check.Message += fmt.Sprintf(", %d kept local", kept)
+	}
+	return check
+}
+
+// verifyDoctor runs the doctor checks; warnings don't fail verification
+func verifyDoctor(ctx context.Context) output.VerifyCheck {
+	report := diagnostics.RunHealthChecksWithContext(ctx)
+	check := output.VerifyCheck{Name: "Doctor", Message: report.Overall.Message}
+	for _, c := range report.Checks {
+		if c.Status == "fail" {
+			check.Issues = append(check.Issues, fmt.Sprintf("%s: %s", c.Name, c.Message))
+		}
+	}
+	check.Passed = report.Overall.Status != "unhealthy"
+	if !check.Passed {
+		check.Issues = append(check.Issues, "run 'plonk doctor' for details")
+	}
+	return check
+}
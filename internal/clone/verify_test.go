@@ -0,0 +1,55 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package clone
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/richhaase/plonk/internal/config"
+	"github.com/richhaase/plonk/internal/lock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyCommandPath(t *testing.T) {
+	cargoHome := t.TempDir()
+	t.Setenv("CARGO_HOME", cargoHome)
+	cargoBin := filepath.Join(cargoHome, "bin")
+	require.NoError(t, os.MkdirAll(cargoBin, 0o755))
+
+	dir := t.TempDir()
+	lockFile := lock.NewLockV3()
+	lockFile.AddPackage("cargo", "bat")
+	lockFile.AddPackage("mas", "497799835") // a plugin: no known command directory
+	require.NoError(t, lock.NewLockV3Service(dir).Write(lockFile))
+
+	check := verifyCommandPath(dir, &config.Config{}, "/usr/bin")
+	assert.False(t, check.Passed)
+	assert.Equal(t, []string{"cargo installs commands in " + cargoBin + ", which is not on PATH"}, check.Issues)
+
+	check = verifyCommandPath(dir, &config.Config{}, "/usr/bin"+string(os.PathListSeparator)+cargoBin+"/")
+	assert.True(t, check.Passed)
+	assert.Equal(t, "1 of 1 managers' command directories on PATH", check.Message)
+}
+
+func TestVerifyDotfiles(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	plonkDir, homeDir := t.TempDir(), t.TempDir()
+	for name, content := range map[string]string{"zshrc": "export EDITOR=nvim\n", "vimrc": "set number\n"} {
+		require.NoError(t, os.WriteFile(filepath.Join(plonkDir, name), []byte(content), 0o644))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(homeDir, ".zshrc"), []byte("export EDITOR=nvim\n"), 0o644))
+
+	check := verifyDotfiles(plonkDir, homeDir, &config.Config{})
+	assert.False(t, check.Passed)
+	assert.Equal(t, []string{filepath.Join(homeDir, ".vimrc") + " is not deployed"}, check.Issues)
+	assert.Equal(t, "1 of 2 match the repository", check.Message)
+
+	require.NoError(t, os.WriteFile(filepath.Join(homeDir, ".vimrc"), []byte("set number\n"), 0o644))
+	check = verifyDotfiles(plonkDir, homeDir, &config.Config{})
+	assert.True(t, check.Passed)
+	assert.Empty(t, check.Issues)
+}
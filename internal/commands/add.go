@@ -67,6 +67,7 @@ func init() {
 	rootCmd.AddCommand(addCmd)
 	addCmd.Flags().BoolP("dry-run", "n", false, "Show what would be added without making changes")
 	addCmd.Flags().BoolP("sync-drifted", "y", false, "Sync all drifted files from $HOME back to $PLONKDIR")
+	addCmd.Flags().Bool("allow-secrets", false, "Add files even if they contain probable secrets")
 
 	// Add file path completion
 	addCmd.ValidArgsFunction = CompleteDotfilePaths
@@ -76,6 +77,7 @@ func runAdd(cmd *cobra.Command, args []string) error {
 	// Get flags
 	dryRun, _ := cmd.Flags().GetBool("dry-run")
 	syncDrifted, _ := cmd.Flags().GetBool("sync-drifted")
+	allowSecrets, _ := cmd.Flags().GetBool("allow-secrets")
 
 	// Get directories
 	homeDir, err := config.GetHomeDir()
@@ -89,7 +91,7 @@ func runAdd(cmd *cobra.Command, args []string) error {
 
 	// Handle sync-drifted flag
 	if syncDrifted {
-		return runSyncDrifted(cmd.Context(), cfg, configDir, homeDir, dryRun)
+		return runSyncDrifted(cmd.Context(), cfg, configDir, homeDir, AddOptions{DryRun: dryRun, AllowSecrets: allowSecrets})
 	}
 
 	// Require at least one file argument if not syncing drifted
@@ -102,7 +104,8 @@ func runAdd(cmd *cobra.Command, args []string) error {
 
 	// Configure options
 	opts := AddOptions{
-		DryRun: dryRun,
+		DryRun:       dryRun,
+		AllowSecrets: allowSecrets,
 	}
 
 	// Process dotfiles using helper function
@@ -145,7 +148,7 @@ func runAdd(cmd *cobra.Command, args []string) error {
 }
 
 // runSyncDrifted syncs all drifted files from $HOME back to $PLONKDIR
-func runSyncDrifted(ctx context.Context, cfg *config.Config, configDir, homeDir string, dryRun bool) error {
+func runSyncDrifted(ctx context.Context, cfg *config.Config, configDir, homeDir string, opts AddOptions) error {
 	// Get drifted dotfiles from reconciliation
 	driftedFiles, err := getDriftedDotfileStatuses(cfg, configDir, homeDir)
 	if err != nil {
@@ -173,11 +176,6 @@ func runSyncDrifted(ctx context.Context, cfg *config.Config, configDir, homeDir
 	// Create DotfileManager directly
 	dm := dotfiles.NewDotfileManager(configDir, homeDir, cfg.IgnorePatterns)
 
-	// Configure options
-	opts := AddOptions{
-		DryRun: dryRun,
-	}
-
 	// Process the drifted files
 	results := addDotfiles(dm, configDir, homeDir, paths, opts)
 
@@ -209,7 +207,7 @@ func runSyncDrifted(ctx context.Context, cfg *config.Config, configDir, homeDir
 	output.RenderOutput(outputData)
 
 	// Auto-commit synced drifted files
-	if !dryRun && validateAddResultsErr(results) == nil {
+	if !opts.DryRun && validateAddResultsErr(results) == nil {
 		gitops.AutoCommit(ctx, configDir, "add --sync-drifted", paths)
 	}
 
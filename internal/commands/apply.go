@@ -4,16 +4,19 @@
 package commands
 
 import (
+	"bufio"
 	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/richhaase/plonk/internal/config"
 	"github.com/richhaase/plonk/internal/dotfiles"
 	"github.com/richhaase/plonk/internal/orchestrator"
 	"github.com/richhaase/plonk/internal/output"
+	"github.com/richhaase/plonk/internal/packages"
 	"github.com/spf13/cobra"
 )
 
@@ -37,10 +40,14 @@ only those dotfiles will be deployed (packages are not applied).
 Examples:
   plonk apply                    # Apply all configuration changes
   plonk apply --dry-run          # Show what would be applied without making changes
+  plonk apply --check            # Exit 2 if anything would change (for CI)
   plonk apply --packages         # Apply packages only
   plonk apply --dotfiles         # Apply dotfiles only
+  plonk apply --verbose          # Show build/download output as it happens
+  plonk apply --offline          # Install only from local caches (see plonk cache)
   plonk apply ~/.vimrc ~/.zshrc  # Apply only specific dotfiles`,
 	RunE:         runApply,
+	SuggestFor:   []string{"upgrade"},
 	SilenceUsage: true,
 }
 
@@ -54,13 +61,25 @@ func init() {
 
 	// Behavior flags
 	applyCmd.Flags().BoolP("dry-run", "n", false, "Show what would be applied without making changes")
+	applyCmd.Flags().Bool("check", false, "Dry run that exits 2 if anything would change")
+	applyCmd.Flags().BoolP("verbose", "v", false, "Stream package manager output while installing")
+	applyCmd.Flags().Bool("offline", false, "Install packages only from the managers' local caches")
+	addSchemaFlag(applyCmd)
 }
 
 func runApply(cmd *cobra.Command, args []string) error {
+	if handled, err := printSchemaIfRequested(cmd, "apply"); handled {
+		return err
+	}
+
 	// Parse flags
 	dryRun, _ := cmd.Flags().GetBool("dry-run")
 	packagesOnly, _ := cmd.Flags().GetBool("packages")
 	dotfilesOnly, _ := cmd.Flags().GetBool("dotfiles")
+	verbose, _ := cmd.Flags().GetBool("verbose")
+	check, _ := cmd.Flags().GetBool("check")
+	offline, _ := cmd.Flags().GetBool("offline")
+	dryRun = dryRun || check
 
 	// Get directories
 	homeDir, err := config.GetHomeDir()
@@ -71,21 +90,36 @@ func runApply(cmd *cobra.Command, args []string) error {
 
 	// Load configuration
 	cfg := config.LoadWithDefaults(configDir)
+	if offline {
+		cfg.Managers.Offline = true
+	}
 
 	ctx := context.Background()
 
+	if !dryRun && !packagesOnly {
+		if err := answerTemplatePrompts(cmd, configDir, homeDir); err != nil {
+			return err
+		}
+	}
+
 	// If specific files are provided, apply only those dotfiles
 	if len(args) > 0 {
+		if check {
+			return fmt.Errorf("cannot specify files with --check")
+		}
 		if packagesOnly || dotfilesOnly {
 			return fmt.Errorf("cannot specify files with --packages or --dotfiles flags")
 		}
 		return runSelectiveApply(ctx, args, cfg, configDir, homeDir, dryRun)
 	}
 
+	packages.SetStreamOutput(verbose)
+
 	// Create new orchestrator with all options
 	orch := orchestrator.New(
 		orchestrator.WithConfig(cfg),
 		orchestrator.WithConfigDir(configDir),
+		orchestrator.WithHistory(orchestrator.HistoryPath()),
 		orchestrator.WithHomeDir(homeDir),
 		orchestrator.WithDryRun(dryRun),
 		orchestrator.WithPackagesOnly(packagesOnly),
@@ -103,13 +137,64 @@ func runApply(cmd *cobra.Command, args []string) error {
 
 	// Now handle any errors from apply
 	if err != nil {
+		if !dryRun {
+			writeApplyFailureReport(result)
+		}
 		// The apply completed with some errors, exit with non-zero
 		return err
 	}
 
+	if check && result.Changed {
+		return &pendingChangesError{count: pendingChangeCount(result)}
+	}
+
 	return nil
 }
 
+// writeApplyFailureReport saves what failed in the state directory's logs
+// and says where, so it can be shared without the terminal scrollback.
+// Failing to write it only costs the report.
+func writeApplyFailureReport(result output.ApplyResult) {
+	report, failed := orchestrator.NewFailureReport(result, time.Now())
+	if !failed {
+		return
+	}
+	path, err := orchestrator.WriteFailureReport(orchestrator.FailureReportDir(), report)
+	if err != nil {
+		output.Printf("Warning: could not write failure report: %v\n", err)
+		return
+	}
+	output.Printf("Failure report: %s\n", path)
+}
+
+// answerTemplatePrompts asks the template prompts this machine hasn't
+// answered yet. Without a terminal they stay unanswered and the templates
+// that use them fail to render, naming the variables.
+func answerTemplatePrompts(cmd *cobra.Command, configDir, homeDir string) error {
+	if !stdinIsTerminal() {
+		return nil
+	}
+	reader := bufio.NewReader(cmd.InOrStdin())
+	return dotfiles.AnswerPrompts(configDir, homeDir, func(p dotfiles.TemplatePrompt) (string, error) {
+		return promptLine(reader, cmd.ErrOrStderr(), p.Question, p.Default)
+	})
+}
+
+// pendingChangeCount counts the items a dry run would change
+func pendingChangeCount(result output.ApplyResult) int {
+	count := 0
+	if result.Packages != nil {
+		count += result.Packages.TotalWouldInstall
+	}
+	if result.Dotfiles != nil {
+		count += result.Dotfiles.Summary.Added + result.Dotfiles.Summary.Updated
+	}
+	if result.Resources != nil {
+		count += result.Resources.TotalWouldApply
+	}
+	return count
+}
+
 // getApplyScope returns a description of what's being applied
 func getApplyScope(packagesOnly, dotfilesOnly bool) string {
 	if packagesOnly {
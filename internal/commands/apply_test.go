@@ -4,9 +4,14 @@
 package commands
 
 import (
+	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/richhaase/plonk/internal/output"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetApplyScope(t *testing.T) {
@@ -49,3 +54,41 @@ func TestGetApplyScope(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyCheck(t *testing.T) {
+	home := t.TempDir()
+	configDir := filepath.Join(home, "plonk")
+	require.NoError(t, os.MkdirAll(configDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "zshrc"), []byte("export A=1\n"), 0o644))
+	t.Setenv("HOME", home)
+	t.Setenv("PLONK_DIR", configDir)
+	t.Setenv("XDG_STATE_HOME", filepath.Join(home, "state"))
+	t.Cleanup(func() {
+		rootCmd.SetArgs(nil)
+		_ = applyCmd.Flags().Set("check", "false")
+		_ = applyCmd.Flags().Set("dotfiles", "false")
+		_ = rootCmd.PersistentFlags().Set("output", "table")
+		output.SetOutputFormat(output.OutputTable)
+	})
+
+	rootCmd.SetArgs([]string{"apply", "--check", "--dotfiles", "-o", "json"})
+	err := rootCmd.Execute()
+	var pending *pendingChangesError
+	require.True(t, errors.As(err, &pending), "got %v", err)
+	assert.Equal(t, "1 change pending", err.Error())
+	assert.NoFileExists(t, filepath.Join(home, ".zshrc"), "--check never changes anything")
+
+	require.NoError(t, os.WriteFile(filepath.Join(home, ".zshrc"), []byte("export A=1\n"), 0o644))
+	rootCmd.SetArgs([]string{"apply", "--check", "--dotfiles", "-o", "json"})
+	assert.NoError(t, rootCmd.Execute(), "nothing to change")
+}
+
+func TestPendingChangeCount(t *testing.T) {
+	result := output.ApplyResult{
+		Packages:  &output.PackageResults{TotalWouldInstall: 2},
+		Dotfiles:  &output.DotfileResults{Summary: output.DotfileSummary{Added: 1, Updated: 3, Unchanged: 5}},
+		Resources: &output.ResourceResults{TotalWouldApply: 1},
+	}
+	assert.Equal(t, 7, pendingChangeCount(result))
+	assert.Equal(t, 0, pendingChangeCount(output.ApplyResult{}))
+}
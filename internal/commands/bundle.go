@@ -0,0 +1,146 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/richhaase/plonk/internal/config"
+	"github.com/richhaase/plonk/internal/gitops"
+	"github.com/richhaase/plonk/internal/lock"
+	"github.com/richhaase/plonk/internal/manifest"
+	"github.com/richhaase/plonk/internal/orchestrator"
+	"github.com/richhaase/plonk/internal/output"
+	"github.com/richhaase/plonk/internal/packages"
+	"github.com/spf13/cobra"
+)
+
+var bundleCmd = &cobra.Command{
+	Use:   "bundle",
+	Short: "Track and install the packages of a Plonkfile or Brewfile",
+	Long: `Read a Plonkfile, a Brewfile whose lines may name any plonk manager,
+add its packages to plonk.lock and install the ones that are missing.
+
+  brew "ripgrep"
+  cask "firefox"
+  cargo "bat"
+  go "golang.org/x/tools/gopls"
+
+brew and cask lines go to brew; any other keyword is a manager name. Options
+after the package name are ignored, and tap, mas and vscode lines are
+skipped. An existing Brewfile works unchanged, so a Homebrew Bundle setup can
+move to plonk one line at a time.
+
+Without --file, ./Plonkfile is read, or ./Brewfile if there is none.
+
+Examples:
+  plonk bundle                    # Track and install ./Plonkfile
+  plonk bundle --file ~/Brewfile  # Use an existing Brewfile
+  plonk bundle --dry-run          # Show what would be tracked`,
+	RunE:         runBundle,
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+}
+
+func init() {
+	rootCmd.AddCommand(bundleCmd)
+	bundleCmd.Flags().StringP("file", "f", "", "Plonkfile to read (default ./Plonkfile, then ./Brewfile)")
+	bundleCmd.Flags().BoolP("dry-run", "n", false, "Show what would be tracked without changing anything")
+}
+
+func runBundle(cmd *cobra.Command, args []string) error {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	path, _ := cmd.Flags().GetString("file")
+	if path == "" {
+		path = defaultBundleFile()
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	entries, skipped, err := manifest.ParseBundle(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	for _, s := range skipped {
+		output.Printf("%s line %d: skipping %s: %s\n", output.IconWarning, s.Line, s.Text, s.Reason)
+	}
+
+	configDir := config.GetDefaultConfigDirectory()
+	homeDir, err := config.GetHomeDir()
+	if err != nil {
+		return err
+	}
+	cfg := config.LoadWithDefaults(configDir)
+	packages.Configure(cfg)
+
+	lockSvc := lock.NewLockV3Service(configDir)
+	lockFile, err := lockSvc.Read()
+	if err != nil {
+		return fmt.Errorf("failed to read lock file: %w", err)
+	}
+
+	selected := make(map[string]bool)
+	var added []string
+	for _, e := range entries {
+		spec := e.Manager + ":" + e.Name
+		if !packages.IsSupportedManager(e.Manager) {
+			output.Printf("%s line %d: skipping %s: unknown manager %q\n", output.IconWarning, e.Line, spec, e.Manager)
+			continue
+		}
+		selected[spec] = true
+		if lockFile.HasPackage(e.Manager, e.Name) {
+			continue
+		}
+		added = append(added, spec)
+		if !dryRun {
+			lockFile.AddPackage(e.Manager, e.Name)
+		}
+	}
+
+	if dryRun {
+		for _, spec := range added {
+			output.Printf("  %s %s would be tracked\n", output.IconInfo, spec)
+		}
+		output.Printf("%d of %d packages in %s would be tracked\n", len(added), len(selected), path)
+		return nil
+	}
+
+	if len(added) > 0 {
+		if err := lockSvc.Write(lockFile); err != nil {
+			return fmt.Errorf("failed to write lock file: %w", err)
+		}
+		output.Printf("Tracking %d new packages from %s\n", len(added), path)
+		gitops.AutoCommit(cmd.Context(), configDir, "bundle", added)
+	}
+	if len(selected) == 0 {
+		output.Printf("No packages to install from %s\n", path)
+		return nil
+	}
+
+	orch := orchestrator.New(
+		orchestrator.WithConfig(cfg),
+		orchestrator.WithConfigDir(configDir),
+		orchestrator.WithHistory(orchestrator.HistoryPath()),
+		orchestrator.WithHomeDir(homeDir),
+		orchestrator.WithPackagesOnly(true),
+		orchestrator.WithSelection(selected, nil),
+	)
+	result, err := orch.Apply(cmd.Context())
+	result.Scope = "bundle"
+	output.RenderOutput(result)
+	return err
+}
+
+// defaultBundleFile is ./Plonkfile, or ./Brewfile when only that exists
+func defaultBundleFile() string {
+	if _, err := os.Stat("Plonkfile"); err != nil {
+		if _, err := os.Stat("Brewfile"); err == nil {
+			return "Brewfile"
+		}
+	}
+	return "Plonkfile"
+}
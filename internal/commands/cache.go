@@ -0,0 +1,170 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package commands
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/richhaase/plonk/internal/config"
+	"github.com/richhaase/plonk/internal/lock"
+	"github.com/richhaase/plonk/internal/output"
+	"github.com/richhaase/plonk/internal/packages"
+	"github.com/spf13/cobra"
+)
+
+// defaultCacheArchive is where `plonk cache export` writes without an argument
+const defaultCacheArchive = "plonk-cache.tar.gz"
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Carry package downloads to machines without internet access",
+	Long: `Move the download caches of the package managers plonk.lock uses to
+another machine, so it can install packages without internet access.
+
+On a connected machine, run 'plonk apply' so the caches hold everything,
+then export them. On the offline machine, import the archive and apply with
+--offline, which keeps every manager to its local cache.
+
+Exported caches: Homebrew's download cache (bottles and API data), cargo's
+registry index and crate files, go's module downloads, the pnpm store and
+uv's cache. Plugin managers are not included.
+
+Commands:
+  export    Write the caches to an archive
+  import    Add an archive's files to this machine's caches
+
+Examples:
+  plonk cache export /Volumes/usb/plonk-cache.tar.gz
+  plonk cache import /Volumes/usb/plonk-cache.tar.gz
+  plonk apply --offline`,
+}
+
+var cacheExportCmd = &cobra.Command{
+	Use:   "export [archive]",
+	Short: "Write the package managers' download caches to an archive",
+	Long: `Write the download caches of the built-in managers tracked in plonk.lock
+to a gzipped tar archive, plonk-cache.tar.gz unless another path is given.
+
+Caches are copied whole, so the archive also holds downloads of packages
+plonk doesn't track. Clean a manager's cache first to keep it small.
+
+Examples:
+  plonk cache export
+  plonk cache export ~/plonk-cache.tar.gz`,
+	RunE:         runCacheExport,
+	SilenceUsage: true,
+	Args:         cobra.MaximumNArgs(1),
+}
+
+var cacheImportCmd = &cobra.Command{
+	Use:   "import <archive>",
+	Short: "Add an exported archive's files to this machine's caches",
+	Long: `Extract an archive written by 'plonk cache export' into the download
+caches of this machine's managers, wherever each keeps its cache here.
+Files already in a cache are kept. Then run 'plonk apply --offline'.
+
+Examples:
+  plonk cache import plonk-cache.tar.gz`,
+	RunE:         runCacheImport,
+	SilenceUsage: true,
+	Args:         cobra.ExactArgs(1),
+}
+
+func init() {
+	rootCmd.AddCommand(cacheCmd)
+	cacheCmd.AddCommand(cacheExportCmd)
+	cacheCmd.AddCommand(cacheImportCmd)
+}
+
+func runCacheExport(cmd *cobra.Command, args []string) error {
+	archive := defaultCacheArchive
+	if len(args) > 0 {
+		archive = args[0]
+	}
+
+	configDir := config.GetDefaultConfigDirectory()
+	packages.Configure(config.LoadWithDefaults(configDir))
+	lockFile, err := lock.NewLockV3Service(configDir).Read()
+	if err != nil {
+		return fmt.Errorf("failed to read lock file: %w", err)
+	}
+	var tracked []string
+	for manager := range lockFile.Packages {
+		if !packages.IsManagerDisabled(manager) {
+			tracked = append(tracked, manager)
+		}
+	}
+	sort.Strings(tracked)
+	managers := packages.CacheManagers(tracked)
+	if len(managers) == 0 {
+		return fmt.Errorf("plonk.lock tracks no packages of a built-in manager, so there are no caches to export")
+	}
+
+	tmpPath := archive + ".plonk.tmp"
+	f, err := os.Create(tmpPath)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", archive, err)
+	}
+	summary, err := packages.ExportCaches(cmd.Context(), f, managers)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, archive)
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to export caches: %w", err)
+	}
+
+	output.RenderOutput(cacheTransferOutput("exported", archive, managers, summary))
+	return nil
+}
+
+func runCacheImport(cmd *cobra.Command, args []string) error {
+	archive := args[0]
+	f, err := os.Open(archive)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	packages.Configure(config.LoadWithDefaults(config.GetDefaultConfigDirectory()))
+	summary, err := packages.ImportCaches(cmd.Context(), f)
+	if err != nil {
+		return fmt.Errorf("failed to import %s: %w", archive, err)
+	}
+
+	managers := make([]string, 0, len(summary.Files))
+	for manager := range summary.Files {
+		managers = append(managers, manager)
+	}
+	sort.Strings(managers)
+	output.RenderOutput(cacheTransferOutput("imported", archive, managers, summary))
+	return nil
+}
+
+// cacheTransferOutput converts a cache summary for rendering, listing the
+// managers that moved files in the given order
+func cacheTransferOutput(action, archive string, managers []string, summary packages.CacheSummary) output.CacheTransferOutput {
+	result := output.CacheTransferOutput{
+		Action:   action,
+		Archive:  archive,
+		Managers: []output.CacheManagerTotal{},
+		Skipped:  summary.Skipped,
+	}
+	for _, manager := range managers {
+		if summary.Files[manager] == 0 {
+			continue
+		}
+		result.Managers = append(result.Managers, output.CacheManagerTotal{
+			Manager: manager,
+			Files:   summary.Files[manager],
+			Bytes:   summary.Bytes[manager],
+		})
+	}
+	return result
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/richhaase/plonk/internal/ci"
+	"github.com/richhaase/plonk/internal/config"
+	"github.com/richhaase/plonk/internal/gitops"
+	"github.com/richhaase/plonk/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var ciCmd = &cobra.Command{
+	Use:   "ci",
+	Short: "Check the plonk repository in GitHub Actions",
+	Long: `Set up GitHub Actions to check your plonk repository on every push and
+pull request, and report problems as annotations on the changed files.
+
+Commands:
+  setup     Write a GitHub Actions workflow for the repository
+  annotate  Turn plonk JSON output into GitHub problem annotations
+
+Examples:
+  plonk ci setup --write && plonk push
+  plonk simulate -o json | plonk ci annotate`,
+}
+
+var ciSetupCmd = &cobra.Command{
+	Use:   "setup",
+	Short: "Write a GitHub Actions workflow that checks the repository",
+	Long: `Print a GitHub Actions workflow that installs plonk on Linux and macOS
+runners and checks the repository:
+
+- 'plonk simulate' fails the job when a template doesn't render or the lock
+  signature doesn't verify
+- 'plonk apply --check' fails it when anything can't be applied; changes
+  waiting to be made are expected on a fresh runner and pass
+
+Both steps annotate their problems with 'plonk ci annotate'. With --write
+the workflow is saved as .github/workflows/plonk.yml in the plonk directory
+and committed, ready for 'plonk push'.
+
+Examples:
+  plonk ci setup
+  plonk ci setup --write
+  plonk ci setup --runner ubuntu-24.04`,
+	RunE:         runCISetup,
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+}
+
+var ciAnnotateCmd = &cobra.Command{
+	Use:   "annotate [file]",
+	Short: "Turn plonk JSON output into GitHub problem annotations",
+	Long: `Read the JSON output of 'plonk apply' or 'plonk simulate' from a file, or
+from stdin without one, and print a GitHub Actions annotation for every
+problem in it: failed packages point at their line in plonk.lock, failed
+dotfiles and templates at their source file.
+
+Run it in a workflow step so GitHub shows the annotations on the commit or
+pull request. It exits zero; the plonk command's own exit status decides
+whether the step fails.
+
+Examples:
+  plonk simulate . -o json | plonk ci annotate
+  plonk apply --check -o json > apply.json; plonk ci annotate apply.json`,
+	RunE:         runCIAnnotate,
+	SilenceUsage: true,
+	Args:         cobra.MaximumNArgs(1),
+}
+
+func init() {
+	rootCmd.AddCommand(ciCmd)
+	ciCmd.AddCommand(ciSetupCmd)
+	ciCmd.AddCommand(ciAnnotateCmd)
+
+	ciSetupCmd.Flags().Bool("write", false, "Save the workflow in the plonk directory and commit it")
+	ciSetupCmd.Flags().Bool("force", false, "Replace an existing workflow with --write")
+	ciSetupCmd.Flags().StringSlice("runner", ci.DefaultRunners, "GitHub runners to check on (repeatable)")
+}
+
+func runCISetup(cmd *cobra.Command, args []string) error {
+	write, _ := cmd.Flags().GetBool("write")
+	force, _ := cmd.Flags().GetBool("force")
+	runners, _ := cmd.Flags().GetStringSlice("runner")
+	if len(runners) == 0 {
+		return fmt.Errorf("at least one --runner is required")
+	}
+	workflow := ci.Workflow(runners)
+
+	if !write {
+		_, err := io.WriteString(cmd.OutOrStdout(), workflow)
+		return err
+	}
+
+	configDir := config.GetDefaultConfigDirectory()
+	path := filepath.Join(configDir, filepath.FromSlash(ci.WorkflowPath))
+	if _, err := os.Stat(path); err == nil && !force {
+		return fmt.Errorf("%s already exists; use --force to replace it", path)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, []byte(workflow), 0o644); err != nil {
+		return fmt.Errorf("failed to write workflow: %w", err)
+	}
+	output.Printf("%s Wrote %s\n", output.IconSuccess, path)
+	gitops.AutoCommit(cmd.Context(), configDir, "ci setup", []string{ci.WorkflowPath})
+	return nil
+}
+
+func runCIAnnotate(cmd *cobra.Command, args []string) error {
+	var data []byte
+	var err error
+	if len(args) == 0 || args[0] == "-" {
+		data, err = io.ReadAll(cmd.InOrStdin())
+	} else {
+		data, err = os.ReadFile(args[0])
+	}
+	if err != nil {
+		return err
+	}
+
+	configDir := config.GetDefaultConfigDirectory()
+	lockData, _ := os.ReadFile(filepath.Join(configDir, "plonk.lock"))
+	annotations, err := ci.Annotate(data, configDir, lockData)
+	if err != nil {
+		return err
+	}
+	for _, annotation := range annotations {
+		fmt.Fprintln(cmd.OutOrStdout(), annotation)
+	}
+	return nil
+}
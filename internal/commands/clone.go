@@ -4,13 +4,23 @@
 package commands
 
 import (
+	"bufio"
 	"context"
+	"fmt"
+	"io"
 
 	"github.com/richhaase/plonk/internal/clone"
+	"github.com/richhaase/plonk/internal/dotfiles"
+	"github.com/richhaase/plonk/internal/output"
+	"github.com/richhaase/plonk/internal/signing"
 	"github.com/spf13/cobra"
 )
 
-var cloneDryRun bool
+var (
+	cloneDryRun        bool
+	cloneNoInteractive bool
+	cloneTrust         string
+)
 
 var cloneCmd = &cobra.Command{
 	Use:   "clone <git-repo>",
@@ -20,12 +30,31 @@ var cloneCmd = &cobra.Command{
 This command:
 - Clones the repository into your plonk directory
 - Reads the plonk.lock file to detect required package managers
-- Installs ONLY the package managers needed by your dotfiles
-- Runs 'plonk apply' to configure your system
+- Offers to install missing package managers with Homebrew
+- Offers to install tracked packages and deploy dotfiles ('plonk apply')
+- Verifies the machine is ready: packages installed, their commands on
+  PATH, dotfiles matching the repository, and no failing doctor checks
 
 The intelligent detection feature means you don't need to manually specify
 which package managers to install - plonk will figure it out from your lock file.
 
+In a terminal, each setup step is a question with a default. With
+--no-interactive (or when stdin is not a terminal) the defaults are used:
+missing managers are reported but not installed, and everything is applied.
+
+On a machine that already has dotfiles, clone asks about each one the
+repository would overwrite: take the repository's copy, keep the local
+file, merge (the differing lines are written into the local file between
+conflict markers), or skip it for now. Files kept local or merged are
+remembered in ~/.local/state/plonk/kept-local.yaml, so later applies leave
+them alone until 'plonk add' stores them in the repository. Skipped files
+are deployed by the next 'plonk apply'.
+
+With --trust, plonk.lock must carry a valid signature from a key in the
+given SSH allowed_signers file (or minisign public key) before any package
+is installed. The file is kept for this machine, so later applies verify
+too.
+
 Git repository formats supported:
 - GitHub shorthand: user/repo (defaults to HTTPS)
 - HTTPS URL: https://github.com/user/repo.git
@@ -34,7 +63,9 @@ Git repository formats supported:
 
 Examples:
   plonk clone user/dotfiles              # Clone and auto-detect managers
-  plonk clone richhaase/dotfiles         # Clone specific user's dotfiles`,
+  plonk clone richhaase/dotfiles         # Clone specific user's dotfiles
+  plonk clone --no-interactive user/dotfiles  # Unattended setup
+  plonk clone --trust allowed_signers user/dotfiles  # Require a signed plonk.lock`,
 	Args:         cobra.ExactArgs(1),
 	RunE:         runClone,
 	SilenceUsage: true,
@@ -42,6 +73,8 @@ Examples:
 
 func init() {
 	cloneCmd.Flags().BoolVarP(&cloneDryRun, "dry-run", "n", false, "Show what would be cloned without making changes")
+	cloneCmd.Flags().BoolVar(&cloneNoInteractive, "no-interactive", false, "Skip setup questions and use the defaults")
+	cloneCmd.Flags().StringVar(&cloneTrust, "trust", "", "Require plonk.lock to be signed by a key in this allowed_signers file or minisign public key")
 
 	rootCmd.AddCommand(cloneCmd)
 }
@@ -53,6 +86,65 @@ func runClone(cmd *cobra.Command, args []string) error {
 	cloneConfig := clone.Config{
 		DryRun: cloneDryRun,
 	}
+	if !cloneNoInteractive && stdinIsTerminal() {
+		reader := bufio.NewReader(cmd.InOrStdin())
+		cloneConfig.Confirm = func(question string, def bool) bool {
+			answer, err := promptYesNo(reader, cmd.ErrOrStderr(), question, def)
+			if err != nil {
+				return def
+			}
+			return answer
+		}
+		cloneConfig.Ask = func(question, def string) string {
+			answer, err := promptLine(reader, cmd.ErrOrStderr(), question, def)
+			if err != nil {
+				return def
+			}
+			return answer
+		}
+		cloneConfig.Resolve = func(c dotfiles.Conflict) dotfiles.Resolution {
+			return promptConflict(reader, cmd.ErrOrStderr(), c)
+		}
+	}
+
+	if cloneTrust != "" && !cloneDryRun {
+		path, err := signing.InstallTrustFile(cloneTrust)
+		if err != nil {
+			return err
+		}
+		output.Printf("Trusting plonk.lock signers from %s\n", path)
+	}
 
 	return clone.CloneAndSetup(ctx, gitRepo, cloneConfig)
 }
+
+// conflictChoices maps the keys of promptConflict to resolutions
+var conflictChoices = map[byte]dotfiles.Resolution{
+	't': dotfiles.ResolveTakeRepo,
+	'k': dotfiles.ResolveKeepLocal,
+	'm': dotfiles.ResolveMerge,
+	's': dotfiles.ResolveSkip,
+}
+
+// promptConflict asks what to do about a dotfile that already exists with
+// other content; d shows the difference and asks again
+func promptConflict(reader *bufio.Reader, out io.Writer, c dotfiles.Conflict) dotfiles.Resolution {
+	keys, labels := "tkmsd", "[t]ake repo, [k]eep local, [m]erge, [s]kip, [d]iff"
+	if c.Binary {
+		keys, labels = "tksd", "[t]ake repo, [k]eep local, [s]kip, [d]iff"
+	}
+	for {
+		key, err := promptChoice(reader, out, fmt.Sprintf("%s already exists with other content: %s?", c.Target, labels), keys, 's')
+		if err != nil {
+			return dotfiles.ResolveSkip
+		}
+		if key == 'd' {
+			fmt.Fprintln(out, c.Diff)
+			continue
+		}
+		if key == 'm' {
+			fmt.Fprintf(out, "Resolve the conflict markers in %s, then run 'plonk add %s' to store the result.\n", c.Target, c.Target)
+		}
+		return conflictChoices[key]
+	}
+}
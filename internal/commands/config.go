@@ -14,7 +14,8 @@ var configCmd = &cobra.Command{
 
 Commands:
   show      Display current configuration
-  edit      Edit configuration file`,
+  edit      Edit configuration file
+  validate  Check that plonk.yaml loads`,
 }
 
 func init() {
@@ -32,6 +32,7 @@ This command works like visudo:
 - Supports edit/revert/quit on validation errors
 
 Only values that differ from defaults are saved to keep your config minimal.
+Configs that use include: are not supported; edit those files directly.
 
 Examples:
   plonk config edit               # Edit configuration file
@@ -53,6 +54,11 @@ func runConfigEdit(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create config directory: %w", err)
 	}
 
+	// Saving the merged config would copy included settings into plonk.yaml
+	if cfg, err := config.Load(configDir); err == nil && len(cfg.Include) > 0 {
+		return fmt.Errorf("plonk.yaml includes %s; edit the files directly so included settings stay separate", strings.Join(cfg.Include, ", "))
+	}
+
 	return editConfigVisudoStyle(cmd.Context(), configDir)
 }
 
@@ -137,7 +143,7 @@ func openInEditor(editor, filename string) error {
 // createTempConfigFile creates a temp file with the merged runtime config
 func createTempConfigFile(configDir string) (string, error) {
 	configPath := getConfigPath(configDir)
-	cfg, loadErr := config.LoadFromPath(configPath)
+	cfg, loadErr := config.LoadUnexpanded(configPath)
 	useRaw := false
 
 	// Create temp file
@@ -0,0 +1,44 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/richhaase/plonk/internal/config"
+	"github.com/richhaase/plonk/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check that plonk.yaml loads",
+	Long: `Load plonk.yaml and the files it includes and check every setting,
+exiting non-zero with the problem if anything is wrong. A missing
+plonk.yaml is valid: plonk uses its defaults.
+
+Examples:
+  plonk config validate`,
+	RunE:         runConfigValidate,
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+}
+
+func init() {
+	configCmd.AddCommand(configValidateCmd)
+}
+
+func runConfigValidate(cmd *cobra.Command, args []string) error {
+	configPath := getConfigPath(config.GetDefaultConfigDirectory())
+	if _, err := config.LoadFromPath(configPath); err != nil {
+		return fmt.Errorf("%s is invalid: %w", configPath, err)
+	}
+	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+		output.Printf("%s No plonk.yaml; using defaults\n", output.IconInfo)
+		return nil
+	}
+	output.Printf("%s %s is valid\n", output.IconSuccess, configPath)
+	return nil
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package commands
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/richhaase/plonk/internal/config"
+	"github.com/richhaase/plonk/internal/gitops"
+	"github.com/richhaase/plonk/internal/lock"
+	"github.com/richhaase/plonk/internal/output"
+	"github.com/richhaase/plonk/internal/packages"
+	"github.com/spf13/cobra"
+)
+
+var dedupeCmd = &cobra.Command{
+	Use:   "dedupe",
+	Short: "Keep one copy of packages installed by several managers",
+	Long: `Resolve packages that 'plonk status' reports as installed by more than
+one manager (e.g. ripgrep from both brew and cargo).
+
+For each one, the copy that runs from PATH is kept, or the copy from the
+manager given with --keep. plonk.lock is updated to track the kept copy,
+and the commands that remove the redundant copies are printed. plonk does
+not uninstall packages itself; run the printed commands to finish.
+
+Examples:
+  plonk dedupe --dry-run
+  plonk dedupe
+  plonk dedupe --keep cargo`,
+	RunE:         runDedupe,
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+}
+
+func init() {
+	rootCmd.AddCommand(dedupeCmd)
+	dedupeCmd.Flags().String("keep", "", "Keep the copy from this manager instead of the one on PATH")
+	dedupeCmd.Flags().BoolP("dry-run", "n", false, "Show what would change without writing plonk.lock")
+}
+
+func runDedupe(cmd *cobra.Command, args []string) error {
+	keepManager, _ := cmd.Flags().GetString("keep")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	ctx := cmd.Context()
+	configDir := config.GetDefaultConfigDirectory()
+	packages.Configure(config.LoadWithDefaults(configDir))
+
+	lockSvc := lock.NewLockV3Service(configDir)
+	lockFile, err := lockSvc.Read()
+	if err != nil {
+		return fmt.Errorf("failed to read lock file: %w", err)
+	}
+
+	dups := packages.FindDuplicates(ctx, lockFile)
+	if len(dups) == 0 {
+		output.Println("No packages are installed by more than one manager")
+		return nil
+	}
+
+	var lockChanged bool
+	var removals []string
+	for _, d := range dups {
+		copies := append([]string{d.Tracked}, d.Also...)
+		keep, problem := keptCopy(copies, d.Winner, keepManager)
+		if keep == "" {
+			output.Printf("  %s %s: %s\n", output.IconWarning, d.Tracked, problem)
+			continue
+		}
+
+		output.Printf("  %s: keeping %s\n", d.Tracked, keep)
+		for _, spec := range copies {
+			if spec == keep {
+				continue
+			}
+			manager, name, _ := strings.Cut(spec, ":")
+			if lockFile.HasPackage(manager, name) {
+				lockFile.RemovePackage(manager, name)
+				lockChanged = true
+			}
+			if uninstall := packages.UninstallCommand(spec); uninstall != "" {
+				removals = append(removals, uninstall)
+			} else {
+				removals = append(removals, "# remove "+spec+" with its manager")
+			}
+		}
+		if manager, name, _ := strings.Cut(keep, ":"); !lockFile.HasPackage(manager, name) {
+			lockFile.AddPackage(manager, name)
+			lockChanged = true
+		}
+	}
+
+	if lockChanged && dryRun {
+		output.Println("Dry run: plonk.lock not changed")
+	}
+	if lockChanged && !dryRun {
+		if err := lockSvc.Write(lockFile); err != nil {
+			return fmt.Errorf("failed to write lock file: %w", err)
+		}
+		gitops.AutoCommit(ctx, configDir, "dedupe", nil)
+	}
+	if len(removals) > 0 {
+		slices.Sort(removals)
+		output.Println("\nTo remove the redundant copies, run:")
+		for _, r := range slices.Compact(removals) {
+			output.Printf("  %s\n", r)
+		}
+	}
+	return nil
+}
+
+// keptCopy picks which of copies to keep: the one from keepManager when
+// given, else winner, the copy on PATH. When there is none it returns why.
+func keptCopy(copies []string, winner, keepManager string) (string, string) {
+	if keepManager == "" {
+		if winner == "" {
+			return "", fmt.Sprintf("cannot tell which copy to keep (%s); use --keep", strings.Join(copies, ", "))
+		}
+		return winner, ""
+	}
+
+	managers := make([]string, 0, len(copies))
+	for _, spec := range copies {
+		manager, _, _ := strings.Cut(spec, ":")
+		if manager == keepManager {
+			return spec, ""
+		}
+		managers = append(managers, manager)
+	}
+	return "", fmt.Sprintf("--keep %s matches no copy (candidates: %s)", keepManager, strings.Join(managers, ", "))
+}
@@ -0,0 +1,33 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeptCopy(t *testing.T) {
+	copies := []string{"brew:ripgrep", "cargo:ripgrep"}
+	tests := []struct {
+		name        string
+		winner      string
+		keepManager string
+		want        string
+		problem     string
+	}{
+		{"copy on PATH", "cargo:ripgrep", "", "cargo:ripgrep", ""},
+		{"--keep overrides PATH", "cargo:ripgrep", "brew", "brew:ripgrep", ""},
+		{"nothing on PATH", "", "", "", "cannot tell which copy to keep (brew:ripgrep, cargo:ripgrep); use --keep"},
+		{"--keep matches nothing", "cargo:ripgrep", "pipx", "", "--keep pipx matches no copy (candidates: brew, cargo)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keep, problem := keptCopy(copies, tt.winner, tt.keepManager)
+			assert.Equal(t, tt.want, keep)
+			assert.Equal(t, tt.problem, problem)
+		})
+	}
+}
@@ -0,0 +1,11 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+//go:build !unix
+
+package commands
+
+import "os/exec"
+
+// detach is a no-op where there are no sessions to leave
+func detach(cmd *exec.Cmd) {}
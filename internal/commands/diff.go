@@ -24,6 +24,7 @@ var diffCmd = &cobra.Command{
 
 With no arguments, shows diffs for all drifted dotfiles.
 With a file argument, shows diff for that specific file only.
+Binary files are compared by size and SHA-256 instead of diffed.
 
 Examples:
   plonk diff                # Show all drifted files
@@ -81,6 +82,18 @@ func runDiff(cmd *cobra.Command, args []string) error {
 		sourcePath := status.Source
 		destPath := status.Target
 
+		// Binary files are compared by hash; a text diff of them is noise
+		if binary, err := dm.IsBinary(status.Dotfile); err == nil && binary {
+			summary, err := dm.Diff(status.Dotfile)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error comparing %s: %v\n", status.Name, err)
+				diffErrors = append(diffErrors, status.Name)
+				continue
+			}
+			output.Printf("%s", summary)
+			continue
+		}
+
 		// For template files, render to a temp file so the external diff tool
 		// sees rendered content instead of raw {{VAR}} placeholders
 		if strings.HasSuffix(status.Name, ".tmpl") {
@@ -39,9 +39,14 @@ Examples:
 
 func init() {
 	rootCmd.AddCommand(doctorCmd)
+	addSchemaFlag(doctorCmd)
 }
 
 func runDoctor(cmd *cobra.Command, args []string) error {
+	if handled, err := printSchemaIfRequested(cmd, "doctor"); handled {
+		return err
+	}
+
 	// Build a context with configured operation timeout
 	configDir := config.GetDefaultConfigDirectory()
 	cfg := config.LoadWithDefaults(configDir)
@@ -15,10 +15,14 @@ import (
 
 // convertDotfileStatusToOutput converts []dotfiles.DotfileStatus to separate managed, missing, and error slices.
 // Drifted items are included in managed with StateDegraded state.
-func convertDotfileStatusToOutput(statuses []dotfiles.DotfileStatus) (managed, missing, errors []output.Item) {
+func convertDotfileStatusToOutput(statuses []dotfiles.DotfileStatus, homeDir string) (managed, missing, errors []output.Item) {
 	for _, s := range statuses {
-		// Use the target filename for display (strips .tmpl and adds dot prefix)
+		// Use the target path under $HOME for display, e.g. ".zshrc" for
+		// zshrc.tmpl in the flat layout
 		displayName := "." + strings.TrimSuffix(s.Name, ".tmpl")
+		if rel, err := filepath.Rel(homeDir, s.Target); err == nil {
+			displayName = rel
+		}
 		item := output.Item{
 			Name: displayName,
 			Path: s.Target,
@@ -37,6 +41,11 @@ func convertDotfileStatusToOutput(statuses []dotfiles.DotfileStatus) (managed, m
 			missing = append(missing, item)
 		case dotfiles.SyncStateDrifted:
 			item.State = output.StateDegraded
+			if s.Problem != "" {
+				item.Metadata["drift_reason"] = s.Problem
+			} else if s.KeptLocal {
+				item.Metadata["drift_reason"] = "kept local"
+			}
 			managed = append(managed, item)
 		case dotfiles.SyncStateError:
 			item.State = output.StateError
@@ -79,61 +88,25 @@ func resolveDotfilePath(path, homeDir string) string {
 	return filepath.Join(homeDir, path)
 }
 
-// resolveDotfileName resolves a path to the dotfile name (without leading dot)
-func resolveDotfileName(path, homeDir string) string {
-	absPath := resolveDotfilePath(path, homeDir)
-
-	// Get relative to home
-	rel, err := filepath.Rel(homeDir, absPath)
-	if err != nil {
+// resolveRemovalPath resolves a path given to rm to where the dotfile is
+// deployed. Unlike resolveDotfilePath, this doesn't check file existence
+// because the target in $HOME may not exist (not deployed yet) while the
+// source in $PLONK_DIR does exist.
+func resolveRemovalPath(path, homeDir string) string {
+	if filepath.IsAbs(path) {
 		return path
 	}
-
-	// Strip leading dot from first path component (e.g., ".zshrc" -> "zshrc")
-	// but NOT path traversal sequences like ".." or the current dir "."
-	if len(rel) > 1 && rel[0] == '.' && rel[1] != '.' && rel[1] != os.PathSeparator {
-		rel = rel[1:]
-	}
-
-	return rel
-}
-
-// resolveDotfileNameForRemoval resolves a path to the dotfile name for removal.
-// Unlike resolveDotfilePath, this doesn't check file existence because
-// the target in $HOME may not exist (not deployed yet) while the source
-// in $PLONK_DIR does exist.
-func resolveDotfileNameForRemoval(path, homeDir string) string {
-	var absPath string
-
-	if filepath.IsAbs(path) {
-		absPath = path
-	} else if len(path) > 0 && path[0] == '~' {
+	if len(path) > 0 && path[0] == '~' {
 		if len(path) == 1 {
-			absPath = homeDir
-		} else if path[1] == '/' {
-			absPath = filepath.Join(homeDir, path[2:])
-		} else {
-			absPath = filepath.Join(homeDir, path)
+			return homeDir
+		}
+		if path[1] == '/' {
+			return filepath.Join(homeDir, path[2:])
 		}
-	} else {
-		// For removal, always resolve relative to home (not cwd)
-		// because we're finding which managed dotfile to remove
-		absPath = filepath.Join(homeDir, path)
-	}
-
-	// Get relative to home
-	rel, err := filepath.Rel(homeDir, absPath)
-	if err != nil {
-		return path
-	}
-
-	// Strip leading dot from first path component (e.g., ".zshrc" -> "zshrc")
-	// but NOT path traversal sequences like ".." or the current dir "."
-	if len(rel) > 1 && rel[0] == '.' && rel[1] != '.' && rel[1] != os.PathSeparator {
-		rel = rel[1:]
 	}
-
-	return rel
+	// For removal, always resolve relative to home (not cwd)
+	// because we're finding which managed dotfile to remove
+	return filepath.Join(homeDir, path)
 }
 
 // AddStatus represents the status of an add operation
@@ -164,12 +137,14 @@ type AddResult struct {
 
 // AddOptions configures dotfile addition
 type AddOptions struct {
-	DryRun bool
+	DryRun       bool
+	AllowSecrets bool // add files even if they contain probable secrets
 }
 
 // addDotfiles adds files from $HOME to $PLONK_DIR using DotfileManager
 func addDotfiles(dm *dotfiles.DotfileManager, configDir, homeDir string, paths []string, opts AddOptions) []AddResult {
 	var results []AddResult
+	dm.WithAllowSecrets(opts.AllowSecrets)
 
 	for _, path := range paths {
 		result := AddResult{
@@ -179,8 +154,14 @@ func addDotfiles(dm *dotfiles.DotfileManager, configDir, homeDir string, paths [
 		// Resolve path to absolute
 		absPath := resolveDotfilePath(path, homeDir)
 
-		// Calculate the relative source path (without dot)
-		relPath := resolveDotfileName(absPath, homeDir)
+		// Calculate the relative source path through the dotfile layout
+		relPath, err := dm.SourceName(absPath)
+		if err != nil {
+			result.Status = AddStatusFailed
+			result.Error = err
+			results = append(results, result)
+			continue
+		}
 		sourcePath := filepath.Join(configDir, relPath)
 
 		// Check if already managed (plain or template counterpart)
@@ -277,7 +258,13 @@ func removeDotfiles(dm *dotfiles.DotfileManager, configDir, homeDir string, path
 		}
 
 		// Resolve path to get the name in config dir
-		name := resolveDotfileNameForRemoval(path, homeDir)
+		name, err := dm.SourceName(resolveRemovalPath(path, homeDir))
+		if err != nil {
+			result.Status = RemoveStatusFailed
+			result.Error = err
+			results = append(results, result)
+			continue
+		}
 
 		// Validate the removal target (security checks + existence)
 		// If plain name not found, try template counterpart (e.g., "zshrc" -> "zshrc.tmpl")
@@ -289,7 +276,7 @@ func removeDotfiles(dm *dotfiles.DotfileManager, configDir, homeDir string, path
 				} else {
 					result.Status = RemoveStatusSkipped
 					result.Source = name
-					result.Destination = toTargetPath(name, homeDir)
+					result.Destination = dm.TargetPath(name)
 					results = append(results, result)
 					continue
 				}
@@ -304,7 +291,7 @@ func removeDotfiles(dm *dotfiles.DotfileManager, configDir, homeDir string, path
 		if opts.DryRun {
 			result.Status = RemoveStatusWouldRemove
 			result.Source = name
-			result.Destination = toTargetPath(name, homeDir)
+			result.Destination = dm.TargetPath(name)
 		} else {
 			err := dm.Remove(name)
 			if err != nil {
@@ -313,7 +300,7 @@ func removeDotfiles(dm *dotfiles.DotfileManager, configDir, homeDir string, path
 			} else {
 				result.Status = RemoveStatusRemoved
 				result.Source = name
-				result.Destination = toTargetPath(name, homeDir)
+				result.Destination = dm.TargetPath(name)
 			}
 		}
 
@@ -322,10 +309,3 @@ func removeDotfiles(dm *dotfiles.DotfileManager, configDir, homeDir string, path
 
 	return results
 }
-
-// toTargetPath converts a source name to its target path in home
-// e.g., "zshrc" -> "/home/user/.zshrc"
-// e.g., "zshrc.tmpl" -> "/home/user/.zshrc"
-func toTargetPath(name, homeDir string) string {
-	return filepath.Join(homeDir, "."+strings.TrimSuffix(name, ".tmpl"))
-}
@@ -14,7 +14,7 @@ import (
 
 var dotfilesCmd = &cobra.Command{
 	Use:     "dotfiles",
-	Aliases: []string{"d"},
+	Aliases: []string{"d", "dot"},
 	Short:   "Display dotfile status",
 	Long: `Display the status of all plonk-managed dotfiles.
 
@@ -24,17 +24,28 @@ Shows:
 - Drifted dotfiles (modified after deployment)
 
 Examples:
-  plonk dotfiles    # Show all managed dotfiles
-  plonk d           # Short alias`,
+  plonk dotfiles                   # Show all managed dotfiles
+  plonk dotfiles --state drifted   # Only dotfiles modified after deployment
+  plonk d --sort state             # Short alias, sorted by state`,
 	RunE:         runDotfiles,
 	SilenceUsage: true,
 }
 
 func init() {
 	rootCmd.AddCommand(dotfilesCmd)
+	addSchemaFlag(dotfilesCmd)
+	addListFlags(dotfilesCmd, false)
 }
 
 func runDotfiles(cmd *cobra.Command, args []string) error {
+	if handled, err := printSchemaIfRequested(cmd, "dotfiles"); handled {
+		return err
+	}
+	opts, err := listOptions(cmd)
+	if err != nil {
+		return err
+	}
+
 	// Get directories
 	homeDir, err := config.GetHomeDir()
 	if err != nil {
@@ -55,7 +66,7 @@ func runDotfiles(cmd *cobra.Command, args []string) error {
 	}
 
 	// Separate by state and convert to output format
-	managed, missing, errors := convertDotfileStatusToOutput(statuses)
+	managed, missing, errors := convertDotfileStatusToOutput(statuses, homeDir)
 
 	outputResult := output.Result{
 		Domain:  "dotfile",
@@ -68,6 +79,7 @@ func runDotfiles(cmd *cobra.Command, args []string) error {
 	outputData := output.DotfilesStatusOutput{
 		RemoteSync: remoteSync,
 		Result:     outputResult,
+		Options:    opts,
 		ConfigDir:  configDir,
 		HomeDir:    homeDir,
 	}
@@ -0,0 +1,92 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package commands
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/richhaase/plonk/internal/config"
+	"github.com/richhaase/plonk/internal/dotfiles"
+	"github.com/richhaase/plonk/internal/gitops"
+	"github.com/richhaase/plonk/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var dotfilesLayoutCmd = &cobra.Command{
+	Use:   "layout [flat|mirrored-home|custom]",
+	Short: "Show or convert how dotfiles are arranged in the plonk directory",
+	Long: `Show the dotfile layout, or move the dotfiles into another one.
+
+Layouts:
+  flat           zshrc -> ~/.zshrc, config/nvim/init.lua -> ~/.config/nvim/init.lua
+                 (the default: the leading dot is dropped)
+  mirrored-home  home/.zshrc -> ~/.zshrc: the plonk directory holds a copy of
+                 $HOME under home/
+  custom         dotfiles.map in plonk.yaml names each source and its target
+
+Converting moves the files and records the layout in plonk.yaml (dotfiles.layout).
+Converting to custom moves nothing and writes a map of where every file is now,
+which you can then rearrange.
+
+Examples:
+  plonk dotfiles layout                          # Show the current layout
+  plonk dotfiles layout mirrored-home --dry-run  # Show what would move
+  plonk dotfiles layout mirrored-home            # Convert`,
+	RunE:         runDotfilesLayout,
+	SilenceUsage: true,
+	Args:         cobra.MaximumNArgs(1),
+}
+
+func init() {
+	dotfilesCmd.AddCommand(dotfilesLayoutCmd)
+	dotfilesLayoutCmd.Flags().BoolP("dry-run", "n", false, "Show what would move without changing anything")
+}
+
+func runDotfilesLayout(cmd *cobra.Command, args []string) error {
+	configDir := config.GetDefaultConfigDirectory()
+	current := dotfiles.LoadLayout(configDir)
+	currentKind := current.Kind
+	if currentKind == "" {
+		currentKind = dotfiles.LayoutFlat
+	}
+
+	if len(args) == 0 {
+		output.Printf("Dotfile layout: %s\n", currentKind)
+		return nil
+	}
+
+	to := args[0]
+	if !slices.Contains(dotfiles.Layouts, to) {
+		return fmt.Errorf("unknown layout %q (use %s)", to, strings.Join(dotfiles.Layouts, ", "))
+	}
+	if to == currentKind {
+		output.Printf("Dotfiles already use the %s layout\n", to)
+		return nil
+	}
+
+	homeDir, err := config.GetHomeDir()
+	if err != nil {
+		return fmt.Errorf("cannot determine home directory: %w", err)
+	}
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	cfg := config.LoadWithDefaults(configDir)
+
+	moves, err := dotfiles.MigrateLayout(configDir, homeDir, cfg.IgnorePatterns, dotfiles.Layout{Kind: to}, dryRun)
+	if err != nil {
+		return fmt.Errorf("cannot convert to the %s layout: %w", to, err)
+	}
+	for _, move := range moves {
+		output.Printf("  %s -> %s\n", move.From, move.To)
+	}
+
+	if dryRun {
+		output.Printf("Dry run: would move %d files into the %s layout\n", len(moves), to)
+		return nil
+	}
+	output.Printf("%s Moved %d files into the %s layout\n", output.Success(), len(moves), to)
+	gitops.AutoCommit(cmd.Context(), configDir, "dotfiles layout", args)
+	return nil
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/richhaase/plonk/internal/config"
+	"github.com/richhaase/plonk/internal/dotfiles"
+	"github.com/richhaase/plonk/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var dotfilesLsCmd = &cobra.Command{
+	Use:   "ls",
+	Short: "List dotfiles with their sources, targets and modes",
+	Long: `List every managed dotfile: where its source is in the plonk directory,
+where it is deployed, the mode of the deployed file, its state (deployed,
+missing, drifted or error) and when it was last applied.
+
+The applied time is when the deployed file was last written; it is shown
+only for dotfiles that are in sync, since editing a drifted file changes it.
+
+Examples:
+  plonk dotfiles ls          # Table of all dotfiles
+  plonk dot ls --tree        # Sources as a directory tree
+  plonk dot ls -o json       # For scripts`,
+	RunE:         runDotfilesLs,
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+}
+
+func init() {
+	dotfilesCmd.AddCommand(dotfilesLsCmd)
+	dotfilesLsCmd.Flags().Bool("tree", false, "Show sources as a directory tree")
+}
+
+func runDotfilesLs(cmd *cobra.Command, args []string) error {
+	tree, _ := cmd.Flags().GetBool("tree")
+
+	homeDir, err := config.GetHomeDir()
+	if err != nil {
+		return fmt.Errorf("cannot determine home directory: %w", err)
+	}
+	configDir := config.GetDefaultConfigDirectory()
+	cfg := config.LoadWithDefaults(configDir)
+
+	dm := dotfiles.NewDotfileManager(configDir, homeDir, cfg.IgnorePatterns)
+	statuses, err := dm.Reconcile()
+	if err != nil {
+		return err
+	}
+
+	entries := make([]output.DotfilesLsEntry, 0, len(statuses))
+	for _, s := range statuses {
+		entries = append(entries, dotfilesLsEntry(s, configDir))
+	}
+
+	output.RenderOutput(output.DotfilesLsOutput{
+		ConfigDir: configDir,
+		Dotfiles:  entries,
+		HomeDir:   homeDir,
+		Tree:      tree,
+	})
+	return nil
+}
+
+// dotfilesLsEntry describes s for plonk dotfiles ls, reading the mode and
+// modification time of the deployed file
+func dotfilesLsEntry(s dotfiles.DotfileStatus, configDir string) output.DotfilesLsEntry {
+	entry := output.DotfilesLsEntry{
+		Source: filepath.ToSlash(s.Name),
+		Target: s.Target,
+	}
+	if rel, err := filepath.Rel(configDir, s.Source); err == nil {
+		entry.Source = filepath.ToSlash(rel)
+	}
+
+	switch s.State {
+	case dotfiles.SyncStateManaged:
+		entry.State = "deployed"
+	case dotfiles.SyncStateMissing:
+		entry.State = "missing"
+	case dotfiles.SyncStateDrifted:
+		entry.State = "drifted"
+	default:
+		entry.State = "error"
+	}
+
+	info, err := os.Stat(s.Target)
+	if err != nil {
+		return entry
+	}
+	entry.Mode = fmt.Sprintf("%04o", info.Mode().Perm())
+	if s.State == dotfiles.SyncStateManaged {
+		applied := info.ModTime()
+		entry.AppliedAt = &applied
+	}
+	return entry
+}
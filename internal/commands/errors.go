@@ -0,0 +1,84 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/richhaase/plonk/internal/output"
+	"github.com/richhaase/plonk/internal/packages"
+	"github.com/spf13/cobra"
+)
+
+// handleFlagError explains `--<manager>` style flags. Managers are selected
+// with a manager:package prefix, so `plonk track --apt vim` would otherwise
+// only report "unknown flag".
+func handleFlagError(cmd *cobra.Command, err error) error {
+	// Flag parsing fails before PersistentPreRunE, so honor -o here too
+	if formatStr, ferr := cmd.Flags().GetString("output"); ferr == nil {
+		if format, perr := output.ParseOutputFormat(formatStr); perr == nil {
+			output.SetOutputFormat(format)
+		}
+	}
+
+	name, ok := strings.CutPrefix(err.Error(), "unknown flag: --")
+	if !ok || !packages.IsKnownManager(name) {
+		return err
+	}
+
+	usage := fmt.Sprintf("managers are selected with a prefix, not a flag: use %s:<package> instead of --%s", name, name)
+	if availErr := packages.CheckManagerAvailable(name); availErr != nil {
+		return fmt.Errorf("%s: %w", usage, availErr)
+	}
+	return errors.New(usage)
+}
+
+// newErrorOutput builds the structured form of a command error
+func newErrorOutput(err error) output.ErrorOutput {
+	out := output.ErrorOutput{Error: err.Error()}
+
+	var unavailable *packages.UnavailableManagerError
+	var pkgErr *packages.PackageError
+	switch {
+	case errors.As(err, &unavailable):
+		out.ErrorClass = packages.ClassManagerUnavailable
+		out.Manager = unavailable.Manager
+		out.Hint = unavailable.Hint
+	case errors.As(err, &pkgErr):
+		out.ErrorClass = packages.ErrorClass(err)
+		out.Manager = pkgErr.Manager
+		out.Package = pkgErr.Package
+	}
+	return out
+}
+
+// batchError reports a per-item summary while keeping the individual
+// failures reachable through errors.Is/As
+type batchError struct {
+	summary string
+	errs    []error
+}
+
+func (e *batchError) Error() string {
+	return e.summary
+}
+
+func (e *batchError) Unwrap() []error {
+	return e.errs
+}
+
+// pendingChangesError reports that a --check run found work to do. It exits
+// with status 2 so CI can tell drift apart from failures, which exit 1.
+type pendingChangesError struct {
+	count int
+}
+
+func (e *pendingChangesError) Error() string {
+	if e.count == 1 {
+		return "1 change pending"
+	}
+	return fmt.Sprintf("%d changes pending", e.count)
+}
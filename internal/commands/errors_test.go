@@ -0,0 +1,42 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/richhaase/plonk/internal/output"
+	"github.com/richhaase/plonk/internal/packages"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandleFlagError_ManagerFlag(t *testing.T) {
+	t.Cleanup(func() { output.SetOutputFormat(output.OutputTable) })
+	cmd := &cobra.Command{}
+
+	err := handleFlagError(cmd, errors.New("unknown flag: --apt"))
+	assert.Contains(t, err.Error(), "use apt:<package> instead of --apt")
+	assert.True(t, errors.Is(err, packages.ErrManagerUnavailable))
+}
+
+func TestHandleFlagError_OtherFlag(t *testing.T) {
+	original := errors.New("unknown flag: --frobnicate")
+	assert.Equal(t, original, handleFlagError(&cobra.Command{}, original))
+}
+
+func TestNewErrorOutput(t *testing.T) {
+	err := &batchError{
+		summary: "tracked 0, skipped 0, failed 1",
+		errs:    []error{fmt.Errorf("apt:vim: %w", packages.UnsupportedManagerError("apt"))},
+	}
+
+	out := newErrorOutput(err)
+	assert.Equal(t, "tracked 0, skipped 0, failed 1", out.Error)
+	assert.Equal(t, packages.ClassManagerUnavailable, out.ErrorClass)
+	assert.Equal(t, "apt", out.Manager)
+	assert.NotEmpty(t, out.Hint)
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package commands
+
+import (
+	"fmt"
+
+	"github.com/richhaase/plonk/internal/config"
+	"github.com/richhaase/plonk/internal/output"
+	"github.com/richhaase/plonk/internal/state"
+	"github.com/spf13/cobra"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export plonk state for other tools",
+	Long: `Export what plonk manages in a format other tools can read.
+
+Commands:
+  state     Packages and dotfiles as a JSON (or YAML) state document
+  nix       Packages as a home-manager module or flake`,
+}
+
+var exportStateCmd = &cobra.Command{
+	Use:   "state",
+	Short: "Export packages and dotfiles as a state document",
+	Long: `Write the tracked packages and managed dotfiles as a state document.
+The format is documented under "State Format" in docs/reference.md and can
+be read back with 'plonk import state'.
+
+Dotfiles are described by path, mode and sha256. Use --with-content to embed
+their contents too, making the document self-contained.
+
+Examples:
+  plonk export state -o json > state.json
+  plonk export state -o json --with-content > state.json
+  plonk export state              # Human-readable listing`,
+	RunE:         runExportState,
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+	exportCmd.AddCommand(exportStateCmd)
+
+	exportStateCmd.Flags().Bool("with-content", false, "Embed dotfile contents in the document")
+}
+
+func runExportState(cmd *cobra.Command, args []string) error {
+	withContent, _ := cmd.Flags().GetBool("with-content")
+
+	configDir := config.GetDefaultConfigDirectory()
+	homeDir, err := config.GetHomeDir()
+	if err != nil {
+		return fmt.Errorf("cannot determine home directory: %w", err)
+	}
+	cfg := config.LoadWithDefaults(configDir)
+
+	doc, err := state.Export(configDir, homeDir, cfg.IgnorePatterns, withContent)
+	if err != nil {
+		return err
+	}
+
+	result := output.StateExportOutput{Document: doc}
+	for _, p := range doc.Packages {
+		result.Packages = append(result.Packages, p.Manager+":"+p.Name)
+	}
+	for _, d := range doc.Dotfiles {
+		result.Dotfiles = append(result.Dotfiles, output.StateDotfileRow{Source: d.Source, Target: d.Target})
+	}
+	output.RenderOutput(result)
+	return nil
+}
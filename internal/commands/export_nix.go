@@ -0,0 +1,114 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package commands
+
+import (
+	"fmt"
+	"os/user"
+	"runtime"
+	"sort"
+
+	"github.com/richhaase/plonk/internal/config"
+	"github.com/richhaase/plonk/internal/lock"
+	"github.com/richhaase/plonk/internal/nix"
+	"github.com/richhaase/plonk/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var exportNixCmd = &cobra.Command{
+	Use:   "nix",
+	Short: "Export packages as a home-manager module or flake",
+	Long: `Write plonk.lock as a Nix home-manager module (default) or a standalone
+flake, with home.packages listing the nixpkgs attribute for each package.
+
+Brew formulae, cargo crates and uv tools map to the nixpkgs package of the
+same name, apart from a few known renames (e.g. fd-find -> fd); pnpm
+packages map to nodePackages.<name> and go packages to their binary name.
+Packages with no mapping (taps, scoped npm packages, plugin managers) are
+listed in a comment. Dotfiles are not exported.
+
+The mapping is best effort: check the result against nixpkgs before
+relying on it.
+
+Examples:
+  plonk export nix > home.nix
+  plonk export nix --flake > flake.nix
+  plonk export nix --flake --system aarch64-darwin
+  plonk export nix -o json             # Mapping as JSON`,
+	RunE:         runExportNix,
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+}
+
+func init() {
+	exportCmd.AddCommand(exportNixCmd)
+
+	exportNixCmd.Flags().Bool("flake", false, "Write a flake with homeConfigurations instead of a module")
+	exportNixCmd.Flags().String("system", nixSystem(runtime.GOOS, runtime.GOARCH), "Nix system for --flake")
+	exportNixCmd.Flags().String("user", "", "User name for --flake (default: current user)")
+}
+
+func runExportNix(cmd *cobra.Command, args []string) error {
+	asFlake, _ := cmd.Flags().GetBool("flake")
+	system, _ := cmd.Flags().GetString("system")
+	userName, _ := cmd.Flags().GetString("user")
+
+	configDir := config.GetDefaultConfigDirectory()
+	lockFile, err := lock.NewLockV3Service(configDir).Read()
+	if err != nil {
+		return fmt.Errorf("failed to read lock file: %w", err)
+	}
+
+	managers := make([]string, 0, len(lockFile.Packages))
+	for manager := range lockFile.Packages {
+		managers = append(managers, manager)
+	}
+	sort.Strings(managers)
+
+	var pkgs []nix.Package
+	result := output.NixExportOutput{Kind: "home-manager", Packages: []output.NixPackage{}}
+	for _, manager := range managers {
+		for _, name := range lockFile.Packages[manager] {
+			attr, _ := nix.Attribute(manager, name)
+			spec := manager + ":" + name
+			pkgs = append(pkgs, nix.Package{Spec: spec, Attribute: attr})
+			result.Packages = append(result.Packages, output.NixPackage{Spec: spec, Attribute: attr})
+		}
+	}
+
+	if !asFlake {
+		result.Nix = nix.HomeManagerModule(pkgs)
+		output.RenderOutput(result)
+		return nil
+	}
+
+	if system == "" {
+		return fmt.Errorf("cannot determine the Nix system for %s/%s; pass --system", runtime.GOOS, runtime.GOARCH)
+	}
+	if userName == "" {
+		current, err := user.Current()
+		if err != nil {
+			return fmt.Errorf("cannot determine user name (use --user): %w", err)
+		}
+		userName = current.Username
+	}
+	homeDir, err := config.GetHomeDir()
+	if err != nil {
+		return fmt.Errorf("cannot determine home directory: %w", err)
+	}
+	result.Kind = "flake"
+	result.Nix = nix.Flake(pkgs, userName, homeDir, system)
+	output.RenderOutput(result)
+	return nil
+}
+
+// nixSystem returns the Nix system double for a Go platform, or "" if Nix
+// does not support it
+func nixSystem(goos, goarch string) string {
+	arch := map[string]string{"amd64": "x86_64", "arm64": "aarch64"}[goarch]
+	if arch == "" || (goos != "linux" && goos != "darwin") {
+		return ""
+	}
+	return arch + "-" + goos
+}
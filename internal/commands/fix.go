@@ -0,0 +1,96 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package commands
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/richhaase/plonk/internal/config"
+	"github.com/richhaase/plonk/internal/gitops"
+	"github.com/richhaase/plonk/internal/lock"
+	"github.com/richhaase/plonk/internal/output"
+	"github.com/richhaase/plonk/internal/packages"
+	"github.com/spf13/cobra"
+)
+
+var fixCmd = &cobra.Command{
+	Use:   "fix",
+	Short: "Repair plonk.lock entries that no longer match their manager",
+	Long: `Update plonk.lock for changes made by package managers.
+
+With --renames, every tracked package is checked for a new name: packages
+the manager renamed (e.g. a Homebrew formula rename) and packages that are
+deprecated or disabled with a named replacement (exa -> eza) are replaced
+in plonk.lock by their new name. Run 'plonk apply' afterwards to install
+anything the new names are missing. Renames are currently detected for
+Homebrew.
+
+Examples:
+  plonk fix --renames --dry-run    # Show what would change
+  plonk fix --renames && plonk apply`,
+	RunE:         runFix,
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+}
+
+func init() {
+	rootCmd.AddCommand(fixCmd)
+	fixCmd.Flags().Bool("renames", false, "Replace renamed or retired packages with their new names")
+	fixCmd.Flags().BoolP("dry-run", "n", false, "Show what would change without writing plonk.lock")
+}
+
+func runFix(cmd *cobra.Command, args []string) error {
+	renames, _ := cmd.Flags().GetBool("renames")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	if !renames {
+		return fmt.Errorf("nothing to fix: choose what to repair, e.g. --renames")
+	}
+
+	ctx := cmd.Context()
+	configDir := config.GetDefaultConfigDirectory()
+	packages.Configure(config.LoadWithDefaults(configDir))
+
+	lockSvc := lock.NewLockV3Service(configDir)
+	lockFile, err := lockSvc.Read()
+	if err != nil {
+		return fmt.Errorf("failed to read lock file: %w", err)
+	}
+
+	managers := make([]string, 0, len(lockFile.Packages))
+	for manager := range lockFile.Packages {
+		managers = append(managers, manager)
+	}
+	sort.Strings(managers)
+
+	var changed int
+	for _, manager := range managers {
+		found, err := packages.DetectRenames(ctx, manager, lockFile.GetPackages(manager))
+		if err != nil {
+			return fmt.Errorf("failed to check %s packages for renames: %w", manager, err)
+		}
+		for _, r := range found {
+			output.Printf("  %s:%s -> %s:%s (%s)\n", r.Manager, r.Old, r.Manager, r.New, r.Reason)
+			lockFile.RemovePackage(r.Manager, r.Old)
+			lockFile.AddPackage(r.Manager, r.New)
+			changed++
+		}
+	}
+
+	switch {
+	case changed == 0:
+		output.Println("No renamed packages found")
+		return nil
+	case dryRun:
+		output.Printf("Dry run: would update %d packages in plonk.lock\n", changed)
+		return nil
+	}
+
+	if err := lockSvc.Write(lockFile); err != nil {
+		return fmt.Errorf("failed to write lock file: %w", err)
+	}
+	output.Printf("%s Updated %d packages in plonk.lock; run 'plonk apply' to install them\n", output.Success(), changed)
+	gitops.AutoCommit(ctx, configDir, "fix --renames", nil)
+	return nil
+}
@@ -0,0 +1,150 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/richhaase/plonk/internal/config"
+	"github.com/richhaase/plonk/internal/fleet"
+	"github.com/richhaase/plonk/internal/gitops"
+	"github.com/richhaase/plonk/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var fleetCmd = &cobra.Command{
+	Use:   "fleet",
+	Short: "Track status across machines sharing this config",
+	Long: `Share status reports between machines that use the same plonk repository.
+
+Each machine records a report in $PLONK_DIR/.fleet/<host>.json, which is
+committed and travels with 'plonk push' and 'plonk pull' like the rest of
+the config. Reports are never deployed as dotfiles.
+
+Commands:
+  report    Record this machine's status
+  status    Show which machines are out of sync`,
+}
+
+var fleetReportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Record this machine's status for the fleet",
+	Long: `Reconcile this machine and record the result in $PLONK_DIR/.fleet/<host>.json.
+The report is auto-committed when git.auto_commit is enabled; run
+'plonk push' to share it.
+
+Pair with 'plonk schedule install' or a cron job to keep reports fresh.
+
+Examples:
+  plonk fleet report && plonk push
+  plonk fleet report --host work-laptop`,
+	RunE:         runFleetReport,
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+}
+
+var fleetStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show which machines are out of sync",
+	Long: `Show the latest report from every machine. A host is out of sync if it
+reported missing, drifted or failing items, or if its report predates the
+current plonk.lock (the lock changed since that machine last checked in).
+
+Run 'plonk pull' first to fetch other machines' reports.
+
+Examples:
+  plonk pull && plonk fleet status
+  plonk fleet status -o json`,
+	RunE:         runFleetStatus,
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+}
+
+func init() {
+	rootCmd.AddCommand(fleetCmd)
+	fleetCmd.AddCommand(fleetReportCmd)
+	fleetCmd.AddCommand(fleetStatusCmd)
+
+	fleetReportCmd.Flags().String("host", "", "Host name to report as (default: system hostname)")
+}
+
+func runFleetReport(cmd *cobra.Command, args []string) error {
+	host, _ := cmd.Flags().GetString("host")
+	if host == "" {
+		var err error
+		if host, err = os.Hostname(); err != nil {
+			return fmt.Errorf("cannot determine hostname (use --host): %w", err)
+		}
+	}
+
+	homeDir, err := config.GetHomeDir()
+	if err != nil {
+		return fmt.Errorf("cannot determine home directory: %w", err)
+	}
+	configDir := config.GetDefaultConfigDirectory()
+	cfg := config.LoadWithDefaults(configDir)
+
+	ctx := cmd.Context()
+	summary, err := collectStatusSummary(ctx, configDir, homeDir, cfg, 0)
+	if err != nil {
+		return err
+	}
+	digest, err := fleet.LockDigest(configDir)
+	if err != nil {
+		return err
+	}
+
+	report := fleet.NewReport(host, digest, summary)
+	path, err := fleet.Write(configDir, report)
+	if err != nil {
+		return err
+	}
+	output.Printf("Recorded fleet report for %s (%d out of sync) in %s\n", report.Host, report.Counts.OutOfSync(), path)
+
+	gitops.AutoCommit(ctx, configDir, "fleet report", []string{report.Host})
+	return nil
+}
+
+func runFleetStatus(cmd *cobra.Command, args []string) error {
+	configDir := config.GetDefaultConfigDirectory()
+
+	digest, err := fleet.LockDigest(configDir)
+	if err != nil {
+		return err
+	}
+	reports, warnings := fleet.ReadAll(configDir)
+	for _, w := range warnings {
+		output.Printf("Warning: %v\n", w)
+	}
+
+	result := buildFleetStatus(reports, digest)
+	output.RenderOutput(result)
+	return nil
+}
+
+// buildFleetStatus compares each report against the current lock file digest
+func buildFleetStatus(reports []fleet.Report, lockDigest string) output.FleetStatusOutput {
+	result := output.FleetStatusOutput{
+		Hosts:      make([]output.FleetHost, 0, len(reports)),
+		TotalHosts: len(reports),
+	}
+	for _, r := range reports {
+		h := output.FleetHost{
+			Host:        r.Host,
+			ReportedAt:  r.ReportedAt,
+			LockCurrent: r.LockDigest == lockDigest,
+			Missing:     r.Counts.Missing,
+			Drifted:     r.Counts.Drifted,
+			Errors:      r.Counts.Errors,
+			OutOfSync:   r.OutOfSync,
+		}
+		h.InSync = h.LockCurrent && r.Counts.OutOfSync() == 0
+		if !h.InSync {
+			result.OutOfSync++
+		}
+		result.Hosts = append(result.Hosts, h)
+	}
+	return result
+}
@@ -0,0 +1,34 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package commands
+
+import (
+	"testing"
+
+	"github.com/richhaase/plonk/internal/fleet"
+	"github.com/richhaase/plonk/internal/output"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildFleetStatus(t *testing.T) {
+	reports := []fleet.Report{
+		{Host: "clean", LockDigest: "current"},
+		{Host: "stale", LockDigest: "old"},
+		{Host: "missing", LockDigest: "current", Counts: output.StatusCounts{Missing: 2}, OutOfSync: []string{"package brew:jq missing"}},
+	}
+
+	result := buildFleetStatus(reports, "current")
+	assert.Equal(t, 3, result.TotalHosts)
+	assert.Equal(t, 2, result.OutOfSync)
+	assert.True(t, result.Hosts[0].InSync)
+	assert.False(t, result.Hosts[1].InSync)
+	assert.False(t, result.Hosts[1].LockCurrent)
+	assert.False(t, result.Hosts[2].InSync)
+
+	table := result.TableOutput()
+	assert.Contains(t, table, "lock changed since report")
+	assert.Contains(t, table, "2 missing, 0 drifted, 0 errors")
+	assert.Contains(t, table, "package brew:jq missing")
+	assert.Contains(t, table, "2 of 3 hosts out of sync")
+}
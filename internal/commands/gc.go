@@ -0,0 +1,162 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/richhaase/plonk/internal/config"
+	"github.com/richhaase/plonk/internal/gitops"
+	"github.com/richhaase/plonk/internal/lock"
+	"github.com/richhaase/plonk/internal/output"
+	"github.com/richhaase/plonk/internal/packages"
+	"github.com/richhaase/plonk/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+var gcCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "Clean package manager caches and plonk's old snapshots and backups",
+	Long: `Free disk space by cleaning up after the package managers plonk.lock uses
+and after plonk itself, then report how much was reclaimed.
+
+Package managers:
+  brew   brew cleanup              old versions and stale downloads
+  cargo  cargo cache --autoclean   registry sources and git checkouts (needs cargo-cache)
+  go     go clean -cache           the build cache; downloaded modules are kept
+  pnpm   pnpm store prune          packages no project references
+  uv     uv cache prune            unused cache entries
+
+plonk's own files:
+  - snapshots beyond the newest --keep-snapshots (default 10)
+  - migration backups in $PLONK_DIR/.backups older than --backup-age
+    (default 90 days); their removal is committed like any other change
+
+Examples:
+  plonk gc --dry-run             # Show what would be cleaned
+  plonk gc
+  plonk gc --keep-snapshots 3 --backup-age 720h`,
+	RunE:         runGC,
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+}
+
+func init() {
+	rootCmd.AddCommand(gcCmd)
+	gcCmd.Flags().BoolP("dry-run", "n", false, "Show what would be cleaned without cleaning")
+	gcCmd.Flags().Int("keep-snapshots", 10, "Number of newest snapshots to keep")
+	gcCmd.Flags().Duration("backup-age", 90*24*time.Hour, "Remove migration backups older than this")
+}
+
+func runGC(cmd *cobra.Command, args []string) error {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	keep, _ := cmd.Flags().GetInt("keep-snapshots")
+	backupAge, _ := cmd.Flags().GetDuration("backup-age")
+	if keep < 0 {
+		return fmt.Errorf("--keep-snapshots must not be negative")
+	}
+
+	ctx := cmd.Context()
+	configDir := config.GetDefaultConfigDirectory()
+	packages.Configure(config.LoadWithDefaults(configDir))
+	result := output.GCOutput{DryRun: dryRun}
+
+	failed := false
+	for _, manager := range gcManagers(configDir) {
+		entry := output.GCManagerResult{Manager: manager, Command: strings.Join(packages.CleanupCommand(manager), " ")}
+		switch err := packages.CheckCleanupAvailable(manager); {
+		case err != nil:
+			entry.Skipped = err.Error()
+		case !dryRun:
+			output.Printf("Running %s...\n", entry.Command)
+			entry.Freed, err = packages.CleanCache(ctx, manager)
+			if err != nil {
+				entry.Error = err.Error()
+				failed = true
+			}
+			result.Freed += entry.Freed
+		}
+		result.Managers = append(result.Managers, entry)
+	}
+
+	snapshots, err := storage.PruneArchives(snapshotDir(), keep, dryRun)
+	for _, s := range snapshots {
+		result.Removed = append(result.Removed, output.GCRemovedFile{Kind: "snapshot", Path: s.Path, Bytes: s.Size})
+		result.Freed += s.Size
+	}
+	if err != nil {
+		return fmt.Errorf("failed to remove snapshots: %w", err)
+	}
+
+	backups, err := pruneBackups(configDir, backupAge, time.Now(), dryRun)
+	for _, b := range backups {
+		result.Removed = append(result.Removed, b)
+		result.Freed += b.Bytes
+	}
+	if err != nil {
+		return fmt.Errorf("failed to remove backups: %w", err)
+	}
+	if len(backups) > 0 && !dryRun {
+		gitops.AutoCommit(ctx, configDir, "gc", nil)
+	}
+
+	output.RenderOutput(result)
+	if failed {
+		return errors.New("some cache cleanups failed")
+	}
+	return nil
+}
+
+// gcManagers returns the enabled managers plonk.lock tracks that plonk
+// knows how to clean
+func gcManagers(configDir string) []string {
+	lockFile, err := lock.NewLockV3Service(configDir).Read()
+	if err != nil {
+		return nil
+	}
+	var managers []string
+	for manager := range lockFile.Packages {
+		if packages.CleanupCommand(manager) != nil && !packages.IsManagerDisabled(manager) {
+			managers = append(managers, manager)
+		}
+	}
+	sort.Strings(managers)
+	return managers
+}
+
+// pruneBackups removes the migration backups in configDir last modified
+// more than maxAge before now and returns them. With dryRun it only
+// returns them.
+func pruneBackups(configDir string, maxAge time.Duration, now time.Time, dryRun bool) ([]output.GCRemovedFile, error) {
+	dir := config.GetBackupDirectory(configDir)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var removed []output.GCRemovedFile
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil || !info.Mode().IsRegular() || now.Sub(info.ModTime()) <= maxAge {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		if !dryRun {
+			if err := os.Remove(path); err != nil {
+				return removed, err
+			}
+		}
+		removed = append(removed, output.GCRemovedFile{Kind: "backup", Path: path, Bytes: info.Size()})
+	}
+	return removed, nil
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/richhaase/plonk/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPruneBackups(t *testing.T) {
+	configDir := t.TempDir()
+	dir := config.GetBackupDirectory(configDir)
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	now := time.Now()
+	for name, age := range map[string]time.Duration{"plonk.lock.v2.bak": 100 * 24 * time.Hour, "plonk.yaml.new.bak": time.Hour} {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte("backup"), 0o644))
+		require.NoError(t, os.Chtimes(path, now.Add(-age), now.Add(-age)))
+	}
+
+	removed, err := pruneBackups(configDir, 90*24*time.Hour, now, true)
+	require.NoError(t, err)
+	require.Len(t, removed, 1)
+	assert.Equal(t, filepath.Join(dir, "plonk.lock.v2.bak"), removed[0].Path)
+	assert.Equal(t, int64(6), removed[0].Bytes)
+	assert.FileExists(t, removed[0].Path, "dry run must not remove backups")
+
+	_, err = pruneBackups(configDir, 90*24*time.Hour, now, false)
+	require.NoError(t, err)
+	assert.NoFileExists(t, filepath.Join(dir, "plonk.lock.v2.bak"))
+	assert.FileExists(t, filepath.Join(dir, "plonk.yaml.new.bak"))
+
+	removed, err = pruneBackups(t.TempDir(), time.Hour, now, false)
+	require.NoError(t, err)
+	assert.Empty(t, removed)
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/richhaase/plonk/internal/config"
+	"github.com/richhaase/plonk/internal/gitops"
+	"github.com/richhaase/plonk/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var hooksCmd = &cobra.Command{
+	Use:   "hooks",
+	Short: "Install git hooks in the plonk repository",
+	Long: `Install git hooks that check the plonk repository before changes are
+committed.
+
+Commands:
+  install-git  Install a pre-commit hook that checks the plonk directory`,
+}
+
+var hooksInstallGitCmd = &cobra.Command{
+	Use:   "install-git",
+	Short: "Install a pre-commit hook that checks the plonk directory",
+	Long: `Install a git pre-commit hook in the plonk directory that runs
+'plonk config validate', 'plonk lock verify' and 'plonk scan secrets' on
+the repository being committed, and stops the commit when any of them
+fails. Broken config or lock files and leaked credentials then never reach
+the remote for other machines to pull.
+
+The hook checks the working tree, runs the plonk on PATH (or this binary
+when there is none) and honors core.hooksPath. Run the command again to
+update a hook plonk installed; an existing hook from elsewhere is only
+replaced with --force. Skip the hook once with 'git commit --no-verify'.
+
+Examples:
+  plonk hooks install-git
+  plonk hooks install-git --force`,
+	RunE:         runHooksInstallGit,
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+}
+
+func init() {
+	rootCmd.AddCommand(hooksCmd)
+	hooksCmd.AddCommand(hooksInstallGitCmd)
+	hooksInstallGitCmd.Flags().Bool("force", false, "Replace a pre-commit hook plonk didn't install")
+}
+
+func runHooksInstallGit(cmd *cobra.Command, args []string) error {
+	force, _ := cmd.Flags().GetBool("force")
+
+	configDir := config.GetDefaultConfigDirectory()
+	git := gitops.New(configDir)
+	if !git.IsRepo() {
+		return fmt.Errorf("%s is not a git repository", configDir)
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("cannot locate plonk executable: %w", err)
+	}
+
+	path, err := git.InstallHook(cmd.Context(), "pre-commit", gitops.PreCommitHook(exe), force)
+	if err != nil {
+		return err
+	}
+	output.Printf("%s Installed %s\n", output.IconSuccess, path)
+	return nil
+}
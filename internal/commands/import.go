@@ -0,0 +1,113 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/richhaase/plonk/internal/config"
+	"github.com/richhaase/plonk/internal/gitops"
+	"github.com/richhaase/plonk/internal/output"
+	"github.com/richhaase/plonk/internal/state"
+	"github.com/spf13/cobra"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import packages and dotfiles from other formats",
+	Long: `Bring state from other tools under plonk management.
+
+Commands:
+  state     A state document written by 'plonk export state' or another tool
+  npm       Dependencies from a package.json, tracked with pnpm
+  pip       Requirements from a requirements.txt, tracked with uv
+  gemfile   Gems from a Gemfile, tracked with a gem manager plugin`,
+}
+
+var importStateCmd = &cobra.Command{
+	Use:   "state <file|->",
+	Short: "Import a JSON state document",
+	Long: `Merge a state document (see "State Format" in docs/reference.md) into
+the plonk directory. Packages are added to plonk.lock and dotfiles that
+carry content are written as sources; run 'plonk apply' afterwards to
+install and deploy them.
+
+Nothing is removed. Existing dotfile sources that differ from the document
+are left alone and reported. Package versions are ignored, since plonk
+tracks packages unversioned.
+
+Examples:
+  plonk import state state.json --dry-run
+  plonk import state state.json && plonk apply
+  other-tool --emit-state | plonk import state -`,
+	RunE:         runImportState,
+	SilenceUsage: true,
+	Args:         cobra.ExactArgs(1),
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+	importCmd.AddCommand(importStateCmd)
+
+	importStateCmd.Flags().BoolP("dry-run", "n", false, "Show what would be imported without changing anything")
+}
+
+func runImportState(cmd *cobra.Command, args []string) error {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	var data []byte
+	var err error
+	if args[0] == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(args[0])
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read state: %w", err)
+	}
+
+	var doc state.Document
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("invalid state document: %w", err)
+	}
+
+	return importDocument(cmd, &doc, dryRun, "import state", args)
+}
+
+// importDocument merges doc into the plonk directory, reports the outcome
+// and auto-commits; every import subcommand ends here
+func importDocument(cmd *cobra.Command, doc *state.Document, dryRun bool, command string, args []string) error {
+	configDir := config.GetDefaultConfigDirectory()
+	result, err := state.Import(configDir, doc, dryRun)
+	if err != nil {
+		return err
+	}
+
+	verb := "Imported"
+	if dryRun {
+		verb = "Would import"
+	}
+	for _, entry := range result.Added {
+		output.Printf("  + %s\n", entry)
+	}
+	for _, entry := range result.Skipped {
+		output.Printf("  skipped %s\n", entry)
+	}
+	for _, entry := range result.Failed {
+		output.Printf("  failed %s\n", entry)
+	}
+	output.Printf("%s %d items (%d unchanged, %d skipped, %d failed)\n",
+		verb, len(result.Added), len(result.Unchanged), len(result.Skipped), len(result.Failed))
+
+	if !dryRun && len(result.Added) > 0 {
+		gitops.AutoCommit(cmd.Context(), configDir, command, args)
+	}
+	if len(result.Failed) > 0 {
+		return fmt.Errorf("%d items could not be imported", len(result.Failed))
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/richhaase/plonk/internal/config"
+	"github.com/richhaase/plonk/internal/manifest"
+	"github.com/richhaase/plonk/internal/output"
+	"github.com/richhaase/plonk/internal/packages"
+	"github.com/richhaase/plonk/internal/state"
+	"github.com/spf13/cobra"
+)
+
+var importNpmCmd = &cobra.Command{
+	Use:   "npm [package.json]",
+	Short: "Track the dependencies of a package.json with pnpm",
+	Long: `Add the dependencies and devDependencies of a package.json (default:
+./package.json) to plonk.lock as global pnpm packages. The output of
+'npm ls -g --json' is accepted too, to move global npm installs to plonk.
+
+A project manifest usually lists libraries as well as tools. With
+--global-only, only packages already installed globally with pnpm are
+imported.
+
+Versions are not pinned: plonk tracks packages by name and installs the
+latest release. Dropped version constraints are listed.
+
+Examples:
+  plonk import npm --dry-run
+  plonk import npm package.json --global-only
+  npm ls -g --depth=0 --json > global.json && plonk import npm global.json`,
+	RunE:         runImportManifest,
+	SilenceUsage: true,
+	Args:         cobra.MaximumNArgs(1),
+}
+
+var importPipCmd = &cobra.Command{
+	Use:   "pip [requirements.txt]",
+	Short: "Track the packages of a requirements file with uv",
+	Long: `Add the packages in a pip requirements file (default: ./requirements.txt)
+to plonk.lock as uv tools. Comments, options (-r, -e, --index-url),
+extras and environment markers are skipped; URL and VCS requirements are
+rejected.
+
+uv installs packages that provide commands, so review the list with
+--dry-run before importing a project's library requirements.
+
+Versions are not pinned: plonk tracks packages by name and installs the
+latest release. Dropped version constraints are listed.
+
+Examples:
+  plonk import pip --dry-run
+  pip freeze > requirements.txt && plonk import pip requirements.txt`,
+	RunE:         runImportManifest,
+	SilenceUsage: true,
+	Args:         cobra.MaximumNArgs(1),
+}
+
+var importGemfileCmd = &cobra.Command{
+	Use:   "gemfile [Gemfile]",
+	Short: "Track the gems of a Gemfile with a gem manager plugin",
+	Long: `Add the gems declared in a Gemfile (default: ./Gemfile) to plonk.lock
+under the gem manager. plonk has no built-in gem manager, so this needs a
+plonk-manager-gem plugin on PATH (see "Manager Plugins" in the reference).
+
+Only literal gem "name" lines are read; groups, sources and other Ruby
+code are ignored. Versions are not pinned; dropped constraints are listed.
+
+Examples:
+  plonk import gemfile --dry-run
+  plonk import gemfile ~/project/Gemfile`,
+	RunE:         runImportManifest,
+	SilenceUsage: true,
+	Args:         cobra.MaximumNArgs(1),
+}
+
+// manifestImporter describes how one manifest format maps into plonk
+type manifestImporter struct {
+	defaultFile string
+	manager     string
+	parse       func([]byte) ([]manifest.Entry, error)
+}
+
+var manifestImporters = map[string]manifestImporter{
+	"npm":     {defaultFile: "package.json", manager: "pnpm", parse: manifest.ParsePackageJSON},
+	"pip":     {defaultFile: "requirements.txt", manager: "uv", parse: manifest.ParseRequirements},
+	"gemfile": {defaultFile: "Gemfile", manager: "gem", parse: manifest.ParseGemfile},
+}
+
+func init() {
+	importCmd.AddCommand(importNpmCmd)
+	importCmd.AddCommand(importPipCmd)
+	importCmd.AddCommand(importGemfileCmd)
+
+	for _, c := range []*cobra.Command{importNpmCmd, importPipCmd, importGemfileCmd} {
+		c.Flags().BoolP("dry-run", "n", false, "Show what would be imported without changing anything")
+	}
+	importNpmCmd.Flags().Bool("global-only", false, "Only import packages already installed globally with pnpm")
+}
+
+func runImportManifest(cmd *cobra.Command, args []string) error {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	importer := manifestImporters[cmd.Name()]
+
+	path := importer.defaultFile
+	if len(args) > 0 {
+		path = args[0]
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	entries, err := importer.parse(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	packages.Configure(config.LoadWithDefaults(config.GetDefaultConfigDirectory()))
+	if !packages.IsSupportedManager(importer.manager) {
+		return fmt.Errorf("%s packages need a plonk-manager-%s plugin on PATH", cmd.Name(), importer.manager)
+	}
+
+	globalOnly, _ := cmd.Flags().GetBool("global-only")
+	if globalOnly {
+		if entries, err = installedEntries(cmd, importer.manager, entries); err != nil {
+			return err
+		}
+	}
+
+	doc := &state.Document{Schema: state.SchemaVersion}
+	var unpinned []string
+	for _, e := range entries {
+		doc.Packages = append(doc.Packages, state.Package{Manager: importer.manager, Name: e.Name, Version: e.Version})
+		if e.Version != "" && e.Version != "*" && e.Version != "latest" {
+			unpinned = append(unpinned, e.Name+" "+e.Version)
+		}
+	}
+	if len(doc.Packages) == 0 {
+		output.Printf("No packages to import from %s\n", path)
+		return nil
+	}
+	if len(unpinned) > 0 {
+		output.Printf("Versions not pinned (plonk installs the latest): %s\n", strings.Join(unpinned, ", "))
+	}
+
+	return importDocument(cmd, doc, dryRun, "import "+cmd.Name(), []string{path})
+}
+
+// installedEntries keeps the entries that manager reports as installed
+func installedEntries(cmd *cobra.Command, manager string, entries []manifest.Entry) ([]manifest.Entry, error) {
+	if err := packages.CheckManagerAvailable(manager); err != nil {
+		return nil, fmt.Errorf("--global-only: %w", err)
+	}
+	mgr, err := packages.GetManager(manager)
+	if err != nil {
+		return nil, err
+	}
+
+	var kept []manifest.Entry
+	for _, e := range entries {
+		installed, err := mgr.IsInstalled(cmd.Context(), e.Name)
+		if err != nil {
+			return nil, fmt.Errorf("--global-only: %w", err)
+		}
+		if installed {
+			kept = append(kept, e)
+		}
+	}
+	return kept, nil
+}
@@ -0,0 +1,159 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/richhaase/plonk/internal/config"
+	"github.com/richhaase/plonk/internal/gitops"
+	"github.com/richhaase/plonk/internal/output"
+	"github.com/richhaase/plonk/internal/packages"
+	"github.com/spf13/cobra"
+)
+
+var initCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Create a plonk.yaml from a template",
+	Long: `Create plonk.yaml in the plonk directory from a template.
+
+Templates:
+  minimal     Default manager and git settings only (default)
+  full        Every setting written out with its default, ready to edit
+  macos-dev   Homebrew-first macOS setup that also ignores macOS metadata files
+
+When run in a terminal without --template, plonk asks which template,
+default manager and auto-commit setting to use. Use --no-interactive to
+accept the defaults and flags as given.
+
+Examples:
+  plonk init                               # Guided setup
+  plonk init --template full               # Write every setting
+  plonk init --template macos-dev --no-interactive
+  plonk init --default-manager cargo --force`,
+	RunE:         runInit,
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+}
+
+func init() {
+	rootCmd.AddCommand(initCmd)
+	initCmd.Flags().String("template", "", "Template: minimal, full or macos-dev (default: minimal)")
+	initCmd.Flags().String("default-manager", "", "Default package manager (default: brew)")
+	initCmd.Flags().Bool("auto-commit", true, "Enable git.auto_commit")
+	initCmd.Flags().Bool("no-interactive", false, "Do not prompt; use flags and defaults")
+	initCmd.Flags().BoolP("force", "f", false, "Overwrite an existing plonk.yaml")
+}
+
+func runInit(cmd *cobra.Command, args []string) error {
+	templateName, _ := cmd.Flags().GetString("template")
+	defaultManager, _ := cmd.Flags().GetString("default-manager")
+	autoCommit, _ := cmd.Flags().GetBool("auto-commit")
+	noInteractive, _ := cmd.Flags().GetBool("no-interactive")
+	force, _ := cmd.Flags().GetBool("force")
+
+	configDir := config.GetDefaultConfigDirectory()
+	configPath := getConfigPath(configDir)
+	if _, err := os.Stat(configPath); err == nil && !force {
+		return fmt.Errorf("%s already exists; use --force to overwrite it", configPath)
+	}
+
+	opts := config.TemplateOptions{DefaultManager: defaultManager, AutoCommit: autoCommit}
+	if templateName == "" && !noInteractive && stdinIsTerminal() {
+		var err error
+		templateName, opts, err = promptInitChoices(cmd.InOrStdin(), cmd.ErrOrStderr(), opts)
+		if err != nil {
+			return err
+		}
+	}
+	if templateName == "" {
+		templateName = config.TemplateMinimal
+	}
+	if opts.DefaultManager == "" {
+		opts.DefaultManager = config.GetDefaults().DefaultManager
+	}
+	if !packages.IsSupportedManager(opts.DefaultManager) {
+		return packages.UnsupportedManagerError(opts.DefaultManager)
+	}
+
+	content, err := config.RenderTemplate(templateName, opts)
+	if err != nil {
+		return err
+	}
+	if result := config.NewSimpleValidator().ValidateConfigFromYAML(content); !result.Valid {
+		return fmt.Errorf("template %s produced an invalid config: %s", templateName, strings.Join(result.Errors, "; "))
+	}
+
+	if err := os.MkdirAll(configDir, 0o750); err != nil {
+		return fmt.Errorf("failed to create %s: %w", configDir, err)
+	}
+	if err := os.WriteFile(configPath, content, 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", configPath, err)
+	}
+
+	output.Printf("Created %s from the %s template\n", configPath, templateName)
+	output.Println("Next steps:")
+	output.Println("  plonk track brew:<package>     # Track packages")
+	output.Println("  plonk add ~/.zshrc             # Manage dotfiles")
+	output.Println("  plonk status                   # See what plonk manages")
+
+	if gitops.New(configDir).IsRepo() {
+		gitops.AutoCommit(cmd.Context(), configDir, "init", []string{templateName})
+	}
+	return nil
+}
+
+// promptInitChoices asks for the template, default manager and auto-commit
+// setting. Empty answers keep the value shown in brackets.
+func promptInitChoices(in io.Reader, out io.Writer, opts config.TemplateOptions) (string, config.TemplateOptions, error) {
+	reader := bufio.NewReader(in)
+
+	fmt.Fprintln(out, "Templates:")
+	for i, name := range config.TemplateNames {
+		fmt.Fprintf(out, "  %d) %-10s %s\n", i+1, name, config.TemplateDescriptions[name])
+	}
+	templateName := config.TemplateMinimal
+	answer, err := promptLine(reader, out, "Template", templateName)
+	if err != nil {
+		return "", opts, err
+	}
+	if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= len(config.TemplateNames) {
+		templateName = config.TemplateNames[n-1]
+	} else {
+		templateName = answer
+	}
+
+	defaultManager := opts.DefaultManager
+	if defaultManager == "" {
+		defaultManager = suggestDefaultManager(templateName)
+	}
+	if opts.DefaultManager, err = promptLine(reader, out,
+		fmt.Sprintf("Default package manager (%s)", strings.Join(packages.SupportedManagers, ", ")), defaultManager); err != nil {
+		return "", opts, err
+	}
+
+	if opts.AutoCommit, err = promptYesNo(reader, out, "Auto-commit changes to the plonk directory with git?", opts.AutoCommit); err != nil {
+		return "", opts, err
+	}
+
+	return templateName, opts, nil
+}
+
+// suggestDefaultManager prefers brew, then the first supported manager on PATH
+func suggestDefaultManager(templateName string) string {
+	if templateName == config.TemplateMacOSDev {
+		return "brew"
+	}
+	for _, name := range packages.SupportedManagers {
+		if packages.CheckManagerAvailable(name) == nil {
+			return name
+		}
+	}
+	return config.GetDefaults().DefaultManager
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package commands
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/richhaase/plonk/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPromptInitChoices(t *testing.T) {
+	in := strings.NewReader("3\ncargo\nn\n")
+	var out bytes.Buffer
+
+	name, opts, err := promptInitChoices(in, &out, config.TemplateOptions{AutoCommit: true})
+	require.NoError(t, err)
+	assert.Equal(t, config.TemplateMacOSDev, name)
+	assert.Equal(t, "cargo", opts.DefaultManager)
+	assert.False(t, opts.AutoCommit)
+	assert.Contains(t, out.String(), "1) minimal")
+}
+
+func TestPromptInitChoices_Defaults(t *testing.T) {
+	name, opts, err := promptInitChoices(strings.NewReader(""), &bytes.Buffer{},
+		config.TemplateOptions{DefaultManager: "uv", AutoCommit: true})
+	require.NoError(t, err)
+	assert.Equal(t, config.TemplateMinimal, name)
+	assert.Equal(t, "uv", opts.DefaultManager)
+	assert.True(t, opts.AutoCommit)
+}
+
+func TestRunInit_WritesTemplate(t *testing.T) {
+	configDir := filepath.Join(t.TempDir(), "plonk")
+	t.Setenv("PLONK_DIR", configDir)
+
+	cmd := initCmd
+	t.Cleanup(func() {
+		_ = cmd.Flags().Set("template", "")
+		_ = cmd.Flags().Set("force", "false")
+		_ = cmd.Flags().Set("no-interactive", "false")
+	})
+	require.NoError(t, cmd.Flags().Set("template", "full"))
+	require.NoError(t, cmd.Flags().Set("no-interactive", "true"))
+	require.NoError(t, runInit(cmd, nil))
+
+	data, err := os.ReadFile(filepath.Join(configDir, "plonk.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "operation_timeout: 300")
+
+	// Refuses to overwrite without --force
+	assert.ErrorContains(t, runInit(cmd, nil), "already exists")
+	require.NoError(t, cmd.Flags().Set("force", "true"))
+	assert.NoError(t, runInit(cmd, nil))
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/richhaase/plonk/internal/config"
+	"github.com/richhaase/plonk/internal/lock"
+	"github.com/richhaase/plonk/internal/output"
+	"github.com/richhaase/plonk/internal/packages"
+	"github.com/spf13/cobra"
+)
+
+var licensesCmd = &cobra.Command{
+	Use:   "licenses",
+	Short: "List the license of each tracked package",
+	Long: `List the license each package in plonk.lock declares, for reviews under
+an open source policy. Licenses come from what the package managers keep
+locally, usually as SPDX expressions:
+
+  brew   the formula's license in 'brew info' (casks declare none)
+  cargo  'cargo info' for crates.io crates, Cargo.toml for path sources
+  pnpm   the license field of the installed package.json
+  uv     the installed tool's metadata (License-Expression, License or
+         its license classifier)
+
+Packages that aren't installed, git sources, Go modules and packages that
+declare nothing are listed as unknown. A declared license is what the
+author states; check anything that matters with the project itself.
+
+Formats (-o): table (default), csv, json or yaml.
+
+Examples:
+  plonk licenses
+  plonk licenses -o csv > licenses.csv`,
+	RunE:         runLicenses,
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+	Annotations:  map[string]string{ownOutputFlag: "true"},
+}
+
+func init() {
+	rootCmd.AddCommand(licensesCmd)
+	licensesCmd.Flags().StringP("output", "o", "table", "Output format (table|csv|json|yaml)")
+}
+
+func runLicenses(cmd *cobra.Command, args []string) error {
+	format, _ := cmd.Flags().GetString("output")
+	format = strings.ToLower(format)
+	switch format {
+	case "table", "csv", "json", "yaml":
+	default:
+		return fmt.Errorf("unsupported licenses format: %s (use table, csv, json or yaml)", format)
+	}
+
+	configDir := config.GetDefaultConfigDirectory()
+	cfg := config.LoadWithDefaults(configDir)
+	packages.Configure(cfg)
+	lockFile, err := lock.NewLockV3Service(configDir).Read()
+	if err != nil {
+		return fmt.Errorf("failed to read lock file: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(cmd.Context(), config.GetTimeouts(cfg).Operation)
+	defer cancel()
+
+	result, err := collectLicenses(ctx, lockFile.Packages)
+	if err != nil {
+		return err
+	}
+
+	if format == "csv" {
+		csv, err := result.CSV()
+		if err != nil {
+			return err
+		}
+		fmt.Print(csv)
+		return nil
+	}
+	f, _ := output.ParseOutputFormat(format)
+	output.SetOutputFormat(f)
+	output.RenderOutput(result)
+	return nil
+}
+
+// collectLicenses looks up the licenses of tracked packages, by manager,
+// sorted by manager then name. Disabled managers are left out.
+func collectLicenses(ctx context.Context, tracked map[string][]string) (output.LicensesOutput, error) {
+	var result output.LicensesOutput
+	managers := make([]string, 0, len(tracked))
+	for manager := range tracked {
+		if !packages.IsManagerDisabled(manager) {
+			managers = append(managers, manager)
+		}
+	}
+	sort.Strings(managers)
+
+	for _, manager := range managers {
+		names := append([]string(nil), tracked[manager]...)
+		sort.Strings(names)
+		licenses, err := packages.DetectLicenses(ctx, manager, names)
+		if err != nil {
+			return result, fmt.Errorf("failed to read %s licenses: %w", manager, err)
+		}
+		for _, name := range names {
+			result.Packages = append(result.Packages, output.PackageLicense{Manager: manager, Name: name, License: licenses[name]})
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,32 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package commands
+
+import (
+	"strings"
+
+	"github.com/richhaase/plonk/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// addListFlags adds --sort and --state to a listing command, and --group-by
+// when its items belong to managers
+func addListFlags(cmd *cobra.Command, groupBy bool) {
+	cmd.Flags().String("sort", "", "Sort by "+strings.Join(output.ListSorts, ", "))
+	cmd.Flags().String("state", "", "Only show items that are "+strings.Join(output.ListStates, ", "))
+	if groupBy {
+		cmd.Flags().String("group-by", "", "Group items by manager")
+	}
+}
+
+// listOptions reads and validates the flags added by addListFlags
+func listOptions(cmd *cobra.Command) (output.ListOptions, error) {
+	var opts output.ListOptions
+	opts.Sort, _ = cmd.Flags().GetString("sort")
+	opts.State, _ = cmd.Flags().GetString("state")
+	if cmd.Flags().Lookup("group-by") != nil {
+		opts.GroupBy, _ = cmd.Flags().GetString("group-by")
+	}
+	return opts, opts.Validate()
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package commands
+
+import (
+	"fmt"
+
+	"github.com/richhaase/plonk/internal/config"
+	"github.com/richhaase/plonk/internal/lock"
+	"github.com/richhaase/plonk/internal/output"
+	"github.com/richhaase/plonk/internal/signing"
+	"github.com/spf13/cobra"
+)
+
+var lockCmd = &cobra.Command{
+	Use:   "lock",
+	Short: "Inspect plonk.lock",
+	Long: `Inspect plonk.lock, which plonk maintains as you track packages and
+apply dotfiles.
+
+Commands:
+  verify    Check plonk.lock and its signature`,
+}
+
+var lockVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Check plonk.lock and its signature",
+	Long: `Check that plonk.lock parses and is in the current format, with no empty
+or duplicate package entries, as a hand edit or a bad merge can leave it.
+When this machine has a trust file (see 'Lock Signing' in the reference),
+the signature is verified too. Nothing is migrated or rewritten.
+
+Examples:
+  plonk lock verify`,
+	RunE:         runLockVerify,
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+}
+
+func init() {
+	rootCmd.AddCommand(lockCmd)
+	lockCmd.AddCommand(lockVerifyCmd)
+}
+
+func runLockVerify(cmd *cobra.Command, args []string) error {
+	configDir := config.GetDefaultConfigDirectory()
+	lockFile, err := lock.NewLockV3Service(configDir).Check()
+	if err != nil {
+		return err
+	}
+	output.Printf("%s plonk.lock is valid (%d packages)\n", output.IconSuccess, len(lockFile.GetAllPackages()))
+
+	if signing.TrustFile() == "" {
+		output.Printf("%s Signature not checked: no trust file on this machine\n", output.IconInfo)
+		return nil
+	}
+	if err := signing.VerifyLock(cmd.Context(), configDir); err != nil {
+		return fmt.Errorf("plonk.lock signature: %w", err)
+	}
+	output.Printf("%s plonk.lock signature verified\n", output.IconSuccess)
+	return nil
+}
@@ -0,0 +1,225 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/richhaase/plonk/internal/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var manCmd = &cobra.Command{
+	Use:   "man <dir>",
+	Short: "Write man pages for plonk and its commands",
+	Long: `Write a section 1 man page for plonk, each of its commands and each help
+topic into a directory, from the same text --help shows.
+
+Packagers run this at build time (make man). The page date comes from
+$SOURCE_DATE_EPOCH when set, so builds are reproducible.
+
+Examples:
+  plonk man ./man                    # Write man/plonk.1, man/plonk-track.1, ...`,
+	Hidden:       true,
+	Args:         cobra.ExactArgs(1),
+	RunE:         runMan,
+	SilenceUsage: true,
+}
+
+func init() {
+	rootCmd.AddCommand(manCmd)
+}
+
+func runMan(cmd *cobra.Command, args []string) error {
+	date := time.Now()
+	if epoch := os.Getenv("SOURCE_DATE_EPOCH"); epoch != "" {
+		seconds, err := strconv.ParseInt(epoch, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid SOURCE_DATE_EPOCH %q: %w", epoch, err)
+		}
+		date = time.Unix(seconds, 0).UTC()
+	}
+
+	written, err := writeManPages(rootCmd, args[0], date, "plonk "+formatVersion())
+	if err != nil {
+		return err
+	}
+	output.Printf("Wrote %d man pages to %s\n", len(written), args[0])
+	return nil
+}
+
+// manPageCommands returns cmd and every command and help topic under it
+// that --help lists
+func manPageCommands(cmd *cobra.Command) []*cobra.Command {
+	cmds := []*cobra.Command{cmd}
+	for _, sub := range cmd.Commands() {
+		if sub.Name() == "help" || !(sub.IsAvailableCommand() || sub.IsAdditionalHelpTopicCommand()) {
+			continue
+		}
+		cmds = append(cmds, manPageCommands(sub)...)
+	}
+	return cmds
+}
+
+// manPageName is the page a command is documented in, e.g. plonk-config-show
+func manPageName(cmd *cobra.Command) string {
+	return strings.ReplaceAll(cmd.CommandPath(), " ", "-")
+}
+
+// writeManPages writes a page for root and everything under it into dir,
+// returning the paths written
+func writeManPages(root *cobra.Command, dir string, date time.Time, source string) ([]string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	var written []string
+	for _, cmd := range manPageCommands(root) {
+		path := filepath.Join(dir, manPageName(cmd)+".1")
+		if err := os.WriteFile(path, []byte(manPage(cmd, date, source)), 0o644); err != nil {
+			return written, err
+		}
+		written = append(written, path)
+	}
+	return written, nil
+}
+
+// manPage renders cmd as a roff man page. The Examples: block that ends
+// most Long texts becomes its own section.
+func manPage(cmd *cobra.Command, date time.Time, source string) string {
+	var b strings.Builder
+	name := manPageName(cmd)
+	fmt.Fprintf(&b, ".TH %q \"1\" %q %q \"Plonk Manual\"\n", strings.ToUpper(name), date.Format("January 2006"), source)
+
+	b.WriteString(".SH NAME\n")
+	fmt.Fprintf(&b, "%s \\- %s\n", roffEscape(name), roffEscape(cmd.Short))
+
+	b.WriteString(".SH SYNOPSIS\n")
+	fmt.Fprintf(&b, ".B %s\n", roffEscape(cmd.CommandPath()))
+	if _, rest, ok := strings.Cut(cmd.Use, " "); ok {
+		b.WriteString(roffEscape(rest) + "\n")
+	} else if cmd.HasAvailableSubCommands() {
+		b.WriteString("\\fIcommand\\fR\n")
+	}
+
+	description, examples, _ := strings.Cut(cmd.Long, "\nExamples:\n")
+	if description == "" {
+		description = cmd.Short
+	}
+	b.WriteString(".SH DESCRIPTION\n")
+	writeRoffParagraphs(&b, description)
+
+	if cmd.HasAvailableSubCommands() {
+		b.WriteString(".SH COMMANDS\n")
+		for _, sub := range manPageCommands(cmd)[1:] {
+			if sub.Parent() != cmd {
+				continue
+			}
+			fmt.Fprintf(&b, ".TP\n\\fB%s\\fR(1)\n%s\n", roffEscape(manPageName(sub)), roffEscape(sub.Short))
+		}
+	}
+
+	writeRoffFlags(&b, "OPTIONS", cmd.NonInheritedFlags())
+	writeRoffFlags(&b, "GLOBAL OPTIONS", cmd.InheritedFlags())
+
+	if examples = strings.Trim(examples, "\n"); examples != "" {
+		b.WriteString(".SH EXAMPLES\n.nf\n")
+		for _, line := range strings.Split(examples, "\n") {
+			b.WriteString(roffEscape(strings.TrimPrefix(line, "  ")) + "\n")
+		}
+		b.WriteString(".fi\n")
+	}
+
+	var seeAlso []string
+	if cmd.HasParent() {
+		seeAlso = append(seeAlso, manPageName(cmd.Parent()))
+	}
+	for _, sub := range manPageCommands(cmd)[1:] {
+		if sub.Parent() == cmd {
+			seeAlso = append(seeAlso, manPageName(sub))
+		}
+	}
+	if len(seeAlso) > 0 {
+		b.WriteString(".SH SEE ALSO\n")
+		for i, page := range seeAlso {
+			sep := ",\n"
+			if i == len(seeAlso)-1 {
+				sep = "\n"
+			}
+			fmt.Fprintf(&b, "\\fB%s\\fR(1)%s", roffEscape(page), sep)
+		}
+	}
+	return b.String()
+}
+
+// writeRoffParagraphs writes text's blank-line separated paragraphs.
+// Paragraphs with indented or bulleted lines keep their line breaks; the
+// rest are filled.
+func writeRoffParagraphs(b *strings.Builder, text string) {
+	for i, para := range strings.Split(strings.Trim(text, "\n"), "\n\n") {
+		if i > 0 {
+			b.WriteString(".PP\n")
+		}
+		lines := strings.Split(para, "\n")
+		preformatted := false
+		for _, line := range lines {
+			if strings.HasPrefix(line, " ") || strings.HasPrefix(line, "- ") {
+				preformatted = true
+			}
+		}
+		if preformatted {
+			b.WriteString(".nf\n")
+		}
+		for _, line := range lines {
+			b.WriteString(roffEscape(line) + "\n")
+		}
+		if preformatted {
+			b.WriteString(".fi\n")
+		}
+	}
+}
+
+// writeRoffFlags writes a section listing flags, skipping hidden ones
+func writeRoffFlags(b *strings.Builder, section string, flags *pflag.FlagSet) {
+	var entries []string
+	flags.VisitAll(func(f *pflag.Flag) {
+		if f.Hidden {
+			return
+		}
+		names := fmt.Sprintf("\\fB\\-\\-%s\\fR", roffEscape(f.Name))
+		if f.Shorthand != "" {
+			names = fmt.Sprintf("\\fB\\-%s\\fR, %s", roffEscape(f.Shorthand), names)
+		}
+		if typ := f.Value.Type(); typ != "bool" {
+			names += " \\fI" + roffEscape(typ) + "\\fR"
+		}
+		usage := f.Usage
+		if f.DefValue != "" && f.DefValue != "false" && f.DefValue != "[]" && f.DefValue != "0" {
+			usage += fmt.Sprintf(" (default %s)", f.DefValue)
+		}
+		entries = append(entries, fmt.Sprintf(".TP\n%s\n%s\n", names, roffEscape(usage)))
+	})
+	if len(entries) == 0 {
+		return
+	}
+	b.WriteString(".SH " + section + "\n")
+	for _, entry := range entries {
+		b.WriteString(entry)
+	}
+}
+
+// roffEscape keeps text from being read as roff requests or escapes
+func roffEscape(text string) string {
+	text = strings.ReplaceAll(text, `\`, `\e`)
+	text = strings.ReplaceAll(text, "-", `\-`)
+	if strings.HasPrefix(text, ".") || strings.HasPrefix(text, "'") {
+		text = `\&` + text
+	}
+	return text
+}
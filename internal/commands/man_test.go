@@ -0,0 +1,70 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManPage(t *testing.T) {
+	root := &cobra.Command{Use: "plonk", Short: "A developer environment manager"}
+	root.PersistentFlags().StringP("output", "o", "table", "Output format")
+	sub := &cobra.Command{
+		Use:   "track <manager:package>...",
+		Short: "Track installed packages",
+		Long: `Track packages that are already installed.
+
+.plonk.yaml is read first:
+  - aliases
+  - manager_priority
+
+Examples:
+  plonk track brew:ripgrep    # Track a brew package`,
+		RunE: func(*cobra.Command, []string) error { return nil },
+	}
+	sub.Flags().BoolP("dry-run", "n", false, "Show what would be tracked")
+	root.AddCommand(sub)
+
+	page := manPage(sub, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), "plonk v1.0.0")
+	assert.Contains(t, page, `.TH "PLONK-TRACK" "1" "March 2025" "plonk v1.0.0" "Plonk Manual"`)
+	assert.Contains(t, page, ".SH NAME\nplonk\\-track \\- Track installed packages\n")
+	assert.Contains(t, page, ".SH SYNOPSIS\n.B plonk track\n<manager:package>...\n")
+	assert.Contains(t, page, ".PP\n.nf\n\\&.plonk.yaml is read first:\n  \\- aliases\n", "a leading dot is escaped")
+	assert.Contains(t, page, ".SH OPTIONS\n.TP\n\\fB\\-n\\fR, \\fB\\-\\-dry\\-run\\fR\nShow what would be tracked\n")
+	assert.Contains(t, page, ".SH GLOBAL OPTIONS\n.TP\n\\fB\\-o\\fR, \\fB\\-\\-output\\fR \\fIstring\\fR\nOutput format (default table)\n")
+	assert.Contains(t, page, ".SH EXAMPLES\n.nf\nplonk track brew:ripgrep    # Track a brew package\n.fi\n")
+	assert.Contains(t, page, ".SH SEE ALSO\n\\fBplonk\\fR(1)\n")
+	assert.NotContains(t, page, "Examples:")
+}
+
+func TestWriteManPages(t *testing.T) {
+	dir := t.TempDir()
+	written, err := writeManPages(rootCmd, dir, time.Now(), "plonk dev")
+	require.NoError(t, err)
+
+	for _, name := range []string{"plonk.1", "plonk-track.1", "plonk-config-show.1", "plonk-managers.1"} {
+		assert.Contains(t, written, filepath.Join(dir, name))
+	}
+	assert.NotContains(t, written, filepath.Join(dir, "plonk-man.1"), "hidden commands have no page")
+	assert.NotContains(t, written, filepath.Join(dir, "plonk-help.1"))
+
+	root, err := os.ReadFile(filepath.Join(dir, "plonk.1"))
+	require.NoError(t, err)
+	assert.Contains(t, string(root), "\\fBplonk\\-track\\fR(1)")
+}
+
+func TestManagersTopic(t *testing.T) {
+	topic := managersTopic()
+	assert.Contains(t, topic, "CAPABILITY")
+	for _, name := range []string{"batch", "pinning", "licenses"} {
+		assert.Contains(t, topic, name)
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/richhaase/plonk/internal/config"
+	"github.com/richhaase/plonk/internal/output"
+	"github.com/richhaase/plonk/internal/packages"
+	"github.com/spf13/cobra"
+)
+
+var managersCmd = &cobra.Command{
+	Use:          "managers",
+	Short:        "List package managers, their versions and what plonk can do with each",
+	Args:         cobra.NoArgs,
+	RunE:         runManagers,
+	SilenceUsage: true,
+}
+
+func init() {
+	// Built from the package registries, so the matrix can't go stale
+	managersCmd.Long = managersTopic() + `
+
+Examples:
+  plonk managers                     # Which managers are available here
+  plonk managers -o json             # For scripts`
+	rootCmd.AddCommand(managersCmd)
+}
+
+func runManagers(cmd *cobra.Command, args []string) error {
+	packages.Configure(config.LoadWithDefaults(config.GetDefaultConfigDirectory()))
+
+	var result output.ManagersOutput
+	for _, name := range append(slices.Clone(packages.SupportedManagers), packages.DiscoverPlugins()...) {
+		result.Managers = append(result.Managers, describeManager(cmd, name))
+	}
+	output.RenderOutput(result)
+	return nil
+}
+
+// describeManager reports whether a manager can run here, its version and
+// its capabilities
+func describeManager(cmd *cobra.Command, name string) output.ManagerInfo {
+	info := output.ManagerInfo{
+		Name:         name,
+		Plugin:       !slices.Contains(packages.SupportedManagers, name),
+		Capabilities: packages.ManagerCapabilities(name),
+	}
+	if err := packages.CheckManagerAvailable(name); err != nil {
+		info.Reason = err.Error()
+		var unavailable *packages.UnavailableManagerError
+		if errors.As(err, &unavailable) {
+			info.Reason, info.Hint = unavailable.Reason, unavailable.Hint
+		}
+		return info
+	}
+
+	info.Available = true
+	if info.Plugin {
+		info.Path = packages.PluginPath(name)
+	} else {
+		info.Path, _ = packages.LookPath(name)
+	}
+	version, err := packages.ManagerVersion(cmd.Context(), name)
+	if err != nil {
+		output.Printf("Warning: %v\n", err)
+	}
+	info.Version = version
+	return info
+}
+
+// managersTopic describes the built-in managers with a matrix of their
+// capabilities
+func managersTopic() string {
+	var b strings.Builder
+	b.WriteString(`List the package managers plonk drives - built in and plugins - with
+whether each is available here, its version, and what plonk can do with
+it beyond installing packages. Packages are tracked as manager:package.
+
+`)
+
+	table := output.NewStandardTableBuilder("")
+	table.SetHeaders(append([]string{"CAPABILITY"}, packages.SupportedManagers...)...)
+	supported := make(map[string][]string, len(packages.SupportedManagers))
+	for _, manager := range packages.SupportedManagers {
+		supported[manager] = packages.ManagerCapabilities(manager)
+	}
+	for _, c := range packages.Capabilities {
+		row := []string{c.Name}
+		for _, manager := range packages.SupportedManagers {
+			mark := "-"
+			if slices.Contains(supported[manager], c.Name) {
+				mark = "yes"
+			}
+			row = append(row, mark)
+		}
+		table.AddRow(row...)
+	}
+	b.WriteString(table.Build())
+
+	b.WriteString("Capabilities:\n")
+	for _, c := range packages.Capabilities {
+		fmt.Fprintf(&b, "  %-14s %s\n", c.Name, c.Description)
+	}
+
+	b.WriteString(`
+Any other manager can be added as an executable named plonk-manager-<name>
+on PATH; plonk installs and checks packages through it, without the
+capabilities above.`)
+	return b.String()
+}
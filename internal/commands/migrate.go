@@ -0,0 +1,73 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/richhaase/plonk/internal/config"
+	"github.com/richhaase/plonk/internal/gitops"
+	"github.com/richhaase/plonk/internal/lock"
+	"github.com/richhaase/plonk/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Upgrade plonk.yaml and plonk.lock to the current format",
+	Long: `Rewrite plonk.yaml and plonk.lock in the format this version of plonk uses.
+
+Older files are already read transparently: older lock versions are
+migrated in place the first time they are read, and renamed config keys are
+understood on every load. This command makes the config change permanent
+too, so the files in your repo match what plonk writes.
+
+Originals are kept in the .backups directory inside your plonk directory.
+Files included from plonk.yaml are not rewritten.
+
+Examples:
+  plonk migrate                 # Upgrade config and lock file`,
+	Args:         cobra.NoArgs,
+	RunE:         runMigrate,
+	SilenceUsage: true,
+}
+
+func init() {
+	rootCmd.AddCommand(migrateCmd)
+}
+
+func runMigrate(cmd *cobra.Command, args []string) error {
+	configDir := config.GetDefaultConfigDirectory()
+	var changed bool
+
+	from, backup, err := lock.NewLockV3Service(configDir).Migrate()
+	if err != nil {
+		return err
+	}
+	if backup != "" {
+		changed = true
+		output.Printf("%s %s: migrated from version %d to %d (backup: %s)\n",
+			output.Success(), lock.LockFileName, from, lock.CurrentVersion, backup)
+	} else {
+		output.Printf("%s: up to date\n", lock.LockFileName)
+	}
+
+	applied, backup, err := config.MigrateFile(filepath.Join(configDir, "plonk.yaml"))
+	if err != nil {
+		return fmt.Errorf("failed to migrate plonk.yaml: %w", err)
+	}
+	if backup != "" {
+		changed = true
+		output.Printf("%s plonk.yaml: %s (backup: %s)\n", output.Success(), strings.Join(applied, "; "), backup)
+	} else {
+		output.Println("plonk.yaml: up to date")
+	}
+
+	if changed {
+		gitops.AutoCommit(cmd.Context(), configDir, "migrate", nil)
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ package commands
 import (
 	"github.com/richhaase/plonk/internal/config"
 	"github.com/richhaase/plonk/internal/output"
+	"github.com/richhaase/plonk/internal/packages"
 	"github.com/spf13/cobra"
 )
 
@@ -20,23 +21,36 @@ Shows:
 - Missing packages that need to be installed
 
 Examples:
-  plonk packages    # Show all managed packages
-  plonk p           # Short alias`,
+  plonk packages                      # Show all managed packages
+  plonk packages --state missing      # Only packages that need installing
+  plonk packages --group-by manager   # One table per package manager
+  plonk p --sort state -o json        # Short alias, sorted JSON`,
 	RunE:         runPackages,
 	SilenceUsage: true,
 }
 
 func init() {
 	rootCmd.AddCommand(packagesCmd)
+	addSchemaFlag(packagesCmd)
+	addListFlags(packagesCmd, true)
 }
 
 func runPackages(cmd *cobra.Command, args []string) error {
+	if handled, err := printSchemaIfRequested(cmd, "packages"); handled {
+		return err
+	}
+	opts, err := listOptions(cmd)
+	if err != nil {
+		return err
+	}
+
 	// Get directories
 	configDir := config.GetDefaultConfigDirectory()
 	ctx := cmd.Context()
 	remoteSync := getRemoteSyncStatus(ctx, configDir)
 
 	// Get package status from lock file
+	packages.Configure(config.LoadWithDefaults(configDir))
 	pkgResult, err := getPackageStatus(ctx, configDir)
 	if err != nil {
 		return err
@@ -54,6 +68,7 @@ func runPackages(cmd *cobra.Command, args []string) error {
 	outputData := output.PackagesStatusOutput{
 		RemoteSync: remoteSync,
 		Result:     outputResult,
+		Options:    opts,
 	}
 
 	// Create formatter and render
@@ -0,0 +1,181 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/richhaase/plonk/internal/config"
+	"github.com/richhaase/plonk/internal/output"
+	"github.com/richhaase/plonk/internal/packages"
+	"github.com/richhaase/plonk/internal/project"
+	"github.com/spf13/cobra"
+)
+
+var projectCmd = &cobra.Command{
+	Use:   "project",
+	Short: "Install and check the tools a repository declares in .plonk.yaml",
+	Long: `Work with the .plonk.yaml at the root of a project repository, which
+declares the packages its developers need and the versions of the tools
+it expects:
+
+  packages:
+    - brew:terraform
+    - uv:ruff
+  tools:
+    terraform: ">=1.6,<2"
+    ruff: "0.6"
+
+The nearest .plonk.yaml in the current directory or its parents is used.
+Project packages are installed with your plonk configuration but are not
+added to plonk.lock.
+
+Examples:
+  plonk project apply     # Install the project's missing packages
+  plonk project check     # Exit non-zero if anything is missing or out of range`,
+}
+
+var projectApplyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Install the packages a project's .plonk.yaml declares",
+	Long: `Install the missing packages listed in the project's .plonk.yaml, then
+check the versions of its tools.
+
+Examples:
+  plonk project apply
+  plonk project apply --dry-run`,
+	RunE:         runProjectApply,
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+}
+
+var projectCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Check that a project's packages and tool versions are present",
+	Long: `Check that every package in the project's .plonk.yaml is installed and
+every tool prints a version within its range. Nothing is installed; the
+exit status is non-zero when anything is missing, so the check can run in
+CI or a git hook.
+
+A tool's version is the first version number it prints for --version (or
+the version subcommand). Ranges are pip-style clauses: ">=1.6,<2", "~=1.6"
+or "1.6" for any 1.6.x.
+
+Examples:
+  plonk project check`,
+	RunE:         runProjectCheck,
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+}
+
+func init() {
+	rootCmd.AddCommand(projectCmd)
+	projectCmd.AddCommand(projectApplyCmd)
+	projectCmd.AddCommand(projectCheckCmd)
+	projectApplyCmd.Flags().BoolP("dry-run", "n", false, "Show what would be installed without installing")
+}
+
+// loadProject finds and reads the nearest .plonk.yaml, with the managers
+// configured from the user's plonk.yaml
+func loadProject() (*project.Manifest, error) {
+	packages.Configure(config.LoadWithDefaults(config.GetDefaultConfigDirectory()))
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	path, err := project.Find(cwd)
+	if err != nil {
+		return nil, err
+	}
+	m, err := project.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	output.Printf("%s (%s)\n", path, m.Summary())
+	return m, nil
+}
+
+func runProjectApply(cmd *cobra.Command, args []string) error {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	m, err := loadProject()
+	if err != nil {
+		return err
+	}
+	lockFile, err := m.Lock()
+	if err != nil {
+		return err
+	}
+
+	result, applyErr := packages.ApplyLock(cmd.Context(), lockFile, dryRun, nil)
+	if result != nil {
+		for _, spec := range result.WouldInstall {
+			output.Printf("  %s %s would be installed\n", output.IconInfo, spec)
+		}
+		for _, spec := range result.Skipped {
+			output.Printf("  %s %s installed\n", output.IconSuccess, spec)
+		}
+		for _, err := range result.Errors {
+			output.Printf("  %s %v\n", output.IconError, err)
+		}
+	}
+	if dryRun {
+		return applyErr
+	}
+
+	if failed := reportToolChecks(cmd, m); failed > 0 && applyErr == nil {
+		return fmt.Errorf("%d tool(s) missing or out of range", failed)
+	}
+	return applyErr
+}
+
+func runProjectCheck(cmd *cobra.Command, args []string) error {
+	m, err := loadProject()
+	if err != nil {
+		return err
+	}
+	lockFile, err := m.Lock()
+	if err != nil {
+		return err
+	}
+
+	missing := 0
+	result, err := packages.ApplyLock(cmd.Context(), lockFile, true, nil)
+	if result != nil {
+		for _, spec := range result.Skipped {
+			output.Printf("  %s %s installed\n", output.IconSuccess, spec)
+		}
+		for _, spec := range result.WouldInstall {
+			output.Printf("  %s %s missing\n", output.IconError, spec)
+		}
+		for _, err := range result.Errors {
+			output.Printf("  %s %v\n", output.IconError, err)
+		}
+		missing = len(result.WouldInstall) + len(result.Failed)
+	} else if err != nil {
+		return err
+	}
+
+	missing += reportToolChecks(cmd, m)
+	if missing > 0 {
+		return fmt.Errorf("%d project requirement(s) not met; run 'plonk project apply'", missing)
+	}
+	return nil
+}
+
+// reportToolChecks prints the tool version checks and returns how many
+// failed
+func reportToolChecks(cmd *cobra.Command, m *project.Manifest) int {
+	failed := 0
+	for _, check := range m.CheckTools(cmd.Context()) {
+		if check.OK {
+			output.Printf("  %s %s %s (%s)\n", output.IconSuccess, check.Command, check.Version, check.Specifier)
+			continue
+		}
+		output.Printf("  %s %s: %s\n", output.IconError, check.Command, check.Problem)
+		failed++
+	}
+	return failed
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/mattn/go-isatty"
+)
+
+// stdinIsTerminal reports whether prompts can be answered interactively
+func stdinIsTerminal() bool {
+	return isatty.IsTerminal(os.Stdin.Fd()) || isatty.IsCygwinTerminal(os.Stdin.Fd())
+}
+
+// promptLine prints question and returns the trimmed answer, or def if the
+// answer is empty or input has ended
+func promptLine(reader *bufio.Reader, out io.Writer, question, def string) (string, error) {
+	fmt.Fprintf(out, "%s [%s]: ", question, def)
+	line, err := reader.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	if answer := strings.TrimSpace(line); answer != "" {
+		return answer, nil
+	}
+	return def, nil
+}
+
+// promptYesNo asks a yes/no question; an empty answer means def
+func promptYesNo(reader *bufio.Reader, out io.Writer, question string, def bool) (bool, error) {
+	hint := "y/N"
+	if def {
+		hint = "Y/n"
+	}
+	for {
+		fmt.Fprintf(out, "%s [%s]: ", question, hint)
+		line, err := reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return false, err
+		}
+		switch strings.ToLower(strings.TrimSpace(line)) {
+		case "":
+			return def, nil
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+		if err == io.EOF {
+			return def, nil
+		}
+		fmt.Fprintln(out, "Please answer y or n.")
+	}
+}
+
+// promptChoice asks for one of keys, e.g. "igs" for [i]nstall, i[g]nore,
+// [s]kip, and returns the key chosen; an empty answer or end of input
+// means def
+func promptChoice(reader *bufio.Reader, out io.Writer, question, keys string, def byte) (byte, error) {
+	for {
+		fmt.Fprintf(out, "%s ", question)
+		line, err := reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return 0, err
+		}
+		answer := strings.ToLower(strings.TrimSpace(line))
+		if answer == "" {
+			return def, nil
+		}
+		if len(answer) == 1 && strings.Contains(keys, answer) {
+			return answer[0], nil
+		}
+		if err == io.EOF {
+			return def, nil
+		}
+		fmt.Fprintf(out, "Please answer one of: %s\n", strings.Join(strings.Split(keys, ""), ", "))
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package commands
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/richhaase/plonk/internal/dotfiles"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPromptYesNo(t *testing.T) {
+	tests := []struct {
+		input string
+		def   bool
+		want  bool
+	}{
+		{"\n", true, true},
+		{"\n", false, false},
+		{"y\n", false, true},
+		{"No\n", true, false},
+		{"maybe\ny\n", false, true},
+		{"", true, true}, // EOF takes the default
+	}
+	for _, tt := range tests {
+		var out bytes.Buffer
+		got, err := promptYesNo(bufio.NewReader(strings.NewReader(tt.input)), &out, "Continue?", tt.def)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, got, "input %q", tt.input)
+	}
+}
+
+func TestPromptLine(t *testing.T) {
+	var out bytes.Buffer
+	got, err := promptLine(bufio.NewReader(strings.NewReader("  cargo \n")), &out, "Manager", "brew")
+	require.NoError(t, err)
+	assert.Equal(t, "cargo", got)
+	assert.Equal(t, "Manager [brew]: ", out.String())
+}
+
+func TestPromptChoice(t *testing.T) {
+	tests := []struct {
+		input string
+		want  byte
+	}{
+		{"i\n", 'i'},
+		{"G\n", 'g'},
+		{"\n", 's'},
+		{"x\nr\n", 'r'},
+		{"", 's'}, // EOF takes the default
+	}
+	for _, tt := range tests {
+		var out bytes.Buffer
+		got, err := promptChoice(bufio.NewReader(strings.NewReader(tt.input)), &out, "Fix?", "igrs", 's')
+		require.NoError(t, err)
+		assert.Equal(t, string(tt.want), string(got), "input %q", tt.input)
+	}
+}
+
+func TestPromptConflict(t *testing.T) {
+	c := dotfiles.Conflict{Name: "zshrc", Target: "/home/user/.zshrc", Diff: "-repo\n+local"}
+
+	var out bytes.Buffer
+	got := promptConflict(bufio.NewReader(strings.NewReader("d\nk\n")), &out, c)
+	assert.Equal(t, dotfiles.ResolveKeepLocal, got)
+	assert.Contains(t, out.String(), "-repo\n+local")
+
+	got = promptConflict(bufio.NewReader(strings.NewReader("\n")), &out, c)
+	assert.Equal(t, dotfiles.ResolveSkip, got)
+
+	// Binary files can't be merged
+	c.Binary = true
+	got = promptConflict(bufio.NewReader(strings.NewReader("m\nt\n")), &out, c)
+	assert.Equal(t, dotfiles.ResolveTakeRepo, got)
+}
@@ -90,6 +90,7 @@ func runPull(cmd *cobra.Command, args []string) error {
 		orch := orchestrator.New(
 			orchestrator.WithConfig(cfg),
 			orchestrator.WithConfigDir(configDir),
+			orchestrator.WithHistory(orchestrator.HistoryPath()),
 			orchestrator.WithHomeDir(homeDir),
 			orchestrator.WithDryRun(false),
 		)
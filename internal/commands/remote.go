@@ -0,0 +1,94 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package commands
+
+import (
+	"fmt"
+
+	"github.com/richhaase/plonk/internal/config"
+	"github.com/richhaase/plonk/internal/output"
+	"github.com/richhaase/plonk/internal/remote"
+	"github.com/spf13/cobra"
+)
+
+var remoteCmd = &cobra.Command{
+	Use:   "remote",
+	Short: "Apply the configuration on other machines over ssh",
+	Long: `Maintain servers and cloud devboxes from this machine's plonk directory.
+
+Commands:
+  apply     Copy the plonk directory to hosts and run plonk apply there`,
+}
+
+var remoteApplyCmd = &cobra.Command{
+	Use:   "apply <host>... [-- apply flags]",
+	Short: "Copy the plonk directory to hosts and run plonk apply there",
+	Long: `Copy the plonk directory to each host over ssh and run 'plonk apply'
+there, with its output streamed back. Hosts are handled one after another.
+
+The copy replaces the host's plonk directory (~/.config/plonk unless
+--remote-dir is given) except its .git directory, so make changes here
+rather than on the host. A host without plonk gets this plonk binary in
+~/.local/bin when it runs the same OS and architecture; otherwise pass
+--binary with a build for the host.
+
+ssh runs in batch mode, so hosts must accept a key or your agent. Flags
+after -- are passed to plonk apply on each host.
+
+Examples:
+  plonk remote apply devbox
+  plonk remote apply admin@web1 admin@web2 -- --packages
+  plonk remote apply devbox -- --dry-run -o json
+  plonk remote apply pi@raspberrypi --binary ~/Downloads/plonk_linux_arm64/plonk
+  plonk remote apply devbox --ssh-option -p --ssh-option 2222`,
+	RunE:         runRemoteApply,
+	SilenceUsage: true,
+	Args:         cobra.MinimumNArgs(1),
+}
+
+func init() {
+	rootCmd.AddCommand(remoteCmd)
+	remoteCmd.AddCommand(remoteApplyCmd)
+	remoteApplyCmd.Flags().String("remote-dir", remote.DefaultDir, "Plonk directory on the hosts")
+	remoteApplyCmd.Flags().String("binary", "", "plonk binary to install on hosts that lack plonk")
+	remoteApplyCmd.Flags().StringArray("ssh-option", nil, "Extra argument for ssh (repeatable)")
+}
+
+func runRemoteApply(cmd *cobra.Command, args []string) error {
+	remoteDir, _ := cmd.Flags().GetString("remote-dir")
+	binary, _ := cmd.Flags().GetString("binary")
+	sshArgs, _ := cmd.Flags().GetStringArray("ssh-option")
+
+	hosts, applyArgs := args, []string(nil)
+	if dash := cmd.ArgsLenAtDash(); dash >= 0 {
+		hosts, applyArgs = args[:dash], args[dash:]
+	}
+	if len(hosts) == 0 {
+		return fmt.Errorf("no host given")
+	}
+
+	var failed []string
+	for _, host := range hosts {
+		opts := remote.Options{
+			Host:      host,
+			Dir:       config.GetDefaultConfigDirectory(),
+			RemoteDir: remoteDir,
+			Binary:    binary,
+			ApplyArgs: applyArgs,
+			SSHArgs:   sshArgs,
+		}
+		err := remote.Apply(cmd.Context(), opts, cmd.OutOrStdout(), cmd.ErrOrStderr(), func(step string) {
+			output.Printf("%s %s...\n", output.IconInfo, step)
+		})
+		if err != nil {
+			output.Printf("%s %s: %v\n", output.IconError, host, err)
+			failed = append(failed, host)
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("remote apply failed on %d of %d hosts", len(failed), len(hosts))
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/richhaase/plonk/internal/config"
+	"github.com/richhaase/plonk/internal/diagnostics"
+	"github.com/richhaase/plonk/internal/dotfiles"
+	"github.com/richhaase/plonk/internal/output"
+	"github.com/richhaase/plonk/internal/packages"
+	"github.com/spf13/cobra"
+)
+
+// managerVersionTimeout bounds one `<manager> --version` for the report
+const managerVersionTimeout = 5 * time.Second
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Render a shareable report of packages, dotfiles and doctor results",
+	Long: `Render a report of the environment for a team wiki or a support request:
+tracked packages by manager (with the manager's version) and whether they
+are installed, managed dotfiles and their state, and the doctor checks.
+
+The report is built locally and written to stdout; nothing is sent
+anywhere. Paths under your home directory are shown as ~. File contents,
+template variables and environment values are not included.
+
+Formats (-o): md (default), html, json or yaml.
+
+Examples:
+  plonk report > plonk-report.md
+  plonk report -o html > plonk-report.html`,
+	RunE:         runReport,
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+	Annotations:  map[string]string{ownOutputFlag: "true"},
+}
+
+func init() {
+	rootCmd.AddCommand(reportCmd)
+	reportCmd.Flags().StringP("output", "o", "md", "Report format (md|html|json|yaml)")
+}
+
+func runReport(cmd *cobra.Command, args []string) error {
+	format, _ := cmd.Flags().GetString("output")
+	format = strings.ToLower(format)
+	switch format {
+	case "md", "markdown", "html", "json", "yaml":
+	default:
+		return fmt.Errorf("unsupported report format: %s (use md, html, json or yaml)", format)
+	}
+
+	configDir := config.GetDefaultConfigDirectory()
+	homeDir, err := config.GetHomeDir()
+	if err != nil {
+		return err
+	}
+	cfg := config.LoadWithDefaults(configDir)
+	ctx, cancel := context.WithTimeout(cmd.Context(), config.GetTimeouts(cfg).Operation)
+	defer cancel()
+
+	report, err := buildReport(ctx, configDir, homeDir, cfg)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case "html":
+		page, err := report.HTML()
+		if err != nil {
+			return err
+		}
+		fmt.Print(page)
+	case "json", "yaml":
+		f, _ := output.ParseOutputFormat(format)
+		output.SetOutputFormat(f)
+		output.RenderOutput(report)
+	default:
+		fmt.Print(report.Markdown())
+	}
+	return nil
+}
+
+// buildReport gathers the report, with paths under homeDir shortened to ~
+func buildReport(ctx context.Context, configDir, homeDir string, cfg *config.Config) (output.Report, error) {
+	report := output.Report{
+		GeneratedAt: time.Now(),
+		Plonk:       formatVersion(),
+		Platform:    runtime.GOOS + "/" + runtime.GOARCH,
+	}
+	redact := func(s string) string {
+		if homeDir == "" {
+			return s
+		}
+		return strings.ReplaceAll(s, homeDir, "~")
+	}
+
+	packages.Configure(cfg)
+	statuses, err := packages.Reconcile(ctx, configDir)
+	if err != nil {
+		return report, err
+	}
+	byManager := make(map[string]*output.ReportManager)
+	for _, s := range statuses {
+		m, ok := byManager[s.Manager]
+		if !ok {
+			m = &output.ReportManager{Name: s.Manager, Version: managerVersion(ctx, s.Manager)}
+			byManager[s.Manager] = m
+		}
+		m.Packages = append(m.Packages, output.ReportPackage{Name: s.Name, State: string(s.State)})
+	}
+	names := make([]string, 0, len(byManager))
+	for name := range byManager {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		report.Managers = append(report.Managers, *byManager[name])
+	}
+
+	dm := dotfiles.NewDotfileManager(configDir, homeDir, cfg.IgnorePatterns)
+	dotfileStatuses, err := dm.Reconcile()
+	if err != nil {
+		return report, err
+	}
+	for _, s := range dotfileStatuses {
+		entry := dotfilesLsEntry(s, configDir)
+		report.Dotfiles = append(report.Dotfiles, output.ReportDotfile{Source: entry.Source, Target: redact(entry.Target), State: entry.State})
+	}
+	sort.Slice(report.Dotfiles, func(i, j int) bool { return report.Dotfiles[i].Source < report.Dotfiles[j].Source })
+
+	health := diagnostics.RunHealthChecksWithContext(ctx)
+	report.Doctor = output.DoctorOutput{
+		Overall: output.HealthStatus{Status: health.Overall.Status, Message: redact(health.Overall.Message)},
+		Checks:  convertHealthChecks(health.Checks),
+	}
+	for i := range report.Doctor.Checks {
+		c := &report.Doctor.Checks[i]
+		c.Message = redact(c.Message)
+		for _, list := range [][]string{c.Details, c.Issues, c.Suggestions} {
+			for j := range list {
+				list[j] = redact(list[j])
+			}
+		}
+	}
+	return report, nil
+}
+
+// managerVersion returns the first line a built-in manager prints for its
+// version, or "" for a plugin or a manager that isn't available
+func managerVersion(ctx context.Context, manager string) string {
+	if !slices.Contains(packages.SupportedManagers, manager) {
+		return ""
+	}
+	ctx, cancel := context.WithTimeout(ctx, managerVersionTimeout)
+	defer cancel()
+	line, err := packages.ManagerVersionLine(ctx, manager)
+	if err != nil {
+		return ""
+	}
+	return line
+}
@@ -55,6 +55,7 @@ Examples:
   plonk rm --dry-run ~/.zshrc ~/.vimrc # Preview what would be removed`,
 	Args:         cobra.MinimumNArgs(1),
 	RunE:         runRm,
+	SuggestFor:   []string{"remove", "delete"},
 	SilenceUsage: true,
 }
 
@@ -4,9 +4,14 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
+	"path/filepath"
 
+	"github.com/richhaase/plonk/internal/config"
+	"github.com/richhaase/plonk/internal/i18n"
 	"github.com/richhaase/plonk/internal/output"
+	"github.com/richhaase/plonk/internal/packages"
 	"github.com/spf13/cobra"
 )
 
@@ -21,14 +26,39 @@ type VersionInfo struct {
 	Date    string
 }
 
+// ownOutputFlag annotates a command whose -o flag takes formats other than
+// table, json and yaml
+const ownOutputFlag = "plonk/own-output"
+
 var rootCmd = &cobra.Command{
 	Use:   "plonk",
 	Short: "A developer environment manager",
 	Long: `Plonk manages your development environment by installing packages
 and managing dotfiles across multiple package managers.`,
-	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 		// Initialize color support based on terminal capabilities and NO_COLOR env var
 		output.InitColors()
+
+		// Commands with their own -o formats (plonk report) parse it themselves
+		if cmd.Annotations[ownOutputFlag] == "" {
+			formatStr, _ := cmd.Flags().GetString("output")
+			format, err := output.ParseOutputFormat(formatStr)
+			if err != nil {
+				return err
+			}
+			output.SetOutputFormat(format)
+		}
+
+		summary, _ := cmd.Flags().GetString("summary")
+		if err := output.SetSummaryFormat(summary); err != nil {
+			return err
+		}
+		quiet, _ := cmd.Flags().GetBool("quiet")
+		output.SetQuiet(quiet || summary != "")
+
+		// Remember where package managers live so each run doesn't search PATH again
+		packages.EnableAvailabilityCache(filepath.Join(config.GetStateDirectory(), "managers.json"))
+		return nil
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if version, _ := cmd.Flags().GetBool("version"); version {
@@ -37,11 +67,16 @@ and managing dotfiles across multiple package managers.`,
 		}
 		return cmd.Help()
 	},
+	Args:         unknownCommand,
 	SilenceUsage: true,
 }
 
 func init() {
 	rootCmd.Flags().BoolP("version", "v", false, "Show version information")
+	rootCmd.PersistentFlags().StringP("output", "o", "table", "Output format (table|json|yaml)")
+	rootCmd.PersistentFlags().BoolP("quiet", "q", false, "Print only the final summary line and errors")
+	rootCmd.PersistentFlags().String("summary", "", "Print only a one-line summary (text|json)")
+	rootCmd.SetFlagErrorFunc(handleFlagError)
 }
 
 // ExecuteWithExitCode runs the root command and returns appropriate exit code
@@ -51,8 +86,22 @@ func ExecuteWithExitCode(version, commit, date string) int {
 		Commit:  commit,
 		Date:    date,
 	}
+	// Detect only returns supported locales, so this can't fail
+	_ = i18n.SetLocale(i18n.Detect())
+	rootCmd.SetErrPrefix(i18n.T("Error:"))
+
 	err := rootCmd.Execute()
+	var pending *pendingChangesError
+	if errors.As(err, &pending) {
+		// The command already rendered what would change
+		return 2
+	}
 	if err != nil {
+		// Human-readable errors go to stderr via cobra; structured formats
+		// also get a parseable error document on stdout
+		if output.IsStructured() {
+			output.RenderOutput(newErrorOutput(err))
+		}
 		return 1
 	}
 	return 0
@@ -4,9 +4,13 @@
 package commands
 
 import (
+	"io"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestFormatVersion(t *testing.T) {
@@ -67,3 +71,29 @@ func TestFormatVersion(t *testing.T) {
 		})
 	}
 }
+
+// Help and completion must not load config or touch the plonk directories;
+// commands do that work in RunE, only for the command that was invoked.
+func TestHelpDoesNotTouchFilesystem(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("PLONK_DIR", filepath.Join(home, "plonk"))
+	t.Setenv("XDG_STATE_HOME", filepath.Join(home, "state"))
+
+	for _, args := range [][]string{
+		{"--help"},
+		{"status", "--help"},
+		{"apply", "--help"},
+		{"__complete", "add", ""},
+	} {
+		rootCmd.SetArgs(args)
+		rootCmd.SetOut(io.Discard)
+		require.NoError(t, rootCmd.Execute(), "plonk %v", args)
+	}
+	rootCmd.SetArgs(nil)
+	rootCmd.SetOut(nil)
+
+	entries, err := os.ReadDir(home)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
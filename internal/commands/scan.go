@@ -0,0 +1,73 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package commands
+
+import (
+	"errors"
+
+	"github.com/richhaase/plonk/internal/config"
+	"github.com/richhaase/plonk/internal/output"
+	"github.com/richhaase/plonk/internal/secrets"
+	"github.com/spf13/cobra"
+)
+
+var scanCmd = &cobra.Command{
+	Use:   "scan",
+	Short: "Scan the plonk directory for problems",
+	Long: `Scan the plonk directory for problems before they are committed or synced.
+
+Commands:
+  secrets  Find probable secrets such as private keys and API tokens`,
+}
+
+var scanSecretsCmd = &cobra.Command{
+	Use:   "secrets [path...]",
+	Short: "Find probable secrets such as private keys and API tokens",
+	Long: `Scan $PLONK_DIR, or the given files and directories, for credentials
+that shouldn't be committed: private keys without a passphrase, AWS access
+keys, and GitHub, GitLab, Slack, Stripe, Google, npm, OpenAI and Anthropic
+tokens. The detectors only match those formats, so findings are rarely
+false positives. Binary files and .git directories are skipped.
+
+'plonk add' and 'plonk sync' run the same scan on the files they are about
+to store and stop when it finds anything, unless given --allow-secrets. For
+a match that isn't a secret, such as a documented example, add a comment
+containing plonk:allow-secret to its line.
+
+Exits non-zero when anything is found.
+
+Examples:
+  plonk scan secrets
+  plonk scan secrets ~/.config/gh
+  plonk scan secrets -o json`,
+	RunE:         runScanSecrets,
+	SilenceUsage: true,
+}
+
+func init() {
+	rootCmd.AddCommand(scanCmd)
+	scanCmd.AddCommand(scanSecretsCmd)
+}
+
+func runScanSecrets(cmd *cobra.Command, args []string) error {
+	paths := args
+	if len(paths) == 0 {
+		paths = []string{config.GetDefaultConfigDirectory()}
+	}
+
+	result := output.SecretScanOutput{Paths: paths}
+	for _, path := range paths {
+		findings, err := secrets.ScanPath(path)
+		if err != nil {
+			return err
+		}
+		result.Findings = append(result.Findings, findings...)
+	}
+	output.RenderOutput(result)
+
+	if len(result.Findings) > 0 {
+		return errors.New("probable secrets found")
+	}
+	return nil
+}
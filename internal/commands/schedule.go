@@ -0,0 +1,163 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/richhaase/plonk/internal/config"
+	"github.com/richhaase/plonk/internal/output"
+	"github.com/richhaase/plonk/internal/schedule"
+	"github.com/spf13/cobra"
+)
+
+// scheduleActions maps --run values to the plonk arguments the job runs
+var scheduleActions = map[string][]string{
+	"status": {"status"},
+	"apply":  {"apply"},
+}
+
+var scheduleCmd = &cobra.Command{
+	Use:   "schedule",
+	Short: "Run plonk periodically in the background",
+	Long: `Register plonk with the system's user service manager so it runs on a
+timer: a launchd agent on macOS, a systemd user timer on Linux.
+
+Commands:
+  install     Install or replace the scheduled job
+  uninstall   Remove the scheduled job`,
+}
+
+var scheduleInstallCmd = &cobra.Command{
+	Use:   "install",
+	Short: "Install a launchd/systemd timer that runs plonk",
+	Long: `Install a launchd agent (macOS) or systemd user timer (Linux) that runs
+plonk on a fixed interval. Output from each run is appended to
+$XDG_STATE_HOME/plonk/plonk.log (default ~/.local/state/plonk/plonk.log).
+
+--run selects what the job does:
+  status   Report drift between plonk.lock/dotfiles and the system (default)
+  apply    Install missing packages and deploy dotfiles
+
+The job inherits the current PATH and PLONK_DIR so it finds the same
+package managers and config directory as your shell. Re-run install to
+change the schedule.
+
+Examples:
+  plonk schedule install                      # Drift report once a day
+  plonk schedule install --every 6h --run apply
+  plonk schedule install --dry-run            # Print the unit files only`,
+	RunE:         runScheduleInstall,
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+}
+
+var scheduleUninstallCmd = &cobra.Command{
+	Use:   "uninstall",
+	Short: "Remove the scheduled plonk job",
+	Long: `Stop and remove the launchd agent or systemd user timer installed by
+'plonk schedule install'. The log file is kept.
+
+Examples:
+  plonk schedule uninstall`,
+	RunE:         runScheduleUninstall,
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+}
+
+func init() {
+	rootCmd.AddCommand(scheduleCmd)
+	scheduleCmd.AddCommand(scheduleInstallCmd)
+	scheduleCmd.AddCommand(scheduleUninstallCmd)
+
+	scheduleInstallCmd.Flags().Duration("every", 24*time.Hour, "Time between runs (minimum 5m)")
+	scheduleInstallCmd.Flags().String("run", "status", "What to run: status or apply")
+	scheduleInstallCmd.Flags().BoolP("dry-run", "n", false, "Print the unit files without installing them")
+}
+
+func runScheduleInstall(cmd *cobra.Command, args []string) error {
+	every, _ := cmd.Flags().GetDuration("every")
+	action, _ := cmd.Flags().GetString("run")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	job, err := buildScheduleJob(action, every)
+	if err != nil {
+		return err
+	}
+	homeDir, err := config.GetHomeDir()
+	if err != nil {
+		return err
+	}
+
+	if dryRun {
+		if err := job.Validate(); err != nil {
+			return err
+		}
+		files, err := schedule.Files(job, homeDir)
+		if err != nil {
+			return err
+		}
+		for _, f := range files {
+			fmt.Printf("# %s\n%s\n", f.Path, f.Content)
+		}
+		return nil
+	}
+
+	files, err := schedule.Install(cmd.Context(), job, homeDir)
+	for _, f := range files {
+		output.Printf("Wrote %s\n", f.Path)
+	}
+	if err != nil {
+		return err
+	}
+	output.Printf("Scheduled 'plonk %s' every %s; logging to %s\n", action, every, job.LogPath)
+	return nil
+}
+
+func runScheduleUninstall(cmd *cobra.Command, args []string) error {
+	homeDir, err := config.GetHomeDir()
+	if err != nil {
+		return err
+	}
+	removed, err := schedule.Uninstall(cmd.Context(), homeDir)
+	for _, path := range removed {
+		output.Printf("Removed %s\n", path)
+	}
+	if err != nil {
+		return err
+	}
+	if len(removed) == 0 {
+		output.Printf("No scheduled job installed\n")
+	}
+	return nil
+}
+
+// buildScheduleJob resolves the plonk binary and environment for a scheduled run
+func buildScheduleJob(action string, every time.Duration) (schedule.Job, error) {
+	jobArgs, ok := scheduleActions[action]
+	if !ok {
+		return schedule.Job{}, fmt.Errorf("invalid --run %q: must be status or apply", action)
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return schedule.Job{}, fmt.Errorf("cannot locate plonk executable: %w", err)
+	}
+
+	env := map[string]string{"PATH": os.Getenv("PATH")}
+	if os.Getenv("PLONK_DIR") != "" {
+		env["PLONK_DIR"] = config.GetDefaultConfigDirectory()
+	}
+
+	return schedule.Job{
+		Executable: exe,
+		Args:       jobArgs,
+		Interval:   every,
+		LogPath:    filepath.Join(config.GetStateDirectory(), "plonk.log"),
+		Env:        env,
+	}, nil
+}
@@ -0,0 +1,30 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package commands
+
+import (
+	"os"
+
+	"github.com/richhaase/plonk/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// addSchemaFlag adds --schema to a command with a published output schema
+func addSchemaFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool("schema", false, "Print the JSON schema of this command's -o json output and exit")
+}
+
+// printSchemaIfRequested prints the named output schema when --schema is
+// set, and reports whether it did; the command should then return err
+func printSchemaIfRequested(cmd *cobra.Command, name string) (bool, error) {
+	if show, _ := cmd.Flags().GetBool("schema"); !show {
+		return false, nil
+	}
+	data, err := output.Schema(name)
+	if err != nil {
+		return true, err
+	}
+	_, err = os.Stdout.Write(data)
+	return true, err
+}
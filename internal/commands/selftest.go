@@ -0,0 +1,75 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package commands
+
+import (
+	"errors"
+
+	"github.com/richhaase/plonk/internal/config"
+	"github.com/richhaase/plonk/internal/output"
+	"github.com/richhaase/plonk/internal/selftest"
+	"github.com/spf13/cobra"
+)
+
+var selftestCmd = &cobra.Command{
+	Use:   "selftest [dir]",
+	Short: "Apply the configuration in clean Linux containers",
+	Long: `Apply the plonk directory in a fresh Docker container of each image and
+report how it went on each distribution, to validate a configuration before
+trusting it on a new machine. By default Ubuntu, Fedora and Arch Linux are
+tested; repeat --docker to choose images.
+
+Each container gets the plonk directory (mounted read-only and copied to
+~/.config/plonk, as clone would) and a plonk binary, then runs 'plonk
+apply'. Containers are removed afterwards. Packages from managers an image
+lacks fail there, which is what a new machine would see too.
+
+The running plonk is used when it is a Linux binary; on macOS pass --binary
+with a Linux build of plonk for the containers' architecture.
+
+Examples:
+  plonk selftest
+  plonk selftest --docker ubuntu:24.04
+  plonk selftest --docker debian:12 --docker fedora:41 ~/src/dotfiles
+  plonk selftest --binary ~/Downloads/plonk_linux_arm64/plonk`,
+	RunE:         runSelftest,
+	SilenceUsage: true,
+	Args:         cobra.MaximumNArgs(1),
+}
+
+func init() {
+	rootCmd.AddCommand(selftestCmd)
+	selftestCmd.Flags().StringSlice("docker", selftest.DefaultImages, "Docker image to test in (repeatable)")
+	selftestCmd.Flags().String("binary", "", "Linux plonk binary to run in the containers")
+}
+
+func runSelftest(cmd *cobra.Command, args []string) error {
+	images, _ := cmd.Flags().GetStringSlice("docker")
+	binary, _ := cmd.Flags().GetString("binary")
+
+	opts := selftest.Options{Dir: config.GetDefaultConfigDirectory(), Binary: binary, Images: images}
+	if len(args) > 0 {
+		opts.Dir = args[0]
+	}
+	if opts.Binary == "" {
+		exe, err := selftest.LinuxBinary()
+		if err != nil {
+			return err
+		}
+		opts.Binary = exe
+	}
+
+	result, err := selftest.Run(cmd.Context(), opts, func(image string) {
+		output.Printf("Applying in %s...\n", image)
+	})
+	if err != nil {
+		return err
+	}
+	output.RenderOutput(result)
+
+	if result.Failed() {
+		return errors.New("self test failed")
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package commands
+
+import (
+	"fmt"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/richhaase/plonk/internal/config"
+	"github.com/richhaase/plonk/internal/daemon"
+	"github.com/richhaase/plonk/internal/notify"
+	"github.com/richhaase/plonk/internal/output"
+	"github.com/richhaase/plonk/pkg/plonk"
+	"github.com/spf13/cobra"
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve status and apply over a local socket",
+	Long: `Run plonk as a long-lived process that answers JSON requests over a
+unix socket. Package manager state is cached between requests, so
+repeated status queries are fast.
+
+Endpoints:
+  GET  /v1/status[?refresh=true]             Reconciled state (refresh re-lists managers)
+  POST /v1/apply[?dry_run=true&packages=true&dotfiles=true]
+  GET  /v1/events                            Newline-delimited JSON event stream
+
+The socket is only accessible to the current user. Restart the server
+after editing plonk.yaml.
+
+With notifications.enabled set in plonk.yaml, the server sends desktop
+notifications (osascript on macOS, notify-send on Linux) when an apply
+completes or fails and when a status check finds drift. Drift checks run
+every --check-interval (default 30m when drift notifications are on).
+
+Examples:
+  plonk serve                                # Listen on the default socket
+  plonk serve --socket /tmp/plonk.sock       # Listen on a specific socket
+  plonk serve --check-interval 10m           # Check for drift every 10 minutes
+  curl --unix-socket /tmp/plonk.sock http://plonk/v1/status`,
+	RunE:         runServe,
+	SilenceUsage: true,
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+	serveCmd.Flags().String("socket", "", "Unix socket path (default: $XDG_RUNTIME_DIR/plonk.sock)")
+	serveCmd.Flags().Duration("check-interval", 0, "Publish a status check this often (0: 30m if drift notifications are enabled, otherwise off)")
+}
+
+// defaultCheckInterval is used for drift notifications when --check-interval is unset
+const defaultCheckInterval = 30 * time.Minute
+
+func runServe(cmd *cobra.Command, args []string) error {
+	socketPath, _ := cmd.Flags().GetString("socket")
+	if socketPath == "" {
+		socketPath = daemon.DefaultSocketPath()
+	}
+
+	checkInterval, _ := cmd.Flags().GetDuration("check-interval")
+
+	configDir := config.GetDefaultConfigDirectory()
+	client, err := plonk.New(plonk.WithConfigDir(configDir))
+	if err != nil {
+		return err
+	}
+	cfg := config.LoadWithDefaults(configDir)
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	srv := daemon.New(client)
+	if cfg.Notifications.Enabled {
+		go srv.WatchNotifications(ctx, notify.NewDesktopNotifier(), cfg.Notifications.NotifyOn)
+		if checkInterval == 0 && cfg.Notifications.NotifyOn(daemon.NotifyDrift) {
+			checkInterval = defaultCheckInterval
+		}
+	}
+	if checkInterval > 0 {
+		go srv.WatchStatus(ctx, checkInterval)
+	}
+
+	output.Printf("Serving plonk API on %s\n", socketPath)
+	if err := srv.Serve(ctx, socketPath); err != nil {
+		return fmt.Errorf("serve failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package commands
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/richhaase/plonk/internal/config"
+	"github.com/richhaase/plonk/internal/output"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// promptBudget is how long plonk prompt may take; shells run it before
+	// every prompt, so past this it prints nothing rather than lag
+	promptBudget = 50 * time.Millisecond
+
+	// promptRefreshTimeout is how long a background refresh may run before
+	// another prompt assumes it died and starts a new one
+	promptRefreshTimeout = 2 * time.Minute
+)
+
+var promptCmd = &cobra.Command{
+	Use:   "prompt",
+	Short: "Print a tiny drift token for shell prompts",
+	Long: `Print a short token for a shell prompt: ✗3 when three packages or
+dotfiles are missing, drifted or failing, and nothing when all is in sync.
+
+The token comes from the counts 'plonk status --counts' caches, so plonk
+prompt never checks packages itself and gives up after 50ms. When the cache
+is older than --max-age, or plonk.yaml or plonk.lock changed since, the last
+token is printed and a refresh starts in the background; a later prompt
+shows its result.
+
+Examples:
+  plonk prompt                       # ✗3, or nothing when in sync
+  plonk prompt --synced ✓            # Show ✓ when in sync
+
+  # zsh (~/.zshrc)
+  setopt prompt_subst
+  PROMPT='$(plonk prompt) '$PROMPT
+
+  # starship (~/.config/starship.toml)
+  [custom.plonk]
+  command = "plonk prompt"
+  when = true`,
+	Args:         cobra.NoArgs,
+	RunE:         runPrompt,
+	SilenceUsage: true,
+}
+
+func init() {
+	rootCmd.AddCommand(promptCmd)
+	promptCmd.Flags().Duration("max-age", 5*time.Minute, "Refresh cached counts older than this in the background")
+	promptCmd.Flags().String("synced", "", "Text to print when everything is in sync")
+	promptCmd.Flags().Bool("refresh", false, "Recount and update the cache (run in the background by plonk prompt)")
+	_ = promptCmd.Flags().MarkHidden("refresh")
+}
+
+func runPrompt(cmd *cobra.Command, args []string) error {
+	maxAge, _ := cmd.Flags().GetDuration("max-age")
+	synced, _ := cmd.Flags().GetString("synced")
+	configDir := config.GetDefaultConfigDirectory()
+	cachePath := summaryCachePath(configDir)
+
+	if refresh, _ := cmd.Flags().GetBool("refresh"); refresh {
+		return refreshPromptCache(cmd, configDir, cachePath)
+	}
+
+	type cached struct {
+		counts output.StatusCounts
+		fresh  bool
+	}
+	read := make(chan cached, 1)
+	go func() {
+		counts, fresh := readSummaryCache(cachePath, configDir, maxAge)
+		read <- cached{counts, fresh}
+	}()
+	select {
+	case c := <-read:
+		fmt.Fprint(cmd.OutOrStdout(), output.FormatPromptToken(c.counts, synced))
+		// Started here rather than in the reader, which the process may
+		// exit under once the budget is spent
+		if !c.fresh {
+			startPromptRefresh(cachePath)
+		}
+	case <-time.After(promptBudget):
+	}
+	return nil
+}
+
+// startPromptRefresh recounts status in a detached plonk process, unless
+// one started recently is still at it
+func startPromptRefresh(cachePath string) {
+	lockPath := cachePath + ".refresh"
+	if info, err := os.Stat(lockPath); err == nil && time.Since(info.ModTime()) < promptRefreshTimeout {
+		return
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(lockPath), 0o750); err != nil {
+		return
+	}
+	if err := os.WriteFile(lockPath, nil, 0o600); err != nil {
+		return
+	}
+
+	if err := startRefresh(exe); err != nil {
+		_ = os.Remove(lockPath)
+	}
+}
+
+// startRefresh runs exe prompt --refresh without waiting for it. It is a
+// variable so tests don't start plonk, which under go test is the test
+// binary.
+var startRefresh = func(exe string) error {
+	// No stdio: the shell waits for the prompt's output to close, not for
+	// this process
+	refresh := exec.Command(exe, "prompt", "--refresh")
+	detach(refresh)
+	if err := refresh.Start(); err != nil {
+		return err
+	}
+	return refresh.Process.Release()
+}
+
+// refreshPromptCache recounts status and caches the counts for later prompts
+func refreshPromptCache(cmd *cobra.Command, configDir, cachePath string) error {
+	defer os.Remove(cachePath + ".refresh")
+
+	homeDir, err := config.GetHomeDir()
+	if err != nil {
+		return fmt.Errorf("cannot determine home directory: %w", err)
+	}
+	counts, err := countStatus(cmd.Context(), configDir, homeDir, config.LoadWithDefaults(configDir))
+	if err != nil {
+		return err
+	}
+	return writeSummaryCache(cachePath, counts)
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/richhaase/plonk/internal/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartPromptRefresh_SkipsWhileOneRuns(t *testing.T) {
+	cachePath := filepath.Join(t.TempDir(), "summary.json")
+	lockPath := cachePath + ".refresh"
+	require.NoError(t, os.WriteFile(lockPath, nil, 0o600))
+	stamp := time.Now().Add(-time.Minute)
+	require.NoError(t, os.Chtimes(lockPath, stamp, stamp))
+
+	startPromptRefresh(cachePath)
+
+	info, err := os.Stat(lockPath)
+	require.NoError(t, err)
+	assert.WithinDuration(t, stamp, info.ModTime(), time.Second, "a recent refresh is left to finish")
+}
+
+func TestRunPrompt_PrintsCachedToken(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("PLONK_DIR", configDir)
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	require.NoError(t, writeSummaryCache(summaryCachePath(configDir), output.StatusCounts{Managed: 5, Drifted: 2, GeneratedAt: time.Now()}))
+
+	var out bytes.Buffer
+	promptCmd.SetOut(&out)
+	t.Cleanup(func() { promptCmd.SetOut(nil) })
+	require.NoError(t, runPrompt(promptCmd, nil))
+	assert.Equal(t, "✗2", out.String())
+}
+
+func TestRunPrompt_StartsRefreshBeforeReturning(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("PLONK_DIR", configDir)
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	cachePath := summaryCachePath(configDir)
+	require.NoError(t, writeSummaryCache(cachePath, output.StatusCounts{Managed: 5, GeneratedAt: time.Now().Add(-time.Hour)}))
+
+	var started []string
+	orig := startRefresh
+	startRefresh = func(exe string) error {
+		started = append(started, exe)
+		return nil
+	}
+	t.Cleanup(func() { startRefresh = orig })
+
+	var out bytes.Buffer
+	promptCmd.SetOut(&out)
+	t.Cleanup(func() { promptCmd.SetOut(nil) })
+	require.NoError(t, runPrompt(promptCmd, nil))
+	assert.Empty(t, out.String(), "the stale counts are in sync")
+	assert.Len(t, started, 1, "the refresh starts before plonk prompt exits")
+	assert.FileExists(t, cachePath+".refresh")
+}
+
+func TestStartPromptRefresh_ReleasesLockOnFailure(t *testing.T) {
+	orig := startRefresh
+	startRefresh = func(string) error { return errors.New("exec failed") }
+	t.Cleanup(func() { startRefresh = orig })
+
+	cachePath := filepath.Join(t.TempDir(), "summary.json")
+	startPromptRefresh(cachePath)
+	assert.NoFileExists(t, cachePath+".refresh", "a failed start doesn't block the next prompt")
+}
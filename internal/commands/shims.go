@@ -0,0 +1,93 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/richhaase/plonk/internal/config"
+	"github.com/richhaase/plonk/internal/lock"
+	"github.com/richhaase/plonk/internal/output"
+	"github.com/richhaase/plonk/internal/packages"
+	"github.com/spf13/cobra"
+)
+
+var shimsCmd = &cobra.Command{
+	Use:   "shims",
+	Short: "Link tracked packages' commands into plonk's shim directory",
+	Long: `Rebuild the shim directory: one link per command of a tracked package,
+pointing at the copy installed by the manager that tracks it.
+
+Put the shim directory first on PATH and a command that several managers
+provide (ripgrep from both brew and cargo) always runs the tracked copy,
+whatever order the managers' own directories have on PATH. When more than
+one tracked package provides a command, the one whose manager comes first
+in manager_priority wins. 'plonk which' shows what a command resolves to.
+
+With shims: true in plonk.yaml, 'plonk apply' rebuilds the shims after
+installing packages.
+
+Examples:
+  plonk shims             # Rebuild the shims
+  plonk shims --dry-run   # Show what would change`,
+	RunE:         runShims,
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+}
+
+func init() {
+	rootCmd.AddCommand(shimsCmd)
+	shimsCmd.Flags().BoolP("dry-run", "n", false, "Show what would change without touching the shim directory")
+}
+
+func runShims(cmd *cobra.Command, args []string) error {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	configDir := config.GetDefaultConfigDirectory()
+	cfg := config.LoadWithDefaults(configDir)
+	lockFile, err := lock.NewLockV3Service(configDir).Read()
+	if err != nil {
+		return fmt.Errorf("failed to read lock file: %w", err)
+	}
+
+	shimDir := config.GetShimDirectory()
+	shims, unresolved := packages.PlanShims(lockFile, cfg.ManagerOrder(), os.Getenv("PATH"), shimDir)
+	changes, err := packages.WriteShims(shimDir, shims, dryRun)
+	if err != nil {
+		return fmt.Errorf("failed to update shims in %s: %w", shimDir, err)
+	}
+
+	verb := ""
+	if dryRun {
+		verb = "would be "
+	}
+	for _, name := range changes.Added {
+		output.Printf("  %s %s %sadded\n", output.IconSuccess, name, verb)
+	}
+	for _, name := range changes.Updated {
+		output.Printf("  %s %s %supdated\n", output.IconSuccess, name, verb)
+	}
+	for _, name := range changes.Removed {
+		output.Printf("  %s %s %sremoved\n", output.IconSuccess, name, verb)
+	}
+	for _, shim := range shims {
+		if len(shim.Shadowed) > 0 {
+			output.Printf("  %s %s: %s wins over %s\n", output.IconInfo, shim.Binary, shim.Spec, strings.Join(shim.Shadowed, ", "))
+		}
+	}
+	for _, name := range changes.Kept {
+		output.Printf("  %s %s is not a link plonk made; left alone\n", output.IconWarning, name)
+	}
+	if len(unresolved) > 0 {
+		output.Printf("  %s No command found for %s\n", output.IconWarning, strings.Join(unresolved, ", "))
+	}
+
+	output.Printf("%d shim(s) in %s\n", len(shims), shimDir)
+	if !packages.ShimDirFirst(os.Getenv("PATH"), shimDir) {
+		output.Printf("Put the shim directory first on PATH to use them:\n  export PATH=\"%s:$PATH\"\n", shimDir)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package commands
+
+import (
+	"errors"
+
+	"github.com/richhaase/plonk/internal/clone"
+	"github.com/richhaase/plonk/internal/config"
+	"github.com/richhaase/plonk/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var simulateCmd = &cobra.Command{
+	Use:   "simulate [dir|repo]",
+	Short: "Show what clone and apply would do on a fresh machine",
+	Long: `Plan 'plonk clone' followed by 'plonk apply' for a machine with nothing
+installed and an empty home directory, and report every package that would
+be installed and every dotfile that would be deployed. Nothing is installed,
+deployed or run, and the real system is not looked at, so the result is the
+same on any machine.
+
+The argument is a local plonk directory, such as a checkout of a pull
+request to your dotfiles repository, or a repository in any form 'plonk
+clone' accepts, which is cloned to a temporary directory. Without an
+argument the current plonk directory is used.
+
+Templates are rendered against the current environment; a missing variable
+is reported as a problem, as is a lock signature that does not verify. The
+command exits non-zero when there are problems.
+
+Examples:
+  plonk simulate
+  plonk simulate .
+  plonk simulate user/dotfiles
+  plonk simulate https://github.com/user/dotfiles.git -o json`,
+	RunE:         runSimulate,
+	SilenceUsage: true,
+	Args:         cobra.MaximumNArgs(1),
+}
+
+func init() {
+	rootCmd.AddCommand(simulateCmd)
+}
+
+func runSimulate(cmd *cobra.Command, args []string) error {
+	source := config.GetDefaultConfigDirectory()
+	if len(args) > 0 {
+		source = args[0]
+	}
+
+	result, err := clone.Simulate(cmd.Context(), source)
+	if err != nil {
+		return err
+	}
+	output.RenderOutput(result)
+
+	if result.Failed() {
+		return errors.New("the simulated apply has problems")
+	}
+	return nil
+}
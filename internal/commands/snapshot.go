@@ -0,0 +1,202 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/richhaase/plonk/internal/config"
+	"github.com/richhaase/plonk/internal/gitops"
+	"github.com/richhaase/plonk/internal/orchestrator"
+	"github.com/richhaase/plonk/internal/output"
+	"github.com/richhaase/plonk/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Save and restore rollback points of the plonk directory",
+	Long: `Save the whole plonk directory (plonk.yaml, plonk.lock and dotfile
+sources, everything except .git) as a timestamped archive, and roll back to
+it later.
+
+Snapshots are kept on this machine in $XDG_STATE_HOME/plonk/snapshots. They
+are not uploaded to the sync backend, which holds a single copy used by
+'plonk sync'.
+
+Commands:
+  create    Save a snapshot
+  list      Show saved snapshots
+  restore   Roll the plonk directory back to a snapshot`,
+}
+
+var snapshotCreateCmd = &cobra.Command{
+	Use:   "create [label]",
+	Short: "Save a snapshot of the plonk directory",
+	Long: `Save the plonk directory as a new snapshot. The snapshot is named after
+the current time, followed by the label if one is given.
+
+Examples:
+  plonk snapshot create
+  plonk snapshot create before-upgrade`,
+	RunE:         runSnapshotCreate,
+	SilenceUsage: true,
+	Args:         cobra.MaximumNArgs(1),
+}
+
+var snapshotListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Show saved snapshots",
+	Long: `Show saved snapshots, newest first.
+
+Examples:
+  plonk snapshot list
+  plonk snapshot list -o json`,
+	RunE:         runSnapshotList,
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+}
+
+var snapshotRestoreCmd = &cobra.Command{
+	Use:   "restore <name|latest>",
+	Short: "Roll the plonk directory back to a snapshot",
+	Long: `Make the plonk directory match a snapshot: files are rewritten, and files
+added since the snapshot are removed. The .git directory is left alone.
+
+The current state is saved as a "pre-restore" snapshot first, so a restore
+can itself be undone. Use --apply to also bring this machine in line with
+the restored configuration.
+
+Examples:
+  plonk snapshot restore latest --dry-run
+  plonk snapshot restore 20250101-120000-before-upgrade
+  plonk snapshot restore latest --apply`,
+	RunE:         runSnapshotRestore,
+	SilenceUsage: true,
+	Args:         cobra.ExactArgs(1),
+}
+
+func init() {
+	rootCmd.AddCommand(snapshotCmd)
+	snapshotCmd.AddCommand(snapshotCreateCmd)
+	snapshotCmd.AddCommand(snapshotListCmd)
+	snapshotCmd.AddCommand(snapshotRestoreCmd)
+
+	snapshotRestoreCmd.Flags().BoolP("dry-run", "n", false, "Show what would change without restoring")
+	snapshotRestoreCmd.Flags().BoolP("apply", "a", false, "Run plonk apply after restoring")
+}
+
+// snapshotDir returns where snapshot archives are kept on this machine
+func snapshotDir() string {
+	return filepath.Join(config.GetStateDirectory(), "snapshots")
+}
+
+func runSnapshotCreate(cmd *cobra.Command, args []string) error {
+	var label string
+	if len(args) > 0 {
+		label = args[0]
+	}
+
+	info, err := storage.CreateArchive(snapshotDir(), config.GetDefaultConfigDirectory(), label, time.Now())
+	if err != nil {
+		return err
+	}
+	output.Printf("%s Created snapshot %s\n", output.Success(), info.Name)
+	return nil
+}
+
+func runSnapshotList(cmd *cobra.Command, args []string) error {
+	dir := snapshotDir()
+	archives, err := storage.ListArchives(dir)
+	if err != nil {
+		return fmt.Errorf("failed to list snapshots: %w", err)
+	}
+
+	result := output.SnapshotListOutput{Directory: dir, Snapshots: []output.SnapshotEntry{}}
+	for _, a := range archives {
+		result.Snapshots = append(result.Snapshots, output.SnapshotEntry{
+			Name:      a.Name,
+			CreatedAt: a.CreatedAt,
+			Size:      a.Size,
+		})
+	}
+	output.RenderOutput(result)
+	return nil
+}
+
+func runSnapshotRestore(cmd *cobra.Command, args []string) error {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	applyAfter, _ := cmd.Flags().GetBool("apply")
+
+	ctx := cmd.Context()
+	configDir := config.GetDefaultConfigDirectory()
+	dir := snapshotDir()
+
+	name := args[0]
+	if name == "latest" {
+		archives, err := storage.ListArchives(dir)
+		if err != nil {
+			return fmt.Errorf("failed to list snapshots: %w", err)
+		}
+		if len(archives) == 0 {
+			return fmt.Errorf("no snapshots yet; create one with 'plonk snapshot create'")
+		}
+		name = archives[0].Name
+	}
+
+	target, err := storage.LoadArchive(dir, name)
+	if err != nil {
+		return err
+	}
+	current, err := storage.Capture(configDir)
+	if err != nil {
+		return err
+	}
+
+	changes := storage.Diff(current, target)
+	for _, change := range changes {
+		output.Printf("  %s\n", change)
+	}
+	switch {
+	case len(changes) == 0:
+		output.Printf("Already matches snapshot %s\n", name)
+		return nil
+	case dryRun:
+		output.Printf("Dry run: would restore %s (%d file changes)\n", name, len(changes))
+		return nil
+	}
+
+	safety, err := storage.CreateArchive(dir, configDir, "pre-restore", time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to save current state before restoring: %w", err)
+	}
+	if err := storage.Restore(configDir, target); err != nil {
+		return fmt.Errorf("failed to restore %s (previous state saved as %s): %w", name, safety.Name, err)
+	}
+	output.Printf("%s Restored snapshot %s: %d file changes (previous state saved as %s)\n",
+		output.Success(), name, len(changes), safety.Name)
+	gitops.AutoCommit(ctx, configDir, "snapshot restore", []string{name})
+
+	if applyAfter {
+		output.Println("Applying configuration...")
+		homeDir, err := config.GetHomeDir()
+		if err != nil {
+			return fmt.Errorf("cannot determine home directory: %w", err)
+		}
+		orch := orchestrator.New(
+			orchestrator.WithConfig(config.LoadWithDefaults(configDir)),
+			orchestrator.WithConfigDir(configDir),
+			orchestrator.WithHistory(orchestrator.HistoryPath()),
+			orchestrator.WithHomeDir(homeDir),
+		)
+		applyResult, err := orch.Apply(ctx)
+		output.RenderOutput(applyResult)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
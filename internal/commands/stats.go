@@ -0,0 +1,137 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/richhaase/plonk/internal/config"
+	"github.com/richhaase/plonk/internal/dotfiles"
+	"github.com/richhaase/plonk/internal/lock"
+	"github.com/richhaase/plonk/internal/orchestrator"
+	"github.com/richhaase/plonk/internal/output"
+	"github.com/richhaase/plonk/internal/packages"
+	"github.com/spf13/cobra"
+)
+
+// statsWeeks is how many weeks of apply history stats shows
+const statsWeeks = 4
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Summarize tracked packages, dotfiles and recent applies",
+	Long: `Show a quantified view of the environment: tracked packages per manager
+and the disk space of each manager's install directories, managed and
+drifted dotfiles, and what applies did over the last few weeks.
+
+Applies are recorded in history.jsonl in the state directory by plonk
+apply, pull --apply, sync, bundle, status --fix and snapshot restore.
+Measuring disk usage walks the managers' directories and can take a few
+seconds with a large Homebrew Cellar; --no-disk skips it.
+
+Examples:
+  plonk stats
+  plonk stats --no-disk
+  plonk stats -o json`,
+	RunE:         runStats,
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+}
+
+func init() {
+	rootCmd.AddCommand(statsCmd)
+	statsCmd.Flags().Bool("no-disk", false, "Don't measure the managers' disk usage")
+}
+
+func runStats(cmd *cobra.Command, args []string) error {
+	noDisk, _ := cmd.Flags().GetBool("no-disk")
+
+	configDir := config.GetDefaultConfigDirectory()
+	homeDir, err := config.GetHomeDir()
+	if err != nil {
+		return err
+	}
+	cfg := config.LoadWithDefaults(configDir)
+	packages.Configure(cfg)
+
+	lockFile, err := lock.NewLockV3Service(configDir).Read()
+	if err != nil {
+		return fmt.Errorf("failed to read lock file: %w", err)
+	}
+
+	stats := output.StatsOutput{}
+	managers := make([]string, 0, len(lockFile.Packages))
+	for manager := range lockFile.Packages {
+		managers = append(managers, manager)
+	}
+	sort.Strings(managers)
+	for _, manager := range managers {
+		n := len(lockFile.Packages[manager])
+		if n == 0 {
+			continue
+		}
+		m := output.ManagerStats{Name: manager, Packages: n}
+		if !noDisk {
+			m.Dirs = packages.ManagerDirs(manager)
+			m.DiskBytes = packages.DiskUsage(m.Dirs)
+		}
+		stats.Managers = append(stats.Managers, m)
+		stats.TotalPackages += n
+	}
+
+	dm := dotfiles.NewDotfileManager(configDir, homeDir, cfg.IgnorePatterns)
+	statuses, err := dm.Reconcile()
+	if err != nil {
+		return err
+	}
+	stats.Dotfiles = len(statuses)
+	for _, s := range statuses {
+		switch s.State {
+		case dotfiles.SyncStateDrifted:
+			stats.DriftedDotfiles++
+		case dotfiles.SyncStateMissing:
+			stats.MissingDotfiles++
+		}
+	}
+
+	history, err := orchestrator.ReadHistory(orchestrator.HistoryPath())
+	if err != nil {
+		return fmt.Errorf("failed to read apply history: %w", err)
+	}
+	stats.Applies = len(history)
+	if len(history) > 0 {
+		last := history[len(history)-1]
+		stats.LastApply = &last.Time
+		stats.LastApplySuccess = last.Success
+	}
+	stats.Weeks = applyWeeks(history, time.Now(), statsWeeks)
+
+	output.RenderOutput(stats)
+	return nil
+}
+
+// applyWeeks totals history by week, counting back from now, most recent
+// week first
+func applyWeeks(history []orchestrator.HistoryEntry, now time.Time, weeks int) []output.ApplyPeriod {
+	const week = 7 * 24 * time.Hour
+	periods := make([]output.ApplyPeriod, weeks)
+	for i := range periods {
+		periods[i].Start = now.Add(-time.Duration(i+1) * week)
+	}
+	for _, e := range history {
+		age := now.Sub(e.Time)
+		if age < 0 || age >= time.Duration(weeks)*week {
+			continue
+		}
+		p := &periods[int(age/week)]
+		p.Applies++
+		p.Installed += e.Installed
+		p.Added += e.Added
+		p.Redeployed += e.Redeployed
+		p.Failed += e.Failed
+	}
+	return periods
+}
@@ -0,0 +1,36 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/richhaase/plonk/internal/orchestrator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyWeeks(t *testing.T) {
+	now := time.Date(2025, 3, 29, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	history := []orchestrator.HistoryEntry{
+		{Time: now.Add(-40 * day), Installed: 9},             // older than four weeks
+		{Time: now.Add(-15 * day), Redeployed: 2, Failed: 1}, // third week back
+		{Time: now.Add(-2 * day), Installed: 1, Added: 1},    // this week
+		{Time: now.Add(-time.Hour), Redeployed: 1},           // this week
+		{Time: now.Add(time.Hour), Installed: 5},             // clock skew: ignored
+	}
+
+	weeks := applyWeeks(history, now, 4)
+	require.Len(t, weeks, 4)
+	assert.Equal(t, now.Add(-7*day), weeks[0].Start)
+	assert.Equal(t, 2, weeks[0].Applies)
+	assert.Equal(t, 1, weeks[0].Installed)
+	assert.Equal(t, 1, weeks[0].Redeployed)
+	assert.Equal(t, 0, weeks[1].Applies)
+	assert.Equal(t, 1, weeks[2].Applies)
+	assert.Equal(t, 1, weeks[2].Failed)
+	assert.Equal(t, 0, weeks[3].Applies)
+}
@@ -8,13 +8,14 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"sort"
+	"time"
 
 	"github.com/richhaase/plonk/internal/config"
 	"github.com/richhaase/plonk/internal/dotfiles"
 	"github.com/richhaase/plonk/internal/lock"
 	"github.com/richhaase/plonk/internal/output"
 	"github.com/richhaase/plonk/internal/packages"
+	"github.com/richhaase/plonk/internal/resources"
 	"github.com/spf13/cobra"
 )
 
@@ -31,18 +32,74 @@ Shows:
 - Missing items that need to be installed
 - Configuration and lock file status
 
+With --fix each missing or drifted item is offered a fix, chosen with a
+keystroke: install, restore (redeploy a drifted dotfile), adopt (keep the
+drifted copy as the new source) or ignore (stop managing it). The chosen
+installs and deploys then run as one apply.
+
+With --counts only counts are reported, and they are cached for --max-age
+so status bars can poll cheaply. --summary text or --summary json prints
+them on one line. --widget formats the counts for waybar, xbar or polybar.
+
+--porcelain reports the same cached counts as stable key=value lines, one
+per line, for prompt frameworks such as starship and oh-my-posh. The
+first line is version=1; later releases only add keys.
+
+Without --counts, --max-age reuses package checks: a package an earlier
+--max-age run found installed within that age is not queried again, and
+is shown with when it was last checked. By default every package is
+checked.
+
 Examples:
-  plonk status    # Show all managed items
-  plonk st        # Short alias`,
+  plonk status                       # Show all managed items
+  plonk st                           # Short alias
+  plonk status --fix                 # Choose fixes for each problem
+  plonk status --max-age 24h         # Recheck only packages not seen in a day
+  plonk status --counts -o json      # Cached counts for scripts
+  plonk status --summary json        # The same, on one line
+  plonk status --widget waybar       # Cached counts for a status bar
+  plonk status --porcelain           # managed=12, missing=1, ... one per line`,
 	RunE:         runStatus,
 	SilenceUsage: true,
+	Args:         cobra.NoArgs,
 }
 
 func init() {
 	rootCmd.AddCommand(statusCmd)
+	statusCmd.Flags().Bool("counts", false, "Only report counts (cached; suitable for polling)")
+	statusCmd.Flags().Duration("max-age", defaultSummaryMaxAge, "Maximum age of cached counts, or of reused package checks (0 disables the cache)")
+	statusCmd.Flags().String("widget", "", "Report cached counts for a status bar (waybar|xbar|polybar)")
+	statusCmd.Flags().Bool("fix", false, "Interactively choose fixes for missing and drifted items")
+	statusCmd.Flags().Bool("porcelain", false, "Report cached counts as stable key=value lines")
+	statusCmd.MarkFlagsMutuallyExclusive("fix", "counts")
+	statusCmd.MarkFlagsMutuallyExclusive("fix", "summary")
+	statusCmd.MarkFlagsMutuallyExclusive("fix", "widget")
+	statusCmd.MarkFlagsMutuallyExclusive("fix", "porcelain")
+	statusCmd.MarkFlagsMutuallyExclusive("widget", "porcelain")
+	addSchemaFlag(statusCmd)
+}
+
+// countsRequested reports whether status should report only cached counts:
+// with --counts, or when they are formatted by --summary, --widget or
+// --porcelain
+func countsRequested(cmd *cobra.Command) bool {
+	counts, _ := cmd.Flags().GetBool("counts")
+	summary, _ := cmd.Flags().GetString("summary")
+	widget, _ := cmd.Flags().GetString("widget")
+	porcelain, _ := cmd.Flags().GetBool("porcelain")
+	return counts || summary != "" || widget != "" || porcelain
 }
 
 func runStatus(cmd *cobra.Command, args []string) error {
+	schema := "status"
+	countsOnly := countsRequested(cmd)
+	if countsOnly {
+		schema = "status-summary"
+	}
+	if handled, err := printSchemaIfRequested(cmd, schema); handled {
+		return err
+	}
+
 	// Get directories
 	homeDir, err := config.GetHomeDir()
 	if err != nil {
@@ -51,29 +108,36 @@ func runStatus(cmd *cobra.Command, args []string) error {
 	configDir := config.GetDefaultConfigDirectory()
 
 	// Load configuration (may fail if config is invalid, but we handle this gracefully)
-	_, configLoadErr := config.Load(configDir)
+	cfg, configLoadErr := config.Load(configDir)
+	if configLoadErr != nil {
+		// Fall back to defaults (and warn) without parsing twice on success
+		cfg = config.LoadWithDefaults(configDir)
+	}
 
-	// Reconcile dotfiles with injected config
-	cfg := config.LoadWithDefaults(configDir)
+	ctx := cmd.Context()
+	if countsOnly {
+		return runStatusSummary(cmd, configDir, homeDir, cfg)
+	}
 
-	// Create DotfileManager and reconcile directly
-	dm := dotfiles.NewDotfileManager(configDir, homeDir, cfg.IgnorePatterns)
-	statuses, err := dm.Reconcile()
-	if err != nil {
-		return err
+	if fix, _ := cmd.Flags().GetBool("fix"); fix {
+		summary, err := collectStatusSummary(ctx, configDir, homeDir, cfg, 0)
+		if err != nil {
+			return err
+		}
+		return runStatusFix(ctx, configDir, homeDir, cfg, summary)
+	}
+
+	var verifyMaxAge time.Duration
+	if cmd.Flags().Changed("max-age") {
+		verifyMaxAge, _ = cmd.Flags().GetDuration("max-age")
 	}
 
-	// Get package status from lock file
-	ctx := cmd.Context()
 	remoteSync := getRemoteSyncStatus(ctx, configDir)
-	packageResult, err := getPackageStatus(ctx, configDir)
+	summary, err := collectStatusSummary(ctx, configDir, homeDir, cfg, verifyMaxAge)
 	if err != nil {
 		return err
 	}
 
-	// Convert to output summary
-	summary := convertStatusToSummary(statuses, packageResult)
-
 	// Check file existence and validity
 	configPath := filepath.Join(configDir, "plonk.yaml")
 	lockPath := filepath.Join(configDir, "plonk.lock")
@@ -91,6 +155,13 @@ func runStatus(cmd *cobra.Command, args []string) error {
 		lockExists = true
 	}
 
+	var duplicates []output.DuplicatePackage
+	if lockFile, err := lock.NewLockV3Service(configDir).Read(); err == nil {
+		for _, d := range packages.FindDuplicates(ctx, lockFile) {
+			duplicates = append(duplicates, output.DuplicatePackage{Tracked: d.Tracked, Also: d.Also, OnPath: d.OnPath, Winner: d.Winner})
+		}
+	}
+
 	// Create formatter data directly
 	formatterData := output.StatusOutput{
 		ConfigPath:   configPath,
@@ -100,6 +171,7 @@ func runStatus(cmd *cobra.Command, args []string) error {
 		LockExists:   lockExists,
 		RemoteSync:   remoteSync,
 		StateSummary: summary,
+		Duplicates:   duplicates,
 		ConfigDir:    configDir,
 		HomeDir:      homeDir,
 	}
@@ -108,6 +180,31 @@ func runStatus(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// collectStatusSummary reconciles dotfiles, packages and custom resources.
+// Packages found installed within verifyMaxAge are not checked again.
+func collectStatusSummary(ctx context.Context, configDir, homeDir string, cfg *config.Config, verifyMaxAge time.Duration) (output.Summary, error) {
+	// Create DotfileManager and reconcile directly
+	dm := dotfiles.NewDotfileManager(configDir, homeDir, cfg.IgnorePatterns)
+	statuses, err := dm.Reconcile()
+	if err != nil {
+		return output.Summary{}, err
+	}
+
+	// Get package status from lock file
+	packages.Configure(cfg)
+	packageResult, err := getVerifiedPackageStatus(ctx, configDir, verifyMaxAge)
+	if err != nil {
+		return output.Summary{}, err
+	}
+
+	// Convert to output summary
+	summary := convertStatusToSummary(statuses, packageResult, homeDir)
+	if len(cfg.ActiveResources()) > 0 {
+		addResultToSummary(&summary, getResourceStatus(ctx, configDir, homeDir, cfg.ActiveResources()))
+	}
+	return summary, nil
+}
+
 // packageStatus holds status information about tracked packages
 type packageStatus struct {
 	Managed []output.Item
@@ -117,93 +214,133 @@ type packageStatus struct {
 
 // getPackageStatus reads the lock file and checks which packages are installed
 func getPackageStatus(ctx context.Context, configDir string) (packageStatus, error) {
+	return getVerifiedPackageStatus(ctx, configDir, 0)
+}
+
+// getVerifiedPackageStatus is getPackageStatus reusing the checks of the
+// packages found installed within maxAge. A zero maxAge checks every
+// package and leaves the verification record alone.
+func getVerifiedPackageStatus(ctx context.Context, configDir string, maxAge time.Duration) (packageStatus, error) {
 	result := packageStatus{}
 
-	// Check if lock file exists first
-	lockPath := filepath.Join(configDir, "plonk.lock")
-	if _, err := os.Stat(lockPath); os.IsNotExist(err) {
-		// No lock file yet - this is fine, just no packages tracked
-		return result, nil
+	var statuses []packages.PackageStatus
+	var err error
+	if maxAge > 0 {
+		verifiedPath := packages.VerifiedPath()
+		verified := packages.LoadVerifications(verifiedPath)
+		statuses, err = packages.ReconcileVerified(ctx, configDir, verified, maxAge)
+		if err == nil {
+			// Best effort: without the record the next run checks everything
+			_ = packages.SaveVerifications(verifiedPath, verified)
+		}
+	} else {
+		statuses, err = packages.Reconcile(ctx, configDir)
 	}
-
-	lockSvc := lock.NewLockV3Service(configDir)
-	lockFile, err := lockSvc.Read()
 	if err != nil {
-		return result, fmt.Errorf("failed to read lock file: %w", err)
+		return result, err
 	}
 
-	// Check each package (sorted for deterministic output)
-	managers := make([]string, 0, len(lockFile.Packages))
-	for manager := range lockFile.Packages {
-		managers = append(managers, manager)
-	}
-	sort.Strings(managers)
-	for _, manager := range managers {
-		pkgs := lockFile.Packages[manager]
-		mgr, err := packages.GetManager(manager)
-		if err != nil {
-			// Unknown/unsupported manager - mark all as errors (not missing)
-			for _, pkg := range pkgs {
-				result.Errors = append(result.Errors, output.Item{
-					Name:    pkg,
-					Manager: manager,
-					State:   output.StateError,
-					Error:   fmt.Sprintf("unsupported manager: %s", manager),
-				})
+	for _, s := range statuses {
+		item := output.Item{
+			Name:    s.Name,
+			Manager: s.Manager,
+		}
+		switch s.State {
+		case packages.StatusManaged:
+			item.State = output.StateManaged
+			if s.SatisfiedBy != "" {
+				item.Metadata = map[string]interface{}{"satisfied_by": s.SatisfiedBy}
+			}
+			if !s.CheckedAt.IsZero() {
+				if item.Metadata == nil {
+					item.Metadata = make(map[string]interface{})
+				}
+				item.Metadata["checked_at"] = s.CheckedAt
 			}
+			result.Managed = append(result.Managed, item)
+		case packages.StatusMissing:
+			item.State = output.StateMissing
+			result.Missing = append(result.Missing, item)
+		default:
+			item.State = output.StateError
+			item.Error = s.Err.Error()
+			item.ErrorClass = packages.ErrorClass(s.Err)
+			result.Errors = append(result.Errors, item)
+		}
+	}
+
+	markRenamedPackages(ctx, result.Missing)
+	markDependencyPackages(ctx, result.Managed)
+	return result, nil
+}
+
+// markDependencyPackages records in managed items' metadata when the
+// manager installed the package only as another's dependency, so a cleanup
+// may remove it. Packages taken from the verification record are not asked
+// about. Detection is best effort and never fails status.
+func markDependencyPackages(ctx context.Context, managed []output.Item) {
+	byManager := make(map[string][]string)
+	for _, item := range managed {
+		if _, cached := item.Metadata["checked_at"]; !cached {
+			byManager[item.Manager] = append(byManager[item.Manager], item.Name)
+		}
+	}
+
+	dependencies := make(map[string]bool)
+	for manager, names := range byManager {
+		found, _ := packages.DetectDependencies(ctx, manager, names)
+		for _, name := range found {
+			dependencies[manager+":"+name] = true
+		}
+	}
+
+	for i := range managed {
+		if !dependencies[managed[i].Manager+":"+managed[i].Name] {
 			continue
 		}
+		if managed[i].Metadata == nil {
+			managed[i].Metadata = make(map[string]interface{})
+		}
+		managed[i].Metadata["installed_as"] = "dependency"
+	}
+}
 
-		var managerBroken bool
-		var managerErr string
-		for _, pkg := range pkgs {
-			// Short-circuit remaining packages if the manager itself is broken
-			// (e.g., binary not on PATH) to avoid repeated failing subprocesses.
-			if managerBroken {
-				result.Errors = append(result.Errors, output.Item{
-					Name:    pkg,
-					Manager: manager,
-					State:   output.StateError,
-					Error:   managerErr,
-				})
-				continue
-			}
+// markRenamedPackages records in missing items' metadata when the manager
+// has renamed or retired the package, which usually explains why it is
+// missing. Answers are cached in the state directory for a day, so status
+// doesn't ask the manager on every run. Detection is best effort and never
+// fails status.
+func markRenamedPackages(ctx context.Context, missing []output.Item) {
+	byManager := make(map[string][]string)
+	for _, item := range missing {
+		byManager[item.Manager] = append(byManager[item.Manager], item.Name)
+	}
 
-			installed, err := mgr.IsInstalled(ctx, pkg)
-			if err != nil {
-				managerBroken = true
-				managerErr = err.Error()
-				result.Errors = append(result.Errors, output.Item{
-					Name:    pkg,
-					Manager: manager,
-					State:   output.StateError,
-					Error:   err.Error(),
-				})
-				continue
-			}
-			if installed {
-				result.Managed = append(result.Managed, output.Item{
-					Name:    pkg,
-					Manager: manager,
-					State:   output.StateManaged,
-				})
-			} else {
-				result.Missing = append(result.Missing, output.Item{
-					Name:    pkg,
-					Manager: manager,
-					State:   output.StateMissing,
-				})
-			}
+	renamed := make(map[string]packages.Rename)
+	for manager, names := range byManager {
+		renames, _ := packages.DetectRenamesCached(ctx, packages.RenamesPath(), manager, names)
+		for _, r := range renames {
+			renamed[r.Manager+":"+r.Old] = r
 		}
 	}
 
-	return result, nil
+	for i := range missing {
+		r, ok := renamed[missing[i].Manager+":"+missing[i].Name]
+		if !ok {
+			continue
+		}
+		if missing[i].Metadata == nil {
+			missing[i].Metadata = make(map[string]interface{})
+		}
+		missing[i].Metadata["renamed_to"] = r.New
+		missing[i].Metadata["rename_reason"] = r.Reason
+	}
 }
 
 // convertStatusToSummary combines dotfile statuses and package results into a unified summary
-func convertStatusToSummary(statuses []dotfiles.DotfileStatus, pkgResult packageStatus) output.Summary {
+func convertStatusToSummary(statuses []dotfiles.DotfileStatus, pkgResult packageStatus, homeDir string) output.Summary {
 	// Convert dotfiles to output format
-	managedItems, missingItems, errorItems := convertDotfileStatusToOutput(statuses)
+	managedItems, missingItems, errorItems := convertDotfileStatusToOutput(statuses, homeDir)
 
 	dotfileOutput := output.Result{
 		Domain:  "dotfile",
@@ -232,3 +369,46 @@ func convertStatusToSummary(statuses []dotfiles.DotfileStatus, pkgResult package
 		Results:        []output.Result{packageOutput, dotfileOutput},
 	}
 }
+
+// getResourceStatus reconciles each configured custom resource.
+// A resource that cannot be reconciled is reported as a single error item.
+func getResourceStatus(ctx context.Context, configDir, homeDir string, names []string) output.Result {
+	result := output.Result{Domain: "resource"}
+
+	for _, name := range names {
+		resource, err := resources.Lookup(name, configDir, homeDir)
+		if err != nil {
+			result.Errors = append(result.Errors, output.Item{Name: name, State: output.StateError, Error: err.Error()})
+			continue
+		}
+		statuses, err := resources.Reconcile(ctx, resource)
+		if err != nil {
+			result.Errors = append(result.Errors, output.Item{Name: name, State: output.StateError, Error: err.Error()})
+			continue
+		}
+		for _, s := range statuses {
+			item := output.Item{Name: s.Name, Manager: name}
+			switch s.State {
+			case resources.StateMissing:
+				item.State = output.StateMissing
+				result.Missing = append(result.Missing, item)
+			case resources.StateDrifted:
+				item.State = output.StateDegraded
+				result.Managed = append(result.Managed, item)
+			default:
+				item.State = output.StateManaged
+				result.Managed = append(result.Managed, item)
+			}
+		}
+	}
+
+	return result
+}
+
+// addResultToSummary appends a domain result and folds it into the totals
+func addResultToSummary(summary *output.Summary, result output.Result) {
+	summary.Results = append(summary.Results, result)
+	summary.TotalManaged += len(result.Managed)
+	summary.TotalMissing += len(result.Missing)
+	summary.TotalErrors += len(result.Errors)
+}
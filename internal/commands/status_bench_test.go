@@ -0,0 +1,127 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/richhaase/plonk/internal/config"
+	"github.com/richhaase/plonk/internal/lock"
+	"github.com/richhaase/plonk/internal/packages"
+)
+
+// Size of the simulated large setup used by the status benchmarks
+const (
+	benchPackages = 500
+	benchDotfiles = 300
+)
+
+// setupLargeStatusFixture builds a plonk directory tracking benchPackages brew
+// packages and benchDotfiles dotfiles, plus a fake brew on PATH that reports
+// all but the last ten packages as installed. Every tenth dotfile is drifted
+// and the last five are not deployed.
+func setupLargeStatusFixture(b *testing.B) (configDir, homeDir string) {
+	b.Helper()
+	root := b.TempDir()
+	configDir = filepath.Join(root, "plonk")
+	homeDir = filepath.Join(root, "home")
+	binDir := filepath.Join(root, "bin")
+	for _, dir := range []string{configDir, homeDir, binDir} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	l := lock.NewLockV3()
+	var installed strings.Builder
+	for i := range benchPackages {
+		name := fmt.Sprintf("pkg-%03d", i)
+		l.AddPackage("brew", name)
+		if i < benchPackages-10 {
+			installed.WriteString(name + "\n")
+		}
+	}
+	if err := lock.NewLockV3Service(configDir).Write(l); err != nil {
+		b.Fatal(err)
+	}
+	listFile := filepath.Join(root, "brew-list")
+	if err := os.WriteFile(listFile, []byte(installed.String()), 0o644); err != nil {
+		b.Fatal(err)
+	}
+	script := fmt.Sprintf("#!/bin/sh\n[ \"$2\" = --formula ] && cat %q\nexit 0\n", listFile)
+	if err := os.WriteFile(filepath.Join(binDir, "brew"), []byte(script), 0o755); err != nil {
+		b.Fatal(err)
+	}
+	b.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	for i := range benchDotfiles {
+		rel := filepath.Join(fmt.Sprintf("config/app%02d", i/10), fmt.Sprintf("file%03d.conf", i))
+		content := []byte(fmt.Sprintf("setting = %d\n", i))
+		source := filepath.Join(configDir, rel)
+		if err := os.MkdirAll(filepath.Dir(source), 0o755); err != nil {
+			b.Fatal(err)
+		}
+		if err := os.WriteFile(source, content, 0o644); err != nil {
+			b.Fatal(err)
+		}
+		if i >= benchDotfiles-5 {
+			continue
+		}
+		if i%10 == 0 {
+			content = append(content, "# local edit\n"...)
+		}
+		target := filepath.Join(homeDir, "."+rel)
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			b.Fatal(err)
+		}
+		if err := os.WriteFile(target, content, 0o644); err != nil {
+			b.Fatal(err)
+		}
+	}
+	return configDir, homeDir
+}
+
+// BenchmarkCollectStatusSummary measures a full status reconciliation of a
+// large setup, including the brew list subprocess, as a fresh process would
+// run it.
+func BenchmarkCollectStatusSummary(b *testing.B) {
+	configDir, homeDir := setupLargeStatusFixture(b)
+	cfg := config.LoadWithDefaults(configDir)
+	b.Cleanup(packages.ResetManagerCache)
+
+	b.ResetTimer()
+	for range b.N {
+		packages.ResetManagerCache()
+		summary, err := collectStatusSummary(context.Background(), configDir, homeDir, cfg, 0)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if summary.TotalManaged+summary.TotalMissing != benchPackages+benchDotfiles {
+			b.Fatalf("unexpected summary: %+v", summary)
+		}
+	}
+}
+
+// BenchmarkGetPackageStatus isolates lock parsing and package reconciliation
+func BenchmarkGetPackageStatus(b *testing.B) {
+	configDir, _ := setupLargeStatusFixture(b)
+	b.Cleanup(packages.ResetManagerCache)
+
+	// Warm the brew cache so only lock parsing and matching are measured
+	if _, err := getPackageStatus(context.Background(), configDir); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for range b.N {
+		if _, err := getPackageStatus(context.Background(), configDir); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
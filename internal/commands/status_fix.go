@@ -0,0 +1,248 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package commands
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/richhaase/plonk/internal/config"
+	"github.com/richhaase/plonk/internal/dotfiles"
+	"github.com/richhaase/plonk/internal/gitops"
+	"github.com/richhaase/plonk/internal/lock"
+	"github.com/richhaase/plonk/internal/orchestrator"
+	"github.com/richhaase/plonk/internal/output"
+)
+
+// fixAction is what status --fix does about one problem; its value is the
+// key that chooses it
+type fixAction byte
+
+const (
+	fixSkip    fixAction = 's'
+	fixInstall fixAction = 'i' // install a missing package or deploy a missing dotfile
+	fixRestore fixAction = 'r' // redeploy a drifted dotfile from its source
+	fixAdopt   fixAction = 'a' // copy a drifted dotfile back into its source
+	fixIgnore  fixAction = 'g' // stop managing: untrack the package or ignore the dotfile
+)
+
+var fixActionLabels = map[fixAction]string{
+	fixInstall: "[i]nstall",
+	fixRestore: "[r]estore",
+	fixAdopt:   "[a]dopt",
+	fixIgnore:  "i[g]nore",
+	fixSkip:    "[s]kip",
+}
+
+// fixProblem is a missing or drifted item that status --fix can remediate
+type fixProblem struct {
+	domain  string // "package" or "dotfile"
+	item    output.Item
+	actions []fixAction // skip is always offered as well
+}
+
+// fixProblems lists the problems in summary that status --fix offers to
+// fix. Errors and custom resources are left to plonk apply and doctor.
+func fixProblems(summary output.Summary) []fixProblem {
+	var problems []fixProblem
+	for _, result := range summary.Results {
+		switch result.Domain {
+		case "package":
+			for _, item := range result.Missing {
+				problems = append(problems, fixProblem{domain: "package", item: item, actions: []fixAction{fixInstall, fixIgnore}})
+			}
+		case "dotfile":
+			for _, item := range result.Missing {
+				problems = append(problems, fixProblem{domain: "dotfile", item: item, actions: []fixAction{fixInstall, fixIgnore}})
+			}
+			for _, item := range result.Managed {
+				if item.State != output.StateDegraded {
+					continue
+				}
+				actions := []fixAction{fixRestore, fixAdopt, fixIgnore}
+				if source, _ := item.Metadata["source"].(string); strings.HasSuffix(source, ".tmpl") {
+					// Adopting would replace the template with its rendered output
+					actions = []fixAction{fixRestore, fixIgnore}
+				}
+				problems = append(problems, fixProblem{domain: "dotfile", item: item, actions: actions})
+			}
+		}
+	}
+	return problems
+}
+
+// describe names the problem for the prompt, e.g. "brew:fd is missing"
+func (p fixProblem) describe(homeDir string) string {
+	name := p.item.Manager + ":" + p.item.Name
+	if p.domain == "dotfile" {
+		name = p.item.Path
+		if rel, err := filepath.Rel(homeDir, p.item.Path); err == nil && !strings.HasPrefix(rel, "..") {
+			name = filepath.Join("~", rel)
+		}
+	}
+	return fmt.Sprintf("%s is %s", name, p.item.State)
+}
+
+// fixPlan batches the chosen actions so they run together
+type fixPlan struct {
+	install map[string]bool // package specs to install
+	deploy  map[string]bool // dotfile destinations to deploy or restore
+	adopt   []string        // drifted dotfile destinations to copy into the source
+	untrack []string        // package specs to remove from plonk.lock
+	ignore  []string        // ignore_patterns to add to plonk.yaml
+}
+
+func (p fixPlan) empty() bool {
+	return len(p.install)+len(p.deploy)+len(p.adopt)+len(p.untrack)+len(p.ignore) == 0
+}
+
+// add records action for problem
+func (p *fixPlan) add(problem fixProblem, action fixAction, configDir string) {
+	if p.install == nil {
+		p.install, p.deploy = map[string]bool{}, map[string]bool{}
+	}
+	spec := problem.item.Manager + ":" + problem.item.Name
+	dest := problem.item.Path
+
+	switch {
+	case action == fixInstall && problem.domain == "package":
+		p.install[spec] = true
+	case action == fixInstall, action == fixRestore:
+		p.deploy[filepath.Clean(dest)] = true
+	case action == fixAdopt:
+		p.adopt = append(p.adopt, dest)
+	case action == fixIgnore && problem.domain == "package":
+		p.untrack = append(p.untrack, spec)
+	case action == fixIgnore:
+		source, _ := problem.item.Metadata["source"].(string)
+		if rel, err := filepath.Rel(configDir, source); err == nil {
+			p.ignore = append(p.ignore, "/"+filepath.ToSlash(rel))
+		}
+	}
+}
+
+// chooseFixes asks what to do about each problem, one keystroke each;
+// q skips the rest
+func chooseFixes(reader *bufio.Reader, out io.Writer, problems []fixProblem, configDir, homeDir string) (fixPlan, error) {
+	var plan fixPlan
+	for i, problem := range problems {
+		keys := ""
+		labels := make([]string, 0, len(problem.actions)+2)
+		for _, action := range slices.Concat(problem.actions, []fixAction{fixSkip}) {
+			keys += string(rune(action))
+			labels = append(labels, fixActionLabels[action])
+		}
+		labels = append(labels, "[q]uit")
+
+		question := fmt.Sprintf("(%d/%d) %s: %s?", i+1, len(problems), problem.describe(homeDir), strings.Join(labels, ", "))
+		key, err := promptChoice(reader, out, question, keys+"q", byte(fixSkip))
+		if err != nil {
+			return plan, err
+		}
+		if key == 'q' {
+			break
+		}
+		plan.add(problem, fixAction(key), configDir)
+	}
+	return plan, nil
+}
+
+// runStatusFix walks through the problems status found and applies the
+// chosen fixes as one batch
+func runStatusFix(ctx context.Context, configDir, homeDir string, cfg *config.Config, summary output.Summary) error {
+	problems := fixProblems(summary)
+	if len(problems) == 0 {
+		output.Println("Nothing to fix")
+		return nil
+	}
+	if !stdinIsTerminal() {
+		return fmt.Errorf("status --fix asks what to do about each problem and needs an interactive terminal")
+	}
+
+	plan, err := chooseFixes(bufio.NewReader(os.Stdin), os.Stderr, problems, configDir, homeDir)
+	if err != nil {
+		return err
+	}
+	return applyFixPlan(ctx, plan, cfg, configDir, homeDir)
+}
+
+// applyFixPlan untracks, ignores and adopts first, then installs and
+// deploys everything else in a single apply
+func applyFixPlan(ctx context.Context, plan fixPlan, cfg *config.Config, configDir, homeDir string) error {
+	if plan.empty() {
+		output.Println("No fixes chosen")
+		return nil
+	}
+
+	var errs []error
+	var committed []string
+
+	if len(plan.untrack) > 0 {
+		lockSvc := lock.NewLockV3Service(configDir)
+		lockFile, err := lockSvc.Read()
+		if err == nil {
+			for _, spec := range plan.untrack {
+				manager, pkg, _ := parsePackageSpecNoValidate(spec)
+				lockFile.RemovePackage(manager, pkg)
+			}
+			err = lockSvc.Write(lockFile)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to untrack packages: %w", err))
+		} else {
+			output.Printf("Untracked %s\n", strings.Join(plan.untrack, ", "))
+			committed = append(committed, plan.untrack...)
+		}
+	}
+
+	for _, pattern := range plan.ignore {
+		if _, err := config.AddIgnorePattern(configDir, pattern); err != nil {
+			errs = append(errs, fmt.Errorf("failed to ignore %s: %w", pattern, err))
+			continue
+		}
+		output.Printf("Ignoring %s\n", pattern)
+		committed = append(committed, pattern)
+	}
+
+	if len(plan.adopt) > 0 {
+		dm := dotfiles.NewDotfileManager(configDir, homeDir, cfg.IgnorePatterns)
+		for _, result := range addDotfiles(dm, configDir, homeDir, plan.adopt, AddOptions{}) {
+			if result.Error != nil {
+				errs = append(errs, fmt.Errorf("failed to adopt %s: %w", result.Path, result.Error))
+				continue
+			}
+			output.Printf("Adopted %s\n", result.Path)
+			committed = append(committed, result.Path)
+		}
+	}
+
+	if len(committed) > 0 {
+		gitops.AutoCommit(ctx, configDir, "status --fix", committed)
+	}
+
+	if len(plan.install)+len(plan.deploy) > 0 {
+		orch := orchestrator.New(
+			orchestrator.WithConfig(cfg),
+			orchestrator.WithConfigDir(configDir),
+			orchestrator.WithHistory(orchestrator.HistoryPath()),
+			orchestrator.WithHomeDir(homeDir),
+			orchestrator.WithSelection(plan.install, plan.deploy),
+		)
+		result, err := orch.Apply(ctx)
+		result.Scope = "status --fix"
+		output.RenderOutput(result)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
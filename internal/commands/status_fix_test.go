@@ -0,0 +1,117 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package commands
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/richhaase/plonk/internal/config"
+	"github.com/richhaase/plonk/internal/dotfiles"
+	"github.com/richhaase/plonk/internal/lock"
+	"github.com/richhaase/plonk/internal/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFixProblems_OffersActionsByState(t *testing.T) {
+	summary := output.Summary{Results: []output.Result{
+		{Domain: "package", Missing: []output.Item{{Name: "fd", Manager: "brew", State: output.StateMissing}}},
+		{Domain: "dotfile",
+			Managed: []output.Item{
+				{Name: ".zshrc", State: output.StateManaged},
+				{Name: ".vimrc", State: output.StateDegraded, Metadata: map[string]interface{}{"source": "/p/vimrc"}},
+				{Name: ".gitconfig", State: output.StateDegraded, Metadata: map[string]interface{}{"source": "/p/gitconfig.tmpl"}},
+			},
+			Missing: []output.Item{{Name: ".tmux.conf", State: output.StateMissing}},
+		},
+		{Domain: "resource", Missing: []output.Item{{Name: "settings", State: output.StateMissing}}},
+	}}
+
+	problems := fixProblems(summary)
+	require.Len(t, problems, 4)
+	assert.Equal(t, []fixAction{fixInstall, fixIgnore}, problems[0].actions)
+	assert.Equal(t, []fixAction{fixInstall, fixIgnore}, problems[1].actions)
+	assert.Equal(t, []fixAction{fixRestore, fixAdopt, fixIgnore}, problems[2].actions)
+	assert.Equal(t, []fixAction{fixRestore, fixIgnore}, problems[3].actions, "templates can't be adopted")
+}
+
+func TestChooseFixes_BatchesChoices(t *testing.T) {
+	problems := []fixProblem{
+		{domain: "package", item: output.Item{Name: "fd", Manager: "brew", State: output.StateMissing}, actions: []fixAction{fixInstall, fixIgnore}},
+		{domain: "package", item: output.Item{Name: "jq", Manager: "brew", State: output.StateMissing}, actions: []fixAction{fixInstall, fixIgnore}},
+		{domain: "dotfile", item: output.Item{Name: ".vimrc", Path: "/home/u/.vimrc", State: output.StateDegraded,
+			Metadata: map[string]interface{}{"source": "/p/vimrc"}}, actions: []fixAction{fixRestore, fixAdopt, fixIgnore}},
+		{domain: "dotfile", item: output.Item{Name: ".zshrc", Path: "/home/u/.zshrc", State: output.StateMissing}, actions: []fixAction{fixInstall, fixIgnore}},
+	}
+
+	var out bytes.Buffer
+	plan, err := chooseFixes(bufio.NewReader(strings.NewReader("i\ng\nr\n\n")), &out, problems, "/p", "/home/u")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"brew:fd": true}, plan.install)
+	assert.Equal(t, []string{"brew:jq"}, plan.untrack)
+	assert.Equal(t, map[string]bool{"/home/u/.vimrc": true}, plan.deploy)
+	assert.Contains(t, out.String(), "(3/4) ~/.vimrc is drifted: [r]estore, [a]dopt, i[g]nore, [s]kip, [q]uit?")
+
+	plan, err = chooseFixes(bufio.NewReader(strings.NewReader("g\nq\n")), &out, problems[2:], "/p", "/home/u")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/vimrc"}, plan.ignore)
+	assert.Empty(t, plan.deploy, "q skips the rest")
+
+	plan, err = chooseFixes(bufio.NewReader(strings.NewReader("i\n")), &out, problems[2:3], "/p", "/home/u")
+	require.NoError(t, err)
+	assert.True(t, plan.empty(), "keys a problem doesn't offer are asked again, then skipped at EOF")
+}
+
+func TestApplyFixPlan(t *testing.T) {
+	configDir, homeDir := t.TempDir(), t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "vimrc"), []byte("set nu\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(homeDir, ".vimrc"), []byte("set nonu\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "gitconfig"), []byte("[user]\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(homeDir, ".gitconfig"), []byte("[user]\nname = me\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "zshrc"), []byte("export A=1\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "tmux.conf"), []byte("set -g mouse on\n"), 0644))
+
+	lockSvc := lock.NewLockV3Service(configDir)
+	lockFile := lock.NewLockV3()
+	lockFile.AddPackage("brew", "jq")
+	require.NoError(t, lockSvc.Write(lockFile))
+
+	plan := fixPlan{
+		deploy:  map[string]bool{filepath.Join(homeDir, ".zshrc"): true},
+		adopt:   []string{filepath.Join(homeDir, ".gitconfig")},
+		untrack: []string{"brew:jq"},
+		ignore:  []string{"/tmux.conf"},
+	}
+	cfg := config.LoadWithDefaults(configDir)
+	require.NoError(t, applyFixPlan(context.Background(), plan, cfg, configDir, homeDir))
+
+	data, err := os.ReadFile(filepath.Join(homeDir, ".zshrc"))
+	require.NoError(t, err)
+	assert.Equal(t, "export A=1\n", string(data), "missing dotfile deployed")
+
+	data, err = os.ReadFile(filepath.Join(configDir, "gitconfig"))
+	require.NoError(t, err)
+	assert.Equal(t, "[user]\nname = me\n", string(data), "drifted dotfile adopted")
+
+	data, err = os.ReadFile(filepath.Join(homeDir, ".vimrc"))
+	require.NoError(t, err)
+	assert.Equal(t, "set nonu\n", string(data), "unchosen dotfiles are left alone")
+
+	updated, err := lockSvc.Read()
+	require.NoError(t, err)
+	assert.False(t, updated.HasPackage("brew", "jq"))
+
+	cfg = config.LoadWithDefaults(configDir)
+	statuses, err := dotfiles.NewDotfileManager(configDir, homeDir, cfg.IgnorePatterns).Reconcile()
+	require.NoError(t, err)
+	for _, s := range statuses {
+		assert.NotEqual(t, "tmux.conf", s.Name, "ignored dotfile is no longer managed")
+	}
+}
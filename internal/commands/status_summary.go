@@ -0,0 +1,123 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package commands
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/richhaase/plonk/internal/config"
+	"github.com/richhaase/plonk/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// defaultSummaryMaxAge balances freshness against status bars polling every few seconds
+const defaultSummaryMaxAge = 60 * time.Second
+
+// runStatusSummary reports cached status counts, reconciling only when the
+// cache is missing, too old, or older than plonk.yaml/plonk.lock
+func runStatusSummary(cmd *cobra.Command, configDir, homeDir string, cfg *config.Config) error {
+	maxAge, _ := cmd.Flags().GetDuration("max-age")
+	widget, _ := cmd.Flags().GetString("widget")
+
+	cachePath := summaryCachePath(configDir)
+	counts, ok := readSummaryCache(cachePath, configDir, maxAge)
+	if !ok {
+		var err error
+		if counts, err = countStatus(cmd.Context(), configDir, homeDir, cfg); err != nil {
+			return err
+		}
+		if maxAge > 0 {
+			// Caching is best effort; a read-only cache dir just means slower polls
+			_ = writeSummaryCache(cachePath, counts)
+		}
+	}
+
+	if porcelain, _ := cmd.Flags().GetBool("porcelain"); porcelain {
+		fmt.Print(output.FormatStatusPorcelain(counts))
+		return nil
+	}
+	if widget != "" {
+		text, err := output.FormatStatusWidget(counts, widget)
+		if err != nil {
+			return err
+		}
+		fmt.Print(text)
+		return nil
+	}
+
+	output.RenderOutput(counts)
+	return nil
+}
+
+// countStatus reconciles packages and dotfiles and counts the result
+func countStatus(ctx context.Context, configDir, homeDir string, cfg *config.Config) (output.StatusCounts, error) {
+	summary, err := collectStatusSummary(ctx, configDir, homeDir, cfg, 0)
+	if err != nil {
+		return output.StatusCounts{}, err
+	}
+	counts := output.NewStatusCounts(summary)
+	counts.GeneratedAt = time.Now()
+	return counts, nil
+}
+
+// summaryCachePath returns a per-config-directory cache file under the user cache dir
+func summaryCachePath(configDir string) string {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		base = os.TempDir()
+	}
+	sum := sha256.Sum256([]byte(configDir))
+	return filepath.Join(base, "plonk", "status-summary-"+hex.EncodeToString(sum[:8])+".json")
+}
+
+// readSummaryCache returns cached counts if they are younger than maxAge and
+// newer than the config and lock files
+func readSummaryCache(path, configDir string, maxAge time.Duration) (output.StatusCounts, bool) {
+	var counts output.StatusCounts
+	if maxAge <= 0 {
+		return counts, false
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return counts, false
+	}
+	if err := json.Unmarshal(data, &counts); err != nil {
+		return counts, false
+	}
+	if time.Since(counts.GeneratedAt) > maxAge {
+		return counts, false
+	}
+	for _, name := range []string{"plonk.yaml", "plonk.lock"} {
+		if info, err := os.Stat(filepath.Join(configDir, name)); err == nil && info.ModTime().After(counts.GeneratedAt) {
+			return counts, false
+		}
+	}
+
+	counts.Cached = true
+	return counts, true
+}
+
+// writeSummaryCache atomically stores counts at path
+func writeSummaryCache(path string, counts output.StatusCounts) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+	data, err := json.Marshal(counts)
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/richhaase/plonk/internal/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummaryCache_RoundTrip(t *testing.T) {
+	configDir := t.TempDir()
+	cachePath := filepath.Join(t.TempDir(), "plonk", "summary.json")
+
+	counts := output.StatusCounts{Managed: 2, Missing: 1, GeneratedAt: time.Now()}
+	require.NoError(t, writeSummaryCache(cachePath, counts))
+
+	got, ok := readSummaryCache(cachePath, configDir, time.Minute)
+	require.True(t, ok)
+	assert.True(t, got.Cached)
+	assert.Equal(t, 1, got.Missing)
+
+	// Disabled cache is never read
+	_, ok = readSummaryCache(cachePath, configDir, 0)
+	assert.False(t, ok)
+}
+
+func TestSummaryCache_StaleAfterLockChange(t *testing.T) {
+	configDir := t.TempDir()
+	cachePath := filepath.Join(t.TempDir(), "summary.json")
+
+	counts := output.StatusCounts{GeneratedAt: time.Now().Add(-time.Second)}
+	require.NoError(t, writeSummaryCache(cachePath, counts))
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "plonk.lock"), []byte("version: 3\n"), 0o644))
+
+	_, ok := readSummaryCache(cachePath, configDir, time.Minute)
+	assert.False(t, ok)
+}
+
+func TestSummaryCache_Expired(t *testing.T) {
+	cachePath := filepath.Join(t.TempDir(), "summary.json")
+	require.NoError(t, writeSummaryCache(cachePath, output.StatusCounts{GeneratedAt: time.Now().Add(-2 * time.Minute)}))
+
+	_, ok := readSummaryCache(cachePath, t.TempDir(), time.Minute)
+	assert.False(t, ok)
+}
+
+func TestSummaryCachePath_PerConfigDir(t *testing.T) {
+	assert.NotEqual(t, summaryCachePath("/a"), summaryCachePath("/b"))
+}
+
+func TestCountsRequested(t *testing.T) {
+	tests := []struct {
+		args []string
+		want bool
+	}{
+		{nil, false},
+		{[]string{"--max-age", "5m"}, false},
+		{[]string{"--counts"}, true},
+		{[]string{"--summary", "json"}, true},
+		{[]string{"--widget", "waybar"}, true},
+		{[]string{"--porcelain"}, true},
+	}
+	for _, tt := range tests {
+		cmd := &cobra.Command{Use: "status"}
+		cmd.Flags().AddFlagSet(statusCmd.Flags())
+		cmd.Flags().AddFlagSet(rootCmd.PersistentFlags())
+		require.NoError(t, cmd.ParseFlags(tt.args))
+		assert.Equal(t, tt.want, countsRequested(cmd), "%v", tt.args)
+
+		// The flags are statusCmd's own, so put them back for the next case
+		cmd.Flags().Visit(func(f *pflag.Flag) {
+			_ = f.Value.Set(f.DefValue)
+			f.Changed = false
+		})
+	}
+}
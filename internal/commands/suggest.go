@@ -0,0 +1,72 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package commands
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/richhaase/plonk/internal/i18n"
+	"github.com/richhaase/plonk/internal/packages"
+	"github.com/spf13/cobra"
+)
+
+// unknownCommand rejects a first argument that names none of cmd's
+// subcommands, suggesting the ones it was probably meant to be. Cobra only
+// suggests names within two plain edits; this also counts a swapped pair of
+// letters as one edit and matches typos of each command's SuggestFor words,
+// so "plonk isntall" points at track.
+func unknownCommand(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return nil
+	}
+	msg := i18n.T("unknown command %q for %q", args[0], cmd.CommandPath())
+	if suggestions := suggestCommands(cmd, args[0]); len(suggestions) > 0 {
+		msg += "\n\n" + i18n.T("Did you mean this?") + "\n\t" + strings.Join(suggestions, "\n\t")
+	}
+	return fmt.Errorf("%s\n\n%s", msg, i18n.T("Run '%s --help' for usage", cmd.CommandPath()))
+}
+
+// suggestCommands returns the subcommands of cmd typed may have meant:
+// those known by that word, or failing that the nearest ones
+func suggestCommands(cmd *cobra.Command, typed string) []string {
+	var suggestions []string
+	add := func(name string) {
+		if !slices.Contains(suggestions, name) {
+			suggestions = append(suggestions, name)
+		}
+	}
+
+	commandsFor := make(map[string][]string) // name, alias or SuggestFor word -> commands
+	var words []string
+	for _, sub := range cmd.Commands() {
+		if !sub.IsAvailableCommand() {
+			continue
+		}
+		for _, word := range append(append([]string{sub.Name()}, sub.Aliases...), sub.SuggestFor...) {
+			if strings.EqualFold(word, typed) {
+				add(sub.Name())
+			}
+			if _, seen := commandsFor[word]; !seen {
+				words = append(words, word)
+			}
+			commandsFor[word] = append(commandsFor[word], sub.Name())
+		}
+	}
+	if len(suggestions) > 0 {
+		return suggestions // a word the command is known by beats a near miss
+	}
+	for _, word := range packages.Closest(strings.ToLower(typed), words) {
+		for _, name := range commandsFor[word] {
+			add(name)
+		}
+	}
+	for _, sub := range cmd.Commands() {
+		if sub.IsAvailableCommand() && strings.HasPrefix(sub.Name(), strings.ToLower(typed)) {
+			add(sub.Name())
+		}
+	}
+	return suggestions
+}
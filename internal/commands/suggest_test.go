@@ -0,0 +1,31 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuggestCommands(t *testing.T) {
+	tests := []struct {
+		typed string
+		want  []string
+	}{
+		{"trak", []string{"track"}},
+		{"isntall", []string{"track"}},
+		{"install", []string{"track"}},
+		{"uninstall", []string{"untrack"}},
+		{"stauts", []string{"stats", "status"}},
+		{"xyzzy", nil},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, suggestCommands(rootCmd, tt.typed), tt.typed)
+	}
+
+	err := unknownCommand(rootCmd, []string{"isntall"})
+	assert.EqualError(t, err, "unknown command \"isntall\" for \"plonk\"\n\nDid you mean this?\n\ttrack\n\nRun 'plonk --help' for usage")
+	assert.NoError(t, unknownCommand(rootCmd, nil))
+}
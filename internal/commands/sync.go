@@ -0,0 +1,154 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package commands
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/richhaase/plonk/internal/config"
+	"github.com/richhaase/plonk/internal/orchestrator"
+	"github.com/richhaase/plonk/internal/output"
+	"github.com/richhaase/plonk/internal/secrets"
+	"github.com/richhaase/plonk/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Sync the plonk directory with an S3, Gist or HTTP backend",
+	Long: `Sync $PLONK_DIR with the backend configured under sync: in plonk.yaml,
+for machines that share config without a git remote.
+
+The whole directory (except .git) is stored as one snapshot. plonk remembers
+what was last synced on this machine, so a plain 'plonk sync' pushes local
+changes or pulls remote ones. If both sides changed, it stops and asks you
+to choose with --push or --pull.
+
+Files a push would add or change are scanned for probable secrets first,
+as 'plonk scan secrets' does; the push stops if any are found unless you
+pass --allow-secrets.
+
+Backends:
+  s3     sync.url: s3://bucket/key (AWS_ACCESS_KEY_ID/AWS_SECRET_ACCESS_KEY)
+  gist   sync.gist_id: <id>        (PLONK_GIST_TOKEN or GITHUB_TOKEN)
+  http   sync.url: https://...     (GET/PUT; PLONK_SYNC_TOKEN as bearer token)
+
+Examples:
+  plonk sync                # Push or pull, whichever side changed
+  plonk sync --dry-run      # Show what would change
+  plonk sync --pull --apply # Take the backend's copy and apply it
+  plonk sync --push         # Overwrite the backend with local files`,
+	RunE:         runSync,
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+}
+
+func init() {
+	rootCmd.AddCommand(syncCmd)
+	syncCmd.Flags().Bool("push", false, "Overwrite the backend with the local directory")
+	syncCmd.Flags().Bool("pull", false, "Overwrite the local directory with the backend")
+	syncCmd.Flags().BoolP("dry-run", "n", false, "Show what would change without syncing")
+	syncCmd.Flags().BoolP("apply", "a", false, "Run plonk apply after pulling changes")
+	syncCmd.Flags().Bool("allow-secrets", false, "Push files even if they contain probable secrets")
+	syncCmd.MarkFlagsMutuallyExclusive("push", "pull")
+}
+
+func runSync(cmd *cobra.Command, args []string) error {
+	push, _ := cmd.Flags().GetBool("push")
+	pull, _ := cmd.Flags().GetBool("pull")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	applyAfter, _ := cmd.Flags().GetBool("apply")
+	allowSecrets, _ := cmd.Flags().GetBool("allow-secrets")
+
+	ctx := cmd.Context()
+	configDir := config.GetDefaultConfigDirectory()
+	cfg := config.LoadWithDefaults(configDir)
+
+	backend, err := storage.New(cfg.Sync)
+	if err != nil {
+		return err
+	}
+
+	statePath := syncStatePath(configDir)
+	opts := storage.Options{
+		LastDigest: storage.LoadLastDigest(statePath, backend.Name()),
+		DryRun:     dryRun,
+	}
+	switch {
+	case push:
+		opts.Direction = storage.Push
+	case pull:
+		opts.Direction = storage.Pull
+	}
+	opts.Host, _ = os.Hostname()
+	if !allowSecrets {
+		opts.CheckPush = func(files map[string][]byte) error {
+			if findings := secrets.ScanFiles(files); len(findings) > 0 {
+				return secrets.Error(findings)
+			}
+			return nil
+		}
+	}
+
+	result, err := storage.Sync(ctx, backend, configDir, opts)
+	switch {
+	case errors.Is(err, storage.ErrConflict):
+		return fmt.Errorf("%w; rerun with --push to keep local files or --pull to take %s", err, backend.Name())
+	case errors.Is(err, storage.ErrNoSnapshot):
+		return fmt.Errorf("nothing to pull: %s is empty", backend.Name())
+	case err != nil:
+		return err
+	}
+
+	for _, change := range result.Changes {
+		output.Printf("  %s\n", change)
+	}
+	switch {
+	case result.Action == storage.ActionUpToDate:
+		output.Printf("Already in sync with %s\n", backend.Name())
+	case dryRun:
+		output.Printf("Dry run: would have %s %d file changes (%s)\n", result.Action, len(result.Changes), backend.Name())
+	default:
+		output.Printf("Sync complete: %s %d file changes (%s)\n", result.Action, len(result.Changes), backend.Name())
+	}
+	if dryRun {
+		return nil
+	}
+
+	if err := storage.SaveLastDigest(statePath, backend.Name(), result.Digest); err != nil {
+		output.Printf("Warning: failed to record sync state: %v\n", err)
+	}
+
+	if applyAfter && result.Action == storage.ActionPulled {
+		output.Println("Applying configuration...")
+		homeDir, err := config.GetHomeDir()
+		if err != nil {
+			return fmt.Errorf("cannot determine home directory: %w", err)
+		}
+		orch := orchestrator.New(
+			orchestrator.WithConfig(config.LoadWithDefaults(configDir)),
+			orchestrator.WithConfigDir(configDir),
+			orchestrator.WithHistory(orchestrator.HistoryPath()),
+			orchestrator.WithHomeDir(homeDir),
+		)
+		applyResult, err := orch.Apply(ctx)
+		output.RenderOutput(applyResult)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// syncStatePath returns where this machine records the last synced digest
+// for configDir
+func syncStatePath(configDir string) string {
+	sum := sha256.Sum256([]byte(configDir))
+	return filepath.Join(config.GetStateDirectory(), "sync-"+hex.EncodeToString(sum[:8])+".json")
+}
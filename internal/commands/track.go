@@ -6,6 +6,8 @@ package commands
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/richhaase/plonk/internal/config"
 	"github.com/richhaase/plonk/internal/gitops"
@@ -15,7 +17,7 @@ import (
 )
 
 var trackCmd = &cobra.Command{
-	Use:   "track <manager:package>...",
+	Use:   "track <manager:package|alias|package>...",
 	Short: "Track installed packages",
 	Long: `Track packages that are already installed on your system.
 
@@ -25,20 +27,46 @@ in sync across machines.
 
 The package must already be installed - track only records existing packages.
 
+An alias from plonk.yaml may be given instead of manager:package; it tracks
+the first of the alias's packages that is installed on this machine. A bare
+package name is looked up with each manager in manager_priority for this OS
+(or default_manager) and tracked with the first that has it installed.
+
+Arguments after -- are recorded for a single package under
+managers.<manager>.package_args in plonk.yaml and passed to its manager
+whenever plonk installs it, e.g. on a new machine. Nothing after -- clears
+them.
+
 Examples:
   plonk track brew:ripgrep           # Track a brew package
   plonk track cargo:bat go:golang.org/x/tools/gopls # Track multiple packages
-  plonk track pnpm:typescript        # Track a pnpm package`,
+  plonk track pnpm:typescript        # Track a pnpm package
+  plonk track rg                     # Track whichever package the rg alias resolves to
+  plonk track jq                     # Track jq from the first preferred manager that has it
+  plonk track brew:ffmpeg -- --with-libvpx # Track ffmpeg and install it with --with-libvpx
+  plonk track --dry-run rg fd        # Preview what would be tracked`,
 	Args:         cobra.MinimumNArgs(1),
 	RunE:         runTrack,
+	SuggestFor:   []string{"install"},
 	SilenceUsage: true,
 }
 
 func init() {
 	rootCmd.AddCommand(trackCmd)
+	trackCmd.Flags().BoolP("dry-run", "n", false, "Show what would be tracked without making changes")
 }
 
 func runTrack(cmd *cobra.Command, args []string) error {
+	specs, installArgs := args, []string(nil)
+	dash := cmd.ArgsLenAtDash()
+	if dash >= 0 {
+		specs, installArgs = args[:dash], args[dash:]
+		if len(specs) != 1 {
+			return fmt.Errorf("arguments after -- apply to a single package, got %d", len(specs))
+		}
+	}
+
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
 	configDir := config.GetDefaultConfigDirectory()
 	lockSvc := lock.NewLockV3Service(configDir)
 
@@ -48,27 +76,61 @@ func runTrack(cmd *cobra.Command, args []string) error {
 	}
 
 	ctx := context.Background()
+	cfg := config.LoadWithDefaults(configDir)
+	packages.Configure(cfg)
 	var tracked, skipped, failed int
-
-	for _, arg := range args {
-		manager, pkg, err := packages.ParsePackageSpec(arg)
+	var argsChanged bool
+	var errs []error
+
+	for _, arg := range specs {
+		var manager, pkg string
+		switch {
+		case len(cfg.Aliases[arg]) > 0:
+			manager, pkg, err = packages.ResolveAlias(ctx, arg, cfg.Aliases[arg])
+		case !strings.Contains(arg, ":"):
+			manager, pkg, err = packages.ResolveAlias(ctx, arg, prefixedSpecs(cfg.ManagerOrder(), arg))
+		default:
+			manager, pkg, err = packages.ParsePackageSpec(arg)
+		}
 		if err != nil {
 			fmt.Printf("Error: %s: %v\n", arg, err)
+			errs = append(errs, err)
+			failed++
+			continue
+		}
+
+		if dash >= 0 && !slices.Contains(packages.SupportedManagers, manager) {
+			err := fmt.Errorf("%s is a plugin manager and takes no install arguments", manager)
+			fmt.Printf("Error: %s: %v\n", arg, err)
+			errs = append(errs, err)
 			failed++
 			continue
 		}
 
 		// Check if already tracked
 		if lockFile.HasPackage(manager, pkg) {
-			fmt.Printf("Skipping %s:%s (already tracked)\n", manager, pkg)
+			if dash >= 0 {
+				changed, err := recordInstallArgs(configDir, cfg, manager, pkg, installArgs, dryRun)
+				if err != nil {
+					return err
+				}
+				argsChanged = changed
+			}
+			if !argsChanged {
+				fmt.Printf("Skipping %s:%s (already tracked)\n", manager, pkg)
+			}
 			skipped++
 			continue
 		}
 
 		// Get manager and verify package is installed
 		mgr, err := packages.GetManager(manager)
+		if err == nil && packages.IsManagerDisabled(manager) {
+			err = packages.CheckManagerAvailable(manager)
+		}
 		if err != nil {
 			fmt.Printf("Error: %s: %v\n", arg, err)
+			errs = append(errs, err)
 			failed++
 			continue
 		}
@@ -76,34 +138,93 @@ func runTrack(cmd *cobra.Command, args []string) error {
 		installed, err := mgr.IsInstalled(ctx, pkg)
 		if err != nil {
 			fmt.Printf("Error checking %s:%s: %v\n", manager, pkg, err)
+			errs = append(errs, err)
 			failed++
 			continue
 		}
 
 		if !installed {
-			fmt.Printf("Error: %s:%s is not installed\n", manager, pkg)
+			hint := ""
+			if suggestion := packages.SuggestPackage(ctx, manager, pkg); suggestion != "" {
+				hint = fmt.Sprintf("; did you mean %s:%s?", manager, suggestion)
+			}
+			fmt.Printf("Error: %s:%s is not installed%s\n", manager, pkg, hint)
 			failed++
 			continue
 		}
 
 		// Add to lock file
 		lockFile.AddPackage(manager, pkg)
-		fmt.Printf("Tracking %s:%s\n", manager, pkg)
+		if dryRun {
+			fmt.Printf("Would track %s:%s\n", manager, pkg)
+		} else {
+			fmt.Printf("Tracking %s:%s\n", manager, pkg)
+		}
 		tracked++
+		if dash >= 0 {
+			if argsChanged, err = recordInstallArgs(configDir, cfg, manager, pkg, installArgs, dryRun); err != nil {
+				return err
+			}
+		}
 	}
 
 	// Write updated lock file
-	if tracked > 0 {
+	if tracked > 0 && !dryRun {
 		if err := lockSvc.Write(lockFile); err != nil {
 			return fmt.Errorf("failed to write lock file: %w", err)
 		}
-		gitops.AutoCommit(cmd.Context(), configDir, "track", args)
+	}
+	if (tracked > 0 || argsChanged) && !dryRun {
+		gitops.AutoCommit(cmd.Context(), configDir, "track", specs)
 	}
 
 	// Summary
 	if failed > 0 {
-		return fmt.Errorf("tracked %d, skipped %d, failed %d", tracked, skipped, failed)
+		return &batchError{
+			summary: fmt.Sprintf("tracked %d, skipped %d, failed %d", tracked, skipped, failed),
+			errs:    errs,
+		}
 	}
 
 	return nil
 }
+
+// recordInstallArgs saves the arguments passed after -- for a package in
+// plonk.yaml, reporting whether they changed. A dry run compares them with
+// cfg's and saves nothing.
+func recordInstallArgs(configDir string, cfg *config.Config, manager, pkg string, installArgs []string, dryRun bool) (bool, error) {
+	if dryRun {
+		if slices.Equal(cfg.Managers.Common(manager).PackageArgs[pkg], installArgs) {
+			return false, nil
+		}
+		if len(installArgs) > 0 {
+			fmt.Printf("Would record install arguments for %s:%s: %s\n", manager, pkg, strings.Join(installArgs, " "))
+		} else {
+			fmt.Printf("Would clear install arguments for %s:%s\n", manager, pkg)
+		}
+		return true, nil
+	}
+
+	changed, err := config.SetPackageArgs(configDir, manager, pkg, installArgs)
+	if err != nil {
+		return false, fmt.Errorf("failed to record install arguments: %w", err)
+	}
+	if !changed {
+		return false, nil
+	}
+	if len(installArgs) > 0 {
+		fmt.Printf("Recorded install arguments for %s:%s: %s\n", manager, pkg, strings.Join(installArgs, " "))
+	} else {
+		fmt.Printf("Cleared install arguments for %s:%s\n", manager, pkg)
+	}
+	return true, nil
+}
+
+// prefixedSpecs qualifies pkg with each manager, in order
+func prefixedSpecs(managers []string, pkg string) []string {
+	specs := make([]string, len(managers))
+	for i, manager := range managers {
+		specs[i] = manager + ":" + pkg
+	}
+	return specs
+}
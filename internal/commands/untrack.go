@@ -10,6 +10,7 @@ import (
 	"github.com/richhaase/plonk/internal/config"
 	"github.com/richhaase/plonk/internal/gitops"
 	"github.com/richhaase/plonk/internal/lock"
+	"github.com/richhaase/plonk/internal/packages"
 	"github.com/spf13/cobra"
 )
 
@@ -24,17 +25,21 @@ longer managed by plonk.
 
 Examples:
   plonk untrack brew:ripgrep           # Stop tracking a brew package
-  plonk untrack cargo:bat go:golang.org/x/tools/gopls # Stop tracking multiple packages`,
+  plonk untrack cargo:bat go:golang.org/x/tools/gopls # Stop tracking multiple packages
+  plonk untrack --dry-run brew:ripgrep # Preview what would be untracked`,
 	Args:         cobra.MinimumNArgs(1),
 	RunE:         runUntrack,
+	SuggestFor:   []string{"uninstall", "remove"},
 	SilenceUsage: true,
 }
 
 func init() {
 	rootCmd.AddCommand(untrackCmd)
+	untrackCmd.Flags().BoolP("dry-run", "n", false, "Show what would be untracked without making changes")
 }
 
 func runUntrack(cmd *cobra.Command, args []string) error {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
 	configDir := config.GetDefaultConfigDirectory()
 	lockSvc := lock.NewLockV3Service(configDir)
 
@@ -56,19 +61,27 @@ func runUntrack(cmd *cobra.Command, args []string) error {
 
 		// Check if tracked
 		if !lockFile.HasPackage(manager, pkg) {
-			fmt.Printf("Skipping %s:%s (not tracked)\n", manager, pkg)
+			hint := ""
+			if near := packages.Closest(pkg, lockFile.Packages[manager]); len(near) > 0 {
+				hint = fmt.Sprintf("; did you mean %s:%s?", manager, near[0])
+			}
+			fmt.Printf("Skipping %s:%s (not tracked%s)\n", manager, pkg, hint)
 			skipped++
 			continue
 		}
 
 		// Remove from lock file
 		lockFile.RemovePackage(manager, pkg)
-		fmt.Printf("Untracking %s:%s\n", manager, pkg)
+		if dryRun {
+			fmt.Printf("Would untrack %s:%s\n", manager, pkg)
+		} else {
+			fmt.Printf("Untracking %s:%s\n", manager, pkg)
+		}
 		untracked++
 	}
 
 	// Write updated lock file
-	if untracked > 0 {
+	if untracked > 0 && !dryRun {
 		if err := lockSvc.Write(lockFile); err != nil {
 			return fmt.Errorf("failed to write lock file: %w", err)
 		}
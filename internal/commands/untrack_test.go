@@ -0,0 +1,43 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/richhaase/plonk/internal/lock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUntrackDryRun(t *testing.T) {
+	home := t.TempDir()
+	configDir := filepath.Join(home, "plonk")
+	require.NoError(t, os.MkdirAll(configDir, 0o755))
+	t.Setenv("HOME", home)
+	t.Setenv("PLONK_DIR", configDir)
+	t.Cleanup(func() {
+		rootCmd.SetArgs(nil)
+		_ = untrackCmd.Flags().Set("dry-run", "false")
+	})
+
+	lockSvc := lock.NewLockV3Service(configDir)
+	lockFile := lock.NewLockV3()
+	lockFile.AddPackage("brew", "ripgrep")
+	require.NoError(t, lockSvc.Write(lockFile))
+
+	rootCmd.SetArgs([]string{"untrack", "--dry-run", "brew:ripgrep"})
+	require.NoError(t, rootCmd.Execute())
+	lockFile, err := lockSvc.Read()
+	require.NoError(t, err)
+	assert.True(t, lockFile.HasPackage("brew", "ripgrep"), "--dry-run never changes the lock file")
+
+	rootCmd.SetArgs([]string{"untrack", "--dry-run=false", "brew:ripgrep"})
+	require.NoError(t, rootCmd.Execute())
+	lockFile, err = lockSvc.Read()
+	require.NoError(t, err)
+	assert.False(t, lockFile.HasPackage("brew", "ripgrep"))
+}
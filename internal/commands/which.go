@@ -0,0 +1,91 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package commands
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/richhaase/plonk/internal/config"
+	"github.com/richhaase/plonk/internal/lock"
+	"github.com/richhaase/plonk/internal/output"
+	"github.com/richhaase/plonk/internal/packages"
+	"github.com/spf13/cobra"
+)
+
+var whichCmd = &cobra.Command{
+	Use:   "which <command>",
+	Short: "Show which tracked package provides a command",
+	Long: `Show which tracked package provides a command, the copy its manager
+installed, and whether that is the copy that runs from PATH.
+
+When several tracked packages provide the command, the one whose manager
+comes first in manager_priority is chosen, as for 'plonk shims'.
+
+Examples:
+  plonk which rg
+  plonk which nvim`,
+	RunE:         runWhich,
+	SilenceUsage: true,
+	Args:         cobra.ExactArgs(1),
+}
+
+func init() {
+	rootCmd.AddCommand(whichCmd)
+}
+
+func runWhich(cmd *cobra.Command, args []string) error {
+	binary := args[0]
+
+	configDir := config.GetDefaultConfigDirectory()
+	cfg := config.LoadWithDefaults(configDir)
+	lockFile, err := lock.NewLockV3Service(configDir).Read()
+	if err != nil {
+		return fmt.Errorf("failed to read lock file: %w", err)
+	}
+
+	pathEnv := os.Getenv("PATH")
+	shimDir := config.GetShimDirectory()
+	shims, _ := packages.PlanShims(lockFile, cfg.ManagerOrder(), pathEnv, shimDir)
+	onPath, _ := exec.LookPath(binary)
+
+	var shim *packages.Shim
+	for i := range shims {
+		if shims[i].Binary == binary {
+			shim = &shims[i]
+		}
+	}
+	if shim == nil {
+		if onPath == "" {
+			return fmt.Errorf("%s is not provided by a tracked package and is not on PATH", binary)
+		}
+		output.Printf("%s is not provided by a tracked package; PATH runs %s\n", binary, onPath)
+		return nil
+	}
+
+	output.Printf("%s: %s\n", binary, shim.Spec)
+	output.Printf("  %s\n", shim.Target)
+	if len(shim.Shadowed) > 0 {
+		output.Printf("  also tracked: %s\n", strings.Join(shim.Shadowed, ", "))
+	}
+
+	switch {
+	case onPath == shim.Target:
+		output.Printf("  %s runs from PATH\n", output.IconSuccess)
+	case onPath != "" && filepath.Dir(onPath) == filepath.Clean(shimDir):
+		if target, err := os.Readlink(onPath); err == nil && target == shim.Target {
+			output.Printf("  %s runs from PATH through the shim %s\n", output.IconSuccess, onPath)
+		} else {
+			output.Printf("  %s the shim %s is stale; run 'plonk shims'\n", output.IconWarning, onPath)
+		}
+	case onPath != "":
+		output.Printf("  %s PATH runs %s instead; run 'plonk shims' and put %s first on PATH\n", output.IconWarning, onPath, shimDir)
+	default:
+		output.Printf("  %s not on PATH; run 'plonk shims' and put %s first on PATH\n", output.IconWarning, shimDir)
+	}
+	return nil
+}
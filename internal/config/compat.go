@@ -33,6 +33,34 @@ func GetDefaultConfigDirectory() string {
 	return filepath.Join(os.Getenv("HOME"), ".config", "plonk")
 }
 
+// GetBackupDirectory returns where migrations keep the files they replace.
+// It is inside the config directory so backups travel with the repo, and
+// dot-prefixed so they are never deployed as dotfiles.
+func GetBackupDirectory(configDir string) string {
+	return filepath.Join(configDir, ".backups")
+}
+
+// GetStateDirectory returns the directory for plonk's per-machine state and
+// log files: $XDG_STATE_HOME/plonk, or ~/.local/state/plonk. These are kept
+// out of the config directory so they are never deployed or committed.
+func GetStateDirectory() string {
+	if stateDir := os.Getenv("XDG_STATE_HOME"); stateDir != "" {
+		return filepath.Join(stateDir, "plonk")
+	}
+	return filepath.Join(os.Getenv("HOME"), ".local", "state", "plonk")
+}
+
+// GetShimDirectory returns the directory of plonk's command shims:
+// $XDG_DATA_HOME/plonk/shims, or ~/.local/share/plonk/shims. It goes first
+// on PATH so the manager that tracks a package decides which copy of its
+// command runs.
+func GetShimDirectory() string {
+	if dataDir := os.Getenv("XDG_DATA_HOME"); dataDir != "" {
+		return filepath.Join(dataDir, "plonk", "shims")
+	}
+	return filepath.Join(os.Getenv("HOME"), ".local", "share", "plonk", "shims")
+}
+
 // GetDefaults returns the default configuration
 func GetDefaults() *Config {
 	return &defaultConfig
@@ -50,11 +78,10 @@ type SimpleValidator struct {
 	validator *validator.Validate
 }
 
-// NewSimpleValidator creates a new validator
+// NewSimpleValidator creates a new validator. It shares the lazily built
+// validator used by Load, so struct tags are only parsed once per process.
 func NewSimpleValidator() *SimpleValidator {
-	v := validator.New()
-	// Register custom validators
-	_ = RegisterValidators(v)
+	v, _ := getValidator()
 	return &SimpleValidator{validator: v}
 }
 
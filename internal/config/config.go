@@ -8,10 +8,11 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"runtime"
+	"slices"
 	"sync"
 
 	"github.com/go-playground/validator/v10"
-	"gopkg.in/yaml.v3"
 )
 
 var (
@@ -35,6 +36,48 @@ type Config struct {
 	Dotfiles          Dotfiles                 `yaml:"dotfiles,omitempty"`
 	DiffTool          string                   `yaml:"diff_tool,omitempty"`
 	Git               GitConfig                `yaml:"git,omitempty"`
+	Resources         []string                 `yaml:"resources,omitempty"` // shell_fragments, or names of plonk-resource-<name> plugins
+	Notifications     NotificationsConfig      `yaml:"notifications,omitempty"`
+	Sync              SyncConfig               `yaml:"sync,omitempty"`
+	Managers          ManagersConfig           `yaml:"managers,omitempty"`
+	Aliases           map[string][]string      `yaml:"aliases,omitempty" validate:"omitempty,dive,keys,required,excludes=:,endkeys,min=1,dive,required,contains=:"` // name -> equivalent manager:package specs, in order of preference
+	ManagerPriority   map[string][]string      `yaml:"manager_priority,omitempty" validate:"omitempty,dive,keys,oneof=darwin linux,endkeys,min=1,dive,required,validmanager"` // GOOS -> managers tried in order for unprefixed packages
+	DisabledManagers  []string                 `yaml:"disabled_managers,omitempty" validate:"omitempty,dive,required"` // managers plonk never runs
+	Include           []string                 `yaml:"include,omitempty" validate:"omitempty,dive,required"`           // files layered under this one, relative to it
+	SigningKey        string                   `yaml:"signing_key,omitempty"`                                           // SSH or minisign key that signs plonk.lock
+	Shims             bool                     `yaml:"shims,omitempty"`                                                 // link tracked packages' commands into the shim directory on apply
+	Containers        ContainersConfig         `yaml:"containers,omitempty"`                                            // settings used inside devcontainers, Codespaces and other containers
+
+	// Container is the kind of container detected at load time; the
+	// containers settings are in effect when it is set
+	Container string `yaml:"-"`
+}
+
+// SyncConfig selects a non-git backend for `plonk sync`
+type SyncConfig struct {
+	Backend  string `yaml:"backend,omitempty" validate:"omitempty,oneof=s3 gist http"`
+	URL      string `yaml:"url,omitempty"`      // s3://bucket/key or http(s)://...
+	GistID   string `yaml:"gist_id,omitempty"`  // gist backend
+	Region   string `yaml:"region,omitempty"`   // s3 backend (default: $AWS_REGION or us-east-1)
+	Endpoint string `yaml:"endpoint,omitempty"` // s3-compatible endpoint, e.g. for MinIO or R2
+}
+
+// NotificationsConfig controls desktop notifications sent by plonk serve
+type NotificationsConfig struct {
+	Enabled bool     `yaml:"enabled,omitempty"`
+	Events  []string `yaml:"events,omitempty" validate:"omitempty,dive,oneof=apply failure drift"` // default: all
+}
+
+// NotifyOn reports whether notifications are enabled for event
+// ("apply", "failure" or "drift")
+func (n NotificationsConfig) NotifyOn(event string) bool {
+	if !n.Enabled {
+		return false
+	}
+	if len(n.Events) == 0 {
+		return true
+	}
+	return slices.Contains(n.Events, event)
 }
 
 // AutoCommitEnabled returns whether auto-commit is enabled.
@@ -46,9 +89,39 @@ func (c *Config) AutoCommitEnabled() bool {
 	return *c.Git.AutoCommit
 }
 
+// ManagerOrder returns the managers to try, in order, for a package named
+// without a manager prefix: manager_priority for the current OS, falling
+// back to default_manager.
+func (c *Config) ManagerOrder() []string {
+	return c.managerOrder(runtime.GOOS)
+}
+
+func (c *Config) managerOrder(goos string) []string {
+	if managers := c.containerManagerOrder(goos); managers != nil {
+		return managers
+	}
+	if managers := c.ManagerPriority[goos]; len(managers) > 0 {
+		return managers
+	}
+	if c.DefaultManager != "" {
+		return []string{c.DefaultManager}
+	}
+	return []string{defaultConfig.DefaultManager}
+}
+
 // Dotfiles contains dotfile-specific configuration
 type Dotfiles struct {
-	UnmanagedFilters []string `yaml:"unmanaged_filters,omitempty"`
+	UnmanagedFilters []string                     `yaml:"unmanaged_filters,omitempty"`
+	Layout           string                       `yaml:"layout,omitempty" validate:"omitempty,oneof=flat mirrored-home custom"`  // how dotfiles are arranged in the plonk directory; default flat
+	Map              map[string]string            `yaml:"map,omitempty" validate:"omitempty,dive,keys,required,endkeys,required"` // custom layout: source path -> target under $HOME
+	LFS              bool                         `yaml:"lfs,omitempty"`                                                          // store binary and large dotfiles through git-lfs
+	Templates        map[string]TemplateVariables `yaml:"templates,omitempty"`                                                    // per-template variables, by source name
+}
+
+// TemplateVariables are the variables of one template besides the environment
+type TemplateVariables struct {
+	Vars   map[string]string `yaml:"vars,omitempty"`   // fixed values for this template
+	Prompt map[string]string `yaml:"prompt,omitempty"` // variable -> question asked once per machine
 }
 
 // defaultConfig holds the default configuration values
@@ -181,8 +254,23 @@ func Load(configDir string) (*Config, error) {
 	return LoadFromPath(configPath)
 }
 
-// LoadFromPath reads and validates configuration from a specific path
+// LoadFromPath reads and validates configuration from a specific path.
+// Paths in the config have ~ and environment variables expanded.
 func LoadFromPath(configPath string) (*Config, error) {
+	cfg, err := LoadUnexpanded(configPath)
+	if err != nil {
+		return nil, err
+	}
+	if err := expandConfigPaths(cfg); err != nil {
+		return nil, err
+	}
+	cfg.detectContainer(DetectContainer())
+	return cfg, nil
+}
+
+// LoadUnexpanded is LoadFromPath without expanding ~ and environment
+// variables, for callers that write the config back out
+func LoadUnexpanded(configPath string) (*Config, error) {
 	// Start with a copy of defaults
 	cfg := defaultConfig
 
@@ -196,8 +284,8 @@ func LoadFromPath(configPath string) (*Config, error) {
 		return nil, err
 	}
 
-	// Unmarshal YAML over defaults
-	if err := yaml.Unmarshal(data, &cfg); err != nil {
+	// Unmarshal YAML, and any files it includes, over defaults
+	if err := decodeLayered(&cfg, configPath, data, nil); err != nil {
 		return nil, err
 	}
 
@@ -224,6 +312,7 @@ func LoadWithDefaults(configDir string) *Config {
 		log.Printf("Warning: failed to load config from %s, using defaults: %v", configDir, err)
 		// Return copy of defaults on any error
 		defaultCopy := defaultConfig
+		defaultCopy.detectContainer(DetectContainer())
 		return &defaultCopy
 	}
 	return cfg
@@ -416,3 +416,175 @@ func TestGitConfigYAMLRoundTrip(t *testing.T) {
 		t.Error("expected auto-commit to be disabled after loading YAML with auto_commit: false")
 	}
 }
+
+func TestNotificationsNotifyOn(t *testing.T) {
+	if (NotificationsConfig{}).NotifyOn("apply") {
+		t.Error("expected notifications to be disabled by default")
+	}
+	all := NotificationsConfig{Enabled: true}
+	if !all.NotifyOn("drift") {
+		t.Error("expected all events when no events are listed")
+	}
+	some := NotificationsConfig{Enabled: true, Events: []string{"failure"}}
+	if some.NotifyOn("apply") || !some.NotifyOn("failure") {
+		t.Error("expected only listed events to be enabled")
+	}
+}
+
+func TestLoad_InvalidNotificationEvent(t *testing.T) {
+	tempDir := testutil.NewTestConfig(t, "notifications:\n  enabled: true\n  events: [upgrade]\n")
+	if _, err := Load(tempDir); err == nil {
+		t.Error("expected validation error for unknown notification event")
+	}
+}
+
+func TestLoad_ManagerOptions(t *testing.T) {
+	tempDir := testutil.NewTestConfig(t, `managers:
+  brew:
+    no_auto_update: true
+  cargo:
+    locked: true
+    features: [pcre2]
+    install_args: ["--jobs", "4"]
+  uv:
+    index_url: https://pypi.example.com/simple
+`)
+	cfg, err := Load(tempDir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !cfg.Managers.Brew.NoAutoUpdate || !cfg.Managers.Cargo.Locked {
+		t.Errorf("expected brew and cargo flags to be set: %+v", cfg.Managers)
+	}
+	if len(cfg.Managers.Cargo.InstallArgs) != 2 || cfg.Managers.Cargo.Features[0] != "pcre2" {
+		t.Errorf("unexpected cargo options: %+v", cfg.Managers.Cargo)
+	}
+	if cfg.Managers.UV.IndexURL != "https://pypi.example.com/simple" {
+		t.Errorf("unexpected uv index_url: %q", cfg.Managers.UV.IndexURL)
+	}
+}
+
+func TestLoad_InvalidManagerRegistry(t *testing.T) {
+	tempDir := testutil.NewTestConfig(t, "managers:\n  pnpm:\n    registry: not a url\n")
+	if _, err := Load(tempDir); err == nil {
+		t.Error("expected validation error for invalid pnpm registry")
+	}
+}
+
+func TestLoad_BrewPackageOptions(t *testing.T) {
+	tempDir := testutil.NewTestConfig(t, "managers:\n  brew:\n    packages:\n      neovim:\n        head: true\n      ffmpeg:\n        build_from_source: true\n        options: [--with-libvpx]\n")
+	cfg, err := Load(tempDir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !cfg.Managers.Brew.Packages["neovim"].Head || !cfg.Managers.Brew.Packages["ffmpeg"].BuildFromSource {
+		t.Errorf("unexpected brew packages: %+v", cfg.Managers.Brew.Packages)
+	}
+
+	bad := testutil.NewTestConfig(t, "managers:\n  brew:\n    packages:\n      ffmpeg:\n        options: [with-libvpx]\n")
+	if _, err := Load(bad); err == nil {
+		t.Error("expected validation error for an option without a leading '-'")
+	}
+}
+
+func TestLoad_PNPMScopes(t *testing.T) {
+	tempDir := testutil.NewTestConfig(t, "managers:\n  pnpm:\n    scopes:\n      \"@company\":\n        registry: https://npm.company.com\n        token_env: COMPANY_NPM_TOKEN\n")
+	cfg, err := Load(tempDir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := cfg.Managers.PNPM.Scopes["@company"]; got.Registry != "https://npm.company.com" || got.TokenEnv != "COMPANY_NPM_TOKEN" {
+		t.Errorf("unexpected scope: %+v", got)
+	}
+
+	for _, bad := range []string{
+		"managers:\n  pnpm:\n    scopes:\n      company:\n        registry: https://npm.company.com\n",
+		"managers:\n  pnpm:\n    scopes:\n      \"@company\":\n        token_env: TOKEN\n",
+	} {
+		if _, err := Load(testutil.NewTestConfig(t, bad)); err == nil {
+			t.Errorf("expected validation error for %q", bad)
+		}
+	}
+}
+
+func TestLoad_ManagerEnv(t *testing.T) {
+	tempDir := testutil.NewTestConfig(t, "managers:\n  go:\n    env:\n      GOFLAGS: -mod=mod\n")
+	cfg, err := Load(tempDir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := cfg.Managers.Common("go").Env["GOFLAGS"]; got != "-mod=mod" {
+		t.Errorf("GOFLAGS = %q", got)
+	}
+
+	bad := testutil.NewTestConfig(t, "managers:\n  go:\n    env:\n      \"A=B\": x\n")
+	if _, err := Load(bad); err == nil {
+		t.Error("expected validation error for env name containing '='")
+	}
+}
+
+func TestLoad_Aliases(t *testing.T) {
+	tempDir := testutil.NewTestConfig(t, "aliases:\n  rg: [brew:ripgrep, cargo:ripgrep]\n")
+	cfg, err := Load(tempDir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := cfg.Aliases["rg"]; len(got) != 2 || got[1] != "cargo:ripgrep" {
+		t.Errorf("aliases[rg] = %v", got)
+	}
+
+	for _, bad := range []string{
+		"aliases:\n  rg: [ripgrep]\n",           // not manager:package
+		"aliases:\n  rg: []\n",                  // no candidates
+		"aliases:\n  brew:rg: [brew:ripgrep]\n", // alias names cannot look like specs
+	} {
+		if _, err := Load(testutil.NewTestConfig(t, bad)); err == nil {
+			t.Errorf("expected validation error for %q", bad)
+		}
+	}
+}
+
+func TestLoad_ManagerPriority(t *testing.T) {
+	tempDir := testutil.NewTestConfig(t, "default_manager: cargo\nmanager_priority:\n  darwin: [brew, cargo]\n  linux: [cargo, brew]\n")
+	cfg, err := Load(tempDir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := cfg.managerOrder("darwin"); len(got) != 2 || got[0] != "brew" {
+		t.Errorf("managerOrder(darwin) = %v", got)
+	}
+	if got := cfg.managerOrder("linux"); len(got) != 2 || got[0] != "cargo" {
+		t.Errorf("managerOrder(linux) = %v", got)
+	}
+
+	// Without an entry for the OS, default_manager is the only choice
+	cfg.ManagerPriority = nil
+	if got := cfg.managerOrder("linux"); len(got) != 1 || got[0] != "cargo" {
+		t.Errorf("managerOrder without priority = %v", got)
+	}
+
+	for _, bad := range []string{
+		"manager_priority:\n  windows: [brew]\n",    // unsupported OS
+		"manager_priority:\n  linux: []\n",          // no managers
+		"manager_priority:\n  linux: [brew, apt]\n", // unsupported manager
+	} {
+		if _, err := Load(testutil.NewTestConfig(t, bad)); err == nil {
+			t.Errorf("expected validation error for %q", bad)
+		}
+	}
+}
+
+func TestLoad_DisabledManagers(t *testing.T) {
+	tempDir := testutil.NewTestConfig(t, "disabled_managers: [conda, dotnet]\n")
+	cfg, err := Load(tempDir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(cfg.DisabledManagers) != 2 || cfg.DisabledManagers[1] != "dotnet" {
+		t.Errorf("disabled_managers = %v", cfg.DisabledManagers)
+	}
+
+	if _, err := Load(testutil.NewTestConfig(t, "disabled_managers: [\"\"]\n")); err == nil {
+		t.Error("expected validation error for an empty manager name")
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package config
+
+import (
+	"os"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ContainersConfig changes what plonk does inside a container such as a
+// devcontainer or a GitHub Codespace. Unset settings keep the container
+// defaults: Homebrew is disabled, since installing it needs sudo and casks
+// are macOS apps, and unprefixed packages go to the language managers first.
+type ContainersConfig struct {
+	Detect           *bool    `yaml:"detect,omitempty"`                                                  // false never treats this machine as a container
+	DisabledManagers []string `yaml:"disabled_managers,omitempty" validate:"omitempty,dive,required"`    // default [brew]; [] runs every manager
+	ManagerPriority  []string `yaml:"manager_priority,omitempty" validate:"omitempty,dive,validmanager"` // order for unprefixed packages
+	Resources        []string `yaml:"resources,omitempty"`                                               // replaces resources; [] applies none
+}
+
+// containerDisabledManagers are disabled in containers unless
+// containers.disabled_managers says otherwise
+var containerDisabledManagers = []string{"brew"}
+
+// containerManagerPriority is used in containers for unprefixed packages
+// when neither containers.manager_priority nor manager_priority is set
+var containerManagerPriority = []string{"cargo", "go", "pnpm", "uv", "brew"}
+
+// containerMarkers are files container runtimes create, by container kind
+var containerMarkers = []struct{ path, kind string }{
+	{"/.dockerenv", "docker"},
+	{"/run/.containerenv", "podman"},
+}
+
+// DetectContainer returns the kind of container plonk runs in, such as
+// "codespaces", "devcontainer" or "docker", or "" outside one. PLONK_CONTAINER
+// overrides detection: a false value means none, any other value is the kind.
+func DetectContainer() string {
+	if value, ok := os.LookupEnv("PLONK_CONTAINER"); ok {
+		if on, err := strconv.ParseBool(value); err == nil {
+			if on {
+				return "container"
+			}
+			return ""
+		}
+		return value
+	}
+	if runtime.GOOS != "linux" {
+		return ""
+	}
+	switch {
+	case os.Getenv("CODESPACES") == "true":
+		return "codespaces"
+	case os.Getenv("REMOTE_CONTAINERS") == "true":
+		return "devcontainer"
+	case os.Getenv("KUBERNETES_SERVICE_HOST") != "":
+		return "kubernetes"
+	}
+	for _, marker := range containerMarkers {
+		if _, err := os.Stat(marker.path); err == nil {
+			return marker.kind
+		}
+	}
+	// systemd-nspawn, podman and LXC set $container for init
+	if kind := os.Getenv("container"); kind != "" {
+		return kind
+	}
+	return ""
+}
+
+// detectContainer records the container plonk runs in, unless detection is
+// turned off. The containers settings take effect through the accessors
+// below, so the config itself is never changed and writes back as it was.
+func (c *Config) detectContainer(kind string) {
+	if c.Containers.Detect != nil && !*c.Containers.Detect {
+		kind = ""
+	}
+	c.Container = kind
+}
+
+// ActiveDisabledManagers returns disabled_managers plus, in a container,
+// the managers disabled there
+func (c *Config) ActiveDisabledManagers() []string {
+	if c.Container == "" {
+		return c.DisabledManagers
+	}
+	extra := c.Containers.DisabledManagers
+	if extra == nil {
+		extra = containerDisabledManagers
+	}
+	disabled := slices.Clone(c.DisabledManagers)
+	for _, name := range extra {
+		if !slices.Contains(disabled, name) {
+			disabled = append(disabled, name)
+		}
+	}
+	return disabled
+}
+
+// ActiveResources returns the resources to apply: containers.resources in
+// a container when it is set, resources otherwise
+func (c *Config) ActiveResources() []string {
+	if c.Container != "" && c.Containers.Resources != nil {
+		return c.Containers.Resources
+	}
+	return c.Resources
+}
+
+// containerManagerOrder returns the manager order for unprefixed packages
+// in a container, or nil to use the usual one
+func (c *Config) containerManagerOrder(goos string) []string {
+	if c.Container == "" {
+		return nil
+	}
+	if len(c.Containers.ManagerPriority) > 0 {
+		return c.Containers.ManagerPriority
+	}
+	if len(c.ManagerPriority[goos]) > 0 {
+		return nil
+	}
+	return containerManagerPriority
+}
+
+// ContainerSummary describes the container settings in effect, for doctor
+func (c *Config) ContainerSummary() string {
+	if c.Container == "" {
+		return ""
+	}
+	parts := []string{c.Container}
+	if disabled := c.ActiveDisabledManagers(); len(disabled) > 0 {
+		parts = append(parts, "disabled managers: "+strings.Join(disabled, ", "))
+	}
+	parts = append(parts, "manager order: "+strings.Join(c.ManagerOrder(), ", "))
+	return strings.Join(parts, "; ")
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadInContainer(t *testing.T, yaml string) *Config {
+	t.Helper()
+	t.Setenv("PLONK_CONTAINER", "codespaces")
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "plonk.yaml"), []byte(yaml), 0o644))
+	cfg, err := Load(dir)
+	require.NoError(t, err)
+	return cfg
+}
+
+func TestContainerDefaults(t *testing.T) {
+	cfg := loadInContainer(t, "disabled_managers: [conda]\nresources: [shell_fragments]\n")
+	assert.Equal(t, "codespaces", cfg.Container)
+	assert.Equal(t, []string{"conda", "brew"}, cfg.ActiveDisabledManagers())
+	assert.Equal(t, []string{"conda"}, cfg.DisabledManagers, "the config itself is unchanged")
+	assert.Equal(t, containerManagerPriority, cfg.ManagerOrder())
+	assert.Equal(t, []string{"shell_fragments"}, cfg.ActiveResources())
+	assert.Equal(t, "codespaces; disabled managers: conda, brew; manager order: cargo, go, pnpm, uv, brew", cfg.ContainerSummary())
+}
+
+func TestContainerOverrides(t *testing.T) {
+	cfg := loadInContainer(t, `
+resources: [shell_fragments, gcloud]
+containers:
+  disabled_managers: []
+  manager_priority: [uv, brew]
+  resources: []
+`)
+	assert.Empty(t, cfg.ActiveDisabledManagers())
+	assert.Equal(t, []string{"uv", "brew"}, cfg.ManagerOrder())
+	assert.Empty(t, cfg.ActiveResources())
+}
+
+func TestContainerKeepsManagerPriority(t *testing.T) {
+	cfg := loadInContainer(t, "manager_priority:\n  "+runtime.GOOS+": [go]\n")
+	assert.Equal(t, []string{"go"}, cfg.ManagerOrder())
+}
+
+func TestContainerDetectOff(t *testing.T) {
+	cfg := loadInContainer(t, "containers:\n  detect: false\n")
+	assert.Empty(t, cfg.Container)
+	assert.Empty(t, cfg.ActiveDisabledManagers())
+	assert.Equal(t, []string{"brew"}, cfg.ManagerOrder())
+}
+
+func TestDetectContainer(t *testing.T) {
+	t.Setenv("PLONK_CONTAINER", "false")
+	assert.Empty(t, DetectContainer())
+	t.Setenv("PLONK_CONTAINER", "1")
+	assert.Equal(t, "container", DetectContainer())
+
+	if runtime.GOOS != "linux" {
+		return
+	}
+	os.Unsetenv("PLONK_CONTAINER")
+	t.Setenv("CODESPACES", "true")
+	assert.Equal(t, "codespaces", DetectContainer())
+}
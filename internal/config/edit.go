@@ -0,0 +1,250 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// editConfigFile applies edit to the top-level mapping of plonk.yaml and
+// writes the file back if edit reports a change, keeping the rest of the
+// file and its comments as they are. A missing file starts out empty.
+func editConfigFile(configDir string, edit func(root *yaml.Node) (bool, error)) (bool, error) {
+	configPath := filepath.Join(configDir, "plonk.yaml")
+	data, err := os.ReadFile(configPath)
+	if err != nil && !os.IsNotExist(err) {
+		return false, err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return false, fmt.Errorf("failed to parse %s: %w", configPath, err)
+	}
+	if len(doc.Content) == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return false, fmt.Errorf("%s: top level is not a mapping", configPath)
+	}
+
+	changed, err := edit(root)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", configPath, err)
+	}
+	if !changed {
+		return false, nil
+	}
+
+	updated, err := yaml.Marshal(&doc)
+	if err != nil {
+		return false, err
+	}
+	if err := os.WriteFile(configPath, updated, 0644); err != nil {
+		return false, fmt.Errorf("failed to write config: %w", err)
+	}
+	return true, nil
+}
+
+// mappingValue returns the value node for key in mapping, or nil
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// setMappingValue sets key in mapping to value, adding the key if needed
+func setMappingValue(mapping *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content[i+1] = value
+			return
+		}
+	}
+	mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, value)
+}
+
+// deleteMappingKey removes key from mapping, reporting whether it was there
+func deleteMappingKey(mapping *yaml.Node, key string) bool {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content = append(mapping.Content[:i], mapping.Content[i+2:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// AddIgnorePattern appends pattern to ignore_patterns in plonk.yaml. When
+// the file has no ignore_patterns the defaults are written first, since
+// setting the key replaces them. It reports whether the file changed.
+func AddIgnorePattern(configDir, pattern string) (bool, error) {
+	return editConfigFile(configDir, func(root *yaml.Node) (bool, error) {
+		list := mappingValue(root, "ignore_patterns")
+		if list == nil {
+			list = &yaml.Node{Kind: yaml.SequenceNode}
+			for _, p := range defaultConfig.IgnorePatterns {
+				list.Content = append(list.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: p})
+			}
+			setMappingValue(root, "ignore_patterns", list)
+		}
+		if list.Kind != yaml.SequenceNode {
+			return false, fmt.Errorf("ignore_patterns is not a list")
+		}
+		if slices.ContainsFunc(list.Content, func(n *yaml.Node) bool { return n.Value == pattern }) {
+			return false, nil
+		}
+		list.Content = append(list.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: pattern})
+		return true, nil
+	})
+}
+
+// SetDotfilesLayout records the dotfile layout in plonk.yaml, with the
+// source -> target table for the custom layout. The map is removed for
+// other layouts, and the layout key for the default flat layout.
+func SetDotfilesLayout(configDir, layout string, mapping map[string]string) error {
+	_, err := editConfigFile(configDir, func(root *yaml.Node) (bool, error) {
+		dotfiles := mappingValue(root, "dotfiles")
+		if dotfiles == nil {
+			dotfiles = &yaml.Node{Kind: yaml.MappingNode}
+			setMappingValue(root, "dotfiles", dotfiles)
+		}
+		if dotfiles.Kind != yaml.MappingNode {
+			return false, fmt.Errorf("dotfiles is not a mapping")
+		}
+
+		if layout == "" || layout == "flat" {
+			deleteMappingKey(dotfiles, "layout")
+		} else {
+			setMappingValue(dotfiles, "layout", &yaml.Node{Kind: yaml.ScalarNode, Value: layout})
+		}
+
+		deleteMappingKey(dotfiles, "map")
+		if len(mapping) > 0 {
+			table := &yaml.Node{Kind: yaml.MappingNode}
+			sources := make([]string, 0, len(mapping))
+			for source := range mapping {
+				sources = append(sources, source)
+			}
+			sort.Strings(sources)
+			for _, source := range sources {
+				table.Content = append(table.Content,
+					&yaml.Node{Kind: yaml.ScalarNode, Value: source},
+					&yaml.Node{Kind: yaml.ScalarNode, Value: mapping[source]})
+			}
+			setMappingValue(dotfiles, "map", table)
+		}
+
+		if len(dotfiles.Content) == 0 {
+			deleteMappingKey(root, "dotfiles")
+		}
+		return true, nil
+	})
+	return err
+}
+
+// SetPackageArgs records the install arguments of one package under
+// managers.<manager>.package_args in plonk.yaml, replacing any it had. No
+// args removes the entry. It reports whether the file changed.
+func SetPackageArgs(configDir, manager, name string, args []string) (bool, error) {
+	return editConfigFile(configDir, func(root *yaml.Node) (bool, error) {
+		managers := mappingValue(root, "managers")
+		if managers == nil {
+			if len(args) == 0 {
+				return false, nil
+			}
+			managers = &yaml.Node{Kind: yaml.MappingNode}
+			setMappingValue(root, "managers", managers)
+		}
+		if managers.Kind != yaml.MappingNode {
+			return false, fmt.Errorf("managers is not a mapping")
+		}
+		opts := mappingValue(managers, manager)
+		if opts == nil {
+			if len(args) == 0 {
+				return false, nil
+			}
+			opts = &yaml.Node{Kind: yaml.MappingNode}
+			setMappingValue(managers, manager, opts)
+		}
+		if opts.Kind != yaml.MappingNode {
+			return false, fmt.Errorf("managers.%s is not a mapping", manager)
+		}
+		table := mappingValue(opts, "package_args")
+		if table == nil {
+			if len(args) == 0 {
+				return false, nil
+			}
+			table = &yaml.Node{Kind: yaml.MappingNode}
+			setMappingValue(opts, "package_args", table)
+		}
+		if table.Kind != yaml.MappingNode {
+			return false, fmt.Errorf("managers.%s.package_args is not a mapping", manager)
+		}
+
+		if len(args) == 0 {
+			if !deleteMappingKey(table, name) {
+				return false, nil
+			}
+			if len(table.Content) == 0 {
+				deleteMappingKey(opts, "package_args")
+			}
+			if len(opts.Content) == 0 {
+				deleteMappingKey(managers, manager)
+			}
+			if len(managers.Content) == 0 {
+				deleteMappingKey(root, "managers")
+			}
+			return true, nil
+		}
+
+		if current := mappingValue(table, name); current != nil && current.Kind == yaml.SequenceNode {
+			values := make([]string, len(current.Content))
+			for i, n := range current.Content {
+				values[i] = n.Value
+			}
+			if slices.Equal(values, args) {
+				return false, nil
+			}
+		}
+		list := &yaml.Node{Kind: yaml.SequenceNode, Style: yaml.FlowStyle}
+		for _, arg := range args {
+			list.Content = append(list.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: arg})
+		}
+		setMappingValue(table, name, list)
+		return true, nil
+	})
+}
+
+// LoadDotfiles reads only the dotfiles settings of configDir's plonk.yaml
+// and its includes, for code that needs the dotfile layout without a fully
+// validated config. A missing file gives the defaults.
+func LoadDotfiles(configDir string) (Dotfiles, error) {
+	configPath := filepath.Join(configDir, "plonk.yaml")
+	cfg := Config{Dotfiles: defaultConfig.Dotfiles}
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cfg.Dotfiles, nil
+		}
+		return cfg.Dotfiles, err
+	}
+	if err := decodeLayered(&cfg, configPath, data, nil); err != nil {
+		return defaultConfig.Dotfiles, err
+	}
+	switch cfg.Dotfiles.Layout {
+	case "", "flat", "mirrored-home", "custom":
+		return cfg.Dotfiles, nil
+	}
+	return defaultConfig.Dotfiles, fmt.Errorf("%s: unknown dotfiles layout %q", configPath, cfg.Dotfiles.Layout)
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddIgnorePattern_AppendsAndKeepsComments(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "plonk.yaml")
+	writeConfigFile(t, path, "# my settings\ndefault_manager: cargo\nignore_patterns:\n  - .DS_Store # mac\n")
+
+	changed, err := AddIgnorePattern(dir, "/vimrc")
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "# my settings")
+	assert.Contains(t, string(data), "# mac")
+
+	cfg, err := Load(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{".DS_Store", "/vimrc"}, cfg.IgnorePatterns)
+	assert.Equal(t, "cargo", cfg.DefaultManager)
+
+	changed, err = AddIgnorePattern(dir, "/vimrc")
+	require.NoError(t, err)
+	assert.False(t, changed, "patterns are not added twice")
+}
+
+func TestAddIgnorePattern_KeepsDefaults(t *testing.T) {
+	dir := t.TempDir()
+
+	changed, err := AddIgnorePattern(dir, "/zshrc")
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	cfg, err := Load(dir)
+	require.NoError(t, err)
+	assert.Equal(t, append(slices.Clone(defaultConfig.IgnorePatterns), "/zshrc"), cfg.IgnorePatterns)
+}
+
+func TestSetDotfilesLayout(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "plonk.yaml")
+	writeConfigFile(t, path, "default_manager: brew # keep\n")
+
+	require.NoError(t, SetDotfilesLayout(dir, "custom", map[string]string{"zsh/zshrc": "~/.zshrc", "vimrc": "~/.vimrc"}))
+	cfg, err := Load(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "custom", cfg.Dotfiles.Layout)
+	assert.Equal(t, map[string]string{"zsh/zshrc": "~/.zshrc", "vimrc": "~/.vimrc"}, cfg.Dotfiles.Map)
+	assert.NotEmpty(t, cfg.Dotfiles.UnmanagedFilters, "defaults for other dotfiles settings are kept")
+
+	require.NoError(t, SetDotfilesLayout(dir, "flat", nil))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "default_manager: brew # keep\n", string(data), "the flat default leaves no trace")
+}
+
+func TestLoad_RejectsUnknownDotfilesLayout(t *testing.T) {
+	dir := t.TempDir()
+	writeConfigFile(t, filepath.Join(dir, "plonk.yaml"), "dotfiles:\n  layout: nested\n")
+
+	_, err := Load(dir)
+	assert.Error(t, err)
+}
+
+func TestSetPackageArgs(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "plonk.yaml")
+	writeConfigFile(t, path, "default_manager: brew # keep\nmanagers:\n  brew:\n    no_auto_update: true\n")
+
+	changed, err := SetPackageArgs(dir, "brew", "ffmpeg", []string{"--with-libvpx"})
+	require.NoError(t, err)
+	assert.True(t, changed)
+	changed, err = SetPackageArgs(dir, "cargo", "ripgrep", []string{"--features", "pcre2"})
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	cfg, err := Load(dir)
+	require.NoError(t, err)
+	assert.True(t, cfg.Managers.Brew.NoAutoUpdate)
+	assert.Equal(t, []string{"--with-libvpx"}, cfg.Managers.Brew.PackageArgs["ffmpeg"])
+	assert.Equal(t, []string{"--features", "pcre2"}, cfg.Managers.Cargo.PackageArgs["ripgrep"])
+
+	changed, err = SetPackageArgs(dir, "brew", "ffmpeg", []string{"--with-libvpx"})
+	require.NoError(t, err)
+	assert.False(t, changed, "the same args leave the file alone")
+
+	changed, err = SetPackageArgs(dir, "cargo", "ripgrep", nil)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "cargo", "empty sections are removed")
+	assert.Contains(t, string(data), "# keep")
+}
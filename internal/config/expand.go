@@ -0,0 +1,56 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+// expandValue expands a leading ~ to $HOME and $VAR or ${VAR} references
+// anywhere in value. Unlike os.ExpandEnv, referring to an unset variable is
+// an error rather than an empty string, so a config copied to a machine
+// without it fails loudly instead of pointing somewhere unexpected.
+func expandValue(value string) (string, error) {
+	if value == "~" || strings.HasPrefix(value, "~/") {
+		value = "$HOME" + value[1:]
+	}
+
+	var missing []string
+	expanded := os.Expand(value, func(name string) string {
+		v, ok := os.LookupEnv(name)
+		if !ok && !slices.Contains(missing, "$"+name) {
+			missing = append(missing, "$"+name)
+		}
+		return v
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("undefined variable %s", strings.Join(missing, ", "))
+	}
+	return expanded, nil
+}
+
+// expandConfigPaths expands ~ and environment variables in the settings that
+// name files, commands or locations, so one config works across machines
+// with different usernames and layouts
+func expandConfigPaths(cfg *Config) error {
+	fields := []struct {
+		name  string
+		value *string
+	}{
+		{"diff_tool", &cfg.DiffTool},
+		{"sync.url", &cfg.Sync.URL},
+		{"sync.endpoint", &cfg.Sync.Endpoint},
+	}
+	for _, field := range fields {
+		expanded, err := expandValue(*field.value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", field.name, err)
+		}
+		*field.value = expanded
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandValue(t *testing.T) {
+	t.Setenv("HOME", "/home/alice")
+	t.Setenv("XDG_CONFIG_HOME", "/home/alice/.config")
+	t.Setenv("PLONK_TEST_UNSET", "")
+
+	tests := []struct {
+		in, want string
+	}{
+		{"~", "/home/alice"},
+		{"~/bin/difftool", "/home/alice/bin/difftool"},
+		{"${XDG_CONFIG_HOME}/plonk/base.yaml", "/home/alice/.config/plonk/base.yaml"},
+		{"$HOME/x --flag", "/home/alice/x --flag"},
+		{"a~/b", "a~/b"}, // only a leading ~ is expanded
+		{"~bob/x", "~bob/x"},
+		{"$PLONK_TEST_UNSET", ""}, // set but empty is fine
+		{"", ""},
+	}
+	for _, tt := range tests {
+		got, err := expandValue(tt.in)
+		require.NoError(t, err, tt.in)
+		assert.Equal(t, tt.want, got, tt.in)
+	}
+
+	_, err := expandValue("$PLONK_NOT_DEFINED/${PLONK_NOT_DEFINED}/$PLONK_ALSO_MISSING")
+	require.Error(t, err)
+	assert.Equal(t, "undefined variable $PLONK_NOT_DEFINED, $PLONK_ALSO_MISSING", err.Error())
+}
+
+func TestLoad_ExpandsPaths(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv("PLONK_TEST_TEAM", "team")
+	writeConfigFile(t, filepath.Join(dir, "shared", "team.yaml"), "operation_timeout: 42\n")
+	writeConfigFile(t, filepath.Join(dir, "plonk.yaml"), `
+include: ["~/shared/${PLONK_TEST_TEAM}.yaml"]
+diff_tool: ~/bin/difftool --color
+`)
+
+	cfg, err := Load(dir)
+	require.NoError(t, err)
+	assert.Equal(t, 42, cfg.OperationTimeout)
+	assert.Equal(t, filepath.Join(dir, "bin/difftool")+" --color", cfg.DiffTool)
+
+	raw, err := LoadUnexpanded(filepath.Join(dir, "plonk.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "~/bin/difftool --color", raw.DiffTool, "unexpanded for writing back")
+}
+
+func TestLoad_UndefinedVariable(t *testing.T) {
+	dir := t.TempDir()
+	writeConfigFile(t, filepath.Join(dir, "plonk.yaml"), "diff_tool: $PLONK_NOT_DEFINED/difftool\n")
+
+	_, err := Load(dir)
+	require.Error(t, err)
+	assert.Equal(t, "diff_tool: undefined variable $PLONK_NOT_DEFINED", err.Error())
+
+	writeConfigFile(t, filepath.Join(dir, "plonk.yaml"), "include: [$PLONK_NOT_DEFINED/base.yaml]\n")
+	_, err = Load(dir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "undefined variable $PLONK_NOT_DEFINED")
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// maxIncludeDepth bounds how deeply included files may include others
+const maxIncludeDepth = 8
+
+// decodeLayered decodes a config file over cfg. Files named in its include:
+// list are decoded first, in order and relative to the including file after
+// expanding ~ and $VARS, then the file itself, so each layer overrides the
+// ones before it: scalars and lists are replaced, maps are merged key by key.
+// stack holds the files currently being decoded, to report include cycles.
+func decodeLayered(cfg *Config, path string, data []byte, stack []string) error {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	if slices.Contains(stack, abs) {
+		return fmt.Errorf("include cycle: %s", strings.Join(append(stack, abs), " -> "))
+	}
+	if len(stack) > maxIncludeDepth {
+		return fmt.Errorf("includes nested more than %d deep", maxIncludeDepth)
+	}
+	stack = append(stack, abs)
+
+	// Read deprecated keys as their current equivalents
+	if data, _, err = migrateConfigData(data); err != nil {
+		return err
+	}
+
+	var header struct {
+		Include []string `yaml:"include"`
+	}
+	if err := yaml.Unmarshal(data, &header); err != nil {
+		return err
+	}
+	for _, include := range header.Include {
+		includePath, err := expandValue(include)
+		if err != nil {
+			return fmt.Errorf("include %s: %w", include, err)
+		}
+		if !filepath.IsAbs(includePath) {
+			includePath = filepath.Join(filepath.Dir(path), includePath)
+		}
+		includeData, err := os.ReadFile(includePath)
+		if err == nil {
+			err = decodeLayered(cfg, includePath, includeData, stack)
+		}
+		if err != nil {
+			return fmt.Errorf("include %s: %w", include, err)
+		}
+	}
+
+	return yaml.Unmarshal(data, cfg)
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfigFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+func TestLoad_IncludeLayering(t *testing.T) {
+	dir := t.TempDir()
+	writeConfigFile(t, filepath.Join(dir, "team", "base.yaml"), `
+default_manager: cargo
+operation_timeout: 120
+ignore_patterns: ["*.team"]
+aliases:
+  rg: [brew:ripgrep]
+  fd: [brew:fd]
+`)
+	writeConfigFile(t, filepath.Join(dir, "plonk.yaml"), `
+include: [team/base.yaml]
+operation_timeout: 60
+aliases:
+  rg: [cargo:ripgrep]
+`)
+
+	cfg, err := Load(dir)
+	require.NoError(t, err)
+
+	assert.Equal(t, "cargo", cfg.DefaultManager, "inherited from the include")
+	assert.Equal(t, 60, cfg.OperationTimeout, "overridden by plonk.yaml")
+	assert.Equal(t, []string{"*.team"}, cfg.IgnorePatterns, "lists replace the defaults")
+	assert.Equal(t, []string{"cargo:ripgrep"}, cfg.Aliases["rg"], "map keys are overridden")
+	assert.Equal(t, []string{"brew:fd"}, cfg.Aliases["fd"], "other map keys are kept")
+	assert.Equal(t, []string{"team/base.yaml"}, cfg.Include)
+}
+
+func TestLoad_IncludeNestedOrder(t *testing.T) {
+	dir := t.TempDir()
+	writeConfigFile(t, filepath.Join(dir, "a.yaml"), "operation_timeout: 10\ndotfile_timeout: 10\n")
+	writeConfigFile(t, filepath.Join(dir, "b.yaml"), "include: [a.yaml]\ndotfile_timeout: 20\n")
+	writeConfigFile(t, filepath.Join(dir, "c.yaml"), "operation_timeout: 30\n")
+	writeConfigFile(t, filepath.Join(dir, "plonk.yaml"), "include: [b.yaml, c.yaml]\n")
+
+	cfg, err := Load(dir)
+	require.NoError(t, err)
+	assert.Equal(t, 30, cfg.OperationTimeout, "later includes win")
+	assert.Equal(t, 20, cfg.DotfileTimeout, "an including file overrides its includes")
+}
+
+func TestLoad_IncludeErrors(t *testing.T) {
+	t.Run("missing file", func(t *testing.T) {
+		dir := t.TempDir()
+		writeConfigFile(t, filepath.Join(dir, "plonk.yaml"), "include: [nope.yaml]\n")
+		_, err := Load(dir)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "include nope.yaml")
+		assert.False(t, os.IsNotExist(err), "a missing include is not a missing config")
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		dir := t.TempDir()
+		writeConfigFile(t, filepath.Join(dir, "a.yaml"), "include: [plonk.yaml]\n")
+		writeConfigFile(t, filepath.Join(dir, "plonk.yaml"), "include: [a.yaml]\n")
+		_, err := Load(dir)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "include cycle")
+	})
+
+	t.Run("invalid included value", func(t *testing.T) {
+		dir := t.TempDir()
+		writeConfigFile(t, filepath.Join(dir, "base.yaml"), "operation_timeout: 99999\n")
+		writeConfigFile(t, filepath.Join(dir, "plonk.yaml"), "include: [base.yaml]\n")
+		_, err := Load(dir)
+		require.Error(t, err, "the merged config is validated")
+	})
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// configMigration rewrites a deprecated part of plonk.yaml into its current
+// form. apply edits the document's top-level mapping in place and reports
+// whether it changed anything.
+type configMigration struct {
+	description string
+	apply       func(root *yaml.Node) bool
+}
+
+// configMigrations run, in order, whenever a config file is read, so old
+// keys keep working; 'plonk migrate' persists the result. Add an entry here
+// (e.g. with renameKey) when a setting is renamed or moved.
+var configMigrations []configMigration
+
+// renameKey returns a migration that moves a top-level key to a new name.
+// If both are present the new key wins and the old one is dropped.
+func renameKey(from, to string) configMigration {
+	return configMigration{
+		description: fmt.Sprintf("renamed %s to %s", from, to),
+		apply: func(root *yaml.Node) bool {
+			oldIdx, newIdx := -1, -1
+			for i := 0; i+1 < len(root.Content); i += 2 {
+				switch root.Content[i].Value {
+				case from:
+					oldIdx = i
+				case to:
+					newIdx = i
+				}
+			}
+			if oldIdx < 0 {
+				return false
+			}
+			if newIdx >= 0 {
+				root.Content = append(root.Content[:oldIdx], root.Content[oldIdx+2:]...)
+			} else {
+				root.Content[oldIdx].Value = to
+			}
+			return true
+		},
+	}
+}
+
+// migrateConfigData applies configMigrations to raw YAML, returning the
+// rewritten data and a description of each migration that changed it. Data
+// that needs no migration is returned unchanged.
+func migrateConfigData(data []byte) ([]byte, []string, error) {
+	if len(configMigrations) == 0 {
+		return data, nil, nil
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, nil, err
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return data, nil, nil
+	}
+
+	var applied []string
+	for _, m := range configMigrations {
+		if m.apply(doc.Content[0]) {
+			applied = append(applied, m.description)
+		}
+	}
+	if len(applied) == 0 {
+		return data, nil, nil
+	}
+
+	migrated, err := yaml.Marshal(&doc)
+	if err != nil {
+		return nil, nil, err
+	}
+	return migrated, applied, nil
+}
+
+// MigrateFile rewrites a config file with all migrations applied, keeping
+// the original in the backup directory next to it. It returns the
+// migrations applied and the backup path; both are empty when the file was
+// already current or does not exist. Included files are left alone.
+func MigrateFile(configPath string) (applied []string, backup string, err error) {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, "", nil
+		}
+		return nil, "", err
+	}
+
+	migrated, applied, err := migrateConfigData(data)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to parse %s: %w", configPath, err)
+	}
+	if len(applied) == 0 {
+		return nil, "", nil
+	}
+
+	backupDir := GetBackupDirectory(filepath.Dir(configPath))
+	backup = filepath.Join(backupDir, fmt.Sprintf("%s.%s.bak", filepath.Base(configPath), time.Now().Format("20060102-150405")))
+	if err := os.MkdirAll(backupDir, 0755); err != nil {
+		return nil, "", fmt.Errorf("failed to create backup directory: %w", err)
+	}
+	if err := os.WriteFile(backup, data, 0644); err != nil {
+		return nil, "", fmt.Errorf("failed to back up %s: %w", configPath, err)
+	}
+
+	tmpPath := configPath + ".tmp"
+	if err := os.WriteFile(tmpPath, migrated, 0644); err != nil {
+		return nil, "", err
+	}
+	if err := os.Rename(tmpPath, configPath); err != nil {
+		os.Remove(tmpPath)
+		return nil, "", err
+	}
+	return applied, backup, nil
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withConfigMigrations swaps in a migration list for the duration of a test
+func withConfigMigrations(t *testing.T, migrations ...configMigration) {
+	t.Helper()
+	saved := configMigrations
+	configMigrations = migrations
+	t.Cleanup(func() { configMigrations = saved })
+}
+
+func TestLoad_AppliesConfigMigrations(t *testing.T) {
+	withConfigMigrations(t, renameKey("op_timeout", "operation_timeout"))
+	dir := t.TempDir()
+	writeConfigFile(t, filepath.Join(dir, "plonk.yaml"), "op_timeout: 42\n")
+
+	cfg, err := Load(dir)
+	require.NoError(t, err)
+	assert.Equal(t, 42, cfg.OperationTimeout)
+}
+
+func TestRenameKey_NewKeyWins(t *testing.T) {
+	withConfigMigrations(t, renameKey("op_timeout", "operation_timeout"))
+
+	migrated, applied, err := migrateConfigData([]byte("op_timeout: 1\noperation_timeout: 2\n"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"renamed op_timeout to operation_timeout"}, applied)
+	assert.Equal(t, "operation_timeout: 2\n", string(migrated))
+}
+
+func TestMigrateFile(t *testing.T) {
+	withConfigMigrations(t, renameKey("op_timeout", "operation_timeout"))
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "plonk.yaml")
+	original := "# my settings\nop_timeout: 42 # seconds\n"
+	writeConfigFile(t, configPath, original)
+
+	applied, backup, err := MigrateFile(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"renamed op_timeout to operation_timeout"}, applied)
+	assert.Equal(t, filepath.Join(dir, ".backups"), filepath.Dir(backup))
+
+	saved, err := os.ReadFile(backup)
+	require.NoError(t, err)
+	assert.Equal(t, original, string(saved))
+
+	data, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, "# my settings\noperation_timeout: 42 # seconds\n", string(data), "comments survive")
+
+	// A second run has nothing to do
+	applied, backup, err = MigrateFile(configPath)
+	require.NoError(t, err)
+	assert.Empty(t, applied)
+	assert.Empty(t, backup)
+}
+
+func TestMigrateFile_NoMigrations(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "plonk.yaml")
+	writeConfigFile(t, configPath, "operation_timeout: 42\n")
+
+	applied, backup, err := MigrateFile(configPath)
+	require.NoError(t, err)
+	assert.Empty(t, applied)
+	assert.Empty(t, backup)
+	assert.NoDirExists(t, filepath.Join(dir, ".backups"))
+}
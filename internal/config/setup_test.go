@@ -25,6 +25,9 @@ func TestMain(m *testing.M) {
 		return false
 	}
 
+	// Load must not pick up container settings when tests run in one
+	os.Setenv("PLONK_CONTAINER", "false")
+
 	// Run all tests
 	code := m.Run()
 
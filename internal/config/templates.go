@@ -0,0 +1,178 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+	"text/template"
+)
+
+// Config templates offered by `plonk init`
+const (
+	TemplateMinimal  = "minimal"
+	TemplateFull     = "full"
+	TemplateMacOSDev = "macos-dev"
+)
+
+// TemplateNames lists the available templates in display order
+var TemplateNames = []string{TemplateMinimal, TemplateFull, TemplateMacOSDev}
+
+// TemplateDescriptions explains each template in one line
+var TemplateDescriptions = map[string]string{
+	TemplateMinimal:  "default manager and git settings only; everything else uses defaults",
+	TemplateFull:     "every setting written out with its default, ready to edit",
+	TemplateMacOSDev: "Homebrew-first macOS setup with extra ignore patterns for macOS clutter",
+}
+
+// TemplateOptions are the choices filled into a template
+type TemplateOptions struct {
+	DefaultManager string
+	AutoCommit     bool
+}
+
+// macOSIgnorePatterns are added to the defaults by the macos-dev template
+var macOSIgnorePatterns = []string{".localized", ".AppleDouble", "._*", "*.icloud"}
+
+const minimalTemplate = `# plonk configuration - see https://github.com/richhaase/plonk/blob/main/docs/reference.md
+default_manager: {{.DefaultManager}}
+
+git:
+  auto_commit: {{.AutoCommit}}
+`
+
+const fullTemplate = `# plonk configuration - see https://github.com/richhaase/plonk/blob/main/docs/reference.md
+# Values shown are the defaults; delete any line to fall back to it.
+
+# Manager used when a package is given without a manager: prefix
+default_manager: {{.DefaultManager}}
+
+git:
+  auto_commit: {{.AutoCommit}}      # Commit $PLONK_DIR after every change
+
+# Timeouts in seconds
+operation_timeout: {{.OperationTimeout}}
+dotfile_timeout: {{.DotfileTimeout}}
+
+# Diff tool for 'plonk diff' (default: git diff --no-index)
+# diff_tool: delta
+
+# Directories in $HOME that 'plonk add' expands into individual files
+expand_directories:
+{{- range .ExpandDirectories}}
+  - {{quote .}}
+{{- end}}
+
+# Paths never treated as dotfiles. This list replaces the defaults.
+ignore_patterns:
+{{- range .IgnorePatterns}}
+  - {{quote .}}
+{{- end}}
+
+# Desktop notifications from 'plonk serve'
+# notifications:
+#   enabled: true
+#   events: [apply, failure, drift]
+
+# Per-manager install options
+# managers:
+#   brew:
+#     no_auto_update: true
+#   pnpm:
+#     registry: https://npm.example.com
+
+# Managers tried in order for bare package names ('plonk track jq')
+# manager_priority:
+#   darwin: [brew, cargo]
+#   linux: [cargo, brew]
+
+# Shared settings layered under this file (see docs/reference.md)
+# include:
+#   - team/base.yaml
+
+# Managers plonk should never run
+# disabled_managers: [conda]
+
+# Equivalent packages across managers ('plonk track rg')
+# aliases:
+#   rg: [brew:ripgrep, cargo:ripgrep]
+
+# Sync without git (see 'plonk sync --help')
+# sync:
+#   backend: s3
+#   url: s3://my-bucket/plonk-state.json
+`
+
+const macOSDevTemplate = `# plonk configuration - see https://github.com/richhaase/plonk/blob/main/docs/reference.md
+# macOS developer setup: Homebrew first, macOS metadata files ignored.
+default_manager: {{.DefaultManager}}
+
+git:
+  auto_commit: {{.AutoCommit}}
+
+expand_directories:
+{{- range .ExpandDirectories}}
+  - {{quote .}}
+{{- end}}
+
+# Defaults plus macOS metadata. This list replaces the defaults.
+ignore_patterns:
+{{- range .IgnorePatterns}}
+  - {{quote .}}
+{{- end}}
+
+# Suggested next steps:
+#   plonk track brew:git brew:gh brew:ripgrep brew:fd brew:jq
+#   plonk add ~/.zshrc ~/.gitconfig ~/.config/nvim
+`
+
+// RenderTemplate returns plonk.yaml content for the named template
+func RenderTemplate(name string, opts TemplateOptions) ([]byte, error) {
+	var text string
+	ignore := slices.Clone(defaultConfig.IgnorePatterns)
+	switch name {
+	case TemplateMinimal:
+		text = minimalTemplate
+	case TemplateFull:
+		text = fullTemplate
+	case TemplateMacOSDev:
+		text = macOSDevTemplate
+		ignore = append(ignore, macOSIgnorePatterns...)
+	default:
+		return nil, fmt.Errorf("unknown template %q (available: %s)", name, strings.Join(TemplateNames, ", "))
+	}
+
+	if opts.DefaultManager == "" {
+		opts.DefaultManager = defaultConfig.DefaultManager
+	}
+
+	tmpl, err := template.New(name).Funcs(template.FuncMap{
+		"quote": func(s string) string { return fmt.Sprintf("%q", s) },
+	}).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+
+	data := struct {
+		TemplateOptions
+		OperationTimeout  int
+		DotfileTimeout    int
+		ExpandDirectories []string
+		IgnorePatterns    []string
+	}{
+		TemplateOptions:   opts,
+		OperationTimeout:  defaultConfig.OperationTimeout,
+		DotfileTimeout:    defaultConfig.DotfileTimeout,
+		ExpandDirectories: defaultConfig.ExpandDirectories,
+		IgnorePatterns:    ignore,
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
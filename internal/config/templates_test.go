@@ -0,0 +1,63 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestRenderTemplate_AllValid(t *testing.T) {
+	for _, name := range TemplateNames {
+		t.Run(name, func(t *testing.T) {
+			content, err := RenderTemplate(name, TemplateOptions{DefaultManager: "cargo", AutoCommit: false})
+			if err != nil {
+				t.Fatalf("render failed: %v", err)
+			}
+			if result := NewSimpleValidator().ValidateConfigFromYAML(content); !result.Valid {
+				t.Fatalf("template is invalid: %v\n%s", result.Errors, content)
+			}
+
+			var cfg Config
+			if err := yaml.Unmarshal(content, &cfg); err != nil {
+				t.Fatalf("unmarshal failed: %v", err)
+			}
+			if cfg.DefaultManager != "cargo" {
+				t.Errorf("default_manager = %q, want cargo", cfg.DefaultManager)
+			}
+			if cfg.AutoCommitEnabled() {
+				t.Error("expected auto_commit: false")
+			}
+			if _, ok := TemplateDescriptions[name]; !ok {
+				t.Error("missing template description")
+			}
+		})
+	}
+}
+
+func TestRenderTemplate_IgnorePatterns(t *testing.T) {
+	content, err := RenderTemplate(TemplateMacOSDev, TemplateOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cfg Config
+	if err := yaml.Unmarshal(content, &cfg); err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.IgnorePatterns) != len(defaultConfig.IgnorePatterns)+len(macOSIgnorePatterns) {
+		t.Errorf("expected defaults plus macOS patterns, got %d patterns", len(cfg.IgnorePatterns))
+	}
+	if cfg.DefaultManager != "brew" {
+		t.Errorf("expected brew when no manager is given, got %q", cfg.DefaultManager)
+	}
+}
+
+func TestRenderTemplate_Unknown(t *testing.T) {
+	_, err := RenderTemplate("huge", TemplateOptions{})
+	if err == nil || !strings.Contains(err.Error(), "minimal") {
+		t.Errorf("expected error listing templates, got %v", err)
+	}
+}
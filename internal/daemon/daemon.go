@@ -0,0 +1,239 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+// Package daemon serves plonk's status and apply operations over a local
+// unix socket, so editors, menubar apps and agents can query a long-lived
+// process instead of spawning plonk and re-listing every manager each time.
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/richhaase/plonk/internal/packages"
+	"github.com/richhaase/plonk/pkg/plonk"
+)
+
+// Event types published on /v1/events
+const (
+	EventApplyStarted  = "apply.started"
+	EventApplyFinished = "apply.finished"
+	EventStatus        = "status"
+)
+
+// Event is one line of the /v1/events NDJSON stream
+type Event struct {
+	Time  time.Time `json:"time"`
+	Type  string    `json:"type"`
+	Data  any       `json:"data,omitempty"`
+	Error string    `json:"error,omitempty"`
+}
+
+// Server answers API requests using a single plonk client. Manager instances
+// (and their installed-package caches) live as long as the server.
+type Server struct {
+	client *plonk.Client
+	ctx    context.Context // outlives requests; cancelled when Serve stops
+
+	applyMu sync.Mutex // one apply at a time, and no manager cache reset during one
+
+	subMu       sync.Mutex
+	subscribers map[chan Event]struct{}
+}
+
+// New creates a server for client
+func New(client *plonk.Client) *Server {
+	return &Server{
+		client:      client,
+		ctx:         context.Background(),
+		subscribers: make(map[chan Event]struct{}),
+	}
+}
+
+// DefaultSocketPath returns $XDG_RUNTIME_DIR/plonk.sock, falling back to a
+// per-user socket in the temp directory
+func DefaultSocketPath() string {
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		return filepath.Join(dir, "plonk.sock")
+	}
+	return filepath.Join(os.TempDir(), fmt.Sprintf("plonk-%d.sock", os.Getuid()))
+}
+
+// Handler returns the HTTP API
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/status", s.handleStatus)
+	mux.HandleFunc("POST /v1/apply", s.handleApply)
+	mux.HandleFunc("GET /v1/events", s.handleEvents)
+	return mux
+}
+
+// Serve listens on socketPath until ctx is cancelled. A stale socket file
+// from a previous run is replaced; a live one is an error.
+func (s *Server) Serve(ctx context.Context, socketPath string) error {
+	if conn, err := net.Dial("unix", socketPath); err == nil {
+		conn.Close()
+		return fmt.Errorf("another plonk server is already listening on %s", socketPath)
+	}
+	if err := os.Remove(socketPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove stale socket %s: %w", socketPath, err)
+	}
+
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", socketPath, err)
+	}
+	defer os.Remove(socketPath)
+	s.ctx = ctx
+
+	// The API can install packages: restrict it to the current user
+	if err := os.Chmod(socketPath, 0o600); err != nil {
+		listener.Close()
+		return fmt.Errorf("failed to restrict socket permissions: %w", err)
+	}
+
+	srv := &http.Server{Handler: s.Handler(), ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
+	if err := srv.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// handleStatus reports the reconciled state. ?refresh=true drops cached
+// manager state so packages installed outside plonk are seen; it waits for
+// a running apply, whose managers it would otherwise pull out from under it.
+func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
+	if refresh, _ := strconv.ParseBool(r.URL.Query().Get("refresh")); refresh {
+		s.applyMu.Lock()
+		packages.ResetManagerCache()
+		s.applyMu.Unlock()
+	}
+
+	status, err := s.client.Status(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	s.Publish(Event{Type: EventStatus, Data: status})
+	writeJSON(w, http.StatusOK, status)
+}
+
+// handleApply runs an apply. Query parameters: dry_run, packages, dotfiles.
+// The apply runs on the server's context, so a client that disconnects
+// doesn't interrupt an install halfway.
+func (s *Server) handleApply(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	opts := plonk.ApplyOptions{}
+	opts.DryRun, _ = strconv.ParseBool(q.Get("dry_run"))
+	opts.PackagesOnly, _ = strconv.ParseBool(q.Get("packages"))
+	opts.DotfilesOnly, _ = strconv.ParseBool(q.Get("dotfiles"))
+
+	if !s.applyMu.TryLock() {
+		writeError(w, http.StatusConflict, errors.New("an apply is already running"))
+		return
+	}
+	defer s.applyMu.Unlock()
+
+	s.Publish(Event{Type: EventApplyStarted, Data: opts})
+	result, err := s.client.Apply(s.ctx, opts)
+
+	finished := Event{Type: EventApplyFinished, Data: result}
+	if err != nil {
+		finished.Error = err.Error()
+		result.Error = err.Error()
+	}
+	s.Publish(finished)
+
+	// Partial failures still return the full result
+	writeJSON(w, http.StatusOK, result)
+}
+
+// handleEvents streams events as newline-delimited JSON until the client disconnects
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, errors.New("streaming unsupported"))
+		return
+	}
+
+	events := s.Subscribe()
+	defer s.Unsubscribe(events)
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	enc := json.NewEncoder(w)
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case ev := <-events:
+			if err := enc.Encode(ev); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// Subscribe returns a channel receiving future events
+func (s *Server) Subscribe() chan Event {
+	ch := make(chan Event, 16)
+	s.subMu.Lock()
+	s.subscribers[ch] = struct{}{}
+	s.subMu.Unlock()
+	return ch
+}
+
+// Unsubscribe stops delivery to ch
+func (s *Server) Unsubscribe(ch chan Event) {
+	s.subMu.Lock()
+	delete(s.subscribers, ch)
+	s.subMu.Unlock()
+}
+
+// Publish delivers ev to all subscribers. Slow subscribers miss events
+// rather than blocking the server.
+func (s *Server) Publish(ev Event) {
+	if ev.Time.IsZero() {
+		ev.Time = time.Now()
+	}
+	s.subMu.Lock()
+	defer s.subMu.Unlock()
+	for ch := range s.subscribers {
+		select {
+		case ch <- ev:
+		default:
+		}
+	}
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, code int, err error) {
+	writeJSON(w, code, map[string]string{
+		"error":       err.Error(),
+		"error_class": packages.ErrorClass(err),
+	})
+}
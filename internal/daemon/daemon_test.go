@@ -0,0 +1,147 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/richhaase/plonk/pkg/plonk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer(t *testing.T) (*Server, string) {
+	t.Helper()
+	configDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "plonk.yaml"), []byte("git:\n  auto_commit: false\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "vimrc"), []byte("set nu\n"), 0o644))
+
+	client, err := plonk.New(plonk.WithConfigDir(configDir), plonk.WithHomeDir(t.TempDir()))
+	require.NoError(t, err)
+	return New(client), configDir
+}
+
+func TestHandleStatus(t *testing.T) {
+	srv, _ := newTestServer(t)
+
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/status", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var status plonk.Status
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &status))
+	require.Len(t, status.Dotfiles, 1)
+	assert.Equal(t, "missing", status.Dotfiles[0].State)
+}
+
+func TestHandleApply_PublishesEvents(t *testing.T) {
+	srv, _ := newTestServer(t)
+	events := srv.Subscribe()
+	defer srv.Unsubscribe(events)
+
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/apply?dry_run=true&dotfiles=true", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var result plonk.ApplyResult
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+	assert.True(t, result.DryRun)
+	require.NotNil(t, result.Dotfiles)
+	assert.Equal(t, 1, result.Dotfiles.Summary.Added)
+
+	assert.Equal(t, EventApplyStarted, (<-events).Type)
+	assert.Equal(t, EventApplyFinished, (<-events).Type)
+}
+
+func TestHandleApply_OutlivesRequest(t *testing.T) {
+	srv, _ := newTestServer(t)
+
+	// The client has already gone away
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/apply?dry_run=true&dotfiles=true", nil).WithContext(ctx))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var result plonk.ApplyResult
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+	assert.Empty(t, result.Error)
+	require.NotNil(t, result.Dotfiles)
+	assert.Equal(t, 1, result.Dotfiles.Summary.Added)
+}
+
+func TestHandleStatus_RefreshWaitsForApply(t *testing.T) {
+	srv, _ := newTestServer(t)
+
+	srv.applyMu.Lock() // an apply is running
+	done := make(chan int)
+	go func() {
+		rec := httptest.NewRecorder()
+		srv.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/status?refresh=true", nil))
+		done <- rec.Code
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("refresh reset the manager cache during an apply")
+	case <-time.After(50 * time.Millisecond):
+	}
+	srv.applyMu.Unlock()
+	assert.Equal(t, http.StatusOK, <-done)
+}
+
+func TestHandleApply_WrongMethod(t *testing.T) {
+	srv, _ := newTestServer(t)
+
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/apply", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestServe_UnixSocket(t *testing.T) {
+	srv, _ := newTestServer(t)
+
+	// Unix socket paths are length-limited; keep this one short
+	dir, err := os.MkdirTemp("", "plonk")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	socketPath := filepath.Join(dir, "s.sock")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- srv.Serve(ctx, socketPath) }()
+
+	httpClient := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", socketPath)
+		},
+	}}
+	require.Eventually(t, func() bool {
+		resp, err := httpClient.Get("http://plonk/v1/status")
+		if err != nil {
+			return false
+		}
+		resp.Body.Close()
+		return resp.StatusCode == http.StatusOK
+	}, 5*time.Second, 20*time.Millisecond)
+
+	info, err := os.Stat(socketPath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	// A second server on the same socket is refused
+	assert.Error(t, New(nil).Serve(context.Background(), socketPath))
+
+	cancel()
+	require.NoError(t, <-done)
+	assert.NoFileExists(t, socketPath)
+}
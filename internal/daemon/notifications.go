@@ -0,0 +1,135 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/richhaase/plonk/internal/notify"
+	"github.com/richhaase/plonk/pkg/plonk"
+)
+
+// Notification event names, as used in the notifications.events config list
+const (
+	NotifyApply   = "apply"   // an apply finished and changed something
+	NotifyFailure = "failure" // an apply finished with errors
+	NotifyDrift   = "drift"   // a status check found missing or drifted items
+)
+
+// NotificationFilter reports whether a notification event should be sent
+type NotificationFilter func(event string) bool
+
+// WatchNotifications turns server events into desktop notifications until
+// ctx is cancelled. Dry runs are ignored, and drift is only reported when
+// the number of out-of-sync items changes, so periodic checks do not repeat
+// the same notification.
+func (s *Server) WatchNotifications(ctx context.Context, n notify.Notifier, enabled NotificationFilter) {
+	events := s.Subscribe()
+	defer s.Unsubscribe(events)
+
+	lastOutOfSync := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case ev := <-events:
+			var title, message string
+			switch ev.Type {
+			case EventApplyFinished:
+				title, message = applyNotification(ev, enabled)
+			case EventStatus:
+				status, ok := ev.Data.(*plonk.Status)
+				if !ok {
+					continue
+				}
+				count := outOfSync(status)
+				if count != lastOutOfSync && count > 0 && enabled(NotifyDrift) {
+					title = "plonk: drift detected"
+					message = fmt.Sprintf("%d item(s) out of sync; run plonk status for details", count)
+				}
+				lastOutOfSync = count
+			}
+			if title == "" {
+				continue
+			}
+			if err := n.Notify(ctx, title, message); err != nil {
+				log.Printf("Warning: failed to send notification: %v", err)
+			}
+		}
+	}
+}
+
+// WatchStatus publishes a status event every interval so drift is noticed
+// without a client polling the API
+func (s *Server) WatchStatus(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			status, err := s.client.Status(ctx)
+			if err != nil {
+				s.Publish(Event{Type: EventStatus, Error: err.Error()})
+				continue
+			}
+			s.Publish(Event{Type: EventStatus, Data: status})
+		}
+	}
+}
+
+// applyNotification returns the title and message for a finished apply, or
+// empty strings if nothing should be sent
+func applyNotification(ev Event, enabled NotificationFilter) (string, string) {
+	result, ok := ev.Data.(plonk.ApplyResult)
+	if !ok || result.DryRun {
+		return "", ""
+	}
+
+	failed := 0
+	if result.Packages != nil {
+		failed += result.Packages.TotalFailed
+	}
+	if result.Dotfiles != nil {
+		failed += result.Dotfiles.Summary.Failed
+	}
+	if result.Resources != nil {
+		failed += result.Resources.TotalFailed
+	}
+
+	if ev.Error != "" || failed > 0 {
+		if !enabled(NotifyFailure) {
+			return "", ""
+		}
+		if failed > 0 {
+			return "plonk: apply failed", fmt.Sprintf("%d item(s) failed; run plonk apply for details", failed)
+		}
+		return "plonk: apply failed", ev.Error
+	}
+
+	if !result.Changed || !enabled(NotifyApply) {
+		return "", ""
+	}
+	return "plonk: apply complete", "Packages and dotfiles are up to date"
+}
+
+// outOfSync counts missing and drifted packages and dotfiles
+func outOfSync(status *plonk.Status) int {
+	count := 0
+	for _, p := range status.Packages {
+		if p.State == "missing" {
+			count++
+		}
+	}
+	for _, d := range status.Dotfiles {
+		if d.State == "missing" || d.State == "drifted" {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package daemon
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/richhaase/plonk/pkg/plonk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingNotifier struct {
+	mu     sync.Mutex
+	titles []string
+}
+
+func (r *recordingNotifier) Notify(_ context.Context, title, _ string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.titles = append(r.titles, title)
+	return nil
+}
+
+func (r *recordingNotifier) Titles() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.titles...)
+}
+
+func allNotifications(string) bool { return true }
+
+func TestWatchNotifications(t *testing.T) {
+	srv, _ := newTestServer(t)
+	n := &recordingNotifier{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan struct{})
+	go func() {
+		srv.WatchNotifications(ctx, n, allNotifications)
+		close(done)
+	}()
+	require.Eventually(t, func() bool {
+		srv.subMu.Lock()
+		defer srv.subMu.Unlock()
+		return len(srv.subscribers) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	drifted := &plonk.Status{Reconciliation: plonk.Reconciliation{
+		Dotfiles: []plonk.DotfileStatus{{Name: "vimrc", State: "drifted"}},
+	}}
+	srv.Publish(Event{Type: EventApplyFinished, Data: plonk.ApplyResult{DryRun: true, Changed: true}})
+	srv.Publish(Event{Type: EventApplyFinished, Data: plonk.ApplyResult{Success: true, Changed: true}})
+	srv.Publish(Event{Type: EventApplyFinished, Data: plonk.ApplyResult{}, Error: "boom"})
+	srv.Publish(Event{Type: EventStatus, Data: drifted})
+	srv.Publish(Event{Type: EventStatus, Data: drifted}) // unchanged drift is not repeated
+
+	require.Eventually(t, func() bool { return len(n.Titles()) == 3 }, time.Second, 10*time.Millisecond)
+	cancel()
+	<-done
+
+	assert.Equal(t, []string{"plonk: apply complete", "plonk: apply failed", "plonk: drift detected"}, n.Titles())
+}
+
+func TestApplyNotification_Filtered(t *testing.T) {
+	onlyDrift := func(event string) bool { return event == NotifyDrift }
+
+	title, _ := applyNotification(Event{Data: plonk.ApplyResult{Changed: true}}, onlyDrift)
+	assert.Empty(t, title)
+
+	title, _ = applyNotification(Event{Data: plonk.ApplyResult{}, Error: "boom"}, onlyDrift)
+	assert.Empty(t, title)
+
+	title, _ = applyNotification(Event{Data: plonk.ApplyResult{}}, allNotifications)
+	assert.Empty(t, title, "no-op apply is not announced")
+}
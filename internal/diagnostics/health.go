@@ -7,17 +7,23 @@ import (
 	"context"
 	"fmt"
 	"io/fs"
+	"net"
+	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/richhaase/plonk/internal/config"
+	"github.com/richhaase/plonk/internal/dotfiles"
 	"github.com/richhaase/plonk/internal/lock"
 	"github.com/richhaase/plonk/internal/packages"
+	"github.com/richhaase/plonk/internal/signing"
 )
 
 var templateVarPattern = regexp.MustCompile(`\{\{([A-Za-z_][A-Za-z0-9_]*)\}\}`)
@@ -141,11 +147,15 @@ func RunHealthChecksWithContext(ctx context.Context) HealthReport {
 	// Lock file checks
 	report.Checks = append(report.Checks, checkLockFile())
 	report.Checks = append(report.Checks, checkLockFileValidity())
+	report.Checks = append(report.Checks, checkLockSignature(ctx))
 
 	// Package manager health checks (UPDATED - replaces old logic)
 	packageHealthChecks := checkPackageManagerHealth(ctx)
 	report.Checks = append(report.Checks, packageHealthChecks...)
 
+	// Proxy reachability check
+	report.Checks = append(report.Checks, checkProxies(ctx))
+
 	// Template readiness check
 	report.Checks = append(report.Checks, checkTemplateReadiness())
 
@@ -313,6 +323,14 @@ func checkConfigurationValidity() HealthCheck {
 		check.Details = append(check.Details, fmt.Sprintf("Ignore patterns: %d", len(cfg.IgnorePatterns)))
 	}
 
+	if len(cfg.ManagerPriority) > 0 {
+		check.Details = append(check.Details, fmt.Sprintf("Manager priority (%s): %s", runtime.GOOS, strings.Join(cfg.ManagerOrder(), ", ")))
+	}
+
+	if summary := cfg.ContainerSummary(); summary != "" {
+		check.Details = append(check.Details, "Container: "+summary)
+	}
+
 	return check
 }
 
@@ -350,6 +368,37 @@ func checkLockFile() HealthCheck {
 	return check
 }
 
+// checkLockSignature verifies plonk.lock against this machine's trust file
+func checkLockSignature(ctx context.Context) HealthCheck {
+	check := NewHealthCheck("Lock File Signature", "configuration", "plonk.lock signature is valid")
+
+	configDir := config.GetDefaultConfigDirectory()
+	_, sigErr := os.Stat(filepath.Join(configDir, signing.SignatureFile))
+	signed := sigErr == nil
+	if key := config.LoadWithDefaults(configDir).SigningKey; key != "" {
+		check.Details = append(check.Details, fmt.Sprintf("Signing key: %s", key))
+	}
+
+	trust := signing.TrustFile()
+	if trust == "" {
+		check.Status = "info"
+		check.Message = "plonk.lock signatures are not verified on this machine"
+		if signed {
+			check.Suggestions = append(check.Suggestions, fmt.Sprintf("plonk.lock is signed; set $%s to an allowed_signers file to verify it before apply", signing.TrustEnv))
+		}
+		return check
+	}
+
+	check.Details = append(check.Details, fmt.Sprintf("Trust file: %s", trust))
+	if err := signing.VerifyLock(ctx, configDir); err != nil {
+		check.Status = "fail"
+		check.Message = "plonk.lock signature does not verify"
+		check.Issues = append(check.Issues, err.Error())
+		check.Suggestions = append(check.Suggestions, "Review plonk.lock changes, then re-sign from a machine with signing_key set")
+	}
+	return check
+}
+
 // checkLockFileValidity validates the lock file format and content
 func checkLockFileValidity() HealthCheck {
 	check := NewHealthCheck("Lock File Validity", "configuration", "Lock file is valid")
@@ -381,7 +430,17 @@ func checkLockFileValidity() HealthCheck {
 
 // checkPackageManagerHealth runs health checks for all package managers
 func checkPackageManagerHealth(_ context.Context) []HealthCheck {
-	requiredManagers := collectRequiredManagers(config.GetDefaultConfigDirectory())
+	configDir := config.GetDefaultConfigDirectory()
+	requiredManagers := collectRequiredManagers(configDir)
+	var managerOpts config.ManagersConfig
+	preferred := config.GetDefaults().ManagerOrder()
+	var disabled []string
+	if cfg, err := config.Load(configDir); err == nil {
+		// an invalid config is reported by checkConfigurationValidity
+		managerOpts = cfg.Managers
+		preferred = cfg.ManagerOrder()
+		disabled = cfg.ActiveDisabledManagers()
+	}
 
 	check := NewHealthCheck("Package Managers", "package-managers", "No package managers configured")
 	check.Status = "info" // Override default "pass" status
@@ -400,51 +459,181 @@ func checkPackageManagerHealth(_ context.Context) []HealthCheck {
 	}
 
 	missing := make([]string, 0)
+	checked := make([]string, 0, len(requiredManagers))
 	for _, managerName := range requiredManagers {
+		if slices.Contains(disabled, managerName) {
+			check.Details = append(check.Details, fmt.Sprintf("%s: disabled", managerName))
+			continue
+		}
+		checked = append(checked, managerName)
+
 		if !packages.IsSupportedManager(managerName) {
 			check.Details = append(check.Details, fmt.Sprintf("%s: unsupported", managerName))
-			check.Issues = append(check.Issues, fmt.Sprintf("%s is not a supported package manager", managerName))
-			check.Suggestions = append(check.Suggestions, fmt.Sprintf("Remove %s entries from lock file or migrate to a supported manager", managerName))
+			check.Issues = append(check.Issues, packages.UnsupportedManagerError(managerName).Error())
+			check.Suggestions = append(check.Suggestions, fmt.Sprintf("Remove %s entries from lock file or migrate them to %s", managerName, strings.Join(preferred, " or ")))
 			missing = append(missing, managerName)
 			continue
 		}
 
 		binary := managerBinaries[managerName]
 		if binary == "" {
-			binary = managerName
+			binary = packages.PluginPrefix + managerName
 		}
 
-		_, err := exec.LookPath(binary)
+		_, err := packages.LookPath(binary)
 		available := err == nil
 
 		if available {
 			check.Details = append(check.Details, fmt.Sprintf("%s: available", managerName))
+			for _, kv := range packages.ManagerEnv(managerName, managerOpts) {
+				check.Details = append(check.Details, fmt.Sprintf("%s env: %s", managerName, redactProxyEnv(kv)))
+			}
 		} else {
 			check.Details = append(check.Details, fmt.Sprintf("%s: missing", managerName))
 			check.Issues = append(check.Issues, fmt.Sprintf("%s is not installed", managerName))
-			check.Suggestions = append(check.Suggestions, fmt.Sprintf("Install %s using the appropriate instructions", managerName))
+			check.Suggestions = append(check.Suggestions, fmt.Sprintf("%s: %s", managerName, packages.ManagerInstallHint(managerName)))
 			missing = append(missing, managerName)
 		}
 	}
 
 	switch {
+	case len(checked) == 0:
+		check.Message = "All required package managers are disabled"
 	case len(missing) == 0:
 		check.Status = "pass"
-		check.Message = fmt.Sprintf("All %d required package managers available", len(requiredManagers))
-	case len(missing) == len(requiredManagers):
+		check.Message = fmt.Sprintf("All %d required package managers available", len(checked))
+	case len(missing) == len(checked):
 		check.Status = "fail"
 		check.Message = "All required package managers are missing"
 	default:
 		check.Status = "warn"
-		check.Message = fmt.Sprintf("%d of %d required package managers are missing", len(missing), len(requiredManagers))
+		check.Message = fmt.Sprintf("%d of %d required package managers are missing", len(missing), len(checked))
 	}
 
 	return []HealthCheck{check}
 }
 
+// proxyDialTimeout bounds each proxy reachability check
+const proxyDialTimeout = 3 * time.Second
+
+// checkProxies checks that the proxies package managers are sent through,
+// from plonk.yaml or the environment, accept connections
+func checkProxies(ctx context.Context) HealthCheck {
+	var managerOpts config.ManagersConfig
+	if cfg, err := config.Load(config.GetDefaultConfigDirectory()); err == nil {
+		managerOpts = cfg.Managers
+	}
+	return proxyCheck(ctx, managerOpts)
+}
+
+// proxyCheck dials every distinct proxy in opts and the environment
+func proxyCheck(ctx context.Context, opts config.ManagersConfig) HealthCheck {
+	check := NewHealthCheck("Proxies", "network", "No proxies configured")
+	check.Status = "info"
+
+	users := make(map[string][]string) // proxy URL -> who is sent through it
+	var proxies []string
+	add := func(proxy config.ProxyConfig, user string) {
+		for _, raw := range []string{proxy.HTTP, proxy.HTTPS} {
+			raw = os.ExpandEnv(raw)
+			if raw == "" || slices.Contains(users[raw], user) {
+				continue
+			}
+			if users[raw] == nil {
+				proxies = append(proxies, raw)
+			}
+			users[raw] = append(users[raw], user)
+		}
+	}
+	add(opts.Proxy, "all managers")
+	for _, manager := range packages.SupportedManagers {
+		add(opts.Common(manager).Proxy, manager)
+	}
+	add(config.ProxyConfig{HTTP: os.Getenv("HTTP_PROXY"), HTTPS: os.Getenv("HTTPS_PROXY")}, "environment")
+	if len(proxies) == 0 {
+		return check
+	}
+
+	check.Status = "pass"
+	var unreachable int
+	for _, raw := range proxies {
+		shown := redactProxyURL(raw)
+		check.Details = append(check.Details, fmt.Sprintf("%s: %s", shown, strings.Join(users[raw], ", ")))
+		address, err := proxyAddress(raw)
+		if err == nil {
+			var conn net.Conn
+			dialer := net.Dialer{Timeout: proxyDialTimeout}
+			if conn, err = dialer.DialContext(ctx, "tcp", address); err == nil {
+				conn.Close()
+				continue
+			}
+		}
+		unreachable++
+		check.Issues = append(check.Issues, fmt.Sprintf("proxy %s is unreachable: %v", shown, err))
+	}
+
+	if unreachable == 0 {
+		check.Message = fmt.Sprintf("%d %s reachable", len(proxies), pluralize(len(proxies), "proxy", "proxies"))
+		return check
+	}
+	check.Status = "warn"
+	check.Message = fmt.Sprintf("%d of %d %s unreachable", unreachable, len(proxies), pluralize(len(proxies), "proxy", "proxies"))
+	check.Suggestions = append(check.Suggestions, "Check the proxy settings under managers: in plonk.yaml and HTTP_PROXY/HTTPS_PROXY, or whether you are on the network the proxy serves")
+	return check
+}
+
+// proxyAddress returns the host:port to dial for a proxy URL
+func proxyAddress(raw string) (string, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", err
+	}
+	if u.Hostname() == "" {
+		return "", fmt.Errorf("no host in %q", redactProxyURL(raw))
+	}
+	port := u.Port()
+	if port == "" {
+		switch u.Scheme {
+		case "https":
+			port = "443"
+		case "socks5", "socks5h":
+			port = "1080"
+		default:
+			port = "80"
+		}
+	}
+	return net.JoinHostPort(u.Hostname(), port), nil
+}
+
+// redactProxyURL hides the password in a proxy URL
+func redactProxyURL(raw string) string {
+	if u, err := url.Parse(raw); err == nil {
+		return u.Redacted()
+	}
+	return raw
+}
+
+// redactProxyEnv hides the password in a NAME=value pair for a proxy variable
+func redactProxyEnv(kv string) string {
+	key, value, ok := strings.Cut(kv, "=")
+	if !ok || !strings.HasSuffix(strings.ToUpper(key), "_PROXY") {
+		return kv
+	}
+	return key + "=" + redactProxyURL(value)
+}
+
+// pluralize picks the singular or plural form for n
+func pluralize(n int, singular, plural string) string {
+	if n == 1 {
+		return singular
+	}
+	return plural
+}
+
 // checkExecutablePath checks if plonk executable is accessible
 // checkTemplateReadiness scans for .tmpl dotfiles and validates that
-// all referenced environment variables are set.
+// all referenced variables are set in the environment, the template's vars,
+// or this machine's answers to its prompts.
 func checkTemplateReadiness() HealthCheck {
 	check := NewHealthCheck("Template Readiness", "dotfiles", "All template variables are available")
 
@@ -455,7 +644,17 @@ func checkTemplateReadiness() HealthCheck {
 		return check
 	}
 
-	var missing []string
+	settings, err := config.LoadDotfiles(configDir)
+	if err != nil {
+		check.Details = append(check.Details, fmt.Sprintf("Cannot read dotfile settings: %v", err))
+	}
+	answers, err := dotfiles.LoadAnswers(dotfiles.AnswersPath())
+	if err != nil {
+		check.Details = append(check.Details, fmt.Sprintf("Cannot read template answers: %v", err))
+	}
+
+	missing := make(map[string]bool)
+	unanswered := make(map[string]bool)
 	seen := make(map[string]bool)
 
 	// Walk config directory for .tmpl files using os.Root to prevent symlink traversal
@@ -480,34 +679,59 @@ func checkTemplateReadiness() HealthCheck {
 		}
 
 		// Scan for {{VAR}} patterns
+		vars := settings.Templates[path]
 		for _, match := range templateVarPattern.FindAllSubmatch(content, -1) {
 			varName := string(match[1])
-			if seen[varName] {
+			seen[varName] = true
+			if _, ok := vars.Vars[varName]; ok {
 				continue
 			}
-			seen[varName] = true
-			if _, ok := os.LookupEnv(varName); !ok {
-				missing = append(missing, varName)
+			if _, ok := os.LookupEnv(varName); ok {
+				continue
+			}
+			if _, ok := vars.Prompt[varName]; ok {
+				if _, ok := answers[varName]; !ok {
+					unanswered[varName] = true
+				}
+				continue
 			}
+			missing[varName] = true
 		}
 		return nil
 	})
 
 	if len(missing) > 0 {
-		sort.Strings(missing)
 		check.Status = "warn"
-		check.Issues = append(check.Issues, fmt.Sprintf("Template variables not set: %s", strings.Join(missing, ", ")))
-		check.Suggestions = append(check.Suggestions, "Set the missing environment variables before running 'plonk apply'")
-		check.Message = fmt.Sprintf("%d template variable(s) missing", len(missing))
-	} else if len(seen) > 0 {
+		check.Issues = append(check.Issues, fmt.Sprintf("Template variables not set: %s", strings.Join(sortedKeys(missing), ", ")))
+		check.Suggestions = append(check.Suggestions, "Set the missing environment variables or add them to the template's vars in plonk.yaml")
+	}
+	if len(unanswered) > 0 {
+		check.Status = "warn"
+		check.Issues = append(check.Issues, fmt.Sprintf("Template prompts not answered on this machine: %s", strings.Join(sortedKeys(unanswered), ", ")))
+		check.Suggestions = append(check.Suggestions, "Run 'plonk apply' in a terminal to answer them")
+	}
+	switch {
+	case check.Status == "warn":
+		check.Message = fmt.Sprintf("%d template variable(s) missing", len(missing)+len(unanswered))
+	case len(seen) > 0:
 		check.Details = append(check.Details, fmt.Sprintf("%d template variable(s) verified", len(seen)))
-	} else {
+	default:
 		check.Details = append(check.Details, "No template files found")
 	}
 
 	return check
 }
 
+// sortedKeys returns the keys of set in order
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 func checkExecutablePath() HealthCheck {
 	check := NewHealthCheck("Executable Path", "installation", "Executable is accessible")
 
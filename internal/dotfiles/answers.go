@@ -0,0 +1,154 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package dotfiles
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/richhaase/plonk/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+// A template variable comes from, in order: the machine's answer to its
+// prompt, the template's vars in plonk.yaml, then the environment. Answers
+// live in the state directory, never in the config directory, so one
+// repository can render a work identity on one machine and a personal one
+// on another.
+
+// answersFileName is the machine-local file of prompt answers
+const answersFileName = "answers.yaml"
+
+// TemplatePrompt is a template variable still to be asked on this machine
+type TemplatePrompt struct {
+	Var      string
+	Question string
+	Default  string // the variable's value from vars, if any
+}
+
+// AnswersPath returns the machine-local prompt answers file
+func AnswersPath() string {
+	return filepath.Join(config.GetStateDirectory(), answersFileName)
+}
+
+// LoadAnswers reads prompt answers from path; a missing file has none
+func LoadAnswers(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]string{}, nil
+		}
+		return nil, err
+	}
+	answers := map[string]string{}
+	if err := yaml.Unmarshal(data, &answers); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return answers, nil
+}
+
+// SaveAnswers writes prompt answers to path, readable only by the user
+// since they tend to be emails and hostnames
+func SaveAnswers(path string, answers map[string]string) error {
+	data, err := yaml.Marshal(answers)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// loadAnswers reads this machine's answers, warning if they can't be read
+func loadAnswers() map[string]string {
+	answers, err := LoadAnswers(AnswersPath())
+	if err != nil {
+		log.Printf("Warning: ignoring template answers: %v", err)
+	}
+	return answers
+}
+
+// WithTemplates sets the per-template variables and this machine's
+// answers to their prompts
+func (m *DotfileManager) WithTemplates(templates map[string]config.TemplateVariables, answers map[string]string) *DotfileManager {
+	m.templates = templates
+	m.answers = answers
+	return m
+}
+
+// templateLookup returns how variables of the template name are looked up
+func (m *DotfileManager) templateLookup(name string) func(string) (string, bool) {
+	vars := m.templates[filepath.ToSlash(name)]
+	return func(key string) (string, bool) {
+		if _, ok := vars.Prompt[key]; ok {
+			if value, ok := m.answers[key]; ok {
+				return value, true
+			}
+		}
+		if value, ok := vars.Vars[key]; ok {
+			return value, true
+		}
+		return m.lookupEnv(key)
+	}
+}
+
+// PendingPrompts returns the prompt variables of existing templates that
+// this machine has neither answered nor set in the environment, once per
+// variable, sorted by name
+func (m *DotfileManager) PendingPrompts() []TemplatePrompt {
+	byVar := make(map[string]TemplatePrompt)
+	for name, vars := range m.templates {
+		if _, err := m.fs.Stat(filepath.Join(m.configDir, filepath.FromSlash(name))); err != nil {
+			continue
+		}
+		for key, question := range vars.Prompt {
+			if _, ok := byVar[key]; ok {
+				continue
+			}
+			if _, ok := m.answers[key]; ok {
+				continue
+			}
+			if _, ok := m.lookupEnv(key); ok {
+				continue
+			}
+			byVar[key] = TemplatePrompt{Var: key, Question: question, Default: vars.Vars[key]}
+		}
+	}
+
+	prompts := make([]TemplatePrompt, 0, len(byVar))
+	for _, p := range byVar {
+		prompts = append(prompts, p)
+	}
+	sort.Slice(prompts, func(i, j int) bool { return prompts[i].Var < prompts[j].Var })
+	return prompts
+}
+
+// AnswerPrompts asks each pending template prompt in configDir and saves
+// the answers for later applies. An empty answer is saved as well: asking
+// again on every apply would be worse than an empty value.
+func AnswerPrompts(configDir, homeDir string, ask func(TemplatePrompt) (string, error)) error {
+	answers, err := LoadAnswers(AnswersPath())
+	if err != nil {
+		return err
+	}
+	m := NewDotfileManager(configDir, homeDir, nil)
+	m.answers = answers
+	prompts := m.PendingPrompts()
+	if len(prompts) == 0 {
+		return nil
+	}
+
+	for _, p := range prompts {
+		answer, err := ask(p)
+		if err != nil {
+			return err
+		}
+		answers[p.Var] = answer
+	}
+	return SaveAnswers(AnswersPath(), answers)
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package dotfiles
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/richhaase/plonk/internal/config"
+)
+
+func TestDotfileManager_TemplateVariables(t *testing.T) {
+	fs := NewMemoryFS()
+	fs.Dirs["/config"] = true
+	fs.Files["/config/gitconfig.tmpl"] = []byte("[user]\n  email = {{GIT_EMAIL}}\n  name = {{GIT_NAME}}\n  editor = {{EDITOR}}\n")
+
+	m := NewDotfileManagerWithFS("/config", "/home/user", nil, fs)
+	m.lookupEnv = func(key string) (string, bool) {
+		env := map[string]string{"EDITOR": "vim", "GIT_NAME": "From Env"}
+		value, ok := env[key]
+		return value, ok
+	}
+	m.WithTemplates(map[string]config.TemplateVariables{
+		"gitconfig.tmpl": {
+			Vars:   map[string]string{"GIT_NAME": "Rich", "GIT_EMAIL": "me@example.com"},
+			Prompt: map[string]string{"GIT_EMAIL": "Git email for this machine?"},
+		},
+	}, nil)
+
+	// Unanswered, the prompt falls back to vars
+	content, err := m.RenderSource("gitconfig.tmpl")
+	if err != nil {
+		t.Fatalf("RenderSource() error = %v", err)
+	}
+	if want := "[user]\n  email = me@example.com\n  name = Rich\n  editor = vim\n"; string(content) != want {
+		t.Errorf("RenderSource() = %q, want %q", content, want)
+	}
+
+	prompts := m.PendingPrompts()
+	if len(prompts) != 1 || prompts[0].Var != "GIT_EMAIL" || prompts[0].Default != "me@example.com" {
+		t.Errorf("PendingPrompts() = %+v, want GIT_EMAIL defaulting to its var", prompts)
+	}
+
+	m.answers = map[string]string{"GIT_EMAIL": "me@work.example.com"}
+	if err := m.Deploy("gitconfig.tmpl"); err != nil {
+		t.Fatalf("Deploy() error = %v", err)
+	}
+	if got := string(fs.Files["/home/user/.gitconfig"]); !strings.Contains(got, "email = me@work.example.com") {
+		t.Errorf("deployed gitconfig = %q, want the answered email", got)
+	}
+	if prompts := m.PendingPrompts(); len(prompts) != 0 {
+		t.Errorf("PendingPrompts() after answering = %+v, want none", prompts)
+	}
+}
+
+func TestDotfileManager_TemplateVariablesArePerFile(t *testing.T) {
+	fs := NewMemoryFS()
+	fs.Dirs["/config"] = true
+	fs.Files["/config/gitconfig.tmpl"] = []byte("{{GIT_EMAIL}}")
+	fs.Files["/config/hgrc.tmpl"] = []byte("{{GIT_EMAIL}}")
+
+	m := NewDotfileManagerWithFS("/config", "/home/user", nil, fs)
+	m.lookupEnv = func(string) (string, bool) { return "", false }
+	m.WithTemplates(map[string]config.TemplateVariables{
+		"gitconfig.tmpl": {Vars: map[string]string{"GIT_EMAIL": "me@example.com"}},
+		"gone.tmpl":      {Prompt: map[string]string{"GONE": "Never asked?"}},
+	}, nil)
+
+	if _, err := m.RenderSource("gitconfig.tmpl"); err != nil {
+		t.Errorf("RenderSource(gitconfig.tmpl) error = %v", err)
+	}
+	_, err := m.RenderSource("hgrc.tmpl")
+	if err == nil || !strings.Contains(err.Error(), "missing template variables: GIT_EMAIL") {
+		t.Errorf("RenderSource(hgrc.tmpl) error = %v, want GIT_EMAIL missing", err)
+	}
+	if prompts := m.PendingPrompts(); len(prompts) != 0 {
+		t.Errorf("PendingPrompts() = %+v, want none for a template that no longer exists", prompts)
+	}
+}
+
+func TestAnswerPrompts(t *testing.T) {
+	configDir := t.TempDir()
+	homeDir := t.TempDir()
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	t.Setenv("WORK_HOST", "")
+	os.Unsetenv("WORK_HOST")
+
+	writeTestFile(t, filepath.Join(configDir, "plonk.yaml"), `dotfiles:
+  templates:
+    ssh/config.tmpl:
+      prompt:
+        WORK_HOST: Work bastion host?
+`)
+	writeTestFile(t, filepath.Join(configDir, "ssh", "config.tmpl"), "Host {{WORK_HOST}}\n")
+
+	asked := 0
+	ask := func(p TemplatePrompt) (string, error) {
+		asked++
+		if p.Question != "Work bastion host?" {
+			t.Errorf("asked %q", p.Question)
+		}
+		return "bastion.example.com", nil
+	}
+	for range 2 {
+		if err := AnswerPrompts(configDir, homeDir, ask); err != nil {
+			t.Fatalf("AnswerPrompts() error = %v", err)
+		}
+	}
+	if asked != 1 {
+		t.Errorf("asked %d times, want once", asked)
+	}
+
+	info, err := os.Stat(AnswersPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := info.Mode().Perm(); got != 0o600 {
+		t.Errorf("answers file mode = %04o, want 0600", got)
+	}
+
+	m := NewDotfileManager(configDir, homeDir, nil)
+	if err := m.Deploy("ssh/config.tmpl"); err != nil {
+		t.Fatalf("Deploy() error = %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(homeDir, ".ssh", "config"))
+	if err != nil || string(data) != "Host bastion.example.com\n" {
+		t.Errorf("deployed ssh config = %q, %v", data, err)
+	}
+}
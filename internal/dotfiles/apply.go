@@ -18,6 +18,9 @@ type ApplyFilterOptions struct {
 	// Filter is a set of normalized destination paths to apply.
 	// If empty or nil, all dotfiles are applied.
 	Filter map[string]bool
+	// Exclude is a set of normalized destination paths to leave alone,
+	// such as conflicts skipped during clone
+	Exclude map[string]bool
 }
 
 // ApplySelective applies only the dotfiles whose destination paths are in the filter set.
@@ -32,17 +35,18 @@ func ApplySelective(ctx context.Context, configDir, homeDir string, cfg *config.
 	}
 
 	// Filter if needed
-	if len(opts.Filter) > 0 {
+	if len(opts.Filter) > 0 || len(opts.Exclude) > 0 {
 		var filtered []DotfileStatus
 		for _, s := range statuses {
-			if opts.Filter[normalizePath(s.Target)] {
+			target := normalizePath(s.Target)
+			if (len(opts.Filter) == 0 || opts.Filter[target]) && !opts.Exclude[target] {
 				filtered = append(filtered, s)
 			}
 		}
 		statuses = filtered
 	}
 
-	return applyStatuses(ctx, manager, statuses, opts.DryRun)
+	return applyStatuses(ctx, manager, statuses, opts.DryRun, len(opts.Filter) == 0 && len(opts.Exclude) == 0)
 }
 
 // Apply applies dotfile configuration and returns the result
@@ -54,7 +58,7 @@ func Apply(ctx context.Context, configDir, homeDir string, cfg *config.Config, d
 		return output.DotfileResults{DryRun: dryRun}, err
 	}
 
-	return applyStatuses(ctx, manager, statuses, dryRun)
+	return applyStatuses(ctx, manager, statuses, dryRun, true)
 }
 
 func normalizePath(path string) string {
@@ -64,16 +68,23 @@ func normalizePath(path string) string {
 	return filepath.Clean(path)
 }
 
-// applyStatuses applies the given dotfile statuses and returns results
-func applyStatuses(ctx context.Context, manager *DotfileManager, statuses []DotfileStatus, dryRun bool) (output.DotfileResults, error) {
+// applyStatuses applies the given dotfile statuses and returns results.
+// A missing dotfile whose source was moved from a deployed one moves that
+// deployed file. complete means statuses cover every dotfile, so records
+// of sources that are gone can be dropped.
+func applyStatuses(ctx context.Context, manager *DotfileManager, statuses []DotfileStatus, dryRun, complete bool) (output.DotfileResults, error) {
 	result := output.DotfileResults{
 		DryRun:     dryRun,
 		TotalFiles: len(statuses),
 	}
 
+	moves := manager.detectMoves(manager.recordedDeployments(), statuses)
+	moved := make(map[string]string)
+	var synced []DotfileStatus
+
 	spinnerCount := 0
 	for _, s := range statuses {
-		if s.State == SyncStateMissing || s.State == SyncStateDrifted {
+		if s.State == SyncStateMissing || (s.State == SyncStateDrifted && !s.KeptLocal) {
 			spinnerCount++
 		}
 	}
@@ -89,9 +100,26 @@ func applyStatuses(ctx context.Context, manager *DotfileManager, statuses []Dotf
 			return result, fmt.Errorf("apply canceled: %w", err)
 		}
 
+		if oldTarget, ok := moves[s.Name]; ok {
+			action := applyMove(manager, spinnerManager, s, oldTarget, dryRun)
+			switch action.Status {
+			case "failed":
+				result.Summary.Failed++
+			case "moved":
+				moved[s.Name] = oldTarget
+				synced = append(synced, s)
+				result.Summary.Added++
+			default:
+				result.Summary.Added++
+			}
+			result.Actions = append(result.Actions, action)
+			continue
+		}
+
 		switch s.State {
 		case SyncStateManaged:
 			result.Summary.Unchanged++
+			synced = append(synced, s)
 
 		case SyncStateError:
 			action := output.DotfileOperation{
@@ -138,6 +166,7 @@ func applyStatuses(ctx context.Context, manager *DotfileManager, statuses []Dotf
 					action.Action = "copy"
 					action.Status = "added"
 					result.Summary.Added++
+					synced = append(synced, s)
 					if spinner != nil {
 						spinner.Success("deployed " + s.Name)
 					}
@@ -146,6 +175,17 @@ func applyStatuses(ctx context.Context, manager *DotfileManager, statuses []Dotf
 			result.Actions = append(result.Actions, action)
 
 		case SyncStateDrifted:
+			if s.KeptLocal {
+				result.Actions = append(result.Actions, output.DotfileOperation{
+					Source:      s.Source,
+					Destination: s.Target,
+					Action:      "keep-local",
+					Status:      "kept",
+				})
+				result.Summary.Unchanged++
+				continue
+			}
+
 			var spinner *output.Spinner
 			if spinnerManager != nil {
 				spinner = spinnerManager.StartSpinner("Updating", s.Name)
@@ -177,6 +217,7 @@ func applyStatuses(ctx context.Context, manager *DotfileManager, statuses []Dotf
 					action.Action = "copy"
 					action.Status = "updated"
 					result.Summary.Updated++
+					synced = append(synced, s)
 					if spinner != nil {
 						spinner.Success("updated " + s.Name)
 					}
@@ -186,9 +227,54 @@ func applyStatuses(ctx context.Context, manager *DotfileManager, statuses []Dotf
 		}
 	}
 
+	if !dryRun {
+		manager.recordDeployed(synced, moved, complete)
+	}
+
 	if result.Summary.Failed > 0 {
 		return result, fmt.Errorf("failed to deploy %d file(s)", result.Summary.Failed)
 	}
 
 	return result, nil
 }
+
+// applyMove deploys a dotfile whose source was moved and removes the file
+// deployed from its old location
+func applyMove(manager *DotfileManager, spinnerManager *output.SpinnerManager, s DotfileStatus, oldTarget string, dryRun bool) output.DotfileOperation {
+	var spinner *output.Spinner
+	if spinnerManager != nil {
+		spinner = spinnerManager.StartSpinner("Moving", s.Name)
+	}
+
+	action := output.DotfileOperation{
+		Source:      s.Source,
+		Destination: s.Target,
+		From:        oldTarget,
+	}
+
+	if dryRun {
+		action.Action = "would-move"
+		action.Status = "would-move"
+		if spinner != nil {
+			spinner.Success("would-move " + oldTarget + " to " + s.Target)
+		}
+		return action
+	}
+
+	if err := manager.moveDeployed(s.Name, oldTarget); err != nil {
+		action.Action = "error"
+		action.Status = "failed"
+		action.Error = err.Error()
+		if spinner != nil {
+			spinner.Error("Failed to move " + s.Name + ": " + err.Error())
+		}
+		return action
+	}
+
+	action.Action = "move"
+	action.Status = "moved"
+	if spinner != nil {
+		spinner.Success("moved " + oldTarget + " to " + s.Target)
+	}
+	return action
+}
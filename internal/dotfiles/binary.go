@@ -0,0 +1,212 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package dotfiles
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// binarySniffLen is how much of a file is checked for a NUL byte to
+	// tell binary from text, as git does
+	binarySniffLen = 8000
+
+	// lfsMinSize is the size from which dotfiles.lfs stores a text file
+	// through git-lfs as well
+	lfsMinSize = 1 << 20
+
+	// lfsPointerPrefix starts a git-lfs pointer file, which is what a
+	// checkout without git-lfs has in place of the real content
+	lfsPointerPrefix = "version https://git-lfs.github.com/spec/v1"
+
+	// lfsAttributes are the .gitattributes of a file stored through git-lfs
+	lfsAttributes = "filter=lfs diff=lfs merge=lfs -text"
+)
+
+// readHead returns up to n bytes from the start of path
+func (m *DotfileManager) readHead(path string, n int) ([]byte, error) {
+	f, err := m.fs.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	buf := make([]byte, n)
+	read, err := io.ReadFull(f, buf)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		err = nil
+	}
+	return buf[:read], err
+}
+
+// isBinaryFile reports whether path looks binary
+func (m *DotfileManager) isBinaryFile(path string) (bool, error) {
+	head, err := m.readHead(path, binarySniffLen)
+	if err != nil {
+		return false, err
+	}
+	return bytes.IndexByte(head, 0) >= 0, nil
+}
+
+// IsBinary reports whether the source or the deployed copy of d is
+// binary, which is compared by hash and never diffed as text. Templates
+// are always text.
+func (m *DotfileManager) IsBinary(d Dotfile) (bool, error) {
+	if isTemplate(d.Name) {
+		return false, nil
+	}
+	for _, path := range []string{d.Source, d.Target} {
+		binary, err := m.isBinaryFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			return false, err
+		}
+		if binary {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// hashFile returns the SHA-256 of path and its size, reading it in chunks
+func (m *DotfileManager) hashFile(path string) (string, int64, error) {
+	f, err := m.fs.Open(path)
+	if err != nil {
+		return "", 0, err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	n, err := io.Copy(h, f)
+	if err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(h.Sum(nil)), n, nil
+}
+
+// sameFile reports whether a and b have the same content without reading
+// either into memory
+func (m *DotfileManager) sameFile(a, b string) (bool, error) {
+	aInfo, err := m.fs.Stat(a)
+	if err != nil {
+		return false, err
+	}
+	bInfo, err := m.fs.Stat(b)
+	if err != nil {
+		return false, err
+	}
+	if aInfo.Size() != bInfo.Size() {
+		return false, nil
+	}
+
+	aHash, _, err := m.hashFile(a)
+	if err != nil {
+		return false, err
+	}
+	bHash, _, err := m.hashFile(b)
+	if err != nil {
+		return false, err
+	}
+	return aHash == bHash, nil
+}
+
+// copyFile streams src into a new file dst with perm
+func (m *DotfileManager) copyFile(src, dst string, perm os.FileMode) error {
+	in, err := m.fs.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := m.fs.Create(dst, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// binaryDiff describes how the binary source and target of d differ
+func (m *DotfileManager) binaryDiff(d Dotfile) (string, error) {
+	sourceHash, sourceSize, err := m.hashFile(d.Source)
+	if err != nil {
+		return "", fmt.Errorf("failed to read source: %w", err)
+	}
+	targetHash, targetSize, err := m.hashFile(d.Target)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Sprintf("(target missing, source has %d bytes)", sourceSize), nil
+		}
+		return "", fmt.Errorf("failed to read target: %w", err)
+	}
+	if sourceHash == targetHash {
+		return "", nil
+	}
+	return fmt.Sprintf("Binary files %s and %s differ\n  source: %d bytes, sha256 %s\n  target: %d bytes, sha256 %s\n",
+		d.Source, d.Target, sourceSize, sourceHash, targetSize, targetHash), nil
+}
+
+// checkLFSPointer fails for a source that is still a git-lfs pointer,
+// rather than deploying the pointer over the real file
+func (m *DotfileManager) checkLFSPointer(sourcePath string) error {
+	head, err := m.readHead(sourcePath, len(lfsPointerPrefix))
+	if err != nil {
+		return fmt.Errorf("failed to read source: %w", err)
+	}
+	if string(head) == lfsPointerPrefix {
+		return fmt.Errorf("%s is a git-lfs pointer, not the file: install git-lfs and run 'git lfs pull' in %s", sourcePath, m.configDir)
+	}
+	return nil
+}
+
+// trackWithLFS adds a git-lfs rule for the source name to .gitattributes
+// in the config directory when the file is binary or large
+func (m *DotfileManager) trackWithLFS(name string) error {
+	sourcePath := filepath.Join(m.configDir, name)
+	info, err := m.fs.Stat(sourcePath)
+	if err != nil {
+		return err
+	}
+	binary, err := m.isBinaryFile(sourcePath)
+	if err != nil {
+		return err
+	}
+	if !binary && info.Size() < lfsMinSize {
+		return nil
+	}
+
+	attrsPath := filepath.Join(m.configDir, ".gitattributes")
+	attrs, err := m.fs.ReadFile(attrsPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	rule := lfsAttributesPattern(name) + " " + lfsAttributes
+	for _, line := range strings.Split(string(attrs), "\n") {
+		if strings.TrimSpace(line) == rule {
+			return nil
+		}
+	}
+	if len(attrs) > 0 && !bytes.HasSuffix(attrs, []byte("\n")) {
+		attrs = append(attrs, '\n')
+	}
+	attrs = append(attrs, rule+"\n"...)
+	return m.fs.WriteFile(attrsPath, attrs, 0644)
+}
+
+// lfsAttributesPattern anchors name to the top of the repository and
+// escapes what .gitattributes patterns treat specially
+func lfsAttributesPattern(name string) string {
+	replacer := strings.NewReplacer(" ", "[[:space:]]", "*", `\*`, "?", `\?`, "[", `\[`)
+	return "/" + replacer.Replace(filepath.ToSlash(name))
+}
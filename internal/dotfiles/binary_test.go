@@ -0,0 +1,99 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package dotfiles
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestDotfileManager_BinaryDrift(t *testing.T) {
+	fs := NewMemoryFS()
+	fs.Dirs["/config"] = true
+	fs.Files["/config/keytab"] = []byte("\x05\x02\x00\x00\x00\x01keytab")
+	fs.Files["/home/user/.keytab"] = []byte("\x05\x02\x00\x00\x00\x01keytab")
+
+	m := NewDotfileManagerWithFS("/config", "/home/user", nil, fs)
+	d := Dotfile{Name: "keytab", Source: "/config/keytab", Target: "/home/user/.keytab"}
+
+	binary, err := m.IsBinary(d)
+	if err != nil || !binary {
+		t.Fatalf("IsBinary() = %v, %v; want true", binary, err)
+	}
+	if drifted, err := m.IsDrifted(d); err != nil || drifted {
+		t.Errorf("IsDrifted() with identical files = %v, %v", drifted, err)
+	}
+
+	fs.Files["/home/user/.keytab"] = []byte("\x05\x02\x00\x00\x00\x02keytab")
+	if drifted, err := m.IsDrifted(d); err != nil || !drifted {
+		t.Errorf("IsDrifted() with changed files = %v, %v", drifted, err)
+	}
+
+	diff, err := m.Diff(d)
+	if err != nil {
+		t.Fatalf("Diff() error = %v", err)
+	}
+	if !strings.HasPrefix(diff, "Binary files") || !strings.Contains(diff, "sha256") {
+		t.Errorf("Diff() = %q, want a binary summary", diff)
+	}
+}
+
+func TestDotfileManager_DeployStreamsAndRefusesLFSPointers(t *testing.T) {
+	fs := NewMemoryFS()
+	fs.Dirs["/config"] = true
+	content := bytes.Repeat([]byte{0, 1, 2, 3}, 10000)
+	fs.Files["/config/terminfo.db"] = content
+	fs.Files["/config/large.bin"] = []byte(lfsPointerPrefix + "\noid sha256:abc\nsize 123\n")
+
+	m := NewDotfileManagerWithFS("/config", "/home/user", nil, fs)
+
+	if err := m.Deploy("terminfo.db"); err != nil {
+		t.Fatalf("Deploy() error = %v", err)
+	}
+	if !bytes.Equal(fs.Files["/home/user/.terminfo.db"], content) {
+		t.Error("Deploy() did not copy the binary content")
+	}
+
+	err := m.Deploy("large.bin")
+	if err == nil || !strings.Contains(err.Error(), "git lfs pull") {
+		t.Errorf("Deploy() of an LFS pointer error = %v, want a git lfs pull hint", err)
+	}
+	if _, ok := fs.Files["/home/user/.large.bin"]; ok {
+		t.Error("Deploy() wrote the LFS pointer over the target")
+	}
+}
+
+func TestDotfileManager_AddTracksWithLFS(t *testing.T) {
+	fs := NewMemoryFS()
+	fs.Dirs["/config"] = true
+	fs.Dirs["/home/user"] = true
+	fs.Files["/home/user/.keytab"] = []byte("\x05\x02\x00binary")
+	fs.Files["/home/user/.my font.ttf"] = []byte("\x00\x01\x00\x00")
+	fs.Files["/home/user/.zshrc"] = []byte("export EDITOR=vim\n")
+
+	m := NewDotfileManagerWithFS("/config", "/home/user", nil, fs).WithLFS(true)
+	for _, path := range []string{"/home/user/.keytab", "/home/user/.keytab", "/home/user/.my font.ttf", "/home/user/.zshrc"} {
+		if err := m.Add(path); err != nil {
+			t.Fatalf("Add(%s) error = %v", path, err)
+		}
+	}
+
+	want := "/keytab " + lfsAttributes + "\n/my[[:space:]]font.ttf " + lfsAttributes + "\n"
+	if got := string(fs.Files["/config/.gitattributes"]); got != want {
+		t.Errorf(".gitattributes = %q, want %q", got, want)
+	}
+
+	// Without dotfiles.lfs nothing is written
+	fs = NewMemoryFS()
+	fs.Dirs["/config"] = true
+	fs.Files["/home/user/.keytab"] = []byte("\x05\x02\x00binary")
+	m = NewDotfileManagerWithFS("/config", "/home/user", nil, fs)
+	if err := m.Add("/home/user/.keytab"); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if _, ok := fs.Files["/config/.gitattributes"]; ok {
+		t.Error("Add() wrote .gitattributes without dotfiles.lfs")
+	}
+}
@@ -0,0 +1,245 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package dotfiles
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/richhaase/plonk/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+// A dotfile conflicts when the home directory already has it with other
+// content, as on a machine plonk didn't set up. Clone asks what to do about
+// each one. Keeping the local file, as is or merged, is remembered in the
+// state directory, so later applies leave it alone until 'plonk add' stores
+// it in the repository.
+
+// keptLocalFileName is the machine-local list of dotfiles applies leave alone
+const keptLocalFileName = "kept-local.yaml"
+
+// Resolution is what to do about a conflicting dotfile
+type Resolution string
+
+const (
+	ResolveTakeRepo  Resolution = "take-repo"  // deploy the repository's file over the local one
+	ResolveKeepLocal Resolution = "keep-local" // keep the local file, on this and later applies
+	ResolveMerge     Resolution = "merge"      // keep the local file with the repository's changes marked in it
+	ResolveSkip      Resolution = "skip"       // leave the local file alone this time and decide later
+)
+
+// Conflict is a dotfile whose home copy differs from the repository's
+type Conflict struct {
+	Name   string // source name
+	Target string
+	Diff   string // from the repository's copy to the local one
+	Binary bool   // can't be merged
+}
+
+// KeptLocalPath returns the machine-local list of dotfiles kept local
+func KeptLocalPath() string {
+	return filepath.Join(config.GetStateDirectory(), keptLocalFileName)
+}
+
+// LoadKeptLocal reads the source names of dotfiles kept local from path; a
+// missing file has none
+func LoadKeptLocal(path string) (map[string]bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]bool{}, nil
+		}
+		return nil, err
+	}
+	var names []string
+	if err := yaml.Unmarshal(data, &names); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	kept := make(map[string]bool, len(names))
+	for _, name := range names {
+		kept[name] = true
+	}
+	return kept, nil
+}
+
+// SaveKeptLocal writes the source names of dotfiles kept local to path,
+// sorted, removing the file when there are none
+func SaveKeptLocal(path string, kept map[string]bool) error {
+	if len(kept) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	names := make([]string, 0, len(kept))
+	for name := range kept {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	data, err := yaml.Marshal(names)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// loadKeptLocal reads this machine's dotfiles kept local, warning if they
+// can't be read
+func loadKeptLocal() map[string]bool {
+	kept, err := LoadKeptLocal(KeptLocalPath())
+	if err != nil {
+		log.Printf("Warning: ignoring dotfiles kept local: %v", err)
+	}
+	return kept
+}
+
+// WithKeptLocal sets the dotfiles applies leave alone on this machine
+func (m *DotfileManager) WithKeptLocal(kept map[string]bool) *DotfileManager {
+	m.keptLocal = kept
+	return m
+}
+
+// forgetKeptLocal stops keeping name local, once 'plonk add' has stored
+// the local file in the repository
+func (m *DotfileManager) forgetKeptLocal(name string) {
+	if !m.keptLocal[name] {
+		return
+	}
+	delete(m.keptLocal, name)
+	if err := SaveKeptLocal(KeptLocalPath(), m.keptLocal); err != nil {
+		log.Printf("Warning: failed to update %s: %v", KeptLocalPath(), err)
+	}
+}
+
+// Conflicts returns the dotfiles whose home copy has content other than
+// the repository's and that aren't already kept local. Files that differ
+// only in mode are left to apply.
+func (m *DotfileManager) Conflicts() ([]Conflict, error) {
+	statuses, err := m.Reconcile()
+	if err != nil {
+		return nil, err
+	}
+	var conflicts []Conflict
+	for _, s := range statuses {
+		if s.State != SyncStateDrifted || s.Problem != "" || s.KeptLocal {
+			continue
+		}
+		binary, err := m.IsBinary(s.Dotfile)
+		if err != nil {
+			return nil, err
+		}
+		diff, err := m.Diff(s.Dotfile)
+		if err != nil {
+			return nil, err
+		}
+		conflicts = append(conflicts, Conflict{Name: s.Name, Target: s.Target, Diff: diff, Binary: binary})
+	}
+	return conflicts, nil
+}
+
+// ResolveConflicts asks choose about each conflicting dotfile in
+// configDir, carries out the choices that keep the local file and saves
+// them for later applies. It returns the destinations the caller's apply
+// must leave alone: those kept local or skipped. Taking the repository's
+// file is left to that apply.
+func ResolveConflicts(configDir, homeDir string, ignorePatterns []string, choose func(Conflict) Resolution) (map[string]bool, error) {
+	kept, err := LoadKeptLocal(KeptLocalPath())
+	if err != nil {
+		return nil, err
+	}
+	m := NewDotfileManager(configDir, homeDir, ignorePatterns).WithKeptLocal(kept)
+	conflicts, err := m.Conflicts()
+	if err != nil {
+		return nil, err
+	}
+
+	leave := make(map[string]bool)
+	for _, c := range conflicts {
+		resolution := choose(c)
+		if resolution == ResolveMerge && c.Binary {
+			resolution = ResolveKeepLocal
+		}
+		switch resolution {
+		case ResolveMerge:
+			if err := m.mergeIntoTarget(c); err != nil {
+				return nil, err
+			}
+			kept[c.Name] = true
+		case ResolveKeepLocal:
+			kept[c.Name] = true
+		case ResolveSkip:
+		default:
+			continue
+		}
+		leave[normalizePath(c.Target)] = true
+	}
+	return leave, SaveKeptLocal(KeptLocalPath(), kept)
+}
+
+// mergeIntoTarget rewrites the local copy of a conflicting dotfile with the
+// lines that differ from the repository's between conflict markers
+func (m *DotfileManager) mergeIntoTarget(c Conflict) error {
+	repo, err := m.RenderSource(c.Name)
+	if err != nil {
+		return err
+	}
+	local, err := m.fs.ReadFile(c.Target)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", c.Target, err)
+	}
+	info, err := m.fs.Stat(c.Target)
+	if err != nil {
+		return fmt.Errorf("failed to stat %s: %w", c.Target, err)
+	}
+	merged := conflictMarkers(local, repo, "local", "plonk "+filepath.ToSlash(c.Name))
+	if err := m.fs.WriteFile(c.Target, merged, info.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to write %s: %w", c.Target, err)
+	}
+	return nil
+}
+
+// conflictMarkers puts the lines of local and repo that differ between git
+// style conflict markers, keeping the lines they start and end with in
+// common outside them
+func conflictMarkers(local, repo []byte, localLabel, repoLabel string) []byte {
+	localLines := strings.SplitAfter(string(local), "\n")
+	repoLines := strings.SplitAfter(string(repo), "\n")
+
+	prefix := 0
+	for prefix < len(localLines) && prefix < len(repoLines) && localLines[prefix] == repoLines[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(localLines)-prefix && suffix < len(repoLines)-prefix &&
+		localLines[len(localLines)-1-suffix] == repoLines[len(repoLines)-1-suffix] {
+		suffix++
+	}
+
+	var b bytes.Buffer
+	writeLines := func(lines []string) {
+		for _, line := range lines {
+			b.WriteString(line)
+			if !strings.HasSuffix(line, "\n") && line != "" {
+				b.WriteString("\n")
+			}
+		}
+	}
+	writeLines(localLines[:prefix])
+	b.WriteString("<<<<<<< " + localLabel + "\n")
+	writeLines(localLines[prefix : len(localLines)-suffix])
+	b.WriteString("=======\n")
+	writeLines(repoLines[prefix : len(repoLines)-suffix])
+	b.WriteString(">>>>>>> " + repoLabel + "\n")
+	writeLines(localLines[len(localLines)-suffix:])
+	return b.Bytes()
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package dotfiles
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/richhaase/plonk/internal/config"
+)
+
+func TestConflictMarkers(t *testing.T) {
+	local := "export PATH=$HOME/bin:$PATH\nalias ll='ls -l'\nsource ~/.work\n"
+	repo := "export PATH=$HOME/bin:$PATH\nalias ll='ls -la'\nsource ~/.work\n"
+
+	got := string(conflictMarkers([]byte(local), []byte(repo), "local", "plonk zshrc"))
+	want := "export PATH=$HOME/bin:$PATH\n" +
+		"<<<<<<< local\nalias ll='ls -l'\n=======\nalias ll='ls -la'\n>>>>>>> plonk zshrc\n" +
+		"source ~/.work\n"
+	if got != want {
+		t.Errorf("conflictMarkers() = %q, want %q", got, want)
+	}
+
+	// A last line without a newline still ends before the marker
+	got = string(conflictMarkers([]byte("a\nb"), []byte("a\nc\n"), "local", "repo"))
+	if want := "a\n<<<<<<< local\nb\n=======\nc\n>>>>>>> repo\n"; got != want {
+		t.Errorf("conflictMarkers() = %q, want %q", got, want)
+	}
+}
+
+func TestResolveConflicts(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	configDir, homeDir := t.TempDir(), t.TempDir()
+	for name, content := range map[string]string{"zshrc": "repo zshrc\n", "vimrc": "repo vimrc\n", "gitconfig": "repo git\n", "tmux.conf": "repo tmux\n"} {
+		if err := os.WriteFile(filepath.Join(configDir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(homeDir, "."+name), []byte("local "+name+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	choices := map[string]Resolution{"zshrc": ResolveTakeRepo, "vimrc": ResolveKeepLocal, "gitconfig": ResolveMerge, "tmux.conf": ResolveSkip}
+	leave, err := ResolveConflicts(configDir, homeDir, nil, func(c Conflict) Resolution {
+		if c.Diff == "" {
+			t.Errorf("conflict %s has no diff", c.Name)
+		}
+		return choices[c.Name]
+	})
+	if err != nil {
+		t.Fatalf("ResolveConflicts() error = %v", err)
+	}
+	for _, name := range []string{".vimrc", ".gitconfig", ".tmux.conf"} {
+		if !leave[filepath.Join(homeDir, name)] {
+			t.Errorf("ResolveConflicts() should leave %s alone, got %v", name, leave)
+		}
+	}
+	if len(leave) != 3 {
+		t.Errorf("ResolveConflicts() left %d files alone, want 3", len(leave))
+	}
+
+	merged, _ := os.ReadFile(filepath.Join(homeDir, ".gitconfig"))
+	if want := "<<<<<<< local\nlocal gitconfig\n=======\nrepo git\n>>>>>>> plonk gitconfig\n"; string(merged) != want {
+		t.Errorf("merged .gitconfig = %q, want %q", merged, want)
+	}
+
+	kept, err := LoadKeptLocal(KeptLocalPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(kept) != 2 || !kept["vimrc"] || !kept["gitconfig"] {
+		t.Errorf("kept local = %v, want vimrc and gitconfig", kept)
+	}
+
+	// Later applies deploy everything but the files kept local
+	cfg := &config.Config{}
+	result, err := Apply(context.Background(), configDir, homeDir, cfg, false)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if result.Summary.Updated != 2 || result.Summary.Unchanged != 2 {
+		t.Errorf("Apply() summary = %+v, want 2 updated and 2 kept", result.Summary)
+	}
+	if content, _ := os.ReadFile(filepath.Join(homeDir, ".vimrc")); string(content) != "local vimrc\n" {
+		t.Errorf(".vimrc = %q, want the local file kept", content)
+	}
+
+	// Adding the local file stores it and stops keeping it local
+	m := NewDotfileManager(configDir, homeDir, nil)
+	if err := m.Add(filepath.Join(homeDir, ".vimrc")); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	kept, _ = LoadKeptLocal(KeptLocalPath())
+	if kept["vimrc"] || !kept["gitconfig"] {
+		t.Errorf("kept local after add = %v, want only gitconfig", kept)
+	}
+}
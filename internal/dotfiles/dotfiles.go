@@ -11,8 +11,10 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"runtime"
 	"strings"
 
+	"github.com/richhaase/plonk/internal/config"
 	"github.com/richhaase/plonk/internal/ignore"
 )
 
@@ -47,7 +49,7 @@ func renderTemplate(content []byte, lookupEnv func(string) (string, bool)) ([]by
 	}
 
 	if len(missing) > 0 {
-		return nil, fmt.Errorf("missing environment variables: %s", strings.Join(missing, ", "))
+		return nil, fmt.Errorf("missing template variables: %s", strings.Join(missing, ", "))
 	}
 
 	result := templateVarPattern.ReplaceAllFunc(content, func(match []byte) []byte {
@@ -61,16 +63,28 @@ func renderTemplate(content []byte, lookupEnv func(string) (string, bool)) ([]by
 
 // DotfileManager manages dotfiles in a single config directory
 type DotfileManager struct {
-	configDir string     // $PLONK_DIR
-	homeDir   string     // $HOME
-	fs        FileSystem // file operations
-	matcher   *ignore.Matcher
-	lookupEnv func(string) (string, bool)
+	configDir    string     // $PLONK_DIR
+	homeDir      string     // $HOME
+	fs           FileSystem // file operations
+	matcher      *ignore.Matcher
+	lookupEnv    func(string) (string, bool)
+	layout       Layout
+	lfs          bool // track binary and large files with git-lfs on add
+	allowSecrets bool // store files with probable secrets on add
+	templates    map[string]config.TemplateVariables
+	answers      map[string]string // this machine's answers to template prompts
+	keptLocal    map[string]bool   // source names of dotfiles applies leave alone here
 }
 
-// NewDotfileManager creates a manager using the real filesystem
+// NewDotfileManager creates a manager using the real filesystem and the
+// dotfiles settings in configDir
 func NewDotfileManager(configDir, homeDir string, ignorePatterns []string) *DotfileManager {
-	return NewDotfileManagerWithFS(configDir, homeDir, ignorePatterns, OSFileSystem{})
+	settings := loadSettings(configDir)
+	return NewDotfileManagerWithFS(configDir, homeDir, ignorePatterns, OSFileSystem{}).
+		WithLayout(Layout{Kind: settings.Layout, Map: settings.Map}).
+		WithLFS(settings.LFS).
+		WithTemplates(settings.Templates, loadAnswers()).
+		WithKeptLocal(loadKeptLocal())
 }
 
 // NewDotfileManagerWithFS creates a manager with a custom filesystem (for testing)
@@ -84,6 +98,18 @@ func NewDotfileManagerWithFS(configDir, homeDir string, ignorePatterns []string,
 	}
 }
 
+// WithLayout sets how dotfiles are arranged in the config directory
+func (m *DotfileManager) WithLayout(layout Layout) *DotfileManager {
+	m.layout = layout.resolve(runtime.GOOS, m.homeDir, m.lookupEnv)
+	return m
+}
+
+// WithLFS sets whether plonk add tracks binary and large files with git-lfs
+func (m *DotfileManager) WithLFS(lfs bool) *DotfileManager {
+	m.lfs = lfs
+	return m
+}
+
 // List returns all dotfiles in the config directory
 func (m *DotfileManager) List() ([]Dotfile, error) {
 	var dotfiles []Dotfile
@@ -106,10 +132,14 @@ func (m *DotfileManager) List() ([]Dotfile, error) {
 			return nil // Continue into non-ignored directory
 		}
 
+		target := m.toTarget(relPath)
+		if target == "" {
+			return nil // Not a dotfile in this layout
+		}
 		dotfiles = append(dotfiles, Dotfile{
 			Name:   relPath,
 			Source: sourcePath,
-			Target: m.toTarget(relPath),
+			Target: target,
 		})
 		return nil
 	})
@@ -157,6 +187,11 @@ func (m *DotfileManager) Add(targetPath string) error {
 		return err
 	}
 
+	// The layout must have a place for it
+	if _, err := m.SourceName(absTarget); err != nil {
+		return err
+	}
+
 	// Verify source exists
 	info, err := m.fs.Stat(absTarget)
 	if err != nil {
@@ -172,7 +207,10 @@ func (m *DotfileManager) Add(targetPath string) error {
 
 // addFile adds a single file
 func (m *DotfileManager) addFile(absTarget string) error {
-	relPath := m.toSource(absTarget)
+	relPath, err := m.SourceName(absTarget)
+	if err != nil {
+		return err
+	}
 	destPath := filepath.Join(m.configDir, relPath)
 
 	// Get source file info to preserve permissions
@@ -182,22 +220,34 @@ func (m *DotfileManager) addFile(absTarget string) error {
 	}
 	mode := info.Mode().Perm()
 
-	// Read source
-	content, err := m.fs.ReadFile(absTarget)
+	unencrypted, err := m.isUnencryptedKey(absTarget)
 	if err != nil {
 		return fmt.Errorf("failed to read %s: %w", absTarget, err)
 	}
+	if unencrypted {
+		return errUnencryptedKey(absTarget)
+	}
+	if err := m.checkSecrets(absTarget); err != nil {
+		return err
+	}
 
 	// Create parent directories
 	if err := m.fs.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
 		return fmt.Errorf("failed to create directory: %w", err)
 	}
 
-	// Write to config dir, preserving original permissions
-	if err := m.fs.WriteFile(destPath, content, mode); err != nil {
+	// Copy to config dir, preserving original permissions
+	if err := m.copyFile(absTarget, destPath, mode); err != nil {
 		return fmt.Errorf("failed to write %s: %w", destPath, err)
 	}
 
+	if m.lfs {
+		if err := m.trackWithLFS(relPath); err != nil {
+			return fmt.Errorf("failed to track %s with git-lfs: %w", relPath, err)
+		}
+	}
+
+	m.forgetKeptLocal(relPath)
 	return nil
 }
 
@@ -222,6 +272,16 @@ func (m *DotfileManager) addDirectory(absTarget string) error {
 			return nil // Continue into non-ignored directory
 		}
 
+		// Leave private keys behind rather than failing the whole directory
+		if unencrypted, err := m.isUnencryptedKey(path); err == nil && unencrypted {
+			log.Printf("Warning: skipping %s: %v", path, errUnencryptedKey(path))
+			return nil
+		}
+		if err := m.checkSecrets(path); err != nil {
+			log.Printf("Warning: skipping %s: %v", path, err)
+			return nil
+		}
+
 		return m.addFile(path)
 	})
 }
@@ -251,6 +311,7 @@ func (m *DotfileManager) Remove(name string) error {
 		}
 	}
 
+	m.forgetDeployed(name)
 	return nil
 }
 
@@ -285,6 +346,9 @@ func (m *DotfileManager) ValidateRemove(name string) error {
 func (m *DotfileManager) Deploy(name string) error {
 	sourcePath := filepath.Join(m.configDir, name)
 	targetPath := m.toTarget(name)
+	if targetPath == "" {
+		return fmt.Errorf("%s is not a dotfile in the %s layout", name, m.layout.kind())
+	}
 
 	// Get source file info to preserve permissions
 	info, err := m.fs.Stat(sourcePath)
@@ -293,29 +357,41 @@ func (m *DotfileManager) Deploy(name string) error {
 	}
 	mode := info.Mode().Perm()
 
-	// Read source
-	content, err := m.fs.ReadFile(sourcePath)
-	if err != nil {
-		return fmt.Errorf("failed to read source: %w", err)
-	}
-
-	// Render template if needed
+	// Templates are rendered in memory; other files are streamed, so a
+	// large one is never read in whole
+	var content []byte
 	if isTemplate(name) {
-		content, err = renderTemplate(content, m.lookupEnv)
+		content, err = m.fs.ReadFile(sourcePath)
+		if err != nil {
+			return fmt.Errorf("failed to read source: %w", err)
+		}
+		content, err = renderTemplate(content, m.templateLookup(name))
 		if err != nil {
 			return fmt.Errorf("failed to render template %s: %w", name, err)
 		}
+	} else if err := m.checkLFSPointer(sourcePath); err != nil {
+		return err
 	}
 
-	// Create parent directories
-	if err := m.fs.MkdirAll(filepath.Dir(targetPath), 0755); err != nil {
+	// Create parent directories; ssh ignores files others can read
+	if m.sshPath(targetPath) {
+		mode = sshTargetMode(mode)
+		if err := m.prepareSSHDirs(targetPath); err != nil {
+			return err
+		}
+	} else if err := m.fs.MkdirAll(filepath.Dir(targetPath), 0755); err != nil {
 		return fmt.Errorf("failed to create directory: %w", err)
 	}
 
 	// Atomic write: write to temp file, then rename
 	// Use restrictive permissions for temp file, final permissions set after rename
 	tmpPath := targetPath + ".plonk.tmp"
-	if err := m.fs.WriteFile(tmpPath, content, 0600); err != nil {
+	if content != nil {
+		err = m.fs.WriteFile(tmpPath, content, 0600)
+	} else {
+		err = m.copyFile(sourcePath, tmpPath, 0600)
+	}
+	if err != nil {
 		return fmt.Errorf("failed to write temp file: %w", err)
 	}
 
@@ -335,8 +411,24 @@ func (m *DotfileManager) Deploy(name string) error {
 	return nil
 }
 
-// IsDrifted returns true if the target differs from source
+// IsDrifted returns true if the target differs from source. Files other
+// than templates are compared by size and hash, without reading them into
+// memory.
 func (m *DotfileManager) IsDrifted(d Dotfile) (bool, error) {
+	if !isTemplate(d.Name) {
+		if _, err := m.fs.Stat(d.Source); err != nil {
+			return false, fmt.Errorf("failed to read source: %w", err)
+		}
+		same, err := m.sameFile(d.Source, d.Target)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return false, nil // missing, not drifted
+			}
+			return false, fmt.Errorf("failed to compare with target: %w", err)
+		}
+		return !same, nil
+	}
+
 	sourceContent, err := m.fs.ReadFile(d.Source)
 	if err != nil {
 		return false, fmt.Errorf("failed to read source: %w", err)
@@ -344,7 +436,7 @@ func (m *DotfileManager) IsDrifted(d Dotfile) (bool, error) {
 
 	// Render template if needed
 	if isTemplate(d.Name) {
-		sourceContent, err = renderTemplate(sourceContent, m.lookupEnv)
+		sourceContent, err = renderTemplate(sourceContent, m.templateLookup(d.Name))
 		if err != nil {
 			return false, fmt.Errorf("failed to render template %s: %w", d.Name, err)
 		}
@@ -361,8 +453,17 @@ func (m *DotfileManager) IsDrifted(d Dotfile) (bool, error) {
 	return !bytes.Equal(sourceContent, targetContent), nil
 }
 
-// Diff returns the difference between source and target
+// Diff returns the difference between source and target. Binary files
+// are described by size and hash instead of diffed.
 func (m *DotfileManager) Diff(d Dotfile) (string, error) {
+	binary, err := m.IsBinary(d)
+	if err != nil {
+		return "", err
+	}
+	if binary {
+		return m.binaryDiff(d)
+	}
+
 	sourceContent, err := m.fs.ReadFile(d.Source)
 	if err != nil {
 		return "", fmt.Errorf("failed to read source: %w", err)
@@ -370,7 +471,7 @@ func (m *DotfileManager) Diff(d Dotfile) (string, error) {
 
 	// Render template if needed
 	if isTemplate(d.Name) {
-		sourceContent, err = renderTemplate(sourceContent, m.lookupEnv)
+		sourceContent, err = renderTemplate(sourceContent, m.templateLookup(d.Name))
 		if err != nil {
 			return "", fmt.Errorf("failed to render template %s: %w", d.Name, err)
 		}
@@ -425,40 +526,48 @@ func (m *DotfileManager) Diff(d Dotfile) (string, error) {
 }
 
 // toTarget converts a relative source path to an absolute target path
+// through the layout, or "" if the source is not a dotfile in it.
+// In the flat layout:
 // e.g., "zshrc" -> "/home/user/.zshrc"
 // e.g., "config/nvim/init.lua" -> "/home/user/.config/nvim/init.lua"
 func (m *DotfileManager) toTarget(relPath string) string {
-	// Strip .tmpl extension for template files
-	if isTemplate(relPath) {
-		relPath = strings.TrimSuffix(relPath, templateExtension)
+	target, ok := m.layout.target(relPath)
+	if !ok {
+		return ""
 	}
-
-	// Add dot prefix to the first path component
-	parts := strings.SplitN(relPath, string(os.PathSeparator), 2)
-	parts[0] = "." + parts[0]
-	dotPath := strings.Join(parts, string(os.PathSeparator))
-	return filepath.Join(m.homeDir, dotPath)
+	return filepath.Join(m.homeDir, target)
 }
 
 // toSource converts an absolute target path to a relative source path
+// through the layout, or "" if the layout has no place for it.
+// In the flat layout:
 // e.g., "/home/user/.zshrc" -> "zshrc"
 // e.g., "/home/user/.config/nvim/init.lua" -> "config/nvim/init.lua"
 func (m *DotfileManager) toSource(absTarget string) string {
-	// Remove home prefix
-	relPath, err := filepath.Rel(m.homeDir, absTarget)
+	source, err := m.SourceName(absTarget)
 	if err != nil {
-		// This error is unreachable in normal operation since absTarget comes from
-		// walking m.homeDir. Log for debugging but continue with fallback.
-		log.Printf("Warning: failed to compute relative path from %s to %s: %v", m.homeDir, absTarget, err)
-		return absTarget
+		return ""
 	}
+	return source
+}
 
-	// Remove dot prefix from the first component
-	parts := strings.SplitN(relPath, string(os.PathSeparator), 2)
-	if len(parts[0]) > 0 && parts[0][0] == '.' {
-		parts[0] = parts[0][1:]
+// SourceName returns the path in the config directory, relative to it,
+// that holds the dotfile deployed at absTarget
+func (m *DotfileManager) SourceName(absTarget string) (string, error) {
+	relPath, err := filepath.Rel(m.homeDir, absTarget)
+	if err != nil {
+		return "", fmt.Errorf("failed to compute relative path from %s to %s: %w", m.homeDir, absTarget, err)
+	}
+	if relPath == "." || relEscapes(relPath) {
+		return "", fmt.Errorf("path %s is not a dotfile under home directory %s", absTarget, m.homeDir)
 	}
-	return strings.Join(parts, string(os.PathSeparator))
+	return m.layout.source(relPath)
+}
+
+// TargetPath returns where the source name in the config directory is
+// deployed, or "" if it is not a dotfile in the configured layout
+func (m *DotfileManager) TargetPath(name string) string {
+	return m.toTarget(name)
 }
 
 // validatePathUnderHome ensures the path is under $HOME to prevent path traversal attacks
@@ -517,8 +626,11 @@ func (m *DotfileManager) rejectPathUnderConfigDir(absPath string) error {
 
 // requireDotPrefix ensures the first path component under $HOME starts with a dot.
 // Plonk manages dotfiles; non-dot paths would be deployed to the wrong location
-// because toTarget always adds a dot prefix.
+// because the flat layout always adds a dot prefix.
 func (m *DotfileManager) requireDotPrefix(absPath string) error {
+	if m.layout.kind() != LayoutFlat {
+		return nil
+	}
 	rel, err := filepath.Rel(m.homeDir, absPath)
 	if err != nil {
 		return fmt.Errorf("cannot compute relative path: %w", err)
@@ -643,7 +755,7 @@ func (m *DotfileManager) RenderSource(name string) ([]byte, error) {
 	}
 
 	if isTemplate(name) {
-		content, err = renderTemplate(content, m.lookupEnv)
+		content, err = renderTemplate(content, m.templateLookup(name))
 		if err != nil {
 			return nil, fmt.Errorf("failed to render template %s: %w", name, err)
 		}
@@ -675,10 +787,25 @@ func (m *DotfileManager) ValidateAdd(targetPath string) error {
 		return err
 	}
 
+	// The layout must have a place for it
+	if _, err := m.SourceName(absTarget); err != nil {
+		return err
+	}
+
 	// Verify target exists
-	if _, err := m.fs.Stat(absTarget); err != nil {
+	info, err := m.fs.Stat(absTarget)
+	if err != nil {
 		return fmt.Errorf("%s does not exist", absTarget)
 	}
 
+	if !info.IsDir() {
+		if unencrypted, err := m.isUnencryptedKey(absTarget); err == nil && unencrypted {
+			return errUnencryptedKey(absTarget)
+		}
+		if err := m.checkSecrets(absTarget); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
@@ -4,6 +4,8 @@
 package dotfiles
 
 import (
+	"bytes"
+	"io"
 	"io/fs"
 	"os"
 	"time"
@@ -20,6 +22,10 @@ type FileSystem interface {
 	RemoveAll(path string) error
 	Rename(old, new string) error
 	Chmod(path string, mode os.FileMode) error
+	// Open and Create stream file contents, for files too large to read
+	// into memory at once
+	Open(path string) (io.ReadCloser, error)
+	Create(path string, perm os.FileMode) (io.WriteCloser, error)
 }
 
 // OSFileSystem implements FileSystem using the os package
@@ -61,6 +67,14 @@ func (OSFileSystem) Chmod(path string, mode os.FileMode) error {
 	return os.Chmod(path, mode)
 }
 
+func (OSFileSystem) Open(path string) (io.ReadCloser, error) {
+	return os.Open(path)
+}
+
+func (OSFileSystem) Create(path string, perm os.FileMode) (io.WriteCloser, error) {
+	return os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+}
+
 // MemoryFS implements FileSystem for testing
 type MemoryFS struct {
 	Files map[string][]byte
@@ -88,8 +102,8 @@ func (m *MemoryFS) WriteFile(path string, data []byte, _ os.FileMode) error {
 }
 
 func (m *MemoryFS) Stat(path string) (os.FileInfo, error) {
-	if _, ok := m.Files[path]; ok {
-		return &memFileInfo{name: path, isDir: false}, nil
+	if data, ok := m.Files[path]; ok {
+		return &memFileInfo{name: path, isDir: false, size: int64(len(data))}, nil
 	}
 	if m.Dirs[path] {
 		return &memFileInfo{name: path, isDir: true}, nil
@@ -196,14 +210,40 @@ func (m *MemoryFS) Chmod(_ string, _ os.FileMode) error {
 	return nil
 }
 
+func (m *MemoryFS) Open(path string) (io.ReadCloser, error) {
+	data, ok := m.Files[path]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (m *MemoryFS) Create(path string, _ os.FileMode) (io.WriteCloser, error) {
+	m.Files[path] = nil
+	return &memWriter{fs: m, path: path}, nil
+}
+
+// memWriter stores what was written to a MemoryFS file when it is closed
+type memWriter struct {
+	bytes.Buffer
+	fs   *MemoryFS
+	path string
+}
+
+func (w *memWriter) Close() error {
+	w.fs.Files[w.path] = w.Bytes()
+	return nil
+}
+
 // memFileInfo implements os.FileInfo for MemoryFS
 type memFileInfo struct {
 	name  string
 	isDir bool
+	size  int64
 }
 
 func (m *memFileInfo) Name() string       { return m.name }
-func (m *memFileInfo) Size() int64        { return 0 }
+func (m *memFileInfo) Size() int64        { return m.size }
 func (m *memFileInfo) Mode() fs.FileMode  { return 0644 }
 func (m *memFileInfo) ModTime() time.Time { return time.Time{} }
 func (m *memFileInfo) IsDir() bool        { return m.isDir }
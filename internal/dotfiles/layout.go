@@ -0,0 +1,229 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package dotfiles
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/richhaase/plonk/internal/config"
+)
+
+// Dotfile layouts, set with dotfiles.layout in plonk.yaml
+const (
+	LayoutFlat   = "flat"          // zshrc -> ~/.zshrc: leading dot of the first component dropped
+	LayoutHome   = "mirrored-home" // home/.zshrc -> ~/.zshrc: a copy of $HOME under home/
+	LayoutCustom = "custom"        // dotfiles.map names each source and its target
+)
+
+// Layouts lists the supported layouts
+var Layouts = []string{LayoutFlat, LayoutHome, LayoutCustom}
+
+// homeLayoutDir holds the dotfiles of the mirrored-home layout
+const homeLayoutDir = "home"
+
+// Layout decides where each file in the plonk directory is deployed
+type Layout struct {
+	Kind string
+	// Map holds the custom layout: source path in the plonk directory ->
+	// target path relative to $HOME ("~/" is optional, {{xdg_config}} and
+	// friends resolve per OS). A directory maps every file under it.
+	Map map[string]string
+}
+
+// LoadLayout reads the layout configured in configDir. Like the rest of
+// plonk, a config that can't be read means the default (flat) layout.
+func LoadLayout(configDir string) Layout {
+	settings := loadSettings(configDir)
+	return Layout{Kind: settings.Layout, Map: settings.Map}
+}
+
+// loadSettings reads the dotfiles settings of configDir, or the defaults
+// with a warning
+func loadSettings(configDir string) config.Dotfiles {
+	settings, err := config.LoadDotfiles(configDir)
+	if err != nil {
+		log.Printf("Warning: using the default dotfile settings: %v", err)
+	}
+	return settings
+}
+
+func (l Layout) kind() string {
+	if l.Kind == "" {
+		return LayoutFlat
+	}
+	return l.Kind
+}
+
+// target returns the path relative to $HOME that the source file rel
+// deploys to, or false if rel is not a dotfile in this layout
+func (l Layout) target(rel string) (string, bool) {
+	name := strings.TrimSuffix(rel, templateExtension)
+
+	switch l.kind() {
+	case LayoutHome:
+		inner, ok := strings.CutPrefix(name, homeLayoutDir+string(os.PathSeparator))
+		return inner, ok && inner != ""
+
+	case LayoutCustom:
+		// A mapped directory applies to the rendered name of a template;
+		// a file mapping may name the template itself
+		for _, key := range []string{name, rel} {
+			if target, ok := lookupPrefix(l.Map, key, false); ok && !relEscapes(target) && target != "." {
+				return target, true
+			}
+		}
+		return "", false
+	}
+
+	parts := strings.SplitN(name, string(os.PathSeparator), 2)
+	parts[0] = "." + parts[0]
+	return strings.Join(parts, string(os.PathSeparator)), true
+}
+
+// source returns the source path, relative to the plonk directory, for
+// the path homeRel under $HOME
+func (l Layout) source(homeRel string) (string, error) {
+	switch l.kind() {
+	case LayoutHome:
+		return filepath.Join(homeLayoutDir, homeRel), nil
+
+	case LayoutCustom:
+		if source, ok := lookupPrefix(l.Map, homeRel, true); ok {
+			return source, nil
+		}
+		return "", fmt.Errorf("~/%s is not in dotfiles.map in plonk.yaml; map it to a source path first", filepath.ToSlash(homeRel))
+	}
+
+	parts := strings.SplitN(homeRel, string(os.PathSeparator), 2)
+	if len(parts[0]) > 0 && parts[0][0] == '.' {
+		parts[0] = parts[0][1:]
+	}
+	return strings.Join(parts, string(os.PathSeparator)), nil
+}
+
+// lookupPrefix maps path through table by its longest matching entry,
+// which is the path itself or a directory above it. reverse matches
+// against the targets and returns sources.
+func lookupPrefix(table map[string]string, path string, reverse bool) (string, bool) {
+	best, found := -1, ""
+	for source, target := range table {
+		from, to := cleanMapPath(source), cleanMapPath(target)
+		if reverse {
+			from, to = to, from
+		}
+		if len(from) <= best {
+			continue
+		}
+		switch {
+		case path == from:
+			best, found = len(from), to
+		case strings.HasPrefix(path, from+string(os.PathSeparator)):
+			best, found = len(from), filepath.Join(to, path[len(from)+1:])
+		}
+	}
+	return found, best >= 0
+}
+
+// cleanMapPath normalizes a dotfiles.map entry to a relative OS path
+func cleanMapPath(p string) string {
+	p = strings.TrimPrefix(p, "~/")
+	return filepath.Clean(filepath.FromSlash(p))
+}
+
+// LayoutMove is one file moved by MigrateLayout
+type LayoutMove struct {
+	From   string // source path before, relative to the plonk directory
+	To     string // source path after
+	Target string // deployed path, which does not change
+}
+
+// MigrateLayout rearranges the dotfiles in configDir from the configured
+// layout into to, and records to in plonk.yaml. Converting to the custom
+// layout moves nothing: it writes a map of where every file is now. With
+// dryRun it only reports the moves.
+func MigrateLayout(configDir, homeDir string, ignorePatterns []string, to Layout, dryRun bool) ([]LayoutMove, error) {
+	m := NewDotfileManager(configDir, homeDir, ignorePatterns)
+	files, err := m.List()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Name < files[j].Name })
+
+	if to.kind() == LayoutCustom && len(to.Map) == 0 {
+		to.Map = make(map[string]string, len(files))
+		for _, f := range files {
+			rel, _ := filepath.Rel(homeDir, f.Target)
+			to.Map[filepath.ToSlash(f.Name)] = "~/" + filepath.ToSlash(rel)
+		}
+	}
+
+	saved := to.Map
+	to = to.resolve(runtime.GOOS, homeDir, os.LookupEnv)
+
+	var moves []LayoutMove
+	for _, f := range files {
+		rel, err := filepath.Rel(homeDir, f.Target)
+		if err != nil {
+			return nil, err
+		}
+		source, err := to.source(rel)
+		if err != nil {
+			return nil, err
+		}
+		if isTemplate(f.Name) && !isTemplate(source) {
+			source += templateExtension
+		}
+		if target, ok := to.target(source); !ok || target != rel {
+			return nil, fmt.Errorf("%s can't be expressed in the %s layout", f.Name, to.kind())
+		}
+		if source != f.Name {
+			moves = append(moves, LayoutMove{From: f.Name, To: source, Target: f.Target})
+		}
+	}
+	if dryRun {
+		return moves, nil
+	}
+
+	for _, move := range moves {
+		if _, err := os.Stat(filepath.Join(configDir, move.To)); err == nil {
+			return nil, fmt.Errorf("cannot move %s: %s already exists", move.From, move.To)
+		}
+	}
+	for _, move := range moves {
+		from, dest := filepath.Join(configDir, move.From), filepath.Join(configDir, move.To)
+		if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+			return nil, err
+		}
+		if err := os.Rename(from, dest); err != nil {
+			return nil, fmt.Errorf("failed to move %s: %w", move.From, err)
+		}
+		removeEmptyParents(configDir, filepath.Dir(from))
+	}
+
+	var mapping map[string]string
+	if to.kind() == LayoutCustom {
+		mapping = saved
+	}
+	if err := config.SetDotfilesLayout(configDir, to.kind(), mapping); err != nil {
+		return moves, err
+	}
+	return moves, nil
+}
+
+// removeEmptyParents removes dir and its parents up to root while they
+// are empty
+func removeEmptyParents(root, dir string) {
+	for dir != root && strings.HasPrefix(dir, root+string(os.PathSeparator)) {
+		if err := os.Remove(dir); err != nil {
+			return
+		}
+		dir = filepath.Dir(dir)
+	}
+}
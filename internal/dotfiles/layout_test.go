@@ -0,0 +1,183 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package dotfiles
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLayout_TargetAndSource(t *testing.T) {
+	custom := Layout{Kind: LayoutCustom, Map: map[string]string{
+		"shell/zshrc": "~/.zshrc",
+		"nvim":        "~/.config/nvim",
+		"git.tmpl":    "~/.gitconfig",
+	}}
+
+	tests := []struct {
+		name       string
+		layout     Layout
+		source     string
+		target     string
+		sourceBack string // source for target, when it differs from source
+	}{
+		{"flat", Layout{}, "zshrc", ".zshrc", ""},
+		{"flat nested", Layout{Kind: LayoutFlat}, "config/nvim/init.lua", ".config/nvim/init.lua", ""},
+		{"flat template", Layout{}, "gitconfig.tmpl", ".gitconfig", "gitconfig"},
+		{"home", Layout{Kind: LayoutHome}, "home/.zshrc", ".zshrc", ""},
+		{"home nested", Layout{Kind: LayoutHome}, "home/.config/nvim/init.lua", ".config/nvim/init.lua", ""},
+		{"custom file", custom, "shell/zshrc", ".zshrc", ""},
+		{"custom directory", custom, "nvim/lua/plugins.lua", ".config/nvim/lua/plugins.lua", ""},
+		{"custom template", custom, "git.tmpl", ".gitconfig", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target, ok := tt.layout.target(tt.source)
+			if !ok || target != tt.target {
+				t.Errorf("target(%q) = %q, %v; want %q", tt.source, target, ok, tt.target)
+			}
+
+			want := tt.source
+			if tt.sourceBack != "" {
+				want = tt.sourceBack
+			}
+			source, err := tt.layout.source(tt.target)
+			if err != nil || source != want {
+				t.Errorf("source(%q) = %q, %v; want %q", tt.target, source, err, want)
+			}
+		})
+	}
+}
+
+func TestLayout_Unmapped(t *testing.T) {
+	if _, ok := (Layout{Kind: LayoutHome}).target("README.md"); ok {
+		t.Error("mirrored-home target() accepted a file outside home/")
+	}
+
+	custom := Layout{Kind: LayoutCustom, Map: map[string]string{"zshrc": "~/.zshrc", "escape": "../outside"}}
+	if _, ok := custom.target("vimrc"); ok {
+		t.Error("custom target() accepted an unmapped file")
+	}
+	if _, ok := custom.target("escape"); ok {
+		t.Error("custom target() accepted a target outside home")
+	}
+	if _, err := custom.source(".vimrc"); err == nil {
+		t.Error("custom source() accepted an unmapped target")
+	}
+}
+
+func TestDotfileManager_ListMirroredHome(t *testing.T) {
+	fs := NewMemoryFS()
+	fs.Dirs["/config"] = true
+	fs.Dirs["/config/home"] = true
+	fs.Dirs["/config/home/.config"] = true
+	fs.Dirs["/config/home/.config/nvim"] = true
+	fs.Files["/config/home/.zshrc"] = []byte("# zsh config")
+	fs.Files["/config/home/.config/nvim/init.lua"] = []byte("-- nvim")
+	fs.Files["/config/README.md"] = []byte("# notes")
+
+	m := NewDotfileManagerWithFS("/config", "/home/user", nil, fs).WithLayout(Layout{Kind: LayoutHome})
+
+	dotfiles, err := m.List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	targets := make(map[string]string)
+	for _, d := range dotfiles {
+		targets[d.Name] = d.Target
+	}
+	want := map[string]string{
+		"home/.zshrc":                "/home/user/.zshrc",
+		"home/.config/nvim/init.lua": "/home/user/.config/nvim/init.lua",
+	}
+	if len(targets) != len(want) {
+		t.Errorf("List() = %v, want %v", targets, want)
+	}
+	for name, target := range want {
+		if targets[name] != target {
+			t.Errorf("List() target of %s = %q, want %q", name, targets[name], target)
+		}
+	}
+}
+
+func TestMigrateLayout_RoundTrip(t *testing.T) {
+	configDir := t.TempDir()
+	homeDir := t.TempDir()
+
+	files := map[string]string{
+		"zshrc":                "# zsh",
+		"config/nvim/init.lua": "-- nvim",
+		"gitconfig.tmpl":       "[user]",
+	}
+	for name, content := range files {
+		path := filepath.Join(configDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Dry run changes nothing
+	moves, err := MigrateLayout(configDir, homeDir, nil, Layout{Kind: LayoutHome}, true)
+	if err != nil {
+		t.Fatalf("MigrateLayout(dry run) error = %v", err)
+	}
+	if len(moves) != 3 {
+		t.Errorf("MigrateLayout(dry run) = %d moves, want 3", len(moves))
+	}
+	if _, err := os.Stat(filepath.Join(configDir, "zshrc")); err != nil {
+		t.Errorf("dry run moved zshrc: %v", err)
+	}
+
+	if _, err := MigrateLayout(configDir, homeDir, nil, Layout{Kind: LayoutHome}, false); err != nil {
+		t.Fatalf("MigrateLayout(mirrored-home) error = %v", err)
+	}
+	for _, name := range []string{"home/.zshrc", "home/.config/nvim/init.lua", "home/.gitconfig.tmpl"} {
+		if _, err := os.Stat(filepath.Join(configDir, name)); err != nil {
+			t.Errorf("after converting to mirrored-home: %v", err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(configDir, "config")); !os.IsNotExist(err) {
+		t.Error("empty config/ directory was left behind")
+	}
+	if got := LoadLayout(configDir).Kind; got != LayoutHome {
+		t.Errorf("layout after conversion = %q, want %q", got, LayoutHome)
+	}
+
+	// Converting to custom records where the files are without moving them
+	moves, err = MigrateLayout(configDir, homeDir, nil, Layout{Kind: LayoutCustom}, false)
+	if err != nil {
+		t.Fatalf("MigrateLayout(custom) error = %v", err)
+	}
+	if len(moves) != 0 {
+		t.Errorf("MigrateLayout(custom) moved %v", moves)
+	}
+	layout := LoadLayout(configDir)
+	if layout.Kind != LayoutCustom || layout.Map["home/.zshrc"] != "~/.zshrc" {
+		t.Errorf("custom layout = %+v", layout)
+	}
+
+	if _, err := MigrateLayout(configDir, homeDir, nil, Layout{Kind: LayoutFlat}, false); err != nil {
+		t.Fatalf("MigrateLayout(flat) error = %v", err)
+	}
+	for name, content := range files {
+		data, err := os.ReadFile(filepath.Join(configDir, name))
+		if err != nil || string(data) != content {
+			t.Errorf("%s after the round trip = %q, %v; want %q", name, data, err, content)
+		}
+	}
+	data, err := os.ReadFile(filepath.Join(configDir, "plonk.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "dotfiles") {
+		t.Errorf("plonk.yaml still has dotfiles settings for the flat layout:\n%s", data)
+	}
+}
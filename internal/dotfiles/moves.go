@@ -0,0 +1,251 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package dotfiles
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/richhaase/plonk/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+// Apply records each deployed dotfile in the state directory with its
+// source and content hash. When a source is later moved inside the config
+// directory, its new target shows up missing while the record still names
+// the old source; matching the hash turns that into a move of the deployed
+// file instead of a second copy next to the old one. The records are per
+// machine, so they stay out of plonk.lock, which git carries between
+// machines and which may be signed.
+
+// deploymentsFileName is the machine-local record of deployed dotfiles
+const deploymentsFileName = "deployments.yaml"
+
+// Deployment records the source a dotfile was last deployed from and the
+// SHA-256 of its content, so apply can tell a source moved inside the
+// config directory from a new one
+type Deployment struct {
+	Source string `yaml:"source"`
+	SHA256 string `yaml:"sha256"`
+}
+
+// DeploymentsPath returns the machine-local record of deployed dotfiles
+func DeploymentsPath() string {
+	return filepath.Join(config.GetStateDirectory(), deploymentsFileName)
+}
+
+// loadDeployments reads the deployment records from path, by config
+// directory and then by target; a missing file has none
+func loadDeployments(path string) (map[string]map[string]Deployment, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]map[string]Deployment{}, nil
+		}
+		return nil, err
+	}
+	all := map[string]map[string]Deployment{}
+	if err := yaml.Unmarshal(data, &all); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if all == nil {
+		all = map[string]map[string]Deployment{}
+	}
+	return all, nil
+}
+
+// saveDeployments atomically writes the deployment records to path
+func saveDeployments(path string, all map[string]map[string]Deployment) error {
+	for dir, recorded := range all {
+		if len(recorded) == 0 {
+			delete(all, dir)
+		}
+	}
+	data, err := yaml.Marshal(all)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// updateDeployments applies update to this config directory's deployment
+// records and saves them when update reports a change
+func (m *DotfileManager) updateDeployments(update func(recorded map[string]Deployment) bool) error {
+	path := DeploymentsPath()
+	all, err := loadDeployments(path)
+	if err != nil {
+		return err
+	}
+	recorded := all[m.configDir]
+	if recorded == nil {
+		recorded = make(map[string]Deployment)
+		all[m.configDir] = recorded
+	}
+	if !update(recorded) {
+		return nil
+	}
+	return saveDeployments(path, all)
+}
+
+// deployKey is how the records name a deployed target: relative to $HOME,
+// so they survive a change of home directory
+func (m *DotfileManager) deployKey(target string) string {
+	rel, err := filepath.Rel(m.homeDir, target)
+	if err != nil {
+		return filepath.ToSlash(target)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// detectMoves matches the missing dotfiles in statuses with deployments
+// recorded on this machine whose source is gone but whose target is still
+// there. It returns the old target for each moved dotfile, by source name.
+func (m *DotfileManager) detectMoves(recorded map[string]Deployment, statuses []DotfileStatus) map[string]string {
+	if len(recorded) == 0 {
+		return nil
+	}
+
+	current := make(map[string]bool, len(statuses))
+	for _, s := range statuses {
+		current[m.deployKey(s.Target)] = true
+	}
+
+	// Old targets whose source has gone, by the hash they were deployed with
+	orphans := make(map[string][]string)
+	for key, entry := range recorded {
+		if current[key] {
+			continue
+		}
+		if _, err := m.fs.Stat(filepath.Join(m.configDir, entry.Source)); !os.IsNotExist(err) {
+			continue
+		}
+		oldTarget := filepath.Join(m.homeDir, filepath.FromSlash(key))
+		if _, err := m.fs.Stat(oldTarget); err != nil {
+			continue // nothing left to move
+		}
+		orphans[entry.SHA256] = append(orphans[entry.SHA256], oldTarget)
+	}
+	if len(orphans) == 0 {
+		return nil
+	}
+
+	moves := make(map[string]string)
+	for _, s := range statuses {
+		if s.State != SyncStateMissing {
+			continue
+		}
+		hash, _, err := m.hashFile(s.Source)
+		if err != nil || len(orphans[hash]) == 0 {
+			continue
+		}
+		moves[s.Name] = orphans[hash][0]
+		orphans[hash] = orphans[hash][1:]
+	}
+	return moves
+}
+
+// moveDeployed deploys the dotfile name and removes oldTarget, the file
+// deployed from its previous location. An old target changed since it was
+// deployed is left in place with a warning rather than losing the edits.
+func (m *DotfileManager) moveDeployed(name, oldTarget string) error {
+	sourcePath := filepath.Join(m.configDir, name)
+	changed, err := m.IsDrifted(Dotfile{Name: name, Source: sourcePath, Target: oldTarget})
+	if err != nil {
+		return err
+	}
+
+	if err := m.Deploy(name); err != nil {
+		return err
+	}
+
+	if changed {
+		log.Printf("Warning: left %s in place: it was changed after it was deployed", oldTarget)
+		return nil
+	}
+	if err := m.fs.Remove(oldTarget); err != nil {
+		return fmt.Errorf("deployed %s but failed to remove %s: %w", name, oldTarget, err)
+	}
+	return nil
+}
+
+// recordDeployed updates the deployment records after an apply: every
+// dotfile in statuses that is in sync is recorded, moved targets are
+// forgotten, and with prune so are targets whose source no longer exists.
+// Records that can't be updated only cost move detection, so it warns.
+func (m *DotfileManager) recordDeployed(statuses []DotfileStatus, moved map[string]string, prune bool) {
+	err := m.updateDeployments(func(recorded map[string]Deployment) bool {
+		changed := false
+		for _, oldTarget := range moved {
+			key := m.deployKey(oldTarget)
+			if _, ok := recorded[key]; ok {
+				delete(recorded, key)
+				changed = true
+			}
+		}
+		for _, s := range statuses {
+			hash, _, err := m.hashFile(s.Source)
+			if err != nil {
+				continue
+			}
+			key, entry := m.deployKey(s.Target), Deployment{Source: filepath.ToSlash(s.Name), SHA256: hash}
+			if recorded[key] != entry {
+				recorded[key] = entry
+				changed = true
+			}
+		}
+		if prune {
+			for key, entry := range recorded {
+				if _, err := m.fs.Stat(filepath.Join(m.configDir, entry.Source)); os.IsNotExist(err) {
+					delete(recorded, key)
+					changed = true
+				}
+			}
+		}
+		return changed
+	})
+	if err != nil {
+		log.Printf("Warning: not recording deployed dotfiles: %v", err)
+	}
+}
+
+// forgetDeployed drops the records of dotfiles deployed from name or from
+// files under it, so a file removed from management is never mistaken for
+// the old location of a moved one
+func (m *DotfileManager) forgetDeployed(name string) {
+	prefix := filepath.ToSlash(name)
+	err := m.updateDeployments(func(recorded map[string]Deployment) bool {
+		changed := false
+		for key, entry := range recorded {
+			if entry.Source == prefix || strings.HasPrefix(entry.Source, prefix+"/") {
+				delete(recorded, key)
+				changed = true
+			}
+		}
+		return changed
+	})
+	if err != nil {
+		log.Printf("Warning: failed to update %s: %v", DeploymentsPath(), err)
+	}
+}
+
+// recordedDeployments reads the deployments recorded for this config
+// directory
+func (m *DotfileManager) recordedDeployments() map[string]Deployment {
+	all, err := loadDeployments(DeploymentsPath())
+	if err != nil {
+		log.Printf("Warning: ignoring deployed dotfile records: %v", err)
+		return nil
+	}
+	return all[m.configDir]
+}
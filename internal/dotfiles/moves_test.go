@@ -0,0 +1,156 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package dotfiles
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/richhaase/plonk/internal/config"
+)
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func recordedDotfiles(t *testing.T, configDir string) map[string]Deployment {
+	t.Helper()
+	all, err := loadDeployments(DeploymentsPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return all[configDir]
+}
+
+func TestApply_MovesDeployedFileWhenSourceMoves(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	configDir := t.TempDir()
+	homeDir := t.TempDir()
+	cfg := &config.Config{}
+	ctx := context.Background()
+
+	writeTestFile(t, filepath.Join(configDir, "zshrc"), "export EDITOR=vim\n")
+	if _, err := Apply(ctx, configDir, homeDir, cfg, false); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	recorded := recordedDotfiles(t, configDir)
+	if recorded[".zshrc"].Source != "zshrc" || recorded[".zshrc"].SHA256 == "" {
+		t.Fatalf("recorded dotfiles after apply = %+v", recorded)
+	}
+	if _, err := os.Stat(filepath.Join(configDir, "plonk.lock")); !os.IsNotExist(err) {
+		t.Error("apply wrote plonk.lock")
+	}
+
+	// Move the source: in the flat layout its target moves too
+	if err := os.MkdirAll(filepath.Join(configDir, "zsh"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(filepath.Join(configDir, "zshrc"), filepath.Join(configDir, "zsh", "zshrc")); err != nil {
+		t.Fatal(err)
+	}
+	oldTarget := filepath.Join(homeDir, ".zshrc")
+	newTarget := filepath.Join(homeDir, ".zsh", "zshrc")
+
+	result, err := Apply(ctx, configDir, homeDir, cfg, true)
+	if err != nil {
+		t.Fatalf("Apply(dry run) error = %v", err)
+	}
+	if len(result.Actions) != 1 || result.Actions[0].Status != "would-move" || result.Actions[0].From != oldTarget {
+		t.Errorf("Apply(dry run) actions = %+v, want a would-move from %s", result.Actions, oldTarget)
+	}
+	if _, err := os.Stat(oldTarget); err != nil {
+		t.Error("dry run removed the old target")
+	}
+
+	result, err = Apply(ctx, configDir, homeDir, cfg, false)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if len(result.Actions) != 1 || result.Actions[0].Status != "moved" {
+		t.Errorf("Apply() actions = %+v, want moved", result.Actions)
+	}
+	if _, err := os.Stat(newTarget); err != nil {
+		t.Errorf("new target not deployed: %v", err)
+	}
+	if _, err := os.Stat(oldTarget); !os.IsNotExist(err) {
+		t.Error("old target was left behind")
+	}
+
+	recorded = recordedDotfiles(t, configDir)
+	if _, ok := recorded[".zshrc"]; ok || recorded[".zsh/zshrc"].Source != "zsh/zshrc" {
+		t.Errorf("recorded dotfiles after move = %+v", recorded)
+	}
+}
+
+func TestApply_MoveKeepsChangedOldTarget(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	configDir := t.TempDir()
+	homeDir := t.TempDir()
+	cfg := &config.Config{}
+	ctx := context.Background()
+
+	writeTestFile(t, filepath.Join(configDir, "vimrc"), "set number\n")
+	if _, err := Apply(ctx, configDir, homeDir, cfg, false); err != nil {
+		t.Fatal(err)
+	}
+
+	oldTarget := filepath.Join(homeDir, ".vimrc")
+	writeTestFile(t, oldTarget, "set number\nset local-edit\n")
+	if err := os.MkdirAll(filepath.Join(configDir, "vim"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(filepath.Join(configDir, "vimrc"), filepath.Join(configDir, "vim", "vimrc")); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Apply(ctx, configDir, homeDir, cfg, false); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := os.ReadFile(oldTarget); err != nil || string(data) != "set number\nset local-edit\n" {
+		t.Errorf("changed old target = %q, %v; want it kept", data, err)
+	}
+	if _, err := os.Stat(filepath.Join(homeDir, ".vim", "vimrc")); err != nil {
+		t.Errorf("new target not deployed: %v", err)
+	}
+}
+
+func TestRemove_ForgetsDeployment(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	configDir := t.TempDir()
+	homeDir := t.TempDir()
+
+	writeTestFile(t, filepath.Join(configDir, "gitconfig"), "[user]\n")
+	if _, err := Apply(context.Background(), configDir, homeDir, &config.Config{}, false); err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewDotfileManager(configDir, homeDir, nil)
+	if err := m.Remove("gitconfig"); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if recorded := recordedDotfiles(t, configDir); len(recorded) != 0 {
+		t.Errorf("recorded dotfiles after remove = %+v, want none", recorded)
+	}
+
+	// The same content added elsewhere is a new file, not a move
+	writeTestFile(t, filepath.Join(configDir, "config", "git", "config"), "[user]\n")
+	result, err := Apply(context.Background(), configDir, homeDir, &config.Config{}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Actions) != 1 || result.Actions[0].Status != "added" {
+		t.Errorf("Apply() actions = %+v, want added", result.Actions)
+	}
+	if _, err := os.Stat(filepath.Join(homeDir, ".gitconfig")); err != nil {
+		t.Error("Apply() removed a file that is no longer managed")
+	}
+}
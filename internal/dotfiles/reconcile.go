@@ -17,7 +17,7 @@ func (m *DotfileManager) Reconcile() ([]DotfileStatus, error) {
 
 	var statuses []DotfileStatus
 	for _, d := range dotfiles {
-		state, err := m.getState(d)
+		state, problem, err := m.getState(d)
 		if err != nil {
 			// Collect per-file errors instead of aborting; one broken file
 			// should not prevent status/diff/apply from reporting on others.
@@ -29,36 +29,50 @@ func (m *DotfileManager) Reconcile() ([]DotfileStatus, error) {
 			continue
 		}
 		statuses = append(statuses, DotfileStatus{
-			Dotfile: d,
-			State:   state,
+			Dotfile:   d,
+			State:     state,
+			Problem:   problem,
+			KeptLocal: state == SyncStateDrifted && m.keptLocal[d.Name],
 		})
 	}
 
 	return statuses, nil
 }
 
-// getState determines the sync state of a single dotfile
-func (m *DotfileManager) getState(d Dotfile) (SyncState, error) {
+// getState determines the sync state of a single dotfile, and why a
+// drifted file with matching content needs deploying again
+func (m *DotfileManager) getState(d Dotfile) (SyncState, string, error) {
 	// Check if target exists
 	_, err := m.fs.Stat(d.Target)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return SyncStateMissing, nil
+			return SyncStateMissing, "", nil
 		}
-		return "", err
+		return "", "", err
 	}
 
 	// Target exists, check if drifted
 	drifted, err := m.IsDrifted(d)
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 
 	if drifted {
-		return SyncStateDrifted, nil
+		return SyncStateDrifted, "", nil
 	}
 
-	return SyncStateManaged, nil
+	// Matching content under ~/.ssh is still broken if ssh won't read it
+	if m.sshPath(d.Target) {
+		problem, err := m.sshPermissionProblem(d.Target)
+		if err != nil {
+			return "", "", err
+		}
+		if problem != "" {
+			return SyncStateDrifted, problem, nil
+		}
+	}
+
+	return SyncStateManaged, "", nil
 }
 
 // ApplyAll deploys all missing or drifted dotfiles.
@@ -82,6 +96,10 @@ func (m *DotfileManager) ApplyAll(dryRun bool) (DeployResult, error) {
 			result.Errors = append(result.Errors, status.Error)
 
 		case SyncStateMissing, SyncStateDrifted:
+			if status.KeptLocal {
+				result.Skipped = append(result.Skipped, status.Dotfile)
+				continue
+			}
 			if dryRun {
 				result.Deployed = append(result.Deployed, status.Dotfile)
 			} else {
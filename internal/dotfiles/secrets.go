@@ -0,0 +1,34 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package dotfiles
+
+import (
+	"github.com/richhaase/plonk/internal/secrets"
+)
+
+// secretScanLen is how much of a file is scanned for secrets
+const secretScanLen = 1 << 20
+
+// WithAllowSecrets sets whether plonk add stores files that contain
+// probable secrets such as API tokens
+func (m *DotfileManager) WithAllowSecrets(allow bool) *DotfileManager {
+	m.allowSecrets = allow
+	return m
+}
+
+// checkSecrets returns an error describing the probable secrets in path,
+// unless they are allowed
+func (m *DotfileManager) checkSecrets(path string) error {
+	if m.allowSecrets {
+		return nil
+	}
+	head, err := m.readHead(path, secretScanLen)
+	if err != nil {
+		return err
+	}
+	if findings := secrets.Scan(path, head); len(findings) > 0 {
+		return secrets.Error(findings)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package dotfiles
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDotfileManager_AddRefusesSecrets(t *testing.T) {
+	configDir := t.TempDir()
+	homeDir := t.TempDir()
+
+	ghDir := filepath.Join(homeDir, ".config", "gh")
+	if err := os.MkdirAll(ghDir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"config.yml": "git_protocol: ssh\n",
+		"hosts.yml":  "github.com:\n  oauth_token: gho_" + strings.Repeat("a1B2", 9) + "\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(ghDir, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m := NewDotfileManager(configDir, homeDir, nil)
+	hosts := filepath.Join(ghDir, "hosts.yml")
+	if err := m.ValidateAdd(hosts); err == nil || !strings.Contains(err.Error(), "GitHub token") {
+		t.Errorf("ValidateAdd(hosts.yml) error = %v, want a GitHub token finding", err)
+	}
+	if err := m.Add(hosts); err == nil {
+		t.Error("Add(hosts.yml) stored a GitHub token")
+	}
+
+	// Adding the directory keeps the rest and leaves the token out
+	if err := m.Add(ghDir); err != nil {
+		t.Fatalf("Add(~/.config/gh) error = %v", err)
+	}
+	for name, wantStored := range map[string]bool{"config.yml": true, "hosts.yml": false} {
+		_, err := os.Stat(filepath.Join(configDir, "config", "gh", name))
+		if stored := err == nil; stored != wantStored {
+			t.Errorf("config/gh/%s stored = %v, want %v", name, stored, wantStored)
+		}
+	}
+
+	// Unless secrets are allowed
+	if err := m.WithAllowSecrets(true).Add(hosts); err != nil {
+		t.Errorf("Add(hosts.yml) with secrets allowed error = %v", err)
+	}
+}
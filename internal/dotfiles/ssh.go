@@ -0,0 +1,104 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package dotfiles
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/richhaase/plonk/internal/secrets"
+)
+
+// Files under ~/.ssh get modes ssh accepts: it ignores a config or key that
+// others can read or write, and only says so with -v
+const (
+	sshDirName  = ".ssh"
+	sshDirMode  = os.FileMode(0700)
+	sshFileMode = os.FileMode(0600)
+)
+
+// sshPath reports whether target is under ~/.ssh
+func (m *DotfileManager) sshPath(target string) bool {
+	rel, err := filepath.Rel(filepath.Join(m.homeDir, sshDirName), target)
+	return err == nil && rel != "." && !relEscapes(rel)
+}
+
+// sshDirs returns ~/.ssh and the directories below it that lead to target
+func (m *DotfileManager) sshDirs(target string) []string {
+	root := filepath.Join(m.homeDir, sshDirName)
+	var dirs []string
+	for dir := filepath.Dir(target); dir != root; dir = filepath.Dir(dir) {
+		dirs = append(dirs, dir)
+	}
+	return append(dirs, root)
+}
+
+// sshTargetMode is the mode a dotfile deployed under ~/.ssh gets: the
+// source's owner bits, at least read-write, and nothing for anyone else
+func sshTargetMode(mode os.FileMode) os.FileMode {
+	return mode&0700 | sshFileMode
+}
+
+// sshPermissionProblem describes the modes around target that are too
+// open for ssh, or returns "" when they are fine
+func (m *DotfileManager) sshPermissionProblem(target string) (string, error) {
+	info, err := m.fs.Stat(target)
+	if err != nil {
+		return "", err
+	}
+	if mode := info.Mode().Perm(); mode&0077 != 0 {
+		return fmt.Sprintf("mode %04o, ssh wants %04o", mode, sshTargetMode(mode)), nil
+	}
+	for _, dir := range m.sshDirs(target) {
+		info, err := m.fs.Stat(dir)
+		if err != nil {
+			return "", err
+		}
+		if mode := info.Mode().Perm(); mode&0077 != 0 {
+			return fmt.Sprintf("%s is mode %04o, ssh wants %04o", filepath.Base(dir), mode, sshDirMode), nil
+		}
+	}
+	return "", nil
+}
+
+// prepareSSHDirs creates the directories under ~/.ssh leading to target
+// and restricts them to their owner
+func (m *DotfileManager) prepareSSHDirs(target string) error {
+	if err := m.fs.MkdirAll(filepath.Dir(target), sshDirMode); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	for _, dir := range m.sshDirs(target) {
+		if err := m.fs.Chmod(dir, sshDirMode); err != nil {
+			return fmt.Errorf("failed to set permissions on %s: %w", dir, err)
+		}
+	}
+	return nil
+}
+
+// unencryptedPrivateKey reports whether content holds a private key that
+// is stored without a passphrase. Such keys are refused by plonk add: the
+// plonk directory is a git repository that is usually pushed somewhere.
+func unencryptedPrivateKey(content []byte) bool {
+	return secrets.UnencryptedPrivateKey(content)
+}
+
+// keyScanLen is how much of a file is checked for a private key, more than
+// the largest key ssh-keygen writes
+const keyScanLen = 64 << 10
+
+// isUnencryptedKey reads the start of path and checks it for a private key
+// without a passphrase
+func (m *DotfileManager) isUnencryptedKey(path string) (bool, error) {
+	head, err := m.readHead(path, keyScanLen)
+	if err != nil {
+		return false, err
+	}
+	return unencryptedPrivateKey(head), nil
+}
+
+// errUnencryptedKey is the error plonk add returns for such a key
+func errUnencryptedKey(path string) error {
+	return fmt.Errorf("%s is a private key without a passphrase; plonk won't store it (add a passphrase with ssh-keygen -p -f %s, or leave it unmanaged)", path, path)
+}
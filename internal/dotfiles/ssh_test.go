@@ -0,0 +1,160 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package dotfiles
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// openSSHKey returns a PEM block shaped like ssh-keygen output, up to the
+// cipher name, which is all plonk looks at
+func openSSHKey(cipher string) []byte {
+	data := []byte("openssh-key-v1\x00")
+	data = binary.BigEndian.AppendUint32(data, uint32(len(cipher)))
+	data = append(data, cipher...)
+	data = append(data, make([]byte, 32)...)
+	return pem.EncodeToMemory(&pem.Block{Type: "OPENSSH PRIVATE KEY", Bytes: data})
+}
+
+func TestUnencryptedPrivateKey(t *testing.T) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		content []byte
+		want    bool
+	}{
+		{"ssh config", []byte("Host *\n  AddKeysToAgent yes\n"), false},
+		{"public key", []byte("ssh-ed25519 AAAAC3NzaC1lZDI1NTE5 me@host\n"), false},
+		{"openssh without passphrase", openSSHKey("none"), true},
+		{"openssh with passphrase", openSSHKey("aes256-ctr"), false},
+		{"pkcs8", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}), true},
+		{"encrypted pkcs8", pem.EncodeToMemory(&pem.Block{Type: "ENCRYPTED PRIVATE KEY", Bytes: []byte{1}}), false},
+		{"legacy rsa", pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: []byte{1}}), true},
+		{"legacy rsa with passphrase", pem.EncodeToMemory(&pem.Block{
+			Type:    "RSA PRIVATE KEY",
+			Headers: map[string]string{"Proc-Type": "4,ENCRYPTED", "DEK-Info": "AES-128-CBC,00"},
+			Bytes:   []byte{1},
+		}), false},
+		{"certificate then key", append(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte{1}}), openSSHKey("none")...), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := unencryptedPrivateKey(tt.content); got != tt.want {
+				t.Errorf("unencryptedPrivateKey() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDotfileManager_SSHPermissions(t *testing.T) {
+	configDir := t.TempDir()
+	homeDir := t.TempDir()
+
+	if err := os.MkdirAll(filepath.Join(configDir, "ssh"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(configDir, "ssh", "config"), []byte("Host *\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// An existing ~/.ssh that is too open
+	if err := os.Mkdir(filepath.Join(homeDir, ".ssh"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewDotfileManager(configDir, homeDir, nil)
+	if err := m.Deploy("ssh/config"); err != nil {
+		t.Fatalf("Deploy() error = %v", err)
+	}
+
+	target := filepath.Join(homeDir, ".ssh", "config")
+	for path, want := range map[string]os.FileMode{
+		target:                         0600,
+		filepath.Join(homeDir, ".ssh"): 0700,
+	} {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := info.Mode().Perm(); got != want {
+			t.Errorf("mode of %s = %04o, want %04o", path, got, want)
+		}
+	}
+
+	statuses, err := m.Reconcile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) != 1 || statuses[0].State != SyncStateManaged {
+		t.Fatalf("Reconcile() after deploy = %+v, want managed", statuses)
+	}
+
+	// Loosened modes show up as drift with the reason
+	if err := os.Chmod(target, 0644); err != nil {
+		t.Fatal(err)
+	}
+	statuses, err = m.Reconcile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if statuses[0].State != SyncStateDrifted || !strings.Contains(statuses[0].Problem, "0644") {
+		t.Errorf("Reconcile() with mode 0644 = %s %q, want drifted naming the mode", statuses[0].State, statuses[0].Problem)
+	}
+}
+
+func TestDotfileManager_AddRefusesUnencryptedKey(t *testing.T) {
+	configDir := t.TempDir()
+	homeDir := t.TempDir()
+
+	sshDir := filepath.Join(homeDir, ".ssh")
+	if err := os.Mkdir(sshDir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string][]byte{
+		"config":         []byte("Host *\n"),
+		"id_ed25519":     openSSHKey("none"),
+		"id_ed25519.pub": []byte("ssh-ed25519 AAAA me@host\n"),
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(sshDir, name), content, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m := NewDotfileManager(configDir, homeDir, nil)
+	key := filepath.Join(sshDir, "id_ed25519")
+	if err := m.ValidateAdd(key); err == nil || !strings.Contains(err.Error(), "passphrase") {
+		t.Errorf("ValidateAdd(key) error = %v, want a passphrase error", err)
+	}
+	if err := m.Add(key); err == nil {
+		t.Error("Add(key) stored an unencrypted private key")
+	}
+
+	// Adding the directory keeps the rest and leaves the key out
+	if err := m.Add(sshDir); err != nil {
+		t.Fatalf("Add(~/.ssh) error = %v", err)
+	}
+	for name, wantStored := range map[string]bool{"config": true, "id_ed25519.pub": true, "id_ed25519": false} {
+		_, err := os.Stat(filepath.Join(configDir, "ssh", name))
+		if stored := err == nil; stored != wantStored {
+			t.Errorf("ssh/%s stored = %v, want %v", name, stored, wantStored)
+		}
+	}
+}
@@ -23,8 +23,10 @@ const (
 // DotfileStatus combines a dotfile with its current state
 type DotfileStatus struct {
 	Dotfile
-	State SyncState
-	Error error // non-nil when State is SyncStateError
+	State     SyncState
+	Error     error  // non-nil when State is SyncStateError
+	Problem   string // why a drifted file with matching content is drifted, e.g. its mode
+	KeptLocal bool   // drifted on purpose: applies leave it alone on this machine
 }
 
 // DeployResult summarizes what Apply() did
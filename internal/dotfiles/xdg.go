@@ -0,0 +1,76 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package dotfiles
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// xdgDirs are the shorthands a dotfiles.map target may start with, e.g.
+// "{{xdg_config}}/nvim". Each is the XDG base directory on Linux and its
+// counterpart under ~/Library on macOS; a set XDG_*_HOME variable wins on
+// every OS, as the tools reading these directories honor it too.
+var xdgDirs = map[string]struct{ env, linux, darwin string }{
+	"xdg_config": {"XDG_CONFIG_HOME", ".config", "Library/Application Support"},
+	"xdg_data":   {"XDG_DATA_HOME", ".local/share", "Library/Application Support"},
+	"xdg_state":  {"XDG_STATE_HOME", ".local/state", "Library/Application Support"},
+	"xdg_cache":  {"XDG_CACHE_HOME", ".cache", "Library/Caches"},
+}
+
+// xdgVarPattern matches a leading {{name}} and captures the rest of the path
+var xdgVarPattern = regexp.MustCompile(`^\{\{\s*([A-Za-z_]+)\s*\}\}(.*)$`)
+
+// expandTargetVars resolves a leading {{xdg_*}} in target to a path
+// relative to homeDir for goos. Other targets are returned unchanged.
+func expandTargetVars(target, goos, homeDir string, lookupEnv func(string) (string, bool)) (string, error) {
+	match := xdgVarPattern.FindStringSubmatch(target)
+	if match == nil {
+		return target, nil
+	}
+	name, rest := match[1], match[2]
+	dir, ok := xdgDirs[name]
+	if !ok {
+		return "", fmt.Errorf("unknown variable {{%s}} (use xdg_config, xdg_data, xdg_state or xdg_cache)", name)
+	}
+	if rest != "" && !strings.HasPrefix(rest, "/") {
+		return "", fmt.Errorf("{{%s}} must be followed by / or end the target", name)
+	}
+
+	base := dir.linux
+	if goos == "darwin" {
+		base = dir.darwin
+	}
+	// The XDG spec says to ignore relative values
+	if value, ok := lookupEnv(dir.env); ok && filepath.IsAbs(value) {
+		rel, err := filepath.Rel(homeDir, value)
+		if err != nil || relEscapes(rel) {
+			return "", fmt.Errorf("{{%s}} is $%s=%s, which is outside the home directory", name, dir.env, value)
+		}
+		base = filepath.ToSlash(rel)
+	}
+	return base + rest, nil
+}
+
+// resolve returns the layout with the {{xdg_*}} shorthands in its map
+// expanded for goos. An entry that can't be resolved is left out with a
+// warning, so its files are not deployed anywhere unexpected.
+func (l Layout) resolve(goos, homeDir string, lookupEnv func(string) (string, bool)) Layout {
+	if len(l.Map) == 0 {
+		return l
+	}
+	resolved := make(map[string]string, len(l.Map))
+	for source, target := range l.Map {
+		expanded, err := expandTargetVars(target, goos, homeDir, lookupEnv)
+		if err != nil {
+			log.Printf("Warning: dotfiles.map %s: %v", source, err)
+			continue
+		}
+		resolved[source] = expanded
+	}
+	return Layout{Kind: l.Kind, Map: resolved}
+}
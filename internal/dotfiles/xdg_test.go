@@ -0,0 +1,66 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package dotfiles
+
+import "testing"
+
+func TestExpandTargetVars(t *testing.T) {
+	env := map[string]string{}
+	lookupEnv := func(key string) (string, bool) {
+		v, ok := env[key]
+		return v, ok
+	}
+
+	tests := []struct {
+		name    string
+		target  string
+		goos    string
+		env     map[string]string
+		want    string
+		wantErr bool
+	}{
+		{"plain target", "~/.zshrc", "linux", nil, "~/.zshrc", false},
+		{"linux config", "{{xdg_config}}/nvim", "linux", nil, ".config/nvim", false},
+		{"linux data", "{{xdg_data}}/fonts", "linux", nil, ".local/share/fonts", false},
+		{"macos config", "{{xdg_config}}/Code/User", "darwin", nil, "Library/Application Support/Code/User", false},
+		{"macos cache", "{{ xdg_cache }}", "darwin", nil, "Library/Caches", false},
+		{"env wins", "{{xdg_config}}/nvim", "darwin", map[string]string{"XDG_CONFIG_HOME": "/home/user/cfg"}, "cfg/nvim", false},
+		{"relative env ignored", "{{xdg_config}}/nvim", "linux", map[string]string{"XDG_CONFIG_HOME": "cfg"}, ".config/nvim", false},
+		{"env outside home", "{{xdg_config}}/nvim", "linux", map[string]string{"XDG_CONFIG_HOME": "/etc/xdg"}, "", true},
+		{"unknown variable", "{{xdg_music}}/x", "linux", nil, "", true},
+		{"missing separator", "{{xdg_config}}nvim", "linux", nil, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env = tt.env
+			got, err := expandTargetVars(tt.target, tt.goos, "/home/user", lookupEnv)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expandTargetVars(%q) error = %v, wantErr %v", tt.target, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("expandTargetVars(%q) = %q, want %q", tt.target, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLayout_ResolveXDG(t *testing.T) {
+	layout := Layout{Kind: LayoutCustom, Map: map[string]string{
+		"nvim":   "{{xdg_config}}/nvim",
+		"broken": "{{nope}}/x",
+	}}
+	noEnv := func(string) (string, bool) { return "", false }
+
+	resolved := layout.resolve("darwin", "/Users/me", noEnv)
+	if target, ok := resolved.target("nvim/init.lua"); !ok || target != "Library/Application Support/nvim/init.lua" {
+		t.Errorf("target(nvim/init.lua) = %q, %v", target, ok)
+	}
+	if _, ok := resolved.target("broken"); ok {
+		t.Error("an unresolvable map entry was kept")
+	}
+	if layout.Map["nvim"] != "{{xdg_config}}/nvim" {
+		t.Error("resolve() modified the original map")
+	}
+}
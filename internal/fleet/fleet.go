@@ -0,0 +1,153 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+// Package fleet stores per-machine status reports alongside the shared
+// config so any machine can see which hosts are out of sync.
+//
+// Reports live in $PLONK_DIR/.fleet/<host>.json. The dot prefix keeps them
+// out of dotfile deployment, while git (auto-commit, push, pull) carries
+// them between machines.
+package fleet
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/richhaase/plonk/internal/lock"
+	"github.com/richhaase/plonk/internal/output"
+)
+
+// Dir is the report directory, relative to the config directory
+const Dir = ".fleet"
+
+// hostUnsafe matches characters not allowed in report file names
+var hostUnsafe = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// Report is one machine's status at a point in time
+type Report struct {
+	Host       string              `json:"host"`
+	ReportedAt time.Time           `json:"reported_at"`
+	LockDigest string              `json:"lock_digest"` // sha256 of plonk.lock when the report was made
+	Counts     output.StatusCounts `json:"counts"`
+	OutOfSync  []string            `json:"out_of_sync,omitempty"` // e.g. "package brew:jq missing"
+}
+
+// NewReport builds a report for host from a status summary
+func NewReport(host, lockDigest string, summary output.Summary) Report {
+	r := Report{
+		Host:       SanitizeHost(host),
+		ReportedAt: time.Now().UTC(),
+		LockDigest: lockDigest,
+		Counts:     output.NewStatusCounts(summary),
+	}
+	r.Counts.GeneratedAt = r.ReportedAt
+
+	for _, result := range summary.Results {
+		add := func(item output.Item, state output.ItemState) {
+			name := item.Name
+			if item.Manager != "" {
+				name = item.Manager + ":" + item.Name
+			}
+			r.OutOfSync = append(r.OutOfSync, fmt.Sprintf("%s %s %s", result.Domain, name, state))
+		}
+		for _, item := range result.Missing {
+			add(item, output.StateMissing)
+		}
+		for _, item := range result.Managed {
+			if item.State == output.StateDegraded {
+				add(item, output.StateDegraded)
+			}
+		}
+		for _, item := range result.Errors {
+			add(item, output.StateError)
+		}
+	}
+	sort.Strings(r.OutOfSync)
+	return r
+}
+
+// SanitizeHost makes a host name safe to use as a file name
+func SanitizeHost(host string) string {
+	host = strings.Trim(hostUnsafe.ReplaceAllString(host, "-"), ".-")
+	if host == "" {
+		return "unknown"
+	}
+	return host
+}
+
+// LockDigest returns the sha256 of the config directory's lock file, or ""
+// if there is no lock file
+func LockDigest(configDir string) (string, error) {
+	data, err := os.ReadFile(lock.NewLockV3Service(configDir).GetLockPath())
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read lock file: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Write stores r under the config directory and returns the file path
+func Write(configDir string, r Report) (string, error) {
+	dir := filepath.Join(configDir, Dir)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, SanitizeHost(r.Host)+".json")
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil {
+		return "", fmt.Errorf("failed to write report: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return "", fmt.Errorf("failed to write report: %w", err)
+	}
+	return path, nil
+}
+
+// ReadAll loads every report in the config directory, sorted by host.
+// Unreadable reports are skipped and returned as warnings.
+func ReadAll(configDir string) ([]Report, []error) {
+	entries, err := os.ReadDir(filepath.Join(configDir, Dir))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	var reports []Report
+	var warnings []error
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		path := filepath.Join(configDir, Dir, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			warnings = append(warnings, err)
+			continue
+		}
+		var r Report
+		if err := json.Unmarshal(data, &r); err != nil {
+			warnings = append(warnings, fmt.Errorf("invalid report %s: %w", entry.Name(), err))
+			continue
+		}
+		reports = append(reports, r)
+	}
+	sort.Slice(reports, func(i, j int) bool { return reports[i].Host < reports[j].Host })
+	return reports, warnings
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package fleet
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/richhaase/plonk/internal/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewReport(t *testing.T) {
+	summary := output.Summary{
+		TotalManaged: 2,
+		TotalMissing: 1,
+		Results: []output.Result{
+			{Domain: "package", Missing: []output.Item{{Name: "jq", Manager: "brew", State: output.StateMissing}}},
+			{Domain: "dotfile", Managed: []output.Item{
+				{Name: ".zshrc", State: output.StateManaged},
+				{Name: ".vimrc", State: output.StateDegraded},
+			}},
+		},
+	}
+
+	r := NewReport("work laptop.local", "abc", summary)
+	assert.Equal(t, "work-laptop.local", r.Host)
+	assert.Equal(t, "abc", r.LockDigest)
+	assert.Equal(t, 2, r.Counts.OutOfSync())
+	assert.Equal(t, []string{"dotfile .vimrc drifted", "package brew:jq missing"}, r.OutOfSync)
+}
+
+func TestSanitizeHost(t *testing.T) {
+	assert.Equal(t, "host", SanitizeHost("host"))
+	assert.Equal(t, "a-b", SanitizeHost("a/b"))
+	assert.Equal(t, "unknown", SanitizeHost("../"))
+}
+
+func TestWriteAndReadAll(t *testing.T) {
+	configDir := t.TempDir()
+
+	_, err := Write(configDir, Report{Host: "zeta"})
+	require.NoError(t, err)
+	path, err := Write(configDir, Report{Host: "alpha", LockDigest: "d"})
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(configDir, Dir, "alpha.json"), path)
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, Dir, "broken.json"), []byte("{"), 0o644))
+
+	reports, warnings := ReadAll(configDir)
+	require.Len(t, reports, 2)
+	assert.Equal(t, "alpha", reports[0].Host)
+	assert.Equal(t, "d", reports[0].LockDigest)
+	assert.Equal(t, "zeta", reports[1].Host)
+	assert.Len(t, warnings, 1)
+}
+
+func TestLockDigest(t *testing.T) {
+	configDir := t.TempDir()
+
+	digest, err := LockDigest(configDir)
+	require.NoError(t, err)
+	assert.Empty(t, digest)
+
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "plonk.lock"), []byte("version: 3\n"), 0o644))
+	digest, err = LockDigest(configDir)
+	require.NoError(t, err)
+	assert.Len(t, digest, 64)
+}
@@ -8,13 +8,20 @@ import (
 
 	"github.com/richhaase/plonk/internal/config"
 	"github.com/richhaase/plonk/internal/output"
+	"github.com/richhaase/plonk/internal/signing"
 )
 
 // AutoCommit is the standard post-mutation hook.
-// Reads config from disk, checks if auto-commit is enabled, and commits if appropriate.
+// Reads config from disk, re-signs plonk.lock if a signing key is set and
+// the lock changed, checks if auto-commit is enabled, and commits if appropriate.
 // Errors are warnings, never fatal.
 func AutoCommit(ctx context.Context, configDir string, command string, args []string) {
 	cfg := config.LoadWithDefaults(configDir)
+	if cfg.SigningKey != "" {
+		if _, err := signing.SignLock(ctx, configDir, cfg.SigningKey); err != nil {
+			output.Printf("Warning: failed to sign plonk.lock: %v\n", err)
+		}
+	}
 	if !cfg.AutoCommitEnabled() {
 		return
 	}
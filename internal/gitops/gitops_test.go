@@ -376,3 +376,83 @@ func TestCommitMessage(t *testing.T) {
 		}
 	}
 }
+
+func TestInstallHook(t *testing.T) {
+	dir := initTestRepo(t)
+	client := New(dir)
+	ctx := context.Background()
+
+	path, err := client.InstallHook(ctx, "pre-commit", PreCommitHook("/opt/plonk"), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path != filepath.Join(dir, ".git", "hooks", "pre-commit") {
+		t.Errorf("hook path = %s", path)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm()&0o100 == 0 {
+		t.Errorf("hook is not executable: %v", info.Mode())
+	}
+
+	// Reinstalling over plonk's own hook is fine
+	if _, err := client.InstallHook(ctx, "pre-commit", PreCommitHook("/opt/plonk"), false); err != nil {
+		t.Errorf("reinstall: %v", err)
+	}
+
+	// Someone else's hook needs force
+	if err := os.WriteFile(path, []byte("#!/bin/sh\nexit 0\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.InstallHook(ctx, "pre-commit", PreCommitHook("/opt/plonk"), false); err == nil {
+		t.Error("expected an error replacing a foreign hook")
+	}
+	if _, err := client.InstallHook(ctx, "pre-commit", PreCommitHook("/opt/plonk"), true); err != nil {
+		t.Errorf("force: %v", err)
+	}
+}
+
+func TestInstallHook_HooksPath(t *testing.T) {
+	dir := initTestRepo(t)
+	run(t, dir, "git", "config", "core.hooksPath", "githooks")
+
+	path, err := New(dir).InstallHook(context.Background(), "pre-commit", PreCommitHook("plonk"), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path != filepath.Join(dir, "githooks", "pre-commit") {
+		t.Errorf("hook path = %s", path)
+	}
+}
+
+func TestPreCommitHook_BlocksFailingCheck(t *testing.T) {
+	dir := initTestRepo(t)
+	bin := t.TempDir()
+	log := filepath.Join(bin, "log")
+	fake := "#!/bin/sh\necho \"$PLONK_DIR $*\" >> " + log + "\n[ \"$1\" != lock ]\n"
+	if err := os.WriteFile(filepath.Join(bin, "plonk"), []byte(fake), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	if _, err := New(dir).InstallHook(context.Background(), "pre-commit", PreCommitHook("/nonexistent/plonk"), false); err != nil {
+		t.Fatal(err)
+	}
+	cmd := exec.Command("git", "commit", "--allow-empty", "-m", "blocked")
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err == nil {
+		t.Fatalf("commit succeeded despite a failing check:\n%s", out)
+	}
+
+	data, err := os.ReadFile(log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resolved, _ := filepath.EvalSymlinks(dir)
+	want := resolved + " config validate\n" + resolved + " lock verify\n"
+	if string(data) != want {
+		t.Errorf("checks run = %q, want %q", data, want)
+	}
+}
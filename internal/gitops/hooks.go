@@ -0,0 +1,83 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package gitops
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// hookMarker identifies hooks written by plonk, which it may replace
+const hookMarker = "# Installed by 'plonk hooks install-git'"
+
+// PreCommitHookChecks are the plonk commands the pre-commit hook runs
+var PreCommitHookChecks = [][]string{
+	{"config", "validate"},
+	{"lock", "verify"},
+	{"scan", "secrets"},
+}
+
+// PreCommitHook returns a pre-commit hook that runs the checks against the
+// repository being committed to, with the plonk on PATH or else fallback
+func PreCommitHook(fallback string) string {
+	var b strings.Builder
+	b.WriteString("#!/bin/sh\n")
+	b.WriteString(hookMarker + "; run it again to update this hook.\n")
+	b.WriteString("# Skip the checks once with git commit --no-verify.\n")
+	b.WriteString("plonk=$(command -v plonk || echo " + shellQuote(fallback) + ")\n")
+	b.WriteString("PLONK_DIR=$(git rev-parse --show-toplevel) || exit 1\n")
+	b.WriteString("export PLONK_DIR\n")
+	for _, check := range PreCommitHookChecks {
+		b.WriteString(`"$plonk" ` + strings.Join(check, " ") + " || exit 1\n")
+	}
+	return b.String()
+}
+
+// HooksDir returns the repository's hooks directory, honoring core.hooksPath
+func (c *Client) HooksDir(ctx context.Context) (string, error) {
+	//nolint:gosec // G204: git args are constant strings, not user input
+	cmd := exec.CommandContext(ctx, "git", "-C", c.dir, "rev-parse", "--git-path", "hooks")
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git rev-parse failed: %w\n%s", err, stderr.String())
+	}
+	dir := strings.TrimSpace(string(out))
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(c.dir, dir)
+	}
+	return dir, nil
+}
+
+// InstallHook writes the named hook with content and returns its path. A
+// hook that plonk didn't write is only replaced with force.
+func (c *Client) InstallHook(ctx context.Context, name, content string, force bool) (string, error) {
+	dir, err := c.HooksDir(ctx)
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, name)
+	if existing, err := os.ReadFile(path); err == nil && !force && !bytes.Contains(existing, []byte(hookMarker)) {
+		return "", fmt.Errorf("%s exists and was not installed by plonk; use --force to replace it", path)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, []byte(content), 0o755); err != nil {
+		return "", err
+	}
+	// WriteFile keeps the mode of an existing file
+	return path, os.Chmod(path, 0o755)
+}
+
+// shellQuote quotes s for a POSIX shell
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
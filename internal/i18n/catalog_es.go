@@ -0,0 +1,205 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package i18n
+
+// spanish translates plonk's messages into Spanish. Keep the format verbs
+// of each translation in the same order as the English message; the tests
+// check that they match.
+var spanish = map[string]string{
+	// Errors and command suggestions
+	"Error:":                               "Error:",
+	"unknown command %q for %q":            "comando desconocido %q para %q",
+	"Did you mean this?":                   "¿Quiso decir esto?",
+	"Run '%s --help' for usage":            "Ejecute '%s --help' para ver el uso",
+	"Errors:":                              "Errores:",
+	"%s errors:":                           "Errores de %s:",
+	"Remote: %s":                           "Remoto: %s",
+	"Summary:":                             "Resumen:",
+	"No managed items.":                    "No hay elementos gestionados.",
+	"No managed dotfiles.":                 "No hay dotfiles gestionados.",
+	"Installed by more than one manager:":  "Instalado por más de un gestor:",
+	"(%s wins on PATH: %s)":                "(%s gana en el PATH: %s)",
+	"(PATH has %s)":                        "(el PATH tiene %s)",
+	"Run 'plonk dedupe' to keep one copy.": "Ejecute 'plonk dedupe' para conservar una sola copia.",
+
+	// Titles
+	"Plonk Status":       "Estado de Plonk",
+	"Dotfiles Status":    "Estado de los dotfiles",
+	"Packages Status":    "Estado de los paquetes",
+	"Dotfiles":           "Dotfiles",
+	"Fleet Status":       "Estado de la flota",
+	"Garbage Collection": "Limpieza",
+	"Package Caches":     "Cachés de paquetes",
+	"Package Licenses":   "Licencias de paquetes",
+	"Package Managers":   "Gestores de paquetes",
+	"Plonk State":        "Estado guardado de Plonk",
+	"Plonk Stats":        "Estadísticas de Plonk",
+	"Snapshots":          "Instantáneas",
+	"Verification":       "Verificación",
+
+	// Table headers
+	"APPLIED":      "APLICADO",
+	"APPLIES":      "APLICA",
+	"CAPABILITIES": "CAPACIDADES",
+	"CAPABILITY":   "CAPACIDAD",
+	"COMMAND":      "COMANDO",
+	"CREATED":      "CREADO",
+	"DEPLOYED":     "DESPLEGADOS",
+	"DISK":         "DISCO",
+	"DOTFILE":      "DOTFILE",
+	"DOTFILES":     "DOTFILES",
+	"FAILED":       "FALLIDOS",
+	"FILE":         "ARCHIVO",
+	"FILES":        "ARCHIVOS",
+	"FREED":        "LIBERADO",
+	"HOST":         "EQUIPO",
+	"IMAGE":        "IMAGEN",
+	"INSTALLED":    "INSTALADOS",
+	"LICENSE":      "LICENCIA",
+	"LINE":         "LÍNEA",
+	"LOCK":         "BLOQUEO",
+	"MANAGER":      "GESTOR",
+	"MATCH":        "COINCIDENCIA",
+	"MODE":         "MODO",
+	"NAME":         "NOMBRE",
+	"PACKAGE":      "PAQUETE",
+	"PACKAGES":     "PAQUETES",
+	"PROBLEM":      "PROBLEMA",
+	"REDEPLOYED":   "REDESPLEGADOS",
+	"REPORTED":     "INFORMADO",
+	"RESOURCE":     "RECURSO",
+	"RESULT":       "RESULTADO",
+	"RULE":         "REGLA",
+	"SIZE":         "TAMAÑO",
+	"SOURCE":       "ORIGEN",
+	"STATE":        "ESTADO",
+	"STATUS":       "ESTADO",
+	"TARGET":       "DESTINO",
+	"TIME":         "HORA",
+	"TYPE":         "TIPO",
+	"VERSION":      "VERSIÓN",
+	"WEEK OF":      "SEMANA DEL",
+
+	// Item states
+	"managed":                 "gestionado",
+	"missing":                 "ausente",
+	"drifted":                 "modificado",
+	"deployed":                "desplegado",
+	"error":                   "error",
+	"(via %s)":                "(mediante %s)",
+	"(as dependency)":         "(como dependencia)",
+	"(checked %s)":            "(comprobado %s)",
+	"just now":                "ahora mismo",
+	"%dm ago":                 "hace %dm",
+	"%dh ago":                 "hace %dh",
+	"%dd ago":                 "hace %dd",
+	"missing (%s, use %s)":    "ausente (%s, use %s)",
+	"missing (renamed to %s)": "ausente (renombrado a %s)",
+	"Tracked packages installed as dependencies are removed by 'brew autoremove'; keep them with:": "'brew autoremove' elimina los paquetes seguidos que se instalaron como dependencias; consérvelos con:",
+	"Run 'plonk fix --renames' to update renamed packages in plonk.lock.":                          "Ejecute 'plonk fix --renames' para actualizar los paquetes renombrados en plonk.lock.",
+
+	// Summaries
+	"%d managed":  "%d gestionados",
+	"%d missing":  "%d ausentes",
+	"%d drifted":  "%d modificados",
+	"%d errors":   "%d errores",
+	"%d error(s)": "%d error(es)",
+	"%d managed, %d missing, %d drifted, %d errors": "%d gestionados, %d ausentes, %d modificados, %d errores",
+	"%d managed, %d missing, %d errors":             "%d gestionados, %d ausentes, %d errores",
+	"%d packages installed":                         "%d paquetes instalados",
+	"%d packages would install":                     "se instalarían %d paquetes",
+	"%d dotfiles deployed":                          "%d dotfiles desplegados",
+	"%d dotfiles would deploy":                      "se desplegarían %d dotfiles",
+	"%d resources applied":                          "%d recursos aplicados",
+	"%d resources would apply":                      "se aplicarían %d recursos",
+	"%d failed (%s)":                                "%d fallidos (%s)",
+	"%s (%d pass, %d warn, %d fail)":                "%s (%d correctas, %d avisos, %d fallos)",
+
+	// plonk doctor
+	"Plonk Doctor Report": "Informe de Plonk Doctor",
+	"Overall Status: %s":  "Estado general: %s",
+	"HEALTHY":             "CORRECTO",
+	"WARNING":             "AVISO",
+	"UNHEALTHY":           "CON PROBLEMAS",
+	"healthy":             "correcto",
+	"warning":             "aviso",
+	"unhealthy":           "con problemas",
+	"PASS":                "CORRECTO",
+	"WARN":                "AVISO",
+	"FAIL":                "FALLO",
+	"INFO":                "INFO",
+	"UNKNOWN":             "DESCONOCIDO",
+	"**Status**: %s":      "**Estado**: %s",
+	"**Message**: %s":     "**Mensaje**: %s",
+	"**Details:**":        "**Detalles:**",
+	"**Issues:**":         "**Problemas:**",
+	"**Suggestions:**":    "**Sugerencias:**",
+
+	"System":                   "Sistema",
+	"Environment":              "Entorno",
+	"Permissions":              "Permisos",
+	"Configuration":            "Configuración",
+	"Installation":             "Instalación",
+	"All systems operational":  "Todo funciona correctamente",
+	"Critical issues detected": "Se detectaron problemas críticos",
+	"Some issues detected":     "Se detectaron algunos problemas",
+
+	"System Requirements":    "Requisitos del sistema",
+	"Environment Variables":  "Variables de entorno",
+	"Configuration File":     "Archivo de configuración",
+	"Configuration Validity": "Validez de la configuración",
+	"Lock File":              "Archivo de bloqueo",
+	"Lock File Signature":    "Firma del archivo de bloqueo",
+	"Lock File Validity":     "Validez del archivo de bloqueo",
+	"Proxies":                "Proxies",
+	"Template Readiness":     "Plantillas listas",
+	"Executable Path":        "Ruta del ejecutable",
+
+	"System requirements met":                                            "Se cumplen los requisitos del sistema",
+	"System requirements not met":                                        "No se cumplen los requisitos del sistema",
+	"Environment variables configured":                                   "Variables de entorno configuradas",
+	"Critical environment variables missing":                             "Faltan variables de entorno críticas",
+	"File permissions are correct":                                       "Los permisos de archivo son correctos",
+	"Permission issues detected":                                         "Se detectaron problemas de permisos",
+	"Config directory is not writable":                                   "No se puede escribir en el directorio de configuración",
+	"Configuration file exists":                                          "El archivo de configuración existe",
+	"Configuration file does not exist (using defaults)":                 "El archivo de configuración no existe (se usan los valores predeterminados)",
+	"Configuration file is not readable":                                 "No se puede leer el archivo de configuración",
+	"Configuration is valid":                                             "La configuración es válida",
+	"No config file found (using defaults)":                              "No se encontró archivo de configuración (se usan los valores predeterminados)",
+	"Configuration has format errors":                                    "La configuración tiene errores de formato",
+	"Lock file exists":                                                   "El archivo de bloqueo existe",
+	"Lock file does not exist (will be created when packages are added)": "El archivo de bloqueo no existe (se creará al añadir paquetes)",
+	"Lock file is not readable":                                          "No se puede leer el archivo de bloqueo",
+	"Lock file is empty":                                                 "El archivo de bloqueo está vacío",
+	"plonk.lock signature is valid":                                      "La firma de plonk.lock es válida",
+	"plonk.lock signatures are not verified on this machine":             "Las firmas de plonk.lock no se verifican en esta máquina",
+	"plonk.lock signature does not verify":                               "La firma de plonk.lock no es válida",
+	"Lock file is valid":                                                 "El archivo de bloqueo es válido",
+	"Lock file has format errors":                                        "El archivo de bloqueo tiene errores de formato",
+	"Lock file is valid but contains no packages":                        "El archivo de bloqueo es válido pero no contiene paquetes",
+	"No package managers configured":                                     "No hay gestores de paquetes configurados",
+	"All required package managers are disabled":                         "Todos los gestores de paquetes necesarios están desactivados",
+	"All required package managers are missing":                          "Faltan todos los gestores de paquetes necesarios",
+	"No proxies configured":                                              "No hay proxies configurados",
+	"All template variables are available":                               "Todas las variables de plantilla están disponibles",
+	"Executable is accessible":                                           "El ejecutable es accesible",
+	"Executable not in PATH":                                             "El ejecutable no está en el PATH",
+
+	"PATH environment variable is not set": "La variable de entorno PATH no está definida",
+	"plonk executable not found in PATH":   "No se encontró el ejecutable de plonk en el PATH",
+
+	"Ensure HOME environment variable is set correctly":                                                                                     "Compruebe que la variable de entorno HOME esté bien definida",
+	"Set PATH environment variable in your shell configuration":                                                                             "Defina la variable de entorno PATH en la configuración de su shell",
+	"Check permissions for the config directory":                                                                                            "Revise los permisos del directorio de configuración",
+	"Ensure config directory is writable":                                                                                                   "Asegúrese de que se pueda escribir en el directorio de configuración",
+	"Check file permissions and directory access":                                                                                           "Revise los permisos de archivos y el acceso a directorios",
+	"Validate config file format or regenerate with 'plonk init'":                                                                           "Valide el formato del archivo de configuración o vuelva a generarlo con 'plonk init'",
+	"Review plonk.lock changes, then re-sign from a machine with signing_key set":                                                           "Revise los cambios de plonk.lock y vuelva a firmarlo desde una máquina con signing_key definido",
+	"Validate lock file format or regenerate by running 'plonk pkg add' commands":                                                           "Valide el formato del archivo de bloqueo o vuelva a generarlo con comandos 'plonk pkg add'",
+	"Check the proxy settings under managers: in plonk.yaml and HTTP_PROXY/HTTPS_PROXY, or whether you are on the network the proxy serves": "Revise la configuración de proxy en managers: de plonk.yaml y HTTP_PROXY/HTTPS_PROXY, o si está en la red a la que sirve el proxy",
+	"Set the missing environment variables or add them to the template's vars in plonk.yaml":                                                "Defina las variables de entorno que faltan o añádalas a las vars de la plantilla en plonk.yaml",
+	"Run 'plonk apply' in a terminal to answer them":                                                                                        "Ejecute 'plonk apply' en una terminal para responderlas",
+	"Add plonk installation directory to PATH":                                                                                              "Añada el directorio de instalación de plonk al PATH",
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+// Package i18n translates plonk's user-facing messages.
+//
+// Messages are looked up by their English text, as with gettext, so code
+// reads naturally and a message missing from a catalog falls back to
+// English. Only human-readable output is translated: JSON, YAML,
+// --porcelain and --summary json stay English for scripts.
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// English is the locale messages are written in. It needs no catalog.
+const English = "en"
+
+// catalogs maps a locale to its translations, keyed by English message
+var catalogs = map[string]map[string]string{
+	"es": spanish,
+}
+
+var (
+	mu      sync.RWMutex
+	locale  = English
+	catalog map[string]string // nil for English
+)
+
+// Locales returns the supported locales, English first
+func Locales() []string {
+	locales := []string{English}
+	for l := range catalogs {
+		locales = append(locales, l)
+	}
+	sort.Strings(locales[1:])
+	return locales
+}
+
+// SetLocale makes T translate into locale, given as a language ("es") or a
+// POSIX locale name ("es_ES.UTF-8")
+func SetLocale(name string) error {
+	lang := language(name)
+	if lang != English {
+		if _, ok := catalogs[lang]; !ok {
+			return fmt.Errorf("unsupported locale: %s (use %s)", name, strings.Join(Locales(), ", "))
+		}
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	locale, catalog = lang, catalogs[lang]
+	return nil
+}
+
+// Locale returns the locale T translates into
+func Locale() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return locale
+}
+
+// Detect returns the locale plonk should speak: $PLONK_LANG, else the first
+// of $LC_ALL, $LC_MESSAGES and $LANG that is set, as POSIX orders them.
+// Unsupported locales fall back to English.
+func Detect() string {
+	for _, key := range []string{"PLONK_LANG", "LC_ALL", "LC_MESSAGES", "LANG"} {
+		value := os.Getenv(key)
+		if value == "" {
+			continue
+		}
+		if lang := language(value); lang == English || catalogs[lang] != nil {
+			return lang
+		}
+		return English
+	}
+	return English
+}
+
+// language reduces a locale name such as "es_MX.UTF-8@euro" or "es-MX" to
+// its language. The C and POSIX locales are English.
+func language(name string) string {
+	lang := strings.ToLower(name)
+	if i := strings.IndexAny(lang, "_-.@"); i >= 0 {
+		lang = lang[:i]
+	}
+	switch lang {
+	case "", "c", "posix":
+		return English
+	}
+	return lang
+}
+
+// T returns msg in the current locale, formatted with args as by
+// fmt.Sprintf when any are given
+func T(msg string, args ...any) string {
+	mu.RLock()
+	if translated, ok := catalog[msg]; ok {
+		msg = translated
+	}
+	mu.RUnlock()
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package i18n
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// useLocale switches the locale for one test
+func useLocale(t *testing.T, name string) {
+	t.Helper()
+	require.NoError(t, SetLocale(name))
+	t.Cleanup(func() { _ = SetLocale(English) })
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want string
+	}{
+		{"nothing set", nil, English},
+		{"LANG", map[string]string{"LANG": "es_ES.UTF-8"}, "es"},
+		{"LC_ALL beats LANG", map[string]string{"LC_ALL": "C", "LANG": "es_ES.UTF-8"}, English},
+		{"LC_MESSAGES beats LANG", map[string]string{"LC_MESSAGES": "es_MX", "LANG": "en_US.UTF-8"}, "es"},
+		{"PLONK_LANG beats all", map[string]string{"PLONK_LANG": "en", "LC_ALL": "es_ES.UTF-8"}, English},
+		{"unsupported falls back", map[string]string{"LANG": "fr_FR.UTF-8"}, English},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{"PLONK_LANG", "LC_ALL", "LC_MESSAGES", "LANG"} {
+				t.Setenv(key, tt.env[key])
+			}
+			assert.Equal(t, tt.want, Detect())
+		})
+	}
+}
+
+func TestSetLocale(t *testing.T) {
+	useLocale(t, "es-MX")
+	assert.Equal(t, "es", Locale())
+
+	err := SetLocale("fr_FR.UTF-8")
+	assert.ErrorContains(t, err, "unsupported locale: fr_FR.UTF-8 (use en, es)")
+	assert.Equal(t, "es", Locale(), "a failed switch keeps the locale")
+}
+
+func TestT(t *testing.T) {
+	assert.Equal(t, "3 managed", T("%d managed", 3))
+	assert.Equal(t, "100% untranslated", T("100% untranslated"), "messages without args are not formatted")
+
+	useLocale(t, "es")
+	assert.Equal(t, "3 gestionados", T("%d managed", 3))
+	assert.Equal(t, "Not in the catalog: 3", T("Not in the catalog: %d", 3), "missing messages fall back to English")
+}
+
+// formatVerb matches the verbs fmt.Sprintf substitutes, ignoring %%
+var formatVerb = regexp.MustCompile(`%[-+# 0]*[0-9]*(?:\.[0-9]+)?[a-zA-Z]`)
+
+func TestCatalogs_KeepFormatVerbs(t *testing.T) {
+	for locale, catalog := range catalogs {
+		for msg, translated := range catalog {
+			assert.Equal(t, formatVerb.FindAllString(msg, -1), formatVerb.FindAllString(translated, -1),
+				"%s translation of %q", locale, msg)
+		}
+	}
+}
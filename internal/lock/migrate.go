@@ -0,0 +1,109 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package lock
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/richhaase/plonk/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+// CurrentVersion is the lock format this version of plonk reads and writes
+const CurrentVersion = 3
+
+// lockMigrations upgrade raw lock data by one version, keyed by the version
+// they read. Add an entry here when the format changes, so older files keep
+// loading: each step only needs to know about its neighbour.
+var lockMigrations = map[int]func(data []byte) ([]byte, error){
+	2: func(data []byte) ([]byte, error) {
+		v3, err := migrateV2(data)
+		if err != nil {
+			return nil, err
+		}
+		return yaml.Marshal(v3)
+	},
+}
+
+// Pinned reports whether plonk.lock must be left as it is on disk, as on a
+// machine that verifies its signature: a lock rewritten by a migration no
+// longer matches the signature. Read then migrates in memory only. The
+// signing package sets it.
+var Pinned func() bool
+
+// Migrate upgrades the lock file to CurrentVersion in place, keeping the
+// original in the backup directory. It returns the version found and the
+// backup path, which is empty when the file was already current or absent.
+func (s *LockV3Service) Migrate() (from int, backup string, err error) {
+	data, err := os.ReadFile(s.lockPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return CurrentVersion, "", nil
+		}
+		return 0, "", fmt.Errorf("failed to read lock file: %w", err)
+	}
+
+	var header struct {
+		Version int `yaml:"version"`
+	}
+	if err := yaml.Unmarshal(data, &header); err != nil {
+		return 0, "", fmt.Errorf("failed to parse lock file: %w", err)
+	}
+	if header.Version == CurrentVersion {
+		return CurrentVersion, "", nil
+	}
+
+	_, backup, err = s.migrate(data, header.Version)
+	return header.Version, backup, err
+}
+
+// migrate runs the migrations from version up to CurrentVersion, backs up
+// the original data and writes the result
+func (s *LockV3Service) migrate(data []byte, version int) (*LockV3, string, error) {
+	lock, err := upgrade(data, version)
+	if err != nil {
+		return nil, "", err
+	}
+
+	backupDir := config.GetBackupDirectory(filepath.Dir(s.lockPath))
+	backup := filepath.Join(backupDir, fmt.Sprintf("%s.v%d.bak", LockFileName, version))
+	if err := os.MkdirAll(backupDir, 0755); err != nil {
+		return nil, "", fmt.Errorf("failed to create backup directory: %w", err)
+	}
+	if err := os.WriteFile(backup, data, 0644); err != nil {
+		return nil, "", fmt.Errorf("failed to back up lock file: %w", err)
+	}
+
+	if err := s.Write(lock); err != nil {
+		return nil, "", fmt.Errorf("failed to persist lock migration: %w", err)
+	}
+	return lock, backup, nil
+}
+
+// upgrade runs the migrations from version up to CurrentVersion on data
+func upgrade(data []byte, version int) (*LockV3, error) {
+	if version > CurrentVersion {
+		return nil, fmt.Errorf("unsupported lock version %d: this plonk reads up to version %d, upgrade plonk", version, CurrentVersion)
+	}
+
+	migrated := data
+	for v := version; v < CurrentVersion; v++ {
+		step, ok := lockMigrations[v]
+		if !ok {
+			return nil, fmt.Errorf("unsupported lock version %d", version)
+		}
+		var err error
+		if migrated, err = step(migrated); err != nil {
+			return nil, fmt.Errorf("failed to migrate lock from version %d: %w", v, err)
+		}
+	}
+
+	var lock LockV3
+	if err := yaml.Unmarshal(migrated, &lock); err != nil {
+		return nil, fmt.Errorf("failed to parse migrated lock: %w", err)
+	}
+	return &lock, nil
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package lock
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const v2Lock = `version: 2
+resources:
+  - type: package
+    metadata:
+      manager: brew
+      name: ripgrep
+`
+
+func TestLockV3Service_Migrate(t *testing.T) {
+	dir := t.TempDir()
+	lockPath := filepath.Join(dir, LockFileName)
+	require.NoError(t, os.WriteFile(lockPath, []byte(v2Lock), 0644))
+	svc := NewLockV3Service(dir)
+
+	from, backup, err := svc.Migrate()
+	require.NoError(t, err)
+	assert.Equal(t, 2, from)
+	assert.Equal(t, filepath.Join(dir, ".backups", "plonk.lock.v2.bak"), backup)
+
+	original, err := os.ReadFile(backup)
+	require.NoError(t, err)
+	assert.Equal(t, v2Lock, string(original), "backup holds the original bytes")
+
+	lock, err := svc.Read()
+	require.NoError(t, err)
+	assert.True(t, lock.HasPackage("brew", "ripgrep"))
+
+	// Already current: nothing to do
+	from, backup, err = svc.Migrate()
+	require.NoError(t, err)
+	assert.Equal(t, CurrentVersion, from)
+	assert.Empty(t, backup)
+}
+
+func TestLockV3Service_ReadBacksUpBeforeMigrating(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, LockFileName), []byte(v2Lock), 0644))
+
+	_, err := NewLockV3Service(dir).Read()
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(dir, ".backups", "plonk.lock.v2.bak"))
+}
+
+func TestLockV3Service_MigrateMissingFile(t *testing.T) {
+	from, backup, err := NewLockV3Service(t.TempDir()).Migrate()
+	require.NoError(t, err)
+	assert.Equal(t, CurrentVersion, from)
+	assert.Empty(t, backup)
+}
+
+func TestLockV3Service_MigrateNewerVersion(t *testing.T) {
+	dir := t.TempDir()
+	lockPath := filepath.Join(dir, LockFileName)
+	require.NoError(t, os.WriteFile(lockPath, []byte("version: 4\n"), 0644))
+
+	_, _, err := NewLockV3Service(dir).Migrate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "upgrade plonk")
+
+	data, err := os.ReadFile(lockPath)
+	require.NoError(t, err)
+	assert.Equal(t, "version: 4\n", string(data), "a newer file is never rewritten")
+}
+
+func TestLockV3Service_ReadPinnedMigratesInMemory(t *testing.T) {
+	Pinned = func() bool { return true }
+	t.Cleanup(func() { Pinned = nil })
+	dir := t.TempDir()
+	lockPath := filepath.Join(dir, LockFileName)
+	require.NoError(t, os.WriteFile(lockPath, []byte(v2Lock), 0644))
+
+	lock, err := NewLockV3Service(dir).Read()
+	require.NoError(t, err)
+	assert.True(t, lock.HasPackage("brew", "ripgrep"))
+
+	data, err := os.ReadFile(lockPath)
+	require.NoError(t, err)
+	assert.Equal(t, v2Lock, string(data), "a pinned lock is never rewritten")
+	assert.NoDirExists(t, filepath.Join(dir, ".backups"))
+}
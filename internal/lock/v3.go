@@ -110,41 +110,85 @@ func NewLockV3Service(configDir string) *LockV3Service {
 	}
 }
 
-// Read reads the lock file, auto-migrating v2 if needed
+// Read reads the lock file, migrating older versions in place (see Migrate)
+// unless the lock is Pinned
 func (s *LockV3Service) Read() (*LockV3, error) {
-	// If lock file doesn't exist, return empty lock
-	if _, err := os.Stat(s.lockPath); os.IsNotExist(err) {
-		return NewLockV3(), nil
-	}
-
 	data, err := os.ReadFile(s.lockPath)
 	if err != nil {
+		// If lock file doesn't exist, return empty lock
+		if os.IsNotExist(err) {
+			return NewLockV3(), nil
+		}
 		return nil, fmt.Errorf("failed to read lock file: %w", err)
 	}
 
-	// Try to detect version
-	var versionCheck struct {
-		Version int `yaml:"version"`
-	}
-	if err := yaml.Unmarshal(data, &versionCheck); err != nil {
+	// Parse once into a node tree and decode from that, rather than
+	// re-parsing the whole file after detecting the version
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
 		return nil, fmt.Errorf("failed to parse lock file: %w", err)
 	}
 
-	// Handle v2 migration
-	if versionCheck.Version == 2 {
-		return s.migrateV2(data)
+	var lock LockV3
+	if doc.Kind != 0 {
+		if err := doc.Decode(&lock); err != nil {
+			return nil, fmt.Errorf("failed to parse lock file: %w", err)
+		}
 	}
 
-	// Parse v3
+	if lock.Version != CurrentVersion {
+		if Pinned != nil && Pinned() {
+			return upgrade(data, lock.Version)
+		}
+		migrated, _, err := s.migrate(data, lock.Version)
+		return migrated, err
+	}
+
+	return &lock, nil
+}
+
+// Check reads the lock file without migrating it and reports problems a
+// hand edit or a bad merge can leave: another format version, empty
+// manager or package names and packages listed twice
+func (s *LockV3Service) Check() (*LockV3, error) {
+	data, err := os.ReadFile(s.lockPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return NewLockV3(), nil
+		}
+		return nil, fmt.Errorf("failed to read lock file: %w", err)
+	}
 	var lock LockV3
 	if err := yaml.Unmarshal(data, &lock); err != nil {
 		return nil, fmt.Errorf("failed to parse lock file: %w", err)
 	}
-
-	if lock.Version != 3 {
-		return nil, fmt.Errorf("unsupported lock version %d", lock.Version)
+	switch {
+	case lock.Version < CurrentVersion:
+		return nil, fmt.Errorf("lock file is version %d; run plonk migrate", lock.Version)
+	case lock.Version > CurrentVersion:
+		return nil, fmt.Errorf("unsupported lock version %d: this plonk reads up to version %d, upgrade plonk", lock.Version, CurrentVersion)
 	}
 
+	var problems []string
+	for manager, pkgs := range lock.Packages {
+		if manager == "" {
+			problems = append(problems, "a package list has no manager name")
+		}
+		seen := make(map[string]bool, len(pkgs))
+		for _, pkg := range pkgs {
+			switch {
+			case strings.TrimSpace(pkg) == "":
+				problems = append(problems, fmt.Sprintf("%s has an empty package name", manager))
+			case seen[pkg]:
+				problems = append(problems, fmt.Sprintf("%s:%s is listed twice", manager, pkg))
+			}
+			seen[pkg] = true
+		}
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return nil, fmt.Errorf("invalid lock file: %s", strings.Join(problems, "; "))
+	}
 	return &lock, nil
 }
 
@@ -183,8 +227,8 @@ func (s *LockV3Service) Write(lock *LockV3) error {
 	return nil
 }
 
-// migrateV2 converts a v2 lock to v3 format and persists it
-func (s *LockV3Service) migrateV2(data []byte) (*LockV3, error) {
+// migrateV2 converts a v2 lock to the v3 format
+func migrateV2(data []byte) (*LockV3, error) {
 	var old lockV2
 	if err := yaml.Unmarshal(data, &old); err != nil {
 		return nil, fmt.Errorf("failed to parse v2 lock: %w", err)
@@ -225,11 +269,6 @@ func (s *LockV3Service) migrateV2(data []byte) (*LockV3, error) {
 		}
 	}
 
-	// Persist the migrated v3 format to disk
-	if err := s.Write(v3); err != nil {
-		return nil, fmt.Errorf("failed to persist v2 migration: %w", err)
-	}
-
 	return v3, nil
 }
 
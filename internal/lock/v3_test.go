@@ -342,3 +342,36 @@ func TestLockV3Service_UnsupportedVersion(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "unsupported lock version 99")
 }
+
+func TestLockV3Service_Check(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"valid", "version: 3\npackages:\n  brew: [ripgrep, fd]\n", ""},
+		{"old version", "version: 2\nresources: []\n", "run plonk migrate"},
+		{"duplicate", "version: 3\npackages:\n  brew: [fd, ripgrep, fd]\n", "brew:fd is listed twice"},
+		{"empty name", "version: 3\npackages:\n  cargo: [\"\"]\n", "cargo has an empty package name"},
+		{"conflict markers", "version: 3\n<<<<<<< HEAD\npackages: {}\n", "failed to parse lock file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			lockPath := filepath.Join(dir, LockFileName)
+			require.NoError(t, os.WriteFile(lockPath, []byte(tt.content), 0644))
+
+			_, err := NewLockV3Service(dir).Check()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			}
+
+			data, err := os.ReadFile(lockPath)
+			require.NoError(t, err)
+			assert.Equal(t, tt.content, string(data), "Check never rewrites the lock")
+		})
+	}
+}
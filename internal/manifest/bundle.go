@@ -0,0 +1,92 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package manifest
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// BundleEntry is one package line of a Plonkfile or Brewfile
+type BundleEntry struct {
+	Manager string // plonk manager, e.g. "brew" for both brew and cask lines
+	Name    string
+	Line    int
+}
+
+// BundleSkip is a line of a Plonkfile that names no package plonk can track
+type BundleSkip struct {
+	Line   int
+	Text   string
+	Reason string
+}
+
+// bundlePattern matches `<keyword> "name"` with optional Ruby-style
+// options after a comma, e.g. `brew "mysql", restart_service: true`
+var bundlePattern = regexp.MustCompile(`^([a-z][a-z0-9_-]*)\s*\(?\s*["']([^"']+)["']\s*\)?\s*(,.*)?$`)
+
+// bundleKeywords maps Brewfile keywords to the plonk manager that installs
+// them; any other keyword is taken as a manager name
+var bundleKeywords = map[string]string{
+	"cask": "brew",
+}
+
+// bundleUnsupported are Brewfile keywords with no plonk equivalent
+var bundleUnsupported = map[string]string{
+	"tap":       "taps are added by installing a tap-qualified name, e.g. brew \"user/tap/formula\"",
+	"mas":       "Mac App Store apps are not managed by plonk",
+	"vscode":    "VS Code extensions are not managed by plonk",
+	"whalebrew": "whalebrew images are not managed by plonk",
+	"cask_args": "cask options are not supported",
+}
+
+// ParseBundle reads a Plonkfile: Brewfile syntax where each line names a
+// manager and a package, e.g. `brew "ripgrep"`, `cask "firefox"` or
+// `cargo "bat"`. Options after the name are ignored. Lines plonk can't
+// track are returned as skipped; a line that isn't a declaration at all is
+// an error, since a Plonkfile is not run as Ruby.
+func ParseBundle(data []byte) ([]BundleEntry, []BundleSkip, error) {
+	var entries []BundleEntry
+	var skipped []BundleSkip
+	seen := make(map[string]bool)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+
+		m := bundlePattern.FindStringSubmatch(line)
+		if m == nil {
+			return nil, nil, fmt.Errorf("line %d: expected `<manager> \"<package>\"`, got %q", lineNo, line)
+		}
+		keyword, name := m[1], m[2]
+		if reason, ok := bundleUnsupported[keyword]; ok {
+			skipped = append(skipped, BundleSkip{Line: lineNo, Text: line, Reason: reason})
+			continue
+		}
+		manager := keyword
+		if mapped, ok := bundleKeywords[keyword]; ok {
+			manager = mapped
+		}
+
+		spec := manager + ":" + name
+		if seen[spec] {
+			continue
+		}
+		seen[spec] = true
+		entries = append(entries, BundleEntry{Manager: manager, Name: name, Line: lineNo})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, err
+	}
+	return entries, skipped, nil
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+// Package manifest reads language package manifests (package.json,
+// requirements.txt, Gemfile) so their packages can be brought under plonk.
+package manifest
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Entry is one package named in a manifest
+type Entry struct {
+	Name    string
+	Version string // constraint as written, e.g. "^5.4.0" or "==0.5.0"; empty if none
+}
+
+// ParsePackageJSON returns the dependencies and devDependencies of a
+// package.json, sorted by name. The output of 'npm ls -g --json', whose
+// dependencies map to objects with a version, is accepted too.
+func ParsePackageJSON(data []byte) ([]Entry, error) {
+	var doc struct {
+		Dependencies    map[string]json.RawMessage `json:"dependencies"`
+		DevDependencies map[string]json.RawMessage `json:"devDependencies"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid package.json: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	var entries []Entry
+	for _, deps := range []map[string]json.RawMessage{doc.Dependencies, doc.DevDependencies} {
+		for name, raw := range deps {
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+
+			var version string
+			if err := json.Unmarshal(raw, &version); err != nil {
+				var installed struct {
+					Version string `json:"version"`
+				}
+				if err := json.Unmarshal(raw, &installed); err != nil {
+					return nil, fmt.Errorf("invalid package.json: dependency %s: %w", name, err)
+				}
+				version = installed.Version
+			}
+			entries = append(entries, Entry{Name: name, Version: version})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+	return entries, nil
+}
+
+// requirementPattern splits a requirement into name, extras and version
+// specifier, e.g. "black[jupyter]>=24.1"
+var requirementPattern = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)\s*(\[[^\]]*\])?\s*(.*)$`)
+
+// nameSeparators matches the runs of separators PEP 503 normalizes to "-"
+var nameSeparators = regexp.MustCompile(`[-_.]+`)
+
+// Requirement is a pip requirement split into its parts, e.g.
+// ansible[azure]>=9,<10
+type Requirement struct {
+	Name      string // ansible
+	Extras    string // [azure], including brackets
+	Specifier string // >=9,<10, without spaces
+}
+
+// ParseRequirement splits a pip requirement into its parts. It reports
+// false when s doesn't start with a package name.
+func ParseRequirement(s string) (Requirement, bool) {
+	m := requirementPattern.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return Requirement{}, false
+	}
+	return Requirement{Name: m[1], Extras: m[2], Specifier: strings.ReplaceAll(m[3], " ", "")}, true
+}
+
+// NormalizeName normalizes a Python package name as PEP 503 does, so
+// Ruff_LSP and ruff-lsp compare equal
+func NormalizeName(name string) string {
+	return strings.ToLower(nameSeparators.ReplaceAllString(name, "-"))
+}
+
+// ParseRequirements returns the packages in a pip requirements file.
+// Comments, environment markers and extras are dropped, names are
+// normalized the way pip and uv report them, and options such as -r, -e
+// or --index-url are skipped.
+func ParseRequirements(data []byte) ([]Entry, error) {
+	var entries []Entry
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := scanner.Text()
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "-") {
+			continue
+		}
+		// Drop environment markers and per-requirement options like --hash
+		for _, sep := range []string{";", " --", "\\"} {
+			if i := strings.Index(line, sep); i >= 0 {
+				line = strings.TrimSpace(line[:i])
+			}
+		}
+
+		req, ok := ParseRequirement(line)
+		if !ok || strings.Contains(req.Specifier, "://") || strings.HasPrefix(req.Specifier, "@") {
+			return nil, fmt.Errorf("line %d: unsupported requirement %q", lineNo, line)
+		}
+		name := NormalizeName(req.Name)
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		entries = append(entries, Entry{Name: name, Version: req.Specifier})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// gemPattern matches a gem declaration and its first version constraint,
+// e.g. gem "rubocop", "~> 1.60", require: false
+var gemPattern = regexp.MustCompile(`^gem\s*\(?\s*["']([^"']+)["']\s*(?:,\s*["']([^"']+)["'])?`)
+
+// ParseGemfile returns the gems declared in a Gemfile. Only literal gem
+// lines are understood; groups, sources and other Ruby are ignored.
+func ParseGemfile(data []byte) ([]Entry, error) {
+	var entries []Entry
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		m := gemPattern.FindStringSubmatch(strings.TrimSpace(scanner.Text()))
+		if m == nil || seen[m[1]] {
+			continue
+		}
+		seen[m[1]] = true
+		entries = append(entries, Entry{Name: m[1], Version: m[2]})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package manifest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePackageJSON(t *testing.T) {
+	entries, err := ParsePackageJSON([]byte(`{
+  "name": "app",
+  "dependencies": {"typescript": "^5.4.0", "@biomejs/biome": "1.8.3"},
+  "devDependencies": {"prettier": "*", "typescript": "5.0.0"}
+}`))
+	require.NoError(t, err)
+	assert.Equal(t, []Entry{
+		{Name: "@biomejs/biome", Version: "1.8.3"},
+		{Name: "prettier", Version: "*"},
+		{Name: "typescript", Version: "^5.4.0"},
+	}, entries)
+
+	// npm ls -g --json
+	entries, err = ParsePackageJSON([]byte(`{"dependencies": {"npm": {"version": "10.5.0"}}}`))
+	require.NoError(t, err)
+	assert.Equal(t, []Entry{{Name: "npm", Version: "10.5.0"}}, entries)
+
+	_, err = ParsePackageJSON([]byte(`{"dependencies": {"x": 1}}`))
+	assert.ErrorContains(t, err, "dependency x")
+}
+
+func TestParseRequirements(t *testing.T) {
+	entries, err := ParseRequirements([]byte(`# tools
+-r base.txt
+--index-url https://example.com/simple
+ruff==0.5.0
+Black[jupyter] >= 24.1  # formatter
+pre_commit
+httpie ; python_version >= "3.8"
+mypy==1.10 --hash=sha256:abc
+ruff
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []Entry{
+		{Name: "ruff", Version: "==0.5.0"},
+		{Name: "black", Version: ">=24.1"},
+		{Name: "pre-commit"},
+		{Name: "httpie"},
+		{Name: "mypy", Version: "==1.10"},
+	}, entries)
+
+	_, err = ParseRequirements([]byte("tool @ https://example.com/tool.whl\n"))
+	assert.ErrorContains(t, err, "line 1: unsupported requirement")
+}
+
+func TestParseRequirement(t *testing.T) {
+	req, ok := ParseRequirement("ansible[azure]>=9,<10")
+	assert.True(t, ok)
+	assert.Equal(t, Requirement{Name: "ansible", Extras: "[azure]", Specifier: ">=9,<10"}, req)
+
+	req, ok = ParseRequirement(" black ~= 24.1")
+	assert.True(t, ok)
+	assert.Equal(t, Requirement{Name: "black", Specifier: "~=24.1"}, req)
+
+	_, ok = ParseRequirement("@scope/pkg")
+	assert.False(t, ok)
+}
+
+func TestNormalizeName(t *testing.T) {
+	assert.Equal(t, "ruff-lsp", NormalizeName("Ruff_LSP"))
+	assert.Equal(t, "zope-interface", NormalizeName("zope.__interface"))
+}
+
+func TestParseGemfile(t *testing.T) {
+	entries, err := ParseGemfile([]byte(`source "https://rubygems.org"
+
+gem "rubocop", "~> 1.60", require: false
+group :development do
+  gem 'solargraph'
+end
+gem("rake")
+# gem "commented"
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []Entry{
+		{Name: "rubocop", Version: "~> 1.60"},
+		{Name: "solargraph"},
+		{Name: "rake"},
+	}, entries)
+}
+
+func TestParseBundle(t *testing.T) {
+	entries, skipped, err := ParseBundle([]byte(`# Plonkfile
+tap "homebrew/cask-fonts"
+brew "ripgrep"
+brew "mysql", restart_service: :changed
+cask "firefox"  # browser
+cargo 'bat'
+go "golang.org/x/tools/gopls"
+brew "ripgrep"
+mas "Xcode", id: 497799835
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []BundleEntry{
+		{Manager: "brew", Name: "ripgrep", Line: 3},
+		{Manager: "brew", Name: "mysql", Line: 4},
+		{Manager: "brew", Name: "firefox", Line: 5},
+		{Manager: "cargo", Name: "bat", Line: 6},
+		{Manager: "go", Name: "golang.org/x/tools/gopls", Line: 7},
+	}, entries)
+	require.Len(t, skipped, 2)
+	assert.Equal(t, 2, skipped[0].Line)
+	assert.Contains(t, skipped[0].Reason, "tap-qualified")
+	assert.Equal(t, 9, skipped[1].Line)
+
+	_, _, err = ParseBundle([]byte("brew \"jq\"\nif OS.mac?\n"))
+	assert.ErrorContains(t, err, "line 2")
+}
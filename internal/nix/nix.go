@@ -0,0 +1,197 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+// Package nix renders plonk.lock as a home-manager module or flake, for
+// users trying out Nix without retyping their package list.
+package nix
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// Package is one tracked package and its nixpkgs attribute, if known
+type Package struct {
+	Spec      string // "manager:name"
+	Attribute string // e.g. "ripgrep" or "nodePackages.prettier"; empty if unmapped
+}
+
+// renames maps brew formulae and cargo crates whose nixpkgs attribute
+// differs from their name
+var renames = map[string]string{
+	"brew:awscli":              "awscli2",
+	"brew:git-delta":           "delta",
+	"brew:gnu-sed":             "gnused",
+	"brew:gnu-tar":             "gnutar",
+	"brew:grep":                "gnugrep",
+	"brew:node":                "nodejs",
+	"brew:openjdk":             "jdk",
+	"brew:python":              "python3",
+	"brew:python3":             "python3",
+	"brew:the_silver_searcher": "silver-searcher",
+	"cargo:du-dust":            "dust",
+	"cargo:fd-find":            "fd",
+	"cargo:git-delta":          "delta",
+}
+
+// versionedFormula matches versioned brew formulae such as python@3.12
+var versionedFormula = regexp.MustCompile(`^([a-z]+)@(\d+)(?:\.(\d+))?$`)
+
+// majorVersion matches the /vN suffix of a Go module path
+var majorVersion = regexp.MustCompile(`^v\d+$`)
+
+// validAttr matches names usable as a nixpkgs attribute without quoting
+var validAttr = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_'-]*$`)
+
+// Attribute returns the nixpkgs attribute for a plonk package. Brew
+// formulae, cargo crates and uv tools map to the package of the same name
+// unless listed in renames, and versioned pythons to their interpreter;
+// pnpm packages map to nodePackages and go packages to their binary's name.
+// Taps, scoped npm packages and plugin managers have no mapping.
+func Attribute(manager, name string) (string, bool) {
+	if attr, ok := renames[manager+":"+name]; ok {
+		return attr, true
+	}
+
+	attr := name
+	switch manager {
+	case "brew":
+		if m := versionedFormula.FindStringSubmatch(name); m != nil {
+			return versionedAttribute(m[1], m[2], m[3])
+		}
+	case "uv":
+		// uv:python@3.12 is an interpreter, like brew's python@3.12
+		if m := versionedFormula.FindStringSubmatch(name); m != nil && m[1] == "python" {
+			return versionedAttribute(m[1], m[2], m[3])
+		}
+		// Extras and version constraints don't carry over: ansible[azure]>=9 -> ansible
+		if i := strings.IndexAny(name, "[<>=!~ "); i > 0 {
+			attr = name[:i]
+		}
+	case "cargo":
+		// Same name in nixpkgs
+	case "go":
+		// golang.org/x/tools/gopls@latest -> gopls, example.com/tool/v2 -> tool
+		modPath, _, _ := strings.Cut(name, "@")
+		attr = path.Base(modPath)
+		if majorVersion.MatchString(attr) {
+			attr = path.Base(path.Dir(modPath))
+		}
+	case "pnpm":
+		if !validAttr.MatchString(name) {
+			return "", false
+		}
+		return "nodePackages." + name, true
+	default:
+		return "", false
+	}
+
+	if !validAttr.MatchString(attr) {
+		return "", false
+	}
+	return attr, true
+}
+
+// versionedAttribute maps a versioned brew formula, split into base name,
+// major and minor version, to nixpkgs' naming for that language
+func versionedAttribute(base, major, minor string) (string, bool) {
+	switch base {
+	case "python":
+		return "python" + major + minor, true // python@3.12 -> python312
+	case "go":
+		return "go_" + major + "_" + minor, minor != "" // go@1.22 -> go_1_22
+	case "node":
+		return "nodejs_" + major, true
+	case "openjdk":
+		return "jdk" + major, true
+	case "postgresql":
+		return "postgresql_" + major, true
+	default:
+		return "", false
+	}
+}
+
+// HomeManagerModule renders a home-manager module whose home.packages hold
+// the mapped packages. Unmapped packages are listed in a comment.
+func HomeManagerModule(pkgs []Package) string {
+	var b strings.Builder
+	b.WriteString("# Generated by 'plonk export nix' from plonk.lock.\n")
+	b.WriteString("# Check attribute names against nixpkgs before switching over.\n")
+	b.WriteString("{ pkgs, ... }:\n\n{\n  home.packages = with pkgs; [\n")
+	writePackages(&b, pkgs, "    ")
+	b.WriteString("  ];\n}\n")
+	return b.String()
+}
+
+// Flake renders a flake exposing homeConfigurations.<user> built from the
+// same package list as HomeManagerModule
+func Flake(pkgs []Package, user, homeDir, system string) string {
+	var b strings.Builder
+	b.WriteString("# Generated by 'plonk export nix --flake' from plonk.lock.\n")
+	b.WriteString("# Check attribute names against nixpkgs before switching over.\n")
+	b.WriteString("{\n")
+	b.WriteString("  inputs = {\n")
+	b.WriteString("    nixpkgs.url = \"github:nixos/nixpkgs/nixos-unstable\";\n")
+	b.WriteString("    home-manager = {\n")
+	b.WriteString("      url = \"github:nix-community/home-manager\";\n")
+	b.WriteString("      inputs.nixpkgs.follows = \"nixpkgs\";\n")
+	b.WriteString("    };\n")
+	b.WriteString("  };\n\n")
+	b.WriteString("  outputs = { nixpkgs, home-manager, ... }: {\n")
+	fmt.Fprintf(&b, "    homeConfigurations.%s = home-manager.lib.homeManagerConfiguration {\n", quoteAttr(user))
+	fmt.Fprintf(&b, "      pkgs = nixpkgs.legacyPackages.%s;\n", system)
+	b.WriteString("      modules = [\n")
+	b.WriteString("        ({ pkgs, ... }: {\n")
+	fmt.Fprintf(&b, "          home.username = %s;\n", quoteString(user))
+	fmt.Fprintf(&b, "          home.homeDirectory = %s;\n", quoteString(homeDir))
+	b.WriteString("          home.stateVersion = \"24.05\";\n")
+	b.WriteString("          home.packages = with pkgs; [\n")
+	writePackages(&b, pkgs, "            ")
+	b.WriteString("          ];\n")
+	b.WriteString("        })\n")
+	b.WriteString("      ];\n")
+	b.WriteString("    };\n")
+	b.WriteString("  };\n")
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// writePackages writes one mapped attribute per line, commented with its
+// plonk spec when the names differ, followed by the unmapped packages
+func writePackages(b *strings.Builder, pkgs []Package, indent string) {
+	var unmapped []string
+	for _, p := range pkgs {
+		if p.Attribute == "" {
+			unmapped = append(unmapped, p.Spec)
+			continue
+		}
+		_, name, _ := strings.Cut(p.Spec, ":")
+		if name == p.Attribute {
+			fmt.Fprintf(b, "%s%s\n", indent, p.Attribute)
+		} else {
+			fmt.Fprintf(b, "%s%s # %s\n", indent, p.Attribute, p.Spec)
+		}
+	}
+	if len(unmapped) > 0 {
+		fmt.Fprintf(b, "%s# No nixpkgs mapping:\n", indent)
+		for _, spec := range unmapped {
+			fmt.Fprintf(b, "%s#   %s\n", indent, spec)
+		}
+	}
+}
+
+// quoteAttr quotes an attribute name unless it is a plain identifier
+func quoteAttr(name string) string {
+	if validAttr.MatchString(name) {
+		return name
+	}
+	return quoteString(name)
+}
+
+// quoteString renders s as a Nix string literal
+func quoteString(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "${", `\${`)
+	return `"` + r.Replace(s) + `"`
+}
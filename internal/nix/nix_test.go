@@ -0,0 +1,60 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package nix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttribute(t *testing.T) {
+	tests := []struct {
+		manager, name string
+		want          string
+		ok            bool
+	}{
+		{"brew", "ripgrep", "ripgrep", true},
+		{"brew", "gnu-sed", "gnused", true},
+		{"brew", "python@3.12", "python312", true},
+		{"uv", "python@3.11", "python311", true},
+		{"brew", "node@20", "nodejs_20", true},
+		{"brew", "go@1.22", "go_1_22", true},
+		{"brew", "ruby@3.3", "", false},
+		{"brew", "hashicorp/tap/terraform", "", false},
+		{"cargo", "fd-find", "fd", true},
+		{"cargo", "bat", "bat", true},
+		{"uv", "ruff", "ruff", true},
+		{"uv", "ansible[azure]>=9,<10", "ansible", true},
+		{"go", "golang.org/x/tools/gopls", "gopls", true},
+		{"go", "github.com/air-verse/air@latest", "air", true},
+		{"go", "example.com/tool/v2", "tool", true},
+		{"pnpm", "prettier", "nodePackages.prettier", true},
+		{"pnpm", "@biomejs/biome", "", false},
+		{"gem", "rubocop", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := Attribute(tt.manager, tt.name)
+		assert.Equal(t, tt.want, got, "%s:%s", tt.manager, tt.name)
+		assert.Equal(t, tt.ok, ok, "%s:%s", tt.manager, tt.name)
+	}
+}
+
+func TestHomeManagerModule(t *testing.T) {
+	got := HomeManagerModule([]Package{
+		{Spec: "brew:ripgrep", Attribute: "ripgrep"},
+		{Spec: "cargo:fd-find", Attribute: "fd"},
+		{Spec: "brew:hashicorp/tap/terraform"},
+	})
+	assert.Contains(t, got, "{ pkgs, ... }:")
+	assert.Contains(t, got, "  home.packages = with pkgs; [\n    ripgrep\n    fd # cargo:fd-find\n    # No nixpkgs mapping:\n    #   brew:hashicorp/tap/terraform\n  ];\n")
+}
+
+func TestFlake(t *testing.T) {
+	got := Flake([]Package{{Spec: "brew:jq", Attribute: "jq"}}, "first.last", "/home/first.last", "x86_64-linux")
+	assert.Contains(t, got, `homeConfigurations."first.last" = home-manager.lib.homeManagerConfiguration {`)
+	assert.Contains(t, got, "pkgs = nixpkgs.legacyPackages.x86_64-linux;")
+	assert.Contains(t, got, `home.homeDirectory = "/home/first.last";`)
+	assert.Contains(t, got, "            jq\n")
+}
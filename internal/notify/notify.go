@@ -0,0 +1,65 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+// Package notify sends desktop notifications for background operations.
+package notify
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"time"
+)
+
+// Notifier delivers a desktop notification
+type Notifier interface {
+	Notify(ctx context.Context, title, message string) error
+}
+
+// notifyTimeout bounds a single notification command
+const notifyTimeout = 5 * time.Second
+
+// commandNotifier runs a platform notification command
+type commandNotifier struct {
+	name string
+	args func(title, message string) []string
+}
+
+func (n commandNotifier) Notify(ctx context.Context, title, message string) error {
+	ctx, cancel := context.WithTimeout(ctx, notifyTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, n.name, n.args(title, message)...) //nolint:gosec // G204: fixed notifier binary; title/message are passed as arguments
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%s: %s: %w", n.name, string(out), err)
+	}
+	return nil
+}
+
+// noopNotifier is used when no notification command is available
+type noopNotifier struct{}
+
+func (noopNotifier) Notify(context.Context, string, string) error { return nil }
+
+// NewDesktopNotifier returns a notifier for this platform: osascript on
+// macOS, notify-send on Linux. If neither is available, notifications are
+// silently dropped.
+func NewDesktopNotifier() Notifier {
+	switch runtime.GOOS {
+	case "darwin":
+		if _, err := exec.LookPath("osascript"); err == nil {
+			return commandNotifier{name: "osascript", args: func(title, message string) []string {
+				// strconv.Quote produces a valid AppleScript string literal for plain text
+				return []string{"-e", fmt.Sprintf("display notification %s with title %s", strconv.Quote(message), strconv.Quote(title))}
+			}}
+		}
+	case "linux":
+		if _, err := exec.LookPath("notify-send"); err == nil {
+			return commandNotifier{name: "notify-send", args: func(title, message string) []string {
+				return []string{"--app-name=plonk", title, message}
+			}}
+		}
+	}
+	return noopNotifier{}
+}
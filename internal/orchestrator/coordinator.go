@@ -6,12 +6,18 @@ package orchestrator
 import (
 	"context"
 	"fmt"
+	"log"
+	"os"
 	"sort"
+	"time"
 
 	"github.com/richhaase/plonk/internal/config"
 	"github.com/richhaase/plonk/internal/dotfiles"
+	"github.com/richhaase/plonk/internal/lock"
 	"github.com/richhaase/plonk/internal/output"
 	"github.com/richhaase/plonk/internal/packages"
+	"github.com/richhaase/plonk/internal/resources"
+	"github.com/richhaase/plonk/internal/signing"
 )
 
 // Orchestrator manages resources and coordinates apply operations
@@ -23,6 +29,16 @@ type Orchestrator struct {
 	dryRun       bool
 	packagesOnly bool
 	dotfilesOnly bool
+
+	// selected limits apply to packageSelection and dotfileSelection
+	selected         bool
+	packageSelection map[string]bool
+	dotfileSelection map[string]bool
+
+	// dotfileExclusions are dotfile destinations to leave alone
+	dotfileExclusions map[string]bool
+
+	historyPath string // where applies are recorded; empty records nothing
 }
 
 // New creates a new orchestrator instance with options
@@ -53,21 +69,45 @@ func (o *Orchestrator) Apply(ctx context.Context) (output.ApplyResult, error) {
 	// Per-package timeouts live inside packages.SimpleApply; we no longer wrap
 	// the whole batch in one budget — a single slow Homebrew download used to
 	// burn the entire phase's deadline.
-	if !o.dotfilesOnly {
-		simpleResult, err := packages.SimpleApply(ctx, o.configDir, o.dryRun)
-		if simpleResult != nil {
-			packageResult := convertSimpleApplyResult(simpleResult, o.dryRun)
-			result.Packages = &packageResult
-		}
-		if err != nil {
-			result.AddPackageError(fmt.Errorf("package apply failed: %w", err))
+	// A lock that fails signature verification installs nothing.
+	if !o.dotfilesOnly && (!o.selected || len(o.packageSelection) > 0) {
+		if err := signing.VerifyLock(ctx, o.configDir); err != nil {
+			result.AddPackageError(fmt.Errorf("package apply refused: %w", err))
+		} else {
+			if o.config != nil {
+				packages.Configure(o.config)
+			}
+			var only map[string]bool
+			if o.selected {
+				only = o.packageSelection
+			}
+			simpleResult, err := packages.SimpleApplySelected(ctx, o.configDir, o.dryRun, only)
+			if simpleResult != nil {
+				packageResult := convertSimpleApplyResult(simpleResult, o.dryRun)
+				result.Packages = &packageResult
+			}
+			if err != nil {
+				result.AddPackageError(fmt.Errorf("package apply failed: %w", err))
+			}
+			if o.config != nil && o.config.Shims && !o.dryRun {
+				if err := refreshShims(o.configDir, o.config); err != nil {
+					result.AddPackageError(fmt.Errorf("failed to update shims: %w", err))
+				}
+			}
 		}
 	}
 
 	// Apply dotfiles (unless packages-only)
-	if !o.packagesOnly {
+	if !o.packagesOnly && (!o.selected || len(o.dotfileSelection) > 0) {
 		dctx, dcancel := context.WithTimeout(ctx, t.Dotfile)
-		dotfileResult, err := dotfiles.Apply(dctx, o.configDir, o.homeDir, o.config, o.dryRun)
+		var dotfileResult output.DotfileResults
+		var err error
+		if o.selected || len(o.dotfileExclusions) > 0 {
+			dotfileResult, err = dotfiles.ApplySelective(dctx, o.configDir, o.homeDir, o.config,
+				dotfiles.ApplyFilterOptions{DryRun: o.dryRun, Filter: o.dotfileSelection, Exclude: o.dotfileExclusions})
+		} else {
+			dotfileResult, err = dotfiles.Apply(dctx, o.configDir, o.homeDir, o.config, o.dryRun)
+		}
 		dcancel()
 		result.Dotfiles = &dotfileResult
 		if err != nil {
@@ -75,6 +115,15 @@ func (o *Orchestrator) Apply(ctx context.Context) (output.ApplyResult, error) {
 		}
 	}
 
+	// Apply custom resources (full apply only)
+	if !o.packagesOnly && !o.dotfilesOnly && !o.selected && o.config != nil && len(o.config.ActiveResources()) > 0 {
+		resourceResult, errs := applyResources(ctx, o.configDir, o.homeDir, o.config.ActiveResources(), o.dryRun)
+		result.Resources = &resourceResult
+		for _, err := range errs {
+			result.AddResourceError(err)
+		}
+	}
+
 	// Determine overall success
 	// Success means no errors occurred. A clean no-op is considered success.
 	// This supports idempotent operations - running apply multiple times is safe.
@@ -96,8 +145,17 @@ func (o *Orchestrator) Apply(ctx context.Context) (output.ApplyResult, error) {
 			changed = true
 		}
 	}
+	if result.Resources != nil && (result.Resources.TotalApplied > 0 || result.Resources.TotalWouldApply > 0) {
+		changed = true
+	}
 	result.Changed = changed
 
+	if o.historyPath != "" && !o.dryRun {
+		if err := appendHistory(o.historyPath, newHistoryEntry(result, time.Now())); err != nil {
+			log.Printf("Warning: failed to record apply history: %v", err)
+		}
+	}
+
 	// If we had any failures, return an error even if some operations succeeded
 	if result.HasErrors() {
 		return result, result.GetCombinedError()
@@ -106,6 +164,61 @@ func (o *Orchestrator) Apply(ctx context.Context) (output.ApplyResult, error) {
 	return result, nil
 }
 
+// refreshShims relinks the shim directory to the tracked packages, now
+// that newly installed ones have their commands in place
+func refreshShims(configDir string, cfg *config.Config) error {
+	lockFile, err := lock.NewLockV3Service(configDir).Read()
+	if err != nil {
+		return err
+	}
+	shimDir := config.GetShimDirectory()
+	shims, _ := packages.PlanShims(lockFile, cfg.ManagerOrder(), os.Getenv("PATH"), shimDir)
+	_, err = packages.WriteShims(shimDir, shims, false)
+	return err
+}
+
+// applyResources applies each configured resource in order
+func applyResources(ctx context.Context, configDir, homeDir string, names []string, dryRun bool) (output.ResourceResults, []error) {
+	result := output.ResourceResults{DryRun: dryRun}
+	var errs []error
+
+	for _, name := range names {
+		typeResult := output.ResourceTypeResult{Name: name}
+
+		resource, err := resources.Lookup(name, configDir, homeDir)
+		if err != nil {
+			typeResult.Error = err.Error()
+			result.Resources = append(result.Resources, typeResult)
+			result.TotalFailed++
+			errs = append(errs, err)
+			continue
+		}
+
+		r := resources.Apply(ctx, resource, dryRun)
+		if r.ReconcileErr != nil {
+			typeResult.Error = r.ReconcileErr.Error()
+			result.TotalFailed++
+			errs = append(errs, r.ReconcileErr)
+		}
+		for _, item := range r.Applied {
+			typeResult.Items = append(typeResult.Items, output.ResourceOperation{Name: item, Status: "applied"})
+			result.TotalApplied++
+		}
+		for _, item := range r.WouldApply {
+			typeResult.Items = append(typeResult.Items, output.ResourceOperation{Name: item, Status: "would-apply"})
+			result.TotalWouldApply++
+		}
+		for i, item := range r.Failed {
+			typeResult.Items = append(typeResult.Items, output.ResourceOperation{Name: item, Status: "failed", Error: r.Errors[i].Error()})
+			result.TotalFailed++
+			errs = append(errs, fmt.Errorf("%s:%s: %w", name, item, r.Errors[i]))
+		}
+		result.Resources = append(result.Resources, typeResult)
+	}
+
+	return result, errs
+}
+
 // convertSimpleApplyResult converts packages.SimpleApplyResult to output.PackageResults
 func convertSimpleApplyResult(r *packages.SimpleApplyResult, dryRun bool) output.PackageResults {
 	result := output.PackageResults{
@@ -119,8 +232,9 @@ func convertSimpleApplyResult(r *packages.SimpleApplyResult, dryRun bool) output
 	for _, spec := range r.Installed {
 		manager, pkg := splitSpec(spec)
 		managerPackages[manager] = append(managerPackages[manager], output.PackageOperation{
-			Name:   pkg,
-			Status: "installed",
+			Name:    pkg,
+			Manager: manager,
+			Status:  "installed",
 		})
 		result.TotalInstalled++
 	}
@@ -129,17 +243,18 @@ func convertSimpleApplyResult(r *packages.SimpleApplyResult, dryRun bool) output
 	for _, spec := range r.WouldInstall {
 		manager, pkg := splitSpec(spec)
 		managerPackages[manager] = append(managerPackages[manager], output.PackageOperation{
-			Name:   pkg,
-			Status: "would-install",
+			Name:    pkg,
+			Manager: manager,
+			Status:  "would-install",
 		})
 		result.TotalWouldInstall++
 	}
 
 	// Build error map for failed packages
-	errorMap := make(map[string]string)
+	errorMap := make(map[string]error)
 	for i, spec := range r.Failed {
 		if i < len(r.Errors) && r.Errors[i] != nil {
-			errorMap[spec] = r.Errors[i].Error()
+			errorMap[spec] = r.Errors[i]
 		}
 	}
 
@@ -147,11 +262,14 @@ func convertSimpleApplyResult(r *packages.SimpleApplyResult, dryRun bool) output
 	for _, spec := range r.Failed {
 		manager, pkg := splitSpec(spec)
 		op := output.PackageOperation{
-			Name:   pkg,
-			Status: "failed",
+			Name:    pkg,
+			Manager: manager,
+			Status:  "failed",
 		}
-		if errMsg, ok := errorMap[spec]; ok {
-			op.Error = errMsg
+		if err, ok := errorMap[spec]; ok {
+			op.Error = err.Error()
+			op.ErrorClass = packages.ErrorClass(err)
+			op.Err = err
 		}
 		managerPackages[manager] = append(managerPackages[manager], op)
 		result.TotalFailed++
@@ -0,0 +1,122 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package orchestrator
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/richhaase/plonk/internal/config"
+	"github.com/richhaase/plonk/internal/output"
+	"github.com/richhaase/plonk/internal/packages"
+)
+
+// failureReportVersion is bumped when a FailureReport field changes meaning
+// or goes away
+const failureReportVersion = 1
+
+// failureTailLines is how much of a failed command's output a report keeps
+const failureTailLines = 40
+
+// FailureReport records why an apply failed, for sharing without the
+// terminal scrollback
+type FailureReport struct {
+	Version  int       `json:"version"`
+	Time     time.Time `json:"time"`
+	Host     string    `json:"host,omitempty"`
+	Failures []Failure `json:"failures"`
+	Errors   []string  `json:"errors,omitempty"` // failures not tied to one item, e.g. a lock that didn't verify
+}
+
+// Failure is one package, dotfile or resource item that failed to apply
+type Failure struct {
+	Domain     string `json:"domain"` // package, dotfile or resource
+	Manager    string `json:"manager,omitempty"`
+	Package    string `json:"package,omitempty"`
+	Dotfile    string `json:"dotfile,omitempty"`  // destination
+	Resource   string `json:"resource,omitempty"` // type:item
+	Command    string `json:"command,omitempty"`
+	StderrTail string `json:"stderr_tail,omitempty"` // last lines of stdout and stderr, which managers interleave
+	Error      string `json:"error"`
+	ErrorClass string `json:"error_class,omitempty"`
+}
+
+// NewFailureReport collects the failures in result. It reports false when
+// nothing failed.
+func NewFailureReport(result output.ApplyResult, at time.Time) (FailureReport, bool) {
+	report := FailureReport{Version: failureReportVersion, Time: at.UTC()}
+	report.Host, _ = os.Hostname()
+
+	if p := result.Packages; p != nil {
+		for _, m := range p.Managers {
+			for _, pkg := range m.Packages {
+				if pkg.Status != "failed" {
+					continue
+				}
+				f := Failure{Domain: "package", Manager: m.Name, Package: pkg.Name, Error: pkg.Error, ErrorClass: pkg.ErrorClass}
+				f.Command, f.StderrTail = packages.FailedCommand(pkg.Err, failureTailLines)
+				report.Failures = append(report.Failures, f)
+			}
+		}
+	}
+	if d := result.Dotfiles; d != nil {
+		for _, action := range d.Actions {
+			if action.Status == "failed" {
+				report.Failures = append(report.Failures, Failure{Domain: "dotfile", Dotfile: action.Destination, Error: action.Error})
+			}
+		}
+	}
+	if r := result.Resources; r != nil {
+		for _, rt := range r.Resources {
+			if rt.Error != "" {
+				report.Failures = append(report.Failures, Failure{Domain: "resource", Resource: rt.Name, Error: rt.Error})
+			}
+			for _, item := range rt.Items {
+				if item.Status == "failed" {
+					report.Failures = append(report.Failures, Failure{Domain: "resource", Resource: rt.Name + ":" + item.Name, Error: item.Error})
+				}
+			}
+		}
+	}
+
+	// The per-domain errors repeat item failures except when nothing was
+	// attempted, so they are only kept then
+	if len(report.Failures) == 0 {
+		for _, errs := range [][]error{result.PackageErrors, result.DotfileErrors, result.ResourceErrors} {
+			for _, err := range errs {
+				report.Errors = append(report.Errors, err.Error())
+			}
+		}
+	}
+	if report.Failures == nil {
+		report.Failures = []Failure{}
+	}
+	return report, len(report.Failures) > 0 || len(report.Errors) > 0
+}
+
+// FailureReportDir returns where failure reports are written: logs in the
+// state directory, so reports never leave this machine through git, sync or
+// remote pushes
+func FailureReportDir() string {
+	return filepath.Join(config.GetStateDirectory(), "logs")
+}
+
+// WriteFailureReport writes report to dir and returns its path
+func WriteFailureReport(dir string, report FailureReport) (string, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return "", err
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, "apply-"+report.Time.Format("20060102T150405Z")+".json")
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return "", err
+	}
+	return path, nil
+}
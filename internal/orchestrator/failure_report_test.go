@@ -0,0 +1,78 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package orchestrator
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/richhaase/plonk/internal/output"
+	"github.com/richhaase/plonk/internal/packages"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFailureReport_WriteAndRead(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "logs")
+	at := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	installErr := &packages.CommandError{
+		Args:   []string{"brew", "install", "nope"},
+		Output: []byte("==> Fetching nope\nError: No available formula with the name \"nope\".\n"),
+		Err:    errors.New("exit status 1"),
+	}
+	result := output.ApplyResult{
+		Packages: &output.PackageResults{Managers: []output.ManagerResults{{
+			Name: "brew",
+			Packages: []output.PackageOperation{
+				{Name: "ripgrep", Status: "installed"},
+				{Name: "nope", Status: "failed", Error: "brew install nope: exit status 1", ErrorClass: "not_found", Err: installErr},
+			},
+		}}},
+		Dotfiles: &output.DotfileResults{Actions: []output.DotfileOperation{
+			{Destination: "~/.zshrc", Status: "failed", Error: "permission denied"},
+		}},
+		PackageErrors: []error{errors.New("package apply failed: 1 package failed")},
+	}
+
+	report, failed := NewFailureReport(result, at)
+	require.True(t, failed)
+	path, err := WriteFailureReport(dir, report)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "apply-20250301T120000Z.json"), path)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var read FailureReport
+	require.NoError(t, json.Unmarshal(data, &read))
+	assert.Equal(t, []Failure{
+		{
+			Domain: "package", Manager: "brew", Package: "nope",
+			Command:    "brew install nope",
+			StderrTail: "==> Fetching nope\nError: No available formula with the name \"nope\".",
+			Error:      "brew install nope: exit status 1", ErrorClass: "not_found",
+		},
+		{Domain: "dotfile", Dotfile: "~/.zshrc", Error: "permission denied"},
+	}, read.Failures)
+	assert.Empty(t, read.Errors, "item failures already explain the domain error")
+}
+
+func TestFailureReportDir_InStateDirectory(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", "/state")
+	assert.Equal(t, filepath.Join("/state", "plonk", "logs"), FailureReportDir())
+}
+
+func TestNewFailureReport_ErrorsWithoutItems(t *testing.T) {
+	result := output.ApplyResult{PackageErrors: []error{errors.New("package apply refused: plonk.lock signature does not verify")}}
+	report, failed := NewFailureReport(result, time.Now())
+	assert.True(t, failed)
+	assert.Empty(t, report.Failures)
+	assert.Equal(t, []string{"package apply refused: plonk.lock signature does not verify"}, report.Errors)
+
+	_, failed = NewFailureReport(output.ApplyResult{Success: true}, time.Now())
+	assert.False(t, failed)
+}
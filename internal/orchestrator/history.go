@@ -0,0 +1,109 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package orchestrator
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/richhaase/plonk/internal/config"
+	"github.com/richhaase/plonk/internal/output"
+)
+
+// historyFileName is the apply history, one JSON entry per line, in the
+// state directory
+const historyFileName = "history.jsonl"
+
+// historyLimit is how many applies the history keeps
+const historyLimit = 500
+
+// HistoryEntry records what one apply did
+type HistoryEntry struct {
+	Time       time.Time `json:"time"`
+	Success    bool      `json:"success"`
+	Installed  int       `json:"installed"`  // packages installed
+	Added      int       `json:"added"`      // dotfiles deployed for the first time
+	Redeployed int       `json:"redeployed"` // dotfiles that had drifted from their source, or whose source changed
+	Applied    int       `json:"applied"`    // custom resource items applied
+	Failed     int       `json:"failed"`
+}
+
+// HistoryPath returns the apply history's path
+func HistoryPath() string {
+	return filepath.Join(config.GetStateDirectory(), historyFileName)
+}
+
+// newHistoryEntry summarizes result
+func newHistoryEntry(result output.ApplyResult, at time.Time) HistoryEntry {
+	entry := HistoryEntry{Time: at.UTC(), Success: result.Success}
+	if p := result.Packages; p != nil {
+		entry.Installed = p.TotalInstalled
+		entry.Failed += p.TotalFailed
+	}
+	if d := result.Dotfiles; d != nil {
+		entry.Added = d.Summary.Added
+		entry.Redeployed = d.Summary.Updated
+		entry.Failed += d.Summary.Failed
+	}
+	if r := result.Resources; r != nil {
+		entry.Applied = r.TotalApplied
+		entry.Failed += r.TotalFailed
+	}
+	return entry
+}
+
+// ReadHistory returns the recorded applies, oldest first. A missing
+// history is empty, and lines that don't parse are skipped.
+func ReadHistory(path string) ([]HistoryEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var entries []HistoryEntry
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		var entry HistoryEntry
+		if json.Unmarshal(scanner.Bytes(), &entry) == nil {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, scanner.Err()
+}
+
+// appendHistory adds entry to the history, dropping the oldest entries
+// beyond historyLimit
+func appendHistory(path string, entry HistoryEntry) error {
+	entries, err := ReadHistory(path)
+	if err != nil {
+		return err
+	}
+	entries = append(entries, entry)
+	if len(entries) > historyLimit {
+		entries = entries[len(entries)-historyLimit:]
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, e := range entries {
+		if err := enc.Encode(e); err != nil {
+			return err
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package orchestrator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/richhaase/plonk/internal/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistory_AppendAndRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", historyFileName)
+
+	entries, err := ReadHistory(path)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	at := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	result := output.ApplyResult{
+		Success:  false,
+		Packages: &output.PackageResults{TotalInstalled: 2, TotalFailed: 1},
+		Dotfiles: &output.DotfileResults{Summary: output.DotfileSummary{Added: 1, Updated: 3}},
+	}
+	require.NoError(t, appendHistory(path, newHistoryEntry(result, at)))
+	require.NoError(t, appendHistory(path, newHistoryEntry(output.ApplyResult{Success: true}, at.Add(time.Hour))))
+
+	entries, err = ReadHistory(path)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, HistoryEntry{Time: at, Installed: 2, Added: 1, Redeployed: 3, Failed: 1}, entries[0])
+	assert.True(t, entries[1].Success)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+func TestHistory_KeepsLimit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), historyFileName)
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < historyLimit+5; i++ {
+		require.NoError(t, appendHistory(path, HistoryEntry{Time: start.Add(time.Duration(i) * time.Minute)}))
+	}
+
+	entries, err := ReadHistory(path)
+	require.NoError(t, err)
+	require.Len(t, entries, historyLimit)
+	assert.Equal(t, start.Add(5*time.Minute), entries[0].Time)
+}
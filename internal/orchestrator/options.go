@@ -51,3 +51,30 @@ func WithDotfilesOnly(dotfilesOnly bool) Option {
 		o.dotfilesOnly = dotfilesOnly
 	}
 }
+
+// WithSelection applies only the given package specs ("manager:package")
+// and dotfile destinations, skipping custom resources. An empty set skips
+// that domain.
+func WithSelection(packages, dotfiles map[string]bool) Option {
+	return func(o *Orchestrator) {
+		o.selected = true
+		o.packageSelection = packages
+		o.dotfileSelection = dotfiles
+	}
+}
+
+// WithDotfileExclusions leaves the given dotfile destinations alone, such
+// as conflicts skipped during clone
+func WithDotfileExclusions(dotfiles map[string]bool) Option {
+	return func(o *Orchestrator) {
+		o.dotfileExclusions = dotfiles
+	}
+}
+
+// WithHistory records each apply that isn't a dry run in the history at
+// path (see HistoryPath)
+func WithHistory(path string) Option {
+	return func(o *Orchestrator) {
+		o.historyPath = path
+	}
+}
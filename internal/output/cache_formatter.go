@@ -0,0 +1,60 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package output
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CacheManagerTotal is what one manager's caches contributed to a transfer
+type CacheManagerTotal struct {
+	Manager string `json:"manager" yaml:"manager"`
+	Files   int    `json:"files" yaml:"files"`
+	Bytes   int64  `json:"bytes" yaml:"bytes"`
+}
+
+// CacheTransferOutput is the output of `plonk cache export` and `plonk cache import`
+type CacheTransferOutput struct {
+	Action   string              `json:"action" yaml:"action"` // "exported" or "imported"
+	Archive  string              `json:"archive" yaml:"archive"`
+	Managers []CacheManagerTotal `json:"managers" yaml:"managers"`
+	Skipped  []string            `json:"skipped,omitempty" yaml:"skipped,omitempty"`
+}
+
+// TableOutput lists the files moved per manager
+func (c CacheTransferOutput) TableOutput() string {
+	var out strings.Builder
+	WriteTitle(&out, "Package Caches")
+
+	if len(c.Managers) == 0 {
+		fmt.Fprintf(&out, "Nothing %s.\n", c.Action)
+	} else {
+		table := NewStandardTableBuilder("")
+		table.SetHeaders("MANAGER", "FILES", "SIZE")
+		var files int
+		var bytes int64
+		for _, m := range c.Managers {
+			table.AddRow(m.Manager, fmt.Sprintf("%d", m.Files), formatBytes(m.Bytes))
+			files += m.Files
+			bytes += m.Bytes
+		}
+		out.WriteString(table.Build())
+		out.WriteString("\n")
+		direction := "to"
+		if c.Action == "imported" {
+			direction = "from"
+		}
+		fmt.Fprintf(&out, "%s %d files (%s) %s %s %s\n", IconSuccess, files, formatBytes(bytes), c.Action, direction, c.Archive)
+	}
+	for _, skipped := range c.Skipped {
+		fmt.Fprintf(&out, "%s skipped %s\n", IconWarning, skipped)
+	}
+	return out.String()
+}
+
+// StructuredData returns the transfer summary for serialization
+func (c CacheTransferOutput) StructuredData() any {
+	return c
+}
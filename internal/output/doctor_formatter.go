@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/fatih/color"
+	"github.com/richhaase/plonk/internal/i18n"
 )
 
 // HealthStatus represents the overall health status
@@ -43,26 +44,28 @@ func NewDoctorFormatter(data DoctorOutput) DoctorFormatter {
 	return DoctorFormatter{Data: data}
 }
 
-// TableOutput generates human-friendly table output for doctor command
+// TableOutput generates human-friendly table output for doctor command.
+// Check names, messages and advice are translated when the catalog has them;
+// ones built from details (counts, paths) stay English.
 func (f DoctorFormatter) TableOutput() string {
 	d := f.Data
 	var output strings.Builder
 
 	// Overall status
-	output.WriteString("Plonk Doctor Report\n\n")
+	output.WriteString(i18n.T("Plonk Doctor Report") + "\n\n")
 
 	switch d.Overall.Status {
 	case "healthy":
 		green := color.New(color.FgGreen, color.Bold)
-		output.WriteString(green.Sprintf("Overall Status: HEALTHY\n"))
+		output.WriteString(green.Sprint(i18n.T("Overall Status: %s", i18n.T("HEALTHY"))) + "\n")
 	case "warning":
 		yellow := color.New(color.FgYellow, color.Bold)
-		output.WriteString(yellow.Sprintf("Overall Status: WARNING\n"))
+		output.WriteString(yellow.Sprint(i18n.T("Overall Status: %s", i18n.T("WARNING"))) + "\n")
 	case "unhealthy":
 		red := color.New(color.FgRed, color.Bold)
-		output.WriteString(red.Sprintf("Overall Status: UNHEALTHY\n"))
+		output.WriteString(red.Sprint(i18n.T("Overall Status: %s", i18n.T("UNHEALTHY"))) + "\n")
 	}
-	fmt.Fprintf(&output, "   %s\n\n", d.Overall.Message)
+	fmt.Fprintf(&output, "   %s\n\n", i18n.T(d.Overall.Message))
 
 	// Group checks by category
 	categories := make(map[string][]HealthCheck)
@@ -74,7 +77,7 @@ func (f DoctorFormatter) TableOutput() string {
 	categoryOrder := []string{"system", "environment", "permissions", "configuration", "package-managers", "installation", "dotfiles"}
 	for _, category := range categoryOrder {
 		if checks, exists := categories[category]; exists {
-			fmt.Fprintf(&output, "## %s\n", titleCase(strings.ReplaceAll(category, "-", " ")))
+			fmt.Fprintf(&output, "## %s\n", i18n.T(titleCase(strings.ReplaceAll(category, "-", " "))))
 
 			for _, check := range checks {
 				// Color-coded status
@@ -83,46 +86,46 @@ func (f DoctorFormatter) TableOutput() string {
 				switch check.Status {
 				case "pass":
 					statusColor = color.New(color.FgGreen)
-					statusText = "PASS"
+					statusText = i18n.T("PASS")
 				case "warn":
 					statusColor = color.New(color.FgYellow)
-					statusText = "WARN"
+					statusText = i18n.T("WARN")
 				case "fail":
 					statusColor = color.New(color.FgRed)
-					statusText = "FAIL"
+					statusText = i18n.T("FAIL")
 				case "info":
 					statusColor = color.New(color.FgBlue)
-					statusText = "INFO"
+					statusText = i18n.T("INFO")
 				default:
 					statusColor = color.New(color.FgWhite)
-					statusText = "UNKNOWN"
+					statusText = i18n.T("UNKNOWN")
 				}
 
-				coloredName := statusColor.Sprintf("### %s", check.Name)
-				coloredStatus := statusColor.Sprintf("**Status**: %s", statusText)
+				coloredName := statusColor.Sprintf("### %s", i18n.T(check.Name))
+				coloredStatus := statusColor.Sprint(i18n.T("**Status**: %s", statusText))
 
 				fmt.Fprintf(&output, "%s\n", coloredName)
 				fmt.Fprintf(&output, "%s\n", coloredStatus)
-				fmt.Fprintf(&output, "**Message**: %s\n", check.Message)
+				fmt.Fprintf(&output, "%s\n", i18n.T("**Message**: %s", i18n.T(check.Message)))
 
 				if len(check.Details) > 0 {
-					output.WriteString("\n**Details:**\n")
+					output.WriteString("\n" + i18n.T("**Details:**") + "\n")
 					for _, detail := range check.Details {
 						fmt.Fprintf(&output, "- %s\n", detail)
 					}
 				}
 
 				if len(check.Issues) > 0 {
-					output.WriteString("\n**Issues:**\n")
+					output.WriteString("\n" + i18n.T("**Issues:**") + "\n")
 					for _, issue := range check.Issues {
-						fmt.Fprintf(&output, "- %s\n", issue)
+						fmt.Fprintf(&output, "- %s\n", i18n.T(issue))
 					}
 				}
 
 				if len(check.Suggestions) > 0 {
-					output.WriteString("\n**Suggestions:**\n")
+					output.WriteString("\n" + i18n.T("**Suggestions:**") + "\n")
 					for _, suggestion := range check.Suggestions {
-						fmt.Fprintf(&output, "- %s\n", suggestion)
+						fmt.Fprintf(&output, "- %s\n", i18n.T(suggestion))
 					}
 				}
 
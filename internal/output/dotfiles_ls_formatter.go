@@ -0,0 +1,160 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package output
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// DotfilesLsEntry is one dotfile in `plonk dotfiles ls`
+type DotfilesLsEntry struct {
+	Source    string     `json:"source" yaml:"source"` // relative to the config directory
+	Target    string     `json:"target" yaml:"target"`
+	Mode      string     `json:"mode,omitempty" yaml:"mode,omitempty"` // of the deployed file
+	State     string     `json:"state" yaml:"state"`                   // deployed, missing, drifted or error
+	AppliedAt *time.Time `json:"applied_at,omitempty" yaml:"applied_at,omitempty"`
+}
+
+// DotfilesLsOutput is the output of `plonk dotfiles ls`
+type DotfilesLsOutput struct {
+	ConfigDir string            `json:"config_dir" yaml:"config_dir"`
+	Dotfiles  []DotfilesLsEntry `json:"dotfiles" yaml:"dotfiles"`
+	HomeDir   string            `json:"-" yaml:"-"`
+	Tree      bool              `json:"-" yaml:"-"` // --tree: nest sources by directory
+}
+
+// TableOutput lists the dotfiles as a table, or with --tree as the
+// directory tree of their sources
+func (d DotfilesLsOutput) TableOutput() string {
+	var out strings.Builder
+	WriteTitle(&out, "Dotfiles")
+
+	if len(d.Dotfiles) == 0 {
+		out.WriteString("No managed dotfiles. Add one with 'plonk add'.\n")
+		return out.String()
+	}
+
+	entries := make([]DotfilesLsEntry, len(d.Dotfiles))
+	copy(entries, d.Dotfiles)
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Source < entries[j].Source })
+
+	if d.Tree {
+		d.writeTree(&out, entries)
+		return out.String()
+	}
+
+	table := NewStandardTableBuilder("")
+	table.SetHeaders("SOURCE", "TARGET", "MODE", "STATE", "APPLIED")
+	for _, e := range entries {
+		table.AddRow(e.Source, tildeShorthand(e.Target, d.HomeDir), orDash(e.Mode), e.State, e.appliedText())
+	}
+	out.WriteString(table.Build())
+	out.WriteString("\n")
+	return out.String()
+}
+
+// StructuredData returns the dotfile list for serialization
+func (d DotfilesLsOutput) StructuredData() any {
+	return d
+}
+
+// dotfileTreeNode is a directory or file in the tree of sources
+type dotfileTreeNode struct {
+	children map[string]*dotfileTreeNode
+	entry    *DotfilesLsEntry
+}
+
+// writeTree writes the sources as a tree rooted at the config directory,
+// each file followed by its target and state in aligned columns
+func (d DotfilesLsOutput) writeTree(out *strings.Builder, entries []DotfilesLsEntry) {
+	root := &dotfileTreeNode{children: map[string]*dotfileTreeNode{}}
+	for i := range entries {
+		node := root
+		for _, part := range strings.Split(entries[i].Source, "/") {
+			child, ok := node.children[part]
+			if !ok {
+				child = &dotfileTreeNode{children: map[string]*dotfileTreeNode{}}
+				node.children[part] = child
+			}
+			node = child
+		}
+		node.entry = &entries[i]
+	}
+
+	var rows [][]string
+	d.treeRows(root, "", &rows)
+
+	// Align the columns after the tree by hand: tabwriter would pad the
+	// directory lines, which have no columns, with trailing spaces
+	widths := make([]int, 4)
+	for _, row := range rows {
+		for i, cell := range row[:len(row)-1] {
+			widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+		}
+	}
+
+	fmt.Fprintln(out, tildeShorthand(d.ConfigDir, d.HomeDir))
+	for _, row := range rows {
+		if len(row) == 1 {
+			fmt.Fprintln(out, row[0])
+			continue
+		}
+		for i, cell := range row[:len(row)-1] {
+			out.WriteString(cell + strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell)+2))
+		}
+		fmt.Fprintln(out, row[len(row)-1])
+	}
+}
+
+// treeRows adds a row for each child of node, directories first, with the
+// tree drawn in front of the name: one cell for a directory, five for a file
+func (d DotfilesLsOutput) treeRows(node *dotfileTreeNode, prefix string, rows *[][]string) {
+	names := make([]string, 0, len(node.children))
+	for name := range node.children {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		iDir, jDir := node.children[names[i]].entry == nil, node.children[names[j]].entry == nil
+		if iDir != jDir {
+			return iDir
+		}
+		return names[i] < names[j]
+	})
+
+	for i, name := range names {
+		child := node.children[name]
+		branch, indent := "├── ", "│   "
+		if i == len(names)-1 {
+			branch, indent = "└── ", "    "
+		}
+		if child.entry == nil {
+			*rows = append(*rows, []string{prefix + branch + name + "/"})
+			d.treeRows(child, prefix+indent, rows)
+			continue
+		}
+		e := child.entry
+		*rows = append(*rows, []string{prefix + branch + name, "→ " + tildeShorthand(e.Target, d.HomeDir),
+			orDash(e.Mode), e.State, e.appliedText()})
+	}
+}
+
+// appliedText is when the deployed file was last written, or "-"
+func (e DotfilesLsEntry) appliedText() string {
+	if e.AppliedAt == nil {
+		return "-"
+	}
+	return e.AppliedAt.Local().Format("2006-01-02 15:04")
+}
+
+// orDash returns s, or "-" for an empty column
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
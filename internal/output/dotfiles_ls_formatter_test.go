@@ -0,0 +1,44 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package output
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDotfilesLsOutput_Tree(t *testing.T) {
+	applied := time.Date(2025, 6, 1, 12, 30, 0, 0, time.Local)
+	data := DotfilesLsOutput{
+		ConfigDir: "/home/user/.config/plonk",
+		HomeDir:   "/home/user",
+		Tree:      true,
+		Dotfiles: []DotfilesLsEntry{
+			{Source: "zshrc", Target: "/home/user/.zshrc", Mode: "0644", State: "drifted"},
+			{Source: "config/nvim/init.lua", Target: "/home/user/.config/nvim/init.lua", Mode: "0644", State: "deployed", AppliedAt: &applied},
+			{Source: "config/git/config", Target: "/home/user/.config/git/config", State: "missing"},
+		},
+	}
+
+	want := `~/.config/plonk
+├── config/
+│   ├── git/
+│   │   └── config    → ~/.config/git/config     -     missing   -
+│   └── nvim/
+│       └── init.lua  → ~/.config/nvim/init.lua  0644  deployed  2025-06-01 12:30
+└── zshrc             → ~/.zshrc                 0644  drifted   -
+`
+	if got := data.TableOutput(); !strings.HasSuffix(got, want) {
+		t.Errorf("TableOutput() =\n%s\nwant it to end with\n%s", got, want)
+	}
+
+	data.Tree = false
+	table := data.TableOutput()
+	for _, s := range []string{"SOURCE", "APPLIED", "config/nvim/init.lua", "~/.config/nvim/init.lua"} {
+		if !strings.Contains(table, s) {
+			t.Errorf("table output is missing %q:\n%s", s, table)
+		}
+	}
+}
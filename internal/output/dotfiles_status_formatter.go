@@ -4,16 +4,18 @@
 package output
 
 import (
-	"fmt"
 	"strings"
+
+	"github.com/richhaase/plonk/internal/i18n"
 )
 
 // DotfilesStatusOutput represents the output structure for dotfiles status command
 type DotfilesStatusOutput struct {
-	Result     Result `json:"result" yaml:"result"`
-	RemoteSync string `json:"remote_sync,omitempty" yaml:"remote_sync,omitempty"`
-	ConfigDir  string `json:"-" yaml:"-"` // Not included in JSON/YAML output
-	HomeDir    string `json:"-" yaml:"-"` // Not included in JSON/YAML output
+	Result     Result      `json:"result" yaml:"result"`
+	RemoteSync string      `json:"remote_sync,omitempty" yaml:"remote_sync,omitempty"`
+	ConfigDir  string      `json:"-" yaml:"-"` // Not included in JSON/YAML output
+	HomeDir    string      `json:"-" yaml:"-"` // Not included in JSON/YAML output
+	Options    ListOptions `json:"-" yaml:"-"` // --sort, --state
 }
 
 // DotfilesStatusFormatter formats dotfiles status output
@@ -28,6 +30,10 @@ func NewDotfilesStatusFormatter(data DotfilesStatusOutput) DotfilesStatusFormatt
 
 // TableOutput generates human-friendly table output for dotfiles status
 func (f DotfilesStatusFormatter) TableOutput() string {
+	if !f.Data.Options.IsZero() {
+		return f.listOutput()
+	}
+
 	var output strings.Builder
 	result := f.Data.Result
 
@@ -58,12 +64,12 @@ func (f DotfilesStatusFormatter) TableOutput() string {
 				target = tildeShorthand(dest, f.Data.HomeDir)
 			}
 			// Check if this is actually a drifted file or has an error
-			status := "deployed"
+			status := i18n.T("deployed")
 			if item.State == StateDegraded {
 				if driftStatus, ok := item.Metadata["drift_status"].(string); ok && driftStatus == "error" {
-					status = "error"
+					status = i18n.T("error")
 				} else {
-					status = "drifted"
+					status = dotfileStatus(item)
 				}
 			}
 			dotBuilder.AddRow(target, status)
@@ -76,7 +82,7 @@ func (f DotfilesStatusFormatter) TableOutput() string {
 			if dest, ok := item.Metadata["destination"].(string); ok {
 				target = tildeShorthand(dest, f.Data.HomeDir)
 			}
-			dotBuilder.AddRow(target, "missing")
+			dotBuilder.AddRow(target, i18n.T("missing"))
 		}
 
 		// Show error dotfiles
@@ -85,132 +91,118 @@ func (f DotfilesStatusFormatter) TableOutput() string {
 			if dest, ok := item.Metadata["destination"].(string); ok {
 				target = tildeShorthand(dest, f.Data.HomeDir)
 			}
-			dotBuilder.AddRow(target, "error")
+			dotBuilder.AddRow(target, i18n.T("error"))
 		}
 
 		output.WriteString(dotBuilder.Build())
 		output.WriteString("\n")
 	}
 
-	// Add summary
-	// Count drifted items separately
-	driftedCount := 0
-	for _, item := range result.Managed {
-		if item.State == StateDegraded {
-			driftedCount++
-		}
-	}
-
-	// Adjust managed count to exclude drifted
-	managedCount := len(result.Managed) - driftedCount
-
-	output.WriteString("Summary: ")
-	fmt.Fprintf(&output, "%d managed", managedCount)
-	if len(result.Missing) > 0 {
-		fmt.Fprintf(&output, ", %d missing", len(result.Missing))
-	}
-	if driftedCount > 0 {
-		fmt.Fprintf(&output, ", %d drifted", driftedCount)
-	}
-	if len(result.Errors) > 0 {
-		fmt.Fprintf(&output, ", %d error(s)", len(result.Errors))
-	}
-	output.WriteString("\n")
+	writeDotfilesSummary(&output, result)
 
 	if len(result.Managed) == 0 && len(result.Missing) == 0 && len(result.Errors) == 0 {
 		output.Reset()
 		WriteTitle(&output, "Dotfiles Status")
 		WriteRemoteSync(&output, f.Data.RemoteSync)
-		output.WriteString("No managed dotfiles.\n")
+		output.WriteString(i18n.T("No managed dotfiles.") + "\n")
 	}
 
 	return output.String()
 }
 
-// StructuredData returns the structured data for serialization
-func (f DotfilesStatusFormatter) StructuredData() any {
-	result := f.Data.Result
-
-	var items []ManagedItem
+// listOutput renders the dotfiles selected by --sort and --state as a
+// single list
+func (f DotfilesStatusFormatter) listOutput() string {
+	var output strings.Builder
+	opts := f.Data.Options
 
-	// Add managed items
-	for _, item := range result.Managed {
-		managedItem := ManagedItem{
-			Name:     item.Name,
-			Domain:   "dotfile",
-			State:    string(item.State),
-			Manager:  item.Manager,
-			Path:     item.Path,
-			Metadata: sanitizeMetadata(item.Metadata),
+	WriteTitle(&output, "Dotfiles Status")
+	WriteRemoteSync(&output, f.Data.RemoteSync)
+	opts.writeItemList(&output, opts.Items(f.Data.Result), func(item Item) (string, string) {
+		target := item.Name
+		if dest, ok := item.Metadata["destination"].(string); ok {
+			target = tildeShorthand(dest, f.Data.HomeDir)
 		}
-		// Add target for dotfiles
-		if target, ok := item.Metadata["destination"].(string); ok {
-			managedItem.Target = target
+		switch item.State {
+		case StateMissing:
+			return target, i18n.T("missing")
+		case StateError:
+			return target, i18n.T("error")
 		}
-		items = append(items, managedItem)
-	}
+		return target, dotfileStatus(item)
+	})
+	writeDotfilesSummary(&output, opts.Filter(f.Data.Result))
+	return output.String()
+}
 
-	// Add missing items
-	for _, item := range result.Missing {
-		managedItem := ManagedItem{
-			Name:     item.Name,
-			Domain:   "dotfile",
-			State:    string(item.State),
-			Manager:  item.Manager,
-			Path:     item.Path,
-			Metadata: sanitizeMetadata(item.Metadata),
-		}
-		// Add target for dotfiles
-		if target, ok := item.Metadata["destination"].(string); ok {
-			managedItem.Target = target
+// writeDotfilesSummary writes the dotfile counts line, with drifted files
+// counted apart from managed ones
+func writeDotfilesSummary(output *strings.Builder, result Result) {
+	driftedCount := 0
+	for _, item := range result.Managed {
+		if item.State == StateDegraded {
+			driftedCount++
 		}
-		items = append(items, managedItem)
 	}
+	managedCount := len(result.Managed) - driftedCount
 
-	// Add error items
-	for _, item := range result.Errors {
-		managedItem := ManagedItem{
-			Name:     item.Name,
-			Domain:   "dotfile",
-			State:    string(item.State),
-			Manager:  item.Manager,
-			Path:     item.Path,
-			Error:    item.Error,
-			Metadata: sanitizeMetadata(item.Metadata),
-		}
-		if target, ok := item.Metadata["destination"].(string); ok {
-			managedItem.Target = target
-		}
-		items = append(items, managedItem)
+	output.WriteString(i18n.T("Summary:") + " ")
+	output.WriteString(i18n.T("%d managed", managedCount))
+	if len(result.Missing) > 0 {
+		output.WriteString(", " + i18n.T("%d missing", len(result.Missing)))
 	}
-
-	summary := Summary{
-		TotalManaged:   len(result.Managed),
-		TotalMissing:   len(result.Missing),
-		TotalUntracked: len(result.Untracked),
-		Results:        []Result{result},
+	if driftedCount > 0 {
+		output.WriteString(", " + i18n.T("%d drifted", driftedCount))
+	}
+	if len(result.Errors) > 0 {
+		output.WriteString(", " + i18n.T("%d error(s)", len(result.Errors)))
 	}
+	output.WriteString("\n")
+}
 
-	// For backward compatibility with tests, add lowercase field aliases
-	// The test expects "missing", "managed", "untracked" fields
+// StructuredData returns the structured data for serialization
+func (f DotfilesStatusFormatter) StructuredData() any {
+	result := f.Data.Options.Filter(f.Data.Result)
+	items := NewManagedItems("dotfile", result)
+	f.Data.Options.Order(items)
+	drifted := countDrifted([]Result{result})
 	errorCount := len(result.Errors)
-	return map[string]interface{}{
-		"summary": map[string]interface{}{
-			"managed":         summary.TotalManaged,
-			"missing":         summary.TotalMissing,
-			"untracked":       summary.TotalUntracked,
-			"errors":          errorCount,
-			"total_managed":   summary.TotalManaged,
-			"total_missing":   summary.TotalMissing,
-			"total_untracked": summary.TotalUntracked,
-			"total_errors":    errorCount,
+	return DotfilesStatusOutputSummary{
+		RemoteSync: f.Data.RemoteSync,
+		Summary: DotfilesSummary{
+			Managed:        len(result.Managed),
+			Missing:        len(result.Missing),
+			Drifted:        drifted,
+			Untracked:      len(result.Untracked),
+			Errors:         errorCount,
+			TotalManaged:   len(result.Managed),
+			TotalMissing:   len(result.Missing),
+			TotalUntracked: len(result.Untracked),
+			TotalErrors:    errorCount,
 		},
-		"items": items,
+		Items: items,
 	}
 }
 
 // DotfilesStatusOutputSummary represents the structured output format
 type DotfilesStatusOutputSummary struct {
-	Summary Summary       `json:"summary" yaml:"summary"`
-	Items   []ManagedItem `json:"items" yaml:"items"`
+	RemoteSync string          `json:"remote_sync,omitempty" yaml:"remote_sync,omitempty"`
+	Summary    DotfilesSummary `json:"summary" yaml:"summary"`
+	Items      []ManagedItem   `json:"items" yaml:"items"`
+}
+
+// DotfilesSummary counts dotfiles by state. The short and total_ names
+// carry the same numbers; both are kept for existing consumers. Managed
+// includes drifted files, as in the table's summary line before it splits
+// them out.
+type DotfilesSummary struct {
+	Managed        int `json:"managed" yaml:"managed"`
+	Missing        int `json:"missing" yaml:"missing"`
+	Drifted        int `json:"drifted" yaml:"drifted"`
+	Untracked      int `json:"untracked" yaml:"untracked"`
+	Errors         int `json:"errors" yaml:"errors"`
+	TotalManaged   int `json:"total_managed" yaml:"total_managed"`
+	TotalMissing   int `json:"total_missing" yaml:"total_missing"`
+	TotalUntracked int `json:"total_untracked" yaml:"total_untracked"`
+	TotalErrors    int `json:"total_errors" yaml:"total_errors"`
 }
@@ -0,0 +1,78 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package output
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// FleetHost is one machine's row in `plonk fleet status`
+type FleetHost struct {
+	Host        string    `json:"host" yaml:"host"`
+	ReportedAt  time.Time `json:"reported_at" yaml:"reported_at"`
+	LockCurrent bool      `json:"lock_current" yaml:"lock_current"` // report was made against the current plonk.lock
+	Missing     int       `json:"missing" yaml:"missing"`
+	Drifted     int       `json:"drifted" yaml:"drifted"`
+	Errors      int       `json:"errors" yaml:"errors"`
+	InSync      bool      `json:"in_sync" yaml:"in_sync"`
+	OutOfSync   []string  `json:"out_of_sync,omitempty" yaml:"out_of_sync,omitempty"`
+}
+
+// FleetStatusOutput is the output of `plonk fleet status`
+type FleetStatusOutput struct {
+	Hosts      []FleetHost `json:"hosts" yaml:"hosts"`
+	TotalHosts int         `json:"total_hosts" yaml:"total_hosts"`
+	OutOfSync  int         `json:"out_of_sync" yaml:"out_of_sync"`
+}
+
+// TableOutput renders one row per host, followed by what each
+// out-of-sync host reported
+func (f FleetStatusOutput) TableOutput() string {
+	var out strings.Builder
+	WriteTitle(&out, "Fleet Status")
+
+	if len(f.Hosts) == 0 {
+		out.WriteString("No hosts have reported yet. Run 'plonk fleet report' on each machine.\n")
+		return out.String()
+	}
+
+	table := NewStandardTableBuilder("")
+	table.SetHeaders("HOST", "REPORTED", "LOCK", "STATUS")
+	for _, h := range f.Hosts {
+		lockState := "current"
+		if !h.LockCurrent {
+			lockState = "stale"
+		}
+		state := "in sync"
+		if !h.InSync {
+			state = fmt.Sprintf("%d missing, %d drifted, %d errors", h.Missing, h.Drifted, h.Errors)
+			if h.Missing+h.Drifted+h.Errors == 0 {
+				state = "lock changed since report"
+			}
+		}
+		table.AddRow(h.Host, h.ReportedAt.Local().Format("2006-01-02 15:04"), lockState, state)
+	}
+	out.WriteString(table.Build())
+	out.WriteString("\n")
+
+	for _, h := range f.Hosts {
+		if len(h.OutOfSync) == 0 {
+			continue
+		}
+		fmt.Fprintf(&out, "%s:\n", h.Host)
+		for _, item := range h.OutOfSync {
+			fmt.Fprintf(&out, "  %s\n", item)
+		}
+	}
+
+	fmt.Fprintf(&out, "Summary: %d of %d hosts out of sync\n", f.OutOfSync, f.TotalHosts)
+	return out.String()
+}
+
+// StructuredData returns the fleet status for serialization
+func (f FleetStatusOutput) StructuredData() any {
+	return f
+}
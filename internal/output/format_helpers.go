@@ -3,12 +3,17 @@ package output
 import (
 	"fmt"
 	"strings"
+	"unicode/utf8"
+
+	"github.com/richhaase/plonk/internal/i18n"
 )
 
-// WriteTitle writes a title with underline to the builder.
+// WriteTitle writes a title, translated into the current locale, with
+// underline to the builder.
 func WriteTitle(w *strings.Builder, title string) {
+	title = i18n.T(title)
 	w.WriteString(title + "\n")
-	w.WriteString(strings.Repeat("=", len(title)) + "\n\n")
+	w.WriteString(strings.Repeat("=", utf8.RuneCountInString(title)) + "\n\n")
 }
 
 // WriteRemoteSync writes the remote sync status line if non-empty.
@@ -16,7 +21,7 @@ func WriteRemoteSync(w *strings.Builder, syncStatus string) {
 	if syncStatus == "" {
 		return
 	}
-	fmt.Fprintf(w, "Remote: %s\n\n", syncStatus)
+	fmt.Fprintf(w, "%s\n\n", i18n.T("Remote: %s", syncStatus))
 }
 
 // WriteErrors writes domain-specific error items.
@@ -24,7 +29,7 @@ func WriteErrors(w *strings.Builder, domain string, errors []Item) {
 	if len(errors) == 0 {
 		return
 	}
-	fmt.Fprintf(w, "\n%s errors:\n", domain)
+	fmt.Fprintf(w, "\n%s\n", i18n.T("%s errors:", domain))
 	for _, item := range errors {
 		if item.Error != "" {
 			fmt.Fprintf(w, "  %s %s: %s\n", IconError, item.Name, item.Error)
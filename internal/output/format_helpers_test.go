@@ -3,6 +3,8 @@ package output
 import (
 	"strings"
 	"testing"
+
+	"github.com/richhaase/plonk/internal/i18n"
 )
 
 func TestWriteTitle(t *testing.T) {
@@ -14,6 +16,33 @@ func TestWriteTitle(t *testing.T) {
 	}
 }
 
+func TestWriteTitle_Translated(t *testing.T) {
+	if err := i18n.SetLocale("es"); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = i18n.SetLocale(i18n.English) }()
+
+	var b strings.Builder
+	WriteTitle(&b, "Package Licenses")
+	want := "Licencias de paquetes\n=====================\n\n"
+	if got := b.String(); got != want {
+		t.Errorf("WriteTitle() = %q, want %q", got, want)
+	}
+
+	table := NewStandardTableBuilder("")
+	table.SetHeaders("PACKAGE", "VERSIÓN")
+	table.AddRow("ripgrep", "14.1.0")
+	wantTable := "PAQUETE  VERSIÓN\n-------  -------\nripgrep  14.1.0\n\n"
+	if got := table.Build(); got != wantTable {
+		t.Errorf("Build() = %q, want %q", got, wantTable)
+	}
+
+	counts := StatusCounts{Managed: 5, Missing: 1, Drifted: 1}
+	if got, want := counts.TableOutput(), "4 gestionados, 1 ausentes, 1 modificados, 0 errores\n"; got != want {
+		t.Errorf("TableOutput() = %q, want %q", got, want)
+	}
+}
+
 func TestWriteRemoteSync(t *testing.T) {
 	t.Run("non-empty", func(t *testing.T) {
 		var b strings.Builder
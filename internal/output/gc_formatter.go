@@ -0,0 +1,92 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package output
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GCManagerResult is how cleaning one package manager's caches went
+type GCManagerResult struct {
+	Manager string `json:"manager" yaml:"manager"`
+	Command string `json:"command" yaml:"command"`
+	Freed   int64  `json:"freed_bytes" yaml:"freed_bytes"`
+	Skipped string `json:"skipped,omitempty" yaml:"skipped,omitempty"` // why the cleanup didn't run
+	Error   string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// GCRemovedFile is a file of plonk's own that gc removed
+type GCRemovedFile struct {
+	Kind  string `json:"kind" yaml:"kind"` // "snapshot" or "backup"
+	Path  string `json:"path" yaml:"path"`
+	Bytes int64  `json:"bytes" yaml:"bytes"`
+}
+
+// GCOutput is the output of `plonk gc`
+type GCOutput struct {
+	DryRun   bool              `json:"dry_run" yaml:"dry_run"`
+	Managers []GCManagerResult `json:"managers" yaml:"managers"`
+	Removed  []GCRemovedFile   `json:"removed" yaml:"removed"`
+	Freed    int64             `json:"freed_bytes" yaml:"freed_bytes"`
+}
+
+// TableOutput lists what was cleaned and the space it freed
+func (g GCOutput) TableOutput() string {
+	var out strings.Builder
+	WriteTitle(&out, "Garbage Collection")
+
+	if len(g.Managers) > 0 {
+		table := NewStandardTableBuilder("")
+		table.SetHeaders("MANAGER", "COMMAND", "FREED")
+		for _, m := range g.Managers {
+			freed := formatBytes(m.Freed)
+			switch {
+			case m.Error != "":
+				freed = "failed"
+			case m.Skipped != "":
+				freed = "skipped"
+			case g.DryRun:
+				freed = "-"
+			}
+			table.AddRow(m.Manager, m.Command, freed)
+		}
+		out.WriteString(table.Build())
+		out.WriteString("\n")
+		for _, m := range g.Managers {
+			if m.Error != "" {
+				fmt.Fprintf(&out, "%s %s: %s\n", IconError, m.Manager, m.Error)
+			}
+			if m.Skipped != "" {
+				fmt.Fprintf(&out, "%s %s skipped: %s\n", IconWarning, m.Manager, m.Skipped)
+			}
+		}
+	}
+
+	verb := "Removed"
+	if g.DryRun {
+		verb = "Would remove"
+	}
+	for _, r := range g.Removed {
+		fmt.Fprintf(&out, "%s %s %s (%s)\n", verb, r.Kind, r.Path, formatBytes(r.Bytes))
+	}
+
+	if g.DryRun {
+		fmt.Fprintf(&out, "\nDry run: %s of plonk's own files would be freed; cache cleanups were not run\n", formatBytes(g.Freed))
+	} else {
+		fmt.Fprintf(&out, "\n%s Freed %s\n", IconSuccess, formatBytes(g.Freed))
+	}
+	return out.String()
+}
+
+// StructuredData returns the results for serialization
+func (g GCOutput) StructuredData() any {
+	if g.Managers == nil {
+		g.Managers = []GCManagerResult{}
+	}
+	if g.Removed == nil {
+		g.Removed = []GCRemovedFile{}
+	}
+	return g
+}
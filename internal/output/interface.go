@@ -7,3 +7,9 @@ package output
 type OutputData interface {
 	TableOutput() string // Human-friendly table format
 }
+
+// StructuredOutput is implemented by output data that exposes a dedicated
+// shape for JSON/YAML serialization
+type StructuredOutput interface {
+	StructuredData() any
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strings"
+)
+
+// unknownLicense stands in for a license the manager's metadata doesn't
+// declare
+const unknownLicense = "unknown"
+
+// PackageLicense is the license a tracked package declares
+type PackageLicense struct {
+	Manager string `json:"manager" yaml:"manager"`
+	Name    string `json:"name" yaml:"name"`
+	License string `json:"license" yaml:"license"` // "" if undeclared
+}
+
+// LicensesOutput is the output of `plonk licenses`
+type LicensesOutput struct {
+	Packages []PackageLicense `json:"packages" yaml:"packages"`
+}
+
+// TableOutput lists each package's license
+func (l LicensesOutput) TableOutput() string {
+	var out strings.Builder
+	WriteTitle(&out, "Package Licenses")
+
+	if len(l.Packages) == 0 {
+		out.WriteString("No packages tracked\n")
+		return out.String()
+	}
+
+	table := NewStandardTableBuilder("")
+	table.SetHeaders("PACKAGE", "MANAGER", "LICENSE")
+	unknown := 0
+	for _, p := range l.Packages {
+		license := p.License
+		if license == "" {
+			license = unknownLicense
+			unknown++
+		}
+		table.AddRow(p.Name, p.Manager, license)
+	}
+	out.WriteString(table.Build())
+
+	fmt.Fprintf(&out, "\n%d packages", len(l.Packages))
+	if unknown > 0 {
+		fmt.Fprintf(&out, "; %s %d without a declared license, check them by hand", IconWarning, unknown)
+	}
+	out.WriteString("\n")
+	return out.String()
+}
+
+// StructuredData returns the licenses for serialization
+func (l LicensesOutput) StructuredData() any {
+	if l.Packages == nil {
+		l.Packages = []PackageLicense{}
+	}
+	return l
+}
+
+// CSV returns the licenses as CSV with a header row, for spreadsheets and
+// license review tools
+func (l LicensesOutput) CSV() (string, error) {
+	var out strings.Builder
+	w := csv.NewWriter(&out)
+	_ = w.Write([]string{"package", "manager", "license"})
+	for _, p := range l.Packages {
+		license := p.License
+		if license == "" {
+			license = unknownLicense
+		}
+		_ = w.Write([]string{p.Name, p.Manager, license})
+	}
+	w.Flush()
+	return out.String(), w.Error()
+}
@@ -0,0 +1,32 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package output
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLicensesOutput(t *testing.T) {
+	licenses := LicensesOutput{Packages: []PackageLicense{
+		{Manager: "brew", Name: "ripgrep", License: "Unlicense OR MIT"},
+		{Manager: "go", Name: "golang.org/x/tools/gopls"},
+	}}
+
+	table := licenses.TableOutput()
+	for _, want := range []string{"ripgrep", "Unlicense OR MIT", "unknown", "2 packages", "1 without a declared license"} {
+		if !strings.Contains(table, want) {
+			t.Errorf("table output missing %q:\n%s", want, table)
+		}
+	}
+
+	csv, err := licenses.CSV()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "package,manager,license\nripgrep,brew,Unlicense OR MIT\ngolang.org/x/tools/gopls,go,unknown\n"
+	if csv != want {
+		t.Errorf("CSV() = %q, want %q", csv, want)
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package output
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Values accepted by ListOptions
+var (
+	ListSorts    = []string{"name", "manager", "state"}
+	ListStates   = []string{"managed", "missing", "drifted", "error"}
+	ListGroupBys = []string{"manager"}
+)
+
+// ListOptions filters, orders and groups the items of a listing (packages,
+// dotfiles). They are applied to the result before it is rendered, so every
+// output format shows the same items in the same order.
+type ListOptions struct {
+	Sort    string // "name" (default), "manager" or "state"
+	State   string // only items in this state; "" for all
+	GroupBy string // "manager" to group by manager; "" for none
+}
+
+// Validate rejects unknown sort keys, states and groupings
+func (o ListOptions) Validate() error {
+	for _, check := range []struct {
+		flag, value string
+		allowed     []string
+	}{
+		{"sort", o.Sort, ListSorts},
+		{"state", o.State, ListStates},
+		{"group-by", o.GroupBy, ListGroupBys},
+	} {
+		if check.value != "" && !slices.Contains(check.allowed, check.value) {
+			return fmt.Errorf("invalid --%s %q (use %s)", check.flag, check.value, strings.Join(check.allowed, ", "))
+		}
+	}
+	return nil
+}
+
+// IsZero reports whether no option is set, so the default view applies
+func (o ListOptions) IsZero() bool {
+	return o == ListOptions{}
+}
+
+// Filter keeps the items of r in the selected state
+func (o ListOptions) Filter(r Result) Result {
+	if o.State == "" {
+		return r
+	}
+	keep := func(items []Item) []Item {
+		var kept []Item
+		for _, item := range items {
+			if string(item.State) == o.State {
+				kept = append(kept, item)
+			}
+		}
+		return kept
+	}
+	return Result{
+		Domain:    r.Domain,
+		Managed:   keep(r.Managed),
+		Missing:   keep(r.Missing),
+		Untracked: keep(r.Untracked),
+		Errors:    keep(r.Errors),
+	}
+}
+
+// Items returns the shown items of r, filtered and in display order
+func (o ListOptions) Items(r Result) []Item {
+	r = o.Filter(r)
+	items := slices.Concat(r.Managed, r.Missing, r.Errors)
+	slices.SortStableFunc(items, o.compare)
+	return items
+}
+
+// Order sorts structured items the same way Items does. Without options
+// the default order (managed, missing, errors) is kept.
+func (o ListOptions) Order(items []ManagedItem) {
+	if o.IsZero() {
+		return
+	}
+	slices.SortStableFunc(items, func(a, b ManagedItem) int {
+		return o.compare(Item{Name: a.Name, Manager: a.Manager, State: ItemState(a.State)},
+			Item{Name: b.Name, Manager: b.Manager, State: ItemState(b.State)})
+	})
+}
+
+// compare orders items by group, then the sort key, then name
+func (o ListOptions) compare(a, b Item) int {
+	if o.GroupBy == "manager" {
+		if c := cmp.Compare(a.Manager, b.Manager); c != 0 {
+			return c
+		}
+	}
+	switch o.Sort {
+	case "manager":
+		if c := cmp.Compare(a.Manager, b.Manager); c != 0 {
+			return c
+		}
+	case "state":
+		if c := cmp.Compare(string(a.State), string(b.State)); c != 0 {
+			return c
+		}
+	}
+	return cmp.Compare(a.Name, b.Name)
+}
+
+// writeItemList renders items as one table, or a table per manager when
+// grouping. label gives each item's display name and status.
+func (o ListOptions) writeItemList(out *strings.Builder, items []Item, label func(Item) (name, status string)) {
+	if len(items) == 0 {
+		out.WriteString("No matching items.\n\n")
+		return
+	}
+
+	if o.GroupBy != "manager" {
+		table := NewStandardTableBuilder("")
+		table.SetHeaders("NAME", "MANAGER", "STATUS")
+		for _, item := range items {
+			name, status := label(item)
+			table.AddRow(name, item.Manager, status)
+		}
+		out.WriteString(table.Build())
+		out.WriteString("\n")
+		return
+	}
+
+	for start := 0; start < len(items); {
+		manager := items[start].Manager
+		end := start
+		for end < len(items) && items[end].Manager == manager {
+			end++
+		}
+		fmt.Fprintf(out, "%s (%d):\n", cmp.Or(manager, "-"), end-start)
+		table := NewStandardTableBuilder("")
+		table.SetHeaders("NAME", "STATUS")
+		for _, item := range items[start:end] {
+			name, status := label(item)
+			table.AddRow(name, status)
+		}
+		out.WriteString(table.Build())
+		out.WriteString("\n")
+		start = end
+	}
+}
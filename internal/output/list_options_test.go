@@ -0,0 +1,119 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func listPackages() Result {
+	return Result{
+		Domain: "package",
+		Managed: []Item{
+			{Name: "ripgrep", Manager: "brew", State: StateManaged},
+			{Name: "black", Manager: "uv", State: StateManaged},
+		},
+		Missing: []Item{
+			{Name: "fd", Manager: "brew", State: StateMissing},
+		},
+		Errors: []Item{
+			{Name: "bat", Manager: "cargo", State: StateError, Error: "network down"},
+		},
+	}
+}
+
+func itemNames[T any](items []T, name func(T) string) []string {
+	names := make([]string, 0, len(items))
+	for _, item := range items {
+		names = append(names, name(item))
+	}
+	return names
+}
+
+func TestListOptions_Validate(t *testing.T) {
+	assert.NoError(t, ListOptions{}.Validate())
+	assert.NoError(t, ListOptions{Sort: "state", State: "drifted", GroupBy: "manager"}.Validate())
+	assert.ErrorContains(t, ListOptions{Sort: "size"}.Validate(), "--sort")
+	assert.ErrorContains(t, ListOptions{State: "untracked"}.Validate(), "--state")
+	assert.ErrorContains(t, ListOptions{GroupBy: "state"}.Validate(), "--group-by")
+}
+
+func TestListOptions_Items(t *testing.T) {
+	byName := func(i Item) string { return i.Name }
+	tests := []struct {
+		name string
+		opts ListOptions
+		want []string
+	}{
+		{"by name", ListOptions{Sort: "name"}, []string{"bat", "black", "fd", "ripgrep"}},
+		{"by manager", ListOptions{Sort: "manager"}, []string{"fd", "ripgrep", "bat", "black"}},
+		{"by state", ListOptions{Sort: "state"}, []string{"bat", "black", "ripgrep", "fd"}},
+		{"missing only", ListOptions{State: "missing"}, []string{"fd"}},
+		{"grouped", ListOptions{GroupBy: "manager", Sort: "state"}, []string{"ripgrep", "fd", "bat", "black"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, itemNames(tt.opts.Items(listPackages()), byName))
+		})
+	}
+}
+
+func TestListOptions_AllFormatsAgree(t *testing.T) {
+	opts := ListOptions{State: "managed", Sort: "name"}
+	f := NewPackagesStatusFormatter(PackagesStatusOutput{Result: listPackages(), Options: opts})
+
+	table := f.TableOutput()
+	assert.Contains(t, table, "black")
+	assert.Contains(t, table, "ripgrep")
+	assert.NotContains(t, table, "fd")
+	assert.Contains(t, table, "Summary: 2 managed\n")
+
+	sd := f.StructuredData().(PackagesStatusOutputSummary)
+	assert.Equal(t, 2, sd.Summary.TotalManaged)
+	assert.Equal(t, 0, sd.Summary.TotalMissing)
+	assert.Equal(t, []string{"black", "ripgrep"}, itemNames(sd.Items, func(i ManagedItem) string { return i.Name }))
+
+	fromJSON, fromYAML := structured(t, f)
+	assert.Equal(t, fromJSON, fromYAML)
+	assert.Equal(t, "packages: 2 managed, 0 missing, 0 errors", f.SummaryLine())
+}
+
+func TestListOptions_GroupByManagerTable(t *testing.T) {
+	f := NewPackagesStatusFormatter(PackagesStatusOutput{Result: listPackages(), Options: ListOptions{GroupBy: "manager"}})
+	table := f.TableOutput()
+	assert.Contains(t, table, "brew (2):")
+	assert.Contains(t, table, "cargo (1):")
+	assert.Contains(t, table, "uv (1):")
+	assert.Less(t, strings.Index(table, "brew (2):"), strings.Index(table, "uv (1):"))
+}
+
+func TestListOptions_DotfilesDrifted(t *testing.T) {
+	f := NewDotfilesStatusFormatter(DotfilesStatusOutput{
+		Result:  parityDotfiles(),
+		HomeDir: "/home/u",
+		Options: ListOptions{State: "drifted"},
+	})
+	table := f.TableOutput()
+	assert.Contains(t, table, "~/.vimrc")
+	assert.NotContains(t, table, ".zshrc")
+	assert.Contains(t, table, "Summary: 0 managed, 1 drifted\n")
+
+	sd := f.StructuredData().(DotfilesStatusOutputSummary)
+	require.Len(t, sd.Items, 1)
+	assert.Equal(t, ".vimrc", sd.Items[0].Name)
+	assert.Equal(t, 1, sd.Summary.Drifted)
+}
+
+func TestListOptions_NoMatches(t *testing.T) {
+	f := NewPackagesStatusFormatter(PackagesStatusOutput{Result: listPackages(), Options: ListOptions{State: "drifted"}})
+	assert.Contains(t, f.TableOutput(), "No matching items.")
+
+	sd := f.StructuredData().(PackagesStatusOutputSummary)
+	assert.NotNil(t, sd.Items)
+	assert.Empty(t, sd.Items)
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package output
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ManagerInfo describes one package manager plonk can drive on this machine
+type ManagerInfo struct {
+	Name         string   `json:"name" yaml:"name"`
+	Plugin       bool     `json:"plugin" yaml:"plugin"`
+	Available    bool     `json:"available" yaml:"available"`
+	Path         string   `json:"path,omitempty" yaml:"path,omitempty"`
+	Version      string   `json:"version,omitempty" yaml:"version,omitempty"`
+	Reason       string   `json:"reason,omitempty" yaml:"reason,omitempty"` // why it is unavailable
+	Hint         string   `json:"hint,omitempty" yaml:"hint,omitempty"`
+	Capabilities []string `json:"capabilities" yaml:"capabilities"`
+}
+
+// ManagersOutput is the output of `plonk managers`
+type ManagersOutput struct {
+	Managers []ManagerInfo `json:"managers" yaml:"managers"`
+}
+
+// TableOutput lists each manager with its availability and capabilities
+func (m ManagersOutput) TableOutput() string {
+	var out strings.Builder
+	WriteTitle(&out, "Package Managers")
+
+	table := NewStandardTableBuilder("")
+	table.SetHeaders("MANAGER", "STATUS", "VERSION", "CAPABILITIES")
+	var unavailable []ManagerInfo
+	for _, info := range m.Managers {
+		name := info.Name
+		if info.Plugin {
+			name += " (plugin)"
+		}
+		status := "available"
+		if !info.Available {
+			status = "unavailable"
+			unavailable = append(unavailable, info)
+		}
+		version := info.Version
+		if version == "" {
+			version = "-"
+		}
+		capabilities := strings.Join(info.Capabilities, ", ")
+		if capabilities == "" {
+			capabilities = "-"
+		}
+		table.AddRow(name, status, version, capabilities)
+	}
+	out.WriteString(table.Build())
+
+	for _, info := range unavailable {
+		fmt.Fprintf(&out, "%s %s: %s", IconWarning, info.Name, info.Reason)
+		if info.Hint != "" {
+			fmt.Fprintf(&out, "; %s", info.Hint)
+		}
+		out.WriteString("\n")
+	}
+	if len(unavailable) > 0 {
+		out.WriteString("\n")
+	}
+	out.WriteString("See 'plonk help managers' for what each capability means.\n")
+	return out.String()
+}
+
+// StructuredData returns the managers for serialization
+func (m ManagersOutput) StructuredData() any {
+	managers := make([]ManagerInfo, len(m.Managers))
+	for i, info := range m.Managers {
+		if info.Capabilities == nil {
+			info.Capabilities = []string{}
+		}
+		managers[i] = info
+	}
+	return ManagersOutput{Managers: managers}
+}
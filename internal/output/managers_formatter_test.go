@@ -0,0 +1,28 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package output
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManagersOutput(t *testing.T) {
+	out := ManagersOutput{Managers: []ManagerInfo{
+		{Name: "brew", Available: true, Path: "/opt/homebrew/bin/brew", Version: "4.3.5", Capabilities: []string{"batch", "renames"}},
+		{Name: "uv", Reason: "manager not found on PATH", Hint: "install uv", Capabilities: []string{"pinning"}},
+		{Name: "mas", Plugin: true, Available: true},
+	}}
+
+	table := out.TableOutput()
+	assert.Regexp(t, `brew\s+available\s+4\.3\.5\s+batch, renames`, table)
+	assert.Regexp(t, `uv\s+unavailable\s+-\s+pinning`, table)
+	assert.Regexp(t, `mas \(plugin\)\s+available\s+-\s+-`, table)
+	assert.Contains(t, table, "uv: manager not found on PATH; install uv")
+
+	data := out.StructuredData().(ManagersOutput)
+	assert.Equal(t, []string{}, data.Managers[2].Capabilities)
+	assert.Nil(t, out.Managers[2].Capabilities, "StructuredData leaves the output alone")
+}
@@ -0,0 +1,28 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package output
+
+// NixPackage is one package in `plonk export nix`
+type NixPackage struct {
+	Spec      string `json:"spec" yaml:"spec"`
+	Attribute string `json:"attribute,omitempty" yaml:"attribute,omitempty"` // empty when unmapped
+}
+
+// NixExportOutput is the output of `plonk export nix`
+type NixExportOutput struct {
+	Kind     string       `json:"kind" yaml:"kind"` // "home-manager" or "flake"
+	Nix      string       `json:"nix" yaml:"nix"`
+	Packages []NixPackage `json:"packages" yaml:"packages"`
+}
+
+// TableOutput returns the generated Nix expression as-is, so it can be
+// redirected to a file
+func (n NixExportOutput) TableOutput() string {
+	return n.Nix
+}
+
+// StructuredData returns the mapping and the generated expression
+func (n NixExportOutput) StructuredData() any {
+	return n
+}
@@ -10,8 +10,9 @@ import (
 
 // PackagesStatusOutput represents the output structure for packages status command
 type PackagesStatusOutput struct {
-	Result     Result `json:"result" yaml:"result"`
-	RemoteSync string `json:"remote_sync,omitempty" yaml:"remote_sync,omitempty"`
+	Result     Result      `json:"result" yaml:"result"`
+	RemoteSync string      `json:"remote_sync,omitempty" yaml:"remote_sync,omitempty"`
+	Options    ListOptions `json:"-" yaml:"-"` // --sort, --state, --group-by
 }
 
 // PackagesStatusFormatter formats packages status output
@@ -26,6 +27,10 @@ func NewPackagesStatusFormatter(data PackagesStatusOutput) PackagesStatusFormatt
 
 // TableOutput generates human-friendly table output for packages status
 func (f PackagesStatusFormatter) TableOutput() string {
+	if !f.Data.Options.IsZero() {
+		return f.listOutput()
+	}
+
 	var output strings.Builder
 	result := f.Data.Result
 
@@ -57,33 +62,22 @@ func (f PackagesStatusFormatter) TableOutput() string {
 			packages := packagesByManager[manager]
 			sortItems(packages) // Sort packages alphabetically within each manager
 			for _, pkg := range packages {
-				pkgBuilder.AddRow(pkg.Name, manager, "managed")
+				pkgBuilder.AddRow(pkg.Name, manager, packageManagedStatus(pkg))
 			}
 		}
 
 		// Show missing packages
 		for _, pkg := range missingPackages {
-			pkgBuilder.AddRow(pkg.Name, pkg.Manager, "missing")
+			pkgBuilder.AddRow(pkg.Name, pkg.Manager, packageMissingStatus(pkg))
 		}
 
 		output.WriteString(pkgBuilder.Build())
 		output.WriteString("\n")
+		writeDependencyHint(&output, result.Managed)
+		writeRenameHint(&output, missingPackages)
 	}
 
-	// Add summary
-	managedCount := len(result.Managed)
-	missingCount := len(result.Missing)
-	errorCount := len(result.Errors)
-
-	output.WriteString("Summary: ")
-	fmt.Fprintf(&output, "%d managed", managedCount)
-	if missingCount > 0 {
-		fmt.Fprintf(&output, ", %d missing", missingCount)
-	}
-	if errorCount > 0 {
-		fmt.Fprintf(&output, ", %d errors", errorCount)
-	}
-	output.WriteString("\n")
+	writePackagesSummary(&output, result)
 
 	WriteErrors(&output, "package", result.Errors)
 
@@ -97,64 +91,61 @@ func (f PackagesStatusFormatter) TableOutput() string {
 	return output.String()
 }
 
-// StructuredData returns the structured data for serialization
-func (f PackagesStatusFormatter) StructuredData() any {
-	result := f.Data.Result
-
-	var items []ManagedItem
-
-	// Add managed items
-	for _, item := range result.Managed {
-		items = append(items, ManagedItem{
-			Name:     item.Name,
-			Domain:   "package",
-			State:    string(item.State),
-			Manager:  item.Manager,
-			Path:     item.Path,
-			Metadata: sanitizeMetadata(item.Metadata),
-		})
-	}
-
-	// Add missing items
-	for _, item := range result.Missing {
-		items = append(items, ManagedItem{
-			Name:     item.Name,
-			Domain:   "package",
-			State:    string(item.State),
-			Manager:  item.Manager,
-			Path:     item.Path,
-			Metadata: sanitizeMetadata(item.Metadata),
-		})
-	}
+// listOutput renders the packages selected by --sort, --state and
+// --group-by as a single list
+func (f PackagesStatusFormatter) listOutput() string {
+	var output strings.Builder
+	opts := f.Data.Options
 
-	// Add error items
-	for _, item := range result.Errors {
-		mi := ManagedItem{
-			Name:    item.Name,
-			Domain:  "package",
-			State:   string(item.State),
-			Manager: item.Manager,
-			Error:   item.Error,
+	WriteTitle(&output, "Packages Status")
+	WriteRemoteSync(&output, f.Data.RemoteSync)
+	opts.writeItemList(&output, opts.Items(f.Data.Result), func(item Item) (string, string) {
+		switch item.State {
+		case StateMissing:
+			return item.Name, packageMissingStatus(item)
+		case StateError:
+			return item.Name, "error"
 		}
-		items = append(items, mi)
-	}
+		return item.Name, packageManagedStatus(item)
+	})
+	writePackagesSummary(&output, opts.Filter(f.Data.Result))
+	return output.String()
+}
 
-	summary := Summary{
-		TotalManaged:   len(result.Managed),
-		TotalMissing:   len(result.Missing),
-		TotalUntracked: len(result.Untracked),
-		TotalErrors:    len(result.Errors),
-		Results:        []Result{result},
+// writePackagesSummary writes the package counts line
+func writePackagesSummary(output *strings.Builder, result Result) {
+	output.WriteString("Summary: ")
+	fmt.Fprintf(output, "%d managed", len(result.Managed))
+	if len(result.Missing) > 0 {
+		fmt.Fprintf(output, ", %d missing", len(result.Missing))
 	}
+	if len(result.Errors) > 0 {
+		fmt.Fprintf(output, ", %d errors", len(result.Errors))
+	}
+	output.WriteString("\n")
+}
 
+// StructuredData returns the structured data for serialization
+func (f PackagesStatusFormatter) StructuredData() any {
+	result := f.Data.Options.Filter(f.Data.Result)
+	items := NewManagedItems("package", result)
+	f.Data.Options.Order(items)
 	return PackagesStatusOutputSummary{
-		Summary: summary,
-		Items:   items,
+		RemoteSync: f.Data.RemoteSync,
+		Summary: Summary{
+			TotalManaged:   len(result.Managed),
+			TotalMissing:   len(result.Missing),
+			TotalUntracked: len(result.Untracked),
+			TotalErrors:    len(result.Errors),
+			Results:        []Result{sanitizeResult(result)},
+		},
+		Items: items,
 	}
 }
 
 // PackagesStatusOutputSummary represents the structured output format
 type PackagesStatusOutputSummary struct {
-	Summary Summary       `json:"summary" yaml:"summary"`
-	Items   []ManagedItem `json:"items" yaml:"items"`
+	RemoteSync string        `json:"remote_sync,omitempty" yaml:"remote_sync,omitempty"`
+	Summary    Summary       `json:"summary" yaml:"summary"`
+	Items      []ManagedItem `json:"items" yaml:"items"`
 }
@@ -0,0 +1,122 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package output
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func parityPackages() Result {
+	return Result{
+		Domain: "package",
+		Managed: []Item{
+			{Name: "ripgrep", Manager: "brew", State: StateManaged},
+		},
+		Missing: []Item{
+			{Name: "fd", Manager: "brew", State: StateMissing},
+		},
+		Errors: []Item{
+			{Name: "bat", Manager: "cargo", State: StateError, Error: "network down", ErrorClass: "network"},
+		},
+	}
+}
+
+func parityDotfiles() Result {
+	return Result{
+		Domain: "dotfile",
+		Managed: []Item{
+			{Name: ".zshrc", State: StateManaged, Metadata: map[string]interface{}{"destination": "/home/u/.zshrc"}},
+			{Name: ".vimrc", State: StateDegraded, Metadata: map[string]interface{}{"destination": "/home/u/.vimrc", "drift_status": "drifted"}},
+		},
+		Missing: []Item{
+			{Name: ".gitconfig", State: StateMissing, Metadata: map[string]interface{}{"destination": "/home/u/.gitconfig", "compare": func() {}}},
+		},
+	}
+}
+
+// structured encodes data the way -o json and -o yaml do and decodes both
+// into generic values, normalized through JSON for comparison
+func structured(t *testing.T, data OutputData) (fromJSON, fromYAML any) {
+	t.Helper()
+	sd := structuredData(data)
+
+	encoded, err := json.Marshal(sd)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(encoded, &fromJSON))
+
+	encoded, err = yaml.Marshal(sd)
+	require.NoError(t, err)
+	var decoded any
+	require.NoError(t, yaml.Unmarshal(encoded, &decoded))
+	normalized, err := json.Marshal(decoded)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(normalized, &fromYAML))
+	return fromJSON, fromYAML
+}
+
+func TestStructuredOutput_JSONAndYAMLCarrySameData(t *testing.T) {
+	outputs := map[string]OutputData{
+		"status": NewStatusFormatter(StatusOutput{
+			ConfigPath: "/p/plonk.yaml",
+			LockPath:   "/p/plonk.lock",
+			RemoteSync: "2 commits behind",
+			StateSummary: Summary{
+				TotalManaged: 3, TotalMissing: 2, TotalErrors: 1,
+				Results: []Result{parityPackages(), parityDotfiles()},
+			},
+		}),
+		"packages": NewPackagesStatusFormatter(PackagesStatusOutput{Result: parityPackages(), RemoteSync: "up to date"}),
+		"dotfiles": NewDotfilesStatusFormatter(DotfilesStatusOutput{Result: parityDotfiles(), RemoteSync: "up to date"}),
+		"doctor": NewDoctorFormatter(DoctorOutput{
+			Overall: HealthStatus{Status: "warning", Message: "1 warning"},
+			Checks:  []HealthCheck{{Name: "PATH", Category: "environment", Status: "warn", Issues: []string{"~/.cargo/bin missing"}}},
+		}),
+		"apply": ApplyResult{
+			Success: false, Changed: true, Scope: "all", Error: "1 package failed",
+			Packages: &PackageResults{TotalInstalled: 1, TotalFailed: 1, Managers: []ManagerResults{
+				{Name: "brew", MissingCount: 2, Packages: []PackageOperation{{Name: "fd", Status: "installed"}, {Name: "jq", Status: "failed", Error: "boom", ErrorClass: "unknown"}}},
+			}},
+		},
+	}
+
+	for name, data := range outputs {
+		t.Run(name, func(t *testing.T) {
+			fromJSON, fromYAML := structured(t, data)
+			assert.Equal(t, fromJSON, fromYAML)
+		})
+	}
+}
+
+func TestPackagesStructuredData_MatchesTable(t *testing.T) {
+	f := NewPackagesStatusFormatter(PackagesStatusOutput{Result: parityPackages(), RemoteSync: "2 commits behind"})
+	assert.Contains(t, f.TableOutput(), "2 commits behind")
+
+	sd := f.StructuredData().(PackagesStatusOutputSummary)
+	assert.Equal(t, "2 commits behind", sd.RemoteSync)
+	require.Len(t, sd.Items, 3)
+	assert.Equal(t, "network", sd.Items[2].ErrorClass)
+}
+
+func TestDotfilesStructuredData_MatchesTable(t *testing.T) {
+	f := NewDotfilesStatusFormatter(DotfilesStatusOutput{Result: parityDotfiles(), RemoteSync: "up to date", HomeDir: "/home/u"})
+	table := f.TableOutput()
+	assert.Contains(t, table, "1 drifted")
+
+	sd := f.StructuredData().(DotfilesStatusOutputSummary)
+	assert.Equal(t, "up to date", sd.RemoteSync)
+	assert.Equal(t, 1, sd.Summary.Drifted)
+	assert.Equal(t, 2, sd.Summary.Managed)
+
+	targets := map[string]string{}
+	for _, item := range sd.Items {
+		targets[item.Name] = item.Target
+	}
+	assert.Equal(t, "/home/u/.gitconfig", targets[".gitconfig"])
+	assert.NotContains(t, sd.Items[2].Metadata, "compare", "function values are dropped")
+}
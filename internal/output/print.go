@@ -15,3 +15,13 @@ func Printf(format string, args ...interface{}) {
 func Println(args ...interface{}) {
 	progressWriter.Printf("%s\n", fmt.Sprint(args...))
 }
+
+// StreamLine writes one line of live subprocess output prefixed with its
+// source. On a terminal it first clears the current line, so a running
+// spinner is redrawn below the streamed output instead of mangling it.
+func StreamLine(prefix, line string) {
+	if progressWriter.IsTerminal() {
+		progressWriter.Printf("\r\033[K")
+	}
+	progressWriter.Printf("%s %s\n", ColorInfo("["+prefix+"]"), line)
+}
@@ -3,13 +3,91 @@
 
 package output
 
-import "fmt"
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
 
-// RenderOutput renders data in table format.
+	"gopkg.in/yaml.v3"
+)
+
+// OutputFormat represents the output format for command results
+type OutputFormat string
+
+const (
+	OutputTable OutputFormat = "table"
+	OutputJSON  OutputFormat = "json"
+	OutputYAML  OutputFormat = "yaml"
+)
+
+// currentFormat is the format used by RenderOutput, set once from the --output flag
+var currentFormat = OutputTable
+
+// ParseOutputFormat converts a string to an OutputFormat
+func ParseOutputFormat(format string) (OutputFormat, error) {
+	switch strings.ToLower(format) {
+	case "", "table":
+		return OutputTable, nil
+	case "json":
+		return OutputJSON, nil
+	case "yaml":
+		return OutputYAML, nil
+	default:
+		return OutputTable, fmt.Errorf("unsupported output format: %s (use table, json, or yaml)", format)
+	}
+}
+
+// SetOutputFormat sets the format used by RenderOutput
+func SetOutputFormat(format OutputFormat) {
+	currentFormat = format
+}
+
+// GetOutputFormat returns the format used by RenderOutput
+func GetOutputFormat() OutputFormat {
+	return currentFormat
+}
+
+// IsStructured reports whether the current format is machine-readable
+func IsStructured() bool {
+	return currentFormat != OutputTable
+}
+
+// RenderOutput renders data in the current output format.
 // No-op if data is nil.
 func RenderOutput(data OutputData) {
 	if data == nil {
 		return
 	}
-	fmt.Print(data.TableOutput())
+	if renderSummary(data) {
+		return
+	}
+
+	switch currentFormat {
+	case OutputJSON:
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetEscapeHTML(false)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(structuredData(data)); err != nil {
+			fmt.Printf("{\"error\": %q}\n", err.Error())
+		}
+	case OutputYAML:
+		encoded, err := yaml.Marshal(structuredData(data))
+		if err != nil {
+			fmt.Printf("error: %s\n", err.Error())
+			return
+		}
+		fmt.Print(string(encoded))
+	default:
+		fmt.Print(data.TableOutput())
+	}
+}
+
+// structuredData returns the serializable form of data, falling back to
+// the value itself when it does not provide one
+func structuredData(data OutputData) any {
+	if s, ok := data.(StructuredOutput); ok {
+		return s.StructuredData()
+	}
+	return data
 }
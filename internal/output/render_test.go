@@ -11,3 +11,40 @@ func TestRenderOutput_CallsTableOutput(t *testing.T) {
 	// RenderOutput should not panic
 	RenderOutput(d)
 }
+
+func TestParseOutputFormat(t *testing.T) {
+	tests := map[string]OutputFormat{
+		"":      OutputTable,
+		"table": OutputTable,
+		"JSON":  OutputJSON,
+		"yaml":  OutputYAML,
+	}
+	for in, want := range tests {
+		got, err := ParseOutputFormat(in)
+		if err != nil {
+			t.Fatalf("ParseOutputFormat(%q) error: %v", in, err)
+		}
+		if got != want {
+			t.Errorf("ParseOutputFormat(%q) = %q, want %q", in, got, want)
+		}
+	}
+
+	if _, err := ParseOutputFormat("xml"); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
+
+type structuredDummy struct{}
+
+func (d structuredDummy) TableOutput() string { return "table" }
+func (d structuredDummy) StructuredData() any { return map[string]string{"k": "v"} }
+
+func TestStructuredData_PrefersStructuredOutput(t *testing.T) {
+	got, ok := structuredData(structuredDummy{}).(map[string]string)
+	if !ok || got["k"] != "v" {
+		t.Errorf("structuredData() = %#v, want StructuredData() result", got)
+	}
+	if _, ok := structuredData(dummy{}).(dummy); !ok {
+		t.Error("structuredData() should fall back to the value itself")
+	}
+}
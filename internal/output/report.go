@@ -0,0 +1,182 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Report is the shareable environment report of `plonk report`. It is
+// built and rendered locally; nothing is sent anywhere.
+type Report struct {
+	GeneratedAt time.Time       `json:"generated_at" yaml:"generated_at"`
+	Plonk       string          `json:"plonk" yaml:"plonk"`       // plonk version
+	Platform    string          `json:"platform" yaml:"platform"` // e.g. darwin/arm64
+	Managers    []ReportManager `json:"managers" yaml:"managers"`
+	Dotfiles    []ReportDotfile `json:"dotfiles" yaml:"dotfiles"`
+	Doctor      DoctorOutput    `json:"doctor" yaml:"doctor"`
+}
+
+// ReportManager is one manager's tracked packages
+type ReportManager struct {
+	Name     string          `json:"name" yaml:"name"`
+	Version  string          `json:"version,omitempty" yaml:"version,omitempty"` // first line of `<manager> --version`
+	Packages []ReportPackage `json:"packages" yaml:"packages"`
+}
+
+// ReportPackage is one tracked package and whether it is installed
+type ReportPackage struct {
+	Name  string `json:"name" yaml:"name"`
+	State string `json:"state" yaml:"state"` // managed, missing or error
+}
+
+// ReportDotfile is one managed dotfile, with its target under ~
+type ReportDotfile struct {
+	Source string `json:"source" yaml:"source"`
+	Target string `json:"target" yaml:"target"`
+	State  string `json:"state" yaml:"state"`
+}
+
+// Markdown renders the report for a wiki page or issue
+func (r Report) Markdown() string {
+	var out strings.Builder
+	out.WriteString("# Plonk Report\n\n")
+	fmt.Fprintf(&out, "Generated %s by plonk %s on %s.\n\n", r.GeneratedAt.Format("2006-01-02 15:04 MST"), r.Plonk, r.Platform)
+
+	out.WriteString("## Packages\n\n")
+	if len(r.Managers) == 0 {
+		out.WriteString("No tracked packages.\n\n")
+	}
+	for _, m := range r.Managers {
+		fmt.Fprintf(&out, "### %s", m.Name)
+		if m.Version != "" {
+			fmt.Fprintf(&out, " (%s)", markdownCell(m.Version))
+		}
+		out.WriteString("\n\n| Package | State |\n|---------|-------|\n")
+		for _, p := range m.Packages {
+			fmt.Fprintf(&out, "| %s | %s |\n", markdownCell(p.Name), p.State)
+		}
+		out.WriteString("\n")
+	}
+
+	out.WriteString("## Dotfiles\n\n")
+	if len(r.Dotfiles) == 0 {
+		out.WriteString("No managed dotfiles.\n\n")
+	} else {
+		out.WriteString("| Source | Target | State |\n|--------|--------|-------|\n")
+		for _, d := range r.Dotfiles {
+			fmt.Fprintf(&out, "| %s | %s | %s |\n", markdownCell(d.Source), markdownCell(d.Target), d.State)
+		}
+		out.WriteString("\n")
+	}
+
+	fmt.Fprintf(&out, "## Doctor\n\nOverall: **%s**. %s\n\n", strings.ToUpper(r.Doctor.Overall.Status), r.Doctor.Overall.Message)
+	out.WriteString("| Check | Status | Message |\n|-------|--------|---------|\n")
+	for _, c := range r.Doctor.Checks {
+		fmt.Fprintf(&out, "| %s | %s | %s |\n", markdownCell(c.Name), strings.ToUpper(c.Status), markdownCell(reportCheckMessage(c)))
+	}
+	return out.String()
+}
+
+// HTML renders the report as a standalone page
+func (r Report) HTML() (string, error) {
+	var buf bytes.Buffer
+	if err := reportTemplate().Execute(&buf, r); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// reportCheckMessage is a check's message with its issues appended
+func reportCheckMessage(c HealthCheck) string {
+	if len(c.Issues) == 0 {
+		return c.Message
+	}
+	return c.Message + ": " + strings.Join(c.Issues, "; ")
+}
+
+// markdownCell keeps text on one line of a table cell
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// reportTemplate is parsed on first use, keeping it out of every plonk
+// command's startup
+var reportTemplate = sync.OnceValue(func() *template.Template {
+	return template.Must(template.New("report").Funcs(template.FuncMap{
+		"upper":   strings.ToUpper,
+		"message": reportCheckMessage,
+	}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Plonk Report</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", sans-serif; max-width: 60rem; margin: 2rem auto; padding: 0 1rem; color: #222; }
+table { border-collapse: collapse; margin-bottom: 1.5rem; }
+th, td { border: 1px solid #ccc; padding: 0.25rem 0.6rem; text-align: left; vertical-align: top; }
+th { background: #f4f4f4; }
+.managed, .deployed, .pass, .healthy { color: #1a7f37; }
+.missing, .warn, .warning { color: #9a6700; }
+.drifted, .error, .fail, .unhealthy { color: #cf222e; }
+.meta { color: #666; }
+</style>
+</head>
+<body>
+<h1>Plonk Report</h1>
+<p class="meta">Generated {{.GeneratedAt.Format "2006-01-02 15:04 MST"}} by plonk {{.Plonk}} on {{.Platform}}.</p>
+
+<h2>Packages</h2>
+{{- range .Managers}}
+<h3>{{.Name}}{{if .Version}} <span class="meta">({{.Version}})</span>{{end}}</h3>
+<table>
+<tr><th>Package</th><th>State</th></tr>
+{{- range .Packages}}
+<tr><td>{{.Name}}</td><td class="{{.State}}">{{.State}}</td></tr>
+{{- end}}
+</table>
+{{- else}}
+<p>No tracked packages.</p>
+{{- end}}
+
+<h2>Dotfiles</h2>
+{{- if .Dotfiles}}
+<table>
+<tr><th>Source</th><th>Target</th><th>State</th></tr>
+{{- range .Dotfiles}}
+<tr><td>{{.Source}}</td><td>{{.Target}}</td><td class="{{.State}}">{{.State}}</td></tr>
+{{- end}}
+</table>
+{{- else}}
+<p>No managed dotfiles.</p>
+{{- end}}
+
+<h2>Doctor</h2>
+<p>Overall: <strong class="{{.Doctor.Overall.Status}}">{{upper .Doctor.Overall.Status}}</strong>. {{.Doctor.Overall.Message}}</p>
+<table>
+<tr><th>Check</th><th>Status</th><th>Message</th></tr>
+{{- range .Doctor.Checks}}
+<tr><td>{{.Name}}</td><td class="{{.Status}}">{{upper .Status}}</td><td>{{message .}}</td></tr>
+{{- end}}
+</table>
+</body>
+</html>
+`))
+})
+
+// TableOutput renders the report as Markdown
+func (r Report) TableOutput() string {
+	return r.Markdown()
+}
+
+// StructuredData returns the report for JSON/YAML output
+func (r Report) StructuredData() any {
+	return r
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package output
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func testReport() Report {
+	return Report{
+		GeneratedAt: time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC),
+		Plonk:       "v0.30.0",
+		Platform:    "darwin/arm64",
+		Managers: []ReportManager{{
+			Name:    "brew",
+			Version: "Homebrew 4.4.0",
+			Packages: []ReportPackage{
+				{Name: "ripgrep", State: "managed"},
+				{Name: "fd", State: "missing"},
+			},
+		}},
+		Dotfiles: []ReportDotfile{{Source: "zshrc", Target: "~/.zshrc", State: "drifted"}},
+		Doctor: DoctorOutput{
+			Overall: HealthStatus{Status: "warning", Message: "Some issues detected"},
+			Checks: []HealthCheck{{
+				Name: "Package Managers", Status: "warn", Message: "1 missing",
+				Issues: []string{"cargo | rustup <not installed>"},
+			}},
+		},
+	}
+}
+
+func TestReport_Markdown(t *testing.T) {
+	md := testReport().Markdown()
+	for _, want := range []string{
+		"Generated 2025-03-01 12:00 UTC by plonk v0.30.0 on darwin/arm64.",
+		"### brew (Homebrew 4.4.0)",
+		"| fd | missing |",
+		"| zshrc | ~/.zshrc | drifted |",
+		"Overall: **WARNING**.",
+		`| Package Managers | WARN | 1 missing: cargo \| rustup <not installed> |`,
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("expected %q in:\n%s", want, md)
+		}
+	}
+}
+
+func TestReport_HTML(t *testing.T) {
+	page, err := testReport().HTML()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"<!DOCTYPE html>",
+		`<tr><td>fd</td><td class="missing">missing</td></tr>`,
+		"1 missing: cargo | rustup &lt;not installed&gt;",
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("expected %q in:\n%s", want, page)
+		}
+	}
+	if strings.Contains(page, "<not installed>") {
+		t.Error("check messages must be escaped")
+	}
+
+	empty, err := Report{}.HTML()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(empty, "No tracked packages.") || !strings.Contains(empty, "No managed dotfiles.") {
+		t.Errorf("unexpected empty report:\n%s", empty)
+	}
+}
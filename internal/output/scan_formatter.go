@@ -0,0 +1,50 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/richhaase/plonk/internal/secrets"
+)
+
+// SecretScanOutput is the output of `plonk scan secrets`
+type SecretScanOutput struct {
+	Paths    []string          `json:"paths" yaml:"paths"`
+	Findings []secrets.Finding `json:"findings" yaml:"findings"`
+}
+
+// TableOutput lists the findings, one row each
+func (s SecretScanOutput) TableOutput() string {
+	var out strings.Builder
+	WriteTitle(&out, "Secret Scan: "+strings.Join(s.Paths, ", "))
+
+	if len(s.Findings) == 0 {
+		fmt.Fprintf(&out, "%s No probable secrets found\n", IconSuccess)
+		return out.String()
+	}
+
+	table := NewStandardTableBuilder("")
+	table.SetHeaders("FILE", "LINE", "RULE", "MATCH")
+	for _, f := range s.Findings {
+		table.AddRow(f.Path, fmt.Sprint(f.Line), f.Rule, f.Match)
+	}
+	out.WriteString(table.Build())
+	noun := "secrets"
+	if len(s.Findings) == 1 {
+		noun = "secret"
+	}
+	fmt.Fprintf(&out, "\n%s %d probable %s; remove them or mark false positives with a %q comment\n",
+		IconWarning, len(s.Findings), noun, secrets.AllowMarker)
+	return out.String()
+}
+
+// StructuredData returns the findings for serialization
+func (s SecretScanOutput) StructuredData() any {
+	if s.Findings == nil {
+		s.Findings = []secrets.Finding{}
+	}
+	return s
+}
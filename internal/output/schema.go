@@ -0,0 +1,151 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SchemaVersion is the version of the published JSON schemas. It changes
+// only when a structured output changes incompatibly (a field is removed,
+// renamed or retyped); new fields are added within a version.
+const SchemaVersion = 1
+
+// schemaTypes are the structured outputs with a published schema, by name.
+// The name is the command, plus a suffix for alternate outputs.
+var schemaTypes = map[string]any{
+	"apply":          ApplyResult{},
+	"doctor":         DoctorOutput{},
+	"dotfiles":       DotfilesStatusOutputSummary{},
+	"packages":       PackagesStatusOutputSummary{},
+	"status":         StatusOutputSummary{},
+	"status-summary": StatusCounts{},
+}
+
+// SchemaNames lists the outputs that have a schema
+func SchemaNames() []string {
+	names := make([]string, 0, len(schemaTypes))
+	for name := range schemaTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Schema returns the JSON schema of the named output, derived from its Go
+// type: properties follow the json tags, fields without omitempty are
+// required, and arrays and maps without omitempty may be null.
+func Schema(name string) ([]byte, error) {
+	v, ok := schemaTypes[name]
+	if !ok {
+		return nil, fmt.Errorf("no schema for %q (available: %s)", name, strings.Join(SchemaNames(), ", "))
+	}
+
+	defs := make(map[string]any)
+	root := schemaFor(reflect.TypeOf(v), defs)
+	// Inline the root type rather than referring to it
+	rootName := reflect.TypeOf(v).Name()
+	root = defs[rootName].(map[string]any)
+	delete(defs, rootName)
+
+	root["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	root["title"] = fmt.Sprintf("plonk %s output", strings.ReplaceAll(name, "-", " --"))
+	root["version"] = SchemaVersion
+	if len(defs) > 0 {
+		root["$defs"] = defs
+	}
+
+	data, err := json.MarshalIndent(root, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// schemaFor returns the schema of t, adding named structs to defs
+func schemaFor(t reflect.Type, defs map[string]any) map[string]any {
+	if t == timeType {
+		return map[string]any{"type": "string", "format": "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return schemaFor(t.Elem(), defs)
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": schemaFor(t.Elem(), defs)}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": schemaFor(t.Elem(), defs)}
+	case reflect.Struct:
+		if _, ok := defs[t.Name()]; !ok {
+			defs[t.Name()] = nil // placeholder for recursive types
+			defs[t.Name()] = structSchema(t, defs)
+		}
+		return map[string]any{"$ref": "#/$defs/" + t.Name()}
+	}
+	return map[string]any{} // interfaces: any value
+}
+
+// structSchema returns the object schema of a struct's json fields
+func structSchema(t reflect.Type, defs map[string]any) map[string]any {
+	props := make(map[string]any)
+	required := []string{}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = f.Name
+		}
+		omitempty := strings.Contains(opts, "omitempty")
+
+		schema := schemaFor(f.Type, defs)
+		if !omitempty {
+			switch f.Type.Kind() {
+			case reflect.Slice, reflect.Map, reflect.Pointer, reflect.Interface:
+				schema = nullable(schema)
+			}
+			required = append(required, name)
+		}
+		props[name] = schema
+	}
+
+	sort.Strings(required)
+	return map[string]any{"type": "object", "properties": props, "required": required}
+}
+
+// nullable also allows null, which is how Go encodes nil slices, maps and
+// pointers
+func nullable(schema map[string]any) map[string]any {
+	if typ, ok := schema["type"].(string); ok {
+		schema["type"] = []string{typ, "null"}
+		return schema
+	}
+	if len(schema) == 0 {
+		return schema
+	}
+	return map[string]any{"anyOf": []any{schema, map[string]any{"type": "null"}}}
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// schemaDir holds the published schemas, which double as golden files.
+// Regenerate them with PLONK_UPDATE_SCHEMAS=1 go test ./internal/output,
+// and bump SchemaVersion if the change is incompatible.
+var schemaDir = filepath.Join("..", "..", "docs", "schemas")
+
+func TestSchemas_MatchPublished(t *testing.T) {
+	for _, name := range SchemaNames() {
+		t.Run(name, func(t *testing.T) {
+			got, err := Schema(name)
+			require.NoError(t, err)
+
+			path := filepath.Join(schemaDir, fmt.Sprintf("%s.v%d.json", name, SchemaVersion))
+			if os.Getenv("PLONK_UPDATE_SCHEMAS") != "" {
+				require.NoError(t, os.MkdirAll(schemaDir, 0o755))
+				require.NoError(t, os.WriteFile(path, got, 0o644))
+			}
+			want, err := os.ReadFile(path)
+			require.NoError(t, err, "schema not published; run with PLONK_UPDATE_SCHEMAS=1")
+			assert.Equal(t, string(want), string(got), "%s changed; if intended, regenerate with PLONK_UPDATE_SCHEMAS=1", path)
+		})
+	}
+}
+
+func TestSchema_Shape(t *testing.T) {
+	data, err := Schema("status-summary")
+	require.NoError(t, err)
+
+	var schema map[string]any
+	require.NoError(t, json.Unmarshal(data, &schema))
+	assert.Equal(t, "object", schema["type"])
+	assert.Equal(t, float64(SchemaVersion), schema["version"])
+	assert.Equal(t, "plonk status --summary output", schema["title"])
+
+	props := schema["properties"].(map[string]any)
+	assert.Equal(t, map[string]any{"type": "string", "format": "date-time"}, props["generated_at"])
+	assert.Equal(t, map[string]any{"type": "integer"}, props["managed"])
+	assert.Contains(t, schema["required"], "cached")
+}
+
+func TestSchema_OptionalAndNullable(t *testing.T) {
+	data, err := Schema("status")
+	require.NoError(t, err)
+
+	var schema struct {
+		Required []string                  `json:"required"`
+		Props    map[string]map[string]any `json:"properties"`
+		Defs     map[string]map[string]any `json:"$defs"`
+	}
+	require.NoError(t, json.Unmarshal(data, &schema))
+	assert.Contains(t, schema.Required, "config_path")
+	assert.NotContains(t, schema.Required, "duplicates", "omitempty fields are optional")
+	assert.Equal(t, "array", schema.Props["duplicates"]["type"])
+	assert.Contains(t, schema.Defs, "Summary")
+
+	results := schema.Defs["Summary"]["properties"].(map[string]any)["results"].(map[string]any)
+	assert.Equal(t, []any{"array", "null"}, results["type"], "nil slices encode as null")
+}
+
+func TestSchema_Unknown(t *testing.T) {
+	_, err := Schema("upgrade")
+	assert.ErrorContains(t, err, "no schema")
+}
+
+// Every structured output with a schema must validate its own encoding at
+// least structurally: each required property is present
+func TestSchemas_RequiredFieldsPresent(t *testing.T) {
+	for _, name := range SchemaNames() {
+		data, err := Schema(name)
+		require.NoError(t, err)
+		var schema struct {
+			Required []string `json:"required"`
+		}
+		require.NoError(t, json.Unmarshal(data, &schema))
+
+		encoded, err := json.Marshal(schemaTypes[name])
+		require.NoError(t, err)
+		var doc map[string]any
+		require.NoError(t, json.Unmarshal(encoded, &doc))
+		for _, field := range schema.Required {
+			assert.Contains(t, doc, field, "%s: required %s missing from zero value", name, field)
+		}
+	}
+}
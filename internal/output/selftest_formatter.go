@@ -0,0 +1,77 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package output
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SelftestResult is how applying the plonk directory went in one container
+type SelftestResult struct {
+	Image             string        `json:"image" yaml:"image"`
+	Success           bool          `json:"success" yaml:"success"`
+	Duration          time.Duration `json:"duration" yaml:"duration"`
+	PackagesInstalled int           `json:"packages_installed" yaml:"packages_installed"`
+	FailedPackages    []string      `json:"failed_packages,omitempty" yaml:"failed_packages,omitempty"` // "manager:name"
+	DotfilesDeployed  int           `json:"dotfiles_deployed" yaml:"dotfiles_deployed"`
+	DotfilesFailed    int           `json:"dotfiles_failed" yaml:"dotfiles_failed"`
+	Error             string        `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// SelftestOutput is the output of `plonk selftest`
+type SelftestOutput struct {
+	Directory string           `json:"directory" yaml:"directory"`
+	Results   []SelftestResult `json:"results" yaml:"results"`
+}
+
+// Failed reports whether any container failed
+func (s SelftestOutput) Failed() bool {
+	for _, r := range s.Results {
+		if !r.Success {
+			return true
+		}
+	}
+	return false
+}
+
+// TableOutput shows one row per image, then what failed where
+func (s SelftestOutput) TableOutput() string {
+	var out strings.Builder
+	WriteTitle(&out, "Self Test: "+s.Directory)
+
+	table := NewStandardTableBuilder("")
+	table.SetHeaders("IMAGE", "RESULT", "PACKAGES", "DOTFILES", "TIME")
+	for _, r := range s.Results {
+		result := "ok"
+		if !r.Success {
+			result = "failed"
+		}
+		table.AddRow(r.Image, result,
+			fmt.Sprintf("%d installed, %d failed", r.PackagesInstalled, len(r.FailedPackages)),
+			fmt.Sprintf("%d deployed, %d failed", r.DotfilesDeployed, r.DotfilesFailed),
+			r.Duration.String())
+	}
+	out.WriteString(table.Build())
+
+	for _, r := range s.Results {
+		if r.Success {
+			continue
+		}
+		fmt.Fprintf(&out, "\n%s:\n", r.Image)
+		if r.Error != "" {
+			fmt.Fprintf(&out, "  %s\n", r.Error)
+		}
+		for _, p := range r.FailedPackages {
+			fmt.Fprintf(&out, "  %s %s\n", IconError, p)
+		}
+	}
+	return out.String()
+}
+
+// StructuredData returns the results for serialization
+func (s SelftestOutput) StructuredData() any {
+	return s
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package output
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SimulatedManager is one manager's packages in `plonk simulate`
+type SimulatedManager struct {
+	Manager  string   `json:"manager" yaml:"manager"`
+	Packages []string `json:"packages" yaml:"packages"`
+	Skipped  bool     `json:"skipped,omitempty" yaml:"skipped,omitempty"` // nothing would be installed
+	Note     string   `json:"note,omitempty" yaml:"note,omitempty"`
+}
+
+// SimulatedDotfile is one dotfile `plonk simulate` would deploy
+type SimulatedDotfile struct {
+	Source string `json:"source" yaml:"source"`
+	Target string `json:"target" yaml:"target"` // relative to ~
+	Error  string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// SimulateOutput is the output of `plonk simulate`: what clone and apply
+// would do on a machine with nothing installed and an empty home directory
+type SimulateOutput struct {
+	Source    string             `json:"source" yaml:"source"`
+	Signature string             `json:"signature,omitempty" yaml:"signature,omitempty"` // "verified", or why not; "" when verification is off
+	Managers  []SimulatedManager `json:"managers" yaml:"managers"`
+	Dotfiles  []SimulatedDotfile `json:"dotfiles" yaml:"dotfiles"`
+	Resources []string           `json:"resources,omitempty" yaml:"resources,omitempty"` // plugins that would run
+}
+
+// Failed reports whether any part of the plan would fail
+func (s SimulateOutput) Failed() bool {
+	if s.Signature != "" && s.Signature != "verified" {
+		return true
+	}
+	for _, d := range s.Dotfiles {
+		if d.Error != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// TableOutput lists packages by manager, then dotfiles
+func (s SimulateOutput) TableOutput() string {
+	var out strings.Builder
+	WriteTitle(&out, "Simulated Apply: "+s.Source)
+
+	if s.Signature != "" {
+		fmt.Fprintf(&out, "Lock signature: %s\n\n", s.Signature)
+	}
+
+	count := 0
+	for _, m := range s.Managers {
+		if !m.Skipped {
+			count += len(m.Packages)
+		}
+	}
+	fmt.Fprintf(&out, "Packages to install (%d):\n", count)
+	for _, m := range s.Managers {
+		fmt.Fprintf(&out, "  %s (%d)", m.Manager, len(m.Packages))
+		if m.Note != "" {
+			fmt.Fprintf(&out, " - %s", m.Note)
+		}
+		out.WriteString("\n")
+		for _, p := range m.Packages {
+			fmt.Fprintf(&out, "    %s\n", p)
+		}
+	}
+	out.WriteString("\n")
+
+	fmt.Fprintf(&out, "Dotfiles to deploy (%d):\n", len(s.Dotfiles))
+	if len(s.Dotfiles) > 0 {
+		table := NewStandardTableBuilder("")
+		table.SetHeaders("SOURCE", "TARGET", "PROBLEM")
+		for _, d := range s.Dotfiles {
+			table.AddRow(d.Source, d.Target, d.Error)
+		}
+		out.WriteString(table.Build())
+	}
+
+	if len(s.Resources) > 0 {
+		out.WriteString("\n")
+		fmt.Fprintf(&out, "Resources to apply (%d):\n", len(s.Resources))
+		for _, r := range s.Resources {
+			fmt.Fprintf(&out, "  %s\n", r)
+		}
+	}
+	return out.String()
+}
+
+// StructuredData returns the simulation for serialization
+func (s SimulateOutput) StructuredData() any {
+	return s
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package output
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SnapshotEntry is one archive in `plonk snapshot list`
+type SnapshotEntry struct {
+	Name      string    `json:"name" yaml:"name"`
+	CreatedAt time.Time `json:"created_at" yaml:"created_at"`
+	Size      int64     `json:"size" yaml:"size"` // compressed bytes
+}
+
+// SnapshotListOutput is the output of `plonk snapshot list`
+type SnapshotListOutput struct {
+	Directory string          `json:"directory" yaml:"directory"`
+	Snapshots []SnapshotEntry `json:"snapshots" yaml:"snapshots"`
+}
+
+// TableOutput lists snapshots newest first
+func (s SnapshotListOutput) TableOutput() string {
+	var out strings.Builder
+	WriteTitle(&out, "Snapshots")
+
+	if len(s.Snapshots) == 0 {
+		out.WriteString("No snapshots yet. Create one with 'plonk snapshot create'.\n")
+		return out.String()
+	}
+
+	table := NewStandardTableBuilder("")
+	table.SetHeaders("NAME", "CREATED", "SIZE")
+	for _, snap := range s.Snapshots {
+		table.AddRow(snap.Name, snap.CreatedAt.Local().Format("2006-01-02 15:04"), formatSize(snap.Size))
+	}
+	out.WriteString(table.Build())
+	out.WriteString("\n")
+	fmt.Fprintf(&out, "Stored in %s\n", s.Directory)
+	return out.String()
+}
+
+// StructuredData returns the snapshot list for serialization
+func (s SnapshotListOutput) StructuredData() any {
+	return s
+}
+
+// formatSize renders a byte count with a binary unit
+func formatSize(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package output
+
+import (
+	"fmt"
+	"strings"
+)
+
+// StateDotfileRow is one dotfile in `plonk export state`
+type StateDotfileRow struct {
+	Source string
+	Target string
+}
+
+// StateExportOutput is the output of `plonk export state`. Document is the
+// exported state document, written as-is for json and yaml; the table
+// lists its packages and dotfiles.
+type StateExportOutput struct {
+	Document any
+	Packages []string // "manager:name"
+	Dotfiles []StateDotfileRow
+}
+
+// TableOutput lists packages and dotfiles and points at the structured formats
+func (s StateExportOutput) TableOutput() string {
+	var out strings.Builder
+	WriteTitle(&out, "Plonk State")
+
+	fmt.Fprintf(&out, "Packages (%d):\n", len(s.Packages))
+	for _, p := range s.Packages {
+		fmt.Fprintf(&out, "  %s\n", p)
+	}
+	out.WriteString("\n")
+
+	fmt.Fprintf(&out, "Dotfiles (%d):\n", len(s.Dotfiles))
+	if len(s.Dotfiles) > 0 {
+		table := NewStandardTableBuilder("")
+		table.SetHeaders("SOURCE", "TARGET")
+		for _, d := range s.Dotfiles {
+			table.AddRow(d.Source, d.Target)
+		}
+		out.WriteString(table.Build())
+	} else {
+		out.WriteString("\n")
+	}
+	out.WriteString("Use -o json or -o yaml to write the state document.\n")
+	return out.String()
+}
+
+// StructuredData returns the state document
+func (s StateExportOutput) StructuredData() any {
+	return s.Document
+}
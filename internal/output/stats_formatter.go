@@ -0,0 +1,116 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package output
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ManagerStats counts one manager's tracked packages and the space its
+// install directories take
+type ManagerStats struct {
+	Name      string   `json:"name" yaml:"name"`
+	Packages  int      `json:"packages" yaml:"packages"`
+	Dirs      []string `json:"dirs,omitempty" yaml:"dirs,omitempty"`
+	DiskBytes int64    `json:"disk_bytes" yaml:"disk_bytes"`
+}
+
+// ApplyPeriod totals the applies recorded in one period
+type ApplyPeriod struct {
+	Start      time.Time `json:"start" yaml:"start"`
+	Applies    int       `json:"applies" yaml:"applies"`
+	Installed  int       `json:"installed" yaml:"installed"`
+	Added      int       `json:"added" yaml:"added"`
+	Redeployed int       `json:"redeployed" yaml:"redeployed"` // drifted or changed dotfiles written again
+	Failed     int       `json:"failed" yaml:"failed"`
+}
+
+// StatsOutput is the output of `plonk stats`
+type StatsOutput struct {
+	Managers         []ManagerStats `json:"managers" yaml:"managers"`
+	TotalPackages    int            `json:"total_packages" yaml:"total_packages"`
+	Dotfiles         int            `json:"dotfiles" yaml:"dotfiles"`
+	DriftedDotfiles  int            `json:"drifted_dotfiles" yaml:"drifted_dotfiles"`
+	MissingDotfiles  int            `json:"missing_dotfiles" yaml:"missing_dotfiles"`
+	Applies          int            `json:"applies" yaml:"applies"` // recorded in the history
+	LastApply        *time.Time     `json:"last_apply,omitempty" yaml:"last_apply,omitempty"`
+	LastApplySuccess bool           `json:"last_apply_success" yaml:"last_apply_success"`
+	Weeks            []ApplyPeriod  `json:"weeks" yaml:"weeks"` // most recent first
+}
+
+// TableOutput summarizes the environment
+func (s StatsOutput) TableOutput() string {
+	var out strings.Builder
+	WriteTitle(&out, "Plonk Stats")
+
+	if len(s.Managers) > 0 {
+		table := NewStandardTableBuilder("")
+		table.SetHeaders("MANAGER", "PACKAGES", "DISK")
+		var totalBytes int64
+		for _, m := range s.Managers {
+			disk := "-"
+			if len(m.Dirs) > 0 {
+				disk = formatBytes(m.DiskBytes)
+				totalBytes += m.DiskBytes
+			}
+			table.AddRow(m.Name, fmt.Sprint(m.Packages), disk)
+		}
+		table.AddRow("total", fmt.Sprint(s.TotalPackages), formatBytes(totalBytes))
+		out.WriteString(table.Build())
+		out.WriteString("\n")
+	} else {
+		out.WriteString("No tracked packages.\n\n")
+	}
+
+	fmt.Fprintf(&out, "Dotfiles: %d managed", s.Dotfiles)
+	if s.DriftedDotfiles > 0 {
+		fmt.Fprintf(&out, ", %d drifted", s.DriftedDotfiles)
+	}
+	if s.MissingDotfiles > 0 {
+		fmt.Fprintf(&out, ", %d missing", s.MissingDotfiles)
+	}
+	out.WriteString("\n")
+
+	if s.LastApply == nil {
+		out.WriteString("Applies: none recorded yet\n")
+		return out.String()
+	}
+	result := "succeeded"
+	if !s.LastApplySuccess {
+		result = "had failures"
+	}
+	fmt.Fprintf(&out, "Last apply: %s (%s, %s)\n", s.LastApply.Local().Format("2006-01-02 15:04"), formatAge(time.Since(*s.LastApply)), result)
+	fmt.Fprintf(&out, "Applies recorded: %d\n\n", s.Applies)
+
+	table := NewStandardTableBuilder("")
+	table.SetHeaders("WEEK OF", "APPLIES", "INSTALLED", "DEPLOYED", "REDEPLOYED", "FAILED")
+	for _, w := range s.Weeks {
+		table.AddRow(w.Start.Local().Format("2006-01-02"), fmt.Sprint(w.Applies), fmt.Sprint(w.Installed),
+			fmt.Sprint(w.Added), fmt.Sprint(w.Redeployed), fmt.Sprint(w.Failed))
+	}
+	out.WriteString(table.Build())
+	out.WriteString("\nREDEPLOYED counts dotfiles that had drifted or whose source changed.\n")
+	return out.String()
+}
+
+// StructuredData returns the stats for JSON/YAML output
+func (s StatsOutput) StructuredData() any {
+	return s
+}
+
+// formatBytes renders a size with a binary unit, e.g. "1.4 GiB"
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
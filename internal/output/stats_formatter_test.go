@@ -0,0 +1,60 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package output
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStatsOutput_Table(t *testing.T) {
+	last := time.Now().Add(-50 * time.Hour)
+	stats := StatsOutput{
+		Managers: []ManagerStats{
+			{Name: "brew", Packages: 40, Dirs: []string{"/opt/homebrew/Cellar"}, DiskBytes: 3 << 30},
+			{Name: "gem", Packages: 2},
+		},
+		TotalPackages:    42,
+		Dotfiles:         12,
+		DriftedDotfiles:  1,
+		Applies:          7,
+		LastApply:        &last,
+		LastApplySuccess: true,
+		Weeks:            []ApplyPeriod{{Start: last.Add(-24 * time.Hour), Applies: 7, Installed: 3, Redeployed: 2}},
+	}
+
+	out := stats.TableOutput()
+	for _, want := range []string{"3.0 GiB", "total", "42", "Dotfiles: 12 managed, 1 drifted\n", "(2d ago, succeeded)", "Applies recorded: 7"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in:\n%s", want, out)
+		}
+	}
+	// A plugin manager has no known directories to measure
+	for _, line := range strings.Split(out, "\n") {
+		if strings.HasPrefix(line, "gem") && !strings.HasSuffix(strings.TrimSpace(line), "-") {
+			t.Errorf("expected no disk usage for gem: %q", line)
+		}
+	}
+
+	out = StatsOutput{}.TableOutput()
+	if !strings.Contains(out, "No tracked packages.") || !strings.Contains(out, "Applies: none recorded yet") {
+		t.Errorf("unexpected empty stats:\n%s", out)
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := map[int64]string{
+		512:          "512 B",
+		1536:         "1.5 KiB",
+		5 << 20:      "5.0 MiB",
+		1288490188:   "1.2 GiB",
+		3 << 40 >> 1: "1.5 TiB",
+	}
+	for n, want := range tests {
+		if got := formatBytes(n); got != want {
+			t.Errorf("formatBytes(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
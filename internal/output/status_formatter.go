@@ -8,6 +8,9 @@ import (
 	"reflect"
 	"sort"
 	"strings"
+	"time"
+
+	"github.com/richhaase/plonk/internal/i18n"
 )
 
 // Local types to avoid import cycles
@@ -26,66 +29,78 @@ const (
 
 // Item represents a resource item
 type Item struct {
-	Name     string                 `json:"name"`
-	Manager  string                 `json:"manager,omitempty"`
-	Path     string                 `json:"path,omitempty"`
-	State    ItemState              `json:"state"`
-	Error    string                 `json:"error,omitempty"`
-	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	Name       string                 `json:"name" yaml:"name"`
+	Manager    string                 `json:"manager,omitempty" yaml:"manager,omitempty"`
+	Path       string                 `json:"path,omitempty" yaml:"path,omitempty"`
+	State      ItemState              `json:"state" yaml:"state"`
+	Error      string                 `json:"error,omitempty" yaml:"error,omitempty"`
+	ErrorClass string                 `json:"error_class,omitempty" yaml:"error_class,omitempty"`
+	Metadata   map[string]interface{} `json:"metadata,omitempty" yaml:"metadata,omitempty"`
 }
 
 // Result represents domain result
 type Result struct {
-	Domain    string `json:"domain"`
-	Managed   []Item `json:"managed"`
-	Missing   []Item `json:"missing"`
-	Untracked []Item `json:"untracked"`
-	Errors    []Item `json:"errors,omitempty"`
+	Domain    string `json:"domain" yaml:"domain"`
+	Managed   []Item `json:"managed" yaml:"managed"`
+	Missing   []Item `json:"missing" yaml:"missing"`
+	Untracked []Item `json:"untracked" yaml:"untracked"`
+	Errors    []Item `json:"errors,omitempty" yaml:"errors,omitempty"`
 }
 
 // Summary represents resource summary
 type Summary struct {
-	TotalManaged   int      `json:"total_managed"`
-	TotalMissing   int      `json:"total_missing"`
-	TotalUntracked int      `json:"total_untracked"`
-	TotalErrors    int      `json:"total_errors,omitempty"`
-	Results        []Result `json:"results"`
+	TotalManaged   int      `json:"total_managed" yaml:"total_managed"`
+	TotalMissing   int      `json:"total_missing" yaml:"total_missing"`
+	TotalUntracked int      `json:"total_untracked" yaml:"total_untracked"`
+	TotalErrors    int      `json:"total_errors,omitempty" yaml:"total_errors,omitempty"`
+	Results        []Result `json:"results" yaml:"results"`
 }
 
 // StatusOutput represents the output structure for status command
 type StatusOutput struct {
-	ConfigPath   string  `json:"config_path" yaml:"config_path"`
-	LockPath     string  `json:"lock_path" yaml:"lock_path"`
-	ConfigExists bool    `json:"config_exists" yaml:"config_exists"`
-	ConfigValid  bool    `json:"config_valid" yaml:"config_valid"`
-	LockExists   bool    `json:"lock_exists" yaml:"lock_exists"`
-	RemoteSync   string  `json:"remote_sync,omitempty" yaml:"remote_sync,omitempty"`
-	StateSummary Summary `json:"state_summary" yaml:"state_summary"`
-	ConfigDir    string  `json:"-" yaml:"-"` // Not included in JSON/YAML output
-	HomeDir      string  `json:"-" yaml:"-"` // Not included in JSON/YAML output
+	ConfigPath   string             `json:"config_path" yaml:"config_path"`
+	LockPath     string             `json:"lock_path" yaml:"lock_path"`
+	ConfigExists bool               `json:"config_exists" yaml:"config_exists"`
+	ConfigValid  bool               `json:"config_valid" yaml:"config_valid"`
+	LockExists   bool               `json:"lock_exists" yaml:"lock_exists"`
+	RemoteSync   string             `json:"remote_sync,omitempty" yaml:"remote_sync,omitempty"`
+	StateSummary Summary            `json:"state_summary" yaml:"state_summary"`
+	Duplicates   []DuplicatePackage `json:"duplicates,omitempty" yaml:"duplicates,omitempty"`
+	ConfigDir    string             `json:"-" yaml:"-"` // Not included in JSON/YAML output
+	HomeDir      string             `json:"-" yaml:"-"` // Not included in JSON/YAML output
 }
 
 // StatusOutputSummary represents a summary-focused version for JSON/YAML output
 type StatusOutputSummary struct {
-	ConfigPath   string  `json:"config_path" yaml:"config_path"`
-	LockPath     string  `json:"lock_path" yaml:"lock_path"`
-	ConfigExists bool    `json:"config_exists" yaml:"config_exists"`
-	ConfigValid  bool    `json:"config_valid" yaml:"config_valid"`
-	LockExists   bool    `json:"lock_exists" yaml:"lock_exists"`
-	RemoteSync   string  `json:"remote_sync,omitempty" yaml:"remote_sync,omitempty"`
-	StateSummary Summary `json:"state_summary" yaml:"state_summary"`
+	ConfigPath   string             `json:"config_path" yaml:"config_path"`
+	LockPath     string             `json:"lock_path" yaml:"lock_path"`
+	ConfigExists bool               `json:"config_exists" yaml:"config_exists"`
+	ConfigValid  bool               `json:"config_valid" yaml:"config_valid"`
+	LockExists   bool               `json:"lock_exists" yaml:"lock_exists"`
+	RemoteSync   string             `json:"remote_sync,omitempty" yaml:"remote_sync,omitempty"`
+	StateSummary Summary            `json:"state_summary" yaml:"state_summary"`
+	Duplicates   []DuplicatePackage `json:"duplicates,omitempty" yaml:"duplicates,omitempty"`
+}
+
+// DuplicatePackage is a tracked package that another manager also installed
+type DuplicatePackage struct {
+	Tracked string   `json:"tracked" yaml:"tracked"`
+	Also    []string `json:"also" yaml:"also"`
+	OnPath  string   `json:"on_path,omitempty" yaml:"on_path,omitempty"`
+	Winner  string   `json:"winner,omitempty" yaml:"winner,omitempty"` // copy OnPath belongs to
 }
 
 // ManagedItem represents an item under management with its details
 type ManagedItem struct {
-	Name     string                 `json:"name" yaml:"name"`
-	Domain   string                 `json:"domain" yaml:"domain"`
-	State    string                 `json:"state" yaml:"state"`
-	Manager  string                 `json:"manager,omitempty" yaml:"manager,omitempty"`
-	Path     string                 `json:"path,omitempty" yaml:"path,omitempty"`
-	Target   string                 `json:"target,omitempty" yaml:"target,omitempty"`
-	Error    string                 `json:"error,omitempty" yaml:"error,omitempty"`
-	Metadata map[string]interface{} `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+	Name       string                 `json:"name" yaml:"name"`
+	Domain     string                 `json:"domain" yaml:"domain"`
+	State      string                 `json:"state" yaml:"state"`
+	Manager    string                 `json:"manager,omitempty" yaml:"manager,omitempty"`
+	Path       string                 `json:"path,omitempty" yaml:"path,omitempty"`
+	Target     string                 `json:"target,omitempty" yaml:"target,omitempty"`
+	Error      string                 `json:"error,omitempty" yaml:"error,omitempty"`
+	ErrorClass string                 `json:"error_class,omitempty" yaml:"error_class,omitempty"`
+	Metadata   map[string]interface{} `json:"metadata,omitempty" yaml:"metadata,omitempty"`
 }
 
 // StatusFormatter formats status output
@@ -140,16 +155,20 @@ func (f StatusFormatter) TableOutput() string {
 	if dotfileResult := findResultByDomain(s.StateSummary.Results, "dotfile"); dotfileResult != nil {
 		writeDotfilesTable(&output, *dotfileResult, s.HomeDir)
 	}
+	if resourceResult := findResultByDomain(s.StateSummary.Results, "resource"); resourceResult != nil {
+		writeResourcesTable(&output, *resourceResult)
+	}
 
-	driftedCount := countDriftedDotfiles(s.StateSummary.Results)
+	driftedCount := countDrifted(s.StateSummary.Results)
 	writeSummaryLine(&output, s.StateSummary, driftedCount)
 	writeDomainErrors(&output, s.StateSummary.Results)
+	writeDuplicates(&output, s.Duplicates, s.HomeDir)
 
 	if s.StateSummary.TotalManaged == 0 && s.StateSummary.TotalMissing == 0 && s.StateSummary.TotalErrors == 0 {
 		output.Reset()
 		WriteTitle(&output, "Plonk Status")
 		WriteRemoteSync(&output, s.RemoteSync)
-		output.WriteString("No managed items.\n")
+		output.WriteString(i18n.T("No managed items.") + "\n")
 	}
 
 	return output.String()
@@ -184,16 +203,18 @@ func writePackagesTable(output *strings.Builder, result Result) {
 		packages := append([]Item(nil), packagesByManager[manager]...)
 		sortItems(packages)
 		for _, pkg := range packages {
-			pkgBuilder.AddRow(pkg.Name, manager, "managed")
+			pkgBuilder.AddRow(pkg.Name, manager, packageManagedStatus(pkg))
 		}
 	}
 
 	for _, pkg := range missingPackages {
-		pkgBuilder.AddRow(pkg.Name, pkg.Manager, "missing")
+		pkgBuilder.AddRow(pkg.Name, pkg.Manager, packageMissingStatus(pkg))
 	}
 
 	output.WriteString(pkgBuilder.Build())
 	output.WriteString("\n")
+	writeDependencyHint(output, result.Managed)
+	writeRenameHint(output, missingPackages)
 }
 
 func writeDotfilesTable(output *strings.Builder, result Result, homeDir string) {
@@ -214,7 +235,7 @@ func writeDotfilesTable(output *strings.Builder, result Result, homeDir string)
 		dotBuilder.AddRow(dotfileTarget(item, homeDir), dotfileStatus(item))
 	}
 	for _, item := range missing {
-		dotBuilder.AddRow(dotfileTarget(item, homeDir), "missing")
+		dotBuilder.AddRow(dotfileTarget(item, homeDir), i18n.T("missing"))
 	}
 
 	output.WriteString(dotBuilder.Build())
@@ -229,17 +250,124 @@ func dotfileTarget(item Item, homeDir string) string {
 	return target
 }
 
+// packageManagedStatus labels a managed package, naming the equivalent
+// package that satisfies it when it comes from an alias
+func packageManagedStatus(item Item) string {
+	status := i18n.T("managed")
+	if via, ok := item.Metadata["satisfied_by"].(string); ok {
+		status += " " + i18n.T("(via %s)", via)
+	}
+	if item.Metadata["installed_as"] == "dependency" {
+		status += " " + i18n.T("(as dependency)")
+	}
+	if checked, ok := item.Metadata["checked_at"].(time.Time); ok {
+		status += " " + i18n.T("(checked %s)", formatAge(time.Since(checked)))
+	}
+	return status
+}
+
+// formatAge describes how long ago something happened, e.g. "3d ago"
+func formatAge(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return i18n.T("just now")
+	case d < time.Hour:
+		return i18n.T("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return i18n.T("%dh ago", int(d.Hours()))
+	default:
+		return i18n.T("%dd ago", int(d.Hours()/24))
+	}
+}
+
+// packageMissingStatus labels a missing package, naming its replacement
+// when the manager has renamed or retired it
+func packageMissingStatus(item Item) string {
+	to, ok := item.Metadata["renamed_to"].(string)
+	if !ok {
+		return i18n.T("missing")
+	}
+	if reason, _ := item.Metadata["rename_reason"].(string); reason != "renamed" {
+		return i18n.T("missing (%s, use %s)", reason, to)
+	}
+	return i18n.T("missing (renamed to %s)", to)
+}
+
+// writeDependencyHint explains packages brew installed only as
+// dependencies, which 'brew autoremove' uninstalls once nothing needs them,
+// and how to keep them
+func writeDependencyHint(output *strings.Builder, managed []Item) {
+	var names []string
+	for _, item := range managed {
+		if item.Manager == "brew" && item.Metadata["installed_as"] == "dependency" {
+			names = append(names, item.Name)
+		}
+	}
+	if len(names) == 0 {
+		return
+	}
+	sort.Strings(names)
+	fmt.Fprintf(output, "%s\n  brew tab --installed-on-request %s\n\n",
+		i18n.T("Tracked packages installed as dependencies are removed by 'brew autoremove'; keep them with:"),
+		strings.Join(names, " "))
+}
+
+// writeRenameHint points at 'plonk fix --renames' when any missing package
+// has a known new name
+func writeRenameHint(output *strings.Builder, missing []Item) {
+	for _, item := range missing {
+		if _, ok := item.Metadata["renamed_to"]; ok {
+			output.WriteString(i18n.T("Run 'plonk fix --renames' to update renamed packages in plonk.lock.") + "\n\n")
+			return
+		}
+	}
+}
+
 func dotfileStatus(item Item) string {
 	if item.State == StateDegraded {
-		return "drifted"
+		if reason, ok := item.Metadata["drift_reason"].(string); ok {
+			return i18n.T("drifted") + " (" + reason + ")"
+		}
+		return i18n.T("drifted")
 	}
-	return "deployed"
+	return i18n.T("deployed")
 }
 
-func countDriftedDotfiles(results []Result) int {
+func writeResourcesTable(output *strings.Builder, result Result) {
+	if len(result.Managed)+len(result.Missing) == 0 {
+		return
+	}
+
+	builder := NewStandardTableBuilder("")
+	builder.SetHeaders("RESOURCE", "TYPE", "STATUS")
+
+	items := append(append([]Item(nil), result.Managed...), result.Missing...)
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Manager != items[j].Manager {
+			return items[i].Manager < items[j].Manager
+		}
+		return items[i].Name < items[j].Name
+	})
+	for _, item := range items {
+		status := i18n.T("managed")
+		switch item.State {
+		case StateDegraded:
+			status = i18n.T("drifted")
+		case StateMissing:
+			status = i18n.T("missing")
+		}
+		builder.AddRow(item.Name, item.Manager, status)
+	}
+
+	output.WriteString(builder.Build())
+	output.WriteString("\n")
+}
+
+// countDrifted counts drifted items in the domains that can drift
+func countDrifted(results []Result) int {
 	drifted := 0
 	for _, result := range results {
-		if result.Domain != "dotfile" {
+		if result.Domain != "dotfile" && result.Domain != "resource" {
 			continue
 		}
 		for _, item := range result.Managed {
@@ -253,16 +381,16 @@ func countDriftedDotfiles(results []Result) int {
 
 func writeSummaryLine(output *strings.Builder, summary Summary, driftedCount int) {
 	managedCount := summary.TotalManaged - driftedCount
-	output.WriteString("Summary: ")
-	fmt.Fprintf(output, "%d managed", managedCount)
+	output.WriteString(i18n.T("Summary:") + " ")
+	output.WriteString(i18n.T("%d managed", managedCount))
 	if summary.TotalMissing > 0 {
-		fmt.Fprintf(output, ", %d missing", summary.TotalMissing)
+		output.WriteString(", " + i18n.T("%d missing", summary.TotalMissing))
 	}
 	if driftedCount > 0 {
-		fmt.Fprintf(output, ", %d drifted", driftedCount)
+		output.WriteString(", " + i18n.T("%d drifted", driftedCount))
 	}
 	if summary.TotalErrors > 0 {
-		fmt.Fprintf(output, ", %d errors", summary.TotalErrors)
+		output.WriteString(", " + i18n.T("%d errors", summary.TotalErrors))
 	}
 	output.WriteString("\n")
 }
@@ -272,7 +400,7 @@ func writeDomainErrors(output *strings.Builder, results []Result) {
 		if len(result.Errors) == 0 {
 			continue
 		}
-		fmt.Fprintf(output, "\n%s errors:\n", result.Domain)
+		fmt.Fprintf(output, "\n%s\n", i18n.T("%s errors:", result.Domain))
 		for _, item := range result.Errors {
 			if item.Error != "" {
 				fmt.Fprintf(output, "  ✗ %s: %s\n", item.Name, item.Error)
@@ -283,6 +411,26 @@ func writeDomainErrors(output *strings.Builder, results []Result) {
 	}
 }
 
+// writeDuplicates warns about packages installed by more than one manager
+func writeDuplicates(output *strings.Builder, dups []DuplicatePackage, homeDir string) {
+	if len(dups) == 0 {
+		return
+	}
+	output.WriteString("\n" + i18n.T("Installed by more than one manager:") + "\n")
+	for _, d := range dups {
+		fmt.Fprintf(output, "  %s %s, also %s", IconWarning, d.Tracked, strings.Join(d.Also, ", "))
+		switch {
+		case d.Winner != "":
+			fmt.Fprintf(output, " %s\n", i18n.T("(%s wins on PATH: %s)", d.Winner, tildeShorthand(d.OnPath, homeDir)))
+		case d.OnPath != "":
+			fmt.Fprintf(output, " %s\n", i18n.T("(PATH has %s)", tildeShorthand(d.OnPath, homeDir)))
+		default:
+			output.WriteString("\n")
+		}
+	}
+	output.WriteString(i18n.T("Run 'plonk dedupe' to keep one copy.") + "\n")
+}
+
 // StructuredData returns the structured data for serialization
 func (f StatusFormatter) StructuredData() any {
 	s := f.Data
@@ -294,6 +442,7 @@ func (f StatusFormatter) StructuredData() any {
 		LockExists:   s.LockExists,
 		RemoteSync:   s.RemoteSync,
 		StateSummary: sanitizeSummary(s.StateSummary),
+		Duplicates:   s.Duplicates,
 	}
 }
 
@@ -322,36 +471,58 @@ func sanitizeSummary(sum Summary) Summary {
 		Results:        make([]Result, len(sum.Results)),
 	}
 	for i, r := range sum.Results {
-		cr := Result{Domain: r.Domain}
-		if len(r.Managed) > 0 {
-			cr.Managed = make([]Item, len(r.Managed))
-			for j, it := range r.Managed {
-				it.Metadata = sanitizeMetadata(it.Metadata)
-				cr.Managed[j] = it
-			}
-		}
-		if len(r.Missing) > 0 {
-			cr.Missing = make([]Item, len(r.Missing))
-			for j, it := range r.Missing {
-				it.Metadata = sanitizeMetadata(it.Metadata)
-				cr.Missing[j] = it
-			}
-		}
-		if len(r.Untracked) > 0 {
-			cr.Untracked = make([]Item, len(r.Untracked))
-			for j, it := range r.Untracked {
-				it.Metadata = sanitizeMetadata(it.Metadata)
-				cr.Untracked[j] = it
+		cleaned.Results[i] = sanitizeResult(r)
+	}
+	return cleaned
+}
+
+// sanitizeResult removes function-typed metadata values from a result's
+// items. Empty lists stay empty rather than nil, so JSON has [] where YAML
+// does; errors is omitted when empty in both.
+func sanitizeResult(r Result) Result {
+	cleaned := Result{
+		Domain:    r.Domain,
+		Managed:   sanitizeItems(r.Managed),
+		Missing:   sanitizeItems(r.Missing),
+		Untracked: sanitizeItems(r.Untracked),
+	}
+	if len(r.Errors) > 0 {
+		cleaned.Errors = sanitizeItems(r.Errors)
+	}
+	return cleaned
+}
+
+func sanitizeItems(items []Item) []Item {
+	cleaned := make([]Item, len(items))
+	for i, it := range items {
+		it.Metadata = sanitizeMetadata(it.Metadata)
+		cleaned[i] = it
+	}
+	return cleaned
+}
+
+// NewManagedItems flattens a domain result into the item list of the
+// structured packages and dotfiles output: managed (including drifted),
+// then missing, then error items, with the same fields the tables show
+func NewManagedItems(domain string, result Result) []ManagedItem {
+	items := []ManagedItem{}
+	for _, group := range [][]Item{result.Managed, result.Missing, result.Errors} {
+		for _, item := range group {
+			mi := ManagedItem{
+				Name:       item.Name,
+				Domain:     domain,
+				State:      string(item.State),
+				Manager:    item.Manager,
+				Path:       item.Path,
+				Error:      item.Error,
+				ErrorClass: item.ErrorClass,
+				Metadata:   sanitizeMetadata(item.Metadata),
 			}
-		}
-		if len(r.Errors) > 0 {
-			cr.Errors = make([]Item, len(r.Errors))
-			for j, it := range r.Errors {
-				it.Metadata = sanitizeMetadata(it.Metadata)
-				cr.Errors[j] = it
+			if target, ok := item.Metadata["destination"].(string); ok {
+				mi.Target = target
 			}
+			items = append(items, mi)
 		}
-		cleaned.Results[i] = cr
 	}
-	return cleaned
+	return items
 }
@@ -37,3 +37,61 @@ func TestStatusFormatter_Table_Variants(t *testing.T) {
 		t.Fatalf("expected missing entries in output: %s", out)
 	}
 }
+
+func TestStatusFormatter_Table_RenamedPackages(t *testing.T) {
+	miss := []Item{
+		{Name: "exa", Manager: "brew", State: StateMissing, Metadata: map[string]any{"renamed_to": "eza", "rename_reason": "disabled"}},
+		{Name: "http", Manager: "brew", State: StateMissing, Metadata: map[string]any{"renamed_to": "httpie", "rename_reason": "renamed"}},
+	}
+	out := NewStatusFormatter(StatusOutput{StateSummary: makeSummary(nil, miss, nil, nil, nil, nil)}).TableOutput()
+	for _, want := range []string{"missing (disabled, use eza)", "missing (renamed to httpie)", "plonk fix --renames"} {
+		if !contains(out, want) {
+			t.Fatalf("expected %q in output: %s", want, out)
+		}
+	}
+
+	out = NewStatusFormatter(StatusOutput{StateSummary: makeSummary(nil, miss[:0], nil, nil, nil, nil)}).TableOutput()
+	if contains(out, "plonk fix") {
+		t.Fatalf("unexpected rename hint: %s", out)
+	}
+}
+
+func TestStatusFormatter_Table_DependencyPackages(t *testing.T) {
+	pkgs := []Item{
+		{Name: "openssl@3", Manager: "brew", State: StateManaged, Metadata: map[string]any{"installed_as": "dependency"}},
+		{Name: "ripgrep", Manager: "brew", State: StateManaged},
+	}
+	out := NewStatusFormatter(StatusOutput{StateSummary: makeSummary(pkgs, nil, nil, nil, nil, nil)}).TableOutput()
+	for _, want := range []string{"managed (as dependency)", "brew tab --installed-on-request openssl@3\n"} {
+		if !contains(out, want) {
+			t.Fatalf("expected %q in output: %s", want, out)
+		}
+	}
+
+	out = NewStatusFormatter(StatusOutput{StateSummary: makeSummary(pkgs[1:], nil, nil, nil, nil, nil)}).TableOutput()
+	if contains(out, "brew tab") {
+		t.Fatalf("unexpected dependency hint: %s", out)
+	}
+}
+
+func TestStatusFormatter_Table_Duplicates(t *testing.T) {
+	pkgs := []Item{{Name: "ripgrep", Manager: "brew", State: StateManaged}}
+	data := StatusOutput{
+		StateSummary: makeSummary(pkgs, nil, nil, nil, nil, nil),
+		HomeDir:      "/home/u",
+		Duplicates: []DuplicatePackage{
+			{Tracked: "brew:ripgrep", Also: []string{"cargo:ripgrep"}, OnPath: "/home/u/.cargo/bin/rg", Winner: "cargo:ripgrep"},
+		},
+	}
+	out := NewStatusFormatter(data).TableOutput()
+	for _, want := range []string{"brew:ripgrep, also cargo:ripgrep", "cargo:ripgrep wins on PATH: ~/.cargo/bin/rg", "plonk dedupe"} {
+		if !contains(out, want) {
+			t.Fatalf("expected %q in output: %s", want, out)
+		}
+	}
+
+	data.Duplicates = nil
+	if out := NewStatusFormatter(data).TableOutput(); contains(out, "plonk dedupe") {
+		t.Fatalf("unexpected dedupe hint: %s", out)
+	}
+}
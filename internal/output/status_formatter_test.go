@@ -3,6 +3,7 @@ package output
 import (
 	"strings"
 	"testing"
+	"time"
 )
 
 // Test that drifted dotfiles are labeled correctly in status output
@@ -62,3 +63,27 @@ func TestStatusFormatter_SummaryCountsExcludeDrifted(t *testing.T) {
 		t.Fatalf("expected drifted summary to be present; got:\n%s", out)
 	}
 }
+
+// Test that packages reused from an earlier check show its age
+func TestStatusFormatter_CheckedAge(t *testing.T) {
+	data := StatusOutput{
+		StateSummary: Summary{
+			TotalManaged: 2,
+			Results: []Result{{
+				Domain: "package",
+				Managed: []Item{
+					{Name: "jq", Manager: "brew", State: StateManaged, Metadata: map[string]interface{}{"checked_at": time.Now().Add(-75 * time.Hour)}},
+					{Name: "fd", Manager: "brew", State: StateManaged},
+				},
+			}},
+		},
+	}
+
+	out := NewStatusFormatter(data).TableOutput()
+	if !strings.Contains(out, "managed (checked 3d ago)") {
+		t.Fatalf("expected jq to show when it was checked; got:\n%s", out)
+	}
+	if strings.Count(out, "checked") != 1 {
+		t.Fatalf("expected only jq to show a check age; got:\n%s", out)
+	}
+}
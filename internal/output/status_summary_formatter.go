@@ -0,0 +1,141 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/richhaase/plonk/internal/i18n"
+)
+
+// StatusCounts is the compact status reported by `plonk status --counts`
+type StatusCounts struct {
+	Managed     int       `json:"managed" yaml:"managed"` // includes drifted items
+	Missing     int       `json:"missing" yaml:"missing"`
+	Drifted     int       `json:"drifted" yaml:"drifted"`
+	Errors      int       `json:"errors" yaml:"errors"`
+	GeneratedAt time.Time `json:"generated_at" yaml:"generated_at"`
+	Cached      bool      `json:"cached" yaml:"cached"`
+}
+
+// NewStatusCounts derives counts from a full status summary
+func NewStatusCounts(summary Summary) StatusCounts {
+	return StatusCounts{
+		Managed: summary.TotalManaged,
+		Missing: summary.TotalMissing,
+		Drifted: countDrifted(summary.Results),
+		Errors:  summary.TotalErrors,
+	}
+}
+
+// OutOfSync returns the number of items that need attention
+func (c StatusCounts) OutOfSync() int {
+	return c.Missing + c.Drifted + c.Errors
+}
+
+// State names the overall status: error when anything failed, drifted
+// when items are missing or drifted, otherwise synced
+func (c StatusCounts) State() string {
+	switch {
+	case c.Errors > 0:
+		return "error"
+	case c.OutOfSync() > 0:
+		return "drifted"
+	default:
+		return "synced"
+	}
+}
+
+// TableOutput generates a one-line summary
+func (c StatusCounts) TableOutput() string {
+	return i18n.T("%d managed, %d missing, %d drifted, %d errors",
+		c.Managed-c.Drifted, c.Missing, c.Drifted, c.Errors) + "\n"
+}
+
+// StructuredData returns the counts for serialization
+func (c StatusCounts) StructuredData() any {
+	return c
+}
+
+// promptOutOfSync marks the out-of-sync count in a shell prompt token
+const promptOutOfSync = "✗"
+
+// FormatPromptToken renders counts as a shell prompt token: ✗3 when three
+// items need attention, synced when none do, and nothing before the first
+// count
+func FormatPromptToken(c StatusCounts, synced string) string {
+	switch {
+	case c.GeneratedAt.IsZero():
+		return ""
+	case c.OutOfSync() > 0:
+		return fmt.Sprintf("%s%d", promptOutOfSync, c.OutOfSync())
+	default:
+		return synced
+	}
+}
+
+// PorcelainVersion is the first line of `plonk status --porcelain`. Keys
+// are only ever added within a version; bump it if one changes meaning or
+// goes away.
+const PorcelainVersion = 1
+
+// FormatStatusPorcelain renders counts as key=value lines for prompt
+// frameworks and scripts that would rather not parse JSON. Managed includes
+// drifted items, as in the JSON output.
+func FormatStatusPorcelain(c StatusCounts) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "version=%d\n", PorcelainVersion)
+	fmt.Fprintf(&b, "state=%s\n", c.State())
+	fmt.Fprintf(&b, "managed=%d\n", c.Managed)
+	fmt.Fprintf(&b, "missing=%d\n", c.Missing)
+	fmt.Fprintf(&b, "drifted=%d\n", c.Drifted)
+	fmt.Fprintf(&b, "errors=%d\n", c.Errors)
+	fmt.Fprintf(&b, "out_of_sync=%d\n", c.OutOfSync())
+	fmt.Fprintf(&b, "generated_at=%s\n", c.GeneratedAt.UTC().Format(time.RFC3339))
+	fmt.Fprintf(&b, "cached=%t\n", c.Cached)
+	return b.String()
+}
+
+// Widget names accepted by FormatStatusWidget
+const (
+	WidgetWaybar  = "waybar"
+	WidgetXbar    = "xbar"
+	WidgetPolybar = "polybar"
+)
+
+// FormatStatusWidget renders counts for a status bar
+func FormatStatusWidget(c StatusCounts, widget string) (string, error) {
+	detail := strings.TrimSpace(c.TableOutput())
+
+	switch widget {
+	case WidgetWaybar:
+		// https://github.com/Alexays/Waybar/wiki/Module:-Custom (return-type: json)
+		encoded, err := json.Marshal(map[string]string{
+			"text":    fmt.Sprintf("plonk %d", c.OutOfSync()),
+			"tooltip": detail,
+			"class":   c.State(),
+		})
+		if err != nil {
+			return "", err
+		}
+		return string(encoded) + "\n", nil
+	case WidgetXbar:
+		// First line is the menu bar title; lines after --- are the dropdown
+		title := "plonk ✓"
+		if n := c.OutOfSync(); n > 0 {
+			title = fmt.Sprintf("plonk %d | color=orange", n)
+		}
+		return fmt.Sprintf("%s\n---\n%s\nApply | shell=plonk param1=apply terminal=true\n", title, detail), nil
+	case WidgetPolybar:
+		if n := c.OutOfSync(); n > 0 {
+			return fmt.Sprintf("plonk %d\n", n), nil
+		}
+		return "plonk ✓\n", nil
+	default:
+		return "", fmt.Errorf("unsupported widget: %s (use %s, %s, or %s)", widget, WidgetWaybar, WidgetXbar, WidgetPolybar)
+	}
+}
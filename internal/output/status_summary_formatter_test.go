@@ -0,0 +1,92 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package output
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewStatusCounts(t *testing.T) {
+	summary := Summary{
+		TotalManaged: 3,
+		TotalMissing: 1,
+		Results: []Result{
+			{Domain: "dotfile", Managed: []Item{{Name: "a", State: StateDegraded}, {Name: "b", State: StateManaged}}},
+			{Domain: "package", Managed: []Item{{Name: "c", State: StateManaged}}},
+		},
+	}
+
+	counts := NewStatusCounts(summary)
+	assert.Equal(t, 3, counts.Managed)
+	assert.Equal(t, 1, counts.Missing)
+	assert.Equal(t, 1, counts.Drifted)
+	assert.Equal(t, 2, counts.OutOfSync())
+	assert.Equal(t, "2 managed, 1 missing, 1 drifted, 0 errors\n", counts.TableOutput())
+}
+
+func TestFormatStatusWidget(t *testing.T) {
+	inSync := StatusCounts{Managed: 4}
+	outOfSync := StatusCounts{Managed: 4, Missing: 2}
+
+	text, err := FormatStatusWidget(outOfSync, WidgetWaybar)
+	require.NoError(t, err)
+	var waybar map[string]string
+	require.NoError(t, json.Unmarshal([]byte(text), &waybar))
+	assert.Equal(t, "plonk 2", waybar["text"])
+	assert.Equal(t, "drifted", waybar["class"])
+
+	text, err = FormatStatusWidget(inSync, WidgetXbar)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(text, "plonk ✓\n---\n"))
+
+	text, err = FormatStatusWidget(outOfSync, WidgetPolybar)
+	require.NoError(t, err)
+	assert.Equal(t, "plonk 2\n", text)
+
+	_, err = FormatStatusWidget(inSync, "conky")
+	assert.Error(t, err)
+}
+
+func TestFormatPromptToken(t *testing.T) {
+	now := time.Now()
+	assert.Equal(t, "✗3", FormatPromptToken(StatusCounts{Missing: 2, Errors: 1, GeneratedAt: now}, ""))
+	assert.Equal(t, "", FormatPromptToken(StatusCounts{Managed: 4, GeneratedAt: now}, ""))
+	assert.Equal(t, "✓", FormatPromptToken(StatusCounts{Managed: 4, GeneratedAt: now}, "✓"))
+	assert.Equal(t, "", FormatPromptToken(StatusCounts{}, "✓"), "nothing is known before the first count")
+}
+
+// Porcelain output is an interface scripts parse, so it is pinned by golden
+// files. Regenerate them with PLONK_UPDATE_GOLDEN=1 go test ./internal/output,
+// and bump PorcelainVersion if a key changes meaning or goes away.
+func TestFormatStatusPorcelain_Golden(t *testing.T) {
+	generated := time.Date(2025, 3, 14, 9, 26, 53, 0, time.FixedZone("EST", -5*60*60))
+	tests := map[string]StatusCounts{
+		"synced":  {Managed: 12, GeneratedAt: generated, Cached: true},
+		"drifted": {Managed: 12, Missing: 1, Drifted: 2, GeneratedAt: generated},
+		"error":   {Managed: 3, Missing: 1, Errors: 1, GeneratedAt: generated},
+	}
+	for name, counts := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := FormatStatusPorcelain(counts)
+
+			path := filepath.Join("testdata", "status-porcelain-"+name+".golden")
+			if os.Getenv("PLONK_UPDATE_GOLDEN") != "" {
+				require.NoError(t, os.MkdirAll("testdata", 0o755))
+				require.NoError(t, os.WriteFile(path, []byte(got), 0o644))
+			}
+			want, err := os.ReadFile(path)
+			require.NoError(t, err, "golden file missing; run with PLONK_UPDATE_GOLDEN=1")
+			assert.Equal(t, string(want), got)
+			assert.Equal(t, name, counts.State())
+		})
+	}
+}
@@ -0,0 +1,250 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/richhaase/plonk/internal/i18n"
+)
+
+// Summarizer is implemented by results that reduce to a single line, for
+// --quiet and --summary
+type Summarizer interface {
+	SummaryLine() string // e.g. "apply: 3 packages installed, 1 failed"
+	SummaryData() any    // the counts behind the line, for --summary json
+}
+
+// Summary formats accepted by --summary
+const (
+	SummaryText = "text"
+	SummaryJSON = "json"
+)
+
+var (
+	quiet         bool
+	summaryFormat string // "" for the full result
+)
+
+// SetQuiet suppresses progress messages, so only the result (a summary line
+// in table format) and errors are printed
+func SetQuiet(q bool) {
+	quiet = q
+	if q {
+		progressWriter = discardWriter{}
+	} else {
+		progressWriter = &StderrWriter{}
+	}
+}
+
+// IsQuiet reports whether progress messages are suppressed
+func IsQuiet() bool {
+	return quiet
+}
+
+// SetSummaryFormat makes RenderOutput print a one-line summary instead of
+// the full result: SummaryText, SummaryJSON, or "" to turn it off
+func SetSummaryFormat(format string) error {
+	switch format {
+	case "", SummaryText, SummaryJSON:
+		summaryFormat = format
+		return nil
+	}
+	return fmt.Errorf("unsupported summary format: %s (use text or json)", format)
+}
+
+// renderSummary prints data as one line, reporting whether it did. Results
+// that can't summarize themselves are written as compact JSON for
+// SummaryJSON and in full otherwise.
+func renderSummary(data OutputData) bool {
+	format := summaryFormat
+	if format == "" && quiet && currentFormat == OutputTable {
+		format = SummaryText
+	}
+	s, ok := data.(Summarizer)
+
+	switch {
+	case format == SummaryJSON:
+		var v any
+		if ok {
+			v = s.SummaryData()
+		} else {
+			v = structuredData(data)
+		}
+		line, err := json.Marshal(v)
+		if err != nil {
+			fmt.Printf("{\"error\": %q}\n", err.Error())
+			return true
+		}
+		fmt.Println(string(line))
+		return true
+	case format == SummaryText && ok:
+		fmt.Println(s.SummaryLine())
+		return true
+	}
+	return false
+}
+
+// discardWriter drops progress messages in quiet mode
+type discardWriter struct{}
+
+func (discardWriter) Printf(string, ...interface{}) {}
+func (discardWriter) IsTerminal() bool              { return false }
+
+// ApplySummary is the --summary form of an apply result
+type ApplySummary struct {
+	Success           bool     `json:"success" yaml:"success"`
+	DryRun            bool     `json:"dry_run" yaml:"dry_run"`
+	PackagesInstalled int      `json:"packages_installed" yaml:"packages_installed"` // would install, for a dry run
+	PackagesFailed    int      `json:"packages_failed" yaml:"packages_failed"`
+	DotfilesDeployed  int      `json:"dotfiles_deployed" yaml:"dotfiles_deployed"` // would deploy, for a dry run
+	DotfilesFailed    int      `json:"dotfiles_failed" yaml:"dotfiles_failed"`
+	ResourcesApplied  int      `json:"resources_applied" yaml:"resources_applied"` // would apply, for a dry run
+	ResourcesFailed   int      `json:"resources_failed" yaml:"resources_failed"`
+	Failed            []string `json:"failed,omitempty" yaml:"failed,omitempty"` // "manager:name", dotfile paths and resource items
+}
+
+// SummaryData returns the apply counts and what failed
+func (r ApplyResult) SummaryData() any {
+	s := ApplySummary{Success: r.Success, DryRun: r.DryRun}
+	if p := r.Packages; p != nil {
+		s.PackagesInstalled, s.PackagesFailed = p.TotalInstalled+p.TotalWouldInstall, p.TotalFailed
+		for _, m := range p.Managers {
+			for _, pkg := range m.Packages {
+				if pkg.Status == "failed" {
+					s.Failed = append(s.Failed, m.Name+":"+pkg.Name)
+				}
+			}
+		}
+	}
+	if d := r.Dotfiles; d != nil {
+		s.DotfilesFailed = d.Summary.Failed
+		for _, action := range d.Actions {
+			switch action.Status {
+			case "failed":
+				s.Failed = append(s.Failed, action.Destination)
+			case "added", "updated", "moved", "would-add", "would-update", "would-move":
+				s.DotfilesDeployed++
+			}
+		}
+	}
+	if res := r.Resources; res != nil {
+		s.ResourcesApplied, s.ResourcesFailed = res.TotalApplied+res.TotalWouldApply, res.TotalFailed
+		for _, rt := range res.Resources {
+			for _, item := range rt.Items {
+				if item.Status == "failed" {
+					s.Failed = append(s.Failed, rt.Name+":"+item.Name)
+				}
+			}
+		}
+	}
+	return s
+}
+
+// SummaryLine reports apply counts and what failed on one line
+func (r ApplyResult) SummaryLine() string {
+	s := r.SummaryData().(ApplySummary)
+	installed := "%d packages installed"
+	deployed := "%d dotfiles deployed"
+	applied := "%d resources applied"
+	if s.DryRun {
+		installed, deployed, applied = "%d packages would install", "%d dotfiles would deploy", "%d resources would apply"
+	}
+
+	parts := []string{
+		i18n.T(installed, s.PackagesInstalled),
+		i18n.T(deployed, s.DotfilesDeployed),
+	}
+	if r.Resources != nil {
+		parts = append(parts, i18n.T(applied, s.ResourcesApplied))
+	}
+	if failed := s.PackagesFailed + s.DotfilesFailed + s.ResourcesFailed; failed > 0 {
+		parts = append(parts, i18n.T("%d failed (%s)", failed, strings.Join(s.Failed, ", ")))
+	}
+	return "apply: " + strings.Join(parts, ", ")
+}
+
+// SummaryData returns the status counts
+func (f StatusFormatter) SummaryData() any {
+	return NewStatusCounts(f.Data.StateSummary)
+}
+
+// SummaryLine reports the status counts on one line
+func (f StatusFormatter) SummaryLine() string {
+	return "status: " + NewStatusCounts(f.Data.StateSummary).SummaryLine()
+}
+
+// SummaryData returns the counts themselves
+func (c StatusCounts) SummaryData() any {
+	return c
+}
+
+// SummaryLine is the counts' table line without its newline
+func (c StatusCounts) SummaryLine() string {
+	return strings.TrimSpace(c.TableOutput())
+}
+
+// SummaryData returns package counts
+func (f PackagesStatusFormatter) SummaryData() any {
+	result := f.Data.Options.Filter(f.Data.Result)
+	return NewStatusCounts(Summary{
+		TotalManaged: len(result.Managed),
+		TotalMissing: len(result.Missing),
+		TotalErrors:  len(result.Errors),
+	})
+}
+
+// SummaryLine reports package counts on one line
+func (f PackagesStatusFormatter) SummaryLine() string {
+	c := f.SummaryData().(StatusCounts)
+	return "packages: " + i18n.T("%d managed, %d missing, %d errors", c.Managed, c.Missing, c.Errors)
+}
+
+// SummaryData returns dotfile counts
+func (f DotfilesStatusFormatter) SummaryData() any {
+	result := f.Data.Options.Filter(f.Data.Result)
+	return NewStatusCounts(Summary{
+		TotalManaged: len(result.Managed),
+		TotalMissing: len(result.Missing),
+		TotalErrors:  len(result.Errors),
+		Results:      []Result{result},
+	})
+}
+
+// SummaryLine reports dotfile counts on one line
+func (f DotfilesStatusFormatter) SummaryLine() string {
+	return "dotfiles: " + f.SummaryData().(StatusCounts).SummaryLine()
+}
+
+// DoctorSummary is the --summary form of a doctor report
+type DoctorSummary struct {
+	Overall string `json:"overall" yaml:"overall"` // healthy, warning or unhealthy
+	Pass    int    `json:"pass" yaml:"pass"`
+	Warn    int    `json:"warn" yaml:"warn"`
+	Fail    int    `json:"fail" yaml:"fail"`
+}
+
+// SummaryData returns the overall status and check counts
+func (f DoctorFormatter) SummaryData() any {
+	s := DoctorSummary{Overall: f.Data.Overall.Status}
+	for _, check := range f.Data.Checks {
+		switch check.Status {
+		case "pass":
+			s.Pass++
+		case "warn":
+			s.Warn++
+		case "fail":
+			s.Fail++
+		}
+	}
+	return s
+}
+
+// SummaryLine reports the overall status and check counts on one line
+func (f DoctorFormatter) SummaryLine() string {
+	s := f.SummaryData().(DoctorSummary)
+	return "doctor: " + i18n.T("%s (%d pass, %d warn, %d fail)", i18n.T(s.Overall), s.Pass, s.Warn, s.Fail)
+}
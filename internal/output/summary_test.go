@@ -0,0 +1,114 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package output
+
+import (
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// captureStdout returns what fn writes to stdout
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	orig := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = orig }()
+
+	fn()
+	require.NoError(t, w.Close())
+	out, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return string(out)
+}
+
+// withSummary sets quiet mode and the summary format for one test
+func withSummary(t *testing.T, q bool, format string) {
+	t.Helper()
+	origProgress, origFormat := progressWriter, currentFormat
+	SetQuiet(q)
+	require.NoError(t, SetSummaryFormat(format))
+	t.Cleanup(func() {
+		quiet, summaryFormat = false, ""
+		progressWriter, currentFormat = origProgress, origFormat
+	})
+}
+
+func sampleApply() ApplyResult {
+	return ApplyResult{
+		Success: false,
+		Packages: &PackageResults{TotalInstalled: 2, TotalFailed: 1, Managers: []ManagerResults{
+			{Name: "brew", Packages: []PackageOperation{
+				{Name: "fd", Status: "installed"}, {Name: "jq", Status: "installed"}, {Name: "bat", Status: "failed"},
+			}},
+		}},
+		Dotfiles: &DotfileResults{
+			Actions: []DotfileOperation{{Destination: "~/.zshrc", Status: "added"}, {Destination: "~/.vimrc", Status: "unchanged"}},
+			Summary: DotfileSummary{Added: 1, Unchanged: 1},
+		},
+	}
+}
+
+func TestQuiet_PrintsSummaryLine(t *testing.T) {
+	withSummary(t, true, "")
+	Printf("progress that should be dropped\n")
+
+	out := captureStdout(t, func() { RenderOutput(sampleApply()) })
+	assert.Equal(t, "apply: 2 packages installed, 1 dotfiles deployed, 1 failed (brew:bat)\n", out)
+}
+
+func TestQuiet_StructuredFormatsUnchanged(t *testing.T) {
+	withSummary(t, true, "")
+	SetOutputFormat(OutputJSON)
+
+	out := captureStdout(t, func() { RenderOutput(sampleApply()) })
+	assert.Contains(t, out, "\n  \"success\": false", "quiet only affects table output")
+}
+
+func TestSummaryJSON_OneLine(t *testing.T) {
+	withSummary(t, true, SummaryJSON)
+
+	out := captureStdout(t, func() { RenderOutput(sampleApply()) })
+	assert.Equal(t, `{"success":false,"dry_run":false,"packages_installed":2,"packages_failed":1,"dotfiles_deployed":1,"dotfiles_failed":0,"resources_applied":0,"resources_failed":0,"failed":["brew:bat"]}`+"\n", out)
+}
+
+func TestSummaryJSON_FallsBackToCompactStructuredData(t *testing.T) {
+	withSummary(t, true, SummaryJSON)
+
+	out := captureStdout(t, func() { RenderOutput(structuredDummy{}) })
+	assert.Equal(t, "{\"k\":\"v\"}\n", out)
+}
+
+func TestSummaryText_WithoutSummarizerPrintsTable(t *testing.T) {
+	withSummary(t, true, SummaryText)
+
+	out := captureStdout(t, func() { RenderOutput(dummy{}) })
+	assert.Equal(t, "test table output", out)
+}
+
+func TestSetSummaryFormat_Invalid(t *testing.T) {
+	assert.Error(t, SetSummaryFormat("xml"))
+}
+
+func TestSummaryLines(t *testing.T) {
+	dry := ApplyResult{DryRun: true, Packages: &PackageResults{TotalWouldInstall: 3}}
+	assert.Equal(t, "apply: 3 packages would install, 0 dotfiles would deploy", dry.SummaryLine())
+
+	doctor := NewDoctorFormatter(DoctorOutput{
+		Overall: HealthStatus{Status: "warning"},
+		Checks:  []HealthCheck{{Status: "pass"}, {Status: "pass"}, {Status: "warn"}, {Status: "info"}},
+	})
+	assert.Equal(t, "doctor: warning (2 pass, 1 warn, 0 fail)", doctor.SummaryLine())
+
+	dotfiles := NewDotfilesStatusFormatter(DotfilesStatusOutput{Result: parityDotfiles()})
+	assert.Equal(t, "dotfiles: 1 managed, 1 missing, 1 drifted, 0 errors", dotfiles.SummaryLine())
+
+	pkgs := NewPackagesStatusFormatter(PackagesStatusOutput{Result: parityPackages()})
+	assert.Equal(t, "packages: 1 managed, 1 missing, 1 errors", pkgs.SummaryLine())
+}
@@ -10,15 +10,17 @@ import (
 
 // ApplyResult represents the top-level result of any apply operation
 type ApplyResult struct {
-	DryRun        bool            `json:"dry_run" yaml:"dry_run"`
-	Success       bool            `json:"success" yaml:"success"` // True if no errors occurred (includes clean no-op)
-	Changed       bool            `json:"changed" yaml:"changed"` // True if any changes were made
-	Scope         string          `json:"scope" yaml:"scope"`     // "packages", "dotfiles", "all"
-	Packages      *PackageResults `json:"packages,omitempty" yaml:"packages,omitempty"`
-	Dotfiles      *DotfileResults `json:"dotfiles,omitempty" yaml:"dotfiles,omitempty"`
-	Error         string          `json:"error,omitempty" yaml:"error,omitempty"`
-	PackageErrors []error         `json:"-" yaml:"-"`
-	DotfileErrors []error         `json:"-" yaml:"-"`
+	DryRun         bool             `json:"dry_run" yaml:"dry_run"`
+	Success        bool             `json:"success" yaml:"success"` // True if no errors occurred (includes clean no-op)
+	Changed        bool             `json:"changed" yaml:"changed"` // True if any changes were made
+	Scope          string           `json:"scope" yaml:"scope"`     // "packages", "dotfiles", "all"
+	Packages       *PackageResults  `json:"packages,omitempty" yaml:"packages,omitempty"`
+	Dotfiles       *DotfileResults  `json:"dotfiles,omitempty" yaml:"dotfiles,omitempty"`
+	Resources      *ResourceResults `json:"resources,omitempty" yaml:"resources,omitempty"`
+	Error          string           `json:"error,omitempty" yaml:"error,omitempty"`
+	PackageErrors  []error          `json:"-" yaml:"-"`
+	DotfileErrors  []error          `json:"-" yaml:"-"`
+	ResourceErrors []error          `json:"-" yaml:"-"`
 }
 
 // PackageResults represents package apply operation results
@@ -40,9 +42,12 @@ type ManagerResults struct {
 
 // PackageOperation represents a single package operation result
 type PackageOperation struct {
-	Name   string `json:"name" yaml:"name"`
-	Status string `json:"status" yaml:"status"` // "installed", "failed", "would_install", etc.
-	Error  string `json:"error,omitempty" yaml:"error,omitempty"`
+	Name       string `json:"name" yaml:"name"`
+	Manager    string `json:"manager,omitempty" yaml:"manager,omitempty"`
+	Status     string `json:"status" yaml:"status"` // "installed", "failed", "would_install", etc.
+	Error      string `json:"error,omitempty" yaml:"error,omitempty"`
+	ErrorClass string `json:"error_class,omitempty" yaml:"error_class,omitempty"` // "not_found", "permission", "network", "manager_unavailable", "timeout", "unknown"
+	Err        error  `json:"-" yaml:"-"`                                         // the failure itself, for failure reports
 }
 
 // DotfileResults represents dotfile apply operation results
@@ -53,12 +58,36 @@ type DotfileResults struct {
 	Summary    DotfileSummary     `json:"summary" yaml:"summary"`
 }
 
+// ResourceResults represents custom resource apply results
+type ResourceResults struct {
+	DryRun          bool                 `json:"dry_run" yaml:"dry_run"`
+	TotalApplied    int                  `json:"total_applied" yaml:"total_applied"`
+	TotalFailed     int                  `json:"total_failed" yaml:"total_failed"`
+	TotalWouldApply int                  `json:"total_would_apply" yaml:"total_would_apply"`
+	Resources       []ResourceTypeResult `json:"resources" yaml:"resources"`
+}
+
+// ResourceTypeResult represents results for one custom resource plugin
+type ResourceTypeResult struct {
+	Name  string              `json:"name" yaml:"name"`
+	Error string              `json:"error,omitempty" yaml:"error,omitempty"` // resource could not be reconciled
+	Items []ResourceOperation `json:"items" yaml:"items"`
+}
+
+// ResourceOperation represents a single resource item operation result
+type ResourceOperation struct {
+	Name   string `json:"name" yaml:"name"`
+	Status string `json:"status" yaml:"status"` // "applied", "would-apply", "failed"
+	Error  string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
 // DotfileOperation represents a single dotfile operation result
 type DotfileOperation struct {
 	Source      string `json:"source" yaml:"source"`
 	Destination string `json:"destination" yaml:"destination"`
-	Action      string `json:"action" yaml:"action"` // "added", "updated", "unchanged", "failed"
-	Status      string `json:"status" yaml:"status"` // "success", "failed", "skipped"
+	From        string `json:"from,omitempty" yaml:"from,omitempty"` // previous destination of a moved dotfile
+	Action      string `json:"action" yaml:"action"`                 // "added", "updated", "unchanged", "failed"
+	Status      string `json:"status" yaml:"status"`                 // "success", "failed", "skipped"
 	Error       string `json:"error,omitempty" yaml:"error,omitempty"`
 }
 
@@ -119,6 +148,12 @@ func (r ApplyResult) TableOutput() string {
 				output += fmt.Sprintf("  → %s (would deploy)\n", action.Destination)
 			case "would-update":
 				output += fmt.Sprintf("  → %s (would deploy)\n", action.Destination)
+			case "moved":
+				output += fmt.Sprintf("  ✓ %s (moved from %s)\n", action.Destination, action.From)
+			case "would-move":
+				output += fmt.Sprintf("  → %s (would move from %s)\n", action.Destination, action.From)
+			case "kept":
+				output += fmt.Sprintf("  - %s (kept local)\n", action.Destination)
 			case "failed":
 				output += fmt.Sprintf("  ✗ %s: %s\n", action.Destination, action.Error)
 			}
@@ -126,6 +161,30 @@ func (r ApplyResult) TableOutput() string {
 		output += "\n"
 	}
 
+	// Resource details
+	if r.Resources != nil {
+		for _, res := range r.Resources.Resources {
+			if res.Error == "" && len(res.Items) == 0 {
+				continue
+			}
+			output += fmt.Sprintf("%s:\n", res.Name)
+			if res.Error != "" {
+				output += fmt.Sprintf("  ✗ %s\n", res.Error)
+			}
+			for _, item := range res.Items {
+				switch item.Status {
+				case "applied":
+					output += fmt.Sprintf("  ✓ %s\n", item.Name)
+				case "would-apply":
+					output += fmt.Sprintf("  → %s (would apply)\n", item.Name)
+				case "failed":
+					output += fmt.Sprintf("  ✗ %s: %s\n", item.Name, item.Error)
+				}
+			}
+			output += "\n"
+		}
+	}
+
 	// Summary section
 	output += "Summary:\n"
 	output += "--------\n"
@@ -166,6 +225,19 @@ func (r ApplyResult) TableOutput() string {
 		}
 	}
 
+	// Resource summary
+	if r.Resources != nil {
+		if r.DryRun {
+			output += fmt.Sprintf("Resources: %d would be applied\n", r.Resources.TotalWouldApply)
+		} else if r.Resources.TotalApplied > 0 || r.Resources.TotalFailed > 0 {
+			output += fmt.Sprintf("Resources: %d applied, %d failed\n", r.Resources.TotalApplied, r.Resources.TotalFailed)
+			totalSucceeded += r.Resources.TotalApplied
+			totalFailed += r.Resources.TotalFailed
+		} else {
+			output += "Resources: All up to date\n"
+		}
+	}
+
 	// Overall result
 	if !r.DryRun && (totalSucceeded > 0 || totalFailed > 0) {
 		output += fmt.Sprintf("\nTotal: %d succeeded, %d failed\n", totalSucceeded, totalFailed)
@@ -195,17 +267,25 @@ func (r *ApplyResult) AddDotfileError(err error) {
 	}
 }
 
+// AddResourceError adds an error to the resource errors list
+func (r *ApplyResult) AddResourceError(err error) {
+	if err != nil {
+		r.ResourceErrors = append(r.ResourceErrors, err)
+	}
+}
+
 // GetCombinedError returns all errors as a single error using errors.Join
 func (r *ApplyResult) GetCombinedError() error {
 	var allErrors []error
 	allErrors = append(allErrors, r.PackageErrors...)
 	allErrors = append(allErrors, r.DotfileErrors...)
+	allErrors = append(allErrors, r.ResourceErrors...)
 	return errors.Join(allErrors...)
 }
 
 // HasErrors returns true if there are any errors
 func (r *ApplyResult) HasErrors() bool {
-	return len(r.PackageErrors) > 0 || len(r.DotfileErrors) > 0
+	return len(r.PackageErrors) > 0 || len(r.DotfileErrors) > 0 || len(r.ResourceErrors) > 0
 }
 
 // StructuredData returns the data structure for JSON/YAML serialization
@@ -239,3 +319,18 @@ type UpgradeSummary struct {
 	Failed   int `json:"failed" yaml:"failed"`
 	Skipped  int `json:"skipped" yaml:"skipped"`
 }
+
+// ErrorOutput is the structured form of a failed command, rendered on stdout
+// for json/yaml output so tooling does not have to parse stderr
+type ErrorOutput struct {
+	Error      string `json:"error" yaml:"error"`
+	ErrorClass string `json:"error_class,omitempty" yaml:"error_class,omitempty"`
+	Manager    string `json:"manager,omitempty" yaml:"manager,omitempty"`
+	Package    string `json:"package,omitempty" yaml:"package,omitempty"`
+	Hint       string `json:"hint,omitempty" yaml:"hint,omitempty"`
+}
+
+// TableOutput generates human-friendly output for a failed command
+func (e ErrorOutput) TableOutput() string {
+	return fmt.Sprintf("Error: %s\n", e.Error)
+}
@@ -7,6 +7,9 @@ import (
 	"fmt"
 	"strings"
 	"text/tabwriter"
+	"unicode/utf8"
+
+	"github.com/richhaase/plonk/internal/i18n"
 )
 
 // Common status icons used across all commands
@@ -22,9 +25,9 @@ const (
 // GetStatusIcon returns the appropriate icon for a given status
 func GetStatusIcon(status string) string {
 	switch status {
-	case "managed", "added", "installed", "removed", "success", "completed", "deployed":
+	case "managed", "added", "installed", "removed", "success", "completed", "deployed", "moved":
 		return IconSuccess
-	case "missing", "warn", "warning", "would-install", "would-remove", "would-add", "would-update":
+	case "missing", "warn", "warning", "would-install", "would-remove", "would-add", "would-update", "would-move":
 		return IconWarning
 	case "failed", "error", "fail":
 		return IconError
@@ -88,9 +91,13 @@ func NewStandardTableBuilder(title string) *StandardTableBuilder {
 	}
 }
 
-// SetHeaders sets the table column headers
+// SetHeaders sets the table column headers, translated into the current
+// locale
 func (t *StandardTableBuilder) SetHeaders(headers ...string) *StandardTableBuilder {
-	t.headers = headers
+	t.headers = make([]string, len(headers))
+	for i, h := range headers {
+		t.headers[i] = i18n.T(h)
+	}
 	return t
 }
 
@@ -113,7 +120,7 @@ func (t *StandardTableBuilder) Build() string {
 	// Title
 	if t.title != "" {
 		output.WriteString(t.title + "\n")
-		output.WriteString(strings.Repeat("=", len(t.title)) + "\n\n")
+		output.WriteString(strings.Repeat("=", utf8.RuneCountInString(t.title)) + "\n\n")
 	}
 
 	// Table with proper alignment
@@ -124,12 +131,13 @@ func (t *StandardTableBuilder) Build() string {
 		// Compute column widths (max of header and all row values)
 		colWidths := make([]int, len(t.headers))
 		for i, h := range t.headers {
-			colWidths[i] = len(h)
+			colWidths[i] = utf8.RuneCountInString(h)
 		}
 		for _, row := range t.rows {
 			for i, val := range row {
-				if i < len(colWidths) && len(val) > colWidths[i] {
-					colWidths[i] = len(val)
+				// tabwriter counts runes, so translated text lines up too
+				if n := utf8.RuneCountInString(val); i < len(colWidths) && n > colWidths[i] {
+					colWidths[i] = n
 				}
 			}
 		}
@@ -162,7 +170,7 @@ func (t *StandardTableBuilder) Build() string {
 
 	// Errors
 	if len(t.errors) > 0 {
-		output.WriteString("\nErrors:\n")
+		output.WriteString("\n" + i18n.T("Errors:") + "\n")
 		for _, err := range t.errors {
 			fmt.Fprintf(&output, "  %s %s\n", IconError, err)
 		}
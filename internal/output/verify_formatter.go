@@ -0,0 +1,55 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package output
+
+import (
+	"fmt"
+	"strings"
+)
+
+// VerifyCheck is one part of checking that a machine is ready
+type VerifyCheck struct {
+	Name    string   `json:"name" yaml:"name"`
+	Passed  bool     `json:"passed" yaml:"passed"`
+	Message string   `json:"message" yaml:"message"`
+	Issues  []string `json:"issues,omitempty" yaml:"issues,omitempty"`
+}
+
+// VerifyOutput is the readiness report clone prints once setup is done
+type VerifyOutput struct {
+	Ready  bool          `json:"ready" yaml:"ready"`
+	Checks []VerifyCheck `json:"checks" yaml:"checks"`
+}
+
+// TableOutput lists each check with its issues and the verdict
+func (v VerifyOutput) TableOutput() string {
+	var out strings.Builder
+	WriteTitle(&out, "Verification")
+
+	for _, c := range v.Checks {
+		icon := IconSuccess
+		if !c.Passed {
+			icon = IconError
+		}
+		fmt.Fprintf(&out, "%s %s: %s\n", icon, c.Name, c.Message)
+		for _, issue := range c.Issues {
+			fmt.Fprintf(&out, "    %s\n", issue)
+		}
+	}
+
+	if v.Ready {
+		fmt.Fprintf(&out, "\n%s PASS: this machine is ready\n", IconSuccess)
+	} else {
+		fmt.Fprintf(&out, "\n%s FAIL: this machine is not ready yet; fix the issues above, then run 'plonk apply'\n", IconError)
+	}
+	return out.String()
+}
+
+// StructuredData returns the report for serialization
+func (v VerifyOutput) StructuredData() any {
+	if v.Checks == nil {
+		v.Checks = []VerifyCheck{}
+	}
+	return v
+}
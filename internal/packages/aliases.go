@@ -0,0 +1,90 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package packages
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+var (
+	// equivalents maps a manager:package spec to the other specs that
+	// satisfy the same requirement, from the aliases in plonk.yaml
+	equivalents   map[string][]string
+	equivalentsMu sync.RWMutex
+)
+
+// SetAliases registers the aliases from plonk.yaml. Every spec listed under
+// an alias becomes equivalent to the others, so status and apply treat a
+// tracked package as present when any equivalent is installed.
+func SetAliases(aliases map[string][]string) {
+	groups := make(map[string][]string)
+	for _, specs := range aliases {
+		for _, spec := range specs {
+			for _, other := range specs {
+				if other != spec && !slices.Contains(groups[spec], other) {
+					groups[spec] = append(groups[spec], other)
+				}
+			}
+		}
+	}
+
+	equivalentsMu.Lock()
+	defer equivalentsMu.Unlock()
+	equivalents = groups
+}
+
+// Equivalents returns the specs that satisfy the same requirement as spec
+func Equivalents(spec string) []string {
+	equivalentsMu.RLock()
+	defer equivalentsMu.RUnlock()
+	return equivalents[spec]
+}
+
+// installedEquivalent returns the first installed equivalent of
+// manager:pkg, or "" if there is none. Equivalents whose manager is not
+// available here are skipped rather than reported as errors.
+func installedEquivalent(ctx context.Context, manager, pkg string) string {
+	for _, spec := range Equivalents(manager + ":" + pkg) {
+		if installed, _ := specInstalled(ctx, spec); installed {
+			return spec
+		}
+	}
+	return ""
+}
+
+// ResolveAlias picks the candidate to use for an alias (or an unprefixed
+// package name) on this machine: the first one that is installed, checked in
+// the order given. Candidates whose manager is unsupported or missing are
+// skipped.
+func ResolveAlias(ctx context.Context, alias string, candidates []string) (manager, pkg string, err error) {
+	for _, spec := range candidates {
+		installed, err := specInstalled(ctx, spec)
+		if err != nil || !installed {
+			continue
+		}
+		return ParsePackageSpec(spec)
+	}
+	return "", "", fmt.Errorf("%s: none of %s is installed", alias, strings.Join(candidates, ", "))
+}
+
+// specInstalled reports whether spec is installed, treating an unavailable
+// manager as not installed
+func specInstalled(ctx context.Context, spec string) (bool, error) {
+	manager, pkg, err := ParsePackageSpec(spec)
+	if err != nil {
+		return false, err
+	}
+	if err := CheckManagerAvailable(manager); err != nil {
+		return false, err
+	}
+	mgr, err := GetManager(manager)
+	if err != nil {
+		return false, err
+	}
+	return mgr.IsInstalled(ctx, pkg)
+}
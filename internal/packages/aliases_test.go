@@ -0,0 +1,77 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package packages
+
+import (
+	"context"
+	"testing"
+
+	"github.com/richhaase/plonk/internal/lock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetAliases_Equivalents(t *testing.T) {
+	t.Cleanup(func() { SetAliases(nil) })
+	SetAliases(map[string][]string{
+		"rg": {"brew:ripgrep", "cargo:ripgrep", "apt:ripgrep"},
+	})
+
+	assert.Equal(t, []string{"cargo:ripgrep", "apt:ripgrep"}, Equivalents("brew:ripgrep"))
+	assert.Equal(t, []string{"brew:ripgrep", "cargo:ripgrep"}, Equivalents("apt:ripgrep"))
+	assert.Empty(t, Equivalents("brew:fd"))
+
+	SetAliases(nil)
+	assert.Empty(t, Equivalents("brew:ripgrep"))
+}
+
+func TestResolveAlias(t *testing.T) {
+	ResetManagerCache()
+	t.Cleanup(ResetManagerCache)
+	fakePlugin(t, "fake")
+	ctx := context.Background()
+
+	// Unsupported managers and packages that aren't installed are skipped
+	manager, pkg, err := ResolveAlias(ctx, "a", []string{"apt:alpha", "fake:beta", "fake:alpha"})
+	require.NoError(t, err)
+	assert.Equal(t, "fake", manager)
+	assert.Equal(t, "alpha", pkg)
+
+	_, _, err = ResolveAlias(ctx, "b", []string{"apt:beta", "fake:beta"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "b: none of apt:beta, fake:beta is installed")
+}
+
+func TestReconcile_EquivalentSatisfiesMissing(t *testing.T) {
+	ResetManagerCache()
+	t.Cleanup(ResetManagerCache)
+	t.Cleanup(func() { SetAliases(nil) })
+	fakePlugin(t, "fake")
+	fakePlugin(t, "other")
+
+	configDir := t.TempDir()
+	l := lock.NewLockV3()
+	l.AddPackage("fake", "beta")
+	l.AddPackage("fake", "gamma")
+	require.NoError(t, lock.NewLockV3Service(configDir).Write(l))
+
+	SetAliases(map[string][]string{"b": {"fake:beta", "other:alpha"}})
+
+	statuses, err := Reconcile(context.Background(), configDir)
+	require.NoError(t, err)
+	byName := map[string]PackageStatus{}
+	for _, s := range statuses {
+		byName[s.Name] = s
+	}
+	assert.Equal(t, StatusManaged, byName["beta"].State)
+	assert.Equal(t, "other:alpha", byName["beta"].SatisfiedBy)
+	assert.Equal(t, StatusMissing, byName["gamma"].State)
+	assert.Empty(t, byName["gamma"].SatisfiedBy)
+
+	// Apply skips the package instead of installing it
+	result, err := SimpleApply(context.Background(), configDir, true)
+	require.NoError(t, err)
+	assert.Contains(t, result.Skipped, "fake:beta")
+	assert.NotContains(t, result.Skipped, "fake:gamma")
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/richhaase/plonk/internal/lock"
@@ -28,12 +29,23 @@ const PerPackageTimeout = 10 * time.Minute
 
 // SimpleApply installs all tracked packages that are missing
 func SimpleApply(ctx context.Context, configDir string, dryRun bool) (*SimpleApplyResult, error) {
+	return SimpleApplySelected(ctx, configDir, dryRun, nil)
+}
+
+// SimpleApplySelected is SimpleApply limited to the tracked packages whose
+// "manager:package" spec is in only; a nil set selects every package
+func SimpleApplySelected(ctx context.Context, configDir string, dryRun bool, only map[string]bool) (*SimpleApplyResult, error) {
 	lockSvc := lock.NewLockV3Service(configDir)
 	lockFile, err := lockSvc.Read()
 	if err != nil {
 		return nil, fmt.Errorf("failed to read lock file: %w", err)
 	}
+	return ApplyLock(ctx, lockFile, dryRun, only)
+}
 
+// ApplyLock installs the missing packages of lockFile, which need not be
+// plonk.lock: project manifests are applied through it too
+func ApplyLock(ctx context.Context, lockFile *lock.LockV3, dryRun bool, only map[string]bool) (*SimpleApplyResult, error) {
 	result := &SimpleApplyResult{}
 
 	// Sort managers for deterministic order — ensures managers that provide
@@ -41,6 +53,9 @@ func SimpleApply(ctx context.Context, configDir string, dryRun bool) (*SimpleApp
 	// (e.g., go:golang.org/x/tools/gopls)
 	managers := make([]string, 0, len(lockFile.Packages))
 	for manager := range lockFile.Packages {
+		if IsManagerDisabled(manager) {
+			continue
+		}
 		managers = append(managers, manager)
 	}
 	sort.Strings(managers)
@@ -59,8 +74,16 @@ func SimpleApply(ctx context.Context, configDir string, dryRun bool) (*SimpleApp
 		if err != nil {
 			for _, pkg := range pkgs {
 				spec := manager + ":" + pkg
+				if only != nil && !only[spec] {
+					continue
+				}
 				result.Failed = append(result.Failed, spec)
-				result.Errors = append(result.Errors, fmt.Errorf("%s: manager not available: %w", spec, err))
+				result.Errors = append(result.Errors, &PackageError{
+					Class:   ErrManagerUnavailable,
+					Manager: manager,
+					Package: pkg,
+					Err:     fmt.Errorf("%s: manager not available: %w", spec, err),
+				})
 			}
 			continue
 		}
@@ -69,6 +92,9 @@ func SimpleApply(ctx context.Context, configDir string, dryRun bool) (*SimpleApp
 		var managerErr error
 		for _, pkg := range pkgs {
 			spec := manager + ":" + pkg
+			if only != nil && !only[spec] {
+				continue
+			}
 
 			if managerBroken {
 				result.Failed = append(result.Failed, spec)
@@ -87,7 +113,7 @@ func SimpleApply(ctx context.Context, configDir string, dryRun bool) (*SimpleApp
 				continue
 			}
 
-			if installed {
+			if installed || installedEquivalent(ctx, manager, pkg) != "" {
 				result.Skipped = append(result.Skipped, spec)
 				continue
 			}
@@ -101,11 +127,32 @@ func SimpleApply(ctx context.Context, configDir string, dryRun bool) (*SimpleApp
 		}
 	}
 
-	// Phase 2: execute installs with live spinner feedback.
+	// Phase 2: execute installs with live spinner feedback. Consecutive
+	// entries share a manager, so each group can go out as one batch.
 	if len(plan) > 0 {
-		sm := output.NewSpinnerManager(len(plan))
+		type group struct {
+			mgr     Manager
+			entries []planEntry
+		}
+		var groups []group
+		steps := 0
 		for _, p := range plan {
-			spinner := sm.StartSpinner("Installing", p.spec)
+			if n := len(groups); n > 0 && groups[n-1].mgr == p.mgr {
+				groups[n-1].entries = append(groups[n-1].entries, p)
+			} else {
+				groups = append(groups, group{mgr: p.mgr, entries: []planEntry{p}})
+			}
+		}
+		for _, g := range groups {
+			if _, ok := batchInstaller(g.mgr); ok && len(g.entries) > 1 {
+				steps++
+			} else {
+				steps += len(g.entries)
+			}
+		}
+
+		sm := output.NewSpinnerManager(steps)
+		installOne := func(spinner *output.Spinner, p planEntry) {
 			err := callWithTimeoutVoid(ctx, func(c context.Context) error {
 				return p.mgr.Install(c, p.pkg)
 			})
@@ -113,11 +160,44 @@ func SimpleApply(ctx context.Context, configDir string, dryRun bool) (*SimpleApp
 				spinner.Error(fmt.Sprintf("%s: %s", p.spec, err.Error()))
 				result.Failed = append(result.Failed, p.spec)
 				result.Errors = append(result.Errors, fmt.Errorf("%s: %w", p.spec, err))
-				continue
+				return
 			}
 			spinner.Success(fmt.Sprintf("installed %s", p.spec))
 			result.Installed = append(result.Installed, p.spec)
 		}
+
+		for _, g := range groups {
+			batcher, ok := batchInstaller(g.mgr)
+			if !ok || len(g.entries) == 1 {
+				for _, p := range g.entries {
+					installOne(sm.StartSpinner("Installing", p.spec), p)
+				}
+				continue
+			}
+
+			specs := make([]string, len(g.entries))
+			names := make([]string, len(g.entries))
+			for i, p := range g.entries {
+				specs[i] = p.spec
+				names[i] = p.pkg
+			}
+			spinner := sm.StartSpinner("Installing", strings.Join(specs, ", "))
+			err := callWithTimeoutN(ctx, len(names), func(c context.Context) error {
+				return batcher.InstallBatch(c, names)
+			})
+			if err == nil {
+				spinner.Success(fmt.Sprintf("installed %s", strings.Join(specs, ", ")))
+				result.Installed = append(result.Installed, specs...)
+				continue
+			}
+
+			// One bad package fails the whole batch; retry individually so
+			// the rest still install and each failure is attributed.
+			spinner.Error(fmt.Sprintf("batch install failed, retrying one at a time: %s", err.Error()))
+			for _, p := range g.entries {
+				installOne(output.NewSpinner(fmt.Sprintf("Installing: %s", p.spec)).Start(), p)
+			}
+		}
 	}
 
 	// Return error if any packages failed
@@ -128,6 +208,19 @@ func SimpleApply(ctx context.Context, configDir string, dryRun bool) (*SimpleApp
 	return result, nil
 }
 
+// batchInstaller returns mgr as a BatchInstaller if it can batch with its
+// current options
+func batchInstaller(mgr Manager) (BatchInstaller, bool) {
+	b, ok := mgr.(BatchInstaller)
+	if !ok {
+		return nil, false
+	}
+	if c, ok := mgr.(interface{ batchable() bool }); ok && !c.batchable() {
+		return nil, false
+	}
+	return b, true
+}
+
 // callWithTimeout runs fn with a per-call timeout derived from PerPackageTimeout,
 // inheriting cancellation from the parent context.
 func callWithTimeout[T any](ctx context.Context, fn func(context.Context) (T, error)) (T, error) {
@@ -137,7 +230,12 @@ func callWithTimeout[T any](ctx context.Context, fn func(context.Context) (T, er
 }
 
 func callWithTimeoutVoid(ctx context.Context, fn func(context.Context) error) error {
-	c, cancel := context.WithTimeout(ctx, PerPackageTimeout)
+	return callWithTimeoutN(ctx, 1, fn)
+}
+
+// callWithTimeoutN gives a batch of n packages n times the per-package budget
+func callWithTimeoutN(ctx context.Context, n int, fn func(context.Context) error) error {
+	c, cancel := context.WithTimeout(ctx, time.Duration(n)*PerPackageTimeout)
 	defer cancel()
 	return fn(c)
 }
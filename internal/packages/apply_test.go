@@ -91,6 +91,27 @@ func TestSimpleApply_InstallSuccess(t *testing.T) {
 	assert.Empty(t, result.Failed)
 }
 
+func TestSimpleApplySelected_InstallsOnlySelected(t *testing.T) {
+	ResetManagerCache()
+	t.Cleanup(ResetManagerCache)
+
+	tmpDir := t.TempDir()
+	writeLockFile(t, tmpDir, func(l *lock.LockV3) {
+		l.AddPackage("brew", "fd")
+		l.AddPackage("brew", "jq")
+		l.AddPackage("npm", "typescript")
+	})
+
+	mgr := &stubManager{installed: map[string]bool{}}
+	setCachedManager("brew", mgr)
+
+	result, err := SimpleApplySelected(context.Background(), tmpDir, false, map[string]bool{"brew:jq": true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"brew:jq"}, result.Installed)
+	assert.Equal(t, []string{"jq"}, mgr.installedNow)
+	assert.Empty(t, result.Failed, "unselected packages are not checked")
+}
+
 func TestSimpleApply_ShortCircuitsOnIsInstalledFailure(t *testing.T) {
 	ResetManagerCache()
 	t.Cleanup(ResetManagerCache)
@@ -202,3 +223,70 @@ func TestSimpleApply_UnsupportedManagerFailsEachPackage(t *testing.T) {
 	require.Len(t, result.Errors, 2)
 	assert.Contains(t, result.Errors[0].Error()+result.Errors[1].Error(), "manager not available")
 }
+
+// batchManager is a stubManager that also installs in batches
+type batchManager struct {
+	stubManager
+	batches [][]string
+	batchE  error
+}
+
+func (b *batchManager) InstallBatch(_ context.Context, names []string) error {
+	b.batches = append(b.batches, names)
+	if b.batchE != nil {
+		return b.batchE
+	}
+	for _, name := range names {
+		b.installed[name] = true
+	}
+	return nil
+}
+
+func TestSimpleApply_BatchInstall(t *testing.T) {
+	ResetManagerCache()
+	t.Cleanup(ResetManagerCache)
+
+	tmpDir := t.TempDir()
+	writeLockFile(t, tmpDir, func(l *lock.LockV3) {
+		l.AddPackage("brew", "fd")
+		l.AddPackage("brew", "jq")
+		l.AddPackage("brew", "ripgrep")
+	})
+
+	mgr := &batchManager{stubManager: stubManager{installed: map[string]bool{"ripgrep": true}}}
+	setCachedManager("brew", mgr)
+
+	result, err := SimpleApply(context.Background(), tmpDir, false)
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"fd", "jq"}}, mgr.batches)
+	assert.Empty(t, mgr.installedNow, "no per-package installs when the batch succeeds")
+	assert.ElementsMatch(t, []string{"brew:fd", "brew:jq"}, result.Installed)
+	assert.ElementsMatch(t, []string{"brew:ripgrep"}, result.Skipped)
+}
+
+func TestSimpleApply_BatchFailureFallsBackToSingleInstalls(t *testing.T) {
+	ResetManagerCache()
+	t.Cleanup(ResetManagerCache)
+
+	tmpDir := t.TempDir()
+	writeLockFile(t, tmpDir, func(l *lock.LockV3) {
+		l.AddPackage("brew", "bad")
+		l.AddPackage("brew", "fd")
+	})
+
+	mgr := &batchManager{
+		stubManager: stubManager{
+			installed: map[string]bool{},
+			installE:  map[string]error{"bad": errors.New("no formula")},
+		},
+		batchE: errors.New("batch failed"),
+	}
+	setCachedManager("brew", mgr)
+
+	result, err := SimpleApply(context.Background(), tmpDir, false)
+	require.Error(t, err)
+	assert.Len(t, mgr.batches, 1)
+	assert.Equal(t, []string{"fd"}, mgr.installedNow)
+	assert.ElementsMatch(t, []string{"brew:fd"}, result.Installed)
+	assert.ElementsMatch(t, []string{"brew:bad"}, result.Failed)
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package packages
+
+import (
+	"fmt"
+	"runtime"
+	"slices"
+	"strings"
+)
+
+// UnavailableManagerError explains why a manager cannot be used on this
+// system and how to enable it. It is classified as ErrManagerUnavailable.
+type UnavailableManagerError struct {
+	Manager   string   `json:"manager" yaml:"manager"`
+	Reason    string   `json:"reason" yaml:"reason"`
+	Platforms []string `json:"platforms,omitempty" yaml:"platforms,omitempty"` // GOOS values the manager runs on, if restricted
+	Hint      string   `json:"hint,omitempty" yaml:"hint,omitempty"`
+}
+
+func (e *UnavailableManagerError) Error() string {
+	msg := fmt.Sprintf("%s: %s", e.Reason, e.Manager)
+	if len(e.Platforms) > 0 && !slices.Contains(e.Platforms, runtime.GOOS) {
+		msg += fmt.Sprintf(" (only available on %s, not %s)", strings.Join(e.Platforms, ", "), runtime.GOOS)
+	}
+	if e.Hint != "" {
+		msg += "; " + e.Hint
+	}
+	return msg
+}
+
+// Unwrap lets errors.Is match ErrManagerUnavailable
+func (e *UnavailableManagerError) Unwrap() error {
+	return ErrManagerUnavailable
+}
+
+// foreignManager describes a package manager plonk knows about but does not support
+type foreignManager struct {
+	platforms []string // GOOS values the manager runs on; empty means any
+	hint      string
+}
+
+// foreignManagers are managers users commonly reach for that plonk does not
+// drive. Naming them lets us suggest an alternative instead of "unsupported".
+var foreignManagers = map[string]foreignManager{
+	"apt":      {platforms: []string{"linux"}, hint: "system packages are out of scope; use brew:<package> (Homebrew works on Linux too)"},
+	"dnf":      {platforms: []string{"linux"}, hint: "system packages are out of scope; use brew:<package> (Homebrew works on Linux too)"},
+	"yum":      {platforms: []string{"linux"}, hint: "system packages are out of scope; use brew:<package> (Homebrew works on Linux too)"},
+	"pacman":   {platforms: []string{"linux"}, hint: "system packages are out of scope; use brew:<package> (Homebrew works on Linux too)"},
+	"snap":     {platforms: []string{"linux"}, hint: "use brew:<package> instead"},
+	"port":     {platforms: []string{"darwin"}, hint: "use brew:<package> instead"},
+	"mas":      {platforms: []string{"darwin"}, hint: "use brew:<cask> for GUI apps instead"},
+	"winget":   {platforms: []string{"windows"}, hint: "plonk supports macOS and Linux only"},
+	"scoop":    {platforms: []string{"windows"}, hint: "plonk supports macOS and Linux only"},
+	"choco":    {platforms: []string{"windows"}, hint: "plonk supports macOS and Linux only"},
+	"npm":      {hint: "use pnpm:<package> for global Node.js tools"},
+	"yarn":     {hint: "use pnpm:<package> for global Node.js tools"},
+	"bun":      {hint: "use pnpm:<package> for global Node.js tools"},
+	"pip":      {hint: "use uv:<package> for Python CLI tools"},
+	"pipx":     {hint: "use uv:<package> for Python CLI tools"},
+	"conda":    {hint: "use uv:<package> for Python CLI tools"},
+	"gem":      {hint: "use brew:<package> for Ruby-based tools"},
+	"composer": {hint: "use brew:<package> for PHP-based tools"},
+}
+
+// managerInstallHints tell users how to get a supported manager onto PATH
+var managerInstallHints = map[string]string{
+	"brew":  "install Homebrew from https://brew.sh",
+	"cargo": "install Rust via https://rustup.rs or brew install rust",
+	"go":    "install Go from https://go.dev/dl or brew install go",
+	"pnpm":  "install pnpm via https://pnpm.io/installation or brew install pnpm",
+	"uv":    "install uv via https://docs.astral.sh/uv or brew install uv",
+}
+
+// managerBrewFormulas are the Homebrew formulas that provide each manager,
+// used by clone to offer bootstrapping missing managers
+var managerBrewFormulas = map[string]string{
+	"cargo": "rust",
+	"go":    "go",
+	"pnpm":  "pnpm",
+	"uv":    "uv",
+}
+
+// ManagerBrewFormula returns the Homebrew formula that installs a manager,
+// or "" if brew can't install it
+func ManagerBrewFormula(name string) string {
+	return managerBrewFormulas[name]
+}
+
+// ManagerInstallHint returns instructions for installing a supported manager
+func ManagerInstallHint(name string) string {
+	if hint, ok := managerInstallHints[name]; ok {
+		return hint
+	}
+	return "see the manager's official documentation for installation instructions"
+}
+
+// IsKnownManager reports whether name is a supported manager or one plonk
+// recognizes as unsupported
+func IsKnownManager(name string) bool {
+	_, foreign := foreignManagers[name]
+	return foreign || IsSupportedManager(name)
+}
+
+// UnsupportedManagerError returns a descriptive error for a manager plonk
+// does not support, including OS compatibility for platform-specific ones
+func UnsupportedManagerError(name string) error {
+	fm, ok := foreignManagers[name]
+	if !ok {
+		hint := fmt.Sprintf("supported: %s", strings.Join(SupportedManagers, ", "))
+		if suggestion := suggestManager(name); suggestion != "" {
+			hint = fmt.Sprintf("did you mean %s? %s", suggestion, hint)
+		}
+		return &UnavailableManagerError{
+			Manager: name,
+			Reason:  "unsupported manager",
+			Hint:    hint,
+		}
+	}
+	return &UnavailableManagerError{
+		Manager:   name,
+		Reason:    "unsupported manager",
+		Platforms: fm.platforms,
+		Hint:      fm.hint,
+	}
+}
+
+// CheckManagerAvailable returns nil if name is a supported, enabled manager
+// whose binary is on PATH, and an UnavailableManagerError otherwise
+func CheckManagerAvailable(name string) error {
+	if IsManagerDisabled(name) {
+		return &UnavailableManagerError{
+			Manager: name,
+			Reason:  "manager disabled in plonk.yaml",
+			Hint:    "remove it from disabled_managers to use it",
+		}
+	}
+	if !slices.Contains(SupportedManagers, name) {
+		if PluginPath(name) != "" {
+			return nil
+		}
+		return UnsupportedManagerError(name)
+	}
+	if _, err := LookPath(name); err != nil {
+		return &UnavailableManagerError{
+			Manager: name,
+			Reason:  "manager not found on PATH",
+			Hint:    ManagerInstallHint(name),
+		}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package packages
+
+import (
+	"errors"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnsupportedManagerError_Foreign(t *testing.T) {
+	err := UnsupportedManagerError("npm")
+
+	var unavailable *UnavailableManagerError
+	require.True(t, errors.As(err, &unavailable))
+	assert.True(t, errors.Is(err, ErrManagerUnavailable))
+	assert.Equal(t, "npm", unavailable.Manager)
+	assert.Contains(t, err.Error(), "unsupported manager: npm")
+	assert.Contains(t, err.Error(), "pnpm:<package>")
+}
+
+func TestUnsupportedManagerError_PlatformSpecific(t *testing.T) {
+	err := UnsupportedManagerError("winget")
+	if runtime.GOOS == "windows" {
+		t.Skip("winget is native to windows")
+	}
+	assert.Contains(t, err.Error(), "only available on windows, not "+runtime.GOOS)
+}
+
+func TestUnsupportedManagerError_Unknown(t *testing.T) {
+	err := UnsupportedManagerError("bogomgr")
+	assert.Contains(t, err.Error(), "unsupported manager: bogomgr")
+	assert.Contains(t, err.Error(), "supported: brew, cargo, go, pnpm, uv")
+	assert.False(t, IsKnownManager("bogomgr"))
+}
+
+func TestCheckManagerAvailable_MissingBinary(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+
+	err := CheckManagerAvailable("cargo")
+	require.Error(t, err)
+	assert.Equal(t, ClassManagerUnavailable, ErrorClass(err))
+	assert.Contains(t, err.Error(), "rustup")
+}
+
+func TestParsePackageSpec_KnownUnsupportedManager(t *testing.T) {
+	_, _, err := ParsePackageSpec("apt:vim")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrManagerUnavailable))
+	assert.Contains(t, err.Error(), "brew:<package>")
+}
@@ -5,21 +5,27 @@ package packages
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os/exec"
 	"strings"
 	"sync"
+
+	"github.com/richhaase/plonk/internal/config"
 )
 
 // BrewSimple implements Manager for Homebrew
 type BrewSimple struct {
-	mu        sync.Mutex
-	installed map[string]bool
+	mu           sync.Mutex
+	installed    map[string]bool
+	dependencies map[string]bool // installed only as another formula's dependency
+	opts         config.BrewOptions
+	env          []string
 }
 
 // NewBrewSimple creates a new Homebrew manager
-func NewBrewSimple() *BrewSimple {
-	return &BrewSimple{}
+func NewBrewSimple(opts config.BrewOptions) *BrewSimple {
+	return &BrewSimple{opts: opts, env: ManagerEnv("brew", config.ManagersConfig{Brew: opts})}
 }
 
 // IsInstalled checks if a package is installed via brew
@@ -44,15 +50,103 @@ func (b *BrewSimple) IsInstalled(ctx context.Context, name string) (bool, error)
 	return b.installed[name] || b.installed[shortName], nil
 }
 
-// loadInstalled fetches all installed formulas and casks
+// loadInstalled fetches all installed formulae and casks with a single
+// `brew info --json=v2 --installed`, which also gives the tap-qualified
+// names, aliases and old names they can be tracked under. Homebrew versions
+// without it fall back to `brew list`.
 func (b *BrewSimple) loadInstalled(ctx context.Context) error {
+	cmd := managerCommand(ctx, b.env, "brew", "info", "--json=v2", "--installed")
+	output, err := cmd.Output()
+	if err == nil {
+		if installed, perr := parseBrewInstalled(output); perr == nil {
+			b.installed = installed
+			b.dependencies, _ = parseBrewDependencies(output)
+			return nil
+		}
+	}
+	return b.listInstalled(ctx)
+}
+
+// brewInstalledEntry holds the names an installed formula or cask answers to
+type brewInstalledEntry struct {
+	Name      string   `json:"name"`
+	FullName  string   `json:"full_name"`
+	Aliases   []string `json:"aliases"`
+	Oldnames  []string `json:"oldnames"`
+	Token     string   `json:"token"`
+	FullToken string   `json:"full_token"`
+	OldTokens []string `json:"old_tokens"`
+	Installed []struct {
+		InstalledAsDependency bool `json:"installed_as_dependency"`
+		InstalledOnRequest    bool `json:"installed_on_request"`
+	} `json:"installed"`
+}
+
+// parseBrewInstalled indexes `brew info --json=v2 --installed` output by
+// every name a package can be tracked under
+func parseBrewInstalled(data []byte) (map[string]bool, error) {
+	var info struct {
+		Formulae []brewInstalledEntry `json:"formulae"`
+		Casks    []brewInstalledEntry `json:"casks"`
+	}
+	if err := json.Unmarshal(data, &info); err != nil {
+		return nil, err
+	}
+
+	installed := make(map[string]bool)
+	add := func(names ...string) {
+		for _, name := range names {
+			if name != "" {
+				installed[name] = true
+			}
+		}
+	}
+	for _, f := range info.Formulae {
+		add(f.Name, f.FullName)
+		add(f.Aliases...)
+		add(f.Oldnames...)
+	}
+	for _, c := range info.Casks {
+		add(c.Token, c.FullToken)
+		add(c.OldTokens...)
+	}
+	return installed, nil
+}
+
+// parseBrewDependencies indexes the formulae in `brew info --json=v2
+// --installed` output that were installed only as dependencies, by every
+// name they can be tracked under. Casks are always installed on request.
+func parseBrewDependencies(data []byte) (map[string]bool, error) {
+	var info struct {
+		Formulae []brewInstalledEntry `json:"formulae"`
+	}
+	if err := json.Unmarshal(data, &info); err != nil {
+		return nil, err
+	}
+
+	dependencies := make(map[string]bool)
+	for _, f := range info.Formulae {
+		if len(f.Installed) == 0 || !f.Installed[0].InstalledAsDependency || f.Installed[0].InstalledOnRequest {
+			continue
+		}
+		for _, name := range append([]string{f.Name, f.FullName}, append(f.Aliases, f.Oldnames...)...) {
+			if name != "" {
+				dependencies[name] = true
+			}
+		}
+	}
+	return dependencies, nil
+}
+
+// listInstalled fetches installed formulae and casks with `brew list`
+func (b *BrewSimple) listInstalled(ctx context.Context) error {
 	installed := make(map[string]bool)
 
 	// Get formulas
-	cmd := exec.CommandContext(ctx, "brew", "list", "--formula", "-1")
+	cmd := managerCommand(ctx, b.env, "brew", "list", "--formula", "-1")
 	output, err := cmd.Output()
 	if err != nil {
-		return fmt.Errorf("failed to list brew formulas: %w", err)
+		return newPackageError(ctx, "brew", "", nil, fmt.Errorf("failed to list brew formulas: %w", err))
 	}
 	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
 		if line != "" {
@@ -61,7 +155,7 @@ func (b *BrewSimple) loadInstalled(ctx context.Context) error {
 	}
 
 	// Get casks — failure is non-fatal (cask support may be unavailable, e.g., on Linux)
-	cmd = exec.CommandContext(ctx, "brew", "list", "--cask", "-1")
+	cmd = managerCommand(ctx, b.env, "brew", "list", "--cask", "-1")
 	output, err = cmd.Output()
 	if err == nil {
 		for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
@@ -78,15 +172,14 @@ func (b *BrewSimple) loadInstalled(ctx context.Context) error {
 
 // Install installs a package via brew
 func (b *BrewSimple) Install(ctx context.Context, name string) error {
-	cmd := exec.CommandContext(ctx, "brew", "install", "--", name)
-	output, err := cmd.CombinedOutput()
+	output, err := runInstallCommand(b.installCommand(ctx, name), "brew:"+name)
 	if err != nil {
 		// Check if already installed (idempotent)
 		if strings.Contains(strings.ToLower(string(output)), "already installed") {
 			b.markInstalled(name)
 			return nil
 		}
-		return fmt.Errorf("brew install %s: %s: %w", name, strings.TrimSpace(string(output)), err)
+		return newPackageError(ctx, "brew", name, output, fmt.Errorf("brew install %s: %s: %w", name, strings.TrimSpace(string(output)), err))
 	}
 
 	// Update cache after successful install
@@ -94,6 +187,60 @@ func (b *BrewSimple) Install(ctx context.Context, name string) error {
 	return nil
 }
 
+// InstallBatch installs several packages with a single brew install.
+// Formulae with their own build options or install arguments are installed
+// one at a time.
+func (b *BrewSimple) InstallBatch(ctx context.Context, names []string) error {
+	var plain []string
+	for _, name := range names {
+		_, hasOptions := b.opts.Packages[name]
+		if !hasOptions && len(b.opts.PackageArgs[name]) == 0 {
+			plain = append(plain, name)
+		} else if err := b.Install(ctx, name); err != nil {
+			return err
+		}
+	}
+	if len(plain) == 0 {
+		return nil
+	}
+	names = plain
+
+	output, err := runInstallCommand(b.installCommand(ctx, names...), "brew")
+	if err != nil {
+		list := strings.Join(names, " ")
+		return newPackageError(ctx, "brew", "", output, fmt.Errorf("brew install %s: %s: %w", list, strings.TrimSpace(string(output)), err))
+	}
+	for _, name := range names {
+		b.markInstalled(name)
+	}
+	return nil
+}
+
+// installCommand builds brew install for names with the configured
+// options. A single formula also gets its per-package build options and
+// install arguments.
+func (b *BrewSimple) installCommand(ctx context.Context, names ...string) *exec.Cmd {
+	args := append([]string{"install"}, b.opts.InstallArgs...)
+	if len(names) == 1 {
+		args = append(args, brewPackageArgs(b.opts.Packages[names[0]])...)
+		args = append(args, b.opts.PackageArgs[names[0]]...)
+	}
+	args = append(append(args, "--"), names...)
+	return managerCommand(ctx, b.env, "brew", args...)
+}
+
+// brewPackageArgs returns the brew install flags for one formula's options
+func brewPackageArgs(opts config.BrewPackageOptions) []string {
+	var args []string
+	if opts.Head {
+		args = append(args, "--HEAD")
+	}
+	if opts.BuildFromSource {
+		args = append(args, "--build-from-source")
+	}
+	return append(args, opts.Options...)
+}
+
 // markInstalled updates the cache to mark a package as installed
 func (b *BrewSimple) markInstalled(name string) {
 	b.mu.Lock()
@@ -105,4 +252,7 @@ func (b *BrewSimple) markInstalled(name string) {
 			b.installed[name[idx+1:]] = true
 		}
 	}
+	// brew install marks a formula installed on request
+	delete(b.dependencies, name)
+	delete(b.dependencies, name[strings.LastIndex(name, "/")+1:])
 }
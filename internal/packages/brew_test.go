@@ -0,0 +1,83 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package packages
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/richhaase/plonk/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBrewInstalled(t *testing.T) {
+	data := []byte(`{
+  "formulae": [
+    {"name": "python@3.12", "full_name": "python@3.12", "aliases": ["python3", "python"], "oldnames": []},
+    {"name": "httpie", "full_name": "httpie", "aliases": [], "oldnames": ["http"]},
+    {"name": "terraform", "full_name": "hashicorp/tap/terraform", "aliases": [], "oldnames": []}
+  ],
+  "casks": [
+    {"token": "visual-studio-code", "full_token": "visual-studio-code", "old_tokens": ["vscode"]},
+    {"token": "font-hack-nerd-font", "full_token": "homebrew/cask-fonts/font-hack-nerd-font", "old_tokens": []}
+  ]
+}`)
+
+	installed, err := parseBrewInstalled(data)
+	require.NoError(t, err)
+	for _, name := range []string{"python@3.12", "python3", "http", "hashicorp/tap/terraform", "terraform", "vscode", "homebrew/cask-fonts/font-hack-nerd-font"} {
+		assert.True(t, installed[name], name)
+	}
+	assert.False(t, installed["jq"])
+	assert.False(t, installed[""])
+
+	_, err = parseBrewInstalled([]byte("Error: unknown flag"))
+	assert.Error(t, err)
+}
+
+func TestParseBrewDependencies(t *testing.T) {
+	data := []byte(`{
+  "formulae": [
+    {"name": "ripgrep", "full_name": "ripgrep", "installed": [{"installed_as_dependency": false, "installed_on_request": true}]},
+    {"name": "openssl@3", "full_name": "openssl@3", "aliases": ["openssl"], "installed": [{"installed_as_dependency": true, "installed_on_request": false}]},
+    {"name": "pcre2", "full_name": "pcre2", "installed": [{"installed_as_dependency": true, "installed_on_request": true}]}
+  ],
+  "casks": [
+    {"token": "firefox", "full_token": "firefox"}
+  ]
+}`)
+
+	dependencies, err := parseBrewDependencies(data)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"openssl@3": true, "openssl": true}, dependencies)
+}
+
+func TestBrewIsInstalled_FallsBackToList(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as brew")
+	}
+	dir := t.TempDir()
+	// An old brew: info --installed fails, list works
+	script := `#!/bin/sh
+case "$1 $2" in
+  "info --json=v2") echo "Error: invalid option: --installed" >&2; exit 1 ;;
+  "list --formula") echo jq ;;
+  "list --cask") echo firefox ;;
+esac
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "brew"), []byte(script), 0755))
+	t.Setenv("PATH", dir)
+
+	b := NewBrewSimple(config.BrewOptions{})
+	ctx := context.Background()
+	for name, want := range map[string]bool{"jq": true, "firefox": true, "homebrew/core/jq": true, "fd": false} {
+		got, err := b.IsInstalled(ctx, name)
+		require.NoError(t, err)
+		assert.Equal(t, want, got, name)
+	}
+}
@@ -0,0 +1,352 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package packages
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// A cache archive holds the download caches of the built-in managers, so a
+// machine without internet access can install from what another machine
+// downloaded. Entries are stored as <manager>/<cache>/<path>, and each
+// cache is extracted into the matching directory on the importing machine,
+// wherever the manager keeps it there.
+
+// CacheDir is one download cache of a manager
+type CacheDir struct {
+	Manager string
+	Name    string // e.g. "download" for go's module downloads
+	Path    string
+}
+
+// CacheSummary counts what an export or import moved, per manager
+type CacheSummary struct {
+	Files   map[string]int
+	Bytes   map[string]int64
+	Skipped []string // entries not extracted, with the reason
+}
+
+func newCacheSummary() CacheSummary {
+	return CacheSummary{Files: make(map[string]int), Bytes: make(map[string]int64)}
+}
+
+// CacheDirs returns the download caches of a built-in manager on this
+// machine, whether or not they exist yet. Plugin managers have none.
+func CacheDirs(ctx context.Context, manager string) ([]CacheDir, error) {
+	managerMu.Lock()
+	env := ManagerEnv(manager, managerOptions)
+	managerMu.Unlock()
+
+	var dirs []CacheDir
+	switch manager {
+	case "brew":
+		path, err := cacheCommandPath(ctx, env, "brew", "--cache")
+		if err != nil {
+			return nil, err
+		}
+		dirs = append(dirs, CacheDir{Name: "cache", Path: path})
+	case "cargo":
+		home := cargoHome(env)
+		if home == "" {
+			return nil, fmt.Errorf("cannot determine CARGO_HOME")
+		}
+		// The sparse index entries let cargo resolve versions offline
+		dirs = append(dirs,
+			CacheDir{Name: "index", Path: filepath.Join(home, "registry", "index")},
+			CacheDir{Name: "crates", Path: filepath.Join(home, "registry", "cache")})
+	case "go":
+		modCache := goModCache(env)
+		if modCache == "" {
+			return nil, fmt.Errorf("cannot determine GOMODCACHE")
+		}
+		dirs = append(dirs, CacheDir{Name: "download", Path: filepath.Join(modCache, "cache", "download")})
+	case "pnpm":
+		path, err := cacheCommandPath(ctx, env, "pnpm", "store", "path")
+		if err != nil {
+			return nil, err
+		}
+		dirs = append(dirs, CacheDir{Name: "store", Path: path})
+	case "uv":
+		path, err := cacheCommandPath(ctx, env, "uv", "cache", "dir")
+		if err != nil {
+			return nil, err
+		}
+		dirs = append(dirs, CacheDir{Name: "cache", Path: path})
+	default:
+		return nil, nil
+	}
+	for i := range dirs {
+		dirs[i].Manager = manager
+	}
+	return dirs, nil
+}
+
+// cacheCommandPath asks a manager where its cache is
+func cacheCommandPath(ctx context.Context, env []string, name string, args ...string) (string, error) {
+	out, err := managerCommand(ctx, env, name, args...).Output()
+	if err != nil {
+		return "", fmt.Errorf("%s %s: %w", name, strings.Join(args, " "), err)
+	}
+	path := strings.TrimSpace(string(out))
+	if path == "" {
+		return "", fmt.Errorf("%s %s printed no path", name, strings.Join(args, " "))
+	}
+	return path, nil
+}
+
+// cargoHome returns where cargo keeps its registry
+func cargoHome(env []string) string {
+	if home := lookupManagerEnv(env, "CARGO_HOME"); home != "" {
+		return home
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".cargo")
+}
+
+// goModCache returns the module cache go uses, as go env GOMODCACHE would
+func goModCache(env []string) string {
+	if modCache := lookupManagerEnv(env, "GOMODCACHE"); modCache != "" {
+		return modCache
+	}
+	gopath := lookupManagerEnv(env, "GOPATH")
+	if gopath == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		gopath = filepath.Join(home, "go")
+	}
+	paths := filepath.SplitList(gopath)
+	if len(paths) == 0 || paths[0] == "" {
+		return ""
+	}
+	return filepath.Join(paths[0], "pkg", "mod")
+}
+
+// offlineEnv returns the variables that make a manager install only from
+// its local cache
+func offlineEnv(manager string, env map[string]string) map[string]string {
+	offline := make(map[string]string)
+	switch manager {
+	case "brew":
+		offline["HOMEBREW_NO_AUTO_UPDATE"] = "1"
+	case "cargo":
+		offline["CARGO_NET_OFFLINE"] = "true"
+	case "go":
+		// The module cache's download directory is laid out as a module
+		// proxy, so go can resolve @latest from it
+		if modCache := goModCache(envPairs(env)); modCache != "" {
+			offline["GOPROXY"] = "file://" + filepath.ToSlash(filepath.Join(modCache, "cache", "download"))
+		} else {
+			offline["GOPROXY"] = "off"
+		}
+		offline["GOSUMDB"] = "off"
+	case "pnpm":
+		offline["npm_config_offline"] = "true"
+	case "uv":
+		offline["UV_OFFLINE"] = "1"
+	}
+	return offline
+}
+
+// ExportCaches writes the caches of managers that exist on this machine to
+// w as a gzipped tar archive
+func ExportCaches(ctx context.Context, w io.Writer, managers []string) (CacheSummary, error) {
+	summary := newCacheSummary()
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	for _, manager := range managers {
+		dirs, err := CacheDirs(ctx, manager)
+		if err != nil {
+			summary.Skipped = append(summary.Skipped, fmt.Sprintf("%s: %v", manager, err))
+			continue
+		}
+		for _, dir := range dirs {
+			if err := exportCacheDir(ctx, tw, dir, &summary); err != nil {
+				return summary, err
+			}
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return summary, err
+	}
+	return summary, gz.Close()
+}
+
+// exportCacheDir adds the regular files under one cache to the archive
+func exportCacheDir(ctx context.Context, tw *tar.Writer, dir CacheDir, summary *CacheSummary) error {
+	if _, err := os.Stat(dir.Path); os.IsNotExist(err) {
+		return nil
+	}
+	prefix := dir.Manager + "/" + dir.Name
+	return filepath.WalkDir(dir.Path, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir.Path, path)
+		if err != nil {
+			return err
+		}
+
+		header := &tar.Header{
+			Name:    prefix + "/" + filepath.ToSlash(rel),
+			Mode:    int64(info.Mode().Perm()),
+			Size:    info.Size(),
+			ModTime: info.ModTime(),
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		if _, err := io.Copy(tw, f); err != nil {
+			return fmt.Errorf("failed to archive %s: %w", path, err)
+		}
+		summary.Files[dir.Manager]++
+		summary.Bytes[dir.Manager] += info.Size()
+		return nil
+	})
+}
+
+// ImportCaches extracts a cache archive into this machine's manager caches.
+// Files that already exist are kept, and entries for managers or caches
+// this machine doesn't know are skipped.
+func ImportCaches(ctx context.Context, r io.Reader) (CacheSummary, error) {
+	summary := newCacheSummary()
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return summary, fmt.Errorf("not a plonk cache archive: %w", err)
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+
+	targets := make(map[string]string) // manager/cache -> local directory
+	unknown := make(map[string]bool)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return summary, fmt.Errorf("failed to read cache archive: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		parts := strings.SplitN(header.Name, "/", 3)
+		if len(parts) != 3 || !filepath.IsLocal(filepath.FromSlash(parts[2])) {
+			summary.Skipped = append(summary.Skipped, fmt.Sprintf("%s: unsafe path", header.Name))
+			continue
+		}
+		manager, key := parts[0], parts[0]+"/"+parts[1]
+		root, ok := targets[key]
+		if !ok && !unknown[key] {
+			root = importTarget(ctx, manager, parts[1])
+			if root == "" {
+				unknown[key] = true
+				summary.Skipped = append(summary.Skipped, fmt.Sprintf("%s: no such cache on this machine", key))
+			} else {
+				targets[key] = root
+			}
+		}
+		if root == "" {
+			continue
+		}
+
+		path := filepath.Join(root, filepath.FromSlash(parts[2]))
+		if _, err := os.Lstat(path); err == nil {
+			continue
+		}
+		if err := extractCacheFile(tr, path, header); err != nil {
+			return summary, err
+		}
+		summary.Files[manager]++
+		summary.Bytes[manager] += header.Size
+	}
+	return summary, nil
+}
+
+// importTarget returns the local directory for one cache in an archive
+func importTarget(ctx context.Context, manager, name string) string {
+	if !slices.Contains(SupportedManagers, manager) {
+		return ""
+	}
+	dirs, err := CacheDirs(ctx, manager)
+	if err != nil {
+		return ""
+	}
+	for _, dir := range dirs {
+		if dir.Name == name {
+			return dir.Path
+		}
+	}
+	return ""
+}
+
+// extractCacheFile writes one archived file, going through a temporary
+// file so an interrupted import leaves no partial downloads behind
+func extractCacheFile(tr *tar.Reader, path string, header *tar.Header) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmpPath := path + ".plonk.tmp"
+	f, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, fs.FileMode(header.Mode).Perm()|0o200)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, tr); err != nil {
+		f.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to extract %s: %w", header.Name, err)
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Chtimes(tmpPath, header.ModTime, header.ModTime); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// CacheManagers returns the built-in managers among those tracked, which
+// are the ones with caches to export
+func CacheManagers(tracked []string) []string {
+	var managers []string
+	for _, manager := range tracked {
+		if slices.Contains(SupportedManagers, manager) {
+			managers = append(managers, manager)
+		}
+	}
+	return managers
+}
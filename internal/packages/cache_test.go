@@ -0,0 +1,88 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package packages
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/richhaase/plonk/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportImportCaches(t *testing.T) {
+	ctx := context.Background()
+	source := t.TempDir()
+	t.Setenv("GOMODCACHE", filepath.Join(source, "mod"))
+	t.Setenv("CARGO_HOME", filepath.Join(source, "cargo"))
+
+	writeFile := func(path, content string) {
+		t.Helper()
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	writeFile(filepath.Join(source, "mod", "cache", "download", "golang.org", "x", "tools", "@v", "list"), "v0.1.0\n")
+	writeFile(filepath.Join(source, "mod", "golang.org", "x", "tools@v0.1.0", "go.mod"), "extracted sources are not exported")
+	writeFile(filepath.Join(source, "cargo", "registry", "cache", "index.crates.io-1", "ripgrep-14.1.0.crate"), "crate")
+
+	var archive bytes.Buffer
+	summary, err := ExportCaches(ctx, &archive, []string{"cargo", "go"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"cargo": 1, "go": 1}, summary.Files)
+
+	target := t.TempDir()
+	t.Setenv("GOMODCACHE", filepath.Join(target, "mod"))
+	t.Setenv("CARGO_HOME", filepath.Join(target, "cargo"))
+	kept := filepath.Join(target, "cargo", "registry", "cache", "index.crates.io-1", "ripgrep-14.1.0.crate")
+	writeFile(kept, "already here")
+
+	summary, err = ImportCaches(ctx, bytes.NewReader(archive.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"go": 1}, summary.Files)
+
+	data, err := os.ReadFile(filepath.Join(target, "mod", "cache", "download", "golang.org", "x", "tools", "@v", "list"))
+	require.NoError(t, err)
+	assert.Equal(t, "v0.1.0\n", string(data))
+	data, err = os.ReadFile(kept)
+	require.NoError(t, err)
+	assert.Equal(t, "already here", string(data), "existing cache files are kept")
+	assert.NoDirExists(t, filepath.Join(target, "mod", "golang.org"))
+}
+
+func TestImportCaches_SkipsUnsafeAndUnknownEntries(t *testing.T) {
+	t.Setenv("GOMODCACHE", t.TempDir())
+
+	var archive bytes.Buffer
+	gz := gzip.NewWriter(&archive)
+	tw := tar.NewWriter(gz)
+	for _, name := range []string{"go/download/../../escape", "apt/cache/pkg.deb", "go/download/ok"} {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: 2, Typeflag: tar.TypeReg}))
+		_, err := tw.Write([]byte("ok"))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+
+	summary, err := ImportCaches(context.Background(), &archive)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"go": 1}, summary.Files)
+	assert.Equal(t, []string{"go/download/../../escape: unsafe path", "apt/cache: no such cache on this machine"}, summary.Skipped)
+}
+
+func TestManagerEnv_Offline(t *testing.T) {
+	t.Setenv("GOMODCACHE", "/cache/mod")
+	opts := config.ManagersConfig{Offline: true}
+
+	assert.True(t, slices.Contains(ManagerEnv("cargo", opts), "CARGO_NET_OFFLINE=true"))
+	assert.True(t, slices.Contains(ManagerEnv("uv", opts), "UV_OFFLINE=1"))
+	assert.Equal(t, []string{"GOPROXY=file:///cache/mod/cache/download", "GOSUMDB=off"}, ManagerEnv("go", opts))
+	assert.Empty(t, ManagerEnv("go", config.ManagersConfig{}))
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package packages
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Capability is something plonk can do with some managers but not others.
+// Most are read off the optional interfaces a manager implements, so the
+// list stays accurate as managers gain them.
+type Capability struct {
+	Name        string
+	Description string
+	supports    func(name string, mgr Manager) bool
+}
+
+// implements reports whether mgr satisfies the interface T
+func implements[T any](_ string, mgr Manager) bool {
+	_, ok := mgr.(T)
+	return ok
+}
+
+// Capabilities lists what plonk can do with a manager beyond installing
+var Capabilities = []Capability{
+	{"batch", "installs several packages in one command", implements[BatchInstaller]},
+	{"pinning", "versions in the tracked name, e.g. go:...@v1.2.3 or uv:ruff>=0.5", func(name string, _ Manager) bool {
+		return name == "go" || name == "uv"
+	}},
+	{"install-args", "install arguments per package, after -- on plonk track", func(name string, _ Manager) bool {
+		return slices.Contains(SupportedManagers, name)
+	}},
+	{"uninstall", "plonk dedupe names the command that removes a package", func(name string, _ Manager) bool {
+		_, ok := uninstallCommands[name]
+		return ok
+	}},
+	{"renames", "plonk fix --renames finds renamed and retired packages", implements[RenameDetector]},
+	{"licenses", "plonk licenses reads declared licenses", implements[LicenseReporter]},
+	{"dependencies", "tells packages installed on request from their dependencies", implements[DependencyReporter]},
+	{"suggestions", "suggests installed packages for mistyped names", implements[InstalledLister]},
+	{"bootstrap", "plonk clone offers to install the manager itself with brew", func(name string, _ Manager) bool {
+		return ManagerBrewFormula(name) != ""
+	}},
+}
+
+// ManagerCapabilities returns the names of the capabilities manager has,
+// in the order of Capabilities. An unknown manager has none.
+func ManagerCapabilities(manager string) []string {
+	mgr, err := GetManager(manager)
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, c := range Capabilities {
+		if c.supports(manager, mgr) {
+			names = append(names, c.Name)
+		}
+	}
+	return names
+}
+
+// managerVersionArgs make each built-in manager print its version
+var managerVersionArgs = map[string][]string{
+	"brew":  {"--version"},
+	"cargo": {"--version"},
+	"go":    {"version"},
+	"pnpm":  {"--version"},
+	"uv":    {"--version"},
+}
+
+// versionPattern finds a version number in a manager's version output,
+// e.g. 1.23.1 in "go version go1.23.1 darwin/arm64"
+var versionPattern = regexp.MustCompile(`\d+\.\d+[0-9A-Za-z.+-]*`)
+
+// ManagerVersion returns the version of an available manager, as it reports
+// it. Plugins answer with the version from their info operation.
+func ManagerVersion(ctx context.Context, manager string) (string, error) {
+	mgr, err := GetManager(manager)
+	if err != nil {
+		return "", err
+	}
+	if plugin, ok := mgr.(*PluginManager); ok {
+		info, err := plugin.Info(ctx)
+		if err != nil {
+			return "", err
+		}
+		return info.Version, nil
+	}
+
+	line, err := ManagerVersionLine(ctx, manager)
+	if err != nil {
+		return "", err
+	}
+	return parseManagerVersion(line), nil
+}
+
+// ManagerVersionLine returns the first line a built-in manager prints for
+// its version, e.g. "go version go1.23.1 darwin/arm64". The answer is kept
+// with the executable lookups (see LookPath) until the binary changes, so
+// repeated runs don't start the manager again.
+func ManagerVersionLine(ctx context.Context, manager string) (string, error) {
+	args, ok := managerVersionArgs[manager]
+	if !ok {
+		return "", fmt.Errorf("%s is not a built-in manager", manager)
+	}
+	return cachedVersionLine(manager, func() (string, error) {
+		managerMu.Lock()
+		env := ManagerEnv(manager, managerOptions)
+		managerMu.Unlock()
+		out, err := managerCommand(ctx, env, manager, args...).Output()
+		if err != nil {
+			return "", fmt.Errorf("%s %s: %w", manager, strings.Join(args, " "), err)
+		}
+		line, _, _ := strings.Cut(strings.TrimSpace(string(out)), "\n")
+		return strings.TrimSpace(line), nil
+	})
+}
+
+// parseManagerVersion picks the version out of the first line of a
+// manager's version output, or returns the line when it has no number
+func parseManagerVersion(out string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(out), "\n")
+	if v := versionPattern.FindString(line); v != "" {
+		return v
+	}
+	return strings.TrimSpace(line)
+}
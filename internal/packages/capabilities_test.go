@@ -0,0 +1,33 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package packages
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManagerCapabilities(t *testing.T) {
+	ResetManagerCache()
+	assert.Equal(t, []string{"batch", "install-args", "uninstall", "renames", "licenses", "dependencies", "suggestions"}, ManagerCapabilities("brew"))
+	assert.Equal(t, []string{"pinning", "install-args", "bootstrap"}, ManagerCapabilities("go"))
+	assert.Contains(t, ManagerCapabilities("uv"), "pinning")
+	assert.NotContains(t, ManagerCapabilities("uv"), "batch")
+	assert.Nil(t, ManagerCapabilities("apt"))
+}
+
+func TestParseManagerVersion(t *testing.T) {
+	tests := map[string]string{
+		"Homebrew 4.3.5\nHomebrew/homebrew-core (git revision 1a2b)\n": "4.3.5",
+		"cargo 1.80.0 (376290515 2024-07-16)\n":                        "1.80.0",
+		"go version go1.23.1 darwin/arm64\n":                           "1.23.1",
+		"9.6.0\n":                                                      "9.6.0",
+		"uv 0.4.18 (7b55e9790 2024-10-01)\n":                           "0.4.18",
+		"dev build\n":                                                  "dev build",
+	}
+	for out, want := range tests {
+		assert.Equal(t, want, parseManagerVersion(out), out)
+	}
+}
@@ -9,17 +9,22 @@ import (
 	"os/exec"
 	"strings"
 	"sync"
+
+	"github.com/richhaase/plonk/internal/config"
 )
 
-// CargoSimple implements Manager for Rust's Cargo
+// CargoSimple implements Manager for Rust's Cargo. Packages are crates.io
+// crates, or git+ and path+ sources (see crateSource).
 type CargoSimple struct {
 	mu        sync.Mutex
-	installed map[string]bool
+	installed map[string]string // crate -> source, "" for crates.io
+	opts      config.CargoOptions
+	env       []string
 }
 
 // NewCargoSimple creates a new Cargo manager
-func NewCargoSimple() *CargoSimple {
-	return &CargoSimple{}
+func NewCargoSimple(opts config.CargoOptions) *CargoSimple {
+	return &CargoSimple{opts: opts, env: ManagerEnv("cargo", config.ManagersConfig{Cargo: opts})}
 }
 
 // IsInstalled checks if a package is installed via cargo
@@ -34,43 +39,62 @@ func (c *CargoSimple) IsInstalled(ctx context.Context, name string) (bool, error
 		}
 	}
 
-	return c.installed[name], nil
+	if source, ok := parseCrateSource(name); ok {
+		if _, marked := c.installed[name]; marked {
+			return true, nil
+		}
+		for crate, from := range c.installed {
+			if source.matches(crate, from) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	_, ok := c.installed[name]
+	return ok, nil
 }
 
 // loadInstalled fetches all installed cargo packages
 func (c *CargoSimple) loadInstalled(ctx context.Context) error {
-	installed := make(map[string]bool)
-
-	cmd := exec.CommandContext(ctx, "cargo", "install", "--list")
+	cmd := managerCommand(ctx, c.env, "cargo", "install", "--list")
 	output, err := cmd.Output()
 	if err != nil {
-		return fmt.Errorf("failed to list cargo packages: %w", err)
+		return newPackageError(ctx, "cargo", "", nil, fmt.Errorf("failed to list cargo packages: %w", err))
 	}
 
-	// Parse output: each installed package starts at column 0
-	// Format: "package_name v1.2.3:\n    binary1\n"
-	// Skip indented lines (binary names) and non-package lines (e.g., "warning:")
-	lines := strings.Split(string(output), "\n")
-	for _, line := range lines {
+	// Only set the cache after successful loading
+	c.installed = parseCargoList(output)
+	return nil
+}
+
+// parseCargoList reads `cargo install --list` output. Each installed
+// package starts at column 0 as "name v1.2.3:", or "name v1.2.3 (source):"
+// when it came from git or a path, followed by indented binary names.
+// Non-package lines (e.g., "warning:") are skipped.
+func parseCargoList(output []byte) map[string]string {
+	installed := make(map[string]string)
+	for _, line := range strings.Split(string(output), "\n") {
 		if strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") {
 			continue
 		}
 		fields := strings.Fields(line)
 		// Package lines have at least 2 fields: name and version (e.g., "ripgrep v14.1.1:")
-		if len(fields) >= 2 && strings.HasPrefix(fields[1], "v") {
-			installed[fields[0]] = true
+		if len(fields) < 2 || !strings.HasPrefix(fields[1], "v") {
+			continue
 		}
+		var source string
+		if open, end := strings.Index(line, " ("), strings.LastIndex(line, ")"); open >= 0 && end > open {
+			source = line[open+2 : end]
+		}
+		installed[fields[0]] = source
 	}
-
-	// Only set the cache after successful loading
-	c.installed = installed
-	return nil
+	return installed
 }
 
 // Install installs a package via cargo
 func (c *CargoSimple) Install(ctx context.Context, name string) error {
-	cmd := exec.CommandContext(ctx, "cargo", "install", "--", name)
-	output, err := cmd.CombinedOutput()
+	output, err := runInstallCommand(c.installCommand(ctx, name), "cargo:"+name)
 	if err != nil {
 		// Check if already installed (idempotent)
 		outStr := strings.ToLower(string(output))
@@ -78,7 +102,7 @@ func (c *CargoSimple) Install(ctx context.Context, name string) error {
 			c.markInstalled(name)
 			return nil
 		}
-		return fmt.Errorf("cargo install %s: %s: %w", name, strings.TrimSpace(string(output)), err)
+		return newPackageError(ctx, "cargo", name, output, fmt.Errorf("cargo install %s: %s: %w", name, strings.TrimSpace(string(output)), err))
 	}
 
 	// Update cache after successful install
@@ -86,11 +110,65 @@ func (c *CargoSimple) Install(ctx context.Context, name string) error {
 	return nil
 }
 
+// InstallBatch installs several crates with a single cargo install. git
+// and path sources, and crates with their own install arguments, can't
+// share a command and are installed one at a time.
+func (c *CargoSimple) InstallBatch(ctx context.Context, names []string) error {
+	var crates []string
+	for _, name := range names {
+		if _, ok := parseCrateSource(name); !ok && len(c.opts.PackageArgs[name]) == 0 {
+			crates = append(crates, name)
+		} else if err := c.Install(ctx, name); err != nil {
+			return err
+		}
+	}
+	if len(crates) == 0 {
+		return nil
+	}
+	names = crates
+
+	output, err := runInstallCommand(c.installCommand(ctx, names...), "cargo")
+	if err != nil {
+		list := strings.Join(names, " ")
+		return newPackageError(ctx, "cargo", "", output, fmt.Errorf("cargo install %s: %s: %w", list, strings.TrimSpace(string(output)), err))
+	}
+	for _, name := range names {
+		c.markInstalled(name)
+	}
+	return nil
+}
+
+// batchable reports whether InstallBatch can be used: cargo refuses
+// --features when installing more than one crate
+func (c *CargoSimple) batchable() bool {
+	return len(c.opts.Features) == 0
+}
+
+// installCommand builds cargo install for names with the configured options
+func (c *CargoSimple) installCommand(ctx context.Context, names ...string) *exec.Cmd {
+	args := []string{"install"}
+	if c.opts.Locked {
+		args = append(args, "--locked")
+	}
+	if len(c.opts.Features) > 0 {
+		args = append(args, "--features", strings.Join(c.opts.Features, ","))
+	}
+	args = append(args, c.opts.InstallArgs...)
+	if len(names) == 1 {
+		args = append(args, c.opts.PackageArgs[names[0]]...)
+		if source, ok := parseCrateSource(names[0]); ok {
+			return managerCommand(ctx, c.env, "cargo", append(args, source.args()...)...)
+		}
+	}
+	args = append(args, "--")
+	return managerCommand(ctx, c.env, "cargo", append(args, names...)...)
+}
+
 // markInstalled updates the cache to mark a package as installed
 func (c *CargoSimple) markInstalled(name string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	if c.installed != nil {
-		c.installed[name] = true
+		c.installed[name] = ""
 	}
 }
@@ -0,0 +1,134 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package packages
+
+import (
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// crateSource is a cargo package installed from a git repository or a
+// local path instead of crates.io. In plonk.lock it is written the way
+// cargo records sources, optionally prefixed with the crate to pick from
+// a workspace:
+//
+//	git+https://github.com/owner/tool?rev=4f2a1c9
+//	tool@git+https://github.com/owner/workspace?tag=v1.2.0
+//	path+~/src/tool
+type crateSource struct {
+	Crate  string // crate to install; "" if the source has only one
+	Git    string // repository URL, without query
+	Rev    string
+	Tag    string
+	Branch string
+	Path   string // local path, with ~ expanded
+}
+
+// parseCrateSource parses a git+ or path+ cargo package; plain crate names
+// report false
+func parseCrateSource(name string) (crateSource, bool) {
+	var s crateSource
+	rest := name
+	if crate, after, ok := strings.Cut(name, "@"); ok && (strings.HasPrefix(after, "git+") || strings.HasPrefix(after, "path+")) {
+		s.Crate, rest = crate, after
+	}
+
+	switch {
+	case strings.HasPrefix(rest, "git+"):
+		u, err := url.Parse(strings.TrimPrefix(rest, "git+"))
+		if err != nil || u.Host == "" {
+			return crateSource{}, false
+		}
+		query := u.Query()
+		s.Rev, s.Tag, s.Branch = query.Get("rev"), query.Get("tag"), query.Get("branch")
+		u.RawQuery, u.Fragment = "", ""
+		s.Git = u.String()
+	case strings.HasPrefix(rest, "path+"):
+		s.Path = strings.TrimPrefix(rest, "path+")
+		if after, ok := strings.CutPrefix(s.Path, "~/"); ok {
+			if home, err := os.UserHomeDir(); err == nil {
+				s.Path = filepath.Join(home, after)
+			}
+		}
+		if s.Path == "" {
+			return crateSource{}, false
+		}
+	default:
+		return crateSource{}, false
+	}
+	return s, true
+}
+
+// args returns the cargo install arguments for the source
+func (s crateSource) args() []string {
+	var args []string
+	if s.Path != "" {
+		args = []string{"--path", s.Path}
+	} else {
+		args = []string{"--git", s.Git}
+		switch {
+		case s.Rev != "":
+			args = append(args, "--rev", s.Rev)
+		case s.Tag != "":
+			args = append(args, "--tag", s.Tag)
+		case s.Branch != "":
+			args = append(args, "--branch", s.Branch)
+		}
+	}
+	if s.Crate != "" {
+		args = append(args, "--", s.Crate)
+	}
+	return args
+}
+
+// name guesses the installed crate's name: the one given, or the last
+// element of the repository URL or path
+func (s crateSource) name() string {
+	if s.Crate != "" {
+		return s.Crate
+	}
+	if s.Path != "" {
+		return filepath.Base(s.Path)
+	}
+	return strings.TrimSuffix(path.Base(s.Git), ".git")
+}
+
+// matches reports whether a crate listed by `cargo install --list` with the
+// given source was installed from s. Git sources look like
+// https://github.com/owner/tool?rev=4f2a1c9#4f2a1c9e...; the revision
+// matches the full commit hash after # or the requested rev.
+func (s crateSource) matches(crate, source string) bool {
+	if s.Crate != "" && crate != s.Crate {
+		return false
+	}
+	if s.Path != "" {
+		source = strings.TrimPrefix(strings.TrimPrefix(source, "path+"), "file://")
+		return source != "" && filepath.Clean(source) == filepath.Clean(s.Path)
+	}
+
+	base, commit, _ := strings.Cut(strings.TrimPrefix(source, "git+"), "#")
+	base, rawQuery, _ := strings.Cut(base, "?")
+	if normalizeRepoURL(base) != normalizeRepoURL(s.Git) {
+		return false
+	}
+	query, _ := url.ParseQuery(rawQuery)
+	switch {
+	case s.Rev != "":
+		return strings.HasPrefix(commit, s.Rev) || query.Get("rev") == s.Rev
+	case s.Tag != "":
+		return query.Get("tag") == s.Tag
+	case s.Branch != "":
+		return query.Get("branch") == s.Branch
+	}
+	return true
+}
+
+// normalizeRepoURL drops the differences cargo ignores when comparing
+// repository URLs
+func normalizeRepoURL(u string) string {
+	return strings.ToLower(strings.TrimSuffix(strings.TrimSuffix(u, "/"), ".git"))
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package packages
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCrateSource(t *testing.T) {
+	home, err := os.UserHomeDir()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		want crateSource
+		args []string
+	}{
+		{
+			name: "git+https://github.com/owner/tool?rev=4f2a1c9",
+			want: crateSource{Git: "https://github.com/owner/tool", Rev: "4f2a1c9"},
+			args: []string{"--git", "https://github.com/owner/tool", "--rev", "4f2a1c9"},
+		},
+		{
+			name: "cli@git+https://github.com/owner/workspace.git?tag=v1.2.0",
+			want: crateSource{Crate: "cli", Git: "https://github.com/owner/workspace.git", Tag: "v1.2.0"},
+			args: []string{"--git", "https://github.com/owner/workspace.git", "--tag", "v1.2.0", "--", "cli"},
+		},
+		{
+			name: "git+ssh://git@github.com/owner/tool?branch=main",
+			want: crateSource{Git: "ssh://git@github.com/owner/tool", Branch: "main"},
+			args: []string{"--git", "ssh://git@github.com/owner/tool", "--branch", "main"},
+		},
+		{
+			name: "path+~/src/tool",
+			want: crateSource{Path: filepath.Join(home, "src/tool")},
+			args: []string{"--path", filepath.Join(home, "src/tool")},
+		},
+	}
+	for _, tt := range tests {
+		got, ok := parseCrateSource(tt.name)
+		require.True(t, ok, tt.name)
+		assert.Equal(t, tt.want, got, tt.name)
+		assert.Equal(t, tt.args, got.args(), tt.name)
+	}
+
+	for _, name := range []string{"ripgrep", "git+not a url", "path+"} {
+		_, ok := parseCrateSource(name)
+		assert.False(t, ok, name)
+	}
+}
+
+func TestCrateSourceMatches(t *testing.T) {
+	git, _ := parseCrateSource("git+https://github.com/owner/tool?rev=4f2a1c9")
+	assert.True(t, git.matches("tool", "https://github.com/owner/tool?rev=4f2a1c9#4f2a1c9e0b"))
+	assert.True(t, git.matches("tool", "https://github.com/owner/tool.git#4f2a1c9e0b"))
+	assert.False(t, git.matches("tool", "https://github.com/owner/tool#0000000"))
+	assert.False(t, git.matches("tool", "https://github.com/other/tool#4f2a1c9e0b"))
+	assert.False(t, git.matches("tool", ""))
+
+	crate, _ := parseCrateSource("cli@git+https://github.com/owner/ws?tag=v1")
+	assert.True(t, crate.matches("cli", "https://github.com/owner/ws?tag=v1#abc"))
+	assert.False(t, crate.matches("core", "https://github.com/owner/ws?tag=v1#abc"))
+
+	local, _ := parseCrateSource("path+/src/tool")
+	assert.True(t, local.matches("tool", "/src/tool/"))
+	assert.False(t, local.matches("tool", ""))
+
+	assert.Equal(t, "tool", git.name())
+	assert.Equal(t, "cli", crate.name())
+}
+
+func TestParseCargoList(t *testing.T) {
+	out := []byte(`ripgrep v14.1.1:
+    rg
+tool v0.3.0 (https://github.com/owner/tool?rev=4f2a1c9#4f2a1c9e0b):
+    tool
+warning: something
+`)
+	assert.Equal(t, map[string]string{
+		"ripgrep": "",
+		"tool":    "https://github.com/owner/tool?rev=4f2a1c9#4f2a1c9e0b",
+	}, parseCargoList(out))
+}
+
+func TestCargoIsInstalled_Source(t *testing.T) {
+	c := &CargoSimple{installed: map[string]string{
+		"ripgrep": "",
+		"tool":    "https://github.com/owner/tool?rev=4f2a1c9#4f2a1c9e0b",
+	}}
+	ctx := context.Background()
+
+	for name, want := range map[string]bool{
+		"ripgrep": true,
+		"tool":    true,
+		"git+https://github.com/owner/tool?rev=4f2a1c9": true,
+		"git+https://github.com/owner/tool?rev=1234567": false,
+		"git+https://github.com/owner/other":            false,
+	} {
+		got, err := c.IsInstalled(ctx, name)
+		require.NoError(t, err)
+		assert.Equal(t, want, got, name)
+	}
+
+	c.markInstalled("git+https://github.com/owner/other")
+	got, _ := c.IsInstalled(ctx, "git+https://github.com/owner/other")
+	assert.True(t, got)
+}
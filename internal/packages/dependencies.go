@@ -0,0 +1,54 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package packages
+
+import (
+	"context"
+	"strings"
+)
+
+// DependencyReporter is implemented by managers that record whether a
+// package was asked for or only pulled in as another package's dependency
+type DependencyReporter interface {
+	InstalledAsDependency(ctx context.Context, names []string) ([]string, error)
+}
+
+// DetectDependencies returns which of names manager installed only as a
+// dependency. plonk tracks them, but cleanups such as 'brew autoremove'
+// uninstall them once nothing depends on them. Managers that cannot tell,
+// or are unavailable, report none.
+func DetectDependencies(ctx context.Context, manager string, names []string) ([]string, error) {
+	if len(names) == 0 || CheckManagerAvailable(manager) != nil {
+		return nil, nil
+	}
+	mgr, err := GetManager(manager)
+	if err != nil {
+		return nil, nil
+	}
+	reporter, ok := mgr.(DependencyReporter)
+	if !ok {
+		return nil, nil
+	}
+	return reporter.InstalledAsDependency(ctx, names)
+}
+
+// InstalledAsDependency returns the names of installed formulae that brew
+// installed for another formula rather than on request
+func (b *BrewSimple) InstalledAsDependency(ctx context.Context, names []string) ([]string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.installed == nil {
+		if err := b.loadInstalled(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	var dependencies []string
+	for _, name := range names {
+		if b.dependencies[name] || b.dependencies[name[strings.LastIndex(name, "/")+1:]] {
+			dependencies = append(dependencies, name)
+		}
+	}
+	return dependencies, nil
+}
@@ -0,0 +1,204 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package packages
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/richhaase/plonk/internal/lock"
+)
+
+// Duplicate is a tracked package that is also installed by other managers
+type Duplicate struct {
+	Tracked string   // "brew:ripgrep"
+	Also    []string // other installed copies, e.g. "cargo:ripgrep"
+	Binary  string   // command looked up on PATH
+	OnPath  string   // where Binary resolves, "" if not found
+	Winner  string   // the copy that OnPath belongs to, "" if unknown
+}
+
+// knownBinaries maps packages whose command differs from the package name
+var knownBinaries = map[string]string{
+	"bottom":    "btm",
+	"du-dust":   "dust",
+	"fd-find":   "fd",
+	"git-delta": "delta",
+	"neovim":    "nvim",
+	"ripgrep":   "rg",
+	"tealdeer":  "tldr",
+}
+
+// uninstallCommands is how each built-in manager removes a package; plonk
+// itself never uninstalls, so these are shown for the user to run
+var uninstallCommands = map[string]string{
+	"brew":  "brew uninstall",
+	"cargo": "cargo uninstall",
+	"pnpm":  "pnpm remove -g",
+	"uv":    "uv tool uninstall",
+}
+
+// UninstallCommand returns the command that removes spec with its manager,
+// or "" when there is no single command (go binaries are deleted from the
+// go bin directory, plugins have their own)
+func UninstallCommand(spec string) string {
+	manager, name, _ := strings.Cut(spec, ":")
+	if manager == "go" {
+		if dir := goBinDir(nil); dir != "" {
+			return "rm " + filepath.Join(dir, binaryName(manager, name))
+		}
+	}
+	if manager == "uv" {
+		if version, ok := PythonVersion(name); ok {
+			return "uv python uninstall " + version
+		}
+		name = parseRequirement(name).Name
+	}
+	if source, ok := parseCrateSource(name); ok && manager == "cargo" {
+		name = source.name()
+	}
+	if cmd, ok := uninstallCommands[manager]; ok {
+		return cmd + " " + name
+	}
+	return ""
+}
+
+// FindDuplicates reports tracked packages that another manager has also
+// installed: the same name under another built-in manager, or an
+// equivalent from plonk.yaml aliases. Unavailable and disabled managers are
+// not asked, and a manager that fails to answer counts as not having the
+// package. Each set of copies is reported once.
+func FindDuplicates(ctx context.Context, lockFile *lock.LockV3) []Duplicate {
+	var dups []Duplicate
+	reported := make(map[string]bool)
+
+	for _, manager := range sortedManagers(lockFile.Packages) {
+		for _, name := range lockFile.Packages[manager] {
+			tracked := manager + ":" + name
+			if reported[tracked] {
+				continue
+			}
+
+			var also []string
+			for _, candidate := range duplicateCandidates(manager, name) {
+				if installed, _ := specInstalled(ctx, candidate); installed {
+					also = append(also, candidate)
+				}
+			}
+			if len(also) == 0 {
+				continue
+			}
+
+			if installed, _ := specInstalled(ctx, tracked); !installed {
+				continue
+			}
+
+			reported[tracked] = true
+			for _, spec := range also {
+				reported[spec] = true
+			}
+			dup := Duplicate{Tracked: tracked, Also: also, Binary: binaryName(manager, name)}
+			dup.OnPath, dup.Winner = pathWinner(dup.Binary, append([]string{tracked}, also...))
+			dups = append(dups, dup)
+		}
+	}
+	return dups
+}
+
+// duplicateCandidates lists the other specs that would provide the same
+// tool as manager:name
+func duplicateCandidates(manager, name string) []string {
+	candidates := Equivalents(manager + ":" + name)
+	if manager == "uv" {
+		name = parseRequirement(name).Name
+	}
+	if manager == "go" || strings.HasPrefix(name, "@") || strings.Contains(name, "/") {
+		return candidates
+	}
+	for _, other := range SupportedManagers {
+		spec := other + ":" + name
+		if other != manager && other != "go" && !slices.Contains(candidates, spec) {
+			candidates = append(candidates, spec)
+		}
+	}
+	return slices.DeleteFunc(candidates, func(spec string) bool {
+		other, _, _ := strings.Cut(spec, ":")
+		return CheckManagerAvailable(other) != nil
+	})
+}
+
+// binaryName guesses the command a package installs
+func binaryName(manager, name string) string {
+	switch manager {
+	case "go":
+		name, _, _ = strings.Cut(name, "@")
+	case "uv":
+		name = parseRequirement(name).Name
+	case "cargo":
+		if source, ok := parseCrateSource(name); ok {
+			name = source.name()
+		}
+	}
+	name = name[strings.LastIndex(name, "/")+1:]
+	if bin, ok := knownBinaries[name]; ok {
+		return bin
+	}
+	return name
+}
+
+// pathWinner resolves binary on PATH and guesses which of specs installed
+// the copy found there from the directory it lives in
+func pathWinner(binary string, specs []string) (onPath, winner string) {
+	path, err := exec.LookPath(binary)
+	if err != nil {
+		return "", ""
+	}
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		resolved = path
+	}
+	for _, spec := range specs {
+		manager, _, _ := strings.Cut(spec, ":")
+		if ownsPath(manager, path) || ownsPath(manager, resolved) {
+			return path, spec
+		}
+	}
+	return path, ""
+}
+
+// ownsPath reports whether path lies where manager installs binaries
+func ownsPath(manager, path string) bool {
+	path = filepath.ToSlash(path)
+	switch manager {
+	case "brew":
+		return strings.Contains(path, "/Cellar/") || strings.Contains(path, "/Caskroom/") ||
+			strings.HasPrefix(path, "/opt/homebrew/") || strings.Contains(path, "/linuxbrew/")
+	case "cargo":
+		cargoHome := os.Getenv("CARGO_HOME")
+		return strings.Contains(path, "/.cargo/bin/") || (cargoHome != "" && strings.HasPrefix(path, filepath.ToSlash(cargoHome)+"/"))
+	case "go":
+		dir := goBinDir(nil)
+		return dir != "" && strings.HasPrefix(path, filepath.ToSlash(dir)+"/")
+	case "uv":
+		return strings.Contains(path, "/uv/tools/")
+	case "pnpm":
+		pnpmHome := os.Getenv("PNPM_HOME")
+		return strings.Contains(path, "/pnpm/") || (pnpmHome != "" && strings.HasPrefix(path, filepath.ToSlash(pnpmHome)+"/"))
+	}
+	return false
+}
+
+func sortedManagers(pkgs map[string][]string) []string {
+	managers := make([]string, 0, len(pkgs))
+	for manager := range pkgs {
+		managers = append(managers, manager)
+	}
+	sort.Strings(managers)
+	return managers
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package packages
+
+import (
+	"context"
+	"testing"
+
+	"github.com/richhaase/plonk/internal/lock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindDuplicates(t *testing.T) {
+	ResetManagerCache()
+	t.Cleanup(ResetManagerCache)
+	t.Cleanup(func() { SetAliases(nil) })
+	fakePlugin(t, "fake")
+	fakePlugin(t, "other")
+
+	l := lock.NewLockV3()
+	l.AddPackage("fake", "alpha")
+	l.AddPackage("fake", "beta")
+	l.AddPackage("other", "alpha")
+
+	// Without an alias, plugin packages are never compared by name
+	assert.Empty(t, FindDuplicates(context.Background(), l))
+
+	SetAliases(map[string][]string{
+		"a": {"fake:alpha", "other:alpha"},
+		"b": {"fake:beta", "other:beta"},
+	})
+	dups := FindDuplicates(context.Background(), l)
+	require.Len(t, dups, 1, "beta is only installed once; other:alpha is reported with fake:alpha")
+	assert.Equal(t, "fake:alpha", dups[0].Tracked)
+	assert.Equal(t, []string{"other:alpha"}, dups[0].Also)
+	assert.Equal(t, "alpha", dups[0].Binary)
+	assert.Empty(t, dups[0].Winner)
+}
+
+func TestBinaryName(t *testing.T) {
+	assert.Equal(t, "rg", binaryName("brew", "ripgrep"))
+	assert.Equal(t, "jq", binaryName("brew", "jq"))
+	assert.Equal(t, "terraform", binaryName("brew", "hashicorp/tap/terraform"))
+	assert.Equal(t, "gopls", binaryName("go", "golang.org/x/tools/gopls@latest"))
+	assert.Equal(t, "fd", binaryName("cargo", "fd-find"))
+	assert.Equal(t, "ansible", binaryName("uv", "ansible[azure]>=9,<10"))
+}
+
+func TestOwnsPath(t *testing.T) {
+	t.Setenv("GOBIN", "/home/u/gobin")
+	t.Setenv("CARGO_HOME", "")
+	assert.True(t, ownsPath("brew", "/opt/homebrew/bin/rg"))
+	assert.True(t, ownsPath("brew", "/usr/local/Cellar/ripgrep/14.1.0/bin/rg"))
+	assert.True(t, ownsPath("cargo", "/home/u/.cargo/bin/rg"))
+	assert.True(t, ownsPath("go", "/home/u/gobin/gopls"))
+	assert.True(t, ownsPath("uv", "/home/u/.local/share/uv/tools/ruff/bin/ruff"))
+	assert.False(t, ownsPath("brew", "/home/u/.cargo/bin/rg"))
+	assert.False(t, ownsPath("fake", "/usr/bin/rg"))
+}
+
+func TestUninstallCommand(t *testing.T) {
+	t.Setenv("GOBIN", "/home/u/gobin")
+	assert.Equal(t, "brew uninstall ripgrep", UninstallCommand("brew:ripgrep"))
+	assert.Equal(t, "cargo uninstall ripgrep", UninstallCommand("cargo:ripgrep"))
+	assert.Equal(t, "uv python uninstall 3.12", UninstallCommand("uv:python@3.12"))
+	assert.Equal(t, "uv tool uninstall ansible", UninstallCommand("uv:ansible[azure]>=9,<10"))
+	assert.Equal(t, "cargo uninstall tool", UninstallCommand("cargo:git+https://github.com/owner/tool?rev=abc"))
+	assert.Equal(t, "rm /home/u/gobin/gopls", UninstallCommand("go:golang.org/x/tools/gopls"))
+	assert.Empty(t, UninstallCommand("fake:alpha"))
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package packages
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+
+	"github.com/richhaase/plonk/internal/config"
+)
+
+// ManagerEnv returns the variables plonk sets on subprocesses of the named
+// manager, as sorted NAME=value pairs with $VAR references expanded. It
+// includes settings that map to variables, such as brew's no_auto_update
+// and the proxy, which an explicit env entry overrides, and in offline mode
+// the variables that keep the manager to its local cache.
+func ManagerEnv(name string, opts config.ManagersConfig) []string {
+	env := make(map[string]string)
+	proxy := opts.ProxyFor(name)
+	// Tools disagree on the case they read, so both are set
+	for _, v := range []struct{ key, value string }{
+		{"HTTP_PROXY", proxy.HTTP},
+		{"HTTPS_PROXY", proxy.HTTPS},
+		{"NO_PROXY", strings.Join(proxy.NoProxy, ",")},
+	} {
+		if v.value != "" {
+			env[v.key] = os.ExpandEnv(v.value)
+			env[strings.ToLower(v.key)] = env[v.key]
+		}
+	}
+	for key, value := range opts.Common(name).Env {
+		env[key] = os.ExpandEnv(value)
+	}
+	if name == "brew" && opts.Brew.NoAutoUpdate {
+		env["HOMEBREW_NO_AUTO_UPDATE"] = "1"
+	}
+	if opts.Offline {
+		for key, value := range offlineEnv(name, env) {
+			env[key] = value
+		}
+	}
+	return envPairs(env)
+}
+
+// envPairs returns variables as sorted NAME=value pairs
+func envPairs(env map[string]string) []string {
+	pairs := make([]string, 0, len(env))
+	for key, value := range env {
+		pairs = append(pairs, key+"="+value)
+	}
+	slices.Sort(pairs)
+	return pairs
+}
+
+// managerCommand builds a subprocess for a manager with its configured
+// environment layered over plonk's own
+func managerCommand(ctx context.Context, env []string, name string, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, name, args...)
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+	return cmd
+}
+
+// lookupManagerEnv returns a variable as a manager subprocess would see it
+func lookupManagerEnv(env []string, key string) string {
+	for i := len(env) - 1; i >= 0; i-- {
+		if value, ok := strings.CutPrefix(env[i], key+"="); ok {
+			return value
+		}
+	}
+	return os.Getenv(key)
+}
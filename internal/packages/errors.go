@@ -0,0 +1,210 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package packages
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os/exec"
+	"strings"
+)
+
+// Error classes shared by all package managers. Callers branch on them with
+// errors.Is; ErrorClass maps them to stable names for structured output.
+var (
+	ErrNotFound           = errors.New("package not found")
+	ErrPermission         = errors.New("permission denied")
+	ErrNetwork            = errors.New("network error")
+	ErrManagerUnavailable = errors.New("package manager unavailable")
+	ErrTimeout            = errors.New("operation timed out")
+)
+
+// Error class names used in JSON/YAML output
+const (
+	ClassNotFound           = "not_found"
+	ClassPermission         = "permission"
+	ClassNetwork            = "network"
+	ClassManagerUnavailable = "manager_unavailable"
+	ClassTimeout            = "timeout"
+	ClassUnknown            = "unknown"
+)
+
+// PackageError is a classified package manager failure. Its message is the
+// underlying error's; Class is one of the Err* sentinels (or nil if unknown).
+type PackageError struct {
+	Class   error
+	Manager string
+	Package string
+	Err     error
+}
+
+func (e *PackageError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap exposes both the error class and the underlying error to errors.Is/As
+func (e *PackageError) Unwrap() []error {
+	if e.Class == nil {
+		return []error{e.Err}
+	}
+	return []error{e.Class, e.Err}
+}
+
+// CommandError is a manager command that failed, with everything it printed
+type CommandError struct {
+	Args   []string
+	Output []byte // stdout and stderr, interleaved as the manager wrote them
+	Err    error
+}
+
+func (e *CommandError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *CommandError) Unwrap() error {
+	return e.Err
+}
+
+// FailedCommand returns the manager command behind err, quoted for a shell,
+// and the last lines it printed. Both are empty if err didn't come from a
+// command.
+func FailedCommand(err error, lines int) (command, tail string) {
+	var cmdErr *CommandError
+	if !errors.As(err, &cmdErr) {
+		return "", ""
+	}
+	quoted := make([]string, len(cmdErr.Args))
+	for i, arg := range cmdErr.Args {
+		quoted[i] = shellQuote(arg)
+	}
+	output := strings.Split(strings.TrimRight(string(cmdErr.Output), "\n"), "\n")
+	if len(output) > lines {
+		output = output[len(output)-lines:]
+	}
+	return strings.Join(quoted, " "), strings.Join(output, "\n")
+}
+
+// shellQuote quotes s for a POSIX shell when it needs it
+func shellQuote(s string) string {
+	if s != "" && !strings.ContainsFunc(s, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_./:=@+%,", r))
+	}) {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// newPackageError classifies err using the context state, the error chain and
+// the command output, and wraps it in a PackageError
+func newPackageError(ctx context.Context, manager, pkg string, output []byte, err error) error {
+	return &PackageError{
+		Class:   classifyError(ctx, output, err),
+		Manager: manager,
+		Package: pkg,
+		Err:     err,
+	}
+}
+
+// Lowercase substrings of manager output used to classify failures
+var (
+	notFoundMarkers = []string{
+		"no available formula",
+		"no available cask",
+		"no formulae or casks found",
+		"could not find",
+		"no matching package",
+		"not found in registry",
+		"404 not found",
+		"err_pnpm_fetch_404",
+		"no solution found",
+		"cannot find module",
+		"unknown revision",
+	}
+	permissionMarkers = []string{
+		"permission denied",
+		"operation not permitted",
+		"eacces",
+		"eperm",
+	}
+	networkMarkers = []string{
+		"could not resolve",
+		"connection refused",
+		"connection reset",
+		"network is unreachable",
+		"failed to download",
+		"failed to fetch",
+		"tls handshake",
+		"i/o timeout",
+		"enotfound",
+		"econnreset",
+		"etimedout",
+	}
+)
+
+// classifyError returns the sentinel that best describes err, or nil
+func classifyError(ctx context.Context, output []byte, err error) error {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded) || errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return ErrTimeout
+	case errors.Is(err, exec.ErrNotFound):
+		return ErrManagerUnavailable
+	case errors.Is(err, fs.ErrPermission):
+		return ErrPermission
+	}
+
+	text := strings.ToLower(string(output))
+	if text == "" && err != nil {
+		text = strings.ToLower(err.Error())
+	}
+	switch {
+	case containsAny(text, permissionMarkers):
+		return ErrPermission
+	case containsAny(text, networkMarkers):
+		return ErrNetwork
+	case containsAny(text, notFoundMarkers):
+		return ErrNotFound
+	}
+	return nil
+}
+
+// ErrorClass returns the stable class name for err, or "" for a nil error.
+// Errors that were not classified by a manager are classified from their chain.
+func ErrorClass(err error) string {
+	if err == nil {
+		return ""
+	}
+	for _, class := range []error{ErrTimeout, ErrManagerUnavailable, ErrPermission, ErrNetwork, ErrNotFound} {
+		if errors.Is(err, class) {
+			return className(class)
+		}
+	}
+	return className(classifyError(context.Background(), nil, err))
+}
+
+// classNames maps error class sentinels to their output names
+var classNames = map[error]string{
+	ErrTimeout:            ClassTimeout,
+	ErrManagerUnavailable: ClassManagerUnavailable,
+	ErrPermission:         ClassPermission,
+	ErrNetwork:            ClassNetwork,
+	ErrNotFound:           ClassNotFound,
+}
+
+// className maps an error class sentinel to its output name
+func className(class error) string {
+	if name, ok := classNames[class]; ok {
+		return name
+	}
+	return ClassUnknown
+}
+
+func containsAny(s string, markers []string) bool {
+	for _, m := range markers {
+		if strings.Contains(s, m) {
+			return true
+		}
+	}
+	return false
+}
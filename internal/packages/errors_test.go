@@ -0,0 +1,105 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package packages
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/richhaase/plonk/internal/lock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyError(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name   string
+		output string
+		err    error
+		want   error
+	}{
+		{"missing binary", "", &exec.Error{Name: "brew", Err: exec.ErrNotFound}, ErrManagerUnavailable},
+		{"deadline", "", context.DeadlineExceeded, ErrTimeout},
+		{"fs permission", "", &os.PathError{Op: "open", Path: "/x", Err: os.ErrPermission}, ErrPermission},
+		{"brew unknown formula", "Error: No available formula with the name \"nope\".", errors.New("exit status 1"), ErrNotFound},
+		{"cargo unknown crate", "error: could not find `nope` in registry `crates-io`", errors.New("exit status 101"), ErrNotFound},
+		{"pnpm 404", "ERR_PNPM_FETCH_404  GET https://registry.npmjs.org/nope: Not Found - 404", errors.New("exit status 1"), ErrNotFound},
+		{"permission denied output", "Error: Permission denied @ dir_s_mkdir - /usr/local/Cellar", errors.New("exit status 1"), ErrPermission},
+		{"network output", "curl: (6) Could not resolve host: ghcr.io", errors.New("exit status 1"), ErrNetwork},
+		{"unclassified", "something odd happened", errors.New("exit status 1"), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, classifyError(ctx, []byte(tt.output), tt.err))
+		})
+	}
+}
+
+func TestClassifyError_ExpiredContext(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 0)
+	defer cancel()
+	<-ctx.Done()
+
+	// A killed subprocess reports "signal: killed"; the context explains why
+	assert.Equal(t, ErrTimeout, classifyError(ctx, nil, errors.New("signal: killed")))
+}
+
+func TestPackageError_Unwrap(t *testing.T) {
+	cause := errors.New("brew install nope: exit status 1")
+	err := newPackageError(context.Background(), "brew", "nope", []byte("No available formula"), cause)
+
+	assert.True(t, errors.Is(err, ErrNotFound))
+	assert.True(t, errors.Is(err, cause))
+	assert.Equal(t, cause.Error(), err.Error())
+
+	var pkgErr *PackageError
+	assert.True(t, errors.As(fmt.Errorf("brew:nope: %w", err), &pkgErr))
+	assert.Equal(t, "brew", pkgErr.Manager)
+	assert.Equal(t, "nope", pkgErr.Package)
+}
+
+func TestFailedCommand(t *testing.T) {
+	_, err := runInstallCommand(exec.Command("sh", "-c", "printf 'one\\ntwo\\nthree\\n'; exit 1"), "brew:x")
+	wrapped := newPackageError(context.Background(), "brew", "x", nil, fmt.Errorf("brew install x: %w", err))
+
+	command, tail := FailedCommand(wrapped, 2)
+	assert.Equal(t, `sh -c 'printf '\''one\ntwo\nthree\n'\''; exit 1'`, command)
+	assert.Equal(t, "two\nthree", tail)
+
+	var exitErr *exec.ExitError
+	assert.True(t, errors.As(wrapped, &exitErr), "the exit status is still reachable")
+
+	command, tail = FailedCommand(errors.New("no command"), 2)
+	assert.Empty(t, command)
+	assert.Empty(t, tail)
+}
+
+func TestErrorClass(t *testing.T) {
+	assert.Equal(t, "", ErrorClass(nil))
+	assert.Equal(t, ClassNotFound, ErrorClass(fmt.Errorf("wrapped: %w", ErrNotFound)))
+	assert.Equal(t, ClassTimeout, ErrorClass(fmt.Errorf("wrapped: %w", context.DeadlineExceeded)))
+	assert.Equal(t, ClassManagerUnavailable, ErrorClass(&exec.Error{Name: "uv", Err: exec.ErrNotFound}))
+	assert.Equal(t, ClassUnknown, ErrorClass(errors.New("boom")))
+}
+
+func TestSimpleApply_UnsupportedManagerIsClassified(t *testing.T) {
+	ResetManagerCache()
+	t.Cleanup(ResetManagerCache)
+
+	configDir := t.TempDir()
+	writeLockFile(t, configDir, func(l *lock.LockV3) {
+		l.AddPackage("nosuch", "thing")
+	})
+
+	result, err := SimpleApply(context.Background(), configDir, false)
+	assert.Error(t, err)
+	if assert.Len(t, result.Errors, 1) {
+		assert.Equal(t, ClassManagerUnavailable, ErrorClass(result.Errors[0]))
+	}
+}
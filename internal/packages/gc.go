@@ -0,0 +1,126 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package packages
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// cacheCleanup is how a built-in manager drops downloads and old versions
+// it no longer needs
+type cacheCleanup struct {
+	args     []string // the cleanup command
+	requires string   // a separate tool the command needs, if any
+}
+
+var cacheCleanups = map[string]cacheCleanup{
+	"brew":  {args: []string{"brew", "cleanup"}}, // old versions and stale downloads
+	"cargo": {args: []string{"cargo", "cache", "--autoclean"}, requires: "cargo-cache"},
+	"go":    {args: []string{"go", "clean", "-cache"}}, // the build cache; modules are kept for plonk cache export
+	"pnpm":  {args: []string{"pnpm", "store", "prune"}},
+	"uv":    {args: []string{"uv", "cache", "prune"}},
+}
+
+// CleanupCommand returns the command that cleans manager's caches, or nil
+// for managers plonk can't clean
+func CleanupCommand(manager string) []string {
+	return cacheCleanups[manager].args
+}
+
+// CheckCleanupAvailable returns why manager's cleanup can't run on this
+// machine, or nil
+func CheckCleanupAvailable(manager string) error {
+	cleanup, ok := cacheCleanups[manager]
+	if !ok {
+		return fmt.Errorf("%s has no cleanup command", manager)
+	}
+	if err := CheckManagerAvailable(manager); err != nil {
+		return err
+	}
+	if cleanup.requires != "" {
+		if _, err := exec.LookPath(cleanup.requires); err != nil {
+			return fmt.Errorf("needs %s (cargo install %s)", cleanup.requires, cleanup.requires)
+		}
+	}
+	return nil
+}
+
+// CleanCache runs manager's cleanup command and returns how many bytes it
+// freed in the directories the cleanup works on
+func CleanCache(ctx context.Context, manager string) (int64, error) {
+	if err := CheckCleanupAvailable(manager); err != nil {
+		return 0, err
+	}
+	cleanup := cacheCleanups[manager]
+
+	managerMu.Lock()
+	env := ManagerEnv(manager, managerOptions)
+	managerMu.Unlock()
+
+	dirs := cleanupDirs(ctx, manager, env)
+	before := dirsSize(dirs)
+	cmd := managerCommand(ctx, env, cleanup.args[0], cleanup.args[1:]...)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return 0, fmt.Errorf("%s: %w\n%s", strings.Join(cleanup.args, " "), err, strings.TrimSpace(string(out)))
+	}
+	return max(before-dirsSize(dirs), 0), nil
+}
+
+// cleanupDirs returns the directories a manager's cleanup removes files
+// from. A directory that can't be found is left out, so its space is not
+// counted.
+func cleanupDirs(ctx context.Context, manager string, env []string) []string {
+	var dirs []string
+	add := func(path string, err error) {
+		if err == nil {
+			dirs = append(dirs, path)
+		}
+	}
+	switch manager {
+	case "brew":
+		add(cacheCommandPath(ctx, env, "brew", "--cache"))
+		add(cacheCommandPath(ctx, env, "brew", "--cellar"))
+	case "cargo":
+		if home := cargoHome(env); home != "" {
+			dirs = append(dirs, filepath.Join(home, "registry"), filepath.Join(home, "git"))
+		}
+	case "go":
+		add(cacheCommandPath(ctx, env, "go", "env", "GOCACHE"))
+	case "pnpm":
+		add(cacheCommandPath(ctx, env, "pnpm", "store", "path"))
+	case "uv":
+		add(cacheCommandPath(ctx, env, "uv", "cache", "dir"))
+	}
+	return dirs
+}
+
+// dirsSize returns the total size of the regular files under dirs
+func dirsSize(dirs []string) int64 {
+	var total int64
+	for _, dir := range dirs {
+		total += dirSize(dir)
+	}
+	return total
+}
+
+// dirSize returns the total size of the regular files under path.
+// Unreadable entries are skipped.
+func dirSize(path string) int64 {
+	var total int64
+	_ = filepath.WalkDir(path, func(_ string, entry fs.DirEntry, err error) error {
+		if err != nil || !entry.Type().IsRegular() {
+			return nil
+		}
+		if info, err := entry.Info(); err == nil {
+			total += info.Size()
+		}
+		return nil
+	})
+	return total
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package packages
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCleanCache(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as uv")
+	}
+	dir := t.TempDir()
+	cache := filepath.Join(dir, "cache")
+	require.NoError(t, os.MkdirAll(filepath.Join(cache, "wheels"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(cache, "wheels", "stale.whl"), make([]byte, 3000), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(cache, "kept"), make([]byte, 100), 0o644))
+
+	script := `#!/bin/sh
+case "$1 $2" in
+  "cache dir") echo ` + cache + ` ;;
+  "cache prune") /bin/rm -r ` + filepath.Join(cache, "wheels") + ` ;;
+  *) exit 1 ;;
+esac
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "uv"), []byte(script), 0o755))
+	t.Setenv("PATH", dir)
+
+	freed, err := CleanCache(context.Background(), "uv")
+	require.NoError(t, err)
+	assert.Equal(t, int64(3000), freed)
+	assert.FileExists(t, filepath.Join(cache, "kept"))
+
+	assert.Error(t, CheckCleanupAvailable("cargo"), "cargo is not on PATH")
+	assert.Error(t, CheckCleanupAvailable("npm"), "no cleanup for plugins")
+	assert.Nil(t, CleanupCommand("npm"))
+}
@@ -7,21 +7,24 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 	"sync"
+
+	"github.com/richhaase/plonk/internal/config"
 )
 
 // GoSimple implements Manager for Go packages
 type GoSimple struct {
 	mu        sync.Mutex
 	installed map[string]bool
+	opts      config.GoOptions
+	env       []string
 }
 
 // NewGoSimple creates a new Go manager
-func NewGoSimple() *GoSimple {
-	return &GoSimple{}
+func NewGoSimple(opts config.GoOptions) *GoSimple {
+	return &GoSimple{opts: opts, env: ManagerEnv("go", config.ManagersConfig{Go: opts})}
 }
 
 // IsInstalled checks if a go package is installed by looking for its binary
@@ -55,7 +58,7 @@ func (g *GoSimple) IsInstalled(ctx context.Context, name string) (bool, error) {
 func (g *GoSimple) loadInstalled() error {
 	installed := make(map[string]bool)
 
-	binDir := goBinDir()
+	binDir := goBinDir(g.env)
 	if binDir == "" {
 		return fmt.Errorf("failed to determine go bin directory: GOBIN not set and home directory unavailable")
 	}
@@ -90,10 +93,12 @@ func (g *GoSimple) Install(ctx context.Context, name string) error {
 		pkg = name + "@latest"
 	}
 
-	cmd := exec.CommandContext(ctx, "go", "install", pkg)
-	output, err := cmd.CombinedOutput()
+	args := append(append([]string{"install"}, g.opts.InstallArgs...), g.opts.PackageArgs[name]...)
+	args = append(args, pkg)
+	cmd := managerCommand(ctx, g.env, "go", args...)
+	output, err := runInstallCommand(cmd, "go:"+name)
 	if err != nil {
-		return fmt.Errorf("go install failed: %s: %w", strings.TrimSpace(string(output)), err)
+		return newPackageError(ctx, "go", name, output, fmt.Errorf("go install failed: %s: %w", strings.TrimSpace(string(output)), err))
 	}
 
 	// Update cache after successful install
@@ -121,13 +126,14 @@ func (g *GoSimple) markInstalled(name string) {
 	}
 }
 
-// goBinDir returns the directory where go install puts binaries
-func goBinDir() string {
-	if gobin := os.Getenv("GOBIN"); gobin != "" {
+// goBinDir returns the directory where go install puts binaries, honoring
+// GOBIN and GOPATH from the configured manager env
+func goBinDir(env []string) string {
+	if gobin := lookupManagerEnv(env, "GOBIN"); gobin != "" {
 		return gobin
 	}
 
-	gopath := os.Getenv("GOPATH")
+	gopath := lookupManagerEnv(env, "GOPATH")
 	if gopath == "" {
 		home, err := os.UserHomeDir()
 		if err != nil {
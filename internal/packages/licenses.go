@@ -0,0 +1,252 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package packages
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/richhaase/plonk/internal/manifest"
+)
+
+// LicenseReporter is implemented by managers whose package metadata names
+// each package's license
+type LicenseReporter interface {
+	Licenses(ctx context.Context, names []string) (map[string]string, error)
+}
+
+// DetectLicenses returns the license of each of names that manager's
+// metadata declares, usually as an SPDX expression such as "MIT" or
+// "Apache-2.0 OR MIT". Packages without one are left out. Managers that
+// cannot tell, or are unavailable, report none.
+func DetectLicenses(ctx context.Context, manager string, names []string) (map[string]string, error) {
+	if len(names) == 0 || CheckManagerAvailable(manager) != nil {
+		return nil, nil
+	}
+	mgr, err := GetManager(manager)
+	if err != nil {
+		return nil, nil
+	}
+	reporter, ok := mgr.(LicenseReporter)
+	if !ok {
+		return nil, nil
+	}
+	return reporter.Licenses(ctx, names)
+}
+
+// Licenses reads the license field of `brew info`. Casks have none.
+func (b *BrewSimple) Licenses(ctx context.Context, names []string) (map[string]string, error) {
+	licenses := make(map[string]string)
+	err := b.info(ctx, names, func(names []string, out []byte) error {
+		return parseBrewLicenses(names, out, licenses)
+	})
+	return licenses, err
+}
+
+// parseBrewLicenses adds the licenses of the formulae in `brew info
+// --json=v2` output to licenses, keyed by the names that were asked about
+func parseBrewLicenses(names []string, data []byte, licenses map[string]string) error {
+	var info struct {
+		Formulae []struct {
+			Name     string   `json:"name"`
+			FullName string   `json:"full_name"`
+			Oldnames []string `json:"oldnames"`
+			License  string   `json:"license"`
+		} `json:"formulae"`
+	}
+	if err := json.Unmarshal(data, &info); err != nil {
+		return err
+	}
+	for _, name := range names {
+		short := name[strings.LastIndex(name, "/")+1:]
+		for _, f := range info.Formulae {
+			if f.License != "" && (name == f.FullName || short == f.Name || slices.Contains(f.Oldnames, short)) {
+				licenses[name] = f.License
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// Licenses reads the license field of each package's package.json in the
+// global node_modules
+func (p *PNPMSimple) Licenses(ctx context.Context, names []string) (map[string]string, error) {
+	root, err := cacheCommandPath(ctx, p.env, "pnpm", "root", "-g")
+	if err != nil {
+		return nil, err
+	}
+	licenses := make(map[string]string)
+	for _, name := range names {
+		data, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(name), "package.json"))
+		if err != nil {
+			continue
+		}
+		if license := packageJSONLicense(data); license != "" {
+			licenses[name] = license
+		}
+	}
+	return licenses, nil
+}
+
+// packageJSONLicense returns the license of a package.json, which is an
+// SPDX expression or, in old packages, {"type": "MIT"} or a list of those
+func packageJSONLicense(data []byte) string {
+	var pkg struct {
+		License  json.RawMessage `json:"license"`
+		Licenses []struct {
+			Type string `json:"type"`
+		} `json:"licenses"`
+	}
+	if json.Unmarshal(data, &pkg) != nil {
+		return ""
+	}
+	var license string
+	if json.Unmarshal(pkg.License, &license) == nil {
+		return license
+	}
+	var legacy struct {
+		Type string `json:"type"`
+	}
+	if json.Unmarshal(pkg.License, &legacy) == nil && legacy.Type != "" {
+		return legacy.Type
+	}
+	var types []string
+	for _, l := range pkg.Licenses {
+		if l.Type != "" {
+			types = append(types, l.Type)
+		}
+	}
+	return strings.Join(types, " OR ")
+}
+
+// Licenses reads the core metadata of each tool in its uv environment.
+// Interpreters (python@<version>) have none.
+func (u *UVSimple) Licenses(ctx context.Context, names []string) (map[string]string, error) {
+	dir, err := cacheCommandPath(ctx, u.env, "uv", "tool", "dir")
+	if err != nil {
+		return nil, err
+	}
+	// Each tool has a virtual environment named after it, holding the
+	// metadata of the tool and its dependencies
+	metadata := make(map[string]string) // normalized tool name -> METADATA path
+	matches, _ := filepath.Glob(filepath.Join(dir, "*", "lib", "python*", "site-packages", "*.dist-info", "METADATA"))
+	for _, path := range matches {
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			continue
+		}
+		tool, _, _ := strings.Cut(filepath.ToSlash(rel), "/")
+		dist, _, _ := strings.Cut(filepath.Base(filepath.Dir(path)), "-")
+		if manifest.NormalizeName(tool) == manifest.NormalizeName(dist) {
+			metadata[manifest.NormalizeName(tool)] = path
+		}
+	}
+
+	licenses := make(map[string]string)
+	for _, name := range names {
+		if _, ok := PythonVersion(name); ok {
+			continue
+		}
+		path, ok := metadata[manifest.NormalizeName(parseRequirement(name).Name)]
+		if !ok {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		if license := pythonMetadataLicense(data); license != "" {
+			licenses[name] = license
+		}
+	}
+	return licenses, nil
+}
+
+// maxLicenseNameLen tells a license name from a license's full text, which
+// older Python packages put in their License field
+const maxLicenseNameLen = 64
+
+// pythonMetadataLicense returns the license in a Python package's core
+// metadata: License-Expression, else a short License, else the name in a
+// "License :: OSI Approved :: MIT License" classifier
+func pythonMetadataLicense(data []byte) string {
+	var expression, license, classifier string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			break // the headers end at the first blank line
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.ToLower(key) {
+		case "license-expression":
+			expression = value
+		case "license":
+			if len(value) <= maxLicenseNameLen && !strings.EqualFold(value, "UNKNOWN") {
+				license = value
+			}
+		case "classifier":
+			if after, ok := strings.CutPrefix(value, "License :: "); ok && classifier == "" {
+				parts := strings.Split(after, "::")
+				classifier = strings.TrimSpace(parts[len(parts)-1])
+			}
+		}
+	}
+	switch {
+	case expression != "":
+		return expression
+	case license != "":
+		return license
+	}
+	return classifier
+}
+
+// cargoLicense matches the license line of `cargo info` output and of a
+// Cargo.toml's [package] table
+var cargoLicense = regexp.MustCompile(`(?m)^license\s*[:=]\s*"?([^"\n]+?)"?\s*$`)
+
+// Licenses asks `cargo info` about crates.io crates and reads the
+// Cargo.toml of path sources. Git sources are left out: cargo keeps no
+// metadata for them after the install.
+func (c *CargoSimple) Licenses(ctx context.Context, names []string) (map[string]string, error) {
+	licenses := make(map[string]string)
+	for _, name := range names {
+		var out []byte
+		if source, ok := parseCrateSource(name); ok {
+			if source.Path == "" {
+				continue
+			}
+			data, err := os.ReadFile(filepath.Join(source.Path, "Cargo.toml"))
+			if err != nil {
+				continue
+			}
+			out = data
+		} else {
+			data, err := managerCommand(ctx, c.env, "cargo", "info", "--quiet", name).Output()
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil, ctx.Err()
+				}
+				continue
+			}
+			out = data
+		}
+		if m := cargoLicense.FindSubmatch(out); m != nil {
+			licenses[name] = string(m[1])
+		}
+	}
+	return licenses, nil
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package packages
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBrewLicenses(t *testing.T) {
+	data := []byte(`{
+  "formulae": [
+    {"name": "ripgrep", "full_name": "ripgrep", "oldnames": [], "license": "Unlicense OR MIT"},
+    {"name": "httpie", "full_name": "httpie", "oldnames": ["http"], "license": "BSD-3-Clause"},
+    {"name": "terraform", "full_name": "hashicorp/tap/terraform", "license": "BUSL-1.1"},
+    {"name": "unlicensed", "full_name": "unlicensed", "license": null}
+  ],
+  "casks": [
+    {"token": "firefox", "full_token": "firefox"}
+  ]
+}`)
+
+	licenses := make(map[string]string)
+	require.NoError(t, parseBrewLicenses([]string{"ripgrep", "http", "hashicorp/tap/terraform", "unlicensed", "firefox"}, data, licenses))
+	assert.Equal(t, map[string]string{
+		"ripgrep":                 "Unlicense OR MIT",
+		"http":                    "BSD-3-Clause",
+		"hashicorp/tap/terraform": "BUSL-1.1",
+	}, licenses)
+
+	assert.Error(t, parseBrewLicenses([]string{"jq"}, []byte("not json"), licenses))
+}
+
+func TestPackageJSONLicense(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"spdx", `{"name": "typescript", "license": "Apache-2.0"}`, "Apache-2.0"},
+		{"legacy object", `{"license": {"type": "MIT", "url": "https://example.com"}}`, "MIT"},
+		{"legacy list", `{"licenses": [{"type": "MIT"}, {"type": "GPL-2.0"}]}`, "MIT OR GPL-2.0"},
+		{"none", `{"name": "private"}`, ""},
+		{"invalid", `not json`, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, packageJSONLicense([]byte(tt.data)))
+		})
+	}
+}
+
+func TestPythonMetadataLicense(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"expression", "Metadata-Version: 2.4\nName: ruff\nLicense-Expression: MIT\nClassifier: License :: OSI Approved :: MIT License\n", "MIT"},
+		{"license", "Metadata-Version: 2.1\nName: httpie\nLicense: BSD\n", "BSD"},
+		{"classifier", "Metadata-Version: 2.1\nName: black\nLicense: UNKNOWN\nClassifier: Programming Language :: Python\nClassifier: License :: OSI Approved :: MIT License\n", "MIT License"},
+		{"full text", "Metadata-Version: 2.1\nName: old\nLicense: Permission is hereby granted, free of charge, to any person obtaining a copy\nClassifier: License :: OSI Approved :: BSD License\n", "BSD License"},
+		{"body ignored", "Metadata-Version: 2.1\nName: tool\n\nLicense: MIT\n", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, pythonMetadataLicense([]byte(tt.data)))
+		})
+	}
+}
+
+func TestCargoLicense(t *testing.T) {
+	info := "ripgrep #grep #regex\nripgrep is a line-oriented search tool\nversion: 14.1.1\nlicense: Unlicense OR MIT\nrust-version: 1.72\n"
+	m := cargoLicense.FindStringSubmatch(info)
+	require.NotNil(t, m)
+	assert.Equal(t, "Unlicense OR MIT", m[1])
+
+	manifest := "[package]\nname = \"tool\"\nversion = \"0.1.0\"\nlicense = \"MIT OR Apache-2.0\"\n"
+	m = cargoLicense.FindStringSubmatch(manifest)
+	require.NotNil(t, m)
+	assert.Equal(t, "MIT OR Apache-2.0", m[1])
+
+	assert.Nil(t, cargoLicense.FindStringSubmatch("[package]\nlicense.workspace = true\n"))
+}
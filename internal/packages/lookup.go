@@ -0,0 +1,178 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package packages
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// AvailabilityCacheTTL bounds how long a persisted executable lookup or
+// version is trusted
+const AvailabilityCacheTTL = 10 * time.Minute
+
+// lookupCache memoizes successful executable lookups, and the versions
+// managers report, for the life of the process and, once
+// EnableAvailabilityCache is called, across processes. Misses are searched
+// for again each time, so a manager installed while a long-running process
+// such as plonk serve is up is found on the next lookup; entries are
+// dropped whenever $PATH changes.
+var lookupCache = struct {
+	mu       sync.Mutex
+	pathEnv  string
+	found    map[string]string       // binary -> resolved path
+	versions map[string]versionEntry // binary -> version output
+	file     string
+}{}
+
+// availabilityFile is the on-disk form of the lookup cache
+type availabilityFile struct {
+	Path     string                  `json:"path"`
+	SavedAt  time.Time               `json:"saved_at"`
+	Found    map[string]string       `json:"found"`
+	Versions map[string]versionEntry `json:"versions,omitempty"`
+}
+
+// versionEntry is the version line an executable printed. It holds while
+// the binary resolves to the same, unmodified file, so an upgrade in place
+// is probed again.
+type versionEntry struct {
+	Path    string    `json:"path"`
+	ModTime time.Time `json:"mod_time"`
+	Line    string    `json:"line"`
+}
+
+// LookPath resolves binary on $PATH like exec.LookPath, but a binary that
+// was found is only searched for once per process (and once per
+// AvailabilityCacheTTL when the on-disk cache is enabled)
+func LookPath(binary string) (string, error) {
+	lookupCache.mu.Lock()
+	defer lookupCache.mu.Unlock()
+
+	loadLookupCache()
+	if path, ok := lookupCache.found[binary]; ok {
+		return path, nil
+	}
+
+	path, err := exec.LookPath(binary)
+	if err != nil {
+		return "", err
+	}
+	lookupCache.found[binary] = path
+	saveAvailabilityFile(lookupCache.file, lookupCache.pathEnv, lookupCache.found, lookupCache.versions)
+	return path, nil
+}
+
+// loadLookupCache fills lookupCache for the current $PATH. The caller
+// holds lookupCache.mu.
+func loadLookupCache() {
+	pathEnv := os.Getenv("PATH")
+	if lookupCache.found == nil || lookupCache.pathEnv != pathEnv {
+		cached := loadAvailabilityFile(lookupCache.file, pathEnv)
+		lookupCache.pathEnv, lookupCache.found, lookupCache.versions = pathEnv, cached.Found, cached.Versions
+	}
+}
+
+// cachedVersionLine returns the version line binary printed before, or
+// runs probe for it and remembers the result. Failed probes are not
+// remembered.
+func cachedVersionLine(binary string, probe func() (string, error)) (string, error) {
+	path, err := LookPath(binary)
+	if err != nil {
+		return "", err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+
+	lookupCache.mu.Lock()
+	loadLookupCache()
+	entry, ok := lookupCache.versions[binary]
+	lookupCache.mu.Unlock()
+	if ok && entry.Path == path && entry.ModTime.Equal(info.ModTime()) {
+		return entry.Line, nil
+	}
+
+	line, err := probe()
+	if err != nil {
+		return "", err
+	}
+	lookupCache.mu.Lock()
+	defer lookupCache.mu.Unlock()
+	loadLookupCache()
+	lookupCache.versions[binary] = versionEntry{Path: path, ModTime: info.ModTime(), Line: line}
+	saveAvailabilityFile(lookupCache.file, lookupCache.pathEnv, lookupCache.found, lookupCache.versions)
+	return line, nil
+}
+
+// EnableAvailabilityCache persists lookups to file so later
+// processes can skip the $PATH search
+func EnableAvailabilityCache(file string) {
+	lookupCache.mu.Lock()
+	defer lookupCache.mu.Unlock()
+	lookupCache.file = file
+	lookupCache.found = nil
+}
+
+// ResetAvailabilityCache forgets all lookups and disables persistence.
+// Intended for testing purposes.
+func ResetAvailabilityCache() {
+	lookupCache.mu.Lock()
+	defer lookupCache.mu.Unlock()
+	lookupCache.file = ""
+	lookupCache.found = nil
+}
+
+// loadAvailabilityFile returns the persisted lookups and versions for
+// pathEnv, or empty maps if the file is missing, stale or was written for a
+// different $PATH. Persisted paths are checked with a stat so a removed
+// binary is not reported.
+func loadAvailabilityFile(file, pathEnv string) availabilityFile {
+	loaded := availabilityFile{Found: make(map[string]string), Versions: make(map[string]versionEntry)}
+	if file == "" {
+		return loaded
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return loaded
+	}
+	var cached availabilityFile
+	if json.Unmarshal(data, &cached) != nil || cached.Path != pathEnv || time.Since(cached.SavedAt) > AvailabilityCacheTTL {
+		return loaded
+	}
+	for binary, path := range cached.Found {
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			loaded.Found[binary] = path
+		}
+	}
+	for binary, entry := range cached.Versions {
+		loaded.Versions[binary] = entry
+	}
+	return loaded
+}
+
+// saveAvailabilityFile writes the lookups and versions. Failures are
+// ignored: the cache only saves work.
+func saveAvailabilityFile(file, pathEnv string, found map[string]string, versions map[string]versionEntry) {
+	if file == "" {
+		return
+	}
+	cached := availabilityFile{Path: pathEnv, SavedAt: time.Now().UTC(), Found: found, Versions: versions}
+	data, err := json.Marshal(cached)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0o750); err != nil {
+		return
+	}
+	tmp := file + ".tmp"
+	if os.WriteFile(tmp, data, 0o600) == nil {
+		_ = os.Rename(tmp, file)
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package packages
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeExecutable(t *testing.T, dir, name string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"), 0o755))
+	return path
+}
+
+func TestLookPath_MemoizedPerProcess(t *testing.T) {
+	ResetAvailabilityCache()
+	t.Cleanup(ResetAvailabilityCache)
+
+	binDir := t.TempDir()
+	t.Setenv("PATH", binDir)
+	want := writeExecutable(t, binDir, "cargo")
+
+	got, err := LookPath("cargo")
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	// Removing the binary does not trigger another search in this process
+	require.NoError(t, os.Remove(want))
+	got, err = LookPath("cargo")
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	// A different PATH starts over
+	t.Setenv("PATH", t.TempDir())
+	_, err = LookPath("cargo")
+	assert.Error(t, err)
+}
+
+func TestLookPath_RetriesMisses(t *testing.T) {
+	ResetAvailabilityCache()
+	t.Cleanup(ResetAvailabilityCache)
+
+	binDir := t.TempDir()
+	t.Setenv("PATH", binDir)
+	_, err := LookPath("uv")
+	require.Error(t, err)
+
+	// Installed after the miss, as while plonk serve runs
+	want := writeExecutable(t, binDir, "uv")
+	got, err := LookPath("uv")
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestLookPath_PersistsSuccessfulLookups(t *testing.T) {
+	ResetAvailabilityCache()
+	t.Cleanup(ResetAvailabilityCache)
+
+	binDir := t.TempDir()
+	t.Setenv("PATH", binDir)
+	cargo := writeExecutable(t, binDir, "cargo")
+	cacheFile := filepath.Join(t.TempDir(), "managers.json")
+
+	EnableAvailabilityCache(cacheFile)
+	_, err := LookPath("cargo")
+	require.NoError(t, err)
+	_, err = LookPath("uv")
+	require.Error(t, err)
+
+	var cached availabilityFile
+	data, err := os.ReadFile(cacheFile)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &cached))
+	assert.Equal(t, map[string]string{"cargo": cargo}, cached.Found, "misses are not persisted")
+
+	// A new process trusts the file without searching PATH...
+	assert.Equal(t, map[string]string{"cargo": cargo}, loadAvailabilityFile(cacheFile, binDir).Found)
+	// ...unless PATH changed, the entry expired or the binary is gone
+	assert.Empty(t, loadAvailabilityFile(cacheFile, binDir+":/other").Found)
+
+	cached.SavedAt = time.Now().Add(-2 * AvailabilityCacheTTL)
+	data, _ = json.Marshal(cached)
+	require.NoError(t, os.WriteFile(cacheFile, data, 0o600))
+	assert.Empty(t, loadAvailabilityFile(cacheFile, binDir).Found)
+
+	saveAvailabilityFile(cacheFile, binDir, map[string]string{"cargo": cargo}, nil)
+	require.NoError(t, os.Remove(cargo))
+	assert.Empty(t, loadAvailabilityFile(cacheFile, binDir).Found)
+}
+
+func TestCachedVersionLine(t *testing.T) {
+	ResetAvailabilityCache()
+	t.Cleanup(ResetAvailabilityCache)
+
+	binDir := t.TempDir()
+	t.Setenv("PATH", binDir)
+	cargo := writeExecutable(t, binDir, "cargo")
+	cacheFile := filepath.Join(t.TempDir(), "managers.json")
+	EnableAvailabilityCache(cacheFile)
+
+	probes := 0
+	probe := func() (string, error) {
+		probes++
+		return fmt.Sprintf("cargo 1.%d.0", probes), nil
+	}
+	line, err := cachedVersionLine("cargo", probe)
+	require.NoError(t, err)
+	assert.Equal(t, "cargo 1.1.0", line)
+
+	// A later process reads the version from the file
+	EnableAvailabilityCache(cacheFile)
+	line, err = cachedVersionLine("cargo", probe)
+	require.NoError(t, err)
+	assert.Equal(t, "cargo 1.1.0", line)
+	assert.Equal(t, 1, probes)
+
+	// Upgrading the binary in place probes it again
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(cargo, later, later))
+	line, err = cachedVersionLine("cargo", probe)
+	require.NoError(t, err)
+	assert.Equal(t, "cargo 1.2.0", line)
+
+	// Failures are not remembered
+	_, err = cachedVersionLine("uv", probe)
+	require.Error(t, err)
+	writeExecutable(t, binDir, "uv")
+	_, err = cachedVersionLine("uv", func() (string, error) { return "", errors.New("exit status 1") })
+	require.Error(t, err)
+	line, err = cachedVersionLine("uv", func() (string, error) { return "uv 0.5.0", nil })
+	require.NoError(t, err)
+	assert.Equal(t, "uv 0.5.0", line)
+}
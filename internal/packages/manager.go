@@ -27,12 +27,20 @@ type Manager interface {
 	Install(ctx context.Context, name string) error
 }
 
+// BatchInstaller is implemented by managers that can install several
+// packages in a single subprocess call. SimpleApply uses it to avoid one
+// process per package and falls back to Install when the batch fails.
+type BatchInstaller interface {
+	InstallBatch(ctx context.Context, names []string) error
+}
+
 // SupportedManagers lists all available package managers
 var SupportedManagers = []string{"brew", "cargo", "go", "pnpm", "uv"}
 
-// IsSupportedManager checks if a manager name is valid
+// IsSupportedManager checks if a manager name is valid: either built in or
+// provided by a plonk-manager-<name> plugin on PATH
 func IsSupportedManager(name string) bool {
-	return slices.Contains(SupportedManagers, name)
+	return slices.Contains(SupportedManagers, name) || PluginPath(name) != ""
 }
 
 // ParsePackageSpec parses "manager:package" format and validates the manager
@@ -46,7 +54,7 @@ func ParsePackageSpec(spec string) (manager, pkg string, err error) {
 	pkg = spec[idx+1:]
 
 	if !IsSupportedManager(manager) {
-		return "", "", fmt.Errorf("unsupported manager: %s (supported: %v)", manager, SupportedManagers)
+		return "", "", UnsupportedManagerError(manager)
 	}
 
 	if pkg == "" {
@@ -3,7 +3,15 @@
 
 package packages
 
-import "testing"
+import (
+	"context"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/richhaase/plonk/internal/config"
+)
 
 func TestParsePackageSpec(t *testing.T) {
 	tests := []struct {
@@ -38,3 +46,196 @@ func TestParsePackageSpec(t *testing.T) {
 		}
 	}
 }
+
+func TestInstallCommandOptions(t *testing.T) {
+	ctx := context.Background()
+	common := config.ManagerOptions{InstallArgs: []string{"--quiet"}}
+
+	tests := []struct {
+		name string
+		cmd  *exec.Cmd
+		want []string
+	}{
+		{
+			name: "brew",
+			cmd:  NewBrewSimple(config.BrewOptions{ManagerOptions: common}).installCommand(ctx, "a", "b"),
+			want: []string{"brew", "install", "--quiet", "--", "a", "b"},
+		},
+		{
+			name: "brew package options",
+			cmd: NewBrewSimple(config.BrewOptions{Packages: map[string]config.BrewPackageOptions{
+				"ffmpeg": {Head: true, BuildFromSource: true, Options: []string{"--with-libvpx"}},
+			}}).installCommand(ctx, "ffmpeg"),
+			want: []string{"brew", "install", "--HEAD", "--build-from-source", "--with-libvpx", "--", "ffmpeg"},
+		},
+		{
+			name: "brew package options skipped in batches",
+			cmd: NewBrewSimple(config.BrewOptions{Packages: map[string]config.BrewPackageOptions{
+				"ffmpeg": {Head: true},
+			}}).installCommand(ctx, "ffmpeg", "jq"),
+			want: []string{"brew", "install", "--", "ffmpeg", "jq"},
+		},
+		{
+			name: "brew package args",
+			cmd: NewBrewSimple(config.BrewOptions{ManagerOptions: config.ManagerOptions{
+				PackageArgs: map[string][]string{"ffmpeg": {"--with-libvpx"}},
+			}}).installCommand(ctx, "ffmpeg"),
+			want: []string{"brew", "install", "--with-libvpx", "--", "ffmpeg"},
+		},
+		{
+			name: "cargo package args",
+			cmd: NewCargoSimple(config.CargoOptions{ManagerOptions: config.ManagerOptions{
+				PackageArgs: map[string][]string{"ripgrep": {"--features", "pcre2"}},
+			}}).installCommand(ctx, "ripgrep"),
+			want: []string{"cargo", "install", "--features", "pcre2", "--", "ripgrep"},
+		},
+		{
+			name: "cargo",
+			cmd:  NewCargoSimple(config.CargoOptions{ManagerOptions: common, Locked: true, Features: []string{"x", "y"}}).installCommand(ctx, "a"),
+			want: []string{"cargo", "install", "--locked", "--features", "x,y", "--quiet", "--", "a"},
+		},
+		{
+			name: "cargo git",
+			cmd:  NewCargoSimple(config.CargoOptions{Locked: true}).installCommand(ctx, "git+https://github.com/owner/tool?rev=abc"),
+			want: []string{"cargo", "install", "--locked", "--git", "https://github.com/owner/tool", "--rev", "abc"},
+		},
+		{
+			name: "pnpm",
+			cmd:  NewPNPMSimple(config.PNPMOptions{Registry: "https://npm.example.com"}).installCommand(ctx, "a"),
+			want: []string{"pnpm", "add", "-g", "--registry", "https://npm.example.com", "--", "a"},
+		},
+		{
+			name: "pnpm scopes",
+			cmd: NewPNPMSimple(config.PNPMOptions{Scopes: map[string]config.PNPMScope{
+				"@b": {Registry: "https://b.example.com"},
+				"@a": {Registry: "https://a.example.com/npm/"},
+			}}).installCommand(ctx, "@a/cli"),
+			want: []string{"pnpm", "add", "-g", "--@a:registry=https://a.example.com/npm/", "--@b:registry=https://b.example.com", "--", "@a/cli"},
+		},
+		{
+			name: "pnpm package args",
+			cmd: NewPNPMSimple(config.PNPMOptions{ManagerOptions: config.ManagerOptions{
+				PackageArgs: map[string][]string{"a": {"--allow-build=a"}},
+			}}).installCommand(ctx, "a"),
+			want: []string{"pnpm", "add", "-g", "--allow-build=a", "--", "a"},
+		},
+		{
+			name: "uv package args",
+			cmd: NewUVSimple(config.UVOptions{ManagerOptions: config.ManagerOptions{
+				InstallArgs: []string{"--quiet"},
+				PackageArgs: map[string][]string{"ansible": {"--with", "ansible-lint"}},
+			}}).installCommand(ctx, "ansible"),
+			want: []string{"uv", "tool", "install", "--quiet", "--with", "ansible-lint", "--", "ansible"},
+		},
+		{
+			name: "uv",
+			cmd:  NewUVSimple(config.UVOptions{IndexURL: "https://pypi.example.com/simple"}).installCommand(ctx, "a"),
+			want: []string{"uv", "tool", "install", "--index-url", "https://pypi.example.com/simple", "--", "a"},
+		},
+	}
+	for _, tt := range tests {
+		got := append([]string{filepath.Base(tt.cmd.Path)}, tt.cmd.Args[1:]...)
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	brew := NewBrewSimple(config.BrewOptions{NoAutoUpdate: true}).installCommand(ctx, "a")
+	if !slices.Contains(brew.Env, "HOMEBREW_NO_AUTO_UPDATE=1") {
+		t.Errorf("brew no_auto_update: HOMEBREW_NO_AUTO_UPDATE not set")
+	}
+	if NewCargoSimple(config.CargoOptions{Features: []string{"x"}}).batchable() {
+		t.Errorf("cargo with features must not batch")
+	}
+}
+
+func TestConfigureManagers_RebuildsOnChange(t *testing.T) {
+	ResetManagerCache()
+	t.Cleanup(func() {
+		ConfigureManagers(config.ManagersConfig{})
+		ResetManagerCache()
+	})
+
+	ConfigureManagers(config.ManagersConfig{PNPM: config.PNPMOptions{Registry: "https://one.example.com"}})
+	first, _ := GetManager("pnpm")
+	same, _ := GetManager("pnpm")
+	if first != same {
+		t.Fatalf("expected cached manager")
+	}
+
+	ConfigureManagers(config.ManagersConfig{PNPM: config.PNPMOptions{Registry: "https://two.example.com"}})
+	second, _ := GetManager("pnpm")
+	if second == first {
+		t.Fatalf("expected a new manager after options changed")
+	}
+	if got := second.(*PNPMSimple).opts.Registry; got != "https://two.example.com" {
+		t.Errorf("registry = %q", got)
+	}
+}
+
+func TestGetManager_SharedProxy(t *testing.T) {
+	ResetManagerCache()
+	t.Cleanup(func() {
+		ConfigureManagers(config.ManagersConfig{})
+		ResetManagerCache()
+	})
+
+	ConfigureManagers(config.ManagersConfig{Proxy: config.ProxyConfig{HTTPS: "http://proxy.corp:8080"}})
+	mgr, err := GetManager("uv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if env := mgr.(*UVSimple).env; !slices.Contains(env, "HTTPS_PROXY=http://proxy.corp:8080") {
+		t.Errorf("uv env = %v, want the shared proxy", env)
+	}
+}
+
+func TestManagerEnv(t *testing.T) {
+	t.Setenv("PLONK_TEST_HOME", "/home/me")
+	opts := config.ManagersConfig{
+		Brew: config.BrewOptions{NoAutoUpdate: true},
+		Go: config.GoOptions{ManagerOptions: config.ManagerOptions{Env: map[string]string{
+			"GOFLAGS": "-mod=mod",
+			"GOBIN":   "$PLONK_TEST_HOME/bin",
+		}}},
+	}
+
+	if got, want := ManagerEnv("go", opts), []string{"GOBIN=/home/me/bin", "GOFLAGS=-mod=mod"}; !slices.Equal(got, want) {
+		t.Errorf("go env = %v, want %v", got, want)
+	}
+	if got, want := ManagerEnv("brew", opts), []string{"HOMEBREW_NO_AUTO_UPDATE=1"}; !slices.Equal(got, want) {
+		t.Errorf("brew env = %v, want %v", got, want)
+	}
+	if got := ManagerEnv("cargo", opts); len(got) != 0 {
+		t.Errorf("cargo env = %v, want none", got)
+	}
+
+	t.Setenv("PLONK_TEST_PROXY", "cargo.proxy")
+	proxied := config.ManagersConfig{
+		Proxy: config.ProxyConfig{HTTPS: "http://proxy.corp:8080", NoProxy: []string{"localhost", ".corp"}},
+		Cargo: config.CargoOptions{ManagerOptions: config.ManagerOptions{
+			Proxy: config.ProxyConfig{HTTPS: "http://$PLONK_TEST_PROXY:3128"},
+			Env:   map[string]string{"NO_PROXY": "*"},
+		}},
+	}
+	if got, want := ManagerEnv("brew", proxied), []string{
+		"HTTPS_PROXY=http://proxy.corp:8080", "NO_PROXY=localhost,.corp",
+		"https_proxy=http://proxy.corp:8080", "no_proxy=localhost,.corp",
+	}; !slices.Equal(got, want) {
+		t.Errorf("brew proxy env = %v, want %v", got, want)
+	}
+	if got, want := ManagerEnv("cargo", proxied), []string{
+		"HTTPS_PROXY=http://cargo.proxy:3128", "NO_PROXY=*",
+		"https_proxy=http://cargo.proxy:3128", "no_proxy=localhost,.corp",
+	}; !slices.Equal(got, want) {
+		t.Errorf("cargo proxy env = %v, want its own proxy and env over the shared ones", got)
+	}
+
+	g := NewGoSimple(opts.Go)
+	if got := goBinDir(g.env); got != "/home/me/bin" {
+		t.Errorf("goBinDir = %q, want configured GOBIN", got)
+	}
+	if !slices.Contains(managerCommand(context.Background(), g.env, "go", "version").Env, "GOFLAGS=-mod=mod") {
+		t.Errorf("GOFLAGS not passed to go subprocess")
+	}
+}
@@ -0,0 +1,230 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package packages
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/richhaase/plonk/internal/pluginexec"
+)
+
+// PluginPrefix is the executable name prefix for external manager plugins.
+// An executable named plonk-manager-<name> on PATH provides the "<name>" manager.
+const PluginPrefix = "plonk-manager-"
+
+// PluginProtocolVersion is sent with every request so plugins can reject
+// requests they do not understand
+const PluginProtocolVersion = 1
+
+// Plugin operations. plonk currently issues list, install and info;
+// uninstall is reserved so plugins can implement the full set up front.
+const (
+	PluginOpList      = "list"
+	PluginOpInstall   = "install"
+	PluginOpUninstall = "uninstall"
+	PluginOpInfo      = "info"
+)
+
+// pluginNamePattern restricts plugin manager names to what is safe in a
+// manager:package spec and a lock file key
+var pluginNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// PluginRequest is written as JSON to the plugin's stdin
+type PluginRequest struct {
+	Version   int    `json:"version"`
+	Operation string `json:"operation"`
+	Package   string `json:"package,omitempty"`
+}
+
+// PluginResponse is read as JSON from the plugin's stdout. A non-empty Error
+// means the operation failed; ErrorClass uses the names from ErrorClass().
+type PluginResponse struct {
+	Packages   []string `json:"packages,omitempty"` // list: installed package names
+	Name       string   `json:"name,omitempty"`     // info: manager display name
+	Version    string   `json:"version,omitempty"`  // info: plugin version
+	Error      string   `json:"error,omitempty"`
+	ErrorClass string   `json:"error_class,omitempty"`
+}
+
+// pluginMisses remembers the names PluginPath found no plugin for, so
+// validation and status, which ask about every manager in plonk.yaml and
+// plonk.lock, search $PATH once per name. Unlike LookPath's cache it is
+// never persisted, and ResetManagerCache clears it so plonk serve's refresh
+// finds a plugin installed since.
+var pluginMisses = struct {
+	mu      sync.Mutex
+	pathEnv string
+	names   map[string]bool
+}{}
+
+// PluginPath returns the path of the plugin executable for name, or "" if
+// there is none. Built-in managers are never resolved to plugins.
+func PluginPath(name string) string {
+	if slices.Contains(SupportedManagers, name) || !pluginNamePattern.MatchString(name) {
+		return ""
+	}
+
+	pluginMisses.mu.Lock()
+	defer pluginMisses.mu.Unlock()
+	if pathEnv := os.Getenv("PATH"); pluginMisses.names == nil || pluginMisses.pathEnv != pathEnv {
+		pluginMisses.pathEnv, pluginMisses.names = pathEnv, make(map[string]bool)
+	}
+	if pluginMisses.names[name] {
+		return ""
+	}
+	path, err := LookPath(PluginPrefix + name)
+	if err != nil {
+		pluginMisses.names[name] = true
+		return ""
+	}
+	return path
+}
+
+// forgetPluginMisses makes PluginPath search $PATH again for every name
+func forgetPluginMisses() {
+	pluginMisses.mu.Lock()
+	defer pluginMisses.mu.Unlock()
+	pluginMisses.names = nil
+}
+
+// DiscoverPlugins scans PATH for plugin executables and returns the manager
+// names they provide, sorted, leaving out disabled managers. The first match
+// on PATH wins, as with exec.
+func DiscoverPlugins() []string {
+	seen := make(map[string]bool)
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name, ok := strings.CutPrefix(entry.Name(), PluginPrefix)
+			if !ok || seen[name] || IsManagerDisabled(name) || PluginPath(name) == "" {
+				continue
+			}
+			seen[name] = true
+		}
+	}
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// PluginManager implements Manager by talking to a plonk-manager-<name> executable
+type PluginManager struct {
+	name      string
+	path      string
+	env       []string // the shared proxy from managers.proxy
+	mu        sync.Mutex
+	installed map[string]bool
+}
+
+// NewPluginManager creates a manager backed by the plugin executable at path
+func NewPluginManager(name, path string) *PluginManager {
+	return &PluginManager{name: name, path: path}
+}
+
+// IsInstalled checks if a package is installed according to the plugin's list
+func (p *PluginManager) IsInstalled(ctx context.Context, name string) (bool, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// Load installed list on first call
+	if p.installed == nil {
+		resp, err := p.call(ctx, PluginRequest{Operation: PluginOpList})
+		if err != nil {
+			return false, err
+		}
+		installed := make(map[string]bool, len(resp.Packages))
+		for _, pkg := range resp.Packages {
+			installed[pkg] = true
+		}
+		p.installed = installed
+	}
+
+	return p.installed[name], nil
+}
+
+// Install installs a package through the plugin
+func (p *PluginManager) Install(ctx context.Context, name string) error {
+	if _, err := p.call(ctx, PluginRequest{Operation: PluginOpInstall, Package: name}); err != nil {
+		return err
+	}
+
+	// Update cache after successful install
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.installed != nil {
+		p.installed[name] = true
+	}
+	return nil
+}
+
+// Info asks the plugin to describe itself
+func (p *PluginManager) Info(ctx context.Context) (*PluginResponse, error) {
+	return p.call(ctx, PluginRequest{Operation: PluginOpInfo})
+}
+
+// call runs the plugin with one JSON request and decodes its JSON response
+func (p *PluginManager) call(ctx context.Context, req PluginRequest) (*PluginResponse, error) {
+	req.Version = PluginProtocolVersion
+	var resp PluginResponse
+	result, err := pluginexec.Call(ctx, p.path, req.Operation, p.env, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	var runErr error
+	if result.RunErr != nil {
+		runErr = &CommandError{Args: result.Args, Output: result.Stderr, Err: result.RunErr}
+	}
+	label := strings.TrimSpace(fmt.Sprintf("%s %s %s", p.name, req.Operation, req.Package))
+
+	if result.DecodeErr != nil {
+		if runErr != nil {
+			return nil, newPackageError(ctx, p.name, req.Package, result.Stderr,
+				fmt.Errorf("%s: %s: %w", label, result.StderrText(), runErr))
+		}
+		return nil, result.InvalidJSON(p.name, req.Operation)
+	}
+
+	if resp.Error != "" || runErr != nil {
+		msg := resp.Error
+		if msg == "" {
+			msg = result.StderrText()
+		}
+		err := fmt.Errorf("%s: %s", label, msg)
+		if runErr != nil {
+			err = fmt.Errorf("%w: %w", err, runErr)
+		}
+		class := classFromName(resp.ErrorClass)
+		if class == nil {
+			class = classifyError(ctx, []byte(msg), runErr)
+		}
+		return nil, &PackageError{Class: class, Manager: p.name, Package: req.Package, Err: err}
+	}
+
+	return &resp, nil
+}
+
+// classFromName maps an error class name reported by a plugin to its sentinel
+func classFromName(name string) error {
+	for class, className := range classNames {
+		if className == name {
+			return class
+		}
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package packages
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePlugin installs a shell-script plugin named plonk-manager-<name> into a
+// fresh PATH directory. The script answers list from a state file and appends
+// to it on install; installing "missing" reports a not_found error.
+func fakePlugin(t *testing.T, name string) string {
+	t.Helper()
+	dir := t.TempDir()
+	state := filepath.Join(dir, "installed")
+	require.NoError(t, os.WriteFile(state, []byte("alpha\n"), 0o644))
+
+	script := `#!/bin/sh
+req=$(cat)
+state="` + state + `"
+case "$1" in
+  list)
+    printf '{"packages": ['
+    sep=""
+    while read -r p; do printf '%s"%s"' "$sep" "$p"; sep=","; done < "$state"
+    printf ']}\n'
+    ;;
+  install)
+    pkg=$(printf '%s' "$req" | sed -n 's/.*"package":"\([^"]*\)".*/\1/p')
+    if [ "$pkg" = "missing" ]; then
+      echo '{"error": "no such package", "error_class": "not_found"}'
+      exit 1
+    fi
+    echo "$pkg" >> "$state"
+    echo '{}'
+    ;;
+  info)
+    echo '{"name": "Fake", "version": "1.0"}'
+    ;;
+  *)
+    echo "unknown operation" >&2
+    exit 2
+    ;;
+esac
+`
+	path := filepath.Join(dir, PluginPrefix+name)
+	require.NoError(t, os.WriteFile(path, []byte(script), 0o755))
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return path
+}
+
+func TestPluginDiscovery(t *testing.T) {
+	path := fakePlugin(t, "fake")
+
+	assert.Equal(t, path, PluginPath("fake"))
+	assert.True(t, IsSupportedManager("fake"))
+	assert.Contains(t, DiscoverPlugins(), "fake")
+
+	// Built-in names are never shadowed by plugins
+	assert.Empty(t, PluginPath("brew"))
+	assert.Empty(t, PluginPath("Bad Name"))
+}
+
+func TestPluginPath_RemembersMisses(t *testing.T) {
+	ResetAvailabilityCache()
+	t.Cleanup(ResetAvailabilityCache)
+	t.Cleanup(ResetManagerCache)
+	binDir := t.TempDir()
+	t.Setenv("PATH", binDir)
+
+	assert.Empty(t, PluginPath("fake"))
+	path := writeExecutable(t, binDir, PluginPrefix+"fake")
+	assert.Empty(t, PluginPath("fake"), "a miss is searched for once")
+
+	// As after plonk serve's refresh
+	ResetManagerCache()
+	assert.Equal(t, path, PluginPath("fake"))
+}
+
+func TestPluginManager_ListAndInstall(t *testing.T) {
+	path := fakePlugin(t, "fake")
+	mgr := NewPluginManager("fake", path)
+	ctx := context.Background()
+
+	installed, err := mgr.IsInstalled(ctx, "alpha")
+	require.NoError(t, err)
+	assert.True(t, installed)
+
+	installed, err = mgr.IsInstalled(ctx, "beta")
+	require.NoError(t, err)
+	assert.False(t, installed)
+
+	require.NoError(t, mgr.Install(ctx, "beta"))
+	installed, err = mgr.IsInstalled(ctx, "beta")
+	require.NoError(t, err)
+	assert.True(t, installed)
+
+	info, err := mgr.Info(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "1.0", info.Version)
+}
+
+func TestPluginManager_ErrorClass(t *testing.T) {
+	path := fakePlugin(t, "fake")
+	mgr := NewPluginManager("fake", path)
+
+	err := mgr.Install(context.Background(), "missing")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrNotFound))
+	assert.Contains(t, err.Error(), "fake install missing: no such package")
+}
+
+func TestGetManager_ResolvesPlugin(t *testing.T) {
+	ResetManagerCache()
+	t.Cleanup(ResetManagerCache)
+	fakePlugin(t, "fake")
+
+	mgr, err := GetManager("fake")
+	require.NoError(t, err)
+	assert.IsType(t, &PluginManager{}, mgr)
+
+	manager, pkg, err := ParsePackageSpec("fake:thing")
+	require.NoError(t, err)
+	assert.Equal(t, "fake", manager)
+	assert.Equal(t, "thing", pkg)
+}
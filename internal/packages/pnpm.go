@@ -10,17 +10,21 @@ import (
 	"os/exec"
 	"strings"
 	"sync"
+
+	"github.com/richhaase/plonk/internal/config"
 )
 
 // PNPMSimple implements Manager for pnpm
 type PNPMSimple struct {
 	mu        sync.Mutex
 	installed map[string]bool
+	opts      config.PNPMOptions
+	env       []string
 }
 
 // NewPNPMSimple creates a new pnpm manager
-func NewPNPMSimple() *PNPMSimple {
-	return &PNPMSimple{}
+func NewPNPMSimple(opts config.PNPMOptions) *PNPMSimple {
+	return &PNPMSimple{opts: opts, env: ManagerEnv("pnpm", config.ManagersConfig{PNPM: opts})}
 }
 
 // IsInstalled checks if a package is globally installed via pnpm
@@ -42,10 +46,10 @@ func (p *PNPMSimple) IsInstalled(ctx context.Context, name string) (bool, error)
 func (p *PNPMSimple) loadInstalled(ctx context.Context) error {
 	installed := make(map[string]bool)
 
-	cmd := exec.CommandContext(ctx, "pnpm", "list", "-g", "--depth=0", "--json")
+	cmd := managerCommand(ctx, p.env, "pnpm", "list", "-g", "--depth=0", "--json")
 	output, err := cmd.Output()
 	if err != nil {
-		return fmt.Errorf("failed to list pnpm packages: %w", err)
+		return newPackageError(ctx, "pnpm", "", nil, fmt.Errorf("failed to list pnpm packages: %w", err))
 	}
 
 	// pnpm outputs JSON array: [{"dependencies": {...}}]
@@ -69,15 +73,21 @@ func (p *PNPMSimple) loadInstalled(ctx context.Context) error {
 
 // Install installs a package globally via pnpm
 func (p *PNPMSimple) Install(ctx context.Context, name string) error {
-	cmd := exec.CommandContext(ctx, "pnpm", "add", "-g", "--", name)
-	output, err := cmd.CombinedOutput()
+	cmd := p.installCommand(ctx, name)
+	cleanup, err := p.addScopeAuth(ctx, cmd, []string{name})
+	defer cleanup()
+	if err != nil {
+		return newPackageError(ctx, "pnpm", name, nil, err)
+	}
+
+	output, err := runInstallCommand(cmd, "pnpm:"+name)
 	if err != nil {
 		// Check if already installed
 		if strings.Contains(strings.ToLower(string(output)), "already installed") {
 			p.markInstalled(name)
 			return nil
 		}
-		return fmt.Errorf("pnpm add -g %s: %s: %w", name, strings.TrimSpace(string(output)), err)
+		return newPackageError(ctx, "pnpm", name, output, fmt.Errorf("pnpm add -g %s: %s: %w", name, strings.TrimSpace(string(output)), err))
 	}
 
 	// Update cache after successful install
@@ -85,6 +95,56 @@ func (p *PNPMSimple) Install(ctx context.Context, name string) error {
 	return nil
 }
 
+// InstallBatch installs several packages with a single pnpm add -g.
+// Packages with their own install arguments are installed one at a time.
+func (p *PNPMSimple) InstallBatch(ctx context.Context, names []string) error {
+	var plain []string
+	for _, name := range names {
+		if len(p.opts.PackageArgs[name]) == 0 {
+			plain = append(plain, name)
+		} else if err := p.Install(ctx, name); err != nil {
+			return err
+		}
+	}
+	if len(plain) == 0 {
+		return nil
+	}
+	names = plain
+
+	cmd := p.installCommand(ctx, names...)
+	cleanup, err := p.addScopeAuth(ctx, cmd, names)
+	defer cleanup()
+	if err != nil {
+		return newPackageError(ctx, "pnpm", "", nil, err)
+	}
+
+	output, err := runInstallCommand(cmd, "pnpm")
+	if err != nil {
+		list := strings.Join(names, " ")
+		return newPackageError(ctx, "pnpm", "", output, fmt.Errorf("pnpm add -g %s: %s: %w", list, strings.TrimSpace(string(output)), err))
+	}
+	for _, name := range names {
+		p.markInstalled(name)
+	}
+	return nil
+}
+
+// installCommand builds pnpm add -g for names with the configured options.
+// A single package also gets its own install arguments.
+func (p *PNPMSimple) installCommand(ctx context.Context, names ...string) *exec.Cmd {
+	args := []string{"add", "-g"}
+	if p.opts.Registry != "" {
+		args = append(args, "--registry", p.opts.Registry)
+	}
+	args = append(args, scopeArgs(p.opts.Scopes)...)
+	args = append(args, p.opts.InstallArgs...)
+	if len(names) == 1 {
+		args = append(args, p.opts.PackageArgs[names[0]]...)
+	}
+	args = append(args, "--")
+	return managerCommand(ctx, p.env, "pnpm", append(args, names...)...)
+}
+
 // markInstalled updates the cache to mark a package as installed
 func (p *PNPMSimple) markInstalled(name string) {
 	p.mu.Lock()
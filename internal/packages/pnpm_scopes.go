@@ -0,0 +1,161 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package packages
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/richhaase/plonk/internal/config"
+)
+
+// scopeArgs returns a --@scope:registry=<url> flag per configured scope,
+// sorted by scope
+func scopeArgs(scopes map[string]config.PNPMScope) []string {
+	args := make([]string, 0, len(scopes))
+	for scope, s := range scopes {
+		args = append(args, "--"+scope+":registry="+s.Registry)
+	}
+	sort.Strings(args)
+	return args
+}
+
+// packageScope returns the @scope of a package name, or "" if unscoped
+func packageScope(name string) string {
+	if !strings.HasPrefix(name, "@") {
+		return ""
+	}
+	scope, _, _ := strings.Cut(name, "/")
+	return scope
+}
+
+// addScopeAuth gives cmd the auth tokens of the scopes that names belong
+// to. Tokens are written to a private npmrc layered over the user's own
+// (via NPM_CONFIG_USERCONFIG) rather than passed as flags, so they don't
+// show up in process listings. The returned cleanup removes the file.
+func (p *PNPMSimple) addScopeAuth(ctx context.Context, cmd *exec.Cmd, names []string) (func(), error) {
+	var lines []string
+	seen := make(map[string]bool)
+	for _, name := range names {
+		scope := packageScope(name)
+		s, ok := p.opts.Scopes[scope]
+		if !ok || seen[scope] || (s.TokenEnv == "" && s.TokenKeychain == "") {
+			continue
+		}
+		seen[scope] = true
+
+		token, err := scopeToken(ctx, p.env, s)
+		if err != nil {
+			return func() {}, fmt.Errorf("pnpm scope %s: %w", scope, err)
+		}
+		line, err := authTokenLine(s.Registry, token)
+		if err != nil {
+			return func() {}, fmt.Errorf("pnpm scope %s: %w", scope, err)
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) == 0 {
+		return func() {}, nil
+	}
+
+	// Keep the user's settings; later lines win in npmrc files
+	var content []byte
+	if userConfig := userNpmrc(p.env); userConfig != "" {
+		content, _ = os.ReadFile(userConfig)
+	}
+	if len(content) > 0 && content[len(content)-1] != '\n' {
+		content = append(content, '\n')
+	}
+	content = append(content, strings.Join(lines, "\n")+"\n"...)
+
+	f, err := os.CreateTemp("", "plonk-npmrc-*")
+	if err != nil {
+		return func() {}, err
+	}
+	cleanup := func() { os.Remove(f.Name()) }
+	if _, err := f.Write(content); err != nil {
+		f.Close()
+		cleanup()
+		return func() {}, err
+	}
+	if err := f.Close(); err != nil {
+		cleanup()
+		return func() {}, err
+	}
+
+	if cmd.Env == nil {
+		cmd.Env = os.Environ()
+	}
+	cmd.Env = append(cmd.Env, "NPM_CONFIG_USERCONFIG="+f.Name())
+	return cleanup, nil
+}
+
+// scopeToken reads a scope's auth token from its variable, then from the
+// keychain
+func scopeToken(ctx context.Context, env []string, s config.PNPMScope) (string, error) {
+	if s.TokenEnv != "" {
+		if token := lookupManagerEnv(env, s.TokenEnv); token != "" {
+			return token, nil
+		}
+		if s.TokenKeychain == "" {
+			return "", fmt.Errorf("$%s is not set", s.TokenEnv)
+		}
+	}
+
+	cmd, err := keychainCommand(ctx, s.TokenKeychain)
+	if err != nil {
+		return "", err
+	}
+	out, err := cmd.Output()
+	token := strings.TrimSpace(string(out))
+	if err != nil || token == "" {
+		return "", fmt.Errorf("no token for %q in the keychain", s.TokenKeychain)
+	}
+	return token, nil
+}
+
+// keychainCommand prints the password stored under service: the login
+// keychain on macOS, the Secret Service (secret-tool) on Linux
+func keychainCommand(ctx context.Context, service string) (*exec.Cmd, error) {
+	switch runtime.GOOS {
+	case "darwin":
+		return exec.CommandContext(ctx, "security", "find-generic-password", "-s", service, "-w"), nil
+	case "linux":
+		return exec.CommandContext(ctx, "secret-tool", "lookup", "service", service), nil
+	}
+	return nil, fmt.Errorf("token_keychain is not supported on %s", runtime.GOOS)
+}
+
+// authTokenLine renders the npmrc line that authenticates to registry:
+// https://npm.example.com/api/ -> //npm.example.com/api/:_authToken=<token>
+func authTokenLine(registry, token string) (string, error) {
+	u, err := url.Parse(registry)
+	if err != nil || u.Host == "" {
+		return "", fmt.Errorf("invalid registry %q", registry)
+	}
+	path := strings.TrimSuffix(u.Path, "/") + "/"
+	return "//" + u.Host + path + ":_authToken=" + token, nil
+}
+
+// userNpmrc returns the npmrc pnpm would read for user settings
+func userNpmrc(env []string) string {
+	if path := lookupManagerEnv(env, "NPM_CONFIG_USERCONFIG"); path != "" {
+		return path
+	}
+	if path := lookupManagerEnv(env, "npm_config_userconfig"); path != "" {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".npmrc")
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package packages
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/richhaase/plonk/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthTokenLine(t *testing.T) {
+	line, err := authTokenLine("https://npm.example.com/api/npm", "s3cret")
+	require.NoError(t, err)
+	assert.Equal(t, "//npm.example.com/api/npm/:_authToken=s3cret", line)
+
+	line, err = authTokenLine("https://npm.example.com", "s3cret")
+	require.NoError(t, err)
+	assert.Equal(t, "//npm.example.com/:_authToken=s3cret", line)
+
+	_, err = authTokenLine("not a url", "s3cret")
+	assert.Error(t, err)
+}
+
+func TestPackageScope(t *testing.T) {
+	assert.Equal(t, "@company", packageScope("@company/cli"))
+	assert.Equal(t, "", packageScope("prettier"))
+}
+
+func TestAddScopeAuth(t *testing.T) {
+	home := t.TempDir()
+	userConfig := filepath.Join(home, ".npmrc")
+	require.NoError(t, os.WriteFile(userConfig, []byte("save-exact=true"), 0o600))
+	t.Setenv("NPM_CONFIG_USERCONFIG", userConfig)
+	t.Setenv("PLONK_TEST_NPM_TOKEN", "s3cret")
+
+	p := NewPNPMSimple(config.PNPMOptions{Scopes: map[string]config.PNPMScope{
+		"@company": {Registry: "https://npm.company.com/", TokenEnv: "PLONK_TEST_NPM_TOKEN"},
+		"@public":  {Registry: "https://npm.public.com/"},
+	}})
+	ctx := context.Background()
+
+	// Unscoped and token-less packages need no overlay
+	cmd := exec.Command("pnpm")
+	cleanup, err := p.addScopeAuth(ctx, cmd, []string{"prettier", "@public/cli"})
+	require.NoError(t, err)
+	cleanup()
+	assert.Nil(t, cmd.Env)
+
+	cmd = exec.Command("pnpm")
+	cleanup, err = p.addScopeAuth(ctx, cmd, []string{"prettier", "@company/cli"})
+	require.NoError(t, err)
+	overlay := strings.TrimPrefix(cmd.Env[len(cmd.Env)-1], "NPM_CONFIG_USERCONFIG=")
+	data, err := os.ReadFile(overlay)
+	require.NoError(t, err)
+	assert.Equal(t, "save-exact=true\n//npm.company.com/:_authToken=s3cret\n", string(data))
+	for _, arg := range cmd.Args {
+		assert.NotContains(t, arg, "s3cret")
+	}
+	cleanup()
+	assert.NoFileExists(t, overlay)
+
+	t.Setenv("PLONK_TEST_NPM_TOKEN", "")
+	_, err = p.addScopeAuth(ctx, exec.Command("pnpm"), []string{"@company/cli"})
+	assert.ErrorContains(t, err, "$PLONK_TEST_NPM_TOKEN is not set")
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package packages
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/richhaase/plonk/internal/lock"
+)
+
+// PackageState is the reconciled state of a tracked package
+type PackageState string
+
+const (
+	StatusManaged PackageState = "managed" // tracked and installed
+	StatusMissing PackageState = "missing" // tracked but not installed
+	StatusError   PackageState = "error"   // could not be checked
+)
+
+// PackageStatus is the reconciled state of one tracked package
+type PackageStatus struct {
+	Manager     string
+	Name        string
+	State       PackageState
+	Err         error
+	SatisfiedBy string    // installed equivalent (see SetAliases) standing in for a missing package
+	CheckedAt   time.Time // when it was last found installed, if taken from Verifications unchecked
+}
+
+// Reconcile compares the lock file against installed packages. Results are
+// ordered by manager, then lock file order. A missing lock file means nothing
+// is tracked and is not an error.
+func Reconcile(ctx context.Context, configDir string) ([]PackageStatus, error) {
+	return ReconcileVerified(ctx, configDir, nil, 0)
+}
+
+// ReconcileVerified is Reconcile reusing verified: a package found
+// installed within maxAge is reported managed without asking its manager
+// again. verified is updated with what this run found; a nil map is
+// neither read nor updated.
+func ReconcileVerified(ctx context.Context, configDir string, verified Verifications, maxAge time.Duration) ([]PackageStatus, error) {
+	lockSvc := lock.NewLockV3Service(configDir)
+	if _, err := os.Stat(lockSvc.GetLockPath()); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	lockFile, err := lockSvc.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lock file: %w", err)
+	}
+
+	managers := make([]string, 0, len(lockFile.Packages))
+	for manager := range lockFile.Packages {
+		if IsManagerDisabled(manager) {
+			continue
+		}
+		managers = append(managers, manager)
+	}
+	sort.Strings(managers)
+
+	now := time.Now()
+	tracked := make(map[string]bool)
+	var result []PackageStatus
+	for _, manager := range managers {
+		pkgs := lockFile.Packages[manager]
+		mgr, err := GetManager(manager)
+		if err != nil {
+			// Unknown/unsupported manager - mark all as errors (not missing)
+			for _, pkg := range pkgs {
+				result = append(result, PackageStatus{Manager: manager, Name: pkg, State: StatusError, Err: err})
+			}
+			continue
+		}
+
+		// Short-circuit remaining packages if the manager itself is broken
+		// (e.g., binary not on PATH) to avoid repeated failing subprocesses.
+		var managerErr error
+		for _, pkg := range pkgs {
+			spec := manager + ":" + pkg
+			tracked[spec] = true
+			if checked, ok := verified.fresh(spec, maxAge, now); ok {
+				result = append(result, PackageStatus{Manager: manager, Name: pkg, State: StatusManaged, CheckedAt: checked})
+				continue
+			}
+			if managerErr != nil {
+				result = append(result, PackageStatus{Manager: manager, Name: pkg, State: StatusError, Err: managerErr})
+				continue
+			}
+
+			installed, err := mgr.IsInstalled(ctx, pkg)
+			if verified != nil {
+				if installed {
+					verified[spec] = now
+				} else {
+					delete(verified, spec)
+				}
+			}
+			switch {
+			case err != nil:
+				managerErr = err
+				result = append(result, PackageStatus{Manager: manager, Name: pkg, State: StatusError, Err: err})
+			case installed:
+				result = append(result, PackageStatus{Manager: manager, Name: pkg, State: StatusManaged})
+			default:
+				status := PackageStatus{Manager: manager, Name: pkg, State: StatusMissing}
+				if eq := installedEquivalent(ctx, manager, pkg); eq != "" {
+					status.State = StatusManaged
+					status.SatisfiedBy = eq
+				}
+				result = append(result, status)
+			}
+		}
+	}
+
+	for spec := range verified {
+		if !tracked[spec] {
+			delete(verified, spec)
+		}
+	}
+	return result, nil
+}
@@ -4,17 +4,80 @@
 package packages
 
 import (
-	"fmt"
+	"log"
+	"reflect"
 	"sync"
+
+	"github.com/richhaase/plonk/internal/config"
+	"github.com/richhaase/plonk/internal/signing"
 )
 
 var (
-	managerCache = make(map[string]Manager)
-	managerMu    sync.Mutex
+	managerCache     = make(map[string]Manager)
+	managerOptions   config.ManagersConfig
+	disabledManagers map[string]bool
+	managerMu        sync.Mutex
 )
 
-// ResetManagerCache clears the manager cache. Intended for testing purposes.
+// Configure applies the package settings from plonk.yaml: per-manager
+// options, aliases and disabled managers. On a machine that verifies
+// plonk.lock, manager options that could run unsigned code are dropped
+// (see config.ManagersConfig.Restricted), and so are aliases, which would
+// let an unsigned package stand in for a signed one.
+func Configure(cfg *config.Config) {
+	managers, aliases := cfg.Managers, cfg.Aliases
+	if signing.TrustFile() != "" {
+		restricted := managers.Restricted()
+		if !reflect.DeepEqual(restricted, managers) {
+			log.Printf("Warning: ignoring install_args, package_args, env, build options, registries and scopes under managers: in plonk.yaml: it isn't signed, and this machine verifies plonk.lock")
+		}
+		if len(aliases) > 0 {
+			log.Printf("Warning: ignoring aliases in plonk.yaml: it isn't signed, and this machine verifies plonk.lock")
+		}
+		managers, aliases = restricted, nil
+	}
+	ConfigureManagers(managers)
+	SetAliases(aliases)
+	SetDisabledManagers(cfg.ActiveDisabledManagers())
+}
+
+// ConfigureManagers sets the per-manager options from plonk.yaml used by
+// managers created from now on. Cached managers are dropped if the options
+// changed, so the next GetManager picks them up.
+func ConfigureManagers(opts config.ManagersConfig) {
+	managerMu.Lock()
+	defer managerMu.Unlock()
+	if reflect.DeepEqual(opts, managerOptions) {
+		return
+	}
+	managerOptions = opts
+	managerCache = make(map[string]Manager)
+}
+
+// SetDisabledManagers records managers the user never wants plonk to run.
+// They are left out of status, apply and plugin discovery, and report as
+// unavailable everywhere else.
+func SetDisabledManagers(names []string) {
+	disabled := make(map[string]bool, len(names))
+	for _, name := range names {
+		disabled[name] = true
+	}
+	managerMu.Lock()
+	defer managerMu.Unlock()
+	disabledManagers = disabled
+}
+
+// IsManagerDisabled reports whether name is listed in disabled_managers
+func IsManagerDisabled(name string) bool {
+	managerMu.Lock()
+	defer managerMu.Unlock()
+	return disabledManagers[name]
+}
+
+// ResetManagerCache clears the manager cache and the plugins PluginPath
+// found missing, as for plonk serve's refresh and for tests.
 func ResetManagerCache() {
+	forgetPluginMisses()
 	managerMu.Lock()
 	defer managerMu.Unlock()
 	managerCache = make(map[string]Manager)
@@ -30,21 +93,39 @@ func GetManager(name string) (Manager, error) {
 		return mgr, nil
 	}
 
-	// Create new manager
+	// Create new manager. Its environment comes from all the options, since
+	// settings such as managers.proxy apply to every manager.
 	var mgr Manager
+	env := ManagerEnv(name, managerOptions)
 	switch name {
 	case "brew":
-		mgr = NewBrewSimple()
+		brew := NewBrewSimple(managerOptions.Brew)
+		brew.env = env
+		mgr = brew
 	case "cargo":
-		mgr = NewCargoSimple()
+		cargo := NewCargoSimple(managerOptions.Cargo)
+		cargo.env = env
+		mgr = cargo
 	case "go":
-		mgr = NewGoSimple()
+		goMgr := NewGoSimple(managerOptions.Go)
+		goMgr.env = env
+		mgr = goMgr
 	case "pnpm":
-		mgr = NewPNPMSimple()
+		pnpm := NewPNPMSimple(managerOptions.PNPM)
+		pnpm.env = env
+		mgr = pnpm
 	case "uv":
-		mgr = NewUVSimple()
+		uv := NewUVSimple(managerOptions.UV)
+		uv.env = env
+		mgr = uv
 	default:
-		return nil, fmt.Errorf("unsupported package manager: %s (supported: %v)", name, SupportedManagers)
+		path := PluginPath(name)
+		if path == "" {
+			return nil, UnsupportedManagerError(name)
+		}
+		plugin := NewPluginManager(name, path)
+		plugin.env = env
+		mgr = plugin
 	}
 
 	// Cache and return
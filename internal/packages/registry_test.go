@@ -0,0 +1,114 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package packages
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/richhaase/plonk/internal/config"
+	"github.com/richhaase/plonk/internal/lock"
+	"github.com/richhaase/plonk/internal/signing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDisabledManagers(t *testing.T) {
+	ResetManagerCache()
+	t.Cleanup(ResetManagerCache)
+	t.Cleanup(func() { SetDisabledManagers(nil) })
+	fakePlugin(t, "fake")
+	fakePlugin(t, "other")
+
+	configDir := t.TempDir()
+	l := lock.NewLockV3()
+	l.AddPackage("fake", "beta")
+	l.AddPackage("other", "alpha")
+	require.NoError(t, lock.NewLockV3Service(configDir).Write(l))
+
+	SetDisabledManagers([]string{"fake"})
+	assert.True(t, IsManagerDisabled("fake"))
+	assert.False(t, IsManagerDisabled("other"))
+
+	var unavailable *UnavailableManagerError
+	require.True(t, errors.As(CheckManagerAvailable("fake"), &unavailable))
+	assert.Equal(t, "manager disabled in plonk.yaml", unavailable.Reason)
+	assert.NotContains(t, DiscoverPlugins(), "fake")
+	assert.Contains(t, DiscoverPlugins(), "other")
+
+	// Packages of a disabled manager are neither reported nor installed
+	statuses, err := Reconcile(context.Background(), configDir)
+	require.NoError(t, err)
+	require.Len(t, statuses, 1)
+	assert.Equal(t, "other", statuses[0].Manager)
+
+	result, err := SimpleApply(context.Background(), configDir, true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"other:alpha"}, result.Skipped)
+	assert.Empty(t, result.Failed)
+}
+
+func TestConfigure_RestrictsUnsignedManagerOptions(t *testing.T) {
+	t.Cleanup(func() { ConfigureManagers(config.ManagersConfig{}) })
+	cfg := &config.Config{Managers: config.ManagersConfig{
+		Brew: config.BrewOptions{ManagerOptions: config.ManagerOptions{
+			InstallArgs: []string{"--force"},
+			Env:         map[string]string{"HOMEBREW_NO_ANALYTICS": "1"},
+			Proxy:       config.ProxyConfig{HTTPS: "http://proxy:8080"},
+		}},
+		PNPM: config.PNPMOptions{
+			ManagerOptions: config.ManagerOptions{PackageArgs: map[string][]string{"typescript": {"--ignore-scripts"}}},
+			Registry:       "https://npm.example.com",
+			Scopes:         map[string]config.PNPMScope{"@corp": {Registry: "https://npm.corp", TokenKeychain: "npm-corp"}},
+		},
+		UV: config.UVOptions{IndexURL: "https://pypi.example.com/simple"},
+	}}
+
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	t.Setenv(signing.TrustEnv, "")
+	Configure(cfg)
+	assert.Equal(t, cfg.Managers, managerOptions, "unverified machines use every option")
+
+	t.Setenv(signing.TrustEnv, "/etc/plonk/allowed_signers")
+	Configure(cfg)
+	assert.Equal(t, config.ManagersConfig{
+		Brew: config.BrewOptions{ManagerOptions: config.ManagerOptions{Proxy: config.ProxyConfig{HTTPS: "http://proxy:8080"}}},
+	}, managerOptions)
+}
+
+func TestConfigure_DropsUnsignedBuildChoices(t *testing.T) {
+	t.Cleanup(func() { ConfigureManagers(config.ManagersConfig{}) })
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	t.Setenv(signing.TrustEnv, "/etc/plonk/allowed_signers")
+
+	t.Run("brew formula options", func(t *testing.T) {
+		Configure(&config.Config{Managers: config.ManagersConfig{Brew: config.BrewOptions{
+			Packages: map[string]config.BrewPackageOptions{"ffmpeg": {Head: true, BuildFromSource: true, Options: []string{"--with-libvpx"}}},
+		}}})
+		assert.Empty(t, managerOptions.Brew.Packages)
+	})
+
+	t.Run("cargo features and locked", func(t *testing.T) {
+		Configure(&config.Config{Managers: config.ManagersConfig{Cargo: config.CargoOptions{
+			Locked: true, Features: []string{"pcre2"},
+		}}})
+		assert.False(t, managerOptions.Cargo.Locked)
+		assert.Empty(t, managerOptions.Cargo.Features)
+	})
+}
+
+func TestConfigure_IgnoresUnsignedAliases(t *testing.T) {
+	t.Cleanup(func() { SetAliases(nil) })
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	cfg := &config.Config{Aliases: map[string][]string{"rg": {"brew:ripgrep", "cargo:ripgrep"}}}
+
+	t.Setenv(signing.TrustEnv, "")
+	Configure(cfg)
+	assert.Equal(t, []string{"cargo:ripgrep"}, Equivalents("brew:ripgrep"))
+
+	t.Setenv(signing.TrustEnv, "/etc/plonk/allowed_signers")
+	Configure(cfg)
+	assert.Empty(t, Equivalents("brew:ripgrep"), "an unsigned alias can't satisfy a signed lock entry")
+}
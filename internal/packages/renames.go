@@ -0,0 +1,163 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package packages
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+)
+
+// Rename reasons
+const (
+	RenameRenamed    = "renamed"    // the manager moved the package to a new name
+	RenameDeprecated = "deprecated" // still installable, with a named replacement
+	RenameDisabled   = "disabled"   // no longer installable, with a named replacement
+)
+
+// Rename is a tracked package that its manager now publishes under another
+// name
+type Rename struct {
+	Manager string
+	Old     string
+	New     string
+	Reason  string // RenameRenamed, RenameDeprecated or RenameDisabled
+}
+
+// RenameDetector is implemented by managers that know when a package has
+// been renamed or retired in favor of another
+type RenameDetector interface {
+	Renames(ctx context.Context, names []string) ([]Rename, error)
+}
+
+// DetectRenames reports which of names manager has renamed or retired.
+// Managers that cannot tell, or are unavailable, report none.
+func DetectRenames(ctx context.Context, manager string, names []string) ([]Rename, error) {
+	detector := renameDetector(manager)
+	if len(names) == 0 || detector == nil {
+		return nil, nil
+	}
+	return detector.Renames(ctx, names)
+}
+
+// renameDetector returns manager's rename detection, or nil when it has
+// none or is unavailable
+func renameDetector(manager string) RenameDetector {
+	if CheckManagerAvailable(manager) != nil {
+		return nil
+	}
+	mgr, err := GetManager(manager)
+	if err != nil {
+		return nil
+	}
+	detector, _ := mgr.(RenameDetector)
+	return detector
+}
+
+// Renames reports which of names brew has renamed or retired
+func (b *BrewSimple) Renames(ctx context.Context, names []string) ([]Rename, error) {
+	var renames []Rename
+	err := b.info(ctx, names, func(names []string, out []byte) error {
+		found, err := parseBrewRenames(names, out)
+		renames = append(renames, found...)
+		return err
+	})
+	return renames, err
+}
+
+// info runs `brew info --json=v2` for names in one call and passes its
+// output to parse, falling back to one call per name when brew rejects the
+// batch (an unknown name fails the whole call). Names brew doesn't know are
+// left out.
+func (b *BrewSimple) info(ctx context.Context, names []string, parse func(names []string, out []byte) error) error {
+	out, err := managerCommand(ctx, b.env, "brew", append([]string{"info", "--json=v2", "--"}, names...)...).Output()
+	if err == nil {
+		return parse(names, out)
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	for _, name := range names {
+		out, err := managerCommand(ctx, b.env, "brew", "info", "--json=v2", "--", name).Output()
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			continue
+		}
+		if err := parse([]string{name}, out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// brewInfoEntry holds the fields of `brew info --json=v2` that say whether
+// a formula or cask was renamed or retired. Replacement fields moved from
+// *_replacement to *_replacement_formula/_cask in Homebrew 4.5; both are read.
+type brewInfoEntry struct {
+	Name                          string   `json:"name"`
+	FullName                      string   `json:"full_name"`
+	Token                         string   `json:"token"`
+	FullToken                     string   `json:"full_token"`
+	Oldnames                      []string `json:"oldnames"`
+	OldTokens                     []string `json:"old_tokens"`
+	Deprecated                    bool     `json:"deprecated"`
+	Disabled                      bool     `json:"disabled"`
+	DeprecationReplacement        string   `json:"deprecation_replacement"`
+	DeprecationReplacementFormula string   `json:"deprecation_replacement_formula"`
+	DeprecationReplacementCask    string   `json:"deprecation_replacement_cask"`
+	DisableReplacement            string   `json:"disable_replacement"`
+	DisableReplacementFormula     string   `json:"disable_replacement_formula"`
+	DisableReplacementCask        string   `json:"disable_replacement_cask"`
+}
+
+// parseBrewRenames matches `brew info --json=v2` output back to the names
+// that were asked about and reports those that are renamed or retired
+func parseBrewRenames(names []string, data []byte) ([]Rename, error) {
+	var info struct {
+		Formulae []brewInfoEntry `json:"formulae"`
+		Casks    []brewInfoEntry `json:"casks"`
+	}
+	if err := json.Unmarshal(data, &info); err != nil {
+		return nil, err
+	}
+	entries := info.Formulae
+	for _, c := range info.Casks {
+		c.Name, c.FullName, c.Oldnames = c.Token, c.FullToken, c.OldTokens
+		entries = append(entries, c)
+	}
+
+	var renames []Rename
+	for _, name := range names {
+		short := name[strings.LastIndex(name, "/")+1:]
+		for _, e := range entries {
+			current := e.Name
+			if strings.Contains(name, "/") && e.FullName != "" {
+				current = e.FullName
+			}
+
+			var rename Rename
+			switch {
+			case slices.Contains(e.Oldnames, short):
+				rename = Rename{New: current, Reason: RenameRenamed}
+			case short != e.Name:
+				continue
+			case e.Disabled:
+				rename = Rename{New: cmp.Or(e.DisableReplacementFormula, e.DisableReplacementCask, e.DisableReplacement), Reason: RenameDisabled}
+			case e.Deprecated:
+				rename = Rename{New: cmp.Or(e.DeprecationReplacementFormula, e.DeprecationReplacementCask, e.DeprecationReplacement), Reason: RenameDeprecated}
+			}
+			if rename.New != "" && rename.New != name {
+				rename.Manager, rename.Old = "brew", name
+				renames = append(renames, rename)
+			}
+			break
+		}
+	}
+	return renames, nil
+}
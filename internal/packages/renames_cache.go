@@ -0,0 +1,114 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package packages
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/richhaase/plonk/internal/config"
+)
+
+// renamesFileName caches rename lookups in the state directory, so status
+// asks a manager about a missing package at most once per RenameCacheTTL
+const renamesFileName = "renames.json"
+
+// RenameCacheTTL bounds how long a cached rename lookup is trusted. Renames
+// only come with manager updates, so a day is plenty.
+const RenameCacheTTL = 24 * time.Hour
+
+// renameEntry is one cached lookup; New is empty when the package was not
+// renamed
+type renameEntry struct {
+	New       string    `json:"new,omitempty"`
+	Reason    string    `json:"reason,omitempty"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// RenamesPath returns the rename cache's path
+func RenamesPath() string {
+	return filepath.Join(config.GetStateDirectory(), renamesFileName)
+}
+
+// DetectRenamesCached is DetectRenames with each name's answer, renamed or
+// not, cached at path for RenameCacheTTL. plonk fix --renames asks afresh.
+func DetectRenamesCached(ctx context.Context, path, manager string, names []string) ([]Rename, error) {
+	detector := renameDetector(manager)
+	if len(names) == 0 || detector == nil {
+		return nil, nil
+	}
+	return cachedRenames(ctx, path, manager, names, time.Now(), detector.Renames)
+}
+
+// cachedRenames answers from the cache at path where it can, as of now,
+// and asks detect about the rest
+func cachedRenames(ctx context.Context, path, manager string, names []string, now time.Time, detect func(context.Context, []string) ([]Rename, error)) ([]Rename, error) {
+	cache := loadRenames(path)
+	var renames []Rename
+	var unknown []string
+	for _, name := range names {
+		entry, ok := cache[manager+":"+name]
+		if !ok || now.Sub(entry.CheckedAt) > RenameCacheTTL {
+			unknown = append(unknown, name)
+			continue
+		}
+		if entry.New != "" {
+			renames = append(renames, Rename{Manager: manager, Old: name, New: entry.New, Reason: entry.Reason})
+		}
+	}
+	if len(unknown) == 0 {
+		return renames, nil
+	}
+
+	found, err := detect(ctx, unknown)
+	if err != nil {
+		return renames, err
+	}
+	for _, name := range unknown {
+		cache[manager+":"+name] = renameEntry{CheckedAt: now.UTC()}
+	}
+	for _, r := range found {
+		cache[r.Manager+":"+r.Old] = renameEntry{New: r.New, Reason: r.Reason, CheckedAt: now.UTC()}
+	}
+	for key, entry := range cache {
+		if now.Sub(entry.CheckedAt) > RenameCacheTTL {
+			delete(cache, key)
+		}
+	}
+	// The cache only saves work, so failing to write it is ignored
+	_ = saveRenames(path, cache)
+	return append(renames, found...), nil
+}
+
+// loadRenames reads the rename cache; a missing or unreadable one is empty
+func loadRenames(path string) map[string]renameEntry {
+	cache := map[string]renameEntry{}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cache
+	}
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return map[string]renameEntry{}
+	}
+	return cache
+}
+
+// saveRenames atomically writes the rename cache
+func saveRenames(path string, cache map[string]renameEntry) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package packages
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBrewRenames(t *testing.T) {
+	data := []byte(`{
+  "formulae": [
+    {"name": "eza", "full_name": "eza", "oldnames": [], "deprecated": false, "disabled": false},
+    {"name": "exa", "full_name": "exa", "oldnames": [], "disabled": true, "disable_replacement_formula": "eza"},
+    {"name": "youtube-dl", "full_name": "youtube-dl", "oldnames": [], "deprecated": true, "deprecation_replacement": "yt-dlp"},
+    {"name": "httpie", "full_name": "httpie", "oldnames": ["http"]},
+    {"name": "jq", "full_name": "jq", "deprecated": true}
+  ],
+  "casks": [
+    {"token": "visual-studio-code", "full_token": "visual-studio-code", "old_tokens": ["vscode"]}
+  ]
+}`)
+	names := []string{"eza", "exa", "youtube-dl", "http", "jq", "vscode", "unknown"}
+
+	renames, err := parseBrewRenames(names, data)
+	require.NoError(t, err)
+	assert.Equal(t, []Rename{
+		{Manager: "brew", Old: "exa", New: "eza", Reason: RenameDisabled},
+		{Manager: "brew", Old: "youtube-dl", New: "yt-dlp", Reason: RenameDeprecated},
+		{Manager: "brew", Old: "http", New: "httpie", Reason: RenameRenamed},
+		{Manager: "brew", Old: "vscode", New: "visual-studio-code", Reason: RenameRenamed},
+	}, renames)
+
+	_, err = parseBrewRenames(names, []byte("not json"))
+	assert.Error(t, err)
+}
+
+func TestDetectRenames_UnsupportedManager(t *testing.T) {
+	ResetManagerCache()
+	t.Cleanup(ResetManagerCache)
+	fakePlugin(t, "fake")
+
+	// Plugins have no rename support
+	renames, err := DetectRenames(context.Background(), "fake", []string{"alpha"})
+	require.NoError(t, err)
+	assert.Empty(t, renames)
+}
+
+func TestCachedRenames(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "renames.json")
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	var asked [][]string
+	detect := func(_ context.Context, names []string) ([]Rename, error) {
+		asked = append(asked, names)
+		return []Rename{{Manager: "brew", Old: "exa", New: "eza", Reason: RenameDisabled}}, nil
+	}
+
+	renames, err := cachedRenames(context.Background(), path, "brew", []string{"exa", "jq"}, now, detect)
+	require.NoError(t, err)
+	assert.Equal(t, []Rename{{Manager: "brew", Old: "exa", New: "eza", Reason: RenameDisabled}}, renames)
+
+	// Both answers, renamed or not, come from the cache within the TTL
+	renames, err = cachedRenames(context.Background(), path, "brew", []string{"exa", "jq"}, now.Add(time.Hour), detect)
+	require.NoError(t, err)
+	assert.Equal(t, []Rename{{Manager: "brew", Old: "exa", New: "eza", Reason: RenameDisabled}}, renames)
+	assert.Equal(t, [][]string{{"exa", "jq"}}, asked)
+
+	// A new name is asked about alone; expired answers are asked again
+	_, err = cachedRenames(context.Background(), path, "brew", []string{"exa", "fd"}, now.Add(time.Hour), detect)
+	require.NoError(t, err)
+	_, err = cachedRenames(context.Background(), path, "brew", []string{"jq"}, now.Add(RenameCacheTTL+time.Hour), detect)
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"exa", "jq"}, {"fd"}, {"jq"}}, asked)
+
+	// Failed lookups are not cached
+	failing := func(context.Context, []string) ([]Rename, error) { return nil, errors.New("brew failed") }
+	_, err = cachedRenames(context.Background(), path, "brew", []string{"bat"}, now, failing)
+	assert.Error(t, err)
+	assert.NotContains(t, loadRenames(path), "brew:bat")
+}
@@ -0,0 +1,149 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package packages
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/richhaase/plonk/internal/manifest"
+)
+
+// specifierClause matches one comparison of a version specifier
+var specifierClause = regexp.MustCompile(`^(===|==|!=|~=|>=|<=|>|<)\s*([0-9][0-9A-Za-z.*+!-]*)$`)
+
+// parseRequirement splits a uv package written as a pip requirement, e.g.
+// ansible[azure]>=9,<10, into its parts; a plain name has no extras or
+// specifier
+func parseRequirement(name string) manifest.Requirement {
+	if req, ok := manifest.ParseRequirement(name); ok {
+		return req
+	}
+	return manifest.Requirement{Name: name}
+}
+
+// specifierSatisfiedBy reports whether version meets every clause of
+// specifier. Only release numbers are compared (pre-release and local
+// suffixes are ignored), which is enough to tell whether an installed tool
+// is in range. An unknown version or an unparsable clause is treated as
+// satisfied so that status never flags a package it can't judge.
+func specifierSatisfiedBy(specifier, version string) bool {
+	if specifier == "" || version == "" {
+		return true
+	}
+	for _, clause := range strings.Split(specifier, ",") {
+		m := specifierClause.FindStringSubmatch(clause)
+		if m == nil {
+			continue
+		}
+		if !versionMatches(m[1], m[2], version) {
+			return false
+		}
+	}
+	return true
+}
+
+// versionMatches applies one specifier clause (op and want) to version
+func versionMatches(op, want, version string) bool {
+	if prefix, ok := strings.CutSuffix(want, ".*"); ok && (op == "==" || op == "!=") {
+		in := hasReleasePrefix(releaseNumbers(version), releaseNumbers(prefix))
+		return in == (op == "==")
+	}
+
+	cmp := compareReleases(releaseNumbers(version), releaseNumbers(want))
+	switch op {
+	case "==", "===":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case ">=":
+		return cmp >= 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case "<":
+		return cmp < 0
+	case "~=":
+		// ~=2.2 means >=2.2, ==2.*
+		nums := releaseNumbers(want)
+		if len(nums) < 2 {
+			return cmp >= 0
+		}
+		return cmp >= 0 && hasReleasePrefix(releaseNumbers(version), nums[:len(nums)-1])
+	}
+	return true
+}
+
+// releaseNumbers returns the numeric release segments of a version:
+// "v10.0.1rc1" -> [10 0 1]
+func releaseNumbers(version string) []int {
+	version = strings.TrimPrefix(version, "v")
+	if _, after, ok := strings.Cut(version, "!"); ok {
+		version = after // drop the epoch
+	}
+	var nums []int
+	for _, part := range strings.Split(version, ".") {
+		end := 0
+		for end < len(part) && part[end] >= '0' && part[end] <= '9' {
+			end++
+		}
+		if end == 0 {
+			break
+		}
+		n, _ := strconv.Atoi(part[:end])
+		nums = append(nums, n)
+		if end < len(part) {
+			break // pre-release, post-release or local suffix
+		}
+	}
+	return nums
+}
+
+// compareReleases compares release numbers, padding the shorter with zeros
+func compareReleases(a, b []int) int {
+	for i := 0; i < max(len(a), len(b)); i++ {
+		var x, y int
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// hasReleasePrefix reports whether version starts with the release numbers
+// of prefix
+func hasReleasePrefix(version, prefix []int) bool {
+	for i, n := range prefix {
+		v := 0
+		if i < len(version) {
+			v = version[i]
+		}
+		if v != n {
+			return false
+		}
+	}
+	return true
+}
+
+// VersionSatisfies reports whether version meets specifier, a
+// comma-separated list of pip-style clauses such as ">=1.6,<2". A bare
+// version, "1.6", means that release series (==1.6.*).
+func VersionSatisfies(specifier, version string) bool {
+	specifier = strings.ReplaceAll(specifier, " ", "")
+	if specifier != "" && specifier[0] >= '0' && specifier[0] <= '9' {
+		specifier = "==" + strings.TrimSuffix(specifier, ".*") + ".*"
+	}
+	return specifierSatisfiedBy(specifier, version)
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package packages
+
+import (
+	"testing"
+
+	"github.com/richhaase/plonk/internal/manifest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRequirement(t *testing.T) {
+	assert.Equal(t, manifest.Requirement{Name: "ansible", Extras: "[azure]", Specifier: ">=9,<10"}, parseRequirement("ansible[azure]>=9,<10"))
+	assert.Equal(t, manifest.Requirement{Name: "@scope/pkg"}, parseRequirement("@scope/pkg"), "names that aren't requirements are kept whole")
+}
+
+func TestSpecifierSatisfiedBy(t *testing.T) {
+	tests := []struct {
+		specifier string
+		version   string
+		want      bool
+	}{
+		{"", "1.0", true},
+		{">=9,<10", "9.1.0", true},
+		{">=9,<10", "10.0.0", false},
+		{">=9,<10", "8.9", false},
+		{"==9.1", "9.1.0", true},
+		{"==9.*", "9.4.2", true},
+		{"==9.*", "10.0", false},
+		{"!=9.*", "10.0", true},
+		{"~=2.2", "2.9", true},
+		{"~=2.2", "3.0", false},
+		{"~=2.2.1", "2.2.5", true},
+		{"~=2.2.1", "2.3.0", false},
+		{">1.0", "1.0.0", false},
+		{"<=1.0", "1.0.0rc1", true},
+		{">=9", "", true},           // unknown version
+		{">=9,@weird", "9.0", true}, // unparsable clause ignored
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, specifierSatisfiedBy(tt.specifier, tt.version), "%s against %s", tt.version, tt.specifier)
+	}
+}
+
+func TestVersionSatisfies(t *testing.T) {
+	assert.True(t, VersionSatisfies(">= 1.6, <2", "1.9.8"))
+	assert.False(t, VersionSatisfies(">=1.6,<2", "2.0.0"))
+	assert.True(t, VersionSatisfies("1.6", "1.6.3"))
+	assert.False(t, VersionSatisfies("1.6", "1.7.0"))
+	assert.True(t, VersionSatisfies("1.6.*", "1.6.0"))
+}
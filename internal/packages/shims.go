@@ -0,0 +1,208 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package packages
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+
+	"github.com/richhaase/plonk/internal/lock"
+)
+
+// The shim directory holds one symlink per command of a tracked package,
+// pointing at the copy its tracking manager installed. With the directory
+// first on PATH, a command that several managers provide (ripgrep from
+// brew and from cargo) always runs the tracked copy, whatever order the
+// managers' own directories have on PATH.
+
+// Shim links a command to the copy installed by the package that provides it
+type Shim struct {
+	Binary   string   // command name, e.g. "rg"
+	Spec     string   // tracked package providing it, "brew:ripgrep"
+	Target   string   // the manager's copy, e.g. /opt/homebrew/bin/rg
+	Shadowed []string // other tracked packages with the same command
+}
+
+// ShimChanges lists what WriteShims changed, by command
+type ShimChanges struct {
+	Added   []string
+	Updated []string
+	Removed []string
+	Kept    []string // files in the shim directory that plonk did not create
+}
+
+// PlanShims finds the command of each tracked package in the directories
+// its manager installs to and picks one package per command: the first of
+// order (the manager priority), then the others by name. Packages whose
+// command can't be found are returned in unresolved.
+func PlanShims(lockFile *lock.LockV3, order []string, pathEnv, shimDir string) (shims []Shim, unresolved []string) {
+	managers := sortedManagers(lockFile.Packages)
+	sort.SliceStable(managers, func(i, j int) bool {
+		return managerRank(order, managers[i]) < managerRank(order, managers[j])
+	})
+
+	byBinary := make(map[string]int)
+	for _, manager := range managers {
+		dirs := managerSearchDirs(manager, pathEnv, shimDir)
+		for _, name := range lockFile.Packages[manager] {
+			spec := manager + ":" + name
+			binary := binaryName(manager, name)
+			if i, ok := byBinary[binary]; ok {
+				shims[i].Shadowed = append(shims[i].Shadowed, spec)
+				continue
+			}
+			target := findManagedBinary(manager, binary, dirs)
+			if target == "" {
+				unresolved = append(unresolved, spec)
+				continue
+			}
+			byBinary[binary] = len(shims)
+			shims = append(shims, Shim{Binary: binary, Spec: spec, Target: target})
+		}
+	}
+
+	sort.Slice(shims, func(i, j int) bool { return shims[i].Binary < shims[j].Binary })
+	return shims, unresolved
+}
+
+// managerRank is where manager comes in order, after every listed manager
+// if it isn't listed
+func managerRank(order []string, manager string) int {
+	if i := slices.Index(order, manager); i >= 0 {
+		return i
+	}
+	return len(order)
+}
+
+// managerSearchDirs lists where to look for a manager's commands: its own
+// bin directory when it has a fixed one, then PATH without the shim
+// directory
+func managerSearchDirs(manager, pathEnv, shimDir string) []string {
+	var dirs []string
+	switch manager {
+	case "go":
+		if dir := goBinDir(nil); dir != "" {
+			dirs = append(dirs, dir)
+		}
+	case "cargo":
+		if cargoHome := os.Getenv("CARGO_HOME"); cargoHome != "" {
+			dirs = append(dirs, filepath.Join(cargoHome, "bin"))
+		} else if home, err := os.UserHomeDir(); err == nil {
+			dirs = append(dirs, filepath.Join(home, ".cargo", "bin"))
+		}
+	}
+	for _, dir := range filepath.SplitList(pathEnv) {
+		if dir != "" && filepath.Clean(dir) != filepath.Clean(shimDir) {
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
+
+// findManagedBinary returns the first executable binary in dirs that lies
+// where manager installs commands
+func findManagedBinary(manager, binary string, dirs []string) string {
+	for _, dir := range dirs {
+		path := filepath.Join(dir, binary)
+		info, err := os.Stat(path)
+		if err != nil || !info.Mode().IsRegular() || info.Mode().Perm()&0o111 == 0 {
+			continue
+		}
+		if ownsPath(manager, path) {
+			return path
+		}
+		if resolved, err := filepath.EvalSymlinks(path); err == nil && ownsPath(manager, resolved) {
+			return path
+		}
+	}
+	return ""
+}
+
+// WriteShims makes dir hold exactly shims: missing links are added, links
+// pointing elsewhere are updated, and links for commands no longer shimmed
+// are removed. Anything in dir that isn't a symlink is left alone.
+func WriteShims(dir string, shims []Shim, dryRun bool) (ShimChanges, error) {
+	var changes ShimChanges
+
+	existing := make(map[string]string)
+	entries, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return changes, err
+	}
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		if entry.Type()&os.ModeSymlink == 0 {
+			changes.Kept = append(changes.Kept, entry.Name())
+			continue
+		}
+		target, err := os.Readlink(path)
+		if err != nil {
+			return changes, err
+		}
+		existing[entry.Name()] = target
+	}
+
+	if !dryRun {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return changes, err
+		}
+	}
+
+	wanted := make(map[string]bool, len(shims))
+	for _, shim := range shims {
+		wanted[shim.Binary] = true
+		if slices.Contains(changes.Kept, shim.Binary) {
+			continue
+		}
+		current, ok := existing[shim.Binary]
+		if ok && current == shim.Target {
+			continue
+		}
+		if ok {
+			changes.Updated = append(changes.Updated, shim.Binary)
+		} else {
+			changes.Added = append(changes.Added, shim.Binary)
+		}
+		if dryRun {
+			continue
+		}
+		path := filepath.Join(dir, shim.Binary)
+		if ok {
+			if err := os.Remove(path); err != nil {
+				return changes, err
+			}
+		}
+		if err := os.Symlink(shim.Target, path); err != nil {
+			return changes, fmt.Errorf("failed to link %s: %w", shim.Binary, err)
+		}
+	}
+
+	for name := range existing {
+		if wanted[name] {
+			continue
+		}
+		changes.Removed = append(changes.Removed, name)
+		if !dryRun {
+			if err := os.Remove(filepath.Join(dir, name)); err != nil {
+				return changes, err
+			}
+		}
+	}
+	sort.Strings(changes.Removed)
+	return changes, nil
+}
+
+// ShimDirFirst reports whether dir is the first directory on PATH, so its
+// shims win over every manager's own directory
+func ShimDirFirst(pathEnv, dir string) bool {
+	for _, entry := range filepath.SplitList(pathEnv) {
+		if entry != "" {
+			return filepath.Clean(entry) == filepath.Clean(dir)
+		}
+	}
+	return false
+}
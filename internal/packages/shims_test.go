@@ -0,0 +1,77 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package packages
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/richhaase/plonk/internal/lock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanAndWriteShims(t *testing.T) {
+	root := t.TempDir()
+	brewBin := filepath.Join(root, "linuxbrew", "bin")
+	cargoHome := filepath.Join(root, "cargo")
+	t.Setenv("CARGO_HOME", cargoHome)
+	require.NoError(t, os.MkdirAll(brewBin, 0o755))
+	require.NoError(t, os.MkdirAll(filepath.Join(cargoHome, "bin"), 0o755))
+	writeExecutable(t, brewBin, "rg")
+	writeExecutable(t, brewBin, "fd")
+	writeExecutable(t, filepath.Join(cargoHome, "bin"), "rg")
+
+	l := lock.NewLockV3()
+	l.AddPackage("brew", "ripgrep")
+	l.AddPackage("brew", "fd")
+	l.AddPackage("cargo", "ripgrep")
+	l.AddPackage("brew", "not-installed")
+
+	shimDir := filepath.Join(root, "shims")
+	pathEnv := strings.Join([]string{shimDir, brewBin}, string(os.PathListSeparator))
+
+	// cargo first in the priority wins rg, even with only brew on PATH
+	shims, unresolved := PlanShims(l, []string{"cargo", "brew"}, pathEnv, shimDir)
+	require.Len(t, shims, 2)
+	assert.Equal(t, Shim{Binary: "fd", Spec: "brew:fd", Target: filepath.Join(brewBin, "fd")}, shims[0])
+	assert.Equal(t, Shim{Binary: "rg", Spec: "cargo:ripgrep", Target: filepath.Join(cargoHome, "bin", "rg"), Shadowed: []string{"brew:ripgrep"}}, shims[1])
+	assert.Equal(t, []string{"brew:not-installed"}, unresolved)
+
+	changes, err := WriteShims(shimDir, shims, true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"fd", "rg"}, changes.Added)
+	_, err = os.Stat(shimDir)
+	assert.True(t, os.IsNotExist(err), "dry run created the shim directory")
+
+	_, err = WriteShims(shimDir, shims, false)
+	require.NoError(t, err)
+	target, err := os.Readlink(filepath.Join(shimDir, "rg"))
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(cargoHome, "bin", "rg"), target)
+
+	// brew first now: rg is relinked, a dropped package's shim removed, and
+	// a file plonk didn't make is left alone
+	require.NoError(t, os.WriteFile(filepath.Join(shimDir, "mine"), []byte("x"), 0o755))
+	l.RemovePackage("brew", "fd")
+	shims, _ = PlanShims(l, []string{"brew"}, pathEnv, shimDir)
+	changes, err = WriteShims(shimDir, shims, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"rg"}, changes.Updated)
+	assert.Equal(t, []string{"fd"}, changes.Removed)
+	assert.Equal(t, []string{"mine"}, changes.Kept)
+	target, err = os.Readlink(filepath.Join(shimDir, "rg"))
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(brewBin, "rg"), target)
+	assert.FileExists(t, filepath.Join(shimDir, "mine"))
+}
+
+func TestShimDirFirst(t *testing.T) {
+	sep := string(os.PathListSeparator)
+	assert.True(t, ShimDirFirst("/shims/"+sep+"/usr/bin", "/shims"))
+	assert.False(t, ShimDirFirst("/usr/bin"+sep+"/shims", "/shims"))
+	assert.False(t, ShimDirFirst("", "/shims"))
+}
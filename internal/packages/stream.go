@@ -0,0 +1,97 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package packages
+
+import (
+	"bytes"
+	"os/exec"
+	"sync"
+	"sync/atomic"
+
+	"github.com/richhaase/plonk/internal/output"
+)
+
+// streamOutput controls whether installer output is echoed while it runs
+var streamOutput atomic.Bool
+
+// streamLine displays one streamed line; replaced in tests
+var streamLine = output.StreamLine
+
+// SetStreamOutput enables or disables streaming of package manager output
+// during installs. Output is always captured for error reporting; when
+// streaming is on, each line is also shown as it arrives, prefixed with the
+// package being installed, so long builds don't look hung.
+func SetStreamOutput(enabled bool) {
+	streamOutput.Store(enabled)
+}
+
+// runInstallCommand runs an install subprocess and returns its combined
+// output. prefix labels streamed lines, e.g. "brew:ripgrep". A failure is
+// a *CommandError, so failure reports can name the command.
+func runInstallCommand(cmd *exec.Cmd, prefix string) ([]byte, error) {
+	var out []byte
+	var err error
+	if streamOutput.Load() {
+		var buf bytes.Buffer
+		lw := &lineWriter{prefix: prefix, captured: &buf}
+		cmd.Stdout = lw
+		cmd.Stderr = lw
+		err = cmd.Run()
+		lw.flush()
+		out = buf.Bytes()
+	} else {
+		out, err = cmd.CombinedOutput()
+	}
+	if err != nil {
+		return out, &CommandError{Args: cmd.Args, Output: out, Err: err}
+	}
+	return out, nil
+}
+
+// lineWriter captures everything written to it and streams complete lines.
+// Carriage-return progress redraws (download bars) collapse to their final
+// state instead of producing one line per redraw.
+type lineWriter struct {
+	mu       sync.Mutex
+	prefix   string
+	captured *bytes.Buffer
+	pending  []byte
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.captured.Write(p)
+	w.pending = append(w.pending, p...)
+	for {
+		i := bytes.IndexByte(w.pending, '\n')
+		if i < 0 {
+			break
+		}
+		w.emit(w.pending[:i])
+		w.pending = w.pending[i+1:]
+	}
+	return len(p), nil
+}
+
+func (w *lineWriter) flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.pending) > 0 {
+		w.emit(w.pending)
+		w.pending = nil
+	}
+}
+
+func (w *lineWriter) emit(line []byte) {
+	if i := bytes.LastIndexByte(bytes.TrimRight(line, "\r"), '\r'); i >= 0 {
+		line = line[i+1:]
+	}
+	line = bytes.TrimRight(line, "\r")
+	if len(bytes.TrimSpace(line)) == 0 {
+		return
+	}
+	streamLine(w.prefix, string(line))
+}
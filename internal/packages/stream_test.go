@@ -0,0 +1,45 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package packages
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func captureStreamedLines(t *testing.T) *[]string {
+	t.Helper()
+	var lines []string
+	orig := streamLine
+	streamLine = func(prefix, line string) { lines = append(lines, prefix+" "+line) }
+	t.Cleanup(func() {
+		streamLine = orig
+		SetStreamOutput(false)
+	})
+	return &lines
+}
+
+func TestRunInstallCommand_Streams(t *testing.T) {
+	lines := captureStreamedLines(t)
+	SetStreamOutput(true)
+
+	script := `printf 'Compiling foo\n'; printf ' 10%%\r 50%%\r100%%\n' >&2; printf 'done'`
+	out, err := runInstallCommand(exec.Command("sh", "-c", script), "cargo:foo")
+	require.NoError(t, err)
+
+	assert.Contains(t, string(out), "Compiling foo", "output is still captured for error reporting")
+	assert.Equal(t, []string{"cargo:foo Compiling foo", "cargo:foo 100%", "cargo:foo done"}, *lines)
+}
+
+func TestRunInstallCommand_QuietByDefault(t *testing.T) {
+	lines := captureStreamedLines(t)
+
+	out, err := runInstallCommand(exec.Command("sh", "-c", "echo hello; exit 3"), "brew:x")
+	require.Error(t, err)
+	assert.Equal(t, "hello\n", string(out))
+	assert.Empty(t, *lines)
+}
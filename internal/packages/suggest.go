@@ -0,0 +1,163 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package packages
+
+import (
+	"context"
+	"slices"
+	"sort"
+)
+
+// InstalledLister is implemented by managers that can name the packages
+// they have installed. The list comes from the same cache IsInstalled
+// fills, so asking after a lookup runs no further commands. Go is left
+// out: it only knows the commands in its bin directory, not the import
+// paths they were installed from.
+type InstalledLister interface {
+	Installed(ctx context.Context) ([]string, error)
+}
+
+// SuggestPackage returns the installed package of manager whose name is
+// closest to a mistyped name, or "" if none is near or the manager can't
+// list what it has installed
+func SuggestPackage(ctx context.Context, manager, name string) string {
+	mgr, err := GetManager(manager)
+	if err != nil {
+		return ""
+	}
+	lister, ok := mgr.(InstalledLister)
+	if !ok {
+		return ""
+	}
+	installed, err := lister.Installed(ctx)
+	if err != nil {
+		return ""
+	}
+	if near := Closest(name, installed); len(near) > 0 {
+		return near[0]
+	}
+	return ""
+}
+
+// Installed names the formulae and casks brew has installed
+func (b *BrewSimple) Installed(ctx context.Context) ([]string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.installed == nil {
+		if err := b.loadInstalled(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return sortedKeys(b.installed), nil
+}
+
+// Installed names the crates cargo has installed
+func (c *CargoSimple) Installed(ctx context.Context) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.installed == nil {
+		if err := c.loadInstalled(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return sortedKeys(c.installed), nil
+}
+
+// Installed names the packages pnpm has installed globally
+func (p *PNPMSimple) Installed(ctx context.Context) ([]string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.installed == nil {
+		if err := p.loadInstalled(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return sortedKeys(p.installed), nil
+}
+
+// Installed names the tools uv has installed
+func (u *UVSimple) Installed(ctx context.Context) ([]string, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.installed == nil {
+		if err := u.loadInstalled(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return sortedKeys(u.installed), nil
+}
+
+// sortedKeys returns the keys of m in order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// suggestManager returns the supported manager or plugin closest to a
+// mistyped manager name, or ""
+func suggestManager(name string) string {
+	if near := Closest(name, append(slices.Clone(SupportedManagers), DiscoverPlugins()...)); len(near) > 0 {
+		return near[0]
+	}
+	return ""
+}
+
+// Closest returns the candidates near enough to word to be what was meant,
+// nearest first. Longer words tolerate more typos; a swapped pair of
+// letters counts as one.
+func Closest(word string, candidates []string) []string {
+	maxDistance := 1 + len(word)/5
+	distances := make(map[string]int)
+	for _, c := range candidates {
+		if c == word {
+			continue
+		}
+		if d := editDistance(word, c); d <= maxDistance {
+			distances[c] = d
+		}
+	}
+
+	near := make([]string, 0, len(distances))
+	for c := range distances {
+		near = append(near, c)
+	}
+	sort.Slice(near, func(i, j int) bool {
+		if distances[near[i]] != distances[near[j]] {
+			return distances[near[i]] < distances[near[j]]
+		}
+		return near[i] < near[j]
+	})
+	return near
+}
+
+// editDistance counts the insertions, deletions, substitutions and swaps of
+// adjacent letters that turn a into b (optimal string alignment distance)
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(ra)][len(rb)]
+}
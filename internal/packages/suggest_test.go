@@ -0,0 +1,40 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package packages
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"brew", "brew", 0},
+		{"brewq", "brew", 1},
+		{"isntall", "install", 1}, // swapped letters
+		{"ripgrp", "ripgrep", 1},
+		{"go", "uv", 2},
+		{"", "uv", 2},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, editDistance(tt.a, tt.b), "%s -> %s", tt.a, tt.b)
+	}
+}
+
+func TestClosest(t *testing.T) {
+	candidates := []string{"ripgrep", "ripgrep-all", "fd", "bat", "fzf"}
+	assert.Equal(t, []string{"ripgrep"}, Closest("ripgrp", candidates))
+	assert.Equal(t, []string{"fd", "fzf"}, Closest("fz", candidates), "ties are alphabetical")
+	assert.Empty(t, Closest("ripgrep", candidates), "an exact match needs no suggestion")
+	assert.Empty(t, Closest("jq", candidates))
+}
+
+func TestUnsupportedManagerErrorSuggests(t *testing.T) {
+	assert.Contains(t, UnsupportedManagerError("brewq").Error(), "did you mean brew?")
+	assert.NotContains(t, UnsupportedManagerError("xyzzy").Error(), "did you mean")
+}
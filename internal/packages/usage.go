@@ -0,0 +1,126 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package packages
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// brewPrefixes are where Homebrew installs when HOMEBREW_PREFIX is unset
+var brewPrefixes = []string{"/opt/homebrew", "/usr/local", "/home/linuxbrew/.linuxbrew"}
+
+// ManagerDirs returns the existing directories a built-in manager installs
+// packages into, for measuring their size. Plugin managers have none.
+func ManagerDirs(manager string) []string {
+	home, _ := os.UserHomeDir()
+	var candidates []string
+	switch manager {
+	case "brew":
+		prefixes := brewPrefixes
+		if prefix := os.Getenv("HOMEBREW_PREFIX"); prefix != "" {
+			prefixes = []string{prefix}
+		}
+		for _, prefix := range prefixes {
+			if _, err := os.Stat(filepath.Join(prefix, "Cellar")); err == nil {
+				candidates = []string{filepath.Join(prefix, "Cellar"), filepath.Join(prefix, "Caskroom")}
+				break
+			}
+		}
+	case "cargo":
+		if cargoHome := os.Getenv("CARGO_HOME"); cargoHome != "" {
+			candidates = append(candidates, filepath.Join(cargoHome, "bin"))
+		} else if home != "" {
+			candidates = append(candidates, filepath.Join(home, ".cargo", "bin"))
+		}
+	case "go":
+		if dir := goBinDir(nil); dir != "" {
+			candidates = append(candidates, dir)
+		}
+	case "uv":
+		switch {
+		case os.Getenv("UV_TOOL_DIR") != "":
+			candidates = append(candidates, os.Getenv("UV_TOOL_DIR"))
+		case os.Getenv("XDG_DATA_HOME") != "":
+			candidates = append(candidates, filepath.Join(os.Getenv("XDG_DATA_HOME"), "uv", "tools"))
+		case home != "":
+			candidates = append(candidates, filepath.Join(home, ".local", "share", "uv", "tools"))
+		}
+	case "pnpm":
+		switch {
+		case os.Getenv("PNPM_HOME") != "":
+			candidates = append(candidates, os.Getenv("PNPM_HOME"))
+		case runtime.GOOS == "darwin" && home != "":
+			candidates = append(candidates, filepath.Join(home, "Library", "pnpm"))
+		case home != "":
+			candidates = append(candidates, filepath.Join(home, ".local", "share", "pnpm"))
+		}
+	}
+
+	var dirs []string
+	for _, dir := range candidates {
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
+
+// CommandDir returns the directory a built-in manager puts the commands of
+// the packages it installs in, which has to be on PATH for them to run, or
+// "" if it can't tell. Plugin managers have none.
+func CommandDir(manager string) string {
+	home, _ := os.UserHomeDir()
+	switch manager {
+	case "brew":
+		if dirs := ManagerDirs("brew"); len(dirs) > 0 {
+			return filepath.Join(filepath.Dir(dirs[0]), "bin") // next to Cellar
+		}
+	case "cargo", "go", "pnpm":
+		// cargo and go install into their bin directories; PNPM_HOME holds
+		// pnpm's global commands
+		if dirs := ManagerDirs(manager); len(dirs) > 0 {
+			return dirs[0]
+		}
+	case "uv":
+		for _, env := range []string{"UV_TOOL_BIN_DIR", "XDG_BIN_HOME"} {
+			if dir := os.Getenv(env); dir != "" {
+				return dir
+			}
+		}
+		if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+			return filepath.Clean(filepath.Join(dir, "..", "bin"))
+		}
+		if home != "" {
+			return filepath.Join(home, ".local", "bin")
+		}
+	}
+	return ""
+}
+
+// DiskUsage returns the total size of the regular files under dirs.
+// Symlinks are not followed, and unreadable entries are skipped.
+func DiskUsage(dirs []string) int64 {
+	var total int64
+	for _, dir := range dirs {
+		_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if d != nil && d.IsDir() {
+					return fs.SkipDir
+				}
+				return nil
+			}
+			if !d.Type().IsRegular() {
+				return nil
+			}
+			if info, err := d.Info(); err == nil {
+				total += info.Size()
+			}
+			return nil
+		})
+	}
+	return total
+}
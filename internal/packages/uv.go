@@ -7,19 +7,43 @@ import (
 	"context"
 	"fmt"
 	"os/exec"
+	"regexp"
+	"slices"
 	"strings"
 	"sync"
+
+	"github.com/richhaase/plonk/internal/config"
+	"github.com/richhaase/plonk/internal/manifest"
 )
 
-// UVSimple implements Manager for uv (Python)
+// UVSimple implements Manager for uv (Python). Packages are uv tools,
+// written as pip requirements (ruff, ansible[azure]>=9,<10), except
+// python@<version>, which is a uv-managed interpreter.
 type UVSimple struct {
 	mu        sync.Mutex
-	installed map[string]bool
+	installed map[string]string // normalized tool name -> version, "" if unknown
+	pythons   []string          // versions of installed uv-managed interpreters
+	opts      config.UVOptions
+	env       []string
+}
+
+// pythonSpec matches interpreter packages such as python@3.12 or
+// python@3.12.4
+var pythonSpec = regexp.MustCompile(`^python@(\d+(?:\.\d+){0,2})$`)
+
+// PythonVersion returns the interpreter version requested by a uv package
+// name, or false if name is a tool
+func PythonVersion(name string) (string, bool) {
+	m := pythonSpec.FindStringSubmatch(name)
+	if m == nil {
+		return "", false
+	}
+	return m[1], true
 }
 
 // NewUVSimple creates a new uv manager
-func NewUVSimple() *UVSimple {
-	return &UVSimple{}
+func NewUVSimple(opts config.UVOptions) *UVSimple {
+	return &UVSimple{opts: opts, env: ManagerEnv("uv", config.ManagersConfig{UV: opts})}
 }
 
 // IsInstalled checks if a tool is installed via uv
@@ -27,6 +51,15 @@ func (u *UVSimple) IsInstalled(ctx context.Context, name string) (bool, error) {
 	u.mu.Lock()
 	defer u.mu.Unlock()
 
+	if version, ok := PythonVersion(name); ok {
+		if u.pythons == nil {
+			if err := u.loadPythons(ctx); err != nil {
+				return false, err
+			}
+		}
+		return slices.ContainsFunc(u.pythons, func(v string) bool { return pythonMatches(version, v) }), nil
+	}
+
 	// Load installed list on first call
 	if u.installed == nil {
 		if err := u.loadInstalled(ctx); err != nil {
@@ -34,44 +67,93 @@ func (u *UVSimple) IsInstalled(ctx context.Context, name string) (bool, error) {
 		}
 	}
 
-	return u.installed[name], nil
+	// A tool installed outside the requirement's version range counts as
+	// missing, so apply reinstalls it within range
+	req := parseRequirement(name)
+	version, ok := u.installed[manifest.NormalizeName(req.Name)]
+	return ok && specifierSatisfiedBy(req.Specifier, version), nil
 }
 
-// loadInstalled fetches all installed uv tools
-func (u *UVSimple) loadInstalled(ctx context.Context) error {
-	installed := make(map[string]bool)
-
-	cmd := exec.CommandContext(ctx, "uv", "tool", "list")
+// loadPythons fetches the versions of uv-managed interpreters. System
+// interpreters are left out: only what uv installed is reproducible.
+func (u *UVSimple) loadPythons(ctx context.Context) error {
+	cmd := managerCommand(ctx, u.env, "uv", "python", "list", "--only-installed", "--python-preference", "only-managed")
 	output, err := cmd.Output()
 	if err != nil {
-		return fmt.Errorf("failed to list uv tools: %w", err)
+		return newPackageError(ctx, "uv", "", nil, fmt.Errorf("failed to list uv pythons: %w", err))
 	}
+	u.pythons = parsePythonList(output)
+	return nil
+}
 
-	// Parse output: tool names are first token on each line
-	lines := strings.Split(string(output), "\n")
-	for _, line := range lines {
+// parsePythonList reads the versions from `uv python list` output, whose
+// lines start with a key like cpython-3.12.4-macos-aarch64-none
+func parsePythonList(output []byte) []string {
+	versions := []string{}
+	for _, line := range strings.Split(string(output), "\n") {
 		fields := strings.Fields(line)
-		if len(fields) > 0 {
-			installed[fields[0]] = true
+		if len(fields) == 0 {
+			continue
+		}
+		parts := strings.Split(fields[0], "-")
+		if len(parts) > 1 && parts[0] == "cpython" {
+			versions = append(versions, parts[1])
 		}
 	}
+	return versions
+}
+
+// pythonMatches reports whether installed satisfies the requested version:
+// 3.12 is satisfied by any 3.12.x, 3.12.4 only by itself
+func pythonMatches(requested, installed string) bool {
+	return installed == requested || strings.HasPrefix(installed, requested+".")
+}
+
+// loadInstalled fetches all installed uv tools
+func (u *UVSimple) loadInstalled(ctx context.Context) error {
+	cmd := managerCommand(ctx, u.env, "uv", "tool", "list")
+	output, err := cmd.Output()
+	if err != nil {
+		return newPackageError(ctx, "uv", "", nil, fmt.Errorf("failed to list uv tools: %w", err))
+	}
 
 	// Only set the cache after successful loading
-	u.installed = installed
+	u.installed = parseToolList(output)
 	return nil
 }
 
-// Install installs a tool via uv
+// parseToolList reads `uv tool list` output: each tool is a "name vX.Y.Z"
+// line followed by "- command" lines for its executables
+func parseToolList(output []byte) map[string]string {
+	installed := make(map[string]string)
+	for _, line := range strings.Split(string(output), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || fields[0] == "-" {
+			continue
+		}
+		var version string
+		if len(fields) > 1 && strings.HasPrefix(fields[1], "v") {
+			version = strings.TrimPrefix(fields[1], "v")
+		}
+		installed[manifest.NormalizeName(fields[0])] = version
+	}
+	return installed
+}
+
+// Install installs a tool, or a python@<version> interpreter, via uv
 func (u *UVSimple) Install(ctx context.Context, name string) error {
-	cmd := exec.CommandContext(ctx, "uv", "tool", "install", "--", name)
-	output, err := cmd.CombinedOutput()
+	if version, ok := PythonVersion(name); ok {
+		return u.installPython(ctx, name, version)
+	}
+
+	output, err := runInstallCommand(u.installCommand(ctx, name), "uv:"+name)
 	if err != nil {
 		// Check if already installed
 		if strings.Contains(strings.ToLower(string(output)), "already installed") {
 			u.markInstalled(name)
 			return nil
 		}
-		return fmt.Errorf("uv tool install %s: %s: %w", name, strings.TrimSpace(string(output)), err)
+		return newPackageError(ctx, "uv", name, output, fmt.Errorf("uv tool install %s: %s: %w", name, strings.TrimSpace(string(output)), err))
 	}
 
 	// Update cache after successful install
@@ -79,11 +161,40 @@ func (u *UVSimple) Install(ctx context.Context, name string) error {
 	return nil
 }
 
-// markInstalled updates the cache to mark a package as installed
+// installCommand builds uv tool install for name with the configured options
+func (u *UVSimple) installCommand(ctx context.Context, name string) *exec.Cmd {
+	args := []string{"tool", "install"}
+	if u.opts.IndexURL != "" {
+		args = append(args, "--index-url", u.opts.IndexURL)
+	}
+	args = append(append(args, u.opts.InstallArgs...), u.opts.PackageArgs[name]...)
+	args = append(args, "--", name)
+	return managerCommand(ctx, u.env, "uv", args...)
+}
+
+// installPython installs an interpreter with uv python install. Tool
+// options such as index_url don't apply to interpreter downloads.
+func (u *UVSimple) installPython(ctx context.Context, name, version string) error {
+	output, err := runInstallCommand(managerCommand(ctx, u.env, "uv", "python", "install", version), "uv:"+name)
+	if err != nil {
+		return newPackageError(ctx, "uv", name, output, fmt.Errorf("uv python install %s: %s: %w", version, strings.TrimSpace(string(output)), err))
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.pythons != nil {
+		u.pythons = append(u.pythons, version)
+	}
+	return nil
+}
+
+// markInstalled updates the cache to mark a package as installed. The
+// version uv picked isn't known, and an unknown version satisfies any
+// requirement.
 func (u *UVSimple) markInstalled(name string) {
 	u.mu.Lock()
 	defer u.mu.Unlock()
 	if u.installed != nil {
-		u.installed[name] = true
+		u.installed[manifest.NormalizeName(parseRequirement(name).Name)] = ""
 	}
 }
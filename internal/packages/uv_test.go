@@ -0,0 +1,77 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package packages
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPythonVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		ok      bool
+	}{
+		{"python@3.12", "3.12", true},
+		{"python@3.12.4", "3.12.4", true},
+		{"python@3", "3", true},
+		{"python", "", false},
+		{"python@latest", "", false},
+		{"ruff", "", false},
+	}
+	for _, tt := range tests {
+		version, ok := PythonVersion(tt.name)
+		assert.Equal(t, tt.ok, ok, tt.name)
+		assert.Equal(t, tt.version, version, tt.name)
+	}
+}
+
+func TestParsePythonList(t *testing.T) {
+	out := []byte(`cpython-3.13.0-macos-aarch64-none     /Users/u/.local/share/uv/python/cpython-3.13.0-macos-aarch64-none/bin/python3.13
+cpython-3.12.4-macos-aarch64-none     /Users/u/.local/share/uv/python/cpython-3.12.4-macos-aarch64-none/bin/python3.12
+pypy-3.10.14-macos-aarch64-none       /Users/u/.local/share/uv/python/pypy-3.10.14-macos-aarch64-none/bin/pypy3.10
+
+`)
+	assert.Equal(t, []string{"3.13.0", "3.12.4"}, parsePythonList(out))
+	assert.Empty(t, parsePythonList(nil))
+}
+
+func TestPythonMatches(t *testing.T) {
+	assert.True(t, pythonMatches("3.12", "3.12.4"))
+	assert.True(t, pythonMatches("3.12.4", "3.12.4"))
+	assert.True(t, pythonMatches("3", "3.12.4"))
+	assert.False(t, pythonMatches("3.1", "3.12.4"))
+	assert.False(t, pythonMatches("3.12.3", "3.12.4"))
+}
+
+func TestParseToolList(t *testing.T) {
+	out := []byte(`ansible v9.1.0
+- ansible
+- ansible-playbook
+Ruff_LSP v0.0.53
+- ruff-lsp
+`)
+	assert.Equal(t, map[string]string{"ansible": "9.1.0", "ruff-lsp": "0.0.53"}, parseToolList(out))
+}
+
+func TestUVIsInstalled_Requirement(t *testing.T) {
+	u := &UVSimple{installed: map[string]string{"ansible": "9.1.0", "ruff": ""}}
+	ctx := context.Background()
+
+	for name, want := range map[string]bool{
+		"ansible":               true,
+		"ansible[azure]>=9,<10": true,
+		"ansible>=10":           false,
+		"Ansible":               true,
+		"ruff>=0.5":             true, // version unknown
+		"black":                 false,
+	} {
+		got, err := u.IsInstalled(ctx, name)
+		assert.NoError(t, err)
+		assert.Equal(t, want, got, name)
+	}
+}
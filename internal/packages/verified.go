@@ -0,0 +1,66 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package packages
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/richhaase/plonk/internal/config"
+)
+
+// verifiedFileName holds when each tracked package was last found
+// installed, in the state directory. It is per machine, so it stays out of
+// plonk.lock, which git carries between machines.
+const verifiedFileName = "verified.json"
+
+// Verifications maps "manager:package" to when the package was last found
+// installed
+type Verifications map[string]time.Time
+
+// VerifiedPath returns the verification record's path
+func VerifiedPath() string {
+	return filepath.Join(config.GetStateDirectory(), verifiedFileName)
+}
+
+// LoadVerifications reads the verification record. A missing or unreadable
+// record is empty: every package is then checked again.
+func LoadVerifications(path string) Verifications {
+	v := Verifications{}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return v
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return Verifications{}
+	}
+	return v
+}
+
+// SaveVerifications atomically writes the verification record
+func SaveVerifications(path string, v Verifications) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// fresh reports whether spec was found installed within maxAge, as of now
+func (v Verifications) fresh(spec string, maxAge time.Duration, now time.Time) (time.Time, bool) {
+	checked, ok := v[spec]
+	if !ok || maxAge <= 0 {
+		return time.Time{}, false
+	}
+	return checked, now.Sub(checked) <= maxAge
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package packages
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/richhaase/plonk/internal/lock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconcileVerified(t *testing.T) {
+	ResetManagerCache()
+	t.Cleanup(ResetManagerCache)
+	fakePlugin(t, "fake")
+
+	configDir := t.TempDir()
+	l := lock.NewLockV3()
+	l.AddPackage("fake", "alpha")
+	l.AddPackage("fake", "gamma")
+	require.NoError(t, lock.NewLockV3Service(configDir).Write(l))
+
+	recent := time.Now().Add(-time.Hour)
+	verified := Verifications{
+		"fake:gamma":   recent,                            // fresh: reported managed unchecked
+		"fake:alpha":   time.Now().Add(-72 * time.Hour),   // stale: checked again
+		"fake:retired": time.Now().Add(-10 * time.Minute), // no longer tracked
+	}
+
+	statuses, err := ReconcileVerified(context.Background(), configDir, verified, 24*time.Hour)
+	require.NoError(t, err)
+	byName := map[string]PackageStatus{}
+	for _, s := range statuses {
+		byName[s.Name] = s
+	}
+	assert.Equal(t, StatusManaged, byName["gamma"].State)
+	assert.Equal(t, recent, byName["gamma"].CheckedAt)
+	assert.Equal(t, StatusManaged, byName["alpha"].State)
+	assert.True(t, byName["alpha"].CheckedAt.IsZero(), "checked this run")
+
+	assert.WithinDuration(t, time.Now(), verified["fake:alpha"], time.Minute)
+	assert.Equal(t, recent, verified["fake:gamma"])
+	assert.NotContains(t, verified, "fake:retired")
+
+	// Without a max age every package is checked and a missing one is
+	// dropped from the record
+	statuses, err = ReconcileVerified(context.Background(), configDir, verified, 0)
+	require.NoError(t, err)
+	for _, s := range statuses {
+		if s.Name == "gamma" {
+			assert.Equal(t, StatusMissing, s.State)
+		}
+	}
+	assert.NotContains(t, verified, "fake:gamma")
+
+	path := filepath.Join(t.TempDir(), "state", "verified.json")
+	require.NoError(t, SaveVerifications(path, verified))
+	loaded := LoadVerifications(path)
+	assert.True(t, loaded["fake:alpha"].Equal(verified["fake:alpha"]))
+	assert.Empty(t, LoadVerifications(filepath.Join(t.TempDir(), "missing.json")))
+}
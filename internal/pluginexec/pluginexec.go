@@ -0,0 +1,65 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+// Package pluginexec runs plonk's executable plugins (plonk-manager-<name>
+// and plonk-resource-<name>). Each call passes the operation as the only
+// argument and one JSON request on stdin, and reads one JSON response from
+// stdout.
+package pluginexec
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Result is how a plugin call went. A plugin may print a JSON error
+// response and exit non-zero, so RunErr and DecodeErr are both kept for the
+// caller to weigh.
+type Result struct {
+	Args      []string // the command that ran
+	Stderr    []byte
+	RunErr    error // why the plugin failed to run or exited non-zero
+	DecodeErr error // why stdout didn't decode into the response
+}
+
+// StderrText returns stderr without surrounding whitespace, for messages
+func (r *Result) StderrText() string {
+	return strings.TrimSpace(string(r.Stderr))
+}
+
+// InvalidJSON reports that plugin name answered op with something other
+// than a JSON response
+func (r *Result) InvalidJSON(name, op string) error {
+	return fmt.Errorf("%s plugin returned invalid JSON for %s: %w", name, op, r.DecodeErr)
+}
+
+// Call runs the plugin at path for op, with req encoded on stdin, and
+// decodes its stdout into resp. env is added to plonk's environment. Only
+// a request that can't be encoded is returned as an error; how the plugin
+// itself fared is in the Result.
+func Call(ctx context.Context, path, op string, env []string, req, resp any) (*Result, error) {
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s request: %w", op, err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, path, op) //nolint:gosec // G204: path comes from PATH lookup of plonk-manager-<name> or plonk-resource-<name>
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	result := &Result{Args: cmd.Args}
+	result.RunErr = cmd.Run()
+	result.Stderr = stderr.Bytes()
+	result.DecodeErr = json.Unmarshal(stdout.Bytes(), resp)
+	return result, nil
+}
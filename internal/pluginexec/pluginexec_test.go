@@ -0,0 +1,56 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package pluginexec
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writePlugin writes an executable shell script and returns its path
+func writePlugin(t *testing.T, script string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "plonk-manager-fake")
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0o755))
+	return path
+}
+
+func TestCall(t *testing.T) {
+	// Echo the operation, the request and an env var back as the response
+	path := writePlugin(t, `printf '{"op":"%s","request":%s,"env":"%s"}' "$1" "$(cat)" "$FAKE_ENV"`)
+
+	var resp struct {
+		Op      string         `json:"op"`
+		Request map[string]any `json:"request"`
+		Env     string         `json:"env"`
+	}
+	result, err := Call(context.Background(), path, "list", []string{"FAKE_ENV=set"}, map[string]any{"version": 1}, &resp)
+	require.NoError(t, err)
+	assert.NoError(t, result.RunErr)
+	assert.NoError(t, result.DecodeErr)
+	assert.Equal(t, "list", resp.Op)
+	assert.Equal(t, map[string]any{"version": float64(1)}, resp.Request)
+	assert.Equal(t, "set", resp.Env)
+	assert.Equal(t, []string{path, "list"}, result.Args)
+}
+
+func TestCall_Failures(t *testing.T) {
+	path := writePlugin(t, "echo 'not json'\necho 'it broke' >&2\nexit 3\n")
+
+	var resp map[string]any
+	result, err := Call(context.Background(), path, "install", nil, struct{}{}, &resp)
+	require.NoError(t, err, "how the plugin fared is in the result")
+	assert.Error(t, result.RunErr)
+	assert.Error(t, result.DecodeErr)
+	assert.Equal(t, "it broke", result.StderrText())
+	assert.ErrorContains(t, result.InvalidJSON("fake", "install"), "fake plugin returned invalid JSON for install")
+
+	_, err = Call(context.Background(), path, "install", nil, func() {}, &resp)
+	assert.ErrorContains(t, err, "failed to encode install request")
+}
@@ -0,0 +1,183 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+// Package project reads the .plonk.yaml a repository keeps at its root to
+// declare the tools its developers need.
+//
+// A project manifest is separate from the user's plonk directory: its
+// packages are installed but never added to plonk.lock, so leaving the
+// project doesn't leave its tools tracked on every machine.
+//
+//	packages:
+//	  - brew:terraform
+//	  - uv:ruff
+//	tools:
+//	  terraform: ">=1.6,<2"
+//	  ruff: "0.6"
+package project
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/richhaase/plonk/internal/lock"
+	"github.com/richhaase/plonk/internal/packages"
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the project manifest, at the root of a repository
+const FileName = ".plonk.yaml"
+
+// versionTimeout bounds one `<command> --version`
+const versionTimeout = 10 * time.Second
+
+// Manifest is a parsed .plonk.yaml
+type Manifest struct {
+	Packages []string          `yaml:"packages,omitempty"` // manager:package specs to install
+	Tools    map[string]string `yaml:"tools,omitempty"`    // command -> version specifier
+
+	Path string `yaml:"-"`
+}
+
+// Find returns the .plonk.yaml in dir or its closest parent that has one
+func Find(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for {
+		path := filepath.Join(dir, FileName)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("no %s in this directory or its parents", FileName)
+		}
+		dir = parent
+	}
+}
+
+// Load reads and validates a project manifest
+func Load(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Manifest{Path: path}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(m); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if _, err := m.Lock(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return m, nil
+}
+
+// Lock returns the manifest's packages as a lock file, the form
+// packages.ApplyLock installs from
+func (m *Manifest) Lock() (*lock.LockV3, error) {
+	lockFile := lock.NewLockV3()
+	for _, spec := range m.Packages {
+		manager, pkg, err := packages.ParsePackageSpec(spec)
+		if err != nil {
+			return nil, fmt.Errorf("package %q: %w", spec, err)
+		}
+		lockFile.AddPackage(manager, pkg)
+	}
+	return lockFile, nil
+}
+
+// ToolCheck is the result of checking one tool's version
+type ToolCheck struct {
+	Command   string
+	Specifier string
+	Version   string // empty when the command is missing or prints no version
+	Path      string
+	OK        bool
+	Problem   string
+}
+
+// CheckTools runs each tool with --version and compares the version it
+// prints with the manifest's specifier, in command order
+func (m *Manifest) CheckTools(ctx context.Context) []ToolCheck {
+	commands := make([]string, 0, len(m.Tools))
+	for command := range m.Tools {
+		commands = append(commands, command)
+	}
+	sort.Strings(commands)
+
+	checks := make([]ToolCheck, 0, len(commands))
+	for _, command := range commands {
+		check := ToolCheck{Command: command, Specifier: m.Tools[command]}
+		path, err := exec.LookPath(command)
+		if err != nil {
+			check.Problem = "not on PATH"
+			checks = append(checks, check)
+			continue
+		}
+		check.Path = path
+		check.Version = commandVersion(ctx, path)
+		switch {
+		case check.Version == "":
+			check.Problem = "no version in --version output"
+		case !packages.VersionSatisfies(check.Specifier, check.Version):
+			check.Problem = fmt.Sprintf("version %s does not satisfy %s", check.Version, check.Specifier)
+		default:
+			check.OK = true
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+// versionPattern matches the first dotted version number in a command's
+// output, e.g. "Terraform v1.9.8" -> 1.9.8
+var versionPattern = regexp.MustCompile(`\d+(\.\d+)+`)
+
+// commandVersion returns the version path prints with --version, falling
+// back to the `version` subcommand some tools (go, kubectl) use instead
+func commandVersion(ctx context.Context, path string) string {
+	for _, arg := range []string{"--version", "version"} {
+		ctx, cancel := context.WithTimeout(ctx, versionTimeout)
+		out, _ := exec.CommandContext(ctx, path, arg).CombinedOutput()
+		cancel()
+		if v := versionPattern.FindString(string(out)); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// Summary describes the manifest, e.g. "3 packages, 2 tools"
+func (m *Manifest) Summary() string {
+	var parts []string
+	for _, c := range []struct {
+		n    int
+		noun string
+	}{{len(m.Packages), "package"}, {len(m.Tools), "tool"}} {
+		switch {
+		case c.n == 1:
+			parts = append(parts, "1 "+c.noun)
+		case c.n > 1:
+			parts = append(parts, fmt.Sprintf("%d %ss", c.n, c.noun))
+		}
+	}
+	if len(parts) == 0 {
+		return "nothing declared"
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package project
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindAndLoad(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "services", "api")
+	require.NoError(t, os.MkdirAll(nested, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, FileName), []byte(`packages:
+  - brew:terraform
+  - uv:ruff
+tools:
+  terraform: ">=1.6,<2"
+`), 0644))
+
+	path, err := Find(nested)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(root, FileName), path)
+
+	m, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, "2 packages, 1 tool", m.Summary())
+
+	lockFile, err := m.Lock()
+	require.NoError(t, err)
+	assert.True(t, lockFile.HasPackage("brew", "terraform"))
+	assert.True(t, lockFile.HasPackage("uv", "ruff"))
+}
+
+func TestLoad_Rejects(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"bad spec":      "packages:\n  - terraform\n",
+		"unknown field": "pakages:\n  - brew:terraform\n",
+	} {
+		path := filepath.Join(dir, name+".yaml")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		_, err := Load(path)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestCheckTools(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as the tool")
+	}
+	bin := t.TempDir()
+	script := "#!/bin/sh\necho \"Terraform v1.9.8\"\necho \"on linux_amd64\"\n"
+	require.NoError(t, os.WriteFile(filepath.Join(bin, "terraform"), []byte(script), 0755))
+	t.Setenv("PATH", bin)
+
+	m := &Manifest{Tools: map[string]string{
+		"terraform": ">=1.6,<2",
+		"tofu":      "1.8",
+	}}
+	checks := m.CheckTools(context.Background())
+	require.Len(t, checks, 2)
+
+	assert.Equal(t, "terraform", checks[0].Command)
+	assert.True(t, checks[0].OK)
+	assert.Equal(t, "1.9.8", checks[0].Version)
+
+	assert.Equal(t, "tofu", checks[1].Command)
+	assert.False(t, checks[1].OK)
+	assert.Equal(t, "not on PATH", checks[1].Problem)
+
+	m.Tools = map[string]string{"terraform": "1.5"}
+	checks = m.CheckTools(context.Background())
+	assert.Equal(t, "version 1.9.8 does not satisfy 1.5", checks[0].Problem)
+}
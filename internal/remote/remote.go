@@ -0,0 +1,253 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+// Package remote applies a plonk directory on another machine over ssh: the
+// directory is copied there, plonk is installed if the host lacks it, and
+// plonk apply runs on the host with its output streamed back.
+package remote
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/richhaase/plonk/internal/storage"
+)
+
+// DefaultDir is where the plonk directory goes on the host, as clone would
+// put it
+const DefaultDir = "~/.config/plonk"
+
+// remoteBinDir is where a bootstrapped plonk is installed on the host
+const remoteBinDir = "$HOME/.local/bin"
+
+// Options configures a remote apply
+type Options struct {
+	Host      string   // ssh destination, user@host or a Host from ~/.ssh/config
+	Dir       string   // local plonk directory
+	RemoteDir string   // plonk directory on the host; DefaultDir if empty
+	Binary    string   // plonk binary to install when the host has none
+	ApplyArgs []string // extra arguments for plonk apply, e.g. --dry-run
+	SSHArgs   []string // extra ssh options, e.g. -p 2222
+}
+
+// Apply copies opts.Dir to the host, installs plonk there if needed and
+// runs plonk apply, streaming its output to stdout and stderr. step is
+// called before each stage.
+func Apply(ctx context.Context, opts Options, stdout, stderr io.Writer, step func(string)) error {
+	if _, err := exec.LookPath("ssh"); err != nil {
+		return errors.New("ssh is not installed or not in PATH")
+	}
+	if step == nil {
+		step = func(string) {}
+	}
+	remoteDir := opts.RemoteDir
+	if remoteDir == "" {
+		remoteDir = DefaultDir
+	}
+	if err := checkRemoteDir(remoteDir); err != nil {
+		return err
+	}
+
+	step("Checking plonk on " + opts.Host)
+	installed, platform, err := probe(ctx, opts)
+	if err != nil {
+		return err
+	}
+	if !installed {
+		binary, err := bootstrapBinary(opts.Binary, platform)
+		if err != nil {
+			return err
+		}
+		step("Installing plonk on " + opts.Host)
+		if err := install(ctx, opts, binary); err != nil {
+			return err
+		}
+	}
+
+	step("Copying the plonk directory to " + opts.Host + ":" + remoteDir)
+	snapshot, err := storage.Capture(opts.Dir)
+	if err != nil {
+		return err
+	}
+	var archive bytes.Buffer
+	if err := storage.WriteArchive(&archive, snapshot, time.Now()); err != nil {
+		return err
+	}
+	if _, err := run(ctx, opts, pushScript(remoteDir), &archive); err != nil {
+		return fmt.Errorf("failed to copy the plonk directory to %s: %w", opts.Host, err)
+	}
+
+	step("Applying on " + opts.Host)
+	return stream(ctx, opts, applyScript(remoteDir, opts.ApplyArgs), stdout, stderr)
+}
+
+// probe reports whether the host has plonk, and its platform as GOOS/GOARCH
+func probe(ctx context.Context, opts Options) (bool, string, error) {
+	out, err := run(ctx, opts, `PATH="`+remoteBinDir+`:$PATH"; command -v plonk >/dev/null 2>&1 && echo installed || echo missing; uname -sm`, nil)
+	if err != nil {
+		return false, "", fmt.Errorf("cannot reach %s: %w", opts.Host, err)
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) < 2 {
+		return false, "", fmt.Errorf("unexpected answer from %s: %q", opts.Host, out)
+	}
+	return strings.TrimSpace(lines[0]) == "installed", platformOf(lines[1]), nil
+}
+
+// platformOf turns `uname -sm` output into GOOS/GOARCH
+func platformOf(uname string) string {
+	fields := strings.Fields(uname)
+	if len(fields) != 2 {
+		return ""
+	}
+	goos := strings.ToLower(fields[0])
+	arch := fields[1]
+	switch arch {
+	case "x86_64":
+		arch = "amd64"
+	case "aarch64", "arm64":
+		arch = "arm64"
+	}
+	return goos + "/" + arch
+}
+
+// bootstrapBinary picks the plonk binary to install on a host of platform:
+// the one given, or the running one when it is built for that platform
+func bootstrapBinary(given, platform string) (string, error) {
+	if given != "" {
+		return given, nil
+	}
+	if platform != runtime.GOOS+"/"+runtime.GOARCH {
+		return "", fmt.Errorf("plonk is not installed on the host, which runs %s; pass --binary with a plonk build for it", platform)
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	return exe, nil
+}
+
+// install copies binary to the host's ~/.local/bin/plonk
+func install(ctx context.Context, opts Options, binary string) error {
+	f, err := os.Open(binary)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	script := `set -e; mkdir -p "` + remoteBinDir + `"; cat > "` + remoteBinDir + `/plonk.tmp"; chmod 755 "` + remoteBinDir + `/plonk.tmp"; mv "` + remoteBinDir + `/plonk.tmp" "` + remoteBinDir + `/plonk"`
+	if _, err := run(ctx, opts, script, f); err != nil {
+		return fmt.Errorf("failed to install plonk on %s: %w", opts.Host, err)
+	}
+	return nil
+}
+
+// checkRemoteDir refuses remote directories whose contents a push would
+// wipe along with the user's files
+func checkRemoteDir(remoteDir string) error {
+	switch strings.TrimRight(remoteDir, "/") {
+	case "", "~", ".", "..":
+		return fmt.Errorf("refusing to push to %q: the plonk directory on the host must be a directory of its own", remoteDir)
+	}
+	return nil
+}
+
+// pushScript replaces the host's plonk directory with the archive on
+// stdin. Its .git directory and the machine-local storage.LocalDirs are
+// kept, like snapshot restore does. A directory that is the home or root
+// directory, or has files but neither plonk.yaml nor plonk.lock, is not a
+// plonk directory and is refused rather than wiped.
+func pushScript(remoteDir string) string {
+	dir := shellPath(remoteDir)
+	keep := "! -name .git"
+	for _, name := range storage.LocalDirs {
+		keep += " ! -name " + shellQuote(name)
+	}
+	return `set -e
+dir=` + dir + `
+case "$(cd "$dir" 2>/dev/null && pwd -P)" in
+/|"$(cd "$HOME" && pwd -P)")
+  echo "refusing to replace $dir: it is the root or home directory" >&2; exit 1 ;;
+esac
+if [ -n "$(ls -A "$dir" 2>/dev/null)" ] && [ ! -e "$dir/plonk.yaml" ] && [ ! -e "$dir/plonk.lock" ]; then
+  echo "refusing to replace $dir: it has neither plonk.yaml nor plonk.lock" >&2; exit 1
+fi
+tmp="$dir.plonk-push"
+rm -rf "$tmp"
+mkdir -p "$tmp" "$dir"
+tar -xzf - -C "$tmp"
+find "$dir" -mindepth 1 -maxdepth 1 ` + keep + ` -exec rm -rf {} +
+cp -R "$tmp"/. "$dir"/
+rm -rf "$tmp"`
+}
+
+// applyScript runs plonk apply on the host's copy of the plonk directory
+func applyScript(remoteDir string, args []string) string {
+	script := `PATH="` + remoteBinDir + `:$PATH" PLONK_DIR=` + shellPath(remoteDir) + ` exec plonk apply`
+	for _, arg := range args {
+		script += " " + shellQuote(arg)
+	}
+	return script
+}
+
+// shellPath quotes a remote path, letting a leading ~/ expand to $HOME
+func shellPath(path string) string {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		return `"$HOME"/` + shellQuote(rest)
+	}
+	if path == "~" {
+		return `"$HOME"`
+	}
+	return shellQuote(path)
+}
+
+// shellQuote quotes s for a POSIX shell
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// sshArgs runs script with sh on the host, whatever its login shell.
+// BatchMode keeps ssh from prompting for passwords, which would hang behind
+// the archive on stdin; keys or an agent are needed.
+func sshArgs(opts Options, script string) []string {
+	args := append([]string{"-o", "BatchMode=yes"}, opts.SSHArgs...)
+	return append(args, "--", opts.Host, "sh -c "+shellQuote(script))
+}
+
+// run runs script on the host with stdin, returning its output
+func run(ctx context.Context, opts Options, script string, stdin io.Reader) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "ssh", sshArgs(opts, script)...)
+	cmd.Stdin = stdin
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return stdout.String(), fmt.Errorf("%w: %s", err, lastLine(msg))
+		}
+		return stdout.String(), err
+	}
+	return stdout.String(), nil
+}
+
+// stream runs script on the host with its output passed through as it comes
+func stream(ctx context.Context, opts Options, script string, stdout, stderr io.Writer) error {
+	cmd := exec.CommandContext(ctx, "ssh", sshArgs(opts, script)...)
+	cmd.Stdout, cmd.Stderr = stdout, stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("plonk apply on %s: %w", opts.Host, err)
+	}
+	return nil
+}
+
+// lastLine returns the last line of s
+func lastLine(s string) string {
+	lines := strings.Split(s, "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
@@ -0,0 +1,180 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package remote
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSSH puts an ssh on PATH that logs each command, reports plonk as
+// installed or missing on a Linux amd64 host, keeps what is piped to it in
+// dir/stdin-<n> and answers apply with a line of output
+func fakeSSH(t *testing.T, plonkInstalled bool) string {
+	t.Helper()
+	dir := t.TempDir()
+	state := "missing"
+	if plonkInstalled {
+		state = "installed"
+	}
+	script := `#!/bin/sh
+log="` + dir + `/log"
+n=$(wc -l < "$log" 2>/dev/null || echo 0)
+for last; do :; done
+echo "$last" | tr '\n' ' ' >> "$log"; echo >> "$log"
+case "$last" in
+  *"command -v plonk"*) printf '` + state + `\nLinux x86_64\n' ;;
+  *"exec plonk apply"*) echo "applied $*" ;;
+  *) cat > "` + dir + `/stdin-$n" ;;
+esac
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "ssh"), []byte(script), 0o755))
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return dir
+}
+
+func TestApply(t *testing.T) {
+	sshDir := fakeSSH(t, true)
+	plonkDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(plonkDir, "plonk.lock"), []byte("version: 3\n"), 0o644))
+	require.NoError(t, os.MkdirAll(filepath.Join(plonkDir, ".git"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(plonkDir, ".git", "HEAD"), []byte("ref"), 0o644))
+	require.NoError(t, os.MkdirAll(filepath.Join(plonkDir, ".backups"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(plonkDir, ".backups", "plonk.lock.v2.bak"), []byte("version: 2\n"), 0o644))
+
+	var stdout, stderr bytes.Buffer
+	var steps []string
+	err := Apply(context.Background(), Options{
+		Host:      "admin@web1",
+		Dir:       plonkDir,
+		ApplyArgs: []string{"--packages"},
+		SSHArgs:   []string{"-p", "2222"},
+	}, &stdout, &stderr, func(step string) { steps = append(steps, step) })
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"Checking plonk on admin@web1",
+		"Copying the plonk directory to admin@web1:~/.config/plonk",
+		"Applying on admin@web1",
+	}, steps)
+	assert.Contains(t, stdout.String(), "applied -o BatchMode=yes -p 2222 -- admin@web1 sh -c")
+
+	log, err := os.ReadFile(filepath.Join(sshDir, "log"))
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(log)), "\n")
+	require.Len(t, lines, 3)
+	assert.Contains(t, lines[1], `dir="$HOME"/'\''.config/plonk'\''`)
+	assert.Contains(t, lines[2], `PLONK_DIR="$HOME"/'\''.config/plonk'\'' exec plonk apply '\''--packages'\''`)
+
+	// The pushed archive holds the plonk directory without .git and backups
+	if _, err := exec.LookPath("tar"); err == nil {
+		listing, err := exec.Command("tar", "-tzf", filepath.Join(sshDir, "stdin-1")).Output()
+		require.NoError(t, err)
+		assert.Equal(t, "plonk.lock\n", string(listing))
+	}
+}
+
+func TestApply_MissingPlonkOnOtherPlatform(t *testing.T) {
+	if runtime.GOOS == "linux" && runtime.GOARCH == "amd64" {
+		t.Skip("the fake host runs the same platform as this test")
+	}
+	fakeSSH(t, false)
+	err := Apply(context.Background(), Options{Host: "devbox", Dir: t.TempDir()}, &bytes.Buffer{}, &bytes.Buffer{}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "runs linux/amd64; pass --binary")
+}
+
+func TestApply_InstallsPlonk(t *testing.T) {
+	sshDir := fakeSSH(t, false)
+	binary := filepath.Join(t.TempDir(), "plonk")
+	require.NoError(t, os.WriteFile(binary, []byte("#!/bin/sh\n"), 0o755))
+
+	var steps []string
+	err := Apply(context.Background(), Options{Host: "devbox", Dir: t.TempDir(), Binary: binary}, &bytes.Buffer{}, &bytes.Buffer{}, func(step string) {
+		steps = append(steps, step)
+	})
+	require.NoError(t, err)
+	assert.Contains(t, steps, "Installing plonk on devbox")
+
+	installed, err := os.ReadFile(filepath.Join(sshDir, "stdin-1"))
+	require.NoError(t, err)
+	assert.Equal(t, "#!/bin/sh\n", string(installed))
+}
+
+func TestApply_RefusesHomeAndRoot(t *testing.T) {
+	sshDir := fakeSSH(t, true)
+	for _, dir := range []string{"~", "~/", "/"} {
+		err := Apply(context.Background(), Options{Host: "devbox", Dir: t.TempDir(), RemoteDir: dir}, &bytes.Buffer{}, &bytes.Buffer{}, nil)
+		assert.ErrorContains(t, err, "refusing to push", dir)
+	}
+	assert.NoFileExists(t, filepath.Join(sshDir, "log"), "nothing ran on the host")
+}
+
+func TestPushScript(t *testing.T) {
+	if _, err := exec.LookPath("tar"); err != nil {
+		t.Skip("tar is not installed")
+	}
+	src := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(src, "plonk.yaml"), []byte("new\n"), 0o644))
+	archive := filepath.Join(t.TempDir(), "push.tgz")
+	require.NoError(t, exec.Command("tar", "-czf", archive, "-C", src, ".").Run())
+
+	push := func(home, remoteDir string) error {
+		f, err := os.Open(archive)
+		require.NoError(t, err)
+		defer f.Close()
+		cmd := exec.Command("sh", "-c", pushScript(remoteDir))
+		cmd.Env = append(os.Environ(), "HOME="+home)
+		cmd.Stdin = f
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			return errors.New(string(out))
+		}
+		return nil
+	}
+	write := func(path, content string) {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+
+	home := t.TempDir()
+	write(filepath.Join(home, "notes.txt"), "keep me")
+	assert.ErrorContains(t, push(home, home), "it is the root or home directory")
+	assert.FileExists(t, filepath.Join(home, "notes.txt"))
+	write(filepath.Join(home, "projects", "README"), "keep me")
+	err := push(home, filepath.Join(home, "projects"))
+	assert.ErrorContains(t, err, "neither plonk.yaml nor plonk.lock")
+	assert.FileExists(t, filepath.Join(home, "projects", "README"))
+
+	plonkDir := filepath.Join(home, ".config", "plonk")
+	for _, rel := range []string{"plonk.yaml", "stale", ".git/HEAD", ".backups/plonk.lock.v2.bak", ".fleet/web1.json", "logs/app.conf"} {
+		write(filepath.Join(plonkDir, rel), "old")
+	}
+	require.NoError(t, push(home, "~/.config/plonk"))
+	data, err := os.ReadFile(filepath.Join(plonkDir, "plonk.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "new\n", string(data))
+	assert.NoFileExists(t, filepath.Join(plonkDir, "stale"))
+	assert.NoFileExists(t, filepath.Join(plonkDir, "logs", "app.conf"), "logs/ is a dotfile source, replaced like the rest")
+	for _, rel := range []string{".git/HEAD", ".backups/plonk.lock.v2.bak", ".fleet/web1.json"} {
+		assert.FileExists(t, filepath.Join(plonkDir, rel))
+	}
+}
+
+func TestShellPath(t *testing.T) {
+	assert.Equal(t, `"$HOME"/'dots/plonk'`, shellPath("~/dots/plonk"))
+	assert.Equal(t, `'/srv/it'\''s'`, shellPath("/srv/it's"))
+	assert.Equal(t, "linux/arm64", platformOf("Linux aarch64"))
+	assert.Equal(t, "darwin/arm64", platformOf("Darwin arm64"))
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package resources
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
+
+	"github.com/richhaase/plonk/internal/pluginexec"
+)
+
+// PluginPrefix is the executable name prefix for resource plugins.
+// Listing "<name>" under resources in plonk.yaml runs plonk-resource-<name>.
+const PluginPrefix = "plonk-resource-"
+
+// PluginProtocolVersion is sent with every request
+const PluginProtocolVersion = 1
+
+// Plugin operations
+const (
+	OpDesired = "desired"
+	OpActual  = "actual"
+	OpApply   = "apply"
+)
+
+var pluginNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// PluginRequest is written as JSON to the plugin's stdin
+type PluginRequest struct {
+	Version   int    `json:"version"`
+	Operation string `json:"operation"`
+	ConfigDir string `json:"config_dir"`
+	Item      *Item  `json:"item,omitempty"` // apply only
+}
+
+// PluginResponse is read as JSON from the plugin's stdout
+type PluginResponse struct {
+	Items []Item `json:"items,omitempty"` // desired/actual
+	Error string `json:"error,omitempty"`
+}
+
+// Plugin implements Resource by talking to a plonk-resource-<name> executable
+type Plugin struct {
+	name      string
+	path      string
+	configDir string
+}
+
+// Lookup returns the resource listed as name under resources in
+// plonk.yaml: a built-in one, or else the plugin plonk-resource-<name>
+func Lookup(name, configDir, homeDir string) (Resource, error) {
+	if IsBuiltin(name) {
+		return NewShellFragments(configDir, homeDir), nil
+	}
+	return NewPlugin(name, configDir)
+}
+
+// IsBuiltin reports whether name is a resource built into plonk rather
+// than a plugin
+func IsBuiltin(name string) bool {
+	return name == ShellFragmentsName
+}
+
+// NewPlugin resolves the plugin executable for name on PATH
+func NewPlugin(name, configDir string) (*Plugin, error) {
+	if !pluginNamePattern.MatchString(name) {
+		return nil, fmt.Errorf("invalid resource name %q", name)
+	}
+	path, err := exec.LookPath(PluginPrefix + name)
+	if err != nil {
+		return nil, fmt.Errorf("resource plugin %s%s not found on PATH: %w", PluginPrefix, name, err)
+	}
+	return &Plugin{name: name, path: path, configDir: configDir}, nil
+}
+
+// Name returns the resource name
+func (p *Plugin) Name() string {
+	return p.name
+}
+
+// Desired returns the items the plugin says should exist
+func (p *Plugin) Desired(ctx context.Context) ([]Item, error) {
+	resp, err := p.call(ctx, PluginRequest{Operation: OpDesired})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Items, nil
+}
+
+// Actual returns the items the plugin says exist now
+func (p *Plugin) Actual(ctx context.Context) ([]Item, error) {
+	resp, err := p.call(ctx, PluginRequest{Operation: OpActual})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Items, nil
+}
+
+// Apply asks the plugin to bring one item into its desired state
+func (p *Plugin) Apply(ctx context.Context, item Item) error {
+	_, err := p.call(ctx, PluginRequest{Operation: OpApply, Item: &item})
+	return err
+}
+
+// call runs the plugin with one JSON request and decodes its JSON response
+func (p *Plugin) call(ctx context.Context, req PluginRequest) (*PluginResponse, error) {
+	req.Version = PluginProtocolVersion
+	req.ConfigDir = p.configDir
+	var resp PluginResponse
+	result, err := pluginexec.Call(ctx, p.path, req.Operation, nil, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case result.DecodeErr == nil && resp.Error != "":
+		return nil, fmt.Errorf("%s %s: %s", p.name, req.Operation, resp.Error)
+	case result.RunErr != nil:
+		return nil, fmt.Errorf("%s %s: %s: %w", p.name, req.Operation, result.StderrText(), result.RunErr)
+	case result.DecodeErr != nil:
+		return nil, result.InvalidJSON(p.name, req.Operation)
+	}
+	return &resp, nil
+}
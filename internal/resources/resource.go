@@ -0,0 +1,118 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+// Package resources reconciles custom resource types provided by plugins,
+// alongside the built-in package and dotfile domains.
+package resources
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// Item is one unit of a custom resource, e.g. a gcloud component or a VPN
+// profile. Fingerprint is optional; when both sides report one and they
+// differ, the item is drifted.
+type Item struct {
+	Name        string `json:"name"`
+	Fingerprint string `json:"fingerprint,omitempty"`
+}
+
+// Resource is a reconcilable domain. Desired returns what should exist,
+// Actual what exists now, and Apply brings one desired item into place.
+type Resource interface {
+	Name() string
+	Desired(ctx context.Context) ([]Item, error)
+	Actual(ctx context.Context) ([]Item, error)
+	Apply(ctx context.Context, item Item) error
+}
+
+// State is the reconciled state of one desired item
+type State string
+
+const (
+	StateManaged State = "managed" // desired and present
+	StateMissing State = "missing" // desired but absent
+	StateDrifted State = "drifted" // present with a different fingerprint
+)
+
+// ItemStatus pairs a desired item with its reconciled state
+type ItemStatus struct {
+	Item
+	State State
+}
+
+// Reconcile compares a resource's desired and actual items. Items present
+// but not desired are ignored; plonk never removes what it did not declare.
+func Reconcile(ctx context.Context, r Resource) ([]ItemStatus, error) {
+	desired, err := r.Desired(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to read desired state: %w", r.Name(), err)
+	}
+	actual, err := r.Actual(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to read actual state: %w", r.Name(), err)
+	}
+
+	present := make(map[string]Item, len(actual))
+	for _, item := range actual {
+		present[item.Name] = item
+	}
+
+	statuses := make([]ItemStatus, 0, len(desired))
+	for _, want := range desired {
+		have, ok := present[want.Name]
+		state := StateManaged
+		switch {
+		case !ok:
+			state = StateMissing
+		case want.Fingerprint != "" && have.Fingerprint != "" && want.Fingerprint != have.Fingerprint:
+			state = StateDrifted
+		}
+		statuses = append(statuses, ItemStatus{Item: want, State: state})
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Name < statuses[j].Name
+	})
+	return statuses, nil
+}
+
+// ApplyResult summarizes what Apply did for one resource
+type ApplyResult struct {
+	Applied      []string
+	WouldApply   []string
+	Skipped      []string
+	Failed       []string
+	Errors       []error // parallel to Failed
+	ReconcileErr error   // set when the resource could not be reconciled at all
+}
+
+// Apply applies every missing or drifted item of r
+func Apply(ctx context.Context, r Resource, dryRun bool) ApplyResult {
+	var result ApplyResult
+
+	statuses, err := Reconcile(ctx, r)
+	if err != nil {
+		result.ReconcileErr = err
+		return result
+	}
+
+	for _, s := range statuses {
+		switch {
+		case s.State == StateManaged:
+			result.Skipped = append(result.Skipped, s.Name)
+		case dryRun:
+			result.WouldApply = append(result.WouldApply, s.Name)
+		default:
+			if err := r.Apply(ctx, s.Item); err != nil {
+				result.Failed = append(result.Failed, s.Name)
+				result.Errors = append(result.Errors, err)
+				continue
+			}
+			result.Applied = append(result.Applied, s.Name)
+		}
+	}
+	return result
+}
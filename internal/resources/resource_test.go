@@ -0,0 +1,108 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package resources
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeResource struct {
+	desired  []Item
+	actual   []Item
+	applied  []string
+	applyErr map[string]error
+}
+
+func (f *fakeResource) Name() string { return "fake" }
+
+func (f *fakeResource) Desired(context.Context) ([]Item, error) { return f.desired, nil }
+
+func (f *fakeResource) Actual(context.Context) ([]Item, error) { return f.actual, nil }
+
+func (f *fakeResource) Apply(_ context.Context, item Item) error {
+	if err := f.applyErr[item.Name]; err != nil {
+		return err
+	}
+	f.applied = append(f.applied, item.Name)
+	return nil
+}
+
+func TestReconcile(t *testing.T) {
+	r := &fakeResource{
+		desired: []Item{{Name: "kubectl"}, {Name: "gke-auth", Fingerprint: "v2"}, {Name: "beta"}},
+		actual:  []Item{{Name: "kubectl"}, {Name: "gke-auth", Fingerprint: "v1"}, {Name: "extra"}},
+	}
+
+	statuses, err := Reconcile(context.Background(), r)
+	require.NoError(t, err)
+	require.Len(t, statuses, 3)
+
+	got := map[string]State{}
+	for _, s := range statuses {
+		got[s.Name] = s.State
+	}
+	assert.Equal(t, map[string]State{
+		"beta":     StateMissing,
+		"gke-auth": StateDrifted,
+		"kubectl":  StateManaged,
+	}, got)
+}
+
+func TestApply(t *testing.T) {
+	r := &fakeResource{
+		desired:  []Item{{Name: "a"}, {Name: "b"}, {Name: "c"}},
+		actual:   []Item{{Name: "a"}},
+		applyErr: map[string]error{"c": errors.New("boom")},
+	}
+
+	result := Apply(context.Background(), r, false)
+	assert.Equal(t, []string{"a"}, result.Skipped)
+	assert.Equal(t, []string{"b"}, result.Applied)
+	assert.Equal(t, []string{"c"}, result.Failed)
+	assert.Equal(t, []string{"b"}, r.applied)
+}
+
+func TestApply_DryRun(t *testing.T) {
+	r := &fakeResource{desired: []Item{{Name: "a"}}}
+
+	result := Apply(context.Background(), r, true)
+	assert.Equal(t, []string{"a"}, result.WouldApply)
+	assert.Empty(t, r.applied)
+}
+
+func TestPlugin(t *testing.T) {
+	dir := t.TempDir()
+	script := `#!/bin/sh
+cat > /dev/null
+case "$1" in
+  desired) echo '{"items": [{"name": "one"}, {"name": "two"}]}' ;;
+  actual)  echo '{"items": [{"name": "one"}]}' ;;
+  apply)   echo '{"error": "read-only"}'; exit 1 ;;
+esac
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, PluginPrefix+"demo"), []byte(script), 0o755))
+	t.Setenv("PATH", dir)
+
+	plugin, err := NewPlugin("demo", t.TempDir())
+	require.NoError(t, err)
+
+	statuses, err := Reconcile(context.Background(), plugin)
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+	assert.Equal(t, StateMissing, statuses[1].State)
+
+	err = plugin.Apply(context.Background(), Item{Name: "two"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "demo apply: read-only")
+
+	_, err = NewPlugin("nosuch", "")
+	assert.Error(t, err)
+}
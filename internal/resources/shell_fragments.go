@@ -0,0 +1,291 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package resources
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/richhaase/plonk/internal/config"
+	"github.com/richhaase/plonk/internal/dotfiles"
+)
+
+// ShellFragmentsName is the built-in resource that assembles snippets from
+// the plonk directory into a managed block of each shell's rc file
+const ShellFragmentsName = "shell_fragments"
+
+// ShellFragmentsDir holds the snippets, in the config directory. The
+// leading dot keeps them from being deployed as dotfiles.
+const ShellFragmentsDir = ".shell_fragments"
+
+// Markers around the block plonk owns in an rc file. Everything outside
+// them is left as it is.
+const (
+	fragmentsBegin = "# >>> plonk shell fragments >>>"
+	fragmentsEnd   = "# <<< plonk shell fragments <<<"
+)
+
+// fragmentShells maps a fragment's extension to the shells it is for:
+// name.sh goes to every shell, name.zsh and name.bash to one
+var fragmentShells = map[string][]string{
+	".sh":   {"bash", "zsh"},
+	".bash": {"bash"},
+	".zsh":  {"zsh"},
+}
+
+// shellRCFiles are the rc files fragments go into, under $HOME
+var shellRCFiles = map[string]string{
+	"bash": ".bashrc",
+	"zsh":  ".zshrc",
+}
+
+// ShellFragments implements Resource for the shell rc blocks. Each rc file
+// is one item, fingerprinted by the content of its block.
+type ShellFragments struct {
+	configDir string
+	homeDir   string
+	shell     string // login shell, from $SHELL; its rc file is created if missing
+}
+
+// NewShellFragments returns the shell_fragments resource
+func NewShellFragments(configDir, homeDir string) *ShellFragments {
+	return &ShellFragments{configDir: configDir, homeDir: homeDir, shell: filepath.Base(os.Getenv("SHELL"))}
+}
+
+// Name returns the resource name
+func (s *ShellFragments) Name() string {
+	return ShellFragmentsName
+}
+
+// Desired returns the rc files that should hold a block: those with
+// fragments for their shell that exist or belong to the login shell, and
+// those holding a block no fragment is left for, which then goes away
+func (s *ShellFragments) Desired(ctx context.Context) ([]Item, error) {
+	fragments, err := s.fragments()
+	if err != nil {
+		return nil, err
+	}
+	managed := s.managedDotfiles()
+
+	var items []Item
+	for _, shell := range sortedShells() {
+		rc := shellRCFiles[shell]
+		path := filepath.Join(s.homeDir, rc)
+		content, err := os.ReadFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		_, hasBlock := findBlock(string(content))
+		exists := err == nil
+
+		if len(fragments[shell]) == 0 {
+			if hasBlock {
+				items = append(items, Item{Name: "~/" + rc, Fingerprint: fingerprint("")})
+			}
+			continue
+		}
+		if !exists && shell != s.shell {
+			continue
+		}
+		if managed[path] {
+			log.Printf("Warning: not adding shell fragments to ~/%s: it is a managed dotfile, add them to its source instead", rc)
+			continue
+		}
+		items = append(items, Item{Name: "~/" + rc, Fingerprint: fingerprint(s.block(fragments[shell]))})
+	}
+	return items, nil
+}
+
+// Actual returns the rc files that hold a block now
+func (s *ShellFragments) Actual(ctx context.Context) ([]Item, error) {
+	var items []Item
+	for _, shell := range sortedShells() {
+		rc := shellRCFiles[shell]
+		content, err := os.ReadFile(filepath.Join(s.homeDir, rc))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		if block, ok := findBlock(string(content)); ok {
+			items = append(items, Item{Name: "~/" + rc, Fingerprint: fingerprint(block)})
+		}
+	}
+	return items, nil
+}
+
+// Apply rewrites the block in one rc file, adding it at the end if there
+// is none yet, or removing it when no fragments are left for the shell
+func (s *ShellFragments) Apply(ctx context.Context, item Item) error {
+	rc := strings.TrimPrefix(item.Name, "~/")
+	shell := ""
+	for sh, name := range shellRCFiles {
+		if name == rc {
+			shell = sh
+		}
+	}
+	if shell == "" {
+		return fmt.Errorf("%s is not a shell rc file", item.Name)
+	}
+
+	fragments, err := s.fragments()
+	if err != nil {
+		return err
+	}
+	block := ""
+	if len(fragments[shell]) > 0 {
+		block = s.block(fragments[shell])
+	}
+
+	path := filepath.Join(s.homeDir, rc)
+	perm := os.FileMode(0644)
+	content, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if info, statErr := os.Stat(path); statErr == nil {
+		perm = info.Mode().Perm()
+	}
+
+	updated := replaceBlock(string(content), block)
+	tmpPath := path + ".plonk.tmp"
+	if err := os.WriteFile(tmpPath, []byte(updated), perm); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return nil
+}
+
+// fragments reads the fragment files, by shell, in file name order
+func (s *ShellFragments) fragments() (map[string][]fragment, error) {
+	dir := filepath.Join(s.configDir, ShellFragmentsDir)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	byShell := make(map[string][]fragment)
+	for _, entry := range entries {
+		shells, ok := fragmentShells[filepath.Ext(entry.Name())]
+		if !ok || entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		for _, shell := range shells {
+			byShell[shell] = append(byShell[shell], fragment{name: entry.Name(), content: string(content)})
+		}
+	}
+	return byShell, nil
+}
+
+// fragment is one snippet file
+type fragment struct {
+	name    string
+	content string
+}
+
+// block assembles fragments into the text between and including the markers
+func (s *ShellFragments) block(fragments []fragment) string {
+	var b strings.Builder
+	b.WriteString(fragmentsBegin + "\n")
+	fmt.Fprintf(&b, "# Managed by plonk: edit the fragments in %s instead\n", filepath.Join(s.configDir, ShellFragmentsDir))
+	for _, f := range fragments {
+		fmt.Fprintf(&b, "# %s\n", f.name)
+		b.WriteString(f.content)
+		if !strings.HasSuffix(f.content, "\n") {
+			b.WriteString("\n")
+		}
+	}
+	b.WriteString(fragmentsEnd + "\n")
+	return b.String()
+}
+
+// managedDotfiles returns the targets of the managed dotfiles, which plonk
+// deploys whole and must not also edit
+func (s *ShellFragments) managedDotfiles() map[string]bool {
+	cfg := config.LoadWithDefaults(s.configDir)
+	list, err := dotfiles.NewDotfileManager(s.configDir, s.homeDir, cfg.IgnorePatterns).List()
+	if err != nil {
+		return nil
+	}
+	managed := make(map[string]bool, len(list))
+	for _, d := range list {
+		managed[d.Target] = true
+	}
+	return managed
+}
+
+// findBlock returns the managed block in content, markers included
+func findBlock(content string) (string, bool) {
+	start := strings.Index(content, fragmentsBegin+"\n")
+	if start < 0 {
+		return "", false
+	}
+	end := strings.Index(content[start:], fragmentsEnd)
+	if end < 0 {
+		return "", false
+	}
+	end += start + len(fragmentsEnd)
+	if end < len(content) && content[end] == '\n' {
+		end++
+	}
+	return content[start:end], true
+}
+
+// replaceBlock puts block in place of the managed block in content, or
+// appends it after a blank line. An empty block removes the managed one.
+func replaceBlock(content, block string) string {
+	if old, ok := findBlock(content); ok {
+		start := strings.Index(content, old)
+		before, after := content[:start], content[start+len(old):]
+		if block == "" && strings.HasSuffix(before, "\n\n") {
+			// Take the blank line the block was appended after with it
+			before = before[:len(before)-1]
+		}
+		return before + block + after
+	}
+	if block == "" {
+		return content
+	}
+	if content != "" {
+		if !strings.HasSuffix(content, "\n") {
+			content += "\n"
+		}
+		content += "\n"
+	}
+	return content + block
+}
+
+// fingerprint identifies a block's content
+func fingerprint(block string) string {
+	sum := sha256.Sum256([]byte(block))
+	return hex.EncodeToString(sum[:8])
+}
+
+// sortedShells returns the shells with an rc file, in order
+func sortedShells() []string {
+	shells := make([]string, 0, len(shellRCFiles))
+	for shell := range shellRCFiles {
+		shells = append(shells, shell)
+	}
+	sort.Strings(shells)
+	return shells
+}
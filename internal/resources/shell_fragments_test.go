@@ -0,0 +1,117 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package resources
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFragment(t *testing.T, configDir, name, content string) {
+	t.Helper()
+	dir := filepath.Join(configDir, ShellFragmentsDir)
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+}
+
+func TestShellFragments_ApplyInjectsAndUpdatesBlock(t *testing.T) {
+	configDir := t.TempDir()
+	homeDir := t.TempDir()
+	ctx := context.Background()
+
+	writeFragment(t, configDir, "10-path.sh", `export PATH="$HOME/.local/bin:$PATH"`)
+	writeFragment(t, configDir, "20-starship.zsh", "eval \"$(starship init zsh)\"\n")
+	zshrc := filepath.Join(homeDir, ".zshrc")
+	require.NoError(t, os.WriteFile(zshrc, []byte("# my zshrc\nalias ll='ls -l'\n"), 0600))
+
+	r := &ShellFragments{configDir: configDir, homeDir: homeDir, shell: "zsh"}
+	result := Apply(ctx, r, false)
+	require.NoError(t, result.ReconcileErr)
+	assert.Equal(t, []string{"~/.zshrc"}, result.Applied, "no .bashrc exists and bash is not the login shell")
+
+	data, err := os.ReadFile(zshrc)
+	require.NoError(t, err)
+	content := string(data)
+	assert.True(t, strings.HasPrefix(content, "# my zshrc\nalias ll='ls -l'\n\n"+fragmentsBegin+"\n"), content)
+	assert.Contains(t, content, "# 10-path.sh\nexport PATH=\"$HOME/.local/bin:$PATH\"\n# 20-starship.zsh\n")
+	assert.True(t, strings.HasSuffix(content, fragmentsEnd+"\n"), content)
+
+	info, err := os.Stat(zshrc)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm(), "the rc file keeps its mode")
+
+	// In sync now
+	statuses, err := Reconcile(ctx, r)
+	require.NoError(t, err)
+	require.Len(t, statuses, 1)
+	assert.Equal(t, StateManaged, statuses[0].State)
+
+	// Lines added after the block survive a changed fragment
+	require.NoError(t, os.WriteFile(zshrc, append(data, "export EDITOR=vim\n"...), 0600))
+	writeFragment(t, configDir, "20-starship.zsh", "eval \"$(starship init zsh --print-full-init)\"\n")
+	statuses, err = Reconcile(ctx, r)
+	require.NoError(t, err)
+	assert.Equal(t, StateDrifted, statuses[0].State)
+
+	result = Apply(ctx, r, false)
+	assert.Equal(t, []string{"~/.zshrc"}, result.Applied)
+	data, err = os.ReadFile(zshrc)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "--print-full-init")
+	assert.True(t, strings.HasSuffix(string(data), fragmentsEnd+"\nexport EDITOR=vim\n"), string(data))
+	assert.Equal(t, 1, strings.Count(string(data), fragmentsBegin))
+}
+
+func TestShellFragments_RemovesBlockWithoutFragments(t *testing.T) {
+	configDir := t.TempDir()
+	homeDir := t.TempDir()
+	ctx := context.Background()
+
+	writeFragment(t, configDir, "path.sh", "export PATH=\"$HOME/bin:$PATH\"\n")
+	bashrc := filepath.Join(homeDir, ".bashrc")
+	require.NoError(t, os.WriteFile(bashrc, []byte("set -o vi\n"), 0644))
+
+	r := &ShellFragments{configDir: configDir, homeDir: homeDir, shell: "bash"}
+	require.Equal(t, []string{"~/.bashrc"}, Apply(ctx, r, false).Applied)
+
+	require.NoError(t, os.Remove(filepath.Join(configDir, ShellFragmentsDir, "path.sh")))
+	result := Apply(ctx, r, false)
+	assert.Equal(t, []string{"~/.bashrc"}, result.Applied)
+
+	data, err := os.ReadFile(bashrc)
+	require.NoError(t, err)
+	assert.Equal(t, "set -o vi\n", string(data))
+
+	statuses, err := Reconcile(ctx, r)
+	require.NoError(t, err)
+	assert.Empty(t, statuses)
+}
+
+func TestShellFragments_SkipsManagedRCFile(t *testing.T) {
+	configDir := t.TempDir()
+	homeDir := t.TempDir()
+
+	writeFragment(t, configDir, "path.sh", "export PATH=\"$HOME/bin:$PATH\"\n")
+	// zshrc is deployed whole from the plonk directory
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "zshrc"), []byte("# managed\n"), 0644))
+
+	r := &ShellFragments{configDir: configDir, homeDir: homeDir, shell: "zsh"}
+	desired, err := r.Desired(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, desired)
+}
+
+func TestLookup_BuiltinResource(t *testing.T) {
+	r, err := Lookup(ShellFragmentsName, t.TempDir(), t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, ShellFragmentsName, r.Name())
+	assert.True(t, IsBuiltin(ShellFragmentsName))
+	assert.False(t, IsBuiltin("gcloud"))
+}
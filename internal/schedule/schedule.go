@@ -0,0 +1,261 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+// Package schedule registers a recurring plonk job with the platform's user
+// service manager: a launchd agent on macOS, a systemd user timer on Linux.
+package schedule
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Names used for the installed unit files
+const (
+	LaunchdLabel = "dev.plonk.maintenance"
+	SystemdUnit  = "plonk-maintenance"
+)
+
+// MinInterval keeps a misconfigured schedule from hammering package managers
+const MinInterval = 5 * time.Minute
+
+// Job describes the command run on a schedule
+type Job struct {
+	Executable string            // absolute path to the plonk binary
+	Args       []string          // plonk arguments, e.g. ["status"]
+	Interval   time.Duration     // time between runs
+	LogPath    string            // stdout and stderr are appended here
+	Env        map[string]string // environment for the job (PATH, PLONK_DIR)
+}
+
+// Validate checks that the job can be installed
+func (j Job) Validate() error {
+	if !filepath.IsAbs(j.Executable) {
+		return fmt.Errorf("executable must be an absolute path: %s", j.Executable)
+	}
+	if j.Interval < MinInterval {
+		return fmt.Errorf("interval %s is shorter than the minimum of %s", j.Interval, MinInterval)
+	}
+	if !filepath.IsAbs(j.LogPath) {
+		return fmt.Errorf("log path must be an absolute path: %s", j.LogPath)
+	}
+	return nil
+}
+
+// File is a unit file written by Install
+type File struct {
+	Path    string
+	Content string
+}
+
+// runCommand runs a service manager command; replaced in tests
+var runCommand = func(ctx context.Context, name string, args ...string) error {
+	out, err := exec.CommandContext(ctx, name, args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s %s: %s: %w", name, strings.Join(args, " "), strings.TrimSpace(string(out)), err)
+	}
+	return nil
+}
+
+// Files returns the unit files for job on this platform
+func Files(job Job, homeDir string) ([]File, error) {
+	switch runtime.GOOS {
+	case "darwin":
+		return []File{{Path: launchdPlistPath(homeDir), Content: LaunchdPlist(job)}}, nil
+	case "linux":
+		if err := checkSystemdPath(job.LogPath); err != nil {
+			return nil, err
+		}
+		service, timer := SystemdUnits(job)
+		dir := systemdUnitDir(homeDir)
+		return []File{
+			{Path: filepath.Join(dir, SystemdUnit+".service"), Content: service},
+			{Path: filepath.Join(dir, SystemdUnit+".timer"), Content: timer},
+		}, nil
+	default:
+		return nil, fmt.Errorf("scheduling is not supported on %s", runtime.GOOS)
+	}
+}
+
+// Install writes the unit files for job and activates them, replacing any
+// previously installed schedule. It returns the files written.
+func Install(ctx context.Context, job Job, homeDir string) ([]File, error) {
+	if err := job.Validate(); err != nil {
+		return nil, err
+	}
+	files, err := Files(job, homeDir)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(job.LogPath), 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create log directory: %w", err)
+	}
+	for _, f := range files {
+		if err := os.MkdirAll(filepath.Dir(f.Path), 0o750); err != nil {
+			return nil, fmt.Errorf("failed to create %s: %w", filepath.Dir(f.Path), err)
+		}
+		if err := os.WriteFile(f.Path, []byte(f.Content), 0o644); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", f.Path, err)
+		}
+	}
+
+	switch runtime.GOOS {
+	case "darwin":
+		domain := fmt.Sprintf("gui/%d", os.Getuid())
+		// Unload a previous version first; failure just means it wasn't loaded
+		_ = runCommand(ctx, "launchctl", "bootout", domain+"/"+LaunchdLabel)
+		err = runCommand(ctx, "launchctl", "bootstrap", domain, files[0].Path)
+	case "linux":
+		if err = runCommand(ctx, "systemctl", "--user", "daemon-reload"); err == nil {
+			err = runCommand(ctx, "systemctl", "--user", "enable", "--now", SystemdUnit+".timer")
+		}
+	}
+	if err != nil {
+		return files, fmt.Errorf("unit files written but activation failed: %w", err)
+	}
+	return files, nil
+}
+
+// Uninstall deactivates the schedule and removes its unit files. It returns
+// the paths removed; removing a schedule that is not installed is not an error.
+func Uninstall(ctx context.Context, homeDir string) ([]string, error) {
+	files, err := Files(Job{}, homeDir)
+	if err != nil {
+		return nil, err
+	}
+
+	switch runtime.GOOS {
+	case "darwin":
+		_ = runCommand(ctx, "launchctl", "bootout", fmt.Sprintf("gui/%d/%s", os.Getuid(), LaunchdLabel))
+	case "linux":
+		_ = runCommand(ctx, "systemctl", "--user", "disable", "--now", SystemdUnit+".timer")
+	}
+
+	var removed []string
+	var errs []error
+	for _, f := range files {
+		if err := os.Remove(f.Path); err == nil {
+			removed = append(removed, f.Path)
+		} else if !os.IsNotExist(err) {
+			errs = append(errs, err)
+		}
+	}
+	if runtime.GOOS == "linux" && len(removed) > 0 {
+		_ = runCommand(ctx, "systemctl", "--user", "daemon-reload")
+	}
+	return removed, errors.Join(errs...)
+}
+
+// LaunchdPlist renders a launchd agent that runs job every interval
+func LaunchdPlist(job Job) string {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">` + "\n")
+	b.WriteString(`<plist version="1.0">` + "\n<dict>\n")
+	plistKey(&b, "Label", LaunchdLabel)
+	b.WriteString("  <key>ProgramArguments</key>\n  <array>\n")
+	for _, arg := range append([]string{job.Executable}, job.Args...) {
+		fmt.Fprintf(&b, "    <string>%s</string>\n", xmlEscape(arg))
+	}
+	b.WriteString("  </array>\n")
+	fmt.Fprintf(&b, "  <key>StartInterval</key>\n  <integer>%d</integer>\n", int(job.Interval.Seconds()))
+	if len(job.Env) > 0 {
+		b.WriteString("  <key>EnvironmentVariables</key>\n  <dict>\n")
+		for _, k := range sortedKeys(job.Env) {
+			fmt.Fprintf(&b, "    <key>%s</key>\n    <string>%s</string>\n", xmlEscape(k), xmlEscape(job.Env[k]))
+		}
+		b.WriteString("  </dict>\n")
+	}
+	plistKey(&b, "StandardOutPath", job.LogPath)
+	plistKey(&b, "StandardErrorPath", job.LogPath)
+	b.WriteString("</dict>\n</plist>\n")
+	return b.String()
+}
+
+// SystemdUnits renders a oneshot service and the timer that triggers it
+func SystemdUnits(job Job) (service, timer string) {
+	var s strings.Builder
+	s.WriteString("[Unit]\nDescription=plonk scheduled maintenance\n\n[Service]\nType=oneshot\n")
+	for _, k := range sortedKeys(job.Env) {
+		fmt.Fprintf(&s, "Environment=%s\n", systemdQuote(k+"="+job.Env[k]))
+	}
+	args := make([]string, 0, len(job.Args)+1)
+	for _, arg := range append([]string{job.Executable}, job.Args...) {
+		args = append(args, systemdQuote(arg))
+	}
+	fmt.Fprintf(&s, "ExecStart=%s\n", strings.Join(args, " "))
+	logPath := strings.ReplaceAll(job.LogPath, "%", "%%")
+	fmt.Fprintf(&s, "StandardOutput=append:%s\nStandardError=append:%s\n", logPath, logPath)
+
+	timer = fmt.Sprintf(`[Unit]
+Description=Run plonk maintenance every %s
+
+[Timer]
+OnBootSec=5min
+OnUnitActiveSec=%ds
+
+[Install]
+WantedBy=timers.target
+`, job.Interval, int(job.Interval.Seconds()))
+	return s.String(), timer
+}
+
+func launchdPlistPath(homeDir string) string {
+	return filepath.Join(homeDir, "Library", "LaunchAgents", LaunchdLabel+".plist")
+}
+
+func systemdUnitDir(homeDir string) string {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "systemd", "user")
+	}
+	return filepath.Join(homeDir, ".config", "systemd", "user")
+}
+
+func plistKey(b *strings.Builder, key, value string) {
+	fmt.Fprintf(b, "  <key>%s</key>\n  <string>%s</string>\n", key, xmlEscape(value))
+}
+
+func xmlEscape(s string) string {
+	var buf bytes.Buffer
+	_ = xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
+
+// checkSystemdPath rejects a log path systemd can't take after append:,
+// where quotes are not understood and whitespace ends the path
+func checkSystemdPath(path string) error {
+	if strings.IndexFunc(path, func(r rune) bool { return unicode.IsSpace(r) || unicode.IsControl(r) }) >= 0 {
+		return fmt.Errorf("log path must not contain whitespace for a systemd timer: %q", path)
+	}
+	return nil
+}
+
+// systemdQuote quotes a value for ExecStart/Environment lines when needed
+func systemdQuote(s string) string {
+	if s != "" && !strings.ContainsAny(s, " \t\"'\\$%") {
+		return s
+	}
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, `$`, `$$`, `%`, `%%`)
+	return `"` + r.Replace(s) + `"`
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
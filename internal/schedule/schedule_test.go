@@ -0,0 +1,114 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package schedule
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testJob(t *testing.T) Job {
+	t.Helper()
+	return Job{
+		Executable: "/usr/local/bin/plonk",
+		Args:       []string{"status"},
+		Interval:   6 * time.Hour,
+		LogPath:    filepath.Join(t.TempDir(), "logs", "plonk.log"),
+		Env:        map[string]string{"PATH": "/usr/bin:/bin", "PLONK_DIR": "/home/me/my dots"},
+	}
+}
+
+func TestJobValidate(t *testing.T) {
+	job := testJob(t)
+	require.NoError(t, job.Validate())
+
+	short := job
+	short.Interval = time.Minute
+	assert.Error(t, short.Validate())
+
+	relative := job
+	relative.Executable = "plonk"
+	assert.Error(t, relative.Validate())
+}
+
+func TestLaunchdPlist(t *testing.T) {
+	job := testJob(t)
+	job.Args = []string{"status", "<&>"}
+	plist := LaunchdPlist(job)
+
+	assert.Contains(t, plist, "<string>"+LaunchdLabel+"</string>")
+	assert.Contains(t, plist, "<string>/usr/local/bin/plonk</string>")
+	assert.Contains(t, plist, "<string>&lt;&amp;&gt;</string>")
+	assert.Contains(t, plist, "<integer>21600</integer>")
+	assert.Contains(t, plist, "<key>PLONK_DIR</key>")
+	assert.Contains(t, plist, "<key>StandardErrorPath</key>\n  <string>"+job.LogPath+"</string>")
+}
+
+func TestSystemdUnits(t *testing.T) {
+	job := testJob(t)
+	service, timer := SystemdUnits(job)
+
+	assert.Contains(t, service, "ExecStart=/usr/local/bin/plonk status\n")
+	assert.Contains(t, service, `Environment="PLONK_DIR=/home/me/my dots"`)
+	assert.Contains(t, service, "StandardOutput=append:"+job.LogPath)
+	assert.Contains(t, timer, "OnUnitActiveSec=21600s")
+	assert.Contains(t, timer, "WantedBy=timers.target")
+}
+
+func TestSystemdUnits_LogPath(t *testing.T) {
+	job := testJob(t)
+	job.LogPath = "/home/me/100%/plonk.log"
+	service, _ := SystemdUnits(job)
+	assert.Contains(t, service, "StandardOutput=append:/home/me/100%%/plonk.log\n", "% starts a systemd specifier")
+
+	assert.NoError(t, checkSystemdPath("/home/me/.local/state/plonk/plonk.log"))
+	assert.ErrorContains(t, checkSystemdPath("/home/me/my logs/plonk.log"), "must not contain whitespace")
+	assert.Error(t, checkSystemdPath("/home/me/plonk.log\nExecStartPost=/bin/sh"))
+}
+
+func TestInstallAndUninstall(t *testing.T) {
+	if runtime.GOOS != "linux" && runtime.GOOS != "darwin" {
+		t.Skip("scheduling is only supported on macOS and Linux")
+	}
+	t.Setenv("XDG_CONFIG_HOME", "")
+
+	var calls []string
+	orig := runCommand
+	runCommand = func(_ context.Context, name string, args ...string) error {
+		calls = append(calls, name+" "+strings.Join(args, " "))
+		return nil
+	}
+	t.Cleanup(func() { runCommand = orig })
+
+	home := t.TempDir()
+	job := testJob(t)
+	files, err := Install(context.Background(), job, home)
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+	for _, f := range files {
+		data, err := os.ReadFile(f.Path)
+		require.NoError(t, err)
+		assert.Equal(t, f.Content, string(data))
+		assert.True(t, strings.HasPrefix(f.Path, home))
+	}
+	assert.DirExists(t, filepath.Dir(job.LogPath))
+	assert.NotEmpty(t, calls)
+
+	removed, err := Uninstall(context.Background(), home)
+	require.NoError(t, err)
+	assert.Len(t, removed, len(files))
+
+	// Uninstalling again is a no-op
+	removed, err = Uninstall(context.Background(), home)
+	require.NoError(t, err)
+	assert.Empty(t, removed)
+}
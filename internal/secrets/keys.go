@@ -0,0 +1,56 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package secrets
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/pem"
+	"strings"
+)
+
+// UnencryptedPrivateKey reports whether content holds a private key that
+// is stored without a passphrase. A key with one is safe to commit.
+func UnencryptedPrivateKey(content []byte) bool {
+	for rest := content; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return false
+		}
+		switch {
+		case block.Type == "OPENSSH PRIVATE KEY":
+			if openSSHCipher(block.Bytes) == "none" {
+				return true
+			}
+		case block.Type == "ENCRYPTED PRIVATE KEY":
+			// PKCS #8 with a passphrase
+		case strings.HasSuffix(block.Type, "PRIVATE KEY"):
+			// PKCS #1, SEC 1 and PKCS #8 keys; the legacy formats mark
+			// encryption in a header
+			if !strings.Contains(block.Headers["Proc-Type"], "ENCRYPTED") {
+				return true
+			}
+		}
+	}
+}
+
+// openSSHCipher returns the cipher name of an openssh-key-v1 key, which
+// is "none" when the key has no passphrase
+func openSSHCipher(data []byte) string {
+	magic := []byte("openssh-key-v1\x00")
+	if !bytes.HasPrefix(data, magic) {
+		// Not what ssh-keygen writes; don't guess
+		return ""
+	}
+	data = data[len(magic):]
+	if len(data) < 4 {
+		return ""
+	}
+	n := binary.BigEndian.Uint32(data)
+	if uint64(len(data)-4) < uint64(n) {
+		return ""
+	}
+	return string(data[4 : 4+n])
+}
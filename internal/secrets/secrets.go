@@ -0,0 +1,189 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+// Package secrets finds credentials in files before they are committed to
+// the plonk directory or synced elsewhere. The detectors only match formats
+// that are rarely anything but a secret, such as private key blocks and
+// provider tokens with fixed prefixes, so a finding is worth stopping for.
+package secrets
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// AllowMarker on a line tells the scanner the match is not a secret
+const AllowMarker = "plonk:allow-secret"
+
+// maxFileSize bounds the files scanned; dotfiles with credentials are small
+const maxFileSize = 1 << 20
+
+// Finding is a probable secret in a file
+type Finding struct {
+	Path  string `json:"path" yaml:"path"` // as given to the scanner, or relative to the scanned directory
+	Line  int    `json:"line" yaml:"line"`
+	Rule  string `json:"rule" yaml:"rule"`
+	Match string `json:"match" yaml:"match"` // redacted
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s:%d: %s (%s)", f.Path, f.Line, f.Rule, f.Match)
+}
+
+// privateKeyBegin starts a PEM private key block
+var privateKeyBegin = regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY(?: BLOCK)?-----`)
+
+// detector matches one kind of secret
+type detector struct {
+	rule    string
+	pattern *regexp.Regexp
+}
+
+// detectors are compiled on first use rather than at startup, since most
+// plonk commands never scan for secrets
+var detectors = sync.OnceValue(func() []detector {
+	return []detector{
+		{"AWS access key ID", regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)},
+		{"AWS secret access key", regexp.MustCompile(`(?i)aws_secret_access_key\s*[=:]\s*["']?[A-Za-z0-9/+]{40}(?:[^A-Za-z0-9/+=]|$)`)},
+		{"GitHub token", regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{60,})\b`)},
+		{"GitLab token", regexp.MustCompile(`\bglpat-[A-Za-z0-9_-]{20,}\b`)},
+		{"Slack token", regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}`)},
+		{"Stripe live key", regexp.MustCompile(`\b[rs]k_live_[A-Za-z0-9]{20,}\b`)},
+		{"Google API key", regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}\b`)},
+		{"npm token", regexp.MustCompile(`\bnpm_[A-Za-z0-9]{36}\b`)},
+		{"OpenAI API key", regexp.MustCompile(`\bsk-(?:proj-)?[A-Za-z0-9_-]{20,}T3BlbkFJ[A-Za-z0-9_-]{20,}\b`)},
+		{"Anthropic API key", regexp.MustCompile(`\bsk-ant-[a-z]+\d*-[A-Za-z0-9_-]{80,}\b`)},
+	}
+})
+
+// Scan returns the probable secrets in data, reported under name. Private
+// keys count only without a passphrase.
+func Scan(name string, data []byte) []Finding {
+	if bytes.IndexByte(data, 0) >= 0 {
+		return nil // binary
+	}
+	unencryptedKey := UnencryptedPrivateKey(data)
+	var findings []Finding
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), maxFileSize)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if strings.Contains(text, AllowMarker) {
+			continue
+		}
+		if unencryptedKey {
+			if match := privateKeyBegin.FindString(text); match != "" {
+				findings = append(findings, Finding{Path: name, Line: line, Rule: "private key", Match: match})
+				unencryptedKey = false // one finding per file
+			}
+		}
+		for _, d := range detectors() {
+			match := d.pattern.FindString(text)
+			// AWS documents its example keys with EXAMPLE in them
+			if match == "" || strings.Contains(match, "EXAMPLE") {
+				continue
+			}
+			findings = append(findings, Finding{Path: name, Line: line, Rule: d.rule, Match: redact(match)})
+		}
+	}
+	return findings
+}
+
+// ScanPath scans a file, or every file under a directory except .git
+// directories. Findings in a directory are reported relative to it.
+func ScanPath(path string) ([]Finding, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return scanFile(path, path)
+	}
+
+	var findings []Finding
+	err = filepath.WalkDir(path, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if entry.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(path, file)
+		if err != nil {
+			return err
+		}
+		found, err := scanFile(file, filepath.ToSlash(rel))
+		findings = append(findings, found...)
+		return err
+	})
+	return findings, err
+}
+
+// ScanFiles scans file contents by name, such as a snapshot about to be
+// synced, returning findings sorted by name
+func ScanFiles(files map[string][]byte) []Finding {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var findings []Finding
+	for _, name := range names {
+		if len(files[name]) <= maxFileSize {
+			findings = append(findings, Scan(name, files[name])...)
+		}
+	}
+	return findings
+}
+
+// scanFile scans the file at path, skipping large ones
+func scanFile(path, name string) ([]Finding, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() > maxFileSize {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Scan(name, data), nil
+}
+
+// redact keeps enough of a match to find it again
+func redact(match string) string {
+	if len(match) <= 8 {
+		return match
+	}
+	return match[:6] + strings.Repeat("*", 6)
+}
+
+// Error describes findings that stopped an operation
+func Error(findings []Finding) error {
+	lines := make([]string, 0, len(findings))
+	for _, f := range findings {
+		lines = append(lines, "  "+f.String())
+	}
+	noun := "secret"
+	if len(findings) > 1 {
+		noun = "secrets"
+	}
+	return fmt.Errorf("probable %s found:\n%s\nremove them, mark a false positive with a %q comment on its line, or pass --allow-secrets",
+		noun, strings.Join(lines, "\n"), AllowMarker)
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+// Package selftest applies a plonk directory inside throwaway Docker
+// containers, to check a configuration on clean Linux distributions before
+// trusting it on a new machine.
+package selftest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/richhaase/plonk/internal/output"
+)
+
+// DefaultImages are the distributions tested when none are given
+var DefaultImages = []string{"ubuntu:24.04", "fedora:latest", "archlinux:latest"}
+
+// Container paths the plonk directory and binary are mounted at
+const (
+	containerSource = "/plonk-src"
+	containerBinary = "/usr/local/bin/plonk"
+)
+
+// script copies the read-only plonk directory to the default location, as
+// clone would, and applies it
+const script = `set -e
+mkdir -p "$HOME/.config"
+cp -R ` + containerSource + ` "$HOME/.config/plonk"
+exec plonk apply -o json`
+
+// Options configures a self test
+type Options struct {
+	Dir    string   // plonk directory to test
+	Binary string   // Linux plonk binary to run in the containers
+	Images []string // Docker images, one container each
+}
+
+// LinuxBinary returns the plonk binary to mount into containers: the
+// running executable on Linux. Elsewhere it can't run in a Linux container,
+// and one has to be given.
+func LinuxBinary() (string, error) {
+	if runtime.GOOS != "linux" {
+		return "", errors.New("the running plonk is not a Linux binary; pass --binary with a Linux build of plonk")
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	return filepath.EvalSymlinks(exe)
+}
+
+// Run applies opts.Dir in a fresh container of each image, one after
+// another, and reports how each went. progress is called before each image.
+func Run(ctx context.Context, opts Options, progress func(image string)) (output.SelftestOutput, error) {
+	result := output.SelftestOutput{Directory: opts.Dir}
+	if _, err := exec.LookPath("docker"); err != nil {
+		return result, errors.New("docker is not installed or not in PATH")
+	}
+	dir, err := filepath.Abs(opts.Dir)
+	if err != nil {
+		return result, err
+	}
+	binary, err := filepath.Abs(opts.Binary)
+	if err != nil {
+		return result, err
+	}
+
+	for _, image := range opts.Images {
+		if ctx.Err() != nil {
+			return result, ctx.Err()
+		}
+		if progress != nil {
+			progress(image)
+		}
+		result.Results = append(result.Results, runImage(ctx, image, dir, binary))
+	}
+	return result, nil
+}
+
+// runImage applies dir in one container of image
+func runImage(ctx context.Context, image, dir, binary string) output.SelftestResult {
+	start := time.Now()
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "docker", dockerArgs(image, dir, binary)...)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	runErr := cmd.Run()
+
+	res := output.SelftestResult{Image: image, Duration: time.Since(start).Round(time.Second)}
+	// On failure plonk writes an error document after the apply result
+	var apply output.ApplyResult
+	if err := json.NewDecoder(&stdout).Decode(&apply); err != nil {
+		// plonk never ran: the image couldn't be pulled, has no shell, ...
+		res.Error = lastLine(stderr.String())
+		if res.Error == "" && runErr != nil {
+			res.Error = runErr.Error()
+		}
+		return res
+	}
+
+	res.Success = apply.Success && runErr == nil
+	res.Error = apply.Error
+	if apply.Packages != nil {
+		res.PackagesInstalled = apply.Packages.TotalInstalled
+		for _, m := range apply.Packages.Managers {
+			for _, p := range m.Packages {
+				if p.Status == "failed" {
+					res.FailedPackages = append(res.FailedPackages, m.Name+":"+p.Name)
+				}
+			}
+		}
+	}
+	if apply.Dotfiles != nil {
+		res.DotfilesDeployed = apply.Dotfiles.Summary.Added + apply.Dotfiles.Summary.Updated
+		res.DotfilesFailed = apply.Dotfiles.Summary.Failed
+	}
+	if !res.Success && res.Error == "" && len(res.FailedPackages) == 0 && res.DotfilesFailed == 0 {
+		res.Error = lastLine(stderr.String())
+	}
+	return res
+}
+
+// dockerArgs runs script in a disposable container with the plonk
+// directory and binary mounted read-only
+func dockerArgs(image, dir, binary string) []string {
+	return []string{
+		"run", "--rm",
+		"-v", dir + ":" + containerSource + ":ro",
+		"-v", binary + ":" + containerBinary + ":ro",
+		image, "sh", "-c", script,
+	}
+}
+
+// lastLine returns the last non-empty line of s
+func lastLine(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
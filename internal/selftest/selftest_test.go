@@ -0,0 +1,83 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package selftest
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDocker puts a docker on PATH that answers by image: ok applies
+// cleanly, partial fails a package, and anything else can't be pulled
+func fakeDocker(t *testing.T) {
+	t.Helper()
+	dir := t.TempDir()
+	script := `#!/bin/sh
+case "$*" in
+  *" ok sh -c "*)
+    echo '{"success": true, "packages": {"total_installed": 2, "managers": []}, "dotfiles": {"summary": {"added": 3}}}'
+    ;;
+  *" partial sh -c "*)
+    echo '{"success": false, "packages": {"total_installed": 1, "managers": [{"name": "brew", "packages": [{"name": "jq", "status": "installed"}, {"name": "fd", "status": "failed"}]}]}}'
+    echo '{"error": "apply failed"}'
+    echo "brew: command not found" >&2
+    exit 1
+    ;;
+  *)
+    echo "Unable to find image" >&2
+    echo "pull access denied for missing" >&2
+    exit 125
+    ;;
+esac
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "docker"), []byte(script), 0o755))
+	t.Setenv("PATH", dir)
+}
+
+func TestRun(t *testing.T) {
+	fakeDocker(t)
+
+	result, err := Run(context.Background(), Options{
+		Dir:    t.TempDir(),
+		Binary: "/usr/local/bin/plonk",
+		Images: []string{"ok", "partial", "missing"},
+	}, nil)
+	require.NoError(t, err)
+	require.Len(t, result.Results, 3)
+
+	ok := result.Results[0]
+	assert.True(t, ok.Success)
+	assert.Equal(t, 2, ok.PackagesInstalled)
+	assert.Equal(t, 3, ok.DotfilesDeployed)
+
+	partial := result.Results[1]
+	assert.False(t, partial.Success)
+	assert.Equal(t, 1, partial.PackagesInstalled)
+	assert.Equal(t, []string{"brew:fd"}, partial.FailedPackages)
+
+	missing := result.Results[2]
+	assert.False(t, missing.Success)
+	assert.Equal(t, "pull access denied for missing", missing.Error)
+
+	assert.True(t, result.Failed())
+}
+
+func TestRun_NoDocker(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+	_, err := Run(context.Background(), Options{Dir: t.TempDir(), Images: DefaultImages}, nil)
+	assert.ErrorContains(t, err, "docker is not installed")
+}
+
+func TestDockerArgs(t *testing.T) {
+	args := dockerArgs("ubuntu:24.04", "/home/u/.config/plonk", "/usr/bin/plonk")
+	assert.Equal(t, []string{"run", "--rm",
+		"-v", "/home/u/.config/plonk:/plonk-src:ro",
+		"-v", "/usr/bin/plonk:/usr/local/bin/plonk:ro",
+		"ubuntu:24.04", "sh", "-c", script}, args)
+}
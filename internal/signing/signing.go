@@ -0,0 +1,210 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+// Package signing signs plonk.lock and verifies it before packages are
+// installed, so a tampered dotfiles repository can't add packages to a
+// machine unnoticed. SSH keys (ssh-keygen -Y) and minisign keys are
+// supported; the format is read from the key or signature itself.
+package signing
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/richhaase/plonk/internal/config"
+	"github.com/richhaase/plonk/internal/lock"
+)
+
+// SignatureFile is the detached signature of plonk.lock in the config
+// directory. The leading dot keeps it from being deployed as a dotfile.
+const SignatureFile = ".plonk.lock.sig"
+
+// TrustEnv names the trust file that turns on verification: an SSH
+// allowed_signers file or a minisign public key
+const TrustEnv = "PLONK_ALLOWED_SIGNERS"
+
+// trustFileName is the per-machine trust file used when TrustEnv is unset
+const trustFileName = "allowed_signers"
+
+// namespace separates plonk's SSH signatures from git's and others
+const namespace = "plonk-lock"
+
+// ErrUnsigned is returned when verification is on but plonk.lock has no
+// signature
+var ErrUnsigned = errors.New("plonk.lock is not signed")
+
+func init() {
+	// A migrated lock would no longer match the signature it was verified by
+	lock.Pinned = func() bool { return TrustFile() != "" }
+}
+
+// TrustFile returns the trust file for this machine: $PLONK_ALLOWED_SIGNERS,
+// or allowed_signers in the state directory if it exists. It is never read
+// from the config directory, which a tampered repository controls. "" means
+// verification is off.
+func TrustFile() string {
+	if path := os.Getenv(TrustEnv); path != "" {
+		return path
+	}
+	path := filepath.Join(config.GetStateDirectory(), trustFileName)
+	if _, err := os.Stat(path); err == nil {
+		return path
+	}
+	return ""
+}
+
+// InstallTrustFile copies src to the per-machine trust file, so later
+// applies verify plonk.lock without $PLONK_ALLOWED_SIGNERS, and returns
+// where it was written
+func InstallTrustFile(src string) (string, error) {
+	data, err := os.ReadFile(expandHome(src))
+	if err != nil {
+		return "", fmt.Errorf("trust file: %w", err)
+	}
+	dest := filepath.Join(config.GetStateDirectory(), trustFileName)
+	if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(dest, data, 0o644); err != nil {
+		return "", err
+	}
+	return dest, nil
+}
+
+// SignLock signs plonk.lock in configDir with key (an SSH private key, or
+// its .pub with the key in ssh-agent, or a minisign secret key) unless the
+// signature is already newer than the lock. It reports whether it signed.
+func SignLock(ctx context.Context, configDir, key string) (bool, error) {
+	lockPath := filepath.Join(configDir, lock.LockFileName)
+	sigPath := filepath.Join(configDir, SignatureFile)
+
+	lockInfo, err := os.Stat(lockPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	if sigInfo, err := os.Stat(sigPath); err == nil && !sigInfo.ModTime().Before(lockInfo.ModTime()) {
+		return false, nil
+	}
+
+	if err := Sign(ctx, lockPath, sigPath, expandHome(key)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Sign writes a detached signature of file to sigPath
+func Sign(ctx context.Context, file, sigPath, key string) error {
+	minisign, err := isMinisignKey(key)
+	if err != nil {
+		return err
+	}
+
+	if minisign {
+		cmd := exec.CommandContext(ctx, "minisign", "-S", "-s", key, "-m", file, "-x", sigPath)
+		cmd.Stdin = os.Stdin // for the key's password
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("minisign: %s: %w", strings.TrimSpace(string(out)), err)
+		}
+		return nil
+	}
+
+	// ssh-keygen writes <file>.sig next to file; move it into place
+	out, err := exec.CommandContext(ctx, "ssh-keygen", "-Y", "sign", "-f", key, "-n", namespace, file).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("ssh-keygen: %s: %w", strings.TrimSpace(string(out)), err)
+	}
+	return os.Rename(file+".sig", sigPath)
+}
+
+// VerifyLock checks plonk.lock in configDir against this machine's trust
+// file. It does nothing when no trust file is configured and fails closed
+// otherwise: a missing or bad signature is an error.
+func VerifyLock(ctx context.Context, configDir string) error {
+	trust := TrustFile()
+	if trust == "" {
+		return nil
+	}
+	lockPath := filepath.Join(configDir, lock.LockFileName)
+	if _, err := os.Stat(lockPath); os.IsNotExist(err) {
+		return nil
+	}
+	if err := Verify(ctx, lockPath, filepath.Join(configDir, SignatureFile), trust); err != nil {
+		return fmt.Errorf("%w (trust file %s)", err, trust)
+	}
+	return nil
+}
+
+// Verify checks the detached signature at sigPath for file against trust
+func Verify(ctx context.Context, file, sigPath, trust string) error {
+	sig, err := os.ReadFile(sigPath)
+	if os.IsNotExist(err) {
+		return ErrUnsigned
+	}
+	if err != nil {
+		return err
+	}
+
+	var cmd *exec.Cmd
+	if bytes.HasPrefix(sig, []byte("untrusted comment:")) {
+		cmd = exec.CommandContext(ctx, "minisign", "-V", "-q", "-p", trust, "-m", file, "-x", sigPath)
+	} else {
+		principal, err := findPrincipal(ctx, sigPath, trust)
+		if err != nil {
+			return err
+		}
+		data, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer data.Close()
+		cmd = exec.CommandContext(ctx, "ssh-keygen", "-Y", "verify", "-f", trust, "-I", principal, "-n", namespace, "-s", sigPath)
+		cmd.Stdin = data
+	}
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("plonk.lock signature is not valid: %s", strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// findPrincipal returns the allowed_signers principal whose key made the
+// signature
+func findPrincipal(ctx context.Context, sigPath, allowedSigners string) (string, error) {
+	out, err := exec.CommandContext(ctx, "ssh-keygen", "-Y", "find-principals", "-f", allowedSigners, "-s", sigPath).Output()
+	principal, _, _ := strings.Cut(strings.TrimSpace(string(out)), "\n")
+	if err != nil || principal == "" {
+		return "", errors.New("plonk.lock is signed by a key that is not in the trust file")
+	}
+	return principal, nil
+}
+
+// isMinisignKey reports whether key is a minisign secret key rather than
+// an SSH key, from its first line
+func isMinisignKey(key string) (bool, error) {
+	f, err := os.Open(key)
+	if err != nil {
+		return false, fmt.Errorf("signing key: %w", err)
+	}
+	defer f.Close()
+	line, _ := bufio.NewReader(f).ReadString('\n')
+	return strings.HasPrefix(line, "untrusted comment:") && strings.Contains(line, "minisign"), nil
+}
+
+// expandHome expands a leading ~/ in path
+func expandHome(path string) string {
+	if after, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, after)
+		}
+	}
+	return path
+}
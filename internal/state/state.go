@@ -0,0 +1,246 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+// Package state converts what plonk manages to and from a neutral JSON
+// document, so other tools can generate or consume plonk state without
+// parsing plonk.yaml or plonk.lock.
+//
+// The document format is described in docs/reference.md ("State Format").
+// Schema changes that would break existing readers bump SchemaVersion.
+package state
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/richhaase/plonk/internal/dotfiles"
+	"github.com/richhaase/plonk/internal/lock"
+	"github.com/richhaase/plonk/internal/packages"
+)
+
+// SchemaVersion is the version of the document format written by Export
+const SchemaVersion = 1
+
+// Document is the exported state of one plonk directory
+type Document struct {
+	Schema     int       `json:"schema" yaml:"schema"`
+	ExportedAt time.Time `json:"exported_at" yaml:"exported_at"`
+	Host       string    `json:"host,omitempty" yaml:"host,omitempty"`
+	Packages   []Package `json:"packages" yaml:"packages"`
+	Dotfiles   []Dotfile `json:"dotfiles" yaml:"dotfiles"`
+}
+
+// Package is one tracked package
+type Package struct {
+	Manager string `json:"manager" yaml:"manager"`
+	Name    string `json:"name" yaml:"name"`
+	// Version is accepted for interchange with tools that pin versions.
+	// plonk tracks packages unversioned, so Export leaves it empty and
+	// Import ignores it.
+	Version string `json:"version,omitempty" yaml:"version,omitempty"`
+}
+
+// Dotfile is one managed dotfile
+type Dotfile struct {
+	Source   string `json:"source" yaml:"source"` // path in $PLONK_DIR, slash-separated
+	Target   string `json:"target" yaml:"target"` // deploy path, "~/"-relative
+	Mode     string `json:"mode" yaml:"mode"`     // octal permissions, e.g. "0644"
+	SHA256   string `json:"sha256" yaml:"sha256"` // digest of the source file
+	Template bool   `json:"template,omitempty" yaml:"template,omitempty"`
+	Content  []byte `json:"content,omitempty" yaml:"content,omitempty"` // base64 in JSON
+}
+
+// Export reads the lock file and dotfile sources in configDir. File
+// contents are included only when withContent is set.
+func Export(configDir, homeDir string, ignorePatterns []string, withContent bool) (*Document, error) {
+	doc := &Document{
+		Schema:     SchemaVersion,
+		ExportedAt: time.Now().UTC(),
+		Packages:   []Package{},
+		Dotfiles:   []Dotfile{},
+	}
+	doc.Host, _ = os.Hostname()
+
+	lockFile, err := lock.NewLockV3Service(configDir).Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lock file: %w", err)
+	}
+	for _, manager := range sortedKeys(lockFile.Packages) {
+		for _, name := range lockFile.Packages[manager] {
+			doc.Packages = append(doc.Packages, Package{Manager: manager, Name: name})
+		}
+	}
+
+	files, err := dotfiles.NewDotfileManager(configDir, homeDir, ignorePatterns).List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dotfiles: %w", err)
+	}
+	for _, f := range files {
+		info, err := os.Stat(f.Source)
+		if err != nil {
+			return nil, err
+		}
+		data, err := os.ReadFile(f.Source)
+		if err != nil {
+			return nil, err
+		}
+		target, err := filepath.Rel(homeDir, f.Target)
+		if err != nil {
+			return nil, err
+		}
+		entry := Dotfile{
+			Source:   filepath.ToSlash(f.Name),
+			Target:   "~/" + filepath.ToSlash(target),
+			Mode:     fmt.Sprintf("%04o", info.Mode().Perm()),
+			SHA256:   digest(data),
+			Template: strings.HasSuffix(f.Name, ".tmpl"),
+		}
+		if withContent {
+			entry.Content = data
+		}
+		doc.Dotfiles = append(doc.Dotfiles, entry)
+	}
+	sort.Slice(doc.Dotfiles, func(i, j int) bool { return doc.Dotfiles[i].Source < doc.Dotfiles[j].Source })
+	return doc, nil
+}
+
+// ImportResult reports what Import did, or would do in a dry run. Entries
+// are "manager:name" for packages and source paths for dotfiles.
+type ImportResult struct {
+	Added     []string // packages added to the lock, dotfiles written
+	Unchanged []string // already tracked or identical
+	Skipped   []string // reason included, e.g. "zshrc (no content)"
+	Failed    []string // reason included
+}
+
+// Import merges doc into configDir: packages are added to plonk.lock and
+// dotfiles that carry content are written as sources. Nothing is installed
+// or deployed; 'plonk apply' does that. Existing dotfile sources that
+// differ from the document are left alone and reported as failures.
+func Import(configDir string, doc *Document, dryRun bool) (ImportResult, error) {
+	var result ImportResult
+	if doc.Schema < 1 || doc.Schema > SchemaVersion {
+		return result, fmt.Errorf("unsupported state schema %d (this plonk reads up to %d)", doc.Schema, SchemaVersion)
+	}
+
+	lockSvc := lock.NewLockV3Service(configDir)
+	lockFile, err := lockSvc.Read()
+	if err != nil {
+		return result, fmt.Errorf("failed to read lock file: %w", err)
+	}
+
+	var lockChanged bool
+	for _, p := range doc.Packages {
+		spec := p.Manager + ":" + p.Name
+		manager, name, err := packages.ParsePackageSpec(spec)
+		if err != nil {
+			result.Failed = append(result.Failed, fmt.Sprintf("%s (%v)", spec, err))
+			continue
+		}
+		if lockFile.HasPackage(manager, name) {
+			result.Unchanged = append(result.Unchanged, spec)
+			continue
+		}
+		lockFile.AddPackage(manager, name)
+		lockChanged = true
+		result.Added = append(result.Added, spec)
+	}
+
+	for _, d := range doc.Dotfiles {
+		outcome, reason := importDotfile(configDir, d, dryRun)
+		entry := d.Source
+		if reason != "" {
+			entry = fmt.Sprintf("%s (%s)", d.Source, reason)
+		}
+		switch outcome {
+		case outcomeAdded:
+			result.Added = append(result.Added, entry)
+		case outcomeUnchanged:
+			result.Unchanged = append(result.Unchanged, entry)
+		case outcomeSkipped:
+			result.Skipped = append(result.Skipped, entry)
+		default:
+			result.Failed = append(result.Failed, entry)
+		}
+	}
+
+	if lockChanged && !dryRun {
+		if err := lockSvc.Write(lockFile); err != nil {
+			return result, fmt.Errorf("failed to write lock file: %w", err)
+		}
+	}
+	return result, nil
+}
+
+type outcome int
+
+const (
+	outcomeAdded outcome = iota
+	outcomeUnchanged
+	outcomeSkipped
+	outcomeFailed
+)
+
+// importDotfile writes one dotfile source, returning what happened and why
+func importDotfile(configDir string, d Dotfile, dryRun bool) (outcome, string) {
+	rel := filepath.Clean(filepath.FromSlash(d.Source))
+	if !filepath.IsLocal(rel) || strings.HasPrefix(rel, ".") || slices.Contains([]string{"plonk.yaml", "plonk.lock"}, rel) {
+		return outcomeFailed, "not a dotfile source path"
+	}
+	if d.SHA256 != "" && d.Content != nil && digest(d.Content) != d.SHA256 {
+		return outcomeFailed, "content does not match sha256"
+	}
+
+	path := filepath.Join(configDir, rel)
+	existing, err := os.ReadFile(path)
+	switch {
+	case err == nil && (d.SHA256 == digest(existing) || (d.Content != nil && string(existing) == string(d.Content))):
+		return outcomeUnchanged, ""
+	case err == nil:
+		return outcomeFailed, "differs from existing source"
+	case !os.IsNotExist(err):
+		return outcomeFailed, err.Error()
+	case d.Content == nil:
+		return outcomeSkipped, "no content"
+	}
+
+	mode := os.FileMode(0o644)
+	if d.Mode != "" {
+		var m uint32
+		if _, err := fmt.Sscanf(d.Mode, "%o", &m); err != nil || m > 0o777 {
+			return outcomeFailed, fmt.Sprintf("invalid mode %q", d.Mode)
+		}
+		mode = os.FileMode(m)
+	}
+	if dryRun {
+		return outcomeAdded, ""
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return outcomeFailed, err.Error()
+	}
+	if err := os.WriteFile(path, d.Content, mode); err != nil {
+		return outcomeFailed, err.Error()
+	}
+	return outcomeAdded, ""
+}
+
+func digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func sortedKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package state
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/richhaase/plonk/internal/lock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupConfigDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	l := lock.NewLockV3()
+	l.AddPackage("brew", "ripgrep")
+	l.AddPackage("cargo", "bat")
+	require.NoError(t, lock.NewLockV3Service(dir).Write(l))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "config", "git"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "zshrc"), []byte("export A=1\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config", "git", "config.tmpl"), []byte("[user]\n"), 0o644))
+	return dir
+}
+
+func TestExport(t *testing.T) {
+	dir := setupConfigDir(t)
+
+	doc, err := Export(dir, "/home/user", nil, false)
+	require.NoError(t, err)
+	assert.Equal(t, SchemaVersion, doc.Schema)
+	assert.Equal(t, []Package{{Manager: "brew", Name: "ripgrep"}, {Manager: "cargo", Name: "bat"}}, doc.Packages)
+
+	require.Len(t, doc.Dotfiles, 2)
+	assert.Equal(t, Dotfile{
+		Source:   "config/git/config.tmpl",
+		Target:   "~/.config/git/config",
+		Mode:     "0644",
+		SHA256:   digest([]byte("[user]\n")),
+		Template: true,
+	}, doc.Dotfiles[0])
+	assert.Equal(t, "~/.zshrc", doc.Dotfiles[1].Target)
+	assert.Equal(t, "0600", doc.Dotfiles[1].Mode)
+	assert.Nil(t, doc.Dotfiles[1].Content)
+
+	doc, err = Export(dir, "/home/user", nil, true)
+	require.NoError(t, err)
+	assert.Equal(t, "export A=1\n", string(doc.Dotfiles[1].Content))
+}
+
+func TestExportImportRoundTrip(t *testing.T) {
+	doc, err := Export(setupConfigDir(t), "/home/user", nil, true)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(doc)
+	require.NoError(t, err)
+	var decoded Document
+	require.NoError(t, json.Unmarshal(data, &decoded))
+
+	dest := t.TempDir()
+	result, err := Import(dest, &decoded, false)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"brew:ripgrep", "cargo:bat", "config/git/config.tmpl", "zshrc"}, result.Added)
+	assert.Empty(t, result.Failed)
+
+	l, err := lock.NewLockV3Service(dest).Read()
+	require.NoError(t, err)
+	assert.True(t, l.HasPackage("cargo", "bat"))
+	info, err := os.Stat(filepath.Join(dest, "zshrc"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	// Importing again changes nothing
+	result, err = Import(dest, &decoded, false)
+	require.NoError(t, err)
+	assert.Empty(t, result.Added)
+	assert.Len(t, result.Unchanged, 4)
+}
+
+func TestImport_Problems(t *testing.T) {
+	dest := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dest, "vimrc"), []byte("set nu\n"), 0o644))
+
+	doc := &Document{
+		Schema:   SchemaVersion,
+		Packages: []Package{{Manager: "nope", Name: "x"}, {Manager: "brew", Name: "jq", Version: "1.7"}},
+		Dotfiles: []Dotfile{
+			{Source: "../escape", Content: []byte("x")},
+			{Source: ".gitignore", Content: []byte("x")},
+			{Source: "vimrc", Content: []byte("set nonu\n")},
+			{Source: "bashrc", SHA256: "abc"},
+			{Source: "inputrc", SHA256: "abc", Content: []byte("x")},
+		},
+	}
+	result, err := Import(dest, doc, true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"brew:jq"}, result.Added)
+	assert.Equal(t, []string{"bashrc (no content)"}, result.Skipped)
+	assert.Len(t, result.Failed, 5)
+	assert.Contains(t, result.Failed, "vimrc (differs from existing source)")
+	assert.Contains(t, result.Failed, "inputrc (content does not match sha256)")
+
+	// Dry run writes nothing
+	_, err = os.Stat(filepath.Join(dest, lock.LockFileName))
+	assert.True(t, os.IsNotExist(err))
+
+	_, err = Import(dest, &Document{Schema: SchemaVersion + 1}, false)
+	assert.ErrorContains(t, err, "unsupported state schema")
+}
@@ -0,0 +1,241 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package storage
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// archiveExt is the file extension of snapshot archives
+const archiveExt = ".tar.gz"
+
+// archiveTimeFormat names archives so they sort by creation time
+const archiveTimeFormat = "20060102-150405"
+
+// labelPattern restricts archive labels to names that are safe in a path
+var labelPattern = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+// ArchiveInfo describes a snapshot archive stored on this machine
+type ArchiveInfo struct {
+	Name      string
+	CreatedAt time.Time
+	Size      int64 // compressed bytes
+	Path      string
+}
+
+// WriteArchive writes the snapshot as a gzipped tar, one entry per file
+// with its permissions, stamped with modTime
+func WriteArchive(w io.Writer, s *Snapshot, modTime time.Time) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	paths := make([]string, 0, len(s.Files))
+	for p := range s.Files {
+		paths = append(paths, p)
+	}
+	slices.Sort(paths)
+
+	for _, p := range paths {
+		mode := int64(0o644)
+		if m, ok := s.Modes[p]; ok {
+			mode = int64(m)
+		}
+		hdr := &tar.Header{
+			Name:    p,
+			Mode:    mode,
+			Size:    int64(len(s.Files[p])),
+			ModTime: modTime,
+			Format:  tar.FormatPAX,
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if _, err := tw.Write(s.Files[p]); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// ReadArchive parses an archive written by WriteArchive. Entries other than
+// regular files are ignored, and paths that would escape the plonk
+// directory are rejected.
+func ReadArchive(r io.Reader) (*Snapshot, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid snapshot archive: %w", err)
+	}
+	defer gz.Close()
+
+	s := &Snapshot{Version: SnapshotVersion, Files: make(map[string][]byte)}
+	tr := tar.NewReader(gz)
+	total := 0
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid snapshot archive: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		if _, err := snapshotPath(".", hdr.Name); err != nil {
+			return nil, err
+		}
+
+		total += int(hdr.Size)
+		if hdr.Size < 0 || total > MaxSnapshotSize {
+			return nil, fmt.Errorf("snapshot archive exceeds %d MB limit", MaxSnapshotSize>>20)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid snapshot archive: %w", err)
+		}
+		s.Files[hdr.Name] = data
+		if perm := os.FileMode(hdr.Mode).Perm(); perm != 0o644 {
+			if s.Modes == nil {
+				s.Modes = make(map[string]uint32)
+			}
+			s.Modes[hdr.Name] = uint32(perm)
+		}
+	}
+	return s, nil
+}
+
+// CreateArchive captures configDir into a new archive in dir, named after
+// now plus an optional label
+func CreateArchive(dir, configDir, label string, now time.Time) (ArchiveInfo, error) {
+	if label != "" && !labelPattern.MatchString(label) {
+		return ArchiveInfo{}, fmt.Errorf("invalid snapshot label %q: use letters, digits, '.', '_' or '-'", label)
+	}
+	name := now.Format(archiveTimeFormat)
+	if label != "" {
+		name += "-" + label
+	}
+
+	s, err := Capture(configDir)
+	if err != nil {
+		return ArchiveInfo{}, err
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return ArchiveInfo{}, err
+	}
+	path := filepath.Join(dir, name+archiveExt)
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		if os.IsExist(err) {
+			return ArchiveInfo{}, fmt.Errorf("snapshot %s already exists", name)
+		}
+		return ArchiveInfo{}, err
+	}
+	if err := WriteArchive(f, s, now); err != nil {
+		f.Close()
+		os.Remove(path)
+		return ArchiveInfo{}, fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(path)
+		return ArchiveInfo{}, err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return ArchiveInfo{}, err
+	}
+	return ArchiveInfo{Name: name, CreatedAt: now, Size: info.Size(), Path: path}, nil
+}
+
+// ListArchives returns the archives in dir, newest first. A missing
+// directory has no archives.
+func ListArchives(dir string) ([]ArchiveInfo, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var archives []ArchiveInfo
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), archiveExt)
+		if !ok || !entry.Type().IsRegular() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+		archive := ArchiveInfo{Name: name, CreatedAt: info.ModTime(), Size: info.Size(), Path: filepath.Join(dir, entry.Name())}
+		if len(name) >= len(archiveTimeFormat) {
+			if t, err := time.ParseInLocation(archiveTimeFormat, name[:len(archiveTimeFormat)], time.Local); err == nil {
+				archive.CreatedAt = t
+			}
+		}
+		archives = append(archives, archive)
+	}
+
+	slices.SortFunc(archives, func(a, b ArchiveInfo) int {
+		return strings.Compare(b.Name, a.Name)
+	})
+	return archives, nil
+}
+
+// PruneArchives removes all but the newest keep archives in dir and
+// returns the ones removed. With dryRun it only returns them.
+func PruneArchives(dir string, keep int, dryRun bool) ([]ArchiveInfo, error) {
+	archives, err := ListArchives(dir)
+	if err != nil || len(archives) <= keep {
+		return nil, err
+	}
+	stale := archives[max(keep, 0):]
+	if dryRun {
+		return stale, nil
+	}
+	for i, archive := range stale {
+		if err := os.Remove(archive.Path); err != nil {
+			return stale[:i], err
+		}
+	}
+	return stale, nil
+}
+
+// LoadArchive reads the named archive from dir
+func LoadArchive(dir, name string) (*Snapshot, error) {
+	if name == "" || name != filepath.Base(name) {
+		return nil, fmt.Errorf("invalid snapshot name %q", name)
+	}
+	f, err := os.Open(filepath.Join(dir, name+archiveExt))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("no snapshot named %s (see 'plonk snapshot list')", name)
+		}
+		return nil, err
+	}
+	defer f.Close()
+	return ReadArchive(f)
+}
+
+// Diff lists the file changes that restoring to would make over from, as
+// "+ path", "~ path" and "- path" lines sorted by path
+func Diff(from, to *Snapshot) []string {
+	return diffFiles(from, to)
+}
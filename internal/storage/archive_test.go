@@ -0,0 +1,153 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package storage
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveRoundTrip(t *testing.T) {
+	src := t.TempDir()
+	writeFiles(t, src, map[string]string{
+		"plonk.yaml":       "default_manager: brew\n",
+		"plonk.lock":       "version: 3\n",
+		"config/nvim/init": "set nu\n",
+		".git/HEAD":        "ref: refs/heads/main\n",
+	})
+	require.NoError(t, os.Chmod(filepath.Join(src, "plonk.lock"), 0o600))
+
+	dir := t.TempDir()
+	now := time.Date(2025, 3, 1, 12, 30, 0, 0, time.Local)
+	info, err := CreateArchive(dir, src, "known-good", now)
+	require.NoError(t, err)
+	assert.Equal(t, "20250301-123000-known-good", info.Name)
+	assert.Positive(t, info.Size)
+
+	// Change the directory, then roll it back
+	writeFiles(t, src, map[string]string{"plonk.yaml": "default_manager: cargo\n", "extra": "x\n"})
+
+	s, err := LoadArchive(dir, info.Name)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"- extra", "~ plonk.yaml"}, Diff(mustCapture(t, src), s))
+	require.NoError(t, Restore(src, s))
+
+	data, err := os.ReadFile(filepath.Join(src, "plonk.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "default_manager: brew\n", string(data))
+	assert.NoFileExists(t, filepath.Join(src, "extra"))
+	assert.FileExists(t, filepath.Join(src, ".git", "HEAD"))
+
+	stat, err := os.Stat(filepath.Join(src, "plonk.lock"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), stat.Mode().Perm())
+}
+
+func TestCreateArchive_Errors(t *testing.T) {
+	src := t.TempDir()
+	writeFiles(t, src, map[string]string{"plonk.yaml": "{}\n"})
+	dir := t.TempDir()
+	now := time.Now()
+
+	_, err := CreateArchive(dir, src, "../escape", now)
+	assert.ErrorContains(t, err, "invalid snapshot label")
+
+	_, err = CreateArchive(dir, src, "", now)
+	require.NoError(t, err)
+	_, err = CreateArchive(dir, src, "", now)
+	assert.ErrorContains(t, err, "already exists")
+}
+
+func TestListArchives(t *testing.T) {
+	archives, err := ListArchives(filepath.Join(t.TempDir(), "missing"))
+	require.NoError(t, err)
+	assert.Empty(t, archives)
+
+	src := t.TempDir()
+	writeFiles(t, src, map[string]string{"plonk.yaml": "{}\n"})
+	dir := t.TempDir()
+	base := time.Date(2025, 3, 1, 12, 0, 0, 0, time.Local)
+	for i, label := range []string{"first", "second", "third"} {
+		_, err := CreateArchive(dir, src, label, base.Add(time.Duration(i)*time.Hour))
+		require.NoError(t, err)
+	}
+	writeFiles(t, dir, map[string]string{"notes.txt": "not a snapshot\n"})
+
+	archives, err = ListArchives(dir)
+	require.NoError(t, err)
+	require.Len(t, archives, 3)
+	assert.Equal(t, "20250301-140000-third", archives[0].Name)
+	assert.Equal(t, "20250301-120000-first", archives[2].Name)
+	assert.True(t, archives[0].CreatedAt.Equal(base.Add(2*time.Hour)))
+}
+
+func TestPruneArchives(t *testing.T) {
+	src := t.TempDir()
+	writeFiles(t, src, map[string]string{"plonk.yaml": "{}\n"})
+	dir := t.TempDir()
+	base := time.Date(2025, 3, 1, 12, 0, 0, 0, time.Local)
+	for i := range 4 {
+		_, err := CreateArchive(dir, src, "", base.Add(time.Duration(i)*time.Hour))
+		require.NoError(t, err)
+	}
+
+	stale, err := PruneArchives(dir, 2, true)
+	require.NoError(t, err)
+	require.Len(t, stale, 2)
+	assert.Equal(t, "20250301-130000", stale[0].Name)
+	assert.Equal(t, "20250301-120000", stale[1].Name)
+	archives, _ := ListArchives(dir)
+	assert.Len(t, archives, 4, "dry run must not remove archives")
+
+	_, err = PruneArchives(dir, 2, false)
+	require.NoError(t, err)
+	archives, _ = ListArchives(dir)
+	require.Len(t, archives, 2)
+	assert.Equal(t, "20250301-150000", archives[0].Name)
+
+	stale, err = PruneArchives(dir, 5, false)
+	require.NoError(t, err)
+	assert.Empty(t, stale)
+}
+
+func TestLoadArchive_Errors(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := LoadArchive(dir, "20250101-000000")
+	assert.ErrorContains(t, err, "no snapshot named 20250101-000000")
+
+	_, err = LoadArchive(dir, "../other")
+	assert.ErrorContains(t, err, "invalid snapshot name")
+}
+
+func TestReadArchive_RejectsUnsafePaths(t *testing.T) {
+	for _, name := range []string{"../outside", ".git/config"} {
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		tw := tar.NewWriter(gz)
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: 1, Typeflag: tar.TypeReg}))
+		_, err := tw.Write([]byte("x"))
+		require.NoError(t, err)
+		require.NoError(t, tw.Close())
+		require.NoError(t, gz.Close())
+
+		_, err = ReadArchive(&buf)
+		assert.Error(t, err, name)
+	}
+}
+
+func mustCapture(t *testing.T, dir string) *Snapshot {
+	t.Helper()
+	s, err := Capture(dir)
+	require.NoError(t, err)
+	return s
+}
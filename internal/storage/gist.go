@@ -0,0 +1,117 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+)
+
+// GistFileName is the file holding the snapshot inside the gist
+const GistFileName = "plonk-state.json"
+
+// GistBackend stores the snapshot as a file in a GitHub gist. The gist must
+// already exist; create a secret gist and put its ID in sync.gist_id. A token
+// with the gist scope is read from $PLONK_GIST_TOKEN or $GITHUB_TOKEN.
+type GistBackend struct {
+	id      string
+	apiBase string
+	client  *http.Client
+}
+
+// NewGistBackend creates a backend for the gist with id
+func NewGistBackend(id string, client *http.Client) (*GistBackend, error) {
+	if id == "" {
+		return nil, errors.New("sync.gist_id is required for the gist backend")
+	}
+	return &GistBackend{id: id, apiBase: "https://api.github.com", client: client}, nil
+}
+
+// Name identifies the gist
+func (b *GistBackend) Name() string {
+	return "gist:" + b.id
+}
+
+type gistFile struct {
+	Content   string `json:"content"`
+	Truncated bool   `json:"truncated,omitempty"`
+	RawURL    string `json:"raw_url,omitempty"`
+}
+
+type gist struct {
+	Files map[string]*gistFile `json:"files"`
+}
+
+// Get reads the snapshot file from the gist
+func (b *GistBackend) Get(ctx context.Context) ([]byte, error) {
+	req, err := b.newRequest(ctx, http.MethodGet, "/gists/"+b.id, nil)
+	if err != nil {
+		return nil, err
+	}
+	body, err := doRequest(b.client, req)
+	if err != nil {
+		if errors.Is(err, ErrNoSnapshot) {
+			return nil, fmt.Errorf("gist %s not found (is the token allowed to read it?)", b.id)
+		}
+		return nil, err
+	}
+
+	var g gist
+	if err := json.Unmarshal(body, &g); err != nil {
+		return nil, fmt.Errorf("invalid gist response: %w", err)
+	}
+	file, ok := g.Files[GistFileName]
+	if !ok || file == nil {
+		return nil, ErrNoSnapshot
+	}
+	if !file.Truncated {
+		return []byte(file.Content), nil
+	}
+
+	// The API truncates large files; fetch the full content
+	req, err = b.newRequest(ctx, http.MethodGet, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	if req.URL, err = req.URL.Parse(file.RawURL); err != nil {
+		return nil, fmt.Errorf("invalid gist raw URL: %w", err)
+	}
+	return doRequest(b.client, req)
+}
+
+// Put replaces the snapshot file in the gist
+func (b *GistBackend) Put(ctx context.Context, data []byte) error {
+	payload, err := json.Marshal(gist{Files: map[string]*gistFile{GistFileName: {Content: string(data)}}})
+	if err != nil {
+		return err
+	}
+	req, err := b.newRequest(ctx, http.MethodPatch, "/gists/"+b.id, payload)
+	if err != nil {
+		return err
+	}
+	_, err = doRequest(b.client, req)
+	return err
+}
+
+func (b *GistBackend) newRequest(ctx context.Context, method, path string, body []byte) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, b.apiBase+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	token := os.Getenv("PLONK_GIST_TOKEN")
+	if token == "" {
+		token = os.Getenv("GITHUB_TOKEN")
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return req, nil
+}
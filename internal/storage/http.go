@@ -0,0 +1,90 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// HTTPBackend stores the snapshot at a URL using GET and PUT, as supported by
+// WebDAV servers and most object stores' presigned or authenticated URLs.
+// $PLONK_SYNC_TOKEN, if set, is sent as a bearer token.
+type HTTPBackend struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTPBackend creates a backend for rawURL
+func NewHTTPBackend(rawURL string, client *http.Client) (*HTTPBackend, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("sync.url must be an http(s) URL, got %q", rawURL)
+	}
+	return &HTTPBackend{url: rawURL, client: client}, nil
+}
+
+// Name returns the URL without credentials
+func (b *HTTPBackend) Name() string {
+	u, err := url.Parse(b.url)
+	if err != nil {
+		return b.url
+	}
+	return u.Redacted()
+}
+
+// Get downloads the snapshot
+func (b *HTTPBackend) Get(ctx context.Context) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, b.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return doRequest(b.client, b.authorize(req))
+}
+
+// Put uploads the snapshot
+func (b *HTTPBackend) Put(ctx context.Context, data []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, b.url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	_, err = doRequest(b.client, b.authorize(req))
+	return err
+}
+
+func (b *HTTPBackend) authorize(req *http.Request) *http.Request {
+	if token := os.Getenv("PLONK_SYNC_TOKEN"); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return req
+}
+
+// doRequest performs req and returns the body of a 2xx response. A 404 is
+// reported as ErrNoSnapshot.
+func doRequest(client *http.Client, req *http.Request) ([]byte, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 4*MaxSnapshotSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotFound && req.Method == http.MethodGet:
+		return nil, ErrNoSnapshot
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		return nil, fmt.Errorf("%s %s: %s: %s", req.Method, req.URL.Redacted(), resp.Status, strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
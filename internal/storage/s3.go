@@ -0,0 +1,170 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// S3Backend stores the snapshot as an S3 object. Credentials come from
+// $AWS_ACCESS_KEY_ID, $AWS_SECRET_ACCESS_KEY and optionally $AWS_SESSION_TOKEN.
+// Requests are signed with AWS Signature Version 4, so S3-compatible stores
+// (MinIO, R2, ...) work via sync.endpoint.
+type S3Backend struct {
+	bucket   string
+	key      string
+	region   string
+	endpoint string // scheme://host; empty means AWS virtual-hosted style
+	client   *http.Client
+	now      func() time.Time
+}
+
+// NewS3Backend creates a backend for an s3://bucket/key URL
+func NewS3Backend(rawURL, region, endpoint string, client *http.Client) (*S3Backend, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != "s3" || u.Host == "" || strings.Trim(u.Path, "/") == "" {
+		return nil, fmt.Errorf("sync.url must look like s3://bucket/path/plonk-state.json, got %q", rawURL)
+	}
+	if region == "" {
+		region = os.Getenv("AWS_REGION")
+	}
+	if region == "" {
+		region = "us-east-1"
+	}
+	return &S3Backend{
+		bucket:   u.Host,
+		key:      strings.TrimPrefix(u.Path, "/"),
+		region:   region,
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		client:   client,
+		now:      time.Now,
+	}, nil
+}
+
+// Name returns the s3:// URL
+func (b *S3Backend) Name() string {
+	return fmt.Sprintf("s3://%s/%s", b.bucket, b.key)
+}
+
+// Get downloads the object
+func (b *S3Backend) Get(ctx context.Context) ([]byte, error) {
+	req, err := b.newRequest(ctx, http.MethodGet, nil)
+	if err != nil {
+		return nil, err
+	}
+	return doRequest(b.client, req)
+}
+
+// Put uploads the object
+func (b *S3Backend) Put(ctx context.Context, data []byte) error {
+	req, err := b.newRequest(ctx, http.MethodPut, data)
+	if err != nil {
+		return err
+	}
+	_, err = doRequest(b.client, req)
+	return err
+}
+
+// objectURL returns the object URL: path style for custom endpoints,
+// virtual-hosted style for AWS
+func (b *S3Backend) objectURL() string {
+	escapedKey := (&url.URL{Path: b.key}).EscapedPath()
+	if b.endpoint != "" {
+		return fmt.Sprintf("%s/%s/%s", b.endpoint, b.bucket, escapedKey)
+	}
+	return fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", b.bucket, b.region, escapedKey)
+}
+
+// newRequest builds a signed request for the object
+func (b *S3Backend) newRequest(ctx context.Context, method string, body []byte) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, b.objectURL(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if err := b.sign(req, body); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// sign adds AWS Signature Version 4 headers to req
+func (b *S3Backend) sign(req *http.Request, body []byte) error {
+	accessKey := os.Getenv("AWS_ACCESS_KEY_ID")
+	secretKey := os.Getenv("AWS_SECRET_ACCESS_KEY")
+	if accessKey == "" || secretKey == "" {
+		return errors.New("AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY must be set for the s3 backend")
+	}
+
+	now := b.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(body)
+
+	req.Header.Set("Host", req.URL.Host)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if token := os.Getenv("AWS_SESSION_TOKEN"); token != "" {
+		req.Header.Set("X-Amz-Security-Token", token)
+	}
+
+	// Canonical headers: lowercase names, sorted
+	names := make([]string, 0, len(req.Header))
+	for name := range req.Header {
+		names = append(names, strings.ToLower(name))
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&canonicalHeaders, "%s:%s\n", name, strings.TrimSpace(req.Header.Get(name)))
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := fmt.Sprintf("%s/%s/s3/aws4_request", date, b.region)
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, sha256Hex([]byte(canonicalRequest))}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+secretKey), date)
+	key = hmacSHA256(key, b.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		accessKey, scope, signedHeaders, signature))
+	return nil
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
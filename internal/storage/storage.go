@@ -0,0 +1,236 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+// Package storage syncs $PLONK_DIR through backends other than git. The whole
+// config directory is stored as a single JSON snapshot object, so every
+// backend only needs to get and put one blob.
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/richhaase/plonk/internal/config"
+)
+
+// ErrNoSnapshot is returned by Backend.Get when nothing has been pushed yet
+var ErrNoSnapshot = errors.New("no snapshot stored in backend")
+
+// MaxSnapshotSize bounds the config directory size; plonk syncs config and
+// dotfiles, not arbitrary data
+const MaxSnapshotSize = 10 << 20
+
+// SnapshotVersion is the current snapshot format
+const SnapshotVersion = 1
+
+// httpTimeout bounds a single backend request
+const httpTimeout = 60 * time.Second
+
+// Backend stores a single snapshot blob
+type Backend interface {
+	// Name describes the backend for messages, e.g. "s3://bucket/key"
+	Name() string
+	// Get returns the stored snapshot, or ErrNoSnapshot
+	Get(ctx context.Context) ([]byte, error)
+	// Put replaces the stored snapshot
+	Put(ctx context.Context, data []byte) error
+}
+
+// New returns the backend configured in plonk.yaml
+func New(cfg config.SyncConfig) (Backend, error) {
+	client := &http.Client{Timeout: httpTimeout}
+	switch cfg.Backend {
+	case "s3":
+		return NewS3Backend(cfg.URL, cfg.Region, cfg.Endpoint, client)
+	case "gist":
+		return NewGistBackend(cfg.GistID, client)
+	case "http":
+		return NewHTTPBackend(cfg.URL, client)
+	case "":
+		return nil, errors.New("no sync backend configured; set sync.backend in plonk.yaml (s3, gist or http)")
+	default:
+		return nil, fmt.Errorf("unknown sync backend %q (use s3, gist or http)", cfg.Backend)
+	}
+}
+
+// Snapshot is the content of the config directory at one point in time
+type Snapshot struct {
+	Version  int               `json:"version"`
+	PushedAt time.Time         `json:"pushed_at"`
+	Host     string            `json:"host,omitempty"`
+	Files    map[string][]byte `json:"files"`           // slash-separated path -> content
+	Modes    map[string]uint32 `json:"modes,omitempty"` // permissions other than 0644
+}
+
+// Digest identifies the snapshot's file contents, independent of metadata
+func (s *Snapshot) Digest() string {
+	paths := make([]string, 0, len(s.Files))
+	for p := range s.Files {
+		paths = append(paths, p)
+	}
+	slices.Sort(paths)
+
+	h := sha256.New()
+	for _, p := range paths {
+		fileSum := sha256.Sum256(s.Files[p])
+		fmt.Fprintf(h, "%s\x00%x\n", p, fileSum)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Encode serializes the snapshot
+func (s *Snapshot) Encode() ([]byte, error) {
+	return json.Marshal(s)
+}
+
+// Decode parses a serialized snapshot
+func Decode(data []byte) (*Snapshot, error) {
+	var s Snapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("invalid snapshot: %w", err)
+	}
+	if s.Version > SnapshotVersion {
+		return nil, fmt.Errorf("snapshot version %d is newer than this plonk supports (%d); upgrade plonk", s.Version, SnapshotVersion)
+	}
+	if s.Files == nil {
+		s.Files = make(map[string][]byte)
+	}
+	return &s, nil
+}
+
+// skipDir reports whether a directory is excluded from snapshots
+func skipDir(name string) bool {
+	return name == ".git"
+}
+
+// LocalDirs are the top-level directories of the config directory that
+// belong to the machine they are on: migrate's backups and fleet reports.
+// Snapshots and remote pushes leave them out.
+var LocalDirs = []string{".backups", ".fleet"}
+
+// isLocal reports whether rel, a slash-separated snapshot path, is in one
+// of LocalDirs
+func isLocal(rel string) bool {
+	first, _, _ := strings.Cut(rel, "/")
+	return slices.Contains(LocalDirs, first)
+}
+
+// Capture reads every regular file under configDir into a snapshot.
+// The .git directory, LocalDirs and symlinks are skipped.
+func Capture(configDir string) (*Snapshot, error) {
+	s := &Snapshot{Version: SnapshotVersion, Files: make(map[string][]byte)}
+	total := 0
+	err := filepath.WalkDir(configDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == configDir {
+				return fs.SkipAll
+			}
+			return err
+		}
+		if d.IsDir() {
+			if path != configDir && (skipDir(d.Name()) || filepath.Dir(path) == filepath.Clean(configDir) && slices.Contains(LocalDirs, d.Name())) {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		total += len(data)
+		if total > MaxSnapshotSize {
+			return fmt.Errorf("config directory exceeds %d MB sync limit", MaxSnapshotSize>>20)
+		}
+		rel, err := filepath.Rel(configDir, path)
+		if err != nil {
+			return err
+		}
+		s.Files[filepath.ToSlash(rel)] = data
+		if perm := info.Mode().Perm(); perm != 0o644 {
+			if s.Modes == nil {
+				s.Modes = make(map[string]uint32)
+			}
+			s.Modes[filepath.ToSlash(rel)] = uint32(perm)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", configDir, err)
+	}
+	return s, nil
+}
+
+// Restore makes configDir match the snapshot: files are written, and files
+// not in the snapshot are removed. The .git directory and LocalDirs are left
+// alone, even when a snapshot from an older plonk holds files in them.
+func Restore(configDir string, s *Snapshot) error {
+	for rel, data := range s.Files {
+		if isLocal(rel) {
+			continue
+		}
+		path, err := snapshotPath(configDir, rel)
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			return err
+		}
+		mode := os.FileMode(0o644)
+		if m, ok := s.Modes[rel]; ok {
+			mode = os.FileMode(m).Perm()
+		}
+		if err := os.WriteFile(path, data, mode); err != nil {
+			return fmt.Errorf("failed to write %s: %w", rel, err)
+		}
+		// WriteFile keeps the mode of an existing file
+		if err := os.Chmod(path, mode); err != nil {
+			return err
+		}
+	}
+
+	local, err := Capture(configDir)
+	if err != nil {
+		return err
+	}
+	for rel := range local.Files {
+		if _, ok := s.Files[rel]; ok {
+			continue
+		}
+		if err := os.Remove(filepath.Join(configDir, filepath.FromSlash(rel))); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove %s: %w", rel, err)
+		}
+	}
+	return nil
+}
+
+// snapshotPath resolves a snapshot path under configDir, rejecting paths
+// that would escape it
+func snapshotPath(configDir, rel string) (string, error) {
+	clean := filepath.Clean(filepath.FromSlash(rel))
+	if !filepath.IsLocal(clean) {
+		return "", fmt.Errorf("snapshot contains unsafe path: %s", rel)
+	}
+	if first, _, _ := strings.Cut(clean, string(filepath.Separator)); skipDir(first) {
+		return "", fmt.Errorf("snapshot contains reserved path: %s", rel)
+	}
+	return filepath.Join(configDir, clean), nil
+}
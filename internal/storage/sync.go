@@ -0,0 +1,222 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Direction forces which side wins a sync
+type Direction int
+
+const (
+	// Auto pushes local changes or pulls remote changes, and refuses when
+	// both sides changed since the last sync
+	Auto Direction = iota
+	// Push overwrites the backend with the local directory
+	Push
+	// Pull overwrites the local directory with the backend
+	Pull
+)
+
+// Sync actions reported in Result.Action
+const (
+	ActionPushed   = "pushed"
+	ActionPulled   = "pulled"
+	ActionUpToDate = "up-to-date"
+)
+
+// ErrConflict is returned by Sync in Auto mode when both sides changed
+var ErrConflict = errors.New("both the local directory and the backend changed since the last sync")
+
+// Options controls Sync
+type Options struct {
+	Direction  Direction
+	LastDigest string // digest recorded after the previous sync, if any
+	Host       string // recorded in pushed snapshots
+	DryRun     bool
+	// CheckPush, when set, is called with the files a push adds or changes
+	// before anything is written, dry runs included; an error stops the push
+	CheckPush func(files map[string][]byte) error
+}
+
+// Result describes what Sync did (or would do)
+type Result struct {
+	Action  string   `json:"action"`
+	Digest  string   `json:"digest"`            // digest of both sides after the sync
+	Changes []string `json:"changes,omitempty"` // "+ path", "~ path", "- path" on the receiving side
+	DryRun  bool     `json:"dry_run"`
+}
+
+// Sync reconciles configDir with the backend
+func Sync(ctx context.Context, b Backend, configDir string, opts Options) (Result, error) {
+	local, err := Capture(configDir)
+	if err != nil {
+		return Result{}, err
+	}
+	localDigest := local.Digest()
+
+	var remote *Snapshot
+	data, err := b.Get(ctx)
+	switch {
+	case errors.Is(err, ErrNoSnapshot):
+	case err != nil:
+		return Result{}, fmt.Errorf("failed to read %s: %w", b.Name(), err)
+	default:
+		if remote, err = Decode(data); err != nil {
+			return Result{}, err
+		}
+	}
+
+	action, err := chooseAction(opts, localDigest, remote)
+	if err != nil {
+		return Result{}, err
+	}
+
+	result := Result{Action: action, DryRun: opts.DryRun, Digest: localDigest}
+	switch action {
+	case ActionPushed:
+		result.Changes = diffFiles(remote, local)
+		if opts.CheckPush != nil {
+			if err := opts.CheckPush(changedFiles(remote, local)); err != nil {
+				return Result{}, err
+			}
+		}
+		if opts.DryRun {
+			return result, nil
+		}
+		local.PushedAt = time.Now().UTC()
+		local.Host = opts.Host
+		encoded, err := local.Encode()
+		if err != nil {
+			return Result{}, err
+		}
+		if err := b.Put(ctx, encoded); err != nil {
+			return Result{}, fmt.Errorf("failed to write %s: %w", b.Name(), err)
+		}
+	case ActionPulled:
+		result.Digest = remote.Digest()
+		result.Changes = diffFiles(local, remote)
+		if opts.DryRun {
+			return result, nil
+		}
+		if err := Restore(configDir, remote); err != nil {
+			return Result{}, err
+		}
+	}
+	return result, nil
+}
+
+// chooseAction decides the sync direction from the three digests
+func chooseAction(opts Options, localDigest string, remote *Snapshot) (string, error) {
+	if remote == nil {
+		if opts.Direction == Pull {
+			return "", ErrNoSnapshot
+		}
+		return ActionPushed, nil
+	}
+
+	remoteDigest := remote.Digest()
+	switch {
+	case localDigest == remoteDigest:
+		return ActionUpToDate, nil
+	case opts.Direction == Push:
+		return ActionPushed, nil
+	case opts.Direction == Pull:
+		return ActionPulled, nil
+	case localDigest == opts.LastDigest:
+		return ActionPulled, nil
+	case remoteDigest == opts.LastDigest:
+		return ActionPushed, nil
+	default:
+		return "", ErrConflict
+	}
+}
+
+// diffFiles lists the changes that turn from into to. A nil from means empty.
+func diffFiles(from, to *Snapshot) []string {
+	var changes []string
+	for path, data := range to.Files {
+		switch old, ok := lookup(from, path); {
+		case !ok:
+			changes = append(changes, "+ "+path)
+		case string(old) != string(data):
+			changes = append(changes, "~ "+path)
+		}
+	}
+	if from != nil {
+		for path := range from.Files {
+			if _, ok := to.Files[path]; !ok {
+				changes = append(changes, "- "+path)
+			}
+		}
+	}
+	// Sort by path, ignoring the change marker
+	slices.SortFunc(changes, func(a, b string) int { return strings.Compare(a[2:], b[2:]) })
+	return changes
+}
+
+// changedFiles returns the files in to that are new or different from from
+func changedFiles(from, to *Snapshot) map[string][]byte {
+	files := make(map[string][]byte)
+	for path, data := range to.Files {
+		if old, ok := lookup(from, path); !ok || string(old) != string(data) {
+			files[path] = data
+		}
+	}
+	return files
+}
+
+func lookup(s *Snapshot, path string) ([]byte, bool) {
+	if s == nil {
+		return nil, false
+	}
+	data, ok := s.Files[path]
+	return data, ok
+}
+
+// syncState is persisted per machine between syncs
+type syncState struct {
+	Backend  string    `json:"backend"`
+	Digest   string    `json:"digest"`
+	SyncedAt time.Time `json:"synced_at"`
+}
+
+// LoadLastDigest returns the digest recorded by SaveLastDigest for backend,
+// or "" if there is none
+func LoadLastDigest(statePath, backend string) string {
+	data, err := os.ReadFile(statePath)
+	if err != nil {
+		return ""
+	}
+	var st syncState
+	if json.Unmarshal(data, &st) != nil || st.Backend != backend {
+		return ""
+	}
+	return st.Digest
+}
+
+// SaveLastDigest records the digest both sides agree on after a sync
+func SaveLastDigest(statePath, backend, digest string) error {
+	if err := os.MkdirAll(filepath.Dir(statePath), 0o750); err != nil {
+		return err
+	}
+	data, err := json.Marshal(syncState{Backend: backend, Digest: digest, SyncedAt: time.Now().UTC()})
+	if err != nil {
+		return err
+	}
+	tmp := statePath + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, statePath)
+}
@@ -0,0 +1,324 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+// Package plonk is the supported Go API for embedding plonk. It drives the
+// same lock file, config directory and package managers as the CLI, and
+// returns typed results instead of rendered tables.
+//
+//	client, err := plonk.New()
+//	if err != nil { ... }
+//	status, err := client.Status(ctx)
+package plonk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/richhaase/plonk/internal/config"
+	"github.com/richhaase/plonk/internal/dotfiles"
+	"github.com/richhaase/plonk/internal/gitops"
+	"github.com/richhaase/plonk/internal/lock"
+	"github.com/richhaase/plonk/internal/orchestrator"
+	"github.com/richhaase/plonk/internal/output"
+	"github.com/richhaase/plonk/internal/packages"
+)
+
+// Result types shared with the CLI's JSON output
+type (
+	ApplyResult      = output.ApplyResult
+	PackageResults   = output.PackageResults
+	ManagerResults   = output.ManagerResults
+	PackageOperation = output.PackageOperation
+	DotfileResults   = output.DotfileResults
+	DotfileOperation = output.DotfileOperation
+)
+
+// Error classes, usable with errors.Is on errors returned by the client
+var (
+	ErrNotFound           = packages.ErrNotFound
+	ErrPermission         = packages.ErrPermission
+	ErrNetwork            = packages.ErrNetwork
+	ErrManagerUnavailable = packages.ErrManagerUnavailable
+	ErrTimeout            = packages.ErrTimeout
+)
+
+// ErrorClass returns the stable class name of err ("not_found", "network", ...)
+func ErrorClass(err error) string {
+	return packages.ErrorClass(err)
+}
+
+// Client runs plonk operations against one config directory and home directory
+type Client struct {
+	configDir string
+	homeDir   string
+	cfg       *config.Config
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithConfigDir overrides the config directory (default: $PLONK_DIR or ~/.config/plonk)
+func WithConfigDir(dir string) Option {
+	return func(c *Client) {
+		c.configDir = dir
+	}
+}
+
+// WithHomeDir overrides the directory dotfiles are deployed into (default: $HOME)
+func WithHomeDir(dir string) Option {
+	return func(c *Client) {
+		c.homeDir = dir
+	}
+}
+
+// New creates a Client, loading plonk.yaml from the config directory.
+// An invalid config is an error; a missing one means defaults.
+func New(opts ...Option) (*Client, error) {
+	c := &Client{}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.configDir == "" {
+		c.configDir = config.GetDefaultConfigDirectory()
+	}
+	if c.homeDir == "" {
+		home, err := config.GetHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("cannot determine home directory: %w", err)
+		}
+		c.homeDir = home
+	}
+
+	cfg, err := config.Load(c.configDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	c.cfg = cfg
+
+	return c, nil
+}
+
+// configure applies the client's manager settings, aliases and disabled
+// managers. They are process-wide, so each method that uses package
+// managers sets them again in case another client changed them.
+func (c *Client) configure() {
+	packages.Configure(c.cfg)
+}
+
+// ConfigDir returns the config directory the client operates on
+func (c *Client) ConfigDir() string {
+	return c.configDir
+}
+
+// ApplyOptions controls Apply
+type ApplyOptions struct {
+	DryRun       bool
+	PackagesOnly bool
+	DotfilesOnly bool
+}
+
+// Apply installs missing packages and deploys dotfiles, like `plonk apply`.
+// The result is populated even when an error is returned.
+func (c *Client) Apply(ctx context.Context, opts ApplyOptions) (ApplyResult, error) {
+	if opts.PackagesOnly && opts.DotfilesOnly {
+		return ApplyResult{}, errors.New("PackagesOnly and DotfilesOnly are mutually exclusive")
+	}
+
+	orch := orchestrator.New(
+		orchestrator.WithConfig(c.cfg),
+		orchestrator.WithConfigDir(c.configDir),
+		orchestrator.WithHomeDir(c.homeDir),
+		orchestrator.WithDryRun(opts.DryRun),
+		orchestrator.WithPackagesOnly(opts.PackagesOnly),
+		orchestrator.WithDotfilesOnly(opts.DotfilesOnly),
+	)
+	result, err := orch.Apply(ctx)
+	switch {
+	case opts.PackagesOnly:
+		result.Scope = "packages"
+	case opts.DotfilesOnly:
+		result.Scope = "dotfiles"
+	default:
+		result.Scope = "all"
+	}
+	return result, err
+}
+
+// PackageStatus is the reconciled state of a tracked package
+type PackageStatus struct {
+	Manager    string `json:"manager"`
+	Name       string `json:"name"`
+	State      string `json:"state"` // "managed", "missing", "error"
+	Error      string `json:"error,omitempty"`
+	ErrorClass string `json:"error_class,omitempty"`
+}
+
+// DotfileStatus is the reconciled state of a managed dotfile
+type DotfileStatus struct {
+	Name   string `json:"name"`
+	Source string `json:"source"`
+	Target string `json:"target"`
+	State  string `json:"state"` // "managed", "missing", "drifted", "error"
+	Error  string `json:"error,omitempty"`
+}
+
+// Reconciliation compares desired state with the system
+type Reconciliation struct {
+	Packages []PackageStatus `json:"packages"`
+	Dotfiles []DotfileStatus `json:"dotfiles"`
+}
+
+// Reconcile reports the state of every tracked package and managed dotfile
+// without changing anything
+func (c *Client) Reconcile(ctx context.Context) (*Reconciliation, error) {
+	c.configure()
+	pkgStatuses, err := packages.Reconcile(ctx, c.configDir)
+	if err != nil {
+		return nil, err
+	}
+
+	dm := dotfiles.NewDotfileManager(c.configDir, c.homeDir, c.cfg.IgnorePatterns)
+	dotStatuses, err := dm.Reconcile()
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconcile dotfiles: %w", err)
+	}
+
+	r := &Reconciliation{
+		Packages: make([]PackageStatus, 0, len(pkgStatuses)),
+		Dotfiles: make([]DotfileStatus, 0, len(dotStatuses)),
+	}
+	for _, s := range pkgStatuses {
+		ps := PackageStatus{Manager: s.Manager, Name: s.Name, State: string(s.State)}
+		if s.Err != nil {
+			ps.Error = s.Err.Error()
+			ps.ErrorClass = packages.ErrorClass(s.Err)
+		}
+		r.Packages = append(r.Packages, ps)
+	}
+	for _, s := range dotStatuses {
+		ds := DotfileStatus{Name: s.Name, Source: s.Source, Target: s.Target, State: string(s.State)}
+		if s.Error != nil {
+			ds.Error = s.Error.Error()
+		}
+		r.Dotfiles = append(r.Dotfiles, ds)
+	}
+	return r, nil
+}
+
+// Status is the overall state reported by `plonk status`
+type Status struct {
+	ConfigPath   string `json:"config_path"`
+	LockPath     string `json:"lock_path"`
+	ConfigExists bool   `json:"config_exists"`
+	LockExists   bool   `json:"lock_exists"`
+	Reconciliation
+}
+
+// Status reports config/lock file presence along with the reconciled state
+func (c *Client) Status(ctx context.Context) (*Status, error) {
+	r, err := c.Reconcile(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Status{
+		ConfigPath:     filepath.Join(c.configDir, "plonk.yaml"),
+		LockPath:       lock.NewLockV3Service(c.configDir).GetLockPath(),
+		Reconciliation: *r,
+	}
+	if _, err := os.Stat(s.ConfigPath); err == nil {
+		s.ConfigExists = true
+	}
+	if _, err := os.Stat(s.LockPath); err == nil {
+		s.LockExists = true
+	}
+	return s, nil
+}
+
+// InstallResult describes the outcome for one package spec
+type InstallResult struct {
+	Spec       string `json:"spec"`
+	Status     string `json:"status"` // "installed", "already-installed", "would-install", "failed"
+	Error      string `json:"error,omitempty"`
+	ErrorClass string `json:"error_class,omitempty"`
+}
+
+// InstallOptions controls Install
+type InstallOptions struct {
+	DryRun bool
+}
+
+// Install installs each "manager:package" spec if needed and tracks it in
+// the lock file. Failures are reported per spec; the returned error
+// summarizes them.
+func (c *Client) Install(ctx context.Context, specs []string, opts InstallOptions) ([]InstallResult, error) {
+	c.configure()
+	lockSvc := lock.NewLockV3Service(c.configDir)
+	lockFile, err := lockSvc.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lock file: %w", err)
+	}
+
+	results := make([]InstallResult, 0, len(specs))
+	var errs []error
+	var tracked []string
+	for _, spec := range specs {
+		status, err := c.installOne(ctx, lockFile, spec, opts.DryRun)
+		res := InstallResult{Spec: spec, Status: status}
+		if err != nil {
+			res.Status = "failed"
+			res.Error = err.Error()
+			res.ErrorClass = packages.ErrorClass(err)
+			errs = append(errs, fmt.Errorf("%s: %w", spec, err))
+		} else if !opts.DryRun {
+			tracked = append(tracked, spec)
+		}
+		results = append(results, res)
+	}
+
+	if len(tracked) > 0 {
+		if err := lockSvc.Write(lockFile); err != nil {
+			return results, fmt.Errorf("failed to write lock file: %w", err)
+		}
+		gitops.AutoCommit(ctx, c.configDir, "install", tracked)
+	}
+
+	return results, errors.Join(errs...)
+}
+
+// installOne installs a single spec and records it in lockFile
+func (c *Client) installOne(ctx context.Context, lockFile *lock.LockV3, spec string, dryRun bool) (string, error) {
+	manager, pkg, err := packages.ParsePackageSpec(spec)
+	if err != nil {
+		return "", err
+	}
+	mgr, err := packages.GetManager(manager)
+	if err != nil {
+		return "", err
+	}
+
+	installed, err := mgr.IsInstalled(ctx, pkg)
+	if err != nil {
+		return "", err
+	}
+
+	status := "already-installed"
+	switch {
+	case !installed && dryRun:
+		return "would-install", nil
+	case !installed:
+		if err := mgr.Install(ctx, pkg); err != nil {
+			return "", err
+		}
+		status = "installed"
+	}
+
+	if !dryRun {
+		lockFile.AddPackage(manager, pkg)
+	}
+	return status, nil
+}
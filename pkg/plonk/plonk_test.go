@@ -0,0 +1,145 @@
+// Copyright (c) 2025 Rich Haase
+// Licensed under the MIT License. See LICENSE file in the project root for license information.
+
+package plonk
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/richhaase/plonk/internal/packages"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(t *testing.T) (*Client, string, string) {
+	t.Helper()
+	configDir := t.TempDir()
+	homeDir := t.TempDir()
+	// Keep auto-commit quiet: the temp config dir is not a git repo
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "plonk.yaml"), []byte("git:\n  auto_commit: false\n"), 0o644))
+
+	client, err := New(WithConfigDir(configDir), WithHomeDir(homeDir))
+	require.NoError(t, err)
+	return client, configDir, homeDir
+}
+
+func TestNew_InvalidConfig(t *testing.T) {
+	configDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "plonk.yaml"), []byte("default_manager: nosuch\n"), 0o644))
+
+	_, err := New(WithConfigDir(configDir), WithHomeDir(t.TempDir()))
+	assert.Error(t, err)
+}
+
+func TestStatus_Empty(t *testing.T) {
+	client, configDir, _ := newTestClient(t)
+
+	status, err := client.Status(context.Background())
+	require.NoError(t, err)
+	assert.True(t, status.ConfigExists)
+	assert.False(t, status.LockExists)
+	assert.Equal(t, filepath.Join(configDir, "plonk.lock"), status.LockPath)
+	assert.Empty(t, status.Packages)
+	assert.Empty(t, status.Dotfiles)
+}
+
+func TestReconcile_DotfilesAndPackageErrors(t *testing.T) {
+	client, configDir, homeDir := newTestClient(t)
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "zshrc"), []byte("export A=1\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "plonk.lock"), []byte("version: 3\npackages:\n  npm:\n    - typescript\n"), 0o644))
+
+	r, err := client.Reconcile(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, r.Dotfiles, 1)
+	assert.Equal(t, filepath.Join(homeDir, ".zshrc"), r.Dotfiles[0].Target)
+	assert.Equal(t, "missing", r.Dotfiles[0].State)
+
+	require.Len(t, r.Packages, 1)
+	assert.Equal(t, "error", r.Packages[0].State)
+	assert.Equal(t, "manager_unavailable", r.Packages[0].ErrorClass)
+}
+
+func TestReconcile_AppliesPackageConfig(t *testing.T) {
+	t.Cleanup(func() { packages.SetDisabledManagers(nil) })
+	configDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "plonk.yaml"), []byte("git:\n  auto_commit: false\ndisabled_managers: [npm]\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "plonk.lock"), []byte("version: 3\npackages:\n  npm:\n    - typescript\n"), 0o644))
+	client, err := New(WithConfigDir(configDir), WithHomeDir(t.TempDir()))
+	require.NoError(t, err)
+
+	r, err := client.Reconcile(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, r.Packages, "packages of a disabled manager are left out")
+}
+
+func TestApply_DotfilesDryRun(t *testing.T) {
+	client, configDir, homeDir := newTestClient(t)
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "vimrc"), []byte("set nu\n"), 0o644))
+
+	result, err := client.Apply(context.Background(), ApplyOptions{DryRun: true, DotfilesOnly: true})
+	require.NoError(t, err)
+	assert.Equal(t, "dotfiles", result.Scope)
+	require.NotNil(t, result.Dotfiles)
+	assert.Equal(t, 1, result.Dotfiles.Summary.Added)
+	assert.NoFileExists(t, filepath.Join(homeDir, ".vimrc"))
+}
+
+func TestApply_ConflictingScopes(t *testing.T) {
+	client, _, _ := newTestClient(t)
+	_, err := client.Apply(context.Background(), ApplyOptions{PackagesOnly: true, DotfilesOnly: true})
+	assert.Error(t, err)
+}
+
+func TestInstall_UnsupportedManager(t *testing.T) {
+	client, configDir, _ := newTestClient(t)
+
+	results, err := client.Install(context.Background(), []string{"apt:vim"}, InstallOptions{})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrManagerUnavailable))
+	require.Len(t, results, 1)
+	assert.Equal(t, "failed", results[0].Status)
+	assert.Equal(t, "manager_unavailable", results[0].ErrorClass)
+	assert.NoFileExists(t, filepath.Join(configDir, "plonk.lock"))
+}
+
+func TestInstall_CommitsOnlyTrackedSpecs(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	// A manager plugin that reports alpha as installed
+	binDir := t.TempDir()
+	plugin := "#!/bin/sh\ncat >/dev/null\necho '{\"packages\": [\"alpha\"]}'\n"
+	require.NoError(t, os.WriteFile(filepath.Join(binDir, packages.PluginPrefix+"fake"), []byte(plugin), 0o755))
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	packages.ResetManagerCache()
+	t.Cleanup(packages.ResetManagerCache)
+
+	configDir := t.TempDir()
+	git := func(args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = configDir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+		return strings.TrimSpace(string(out))
+	}
+	git("init", "-b", "main")
+	git("config", "user.email", "test@test.com")
+	git("config", "user.name", "Test")
+	git("commit", "--allow-empty", "-m", "initial")
+	client, err := New(WithConfigDir(configDir), WithHomeDir(t.TempDir()))
+	require.NoError(t, err)
+
+	results, err := client.Install(context.Background(), []string{"fake:alpha", "apt:vim"}, InstallOptions{})
+	require.Error(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "already-installed", results[0].Status)
+	assert.Equal(t, "failed", results[1].Status)
+	assert.Equal(t, "plonk: install fake:alpha", git("log", "-1", "--format=%s"))
+}